@@ -15,10 +15,15 @@
 package config
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"sort"
 	"strings"
 
 	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config/source"
 	"github.com/corestoreio/pkg/store/scope"
 )
 
@@ -42,9 +47,9 @@ const (
 // FieldType used in constants to define the frontend and input type
 type FieldType uint8
 
-const fieldTypeName = "TypeButtonTypeCustomTypeLabelTypeHiddenTypeImageTypeObscureTypeMultiselectTypeSelectTypeTextTypeTextareaTypeTime"
+const fieldTypeName = "TypeButtonTypeCustomTypeLabelTypeHiddenTypeImageTypeObscureTypeMultiselectTypeSelectTypeTextTypeTextareaTypeTimeTypeDuration"
 
-var fieldTypeIndex = [...]uint8{10, 20, 29, 39, 48, 59, 74, 84, 92, 104, 112}
+var fieldTypeIndex = [...]uint8{10, 20, 29, 39, 48, 59, 74, 84, 92, 104, 112, 124}
 
 func (i FieldType) String() string {
 	i--
@@ -59,11 +64,62 @@ func (i FieldType) String() string {
 	return fieldTypeName[lo:hi]
 }
 
-// MarshalJSON implements marshaling into a human readable string. @todo UnMarshal
+// MakeFieldType parses the human readable name of a field type, for example
+// "select" or "textarea", case-insensitive and with or without the "Type"
+// prefix. An empty name returns zero, which means not set.
+func MakeFieldType(name string) (FieldType, error) {
+	if name == "" {
+		return 0, nil
+	}
+	for i := TypeButton; i < TypeZMaximum; i++ {
+		if n := i.String(); strings.EqualFold(n, name) || strings.EqualFold(n[4:], name) {
+			return i, nil
+		}
+	}
+	return 0, errors.NotSupported.Newf("[config] FieldType %q not supported", name)
+}
+
+// MarshalJSON implements marshaling into a human readable string.
 func (i FieldType) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + strings.ToLower(i.String()[4:]) + `"`), nil
 }
 
+// UnmarshalJSON implements json.Unmarshaler and parses the human readable name
+// written by MarshalJSON.
+func (i *FieldType) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*i = 0
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return errors.BadEncoding.New(err, "[config] FieldType.UnmarshalJSON with data %q", data)
+	}
+	return i.UnmarshalText([]byte(name))
+}
+
+// MarshalText implements encoding.TextMarshaler, used for example by YAML
+// encoders. A zero FieldType returns an empty text.
+func (i FieldType) MarshalText() ([]byte, error) {
+	if i == 0 {
+		return nil, nil
+	}
+	if i >= TypeZMaximum {
+		return nil, errors.NotSupported.Newf("[config] FieldType %d not supported", i)
+	}
+	return []byte(strings.ToLower(i.String()[4:])), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *FieldType) UnmarshalText(text []byte) error {
+	ft, err := MakeFieldType(string(text))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	*i = ft
+	return nil
+}
+
 // Sections contains a set of Sections. Some nifty helper functions exists.
 // Thread safe for reading. A section slice can be used in many goroutines. It
 // must remain lock-free.
@@ -74,15 +130,17 @@ type Sections []*Section
 type Section struct {
 	// ID unique ID and merged with others. 1st part of the path.
 	ID    string
-	Label string `json:",omitempty"`
+	Label string `json:",omitempty" yaml:",omitempty"`
 	// Scopes: bit value eg: showInDefault="1" showInWebsite="1" showInStore="1"
 	// Scopes can contain multiple Scope but no more than Default, Website and
 	// Store.
-	Scopes    scope.Perm `json:",omitempty"`
-	SortOrder int        `json:",omitempty"`
+	Scopes    scope.Perm `json:",omitempty" yaml:",omitempty"`
+	SortOrder int        `json:",omitempty" yaml:",omitempty"`
 	// Resource TODO some kind of ACL if someone has the right to view,access and/or modify.
-	Resource uint   `json:",omitempty"`
-	Groups   Groups `json:",omitempty"`
+	Resource uint   `json:",omitempty" yaml:",omitempty"`
+	Groups   Groups `json:",omitempty" yaml:",omitempty"`
+	// Remove deletes the section with the same ID. Only used by MergeStrict.
+	Remove bool `json:",omitempty" yaml:",omitempty"`
 }
 
 // MakeSections wrapper function, for now.
@@ -122,6 +180,21 @@ func MakeSectionsMerged(sections ...*Section) (Sections, error) {
 	return ss, nil
 }
 
+// MakeSectionsFromJSON decodes the JSON encoded sections, groups and fields,
+// including defaults and scope permissions, from r. Unknown keys are treated as
+// an error. Duplicated sections in r get merged and the result gets validated.
+// Use Sections.MergeMultiple to merge the returned sections with those defined
+// in Go code.
+func MakeSectionsFromJSON(r io.Reader) (Sections, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	var ss Sections
+	if err := dec.Decode(&ss); err != nil {
+		return nil, errors.BadEncoding.New(err, "[config] MakeSectionsFromJSON.Decode")
+	}
+	return MakeSectionsMerged(ss...)
+}
+
 // MustMakeSectionsMerged same as MakeSectionsMerged but panics on error.
 func MustMakeSectionsMerged(sections ...*Section) Sections {
 	s, err := MakeSectionsMerged(sections...)
@@ -192,6 +265,155 @@ func (ss Sections) merge(s *Section) Sections {
 	return ss
 }
 
+// MergeStrict merges other into a copy of ss and neither modifies ss nor
+// other. Non-empty attributes of sections, groups and fields in other override
+// the existing ones and elements with the Remove flag get deleted. Visible and
+// CanBeEmpty of an existing field can only be switched on, because false
+// cannot be distinguished from not set in a partial override. The returned
+// Sections are sorted by SortOrder; elements with the same SortOrder keep their
+// position, which is the order of ss followed by new elements from other.
+// MergeStrict reports all conflicts at once with an error of kind
+// errors.Mismatch: removing an element which does not exist, changing the Type
+// of a field or changing its ConfigRoute.
+func (ss Sections) MergeStrict(other Sections) (Sections, error) {
+	ret := make(Sections, 0, len(ss)+len(other))
+	for _, s := range ss {
+		ret = append(ret, s.clone())
+	}
+	var conflicts []string
+	for _, s := range other {
+		ret, conflicts = ret.mergeStrict(s, conflicts)
+	}
+	if len(conflicts) > 0 {
+		return nil, errors.Mismatch.Newf("[config] MergeStrict found %d conflict(s): %s", len(conflicts), strings.Join(conflicts, "; "))
+	}
+	if err := ret.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	sort.Stable(ret)
+	for _, s := range ret {
+		sort.Stable(s.Groups)
+		for _, g := range s.Groups {
+			sort.Stable(g.Fields)
+		}
+	}
+	return ret, nil
+}
+
+func (ss Sections) mergeStrict(s *Section, conflicts []string) (Sections, []string) {
+	cs, idx := ss.Find(s.ID)
+	switch {
+	case s.Remove && idx < 0:
+		return ss, append(conflicts, fmt.Sprintf("cannot remove non-existent section %q", s.ID))
+	case s.Remove:
+		return append(ss[:idx], ss[idx+1:]...), conflicts
+	case idx < 0:
+		cs = &Section{ID: s.ID}
+		ss = append(ss, cs)
+	}
+
+	if s.Label != "" {
+		cs.Label = s.Label
+	}
+	if s.Scopes > 0 {
+		cs.Scopes = s.Scopes
+	}
+	if s.SortOrder != 0 {
+		cs.SortOrder = s.SortOrder
+	}
+	if s.Resource > 0 {
+		cs.Resource = s.Resource
+	}
+	for _, g := range s.Groups {
+		cs.Groups, conflicts = cs.Groups.mergeStrict(s.ID, g, conflicts)
+	}
+	return ss, conflicts
+}
+
+func (gs Groups) mergeStrict(sectionID string, g *Group, conflicts []string) (Groups, []string) {
+	cg, idx := gs.Find(g.ID)
+	switch {
+	case g.Remove && idx < 0:
+		return gs, append(conflicts, fmt.Sprintf("cannot remove non-existent group \"%s/%s\"", sectionID, g.ID))
+	case g.Remove:
+		return append(gs[:idx], gs[idx+1:]...), conflicts
+	case idx < 0:
+		cg = &Group{ID: g.ID}
+		gs = append(gs, cg)
+	}
+
+	if g.Label != "" {
+		cg.Label = g.Label
+	}
+	if g.Comment != "" {
+		cg.Comment = g.Comment
+	}
+	if g.Scopes > 0 {
+		cg.Scopes = g.Scopes
+	}
+	if g.SortOrder != 0 {
+		cg.SortOrder = g.SortOrder
+	}
+	if g.HelpURL != "" {
+		cg.HelpURL = g.HelpURL
+	}
+	if g.MoreURL != "" {
+		cg.MoreURL = g.MoreURL
+	}
+	if g.DemoLink != "" {
+		cg.DemoLink = g.DemoLink
+	}
+	for _, f := range g.Fields {
+		cg.Fields, conflicts = cg.Fields.mergeStrict(sectionID+"/"+g.ID, f, conflicts)
+	}
+	return gs, conflicts
+}
+
+func (fs Fields) mergeStrict(groupRoute string, f *Field, conflicts []string) (Fields, []string) {
+	cf, idx := fs.Find(f.ID)
+	switch {
+	case f.Remove && idx < 0:
+		return fs, append(conflicts, fmt.Sprintf("cannot remove non-existent field \"%s/%s\"", groupRoute, f.ID))
+	case f.Remove:
+		return append(fs[:idx], fs[idx+1:]...), conflicts
+	case idx < 0:
+		cf = &Field{ID: f.ID}
+		fs = append(fs, cf)
+	}
+
+	if cf.Type > 0 && f.Type > 0 && cf.Type != f.Type {
+		return fs, append(conflicts, fmt.Sprintf("field \"%s/%s\" cannot change its type from %s to %s", groupRoute, f.ID, cf.Type, f.Type))
+	}
+	if cf.ConfigRoute != "" && f.ConfigRoute != "" && cf.ConfigRoute != f.ConfigRoute {
+		return fs, append(conflicts, fmt.Sprintf("field \"%s/%s\" cannot change its ConfigRoute from %q to %q", groupRoute, f.ID, cf.ConfigRoute, f.ConfigRoute))
+	}
+	if f.ConfigRoute != "" {
+		cf.ConfigRoute = f.ConfigRoute
+	}
+	visible, canBeEmpty := cf.Visible, cf.CanBeEmpty
+	cf.Update(f)
+	cf.Visible = visible || f.Visible
+	cf.CanBeEmpty = canBeEmpty || f.CanBeEmpty
+	return fs, conflicts
+}
+
+// clone creates a deep copy of the section, its groups and its fields. The
+// field Source gets shared.
+func (s *Section) clone() *Section {
+	cs := *s
+	cs.Groups = make(Groups, 0, len(s.Groups))
+	for _, g := range s.Groups {
+		cg := *g
+		cg.Fields = make(Fields, 0, len(g.Fields))
+		for _, f := range g.Fields {
+			cf := *f
+			cg.Fields = append(cg.Fields, &cf)
+		}
+		cs.Groups = append(cs.Groups, &cg)
+	}
+	return &cs
+}
+
 // Find returns a Section pointer or -1 if section not found. Route must be a
 // single part. E.g. if you have path "a/b/c" route would be in this case "a".
 // 2nd return parameter contains the position of the Section within the Sections
@@ -379,17 +601,19 @@ type Groups []*Group
 type Group struct {
 	// ID unique ID and merged with others. 2nd part of the path.
 	ID      string
-	Label   string `json:",omitempty"`
-	Comment string `json:",omitempty"`
+	Label   string `json:",omitempty" yaml:",omitempty"`
+	Comment string `json:",omitempty" yaml:",omitempty"`
 	// Scopes: bit value eg: showInDefault="1" showInWebsite="1" showInStore="1"
-	Scopes    scope.Perm `json:",omitempty"`
-	SortOrder int        `json:",omitempty"`
+	Scopes    scope.Perm `json:",omitempty" yaml:",omitempty"`
+	SortOrder int        `json:",omitempty" yaml:",omitempty"`
 
-	HelpURL  string `json:",omitempty"`
-	MoreURL  string `json:",omitempty"`
-	DemoLink string `json:",omitempty"`
+	HelpURL  string `json:",omitempty" yaml:",omitempty"`
+	MoreURL  string `json:",omitempty" yaml:",omitempty"`
+	DemoLink string `json:",omitempty" yaml:",omitempty"`
 
-	Fields Fields `json:",omitempty"`
+	Fields Fields `json:",omitempty" yaml:",omitempty"`
+	// Remove deletes the group with the same ID. Only used by MergeStrict.
+	Remove bool `json:",omitempty" yaml:",omitempty"`
 	// Groups     Groups @todo see recursive options <xs:element name="group"> in app/code/Magento/Config/etc/system_file.xsd
 }
 
@@ -488,6 +712,11 @@ type FieldMeta struct {
 	ScopeID      scope.TypeID
 	Default      string
 	DefaultValid bool
+	// Encrypted if true, values get encrypted before writing and decrypted
+	// after reading with the Encryptor of the Service Options. Same as
+	// Field.Encrypted.
+	Encrypted bool
+	encryptor Encryptor // set by Service.applyEncryption
 	// Default sets the default value which gets later parsed into the desired
 	// final Go type. An empty string means not set or null.
 	valid bool
@@ -503,29 +732,38 @@ type Field struct {
 	ID string
 	// ConfigRoute if provided defines the storage path and overwrites the path from
 	// section.id + group.id + field.id. ConfigRoute can be nil.
-	ConfigRoute string `json:",omitempty"`
+	ConfigRoute string `json:",omitempty" yaml:",omitempty"`
 	// Type is used for the front end on how to display a Field
-	Type FieldType `json:",omitempty"`
+	Type FieldType `json:",omitempty" yaml:",omitempty"`
 	// Label a short label of the field
-	Label string `json:",omitempty"`
+	Label string `json:",omitempty" yaml:",omitempty"`
 	// Comment can contain HTML
-	Comment string `json:",omitempty"`
+	Comment string `json:",omitempty" yaml:",omitempty"`
 	// Tooltip used for frontend and can contain HTML
-	Tooltip string `json:",omitempty"`
+	Tooltip string `json:",omitempty" yaml:",omitempty"`
 	// SortOrder in ascending order
-	SortOrder int `json:",omitempty"`
+	SortOrder int `json:",omitempty" yaml:",omitempty"`
 	// Visible used for configuration settings which are not exposed to the user.
-	Visible bool `json:",omitempty"`
+	Visible bool `json:",omitempty" yaml:",omitempty"`
 	// CanBeEmpty only used in HTML forms for multiselect fields
-	CanBeEmpty bool `json:",omitempty"`
+	CanBeEmpty bool `json:",omitempty" yaml:",omitempty"`
 	// Scopes defines the max allowed scope. Some paths or values can only act
 	// on default, website or store scope. So perm checks if the provided
 	// path has a scope equal or lower than defined in perm.
-	Scopes scope.Perm `json:",omitempty"`
+	Scopes scope.Perm `json:",omitempty" yaml:",omitempty"`
 	// Default can contain any default config value: float64, int64, string,
 	// bool. An empty string is equal to NULL. A default gets requests if the
 	// value for a path cannot be retrieved from Level1 or Level2 storage.
-	Default string `json:",omitempty"`
+	Default string `json:",omitempty" yaml:",omitempty"`
+	// Encrypted flags sensitive values like API keys or passwords. Those
+	// values get encrypted before writing them into the storage and decrypted
+	// after reading, with the Encryptor set in the Options of the Service.
+	Encrypted bool `json:",omitempty" yaml:",omitempty"`
+	// Source provides the options for fields of type select or multiselect,
+	// either a static source.Slice or loaded lazily from the database.
+	Source source.Provider `json:"-" yaml:"-"`
+	// Remove deletes the field with the same ID. Only used by MergeStrict.
+	Remove bool `json:",omitempty" yaml:",omitempty"`
 }
 
 // MakeFields wrapper to create a new Fields
@@ -617,9 +855,28 @@ func (f *Field) Update(new *Field) *Field {
 
 	f.Visible = new.Visible
 	f.CanBeEmpty = new.CanBeEmpty
+	if new.Encrypted {
+		f.Encrypted = true // once encrypted, a merge cannot reveal the value
+	}
 
 	if new.Default != "" {
 		f.Default = new.Default
 	}
+	if new.Source != nil {
+		f.Source = new.Source
+	}
 	return f
 }
+
+// Options returns the options of a select or multiselect field. Returns nil if
+// the field has no Source.
+func (f *Field) Options(ctx context.Context) (source.Slice, error) {
+	if f.Source == nil {
+		return nil, nil
+	}
+	opts, err := f.Source.Options(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[config] Field %q", f.ID)
+	}
+	return opts, nil
+}
@@ -15,11 +15,15 @@
 package config_test
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/pkg/config"
+	"github.com/corestoreio/pkg/config/source"
 	"github.com/corestoreio/pkg/store/scope"
 	"github.com/corestoreio/pkg/util/assert"
 )
@@ -442,6 +446,151 @@ func TestSectionSliceMerge(t *testing.T) {
 	}
 }
 
+func TestSections_MergeStrict(t *testing.T) {
+	newBase := func() config.Sections {
+		return config.MustMakeSectionsValidate(
+			&config.Section{
+				ID: "web",
+				Groups: config.MakeGroups(
+					&config.Group{
+						ID:        "cors",
+						SortOrder: 10,
+						Fields: config.MakeFields(
+							&config.Field{ID: "allowed_origins", Type: config.TypeTextarea, SortOrder: 10, Default: "*"},
+							&config.Field{ID: "max_age", Type: config.TypeText, SortOrder: 20, Default: "3600"},
+							&config.Field{ID: "debug", Type: config.TypeSelect, SortOrder: 30},
+						),
+					},
+					&config.Group{
+						ID:        "cookie",
+						SortOrder: 20,
+						Fields: config.MakeFields(
+							&config.Field{ID: "lifetime", Type: config.TypeText},
+						),
+					},
+				),
+			},
+		)
+	}
+
+	t.Run("override remove and sort", func(t *testing.T) {
+		base := newBase()
+		ss, err := base.MergeStrict(config.MakeSections(
+			&config.Section{
+				ID:    "web",
+				Label: "Web",
+				Groups: config.MakeGroups(
+					&config.Group{
+						ID: "cors",
+						Fields: config.MakeFields(
+							&config.Field{ID: "max_age", Default: "7200", SortOrder: 5},
+							&config.Field{ID: "debug", Remove: true},
+							&config.Field{ID: "allowed_headers", Type: config.TypeTextarea, SortOrder: 10},
+						),
+					},
+					&config.Group{ID: "cookie", Remove: true},
+					&config.Group{ID: "csp", SortOrder: 1},
+				),
+			},
+		))
+		assert.NoError(t, err)
+
+		assert.Exactly(t, "Web", ss[0].Label)
+		assert.Len(t, ss[0].Groups, 2)
+		assert.Exactly(t, "csp", ss[0].Groups[0].ID)
+		cors := ss[0].Groups[1]
+		assert.Exactly(t, "cors", cors.ID)
+
+		var ids []string
+		for _, f := range cors.Fields {
+			ids = append(ids, f.ID)
+		}
+		// equal SortOrder 10: existing field comes before the new one.
+		assert.Exactly(t, []string{"max_age", "allowed_origins", "allowed_headers"}, ids)
+		assert.Exactly(t, "7200", cors.Fields[0].Default)
+		assert.Exactly(t, config.TypeText, cors.Fields[0].Type)
+
+		// base must not be modified.
+		f, _ := base.FindField("web/cors/max_age")
+		assert.Exactly(t, "3600", f.Default)
+		assert.Exactly(t, 20, f.SortOrder)
+		f, _ = base.FindField("web/cors/debug")
+		assert.NotNil(t, f)
+		_, idx := base.FindGroup("web/cookie")
+		assert.True(t, idx >= 0, "Group web/cookie should still exist")
+	})
+
+	t.Run("partial override keeps Visible and CanBeEmpty", func(t *testing.T) {
+		base := newBase()
+		f, _ := base.FindField("web/cors/max_age")
+		f.Visible = true
+		f.CanBeEmpty = true
+		ss, err := base.MergeStrict(config.MakeSections(&config.Section{
+			ID: "web",
+			Groups: config.MakeGroups(&config.Group{
+				ID: "cors",
+				Fields: config.MakeFields(
+					&config.Field{ID: "max_age", Default: "60"},
+					&config.Field{ID: "debug", Visible: true},
+				),
+			}),
+		}))
+		assert.NoError(t, err)
+		f, _ = ss.FindField("web/cors/max_age")
+		assert.Exactly(t, "60", f.Default)
+		assert.True(t, f.Visible)
+		assert.True(t, f.CanBeEmpty)
+		f, _ = ss.FindField("web/cors/debug")
+		assert.True(t, f.Visible)
+		assert.False(t, f.CanBeEmpty)
+	})
+
+	t.Run("remove section", func(t *testing.T) {
+		ss, err := newBase().MergeStrict(config.MakeSections(&config.Section{ID: "web", Remove: true}))
+		assert.NoError(t, err)
+		assert.Len(t, ss, 0)
+	})
+
+	t.Run("conflicts", func(t *testing.T) {
+		ss, err := newBase().MergeStrict(config.MakeSections(
+			&config.Section{ID: "payment", Remove: true},
+			&config.Section{
+				ID: "web",
+				Groups: config.MakeGroups(
+					&config.Group{ID: "session", Remove: true},
+					&config.Group{
+						ID: "cors",
+						Fields: config.MakeFields(
+							&config.Field{ID: "max_age", Type: config.TypeDuration},
+							&config.Field{ID: "exposed_headers", Remove: true},
+						),
+					},
+				),
+			},
+		))
+		assert.Nil(t, ss)
+		assert.True(t, errors.Mismatch.Match(err), "%+v", err)
+		assert.Contains(t, err.Error(), "4 conflict(s)")
+		assert.Contains(t, err.Error(), `cannot remove non-existent section "payment"`)
+		assert.Contains(t, err.Error(), `cannot remove non-existent group "web/session"`)
+		assert.Contains(t, err.Error(), `field "web/cors/max_age" cannot change its type from TypeText to TypeDuration`)
+		assert.Contains(t, err.Error(), `cannot remove non-existent field "web/cors/exposed_headers"`)
+	})
+
+	t.Run("config route conflict", func(t *testing.T) {
+		base := newBase()
+		base[0].Groups[0].Fields[0].ConfigRoute = "web/cors/origins"
+		_, err := base.MergeStrict(config.MakeSections(&config.Section{
+			ID: "web",
+			Groups: config.MakeGroups(&config.Group{
+				ID:     "cors",
+				Fields: config.MakeFields(&config.Field{ID: "allowed_origins", ConfigRoute: "web/cors/hosts"}),
+			}),
+		}))
+		assert.True(t, errors.Mismatch.Match(err), "%+v", err)
+	})
+}
+
 func TestFieldSliceMerge(t *testing.T) {
 
 	tests := []struct {
@@ -730,6 +879,122 @@ func TestSectionSliceFindFieldByID(t *testing.T) {
 	}
 }
 
+func TestField_Options(t *testing.T) {
+	f := &config.Field{ID: `list_mode`, Type: config.TypeSelect}
+	opts, err := f.Options(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, opts)
+
+	f.Update(&config.Field{Source: source.Slice{
+		{Value: "grid", Label: "Grid Only"},
+		{Value: "list", Label: "List Only"},
+	}})
+	opts, err = f.Options(context.Background())
+	assert.NoError(t, err)
+	assert.Exactly(t, []string{"grid", "list"}, opts.Values())
+	assert.True(t, opts.Contains("list"))
+	assert.False(t, opts.Contains("table"))
+}
+
+func TestFieldType_JSON(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		for ft := config.TypeButton; ft < config.TypeZMaximum; ft++ {
+			data, err := json.Marshal(ft)
+			assert.NoError(t, err)
+			var have config.FieldType
+			assert.NoError(t, json.Unmarshal(data, &have), "%s", data)
+			assert.Exactly(t, ft, have)
+		}
+	})
+	t.Run("duration name", func(t *testing.T) {
+		data, err := config.TypeDuration.MarshalJSON()
+		assert.NoError(t, err)
+		assert.Exactly(t, `"duration"`, string(data))
+	})
+	t.Run("with prefix", func(t *testing.T) {
+		ft, err := config.MakeFieldType("TypeMultiselect")
+		assert.NoError(t, err)
+		assert.Exactly(t, config.TypeMultiselect, ft)
+	})
+	t.Run("not supported", func(t *testing.T) {
+		var ft config.FieldType
+		err := json.Unmarshal([]byte(`"dropdown"`), &ft)
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	})
+}
+
+func TestMakeSectionsFromJSON(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		want := config.MustMakeSectionsValidate(
+			&config.Section{
+				ID:     "web",
+				Label:  "Web",
+				Scopes: scope.PermStore,
+				Groups: config.MakeGroups(
+					&config.Group{
+						ID:        "cors",
+						SortOrder: 20,
+						Scopes:    scope.PermWebsite,
+						Fields: config.MakeFields(
+							&config.Field{
+								ID:      "allowed_origins",
+								Type:    config.TypeTextarea,
+								Scopes:  scope.PermWebsite,
+								Default: "*",
+							},
+							&config.Field{
+								ID:        "api_key",
+								Type:      config.TypeObscure,
+								Scopes:    scope.PermDefault,
+								Encrypted: true,
+							},
+						),
+					},
+				),
+			},
+		)
+
+		data, err := json.Marshal(want)
+		assert.NoError(t, err)
+
+		have, err := config.MakeSectionsFromJSON(bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Exactly(t, want, have)
+	})
+	t.Run("merged with go code", func(t *testing.T) {
+		ss, err := config.MakeSectionsFromJSON(strings.NewReader(`[
+{"ID":"web","Groups":[{"ID":"cors","Fields":[{"ID":"max_age","Type":"text","Scopes":"websites","Default":"3600"}]}]},
+{"ID":"web","Label":"Web Options"}
+]`))
+		assert.NoError(t, err)
+		assert.Exactly(t, "Web Options", ss[0].Label)
+
+		ss = config.MustMakeSectionsValidate(&config.Section{
+			ID: "web",
+			Groups: config.MakeGroups(&config.Group{
+				ID:     "cors",
+				Fields: config.MakeFields(&config.Field{ID: "allowed_origins"}),
+			}),
+		}).MergeMultiple(ss)
+
+		f, _ := ss.FindField("web/cors/max_age")
+		assert.NotNil(t, f)
+		assert.Exactly(t, config.TypeText, f.Type)
+		assert.Exactly(t, scope.PermWebsite, f.Scopes)
+		assert.Exactly(t, "3600", f.Default)
+		f, _ = ss.FindField("web/cors/allowed_origins")
+		assert.NotNil(t, f)
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := config.MakeSectionsFromJSON(strings.NewReader(`[{"ID":"web","Lable":"Web"}]`))
+		assert.True(t, errors.BadEncoding.Match(err), "%+v", err)
+	})
+	t.Run("invalid scope", func(t *testing.T) {
+		_, err := config.MakeSectionsFromJSON(strings.NewReader(`[{"ID":"web","Scopes":"global"}]`))
+		assert.True(t, errors.BadEncoding.Match(err), "%+v", err)
+	})
+}
+
 func TestFieldSliceSort(t *testing.T) {
 
 	want := []int{-10, 1, 10, 11, 20}
@@ -0,0 +1,39 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build csall yaml
+
+package config
+
+import (
+	"io"
+
+	"github.com/corestoreio/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// MakeSectionsFromYAML decodes the YAML encoded sections, groups and fields,
+// including defaults and scope permissions, from r. Keys are the lower case
+// field names, for example "sortorder" or "configroute". Unknown keys are
+// treated as an error. Duplicated sections in r get merged and the result gets
+// validated.
+func MakeSectionsFromYAML(r io.Reader) (Sections, error) {
+	dec := yaml.NewDecoder(r)
+	dec.SetStrict(true)
+	var ss Sections
+	if err := dec.Decode(&ss); err != nil {
+		return nil, errors.BadEncoding.New(err, "[config] MakeSectionsFromYAML.Decode")
+	}
+	return MakeSectionsMerged(ss...)
+}
@@ -0,0 +1,103 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build csall yaml
+
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config"
+	"github.com/corestoreio/pkg/store/scope"
+	"github.com/corestoreio/pkg/util/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestMakeSectionsFromYAML(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		want := config.MustMakeSectionsValidate(
+			&config.Section{
+				ID:     "web",
+				Scopes: scope.PermStore,
+				Groups: config.MakeGroups(
+					&config.Group{
+						ID: "cors",
+						Fields: config.MakeFields(
+							&config.Field{
+								ID:      "allowed_origins",
+								Type:    config.TypeTextarea,
+								Scopes:  scope.PermWebsite,
+								Default: "*",
+							},
+							&config.Field{
+								ID:        "api_key",
+								Type:      config.TypeObscure,
+								Scopes:    scope.PermDefault,
+								Encrypted: true,
+							},
+						),
+					},
+				),
+			},
+		)
+
+		data, err := yaml.Marshal(want)
+		assert.NoError(t, err)
+
+		have, err := config.MakeSectionsFromYAML(strings.NewReader(string(data)))
+		assert.NoError(t, err)
+		assert.Exactly(t, want, have)
+	})
+	t.Run("data file", func(t *testing.T) {
+		ss, err := config.MakeSectionsFromYAML(strings.NewReader(`
+- id: web
+  label: Web
+  groups:
+  - id: cors
+    sortorder: 20
+    fields:
+    - id: max_age
+      type: text
+      scopes: websites
+      default: "3600"
+    - id: exposed_headers
+      configroute: web/cors/exposed
+      type: textarea
+      scopes: s
+`))
+		assert.NoError(t, err)
+
+		f, _ := ss.FindField("web/cors/max_age")
+		assert.NotNil(t, f)
+		assert.Exactly(t, config.TypeText, f.Type)
+		assert.Exactly(t, scope.PermWebsite, f.Scopes)
+		assert.Exactly(t, "3600", f.Default)
+
+		f, _ = ss.FindField("web/cors/exposed_headers")
+		assert.NotNil(t, f)
+		assert.Exactly(t, "web/cors/exposed", f.ConfigRoute)
+		assert.Exactly(t, scope.PermStore, f.Scopes)
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := config.MakeSectionsFromYAML(strings.NewReader("- id: web\n  lable: Web\n"))
+		assert.True(t, errors.BadEncoding.Match(err), "%+v", err)
+	})
+	t.Run("invalid field type", func(t *testing.T) {
+		_, err := config.MakeSectionsFromYAML(strings.NewReader("- id: web\n  groups:\n  - id: cors\n    fields:\n    - id: a\n      type: dropdown\n"))
+		assert.True(t, errors.BadEncoding.Match(err), "%+v", err)
+	})
+}
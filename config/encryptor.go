@@ -0,0 +1,70 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/store/scope"
+)
+
+// Encryptor encrypts and decrypts the values of fields flagged as Encrypted.
+// The Path allows to bind a cipher text to its route. An implementation based
+// on AES-GCM with a pluggable key provider can be found in package
+// config/observer.
+type Encryptor interface {
+	Encrypt(p Path, plaintext []byte) ([]byte, error)
+	Decrypt(p Path, ciphertext []byte) ([]byte, error)
+}
+
+// applyEncryption binds the Encryptor to fm, if fm has been flagged as
+// Encrypted. Encryption runs as the last step of event EventOnBeforeSet and
+// decryption as the first step of event EventOnAfterGet, so all registered
+// observers receive the plain text. Encryption applies to all scopes of a
+// route, so it cannot be set for a specific ScopeID.
+func (s *Service) applyEncryption(route string, fm *FieldMeta) error {
+	if !fm.Encrypted {
+		return nil
+	}
+	if fm.ScopeID > scope.DefaultTypeID {
+		return errors.NotAcceptable.Newf("[config] Encrypted and ScopeID %q cannot be set at once for route %q", fm.ScopeID.String(), route)
+	}
+	if s.config.Encryptor == nil {
+		return errors.Empty.Newf("[config] Route %q is encrypted but Options.Encryptor has not been set", route)
+	}
+	fm.encryptor = s.config.Encryptor
+	return nil
+}
+
+func encryptValue(enc Encryptor, p *Path, rawData []byte) ([]byte, error) {
+	if rawData == nil {
+		return nil, nil
+	}
+	v, err := enc.Encrypt(*p, rawData)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[config] Encrypt Path %q", p.String())
+	}
+	return v, nil
+}
+
+func decryptValue(enc Encryptor, p *Path, rawData []byte, found bool) ([]byte, error) {
+	if !found || rawData == nil {
+		return rawData, nil
+	}
+	v, err := enc.Decrypt(*p, rawData)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[config] Decrypt Path %q", p.String())
+	}
+	return v, nil
+}
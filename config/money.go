@@ -0,0 +1,73 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/storage/null"
+	"github.com/corestoreio/pkg/store/scope"
+)
+
+// RouteCurrencyBase defines the route to the ISO 4217 base currency code, which
+// can be set per website.
+const RouteCurrencyBase = "currency/options/base"
+
+// Money represents a price or a fee from the configuration together with its
+// currency.
+type Money struct {
+	Amount null.Decimal
+	// Currency contains the three letter ISO 4217 currency code.
+	Currency string
+}
+
+// String returns the amount followed by the currency code.
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
+
+// Money returns the decimal amount stored under the route together with the
+// base currency code of the current scope. Both values get resolved with the
+// same fallback store->website->default as Get. Returns ok false if the amount
+// cannot be found and an error with kind NotFound if no currency code has been
+// configured.
+func (ss Scoped) Money(restrictUpTo scope.Type, route string) (m Money, ok bool, err error) {
+	if m.Amount, ok, err = ss.Get(restrictUpTo, route).Decimal(); err != nil || !ok {
+		return Money{}, false, errors.WithStack(err)
+	}
+	cur, ok, err := ss.Get(scope.Absent, RouteCurrencyBase).Str()
+	if err != nil {
+		return Money{}, false, errors.WithStack(err)
+	}
+	if !ok || cur == "" {
+		return Money{}, false, errors.NotFound.Newf("[config] Scoped.Money: Currency %q not found for route %q in scope %s", RouteCurrencyBase, route, ss.ScopeID().String())
+	}
+	if !isCurrencyCode(cur) {
+		return Money{}, false, errors.NotValid.Newf("[config] Scoped.Money: Invalid currency code %q in scope %s", cur, ss.ScopeID().String())
+	}
+	m.Currency = cur
+	return m, true, nil
+}
+
+func isCurrencyCode(c string) bool {
+	if len(c) != 3 {
+		return false
+	}
+	for i := 0; i < 3; i++ {
+		if c[i] < 'A' || c[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
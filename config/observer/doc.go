@@ -29,8 +29,16 @@
 // The list of validators and modifiers will be extended. Please suggest new
 // ones.
 //
+// Function based validators (NewValueValidator with ValidateRegexp,
+// ValidateInt64Range, ValidateEnum) and sanitizers (NewSanitizer) can be
+// registered via RegisterHooks for writing and reading a route.
+//
 // Other encryption algorithms are getting later added.
 //
+// NewAESGCMEncryptor implements config.Encryptor for transparent encryption of
+// fields flagged as Encrypted, with keys from a pluggable KeyProvider like
+// HashiCorp Vault.
+//
 // Note: When using sha256 the fully qualified path gets prefixed to the value.
 //
 // To enabled HTTP handler or protobuf you must set build tags on the CLI.
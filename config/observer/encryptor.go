@@ -0,0 +1,161 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observer
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config"
+)
+
+// KeyProvider returns the keys for the AES-GCM Encryptor. Each key has an ID
+// which gets stored as part of the cipher text. New values get encrypted with
+// the current key, existing values get decrypted with the key referenced in
+// their cipher text, which allows key rotation. An implementation might load
+// the keys from HashiCorp Vault, a KMS or from environment variables.
+type KeyProvider interface {
+	// CurrentKey returns the key and its ID for encryption. The key must
+	// have a length of 16, 24, or 32 bytes to select AES-128, AES-192, or
+	// AES-256.
+	CurrentKey() (keyID string, key []byte, err error)
+	// Key returns the key for decryption. Returns an error with kind NotFound
+	// if the key ID does not exist.
+	Key(keyID string) ([]byte, error)
+}
+
+// StaticKeys implements KeyProvider with a fixed set of keys.
+type StaticKeys struct {
+	// CurrentID defines the key used for encryption.
+	CurrentID string
+	// Keys maps a key ID to its key.
+	Keys map[string][]byte
+}
+
+// CurrentKey implements KeyProvider.
+func (sk StaticKeys) CurrentKey() (string, []byte, error) {
+	k, err := sk.Key(sk.CurrentID)
+	return sk.CurrentID, k, err
+}
+
+// Key implements KeyProvider.
+func (sk StaticKeys) Key(keyID string) ([]byte, error) {
+	k, ok := sk.Keys[keyID]
+	if !ok {
+		return nil, errors.NotFound.Newf("[config/observer] StaticKeys: Key ID %q not found", keyID)
+	}
+	return k, nil
+}
+
+// encryptedPrefix identifies a value encrypted by aesGCMEncryptor. Format:
+// prefix + key ID + "$" + base64(nonce + sealed data).
+const encryptedPrefix = "aesgcm$"
+
+type aesGCMEncryptor struct {
+	kp KeyProvider
+}
+
+// NewAESGCMEncryptor creates a config.Encryptor which uses AES-GCM with a
+// random nonce for each value. The route of the path gets authenticated as
+// additional data, so an encrypted value cannot be copied to a different
+// route. Values without the encryption prefix get returned unchanged while
+// decrypting, which allows to migrate existing cleartext values.
+//		srv := config.MustNewService(storage.NewMap(), config.Options{
+//			Encryptor: observer.NewAESGCMEncryptor(vaultKeyProvider),
+//		}, config.WithApplySections(sections...))
+func NewAESGCMEncryptor(kp KeyProvider) config.Encryptor {
+	return aesGCMEncryptor{kp: kp}
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.NotValid.New(err, "[config/observer] The encryption key has a wrong format.")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Fatal.New(err, "[config/observer] cipher GCM failed")
+	}
+	return aead, nil
+}
+
+func (e aesGCMEncryptor) Encrypt(p config.Path, plaintext []byte) ([]byte, error) {
+	keyID, key, err := e.kp.CurrentKey()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	_, route := p.ScopeRoute()
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.ReadFailed.New(err, "[config/observer] ReadFull failed")
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(route))
+
+	var buf bytes.Buffer
+	buf.Grow(len(encryptedPrefix) + len(keyID) + 1 + base64.RawURLEncoding.EncodedLen(len(sealed)))
+	buf.WriteString(encryptedPrefix)
+	buf.WriteString(keyID)
+	buf.WriteByte('$')
+	enc := base64.NewEncoder(base64.RawURLEncoding, &buf)
+	_, _ = enc.Write(sealed)
+	_ = enc.Close()
+	return buf.Bytes(), nil
+}
+
+func (e aesGCMEncryptor) Decrypt(p config.Path, ciphertext []byte) ([]byte, error) {
+	if !bytes.HasPrefix(ciphertext, []byte(encryptedPrefix)) {
+		return ciphertext, nil
+	}
+	data := ciphertext[len(encryptedPrefix):]
+	pos := bytes.IndexByte(data, '$')
+	if pos < 0 {
+		return nil, errors.NotValid.Newf("[config/observer] Encrypted value contains no key ID")
+	}
+	key, err := e.kp.Key(string(data[:pos]))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	sealed := make([]byte, base64.RawURLEncoding.DecodedLen(len(data)-pos-1))
+	n, err := base64.RawURLEncoding.Decode(sealed, data[pos+1:])
+	if err != nil {
+		return nil, errors.NotValid.New(err, "[config/observer] Encrypted value has an invalid encoding")
+	}
+	sealed = sealed[:n]
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.NotValid.Newf("[config/observer] Encrypted value too short")
+	}
+
+	_, route := p.ScopeRoute()
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(route))
+	if err != nil {
+		return nil, errors.NotValid.New(err, "[config/observer] Decryption failed")
+	}
+	return plaintext, nil
+}
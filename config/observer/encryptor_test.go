@@ -0,0 +1,102 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config"
+	"github.com/corestoreio/pkg/config/observer"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestNewAESGCMEncryptor(t *testing.T) {
+	t.Parallel()
+
+	kp := observer.StaticKeys{
+		CurrentID: "k1",
+		Keys: map[string][]byte{
+			"k1": []byte("0123456789abcdef"),
+			"k2": []byte("0123456789abcdef0123456789abcdef"),
+		},
+	}
+	p := *config.MustNewPath("carriers/dhl/password")
+	plainText := []byte(`S3cr3t!`)
+
+	t.Run("encrypt and decrypt", func(t *testing.T) {
+		enc := observer.NewAESGCMEncryptor(kp)
+		encText, err := enc.Encrypt(p, plainText)
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(encText, []byte("aesgcm$k1$")), "%q", encText)
+		assert.False(t, bytes.Contains(encText, plainText))
+
+		encText2, err := enc.Encrypt(p, plainText)
+		assert.NoError(t, err)
+		assert.NotEqual(t, encText, encText2, "nonce must be random")
+
+		decText, err := enc.Decrypt(p, encText)
+		assert.NoError(t, err, "%+v", err)
+		assert.Exactly(t, plainText, decText)
+	})
+
+	t.Run("key rotation", func(t *testing.T) {
+		encText, err := observer.NewAESGCMEncryptor(kp).Encrypt(p, plainText)
+		assert.NoError(t, err)
+
+		kp2 := kp
+		kp2.CurrentID = "k2"
+		enc := observer.NewAESGCMEncryptor(kp2)
+		decText, err := enc.Decrypt(p, encText)
+		assert.NoError(t, err, "%+v", err)
+		assert.Exactly(t, plainText, decText)
+
+		encText, err = enc.Encrypt(p, plainText)
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(encText, []byte("aesgcm$k2$")), "%q", encText)
+	})
+
+	t.Run("different route fails", func(t *testing.T) {
+		enc := observer.NewAESGCMEncryptor(kp)
+		encText, err := enc.Encrypt(p, plainText)
+		assert.NoError(t, err)
+
+		decText, err := enc.Decrypt(*config.MustNewPath("carriers/ups/password"), encText)
+		assert.Nil(t, decText)
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+
+	t.Run("unknown key ID", func(t *testing.T) {
+		decText, err := observer.NewAESGCMEncryptor(kp).Decrypt(p, []byte("aesgcm$k3$AAAA"))
+		assert.Nil(t, decText)
+		assert.True(t, errors.NotFound.Match(err), "%+v", err)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		encText, err := observer.NewAESGCMEncryptor(observer.StaticKeys{
+			CurrentID: "k1",
+			Keys:      map[string][]byte{"k1": []byte("short")},
+		}).Encrypt(p, plainText)
+		assert.Nil(t, encText)
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+
+	t.Run("cleartext passes through", func(t *testing.T) {
+		decText, err := observer.NewAESGCMEncryptor(kp).Decrypt(p, plainText)
+		assert.NoError(t, err)
+		assert.Exactly(t, plainText, decText)
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observer
+
+import (
+	"regexp"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config"
+)
+
+// ValueValidateFn validates a typed value, for example an integer range, a
+// regular expression or the membership in an enumeration.
+type ValueValidateFn func(*config.Value) error
+
+type valueValidators []ValueValidateFn
+
+// NewValueValidator creates an observer which runs all validation functions in
+// order. The first error stops the validation. Values which have not been
+// found, get skipped.
+func NewValueValidator(fns ...ValueValidateFn) config.Observer {
+	return valueValidators(fns)
+}
+
+// Observe runs the validation functions.
+func (vv valueValidators) Observe(p config.Path, rawData []byte, found bool) ([]byte, error) {
+	if !found {
+		return rawData, nil
+	}
+	v := config.NewValue(rawData)
+	v.Path = p
+	for idx, fn := range vv {
+		if err := fn(v); err != nil {
+			return nil, errors.Wrapf(err, "[config/observer] ValueValidator at index %d for Path %q", idx, p.String())
+		}
+	}
+	return rawData, nil
+}
+
+// NewSanitizer creates an observer which applies all functions in order to
+// modify the data, for example to trim or normalize a value.
+func NewSanitizer(fns ...ModifierFn) config.Observer {
+	opType := make([]string, len(fns))
+	for i := range opType {
+		opType[i] = "sanitizer"
+	}
+	return &modifiers{
+		opType: opType,
+		opFns:  fns,
+	}
+}
+
+// ValidateRegexp returns a validation function which checks that the value
+// matches the regular expression.
+func ValidateRegexp(re *regexp.Regexp) ValueValidateFn {
+	return func(v *config.Value) error {
+		str, ok, err := v.Str()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if ok && !re.MatchString(str) {
+			return errors.NotValid.Newf("[config/observer] The value %q does not match %q", str, re.String())
+		}
+		return nil
+	}
+}
+
+// ValidateInt64Range returns a validation function which checks that the
+// value is an integer between min and max, both inclusive.
+func ValidateInt64Range(min, max int64) ValueValidateFn {
+	return func(v *config.Value) error {
+		i, ok, err := v.Int64()
+		if err != nil {
+			return errors.NotValid.New(err, "[config/observer] The value %s is not an integer", v.String())
+		}
+		if ok && (i < min || i > max) {
+			return errors.OutOfRange.Newf("[config/observer] The value %d is not between %d and %d", i, min, max)
+		}
+		return nil
+	}
+}
+
+// ValidateEnum returns a validation function which checks that the value is
+// one of the allowed values.
+func ValidateEnum(allowed ...string) ValueValidateFn {
+	set := make(map[string]bool, len(allowed))
+	for _, a := range allowed {
+		set[a] = true
+	}
+	return func(v *config.Value) error {
+		str, ok, err := v.Str()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if ok && !set[str] {
+			return errors.NotValid.Newf("[config/observer] The value %q is not one of %q", str, allowed)
+		}
+		return nil
+	}
+}
+
+// RegisterHooks registers the observers for the events EventOnBeforeSet and
+// EventOnAfterGet on the route. Values get validated and sanitized
+// consistently when writing and when reading them, for example after
+// importing them from a different source.
+//		err := observer.RegisterHooks(srv, "web/cors/max_age",
+//			observer.NewSanitizer(trimFn),
+//			observer.NewValueValidator(observer.ValidateInt64Range(0, 86400)),
+//		)
+func RegisterHooks(or config.ObserverRegisterer, route string, obs ...config.Observer) error {
+	for _, event := range [...]uint8{config.EventOnBeforeSet, config.EventOnAfterGet} {
+		for _, o := range obs {
+			if err := or.RegisterObserver(event, route, o); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+	return nil
+}
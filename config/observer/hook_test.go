@@ -0,0 +1,99 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observer_test
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config"
+	"github.com/corestoreio/pkg/config/observer"
+	"github.com/corestoreio/pkg/config/storage"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestValueValidators(t *testing.T) {
+	t.Parallel()
+	p := *config.MustNewPath("aa/bb/cc")
+
+	t.Run("regexp", func(t *testing.T) {
+		o := observer.NewValueValidator(observer.ValidateRegexp(regexp.MustCompile(`^[A-Z]{3}$`)))
+		data, err := o.Observe(p, []byte(`CHF`), true)
+		assert.NoError(t, err)
+		assert.Exactly(t, []byte(`CHF`), data)
+
+		data, err = o.Observe(p, []byte(`chf`), true)
+		assert.Nil(t, data)
+		assert.True(t, errors.NotValid.Match(errors.Cause(err)), "%+v", err)
+	})
+	t.Run("int64 range", func(t *testing.T) {
+		o := observer.NewValueValidator(observer.ValidateInt64Range(1, 10))
+		_, err := o.Observe(p, []byte(`10`), true)
+		assert.NoError(t, err)
+
+		_, err = o.Observe(p, []byte(`11`), true)
+		assert.True(t, errors.OutOfRange.Match(errors.Cause(err)), "%+v", err)
+
+		_, err = o.Observe(p, []byte(`x`), true)
+		assert.Contains(t, err.Error(), `The value "x" is not an integer`)
+	})
+	t.Run("enum", func(t *testing.T) {
+		o := observer.NewValueValidator(observer.ValidateEnum("grid", "list"))
+		_, err := o.Observe(p, []byte(`list`), true)
+		assert.NoError(t, err)
+
+		_, err = o.Observe(p, []byte(`table`), true)
+		assert.True(t, errors.NotValid.Match(errors.Cause(err)), "%+v", err)
+	})
+	t.Run("not found skips", func(t *testing.T) {
+		o := observer.NewValueValidator(observer.ValidateEnum("grid", "list"))
+		data, err := o.Observe(p, nil, false)
+		assert.NoError(t, err)
+		assert.Nil(t, data)
+	})
+}
+
+func TestRegisterHooks(t *testing.T) {
+	t.Parallel()
+
+	st := storage.NewMap()
+	srv := config.MustNewService(st, config.Options{})
+	defer func() { assert.NoError(t, srv.Close()) }()
+
+	assert.NoError(t, observer.RegisterHooks(srv, "catalog/frontend/list_mode",
+		observer.NewSanitizer(func(_ *config.Path, data []byte) ([]byte, error) {
+			return bytes.ToLower(bytes.TrimSpace(data)), nil
+		}),
+		observer.NewValueValidator(observer.ValidateEnum("grid", "list")),
+	))
+
+	p := config.MustNewPath("catalog/frontend/list_mode").BindStore(2)
+	assert.NoError(t, srv.Set(p, []byte(" Grid ")))
+	v, _, err := st.Get(p)
+	assert.NoError(t, err)
+	assert.Exactly(t, "grid", string(v))
+
+	err = srv.Set(p, []byte("table"))
+	assert.True(t, errors.NotValid.Match(errors.Cause(err)), "%+v", err)
+
+	assert.NoError(t, st.Set(p, []byte(" LIST\n"))) // written by a different source
+	assert.Exactly(t, `"list"`, srv.Get(p).String())
+
+	assert.NoError(t, st.Set(p, []byte("table")))
+	_, _, err = srv.Get(p).Str()
+	assert.True(t, errors.NotValid.Match(errors.Cause(err)), "value read must be validated: %+v", err)
+}
@@ -59,8 +59,13 @@ type Options struct {
 	// reloading do not trigger an exit of the config.Service.
 	EnableHotReload bool
 	// HotReloadSignals specifies custom signals to listen to. Defaults to
-	// syscall.SIGUSR2
+	// syscall.SIGUSR2. Daemons usually reload their configuration files with
+	// syscall.SIGHUP.
 	HotReloadSignals []os.Signal
+	// Encryptor encrypts and decrypts the values of all fields flagged as
+	// Encrypted, see WithApplySections. Required if at least one field has
+	// been flagged.
+	Encryptor Encryptor
 }
 
 // LoadDataOption allows other storage backends to pump their data into the
@@ -152,6 +157,9 @@ func WithFieldMetaGenerator(fmGenerator func(*Service) (<-chan *FieldMeta, <-cha
 					if !rfm.DefaultValid && rfm.Default != "" {
 						rfm.DefaultValid = true
 					}
+					if err := s.applyEncryption(rfm.Route, rfm); err != nil {
+						return errors.WithStack(err)
+					}
 					s.routeConfig.PutMeta(rfm.Route, rfm)
 
 				case err, ok := <-errC:
@@ -193,6 +201,9 @@ func WithFieldMeta(fms ...*FieldMeta) LoadDataOption {
 				if !rfm.DefaultValid && rfm.Default != "" {
 					rfm.DefaultValid = true
 				}
+				if err := s.applyEncryption(rfm.Route, rfm); err != nil {
+					return errors.WithStack(err)
+				}
 				s.routeConfig.PutMeta(rfm.Route, rfm)
 			}
 			return nil
@@ -204,7 +215,8 @@ func WithFieldMeta(fms ...*FieldMeta) LoadDataOption {
 // routes. This function option cannot handle a default value for a specific
 // website/store scope. Storage level and sort order are not supported. Because
 // of using FieldMeta, it supports hierarchical fall back to the parent scope
-// for default values.
+// for default values. Fields flagged as Encrypted register the Options.Encryptor
+// as observer for the events EventOnBeforeSet and EventOnAfterGet.
 func WithApplySections(sections ...*Section) LoadDataOption {
 	secs := Sections(sections)
 	var once bool
@@ -240,6 +252,11 @@ func WithApplySections(sections ...*Section) LoadDataOption {
 						fm.WriteScopePerm = f.Scopes
 						fm.Default = f.Default
 						fm.DefaultValid = f.Default != ""
+						fm.Encrypted = f.Encrypted
+						fm.Events = [eventMaxCount]observers{}
+						if err := s.applyEncryption(route, fm); err != nil {
+							return errors.WithStack(err)
+						}
 						s.routeConfig.PutMeta(route, fm)
 						buf.Reset()
 					}
@@ -190,12 +190,22 @@ func (trie *trieRoute) process(key string, event uint8, p *Path, v []byte, found
 		return v, found, nil
 	}
 
+	var enc Encryptor
+	if event == EventOnBeforeSet || event == EventOnAfterGet {
+		enc = trie.encryptor(key)
+	}
+	if enc != nil && event == EventOnAfterGet {
+		if v, err = decryptValue(enc, p, v, found); err != nil {
+			return nil, false, errors.WithStack(err)
+		}
+	}
+
 	node := trie
 	for part, i := segmentRoute(key, 0); ; part, i = segmentRoute(key, i) {
 		node = node.children[part]
 
 		if node == nil {
-			return v, found, nil
+			break
 		}
 		if node.fm.valid && event == EventOnBeforeSet && node.fm.WriteScopePerm > 0 && p.ScopeID > 0 && !node.fm.WriteScopePerm.Has(p.ScopeID.Type()) {
 			return nil, false, errors.NotAllowed.Newf("[config] The path %q is not allowed to access this scope %s", p.String(), node.fm.WriteScopePerm.String())
@@ -205,8 +215,7 @@ func (trie *trieRoute) process(key string, event uint8, p *Path, v []byte, found
 			return nil, false, errors.WithStack(err)
 		}
 
-		if node.fm.valid && (len(node.children) == 0 || p.ScopeID == 0 || p.ScopeID == scope.DefaultTypeID) &&
-			event == EventOnAfterGet && !found && v == nil && node.fm.DefaultValid {
+		if event == EventOnAfterGet && !found && v == nil && node.appliesDefault(p) {
 			v = []byte(node.fm.Default)
 			found = true
 		}
@@ -216,9 +225,62 @@ func (trie *trieRoute) process(key string, event uint8, p *Path, v []byte, found
 		}
 	}
 
+	if enc != nil && event == EventOnBeforeSet {
+		if v, err = encryptValue(enc, p, v); err != nil {
+			return nil, false, errors.WithStack(err)
+		}
+	}
 	return v, found, nil
 }
 
+// encryptor returns the Encryptor of the deepest node along the key.
+func (trie *trieRoute) encryptor(key string) (enc Encryptor) {
+	node := trie
+	for part, i := segmentRoute(key, 0); ; part, i = segmentRoute(key, i) {
+		node = node.children[part]
+		if node == nil {
+			return enc
+		}
+		if node.fm.encryptor != nil {
+			enc = node.fm.encryptor
+		}
+		if i == -1 {
+			return enc
+		}
+	}
+}
+
+// appliesDefault reports whether the default value of the node can be used for
+// path p. The default value of a node with children applies only to the
+// default scope.
+func (trie *trieRoute) appliesDefault(p *Path) bool {
+	return trie.fm.valid && trie.fm.DefaultValid &&
+		(len(trie.children) == 0 || p.ScopeID == 0 || p.ScopeID == scope.DefaultTypeID)
+}
+
+// defaultValue returns the default value of a path without dispatching any
+// events. Same logic as in function process.
+func (trie *trieRoute) defaultValue(key string, p *Path) (v []byte, found bool) {
+	if trie == nil {
+		return nil, false
+	}
+	node := trie
+	for part, i := segmentRoute(key, 0); ; part, i = segmentRoute(key, i) {
+		node = node.children[part]
+		if node == nil {
+			return v, found
+		}
+		if !found && node.appliesDefault(p) {
+			v = []byte(node.fm.Default)
+			found = true
+		}
+		if i == -1 {
+			break
+		}
+	}
+	return v, found
+}
+
 func trieGetNode(node *trieRoute, key string, scp scope.TypeID) *trieRoute {
 	key = buildTrieKey(key, scp)
 	for part, i := segmentRoute(key, 0); ; part, i = segmentRoute(key, i) {
@@ -252,7 +314,8 @@ func (trie *trieRoute) PutEvent(event uint8, key string, eo Observer) bool {
 }
 
 // PutMeta inserts the `fm` into the trie at the given key, replacing any
-// existing items, except the Events which will be appended to `fm`. It returns
+// existing items, except the Events which will be appended to `fm`. Once a
+// route has been encrypted, it stays encrypted. It returns
 // true if the put adds a new fm, false if it replaces an existing fm. The
 // pointer fm gets dereferenced.
 func (trie *trieRoute) PutMeta(key string, fm *FieldMeta) bool {
@@ -265,6 +328,10 @@ func (trie *trieRoute) PutMeta(key string, fm *FieldMeta) bool {
 		node.fm.Events[i] = append(node.fm.Events[i], fm.Events[i]...)
 		fm.Events[i] = node.fm.Events[i]
 	}
+	if fm.encryptor == nil && node.fm.encryptor != nil {
+		fm.Encrypted = true
+		fm.encryptor = node.fm.encryptor
+	}
 	node.fm = *fm
 	node.fm.valid = true
 	return isNewVal
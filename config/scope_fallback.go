@@ -0,0 +1,134 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"strings"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/store/scope"
+)
+
+// ScopeFallback returns the scopes in the order in which Scoped.Get queries a
+// route until it finds a value. The arguments are the website and store ID
+// to which Scoped has been bound; an ID can be zero. The built-in chain is
+// store -> website -> default. Path can only store values in the default,
+// websites and stores scopes, hence an additional level gets expressed by the
+// IDs of those scopes, for example a store which inherits the values of a
+// parent store:
+//		func(websiteID, storeID int64) scope.TypeIDs {
+//			return scope.TypeIDs{
+//				scope.Store.WithID(storeID),
+//				scope.Store.WithID(parentStoreID(storeID)),
+//				scope.Website.WithID(websiteID),
+//				scope.DefaultTypeID,
+//			}
+//		}
+type ScopeFallback func(websiteID, storeID int64) scope.TypeIDs
+
+// MakeScopeFallback creates a fallback chain from the scope types in the given
+// order. Website and Store get skipped if Scoped has not been bound to a
+// website or store. MakeScopeFallback(scope.Store, scope.Website) stops at the
+// website scope and never queries the default scope;
+// MakeScopeFallback(scope.Website, scope.Default) ignores the store scope.
+func MakeScopeFallback(scopes ...scope.Type) ScopeFallback {
+	return func(websiteID, storeID int64) scope.TypeIDs {
+		ids := make(scope.TypeIDs, 0, len(scopes))
+		for _, scp := range scopes {
+			switch {
+			case scp == scope.Store && storeID > 0:
+				ids = append(ids, scope.Store.WithID(storeID))
+			case scp == scope.Website && websiteID > 0:
+				ids = append(ids, scope.Website.WithID(websiteID))
+			case scp == scope.Default:
+				ids = append(ids, scope.DefaultTypeID)
+			}
+		}
+		return ids
+	}
+}
+
+// WithScopeFallback overrides the store -> website -> default chain of
+// Scoped.Get for a route. The route can be a section, a section/group or a
+// complete route; the longest matching route wins. Returns a NotValid error if
+// the route is empty or has more than three parts, or an Empty error if sf is
+// nil.
+//		config.WithScopeFallback("web/cors", config.MakeScopeFallback(scope.Store, scope.Website))
+func WithScopeFallback(route string, sf ScopeFallback) LoadDataOption {
+	return MakeLoadDataOption(func(s *Service) error {
+		if route == "" || strings.Count(route, sPathSeparator) > 2 {
+			return errors.NotValid.Newf("[config] WithScopeFallback: invalid route %q", route)
+		}
+		if sf == nil {
+			return errors.Empty.Newf("[config] WithScopeFallback: ScopeFallback for route %q cannot be nil", route)
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.scopeFallbacks == nil {
+			s.scopeFallbacks = make(map[string]ScopeFallback)
+		}
+		s.scopeFallbacks[route] = sf
+		return nil
+	})
+}
+
+// scopeFallback returns the ScopeFallback with the longest matching route or
+// nil.
+func (s *Service) scopeFallback(route string) ScopeFallback {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.scopeFallbacks) == 0 {
+		return nil
+	}
+	for {
+		if sf, ok := s.scopeFallbacks[route]; ok {
+			return sf
+		}
+		pos := strings.LastIndexByte(route, PathSeparator)
+		if pos < 0 {
+			return nil
+		}
+		route = route[:pos]
+	}
+}
+
+type scopeFallbacker interface {
+	scopeFallback(route string) ScopeFallback
+}
+
+// getWithFallback queries the scopes returned by sf in that order and skips
+// all scopes above restrictUpTo.
+func (ss Scoped) getWithFallback(sf ScopeFallback, restrictUpTo scope.Type, route string) (v *Value) {
+	p := Path{
+		route: Route(route),
+	}
+	for _, id := range sf(ss.websiteID, ss.storeID) {
+		if restrictUpTo > scope.Absent && id.Type() > restrictUpTo {
+			continue
+		}
+		p.ScopeID = id
+		v = ss.rootSrv.Get(&p)
+		if v.found > valFoundNo || v.lastErr != nil {
+			if v.lastErr != nil {
+				v.lastErr = errors.WithStack(v.lastErr)
+			}
+			return v
+		}
+	}
+	if v == nil {
+		v = &Value{Path: p}
+	}
+	return v
+}
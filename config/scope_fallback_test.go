@@ -0,0 +1,111 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config"
+	"github.com/corestoreio/pkg/config/storage"
+	"github.com/corestoreio/pkg/store/scope"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestMakeScopeFallback(t *testing.T) {
+	sf := config.MakeScopeFallback(scope.Store, scope.Website, scope.Default)
+	assert.Exactly(t, scope.TypeIDs{scope.Store.WithID(2), scope.Website.WithID(1), scope.DefaultTypeID}, sf(1, 2))
+	assert.Exactly(t, scope.TypeIDs{scope.Website.WithID(1), scope.DefaultTypeID}, sf(1, 0))
+	assert.Exactly(t, scope.TypeIDs{scope.DefaultTypeID}, sf(0, 0))
+}
+
+func TestWithScopeFallback(t *testing.T) {
+	const (
+		routeOrigins = "web/cors/allowed_origins"
+		routeMaxAge  = "web/cors/max_age"
+		routeLocale  = "general/locale/code"
+	)
+
+	srv := config.MustNewService(storage.NewMap(), config.Options{},
+		config.WithScopeFallback("web/cors", config.MakeScopeFallback(scope.Store, scope.Website)),
+		config.WithScopeFallback(routeMaxAge, config.MakeScopeFallback(scope.Website, scope.Default)),
+		config.WithScopeFallback("general", func(websiteID, storeID int64) scope.TypeIDs {
+			// store 3 inherits from store 2
+			ids := scope.TypeIDs{scope.Store.WithID(storeID)}
+			if storeID == 3 {
+				ids = append(ids, scope.Store.WithID(2))
+			}
+			return append(ids, scope.Website.WithID(websiteID), scope.DefaultTypeID)
+		}),
+	)
+	defer func() { assert.NoError(t, srv.Close()) }()
+
+	assert.NoError(t, srv.Set(config.MustNewPath(routeOrigins), []byte(`*`)))
+	assert.NoError(t, srv.Set(config.MustNewPath(routeMaxAge), []byte(`3600`)))
+	assert.NoError(t, srv.Set(config.MustNewPath(routeMaxAge).BindStore(2), []byte(`60`)))
+	assert.NoError(t, srv.Set(config.MustNewPath(routeLocale).BindStore(2), []byte(`de_CH`)))
+
+	scpd := srv.Scoped(1, 3)
+
+	t.Run("stop at website", func(t *testing.T) {
+		_, ok, err := scpd.Get(scope.Absent, routeOrigins).Str()
+		assert.NoError(t, err)
+		assert.False(t, ok, "default scope must not be queried")
+
+		assert.NoError(t, srv.Set(config.MustNewPath(routeOrigins).BindWebsite(1), []byte(`https://example.com`)))
+		s, ok, err := scpd.Get(scope.Absent, routeOrigins).Str()
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Exactly(t, "https://example.com", s)
+	})
+	t.Run("ignore store, longest route wins", func(t *testing.T) {
+		i, ok, err := srv.Scoped(1, 2).Get(scope.Absent, routeMaxAge).Int()
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Exactly(t, 3600, i)
+	})
+	t.Run("additional level", func(t *testing.T) {
+		s, ok, err := scpd.Get(scope.Absent, routeLocale).Str()
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Exactly(t, "de_CH", s)
+
+		_, ok, err = srv.Scoped(1, 4).Get(scope.Absent, routeLocale).Str()
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+	t.Run("restrictUpTo", func(t *testing.T) {
+		_, ok, err := scpd.Get(scope.Website, routeLocale).Str()
+		assert.NoError(t, err)
+		assert.False(t, ok, "store scopes must be skipped")
+	})
+	t.Run("built-in chain for other routes", func(t *testing.T) {
+		assert.NoError(t, srv.Set(config.MustNewPath("carrier/dhl/timeout"), []byte(`30s`)))
+		s, ok, err := scpd.Get(scope.Absent, "carrier/dhl/timeout").Str()
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Exactly(t, "30s", s)
+	})
+	t.Run("invalid route", func(t *testing.T) {
+		_, err := config.NewService(storage.NewMap(), config.Options{},
+			config.WithScopeFallback("a/b/c/d", config.MakeScopeFallback(scope.Default)))
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+	t.Run("nil ScopeFallback", func(t *testing.T) {
+		_, err := config.NewService(storage.NewMap(), config.Options{},
+			config.WithScopeFallback("a/b/c", nil))
+		assert.True(t, errors.Empty.Match(err), "%+v", err)
+	})
+}
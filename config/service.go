@@ -25,6 +25,7 @@ import (
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/log"
 	"github.com/corestoreio/pkg/store/scope"
+	"github.com/corestoreio/pkg/util/bufferpool"
 )
 
 // Scoper creates a hierarchy based configuration retriever based on website and
@@ -57,6 +58,26 @@ type Storager interface {
 	// Delete(p *Path) error TODO
 }
 
+// PathValue combines a path with its value for writing several values at once.
+type PathValue struct {
+	Path  *Path
+	Value []byte
+}
+
+// BatchSetter gets implemented by a Storager which can write several values
+// atomically, for example within a single database transaction. Either all
+// values get written or none.
+type BatchSetter interface {
+	SetBatch(pvs []PathValue) error
+}
+
+// Deleter gets implemented by a Storager which can remove values. Service.Reload
+// removes the keys which have been written by a previous run of the
+// LoadDataOption functions but not by the current run.
+type Deleter interface {
+	Delete(p *Path) error
+}
+
 // ObserverRegisterer adds or removes observers for different events and theirs
 // routes. Extracted for testability in other packages. Type *Service implements
 // this interface.
@@ -83,6 +104,7 @@ type Service struct {
 	pubSub          *pubSub
 	hotReloadSignal chan os.Signal
 	loadDataFns     loadDataOptions
+	loadedKeys      *loadedKeys
 	envReplacer     *strings.Replacer
 
 	// more events can be added once needed.
@@ -90,6 +112,11 @@ type Service struct {
 	// routeConfig contains essential information about a route like scope for
 	// permission, default value or events.
 	routeConfig *trieRoute
+	// defaults contains the default values per route set via ApplyDefaults.
+	defaults map[string]string
+	// scopeFallbacks contains the custom fallback chains of Scoped.Get per
+	// route, see WithScopeFallback.
+	scopeFallbacks map[string]ScopeFallback
 }
 
 // NewService creates the main new configuration for all scopes: default,
@@ -103,6 +130,7 @@ func NewService(level2 Storager, o Options, fns ...LoadDataOption) (s *Service,
 		config:      o,
 		Log:         o.Log,
 		routeConfig: newTrieRoute(),
+		loadedKeys:  new(loadedKeys),
 	}
 
 	if err := s.setupEnv(); err != nil {
@@ -186,7 +214,7 @@ func (s *Service) shouldEnableHotReload() error {
 			if s.config.Log != nil && s.config.Log.IsDebug() {
 				s.config.Log.Debug("config.Service.HotReload.Signal", log.String("signal", sgnl.String()))
 			}
-			err := s.loadData()
+			err := s.Reload()
 			if s.config.Log != nil && s.config.Log.IsInfo() && err != nil {
 				s.config.Log.Debug("config.Service.HotReload.LoadingError", log.String("signal", sgnl.String()), log.Err(err))
 			}
@@ -197,8 +225,11 @@ func (s *Service) shouldEnableHotReload() error {
 }
 
 // loadData used for hot reloading and runs also within another goroutine but
-// reads only from *Service.
+// reads only from *Service. Keys written by a previous run but not by the
+// current run get removed from their storage, if it implements Deleter.
 func (s *Service) loadData() error {
+	lk := s.loadedKeys
+	lk.start()
 	for _, opt := range s.loadDataFns {
 		s2 := s
 		if opt.level == 1 && s2.config.Level1 != nil {
@@ -209,12 +240,99 @@ func (s *Service) loadData() error {
 			s2.level2 = s2.config.Level1
 		}
 		if err := opt.load(s2); err != nil {
+			lk.finish(false)
 			return errors.WithStack(err)
 		}
 	}
+	for _, sk := range lk.finish(true) {
+		if d, ok := sk.storage.(Deleter); ok {
+			if err := d.Delete(sk.path); err != nil && !errors.NotSupported.Match(err) {
+				return errors.Wrapf(err, "[config] Service.loadData failed to delete stale path %q", sk.path.String())
+			}
+		}
+		if d, ok := s.config.Level1.(Deleter); ok && sk.storage != s.config.Level1 {
+			if err := d.Delete(sk.path); err != nil && !errors.NotSupported.Match(err) {
+				return errors.Wrapf(err, "[config] Service.loadData failed to delete stale path %q in Level1", sk.path.String())
+			}
+		}
+	}
 	return nil
 }
 
+// loadedKey references a path written by a LoadDataOption function and the
+// storage containing it.
+type loadedKey struct {
+	path    *Path
+	storage Storager
+}
+
+// loadedKeys tracks the keys written by the LoadDataOption functions. Shared
+// by pointer between the copies of a Service in loadData.
+type loadedKeys struct {
+	// run serializes the runs of loadData, for example a hot reload signal
+	// and a file watcher.
+	run sync.Mutex
+	mu  sync.Mutex
+	// loading is nil when no LoadDataOption functions run.
+	loading map[string]loadedKey
+	loaded  map[string]loadedKey
+}
+
+func (lk *loadedKeys) start() {
+	lk.run.Lock()
+	lk.mu.Lock()
+	lk.loading = make(map[string]loadedKey, len(lk.loaded))
+	lk.mu.Unlock()
+}
+
+// track records the path, if the LoadDataOption functions run. Calls of
+// Service.Set from other goroutines during a reload get recorded too.
+func (lk *loadedKeys) track(p *Path, st Storager) {
+	if lk == nil {
+		return
+	}
+	lk.mu.Lock()
+	if lk.loading != nil {
+		p2 := *p
+		lk.loading[p2.String()] = loadedKey{path: &p2, storage: st}
+	}
+	lk.mu.Unlock()
+}
+
+// finish ends a run and returns on success the stale keys, which have been
+// loaded by the previous run but not by the current one. A failed run keeps
+// all keys for the next run.
+func (lk *loadedKeys) finish(success bool) (stale []loadedKey) {
+	defer lk.run.Unlock()
+	lk.mu.Lock()
+	defer lk.mu.Unlock()
+	if !success {
+		for k, v := range lk.loaded {
+			if _, ok := lk.loading[k]; !ok {
+				lk.loading[k] = v
+			}
+		}
+		lk.loaded, lk.loading = lk.loading, nil
+		return nil
+	}
+	for k, v := range lk.loaded {
+		if _, ok := lk.loading[k]; !ok {
+			stale = append(stale, v)
+		}
+	}
+	lk.loaded, lk.loading = lk.loading, nil
+	return stale
+}
+
+// Reload runs all LoadDataOption functions again, the same way as receiving a
+// hot reload signal does. It allows other triggers, like a file system watcher,
+// to apply changed configuration files. Keys which are not provided anymore,
+// for example removed from a file, get deleted if the storage implements
+// Deleter.
+func (s *Service) Reload() error {
+	return errors.WithStack(s.loadData())
+}
+
 // EnvName returns the environment name to which this service is bound to.
 func (s *Service) EnvName() string {
 	return s.envName
@@ -349,6 +467,7 @@ func (s *Service) Set(p *Path, v []byte) (err error) { // TODO v should be an im
 	if err := s.level2.Set(p, v); err != nil {
 		return errors.Wrap(err, "[config] Service.level2.Set")
 	}
+	s.loadedKeys.track(p, s.level2)
 	if s.pubSub != nil {
 		s.pubSub.sendMsg(*p)
 	}
@@ -356,6 +475,63 @@ func (s *Service) Set(p *Path, v []byte) (err error) { // TODO v should be an im
 	return
 }
 
+// WriteBatch writes all values atomically, needed for importing whole
+// configuration sections safely. First each path gets validated and the
+// observers of event EventOnBeforeSet get dispatched, which might modify the
+// value. If one validation fails, nothing gets written. Then all values get
+// written at once into the Level2 storage, which must implement interface
+// BatchSetter, for example as a single database transaction. Finally the
+// observers of event EventOnAfterSet get dispatched and the paths get published
+// to the subscribers.
+func (s *Service) WriteBatch(values ...PathValue) (err error) {
+	if len(values) == 0 {
+		return nil
+	}
+	bs, ok := s.level2.(BatchSetter)
+	if !ok {
+		return errors.NotSupported.Newf("[config] Service.WriteBatch: Level2 storage %T does not implement interface BatchSetter", s.level2)
+	}
+	if s.config.Log != nil && s.config.Log.IsDebug() {
+		defer log.WhenDone(s.config.Log).Debug("config.Service.WriteBatch", log.Int("values", len(values)), log.Err(err))
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pvs := make([]PathValue, len(values))
+	keys := make([]string, len(values))
+	for i, pv := range values {
+		p := pv.Path
+		if p.UseEnvSuffix && p.envSuffix != s.envName {
+			p.envSuffix = s.envName
+		}
+		if err := p.IsValid(); err != nil {
+			return errors.WithStack(err)
+		}
+		keys[i] = buildTrieKey(p.separatorSuffixRoute(), p.ScopeID)
+		v, _, err := s.routeConfig.process(keys[i], EventOnBeforeSet, p, pv.Value, true)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		pvs[i] = PathValue{Path: p, Value: v}
+	}
+
+	if err := bs.SetBatch(pvs); err != nil {
+		return errors.Wrap(err, "[config] Service.level2.SetBatch")
+	}
+
+	for i, pv := range pvs {
+		s.loadedKeys.track(pv.Path, s.level2)
+		if _, _, err2 := s.routeConfig.process(keys[i], EventOnAfterSet, pv.Path, pv.Value, true); err2 != nil && err == nil {
+			err = errors.WithStack(err2)
+		}
+		if s.pubSub != nil {
+			s.pubSub.sendMsg(*pv.Path)
+		}
+	}
+	return err
+}
+
 // Get returns a configuration value from the Service, ignoring the scope
 // hierarchy/fallback logic using a direct match. Safe for concurrent use.
 // Example usage:
@@ -413,6 +589,10 @@ func (s *Service) Get(p *Path) (v *Value) {
 		if ok2 && v.found == valFoundNo {
 			v.found = valFoundDefaults
 		}
+		if d, ok3 := s.layerDefault(p); ok3 && v.found == valFoundNo && v.lastErr == nil {
+			v.data = []byte(d)
+			v.found = valFoundDefaults
+		}
 		s.mu.RUnlock()
 	}()
 
@@ -444,6 +624,57 @@ func (s *Service) Get(p *Path) (v *Value) {
 	return
 }
 
+// ApplyDefaults writes the default values of all fields in sections into an
+// in-memory defaults layer. Get falls back to this layer for paths in the
+// default scope if neither Level1, Level2 nor the FieldMeta data provide a
+// value, hence Scoped.Get finds a default after it has checked the store and
+// website scopes. In contrast to WithApplySections, ApplyDefaults does not
+// touch permissions or observers and can be called several times, for example
+// once per package at startup; a later default for the same route wins. Fields
+// with a ConfigRoute get stored under that route.
+func (s *Service) ApplyDefaults(sections Sections) error {
+	if err := sections.Validate(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.defaults == nil {
+		s.defaults = make(map[string]string, sections.TotalFields())
+	}
+	for _, sec := range sections {
+		for _, g := range sec.Groups {
+			for _, f := range g.Fields {
+				if f.Default == "" {
+					continue
+				}
+				route := f.ConfigRoute
+				if route == "" {
+					buf.Reset()
+					joinParts(buf, sec.ID, g.ID, f.ID)
+					route = buf.String()
+				}
+				s.defaults[route] = f.Default
+			}
+		}
+	}
+	return nil
+}
+
+// layerDefault returns the default value set via ApplyDefaults. Only the
+// default scope has access to the defaults layer. Must be called with s.mu
+// being locked.
+func (s *Service) layerDefault(p *Path) (string, bool) {
+	if len(s.defaults) == 0 || (p.ScopeID > 0 && p.ScopeID != scope.DefaultTypeID) {
+		return "", false
+	}
+	d, ok := s.defaults[string(p.route)]
+	return d, ok
+}
+
 // Subscribe adds an asynchronous Subscriber to be called when a write event
 // happens. See interface Subscriber for a detailed description. Path can be any
 // kind of level and can contain StrScope and Scope ID. Valid paths can be for
@@ -454,7 +685,8 @@ func (s *Service) Get(p *Path) (v *Value) {
 //		- currency/options/base
 //		- currency/options
 //		- currency
-// Events are running asynchronously.
+// Paths without StrScope and ID receive the changes of all scopes. Events are
+// running asynchronously.
 func (s *Service) Subscribe(path string, mr MessageReceiver) (subscriptionID int, err error) {
 	if s.pubSub == nil {
 		return 0, errors.NotImplemented.Newf("[config] PubSub not enabled")
@@ -462,6 +694,21 @@ func (s *Service) Subscribe(path string, mr MessageReceiver) (subscriptionID int
 	return s.pubSub.Subscribe(path, mr)
 }
 
+// SubscribeFunc same as Subscribe but calls the function `fn` with the changed
+// path and its current value, read via Service.Get. Components can react this
+// way to configuration writes at runtime instead of reading the value on each
+// request or requiring a restart. The function runs asynchronously in the
+// publisher goroutine and hence must not block.
+//		id, err := srv.SubscribeFunc("web/cors", func(p config.Path, v *config.Value) {
+//			// rebuild the CORS settings for p.ScopeID
+//		})
+func (s *Service) SubscribeFunc(path string, fn func(p Path, v *Value)) (subscriptionID int, err error) {
+	return s.Subscribe(path, MessageReceiverFunc(func(p Path) error {
+		fn(p, s.Get(&p))
+		return nil
+	}))
+}
+
 // Unsubscribe removes a subscriber with a specific ID.
 func (s *Service) Unsubscribe(subscriptionID int) error {
 	if s.pubSub == nil {
@@ -571,9 +818,15 @@ func (ss Scoped) isAllowedWebsite(restrictUpTo scope.Type) bool {
 // bubbling. For example a path gets stored in all three scopes but argument
 // `restrictUpTo` specifies only website scope, then the store scope will be
 // ignored for querying. If argument `restrictUpTo` has been set to zero aka.
-// scope.Absent, then all three scopes are considered for querying.
+// scope.Absent, then all three scopes are considered for querying. The chain
+// can be customized per route with WithScopeFallback.
 // Returns a guaranteed non-nil Value.
 func (ss Scoped) Get(restrictUpTo scope.Type, route string) (v *Value) {
+	if sfr, ok := ss.rootSrv.(scopeFallbacker); ok {
+		if sf := sfr.scopeFallback(route); sf != nil {
+			return ss.getWithFallback(sf, restrictUpTo, route)
+		}
+	}
 	// fallback to next parent scope if value does not exists
 	p := Path{
 		route: Route(route),
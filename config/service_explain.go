@@ -0,0 +1,129 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/corestoreio/errors"
+)
+
+// Names of the layers reported by Service.Explain.
+const (
+	LayerLevel1   = "level1"
+	LayerLevel2   = "level2"
+	LayerDefaults = "defaults"
+)
+
+// LayerValue describes the value of a path within one storage layer.
+type LayerValue struct {
+	// Layer names the storage, see the constants Layer* or the names of the
+	// layers of a storage implementing interface Explainer.
+	Layer string
+	Data  []byte
+	Found bool
+}
+
+// Explainer gets implemented by a Storager which consists of several layers,
+// to report the value of a path in each layer in the order of precedence.
+type Explainer interface {
+	Explain(p *Path) ([]LayerValue, error)
+}
+
+// Explanation reports which storage layer supplies the value of a path.
+type Explanation struct {
+	// Source names the first layer which contains the path and hence
+	// supplies the value returned by Service.Get. Empty if not found.
+	Source string
+	// Layers contains all layers in the order of precedence.
+	Layers []LayerValue
+}
+
+// String returns a human readable multi line explanation. The source gets
+// marked with an arrow.
+func (e Explanation) String() string {
+	var buf strings.Builder
+	var sourceMarked bool
+	for _, lv := range e.Layers {
+		if !lv.Found {
+			fmt.Fprintf(&buf, "%s: not found\n", lv.Layer)
+			continue
+		}
+		fmt.Fprintf(&buf, "%s: %q", lv.Layer, lv.Data)
+		if !sourceMarked {
+			buf.WriteString(" <- source")
+			sourceMarked = true
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+// Explain reports for a path the values of all storage layers in the order of
+// precedence and which layer supplies the value: Level1 (a cache of Level2),
+// Level2 and the defaults of the FieldMeta data or of ApplyDefaults. If Level2 implements interface
+// Explainer, for example a layered storage, each of its layers gets reported
+// instead of Level2. Explain helps to debug "where does this value come from"
+// issues; it does not dispatch any events.
+//		e, err := srv.Explain(config.MustNewPath("web/cors/allow_credentials").BindWebsite(1))
+//		fmt.Print(e)
+func (s *Service) Explain(p *Path) (e Explanation, err error) {
+	if p.UseEnvSuffix && p.envSuffix != s.envName {
+		p.envSuffix = s.envName
+	}
+	if err = p.IsValid(); err != nil {
+		return e, errors.WithStack(err)
+	}
+
+	if s.config.Level1 != nil {
+		v, found, err := s.config.Level1.Get(p)
+		if err != nil {
+			return e, errors.Wrapf(err, "[config] Service.Explain.Level1 with path %q", p)
+		}
+		e.Layers = append(e.Layers, LayerValue{Layer: LayerLevel1, Data: v, Found: found})
+	}
+
+	if ex, ok := s.level2.(Explainer); ok {
+		lvs, err := ex.Explain(p)
+		if err != nil {
+			return e, errors.Wrapf(err, "[config] Service.Explain.Level2 with path %q", p)
+		}
+		e.Layers = append(e.Layers, lvs...)
+	} else {
+		v, found, err := s.level2.Get(p)
+		if err != nil {
+			return e, errors.Wrapf(err, "[config] Service.Explain.Level2 with path %q", p)
+		}
+		e.Layers = append(e.Layers, LayerValue{Layer: LayerLevel2, Data: v, Found: found})
+	}
+
+	s.mu.RLock()
+	key := buildTrieKey(p.separatorSuffixRoute(), p.ScopeID)
+	v, found := s.routeConfig.defaultValue(key, p)
+	if d, ok := s.layerDefault(p); ok && !found {
+		v, found = []byte(d), true
+	}
+	s.mu.RUnlock()
+	e.Layers = append(e.Layers, LayerValue{Layer: LayerDefaults, Data: v, Found: found})
+
+	for _, lv := range e.Layers {
+		if lv.Found {
+			e.Source = lv.Layer
+			break
+		}
+	}
+	return e, nil
+}
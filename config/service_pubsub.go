@@ -15,6 +15,7 @@
 package config
 
 import (
+	"strings"
 	"sync"
 
 	"github.com/corestoreio/errors"
@@ -35,6 +36,15 @@ type MessageReceiver interface {
 	MessageConfig(Path) error
 }
 
+// MessageReceiverFunc type is an adapter to allow the use of ordinary functions
+// as MessageReceiver.
+type MessageReceiverFunc func(Path) error
+
+// MessageConfig calls f(p).
+func (f MessageReceiverFunc) MessageConfig(p Path) error {
+	return f(p)
+}
+
 // Subscriber represents the overall service to receive subscriptions from
 // MessageReceiver interfaces. This interface is at the moment only implemented
 // by the config.Service.
@@ -159,6 +169,8 @@ func (s *pubSub) publish() {
 			evict = append(evict, s.readMapAndSend(p, 4)...)  // e.g.: StrScope/ID/system/smtp
 			evict = append(evict, s.readMapAndSend(p, -1)...) // e.g.: StrScope/ID/system/smtp/host/...
 
+			evict = append(evict, s.readMapAndSendRoute(p)...) // e.g.: system/smtp for all scopes
+
 			// remove all failed Subscribers
 			if len(evict) > 0 {
 				for _, e := range evict {
@@ -185,6 +197,30 @@ func (s *pubSub) readMapAndSend(p Path, level int) (evict []int) {
 	return
 }
 
+// readMapAndSendRoute sends the path to the subscribers of a route or a part of
+// it without StrScope and ID, which listen to the changes of all scopes.
+func (s *pubSub) readMapAndSendRoute(p Path) (evict []int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r := p.route.String()
+	for pos := 0; pos < len(r); {
+		lev := r
+		sc := strings.IndexByte(r[pos:], PathSeparator)
+		if sc >= 0 {
+			lev = r[:pos+sc]
+		}
+		if subs, ok := s.subMap[lev]; ok { // e.g.: system/smtp
+			evict = append(evict, s.sendMsgs(subs, p)...)
+		}
+		if sc < 0 {
+			break
+		}
+		pos += sc + 1
+	}
+	return
+}
+
 func (s *pubSub) sendMsgs(subs map[int]MessageReceiver, p Path) (evict []int) {
 	for id, sub := range subs {
 		if err := s.sendMsgRecoverable(id, sub, p); err != nil {
@@ -21,6 +21,7 @@ import (
 	goLog "log"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/log"
@@ -278,3 +279,40 @@ func TestPubSubEvict(t *testing.T) {
 	err = s.Close()
 	assert.True(t, errors.AlreadyClosed.Match(err), "Error: %s", err)
 }
+
+func TestService_SubscribeFunc(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	s := config.MustNewService(storage.NewMap(), config.Options{
+		EnablePubSub: true,
+	})
+
+	type pathValue struct {
+		path  string
+		value string
+	}
+	received := make(chan pathValue, 1)
+	subID, err := s.SubscribeFunc("web/cors", func(p config.Path, v *config.Value) {
+		received <- pathValue{path: p.String(), value: v.String()}
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, subID)
+
+	p := config.MustNewPath("web/cors/allow_credentials").BindWebsite(2)
+	assert.NoError(t, s.Set(p, []byte(`1`)))
+
+	select {
+	case pv := <-received:
+		assert.Exactly(t, p.String(), pv.path)
+		assert.Exactly(t, `"1"`, pv.value)
+	case <-time.After(time.Second):
+		t.Fatal("SubscribeFunc has not been called")
+	}
+	assert.NoError(t, s.Close())
+
+	t.Run("PubSub disabled", func(t *testing.T) {
+		s := config.MustNewService(storage.NewMap(), config.Options{})
+		_, err := s.SubscribeFunc("web/cors", func(config.Path, *config.Value) {})
+		assert.True(t, errors.NotImplemented.Match(err), "%+v", err)
+	})
+}
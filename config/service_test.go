@@ -542,6 +542,43 @@ func TestHotReload(t *testing.T) {
 	assert.Exactly(t, `"3601s"`, srv.Get(pTimeout).String())
 }
 
+func TestService_Reload(t *testing.T) {
+	p := config.MustNewPath("ww/ee/rr")
+	var reloadCounter = new(int64)
+	srv := config.MustNewService(storage.NewMap(), config.Options{},
+		config.MakeLoadDataOption(func(s *config.Service) error {
+			return s.Set(p, strconv.AppendInt(nil, atomic.AddInt64(reloadCounter, 1), 10))
+		}),
+	)
+	defer func() { assert.NoError(t, srv.Close()) }()
+
+	assert.Exactly(t, `"1"`, srv.Get(p).String())
+	assert.NoError(t, srv.Reload())
+	assert.Exactly(t, `"2"`, srv.Get(p).String())
+}
+
+func TestService_Reload_DeletesStaleKeys(t *testing.T) {
+	pKeep := config.MustNewPath("ww/ee/keep")
+	pStale := config.MustNewPath("ww/ee/stale").BindWebsite(2)
+	var reloadCounter = new(int64)
+	srv := config.MustNewService(storage.NewMap(), config.Options{},
+		config.MakeLoadDataOption(func(s *config.Service) error {
+			if atomic.AddInt64(reloadCounter, 1) == 1 {
+				if err := s.Set(pStale, []byte("removed from file")); err != nil {
+					return err
+				}
+			}
+			return s.Set(pKeep, []byte("kept"))
+		}),
+	)
+	defer func() { assert.NoError(t, srv.Close()) }()
+
+	assert.Exactly(t, `"removed from file"`, srv.Get(pStale).String())
+	assert.NoError(t, srv.Reload())
+	assert.Exactly(t, `"kept"`, srv.Get(pKeep).String())
+	assert.False(t, srv.Get(pStale).IsValid(), "stale key must be deleted")
+}
+
 type keyer interface {
 	Keys(ret ...string) []string
 }
@@ -876,3 +913,313 @@ func TestService_FieldMetaData_Fallbacks(t *testing.T) {
 	})
 
 }
+
+func TestService_Explain(t *testing.T) {
+	p := config.MustNewPath("carrier/dhl/timeout").BindStore(2)
+	srv := config.MustNewService(storage.NewMap(), config.Options{
+		Level1: storage.NewMap(),
+	})
+	defer func() { assert.NoError(t, srv.Close()) }()
+
+	e, err := srv.Explain(p)
+	assert.NoError(t, err)
+	assert.Exactly(t, "", e.Source)
+	assert.Exactly(t, "level1: not found\nlevel2: not found\ndefaults: not found\n", e.String())
+
+	assert.NoError(t, srv.Set(p, []byte(`30s`)))
+	e, err = srv.Explain(p)
+	assert.NoError(t, err)
+	assert.Exactly(t, config.LayerLevel2, e.Source)
+
+	assert.Exactly(t, `"30s"`, srv.Get(p).String()) // populates Level1
+	e, err = srv.Explain(p)
+	assert.NoError(t, err)
+	assert.Exactly(t, config.LayerLevel1, e.Source)
+	assert.Exactly(t, "level1: \"30s\" <- source\nlevel2: \"30s\"\ndefaults: not found\n", e.String())
+}
+
+func TestService_WriteBatch(t *testing.T) {
+	srv := config.MustNewService(storage.NewMap(), config.Options{},
+		config.WithFieldMeta(
+			&config.FieldMeta{
+				Route:          "carrier/dhl/timeout",
+				WriteScopePerm: scope.PermDefault,
+				Default:        "3600s",
+			},
+		),
+	)
+	defer func() { assert.NoError(t, srv.Close()) }()
+
+	username := config.MustNewPath("carrier/dhl/username")
+	timeout := config.MustNewPath("carrier/dhl/timeout")
+
+	t.Run("validation fails, nothing written", func(t *testing.T) {
+		err := srv.WriteBatch(
+			config.PathValue{Path: username.BindStore(3), Value: []byte(`guestUser`)},
+			config.PathValue{Path: timeout.BindStore(3), Value: []byte(`1m`)},
+		)
+		assert.True(t, errors.NotAllowed.Match(err), "%+v", err)
+
+		_, ok, err := srv.Get(username.BindStore(3)).Str()
+		assert.NoError(t, err)
+		assert.False(t, ok, "username must not be written")
+		assert.Exactly(t, `"3600s"`, srv.Get(timeout.BindStore(3)).String())
+	})
+
+	t.Run("all written", func(t *testing.T) {
+		assert.NoError(t, srv.WriteBatch(
+			config.PathValue{Path: username.BindStore(3), Value: []byte(`guestUser`)},
+			config.PathValue{Path: timeout, Value: []byte(`1m`)},
+		))
+		assert.Exactly(t, `"guestUser"`, srv.Get(username.BindStore(3)).String())
+		assert.Exactly(t, `"1m"`, srv.Get(timeout).String())
+	})
+
+	t.Run("storage does not support batches", func(t *testing.T) {
+		lay, err := storage.NewLayered(storage.LayeredOptions{},
+			storage.Layer{Name: "env", Storager: storage.NewEnvOverlay(nil, storage.EnvOp{})},
+		)
+		assert.NoError(t, err)
+		srv2 := config.MustNewService(lay, config.Options{})
+		defer func() { assert.NoError(t, srv2.Close()) }()
+
+		err = srv2.WriteBatch(config.PathValue{Path: username, Value: []byte(`guestUser`)})
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	})
+}
+
+type prefixEncryptor struct{}
+
+func (prefixEncryptor) Encrypt(p config.Path, plaintext []byte) ([]byte, error) {
+	return append([]byte("enc:"), plaintext...), nil
+}
+
+func (prefixEncryptor) Decrypt(p config.Path, ciphertext []byte) ([]byte, error) {
+	if !bytes.HasPrefix(ciphertext, []byte("enc:")) {
+		return nil, errors.NotValid.Newf("missing prefix in %q", ciphertext)
+	}
+	return ciphertext[4:], nil
+}
+
+func TestService_Encrypted(t *testing.T) {
+	sections := config.MakeSections(
+		&config.Section{
+			ID: "carriers",
+			Groups: config.MakeGroups(
+				&config.Group{
+					ID: "dhl",
+					Fields: config.MakeFields(
+						&config.Field{ID: "password", Encrypted: true},
+						&config.Field{ID: "username"},
+					),
+				},
+			),
+		},
+	)
+
+	t.Run("Encryptor missing", func(t *testing.T) {
+		srv, err := config.NewService(storage.NewMap(), config.Options{}, config.WithApplySections(sections...))
+		assert.Nil(t, srv)
+		assert.True(t, errors.Empty.Match(err), "%+v", err)
+	})
+
+	t.Run("values encrypted in storage", func(t *testing.T) {
+		st := storage.NewMap()
+		srv := config.MustNewService(st, config.Options{
+			Encryptor: prefixEncryptor{},
+		}, config.WithApplySections(sections...))
+		defer func() { assert.NoError(t, srv.Close()) }()
+
+		pw := config.MustNewPath("carriers/dhl/password").BindWebsite(1)
+		un := config.MustNewPath("carriers/dhl/username").BindWebsite(1)
+		assert.NoError(t, srv.Set(pw, []byte(`S3cr3t`)))
+		assert.NoError(t, srv.Set(un, []byte(`gopher`)))
+
+		v, ok, err := st.Get(pw)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Exactly(t, "enc:S3cr3t", string(v))
+		v, _, err = st.Get(un)
+		assert.NoError(t, err)
+		assert.Exactly(t, "gopher", string(v))
+
+		assert.Exactly(t, `"S3cr3t"`, srv.Get(pw).String())
+		assert.Exactly(t, `"gopher"`, srv.Get(un).String())
+	})
+
+	t.Run("observers receive plain text", func(t *testing.T) {
+		st := storage.NewMap()
+		srv := config.MustNewService(st, config.Options{
+			Encryptor: prefixEncryptor{},
+		}, config.WithApplySections(sections...))
+		defer func() { assert.NoError(t, srv.Close()) }()
+
+		var seen []string
+		upper := testObserver{observe: func(p config.Path, rawData []byte, found bool) ([]byte, error) {
+			seen = append(seen, string(rawData))
+			return bytes.ToUpper(rawData), nil
+		}}
+		assert.NoError(t, srv.RegisterObserver(config.EventOnBeforeSet, "carriers/dhl/password", upper))
+		assert.NoError(t, srv.RegisterObserver(config.EventOnAfterGet, "carriers/dhl/password", upper))
+
+		pw := config.MustNewPath("carriers/dhl/password")
+		assert.NoError(t, srv.Set(pw, []byte(`s3cr3t`)))
+		v, _, err := st.Get(pw)
+		assert.NoError(t, err)
+		assert.Exactly(t, "enc:S3CR3T", string(v))
+
+		assert.Exactly(t, `"S3CR3T"`, srv.Get(pw).String())
+		assert.Exactly(t, []string{"s3cr3t", "S3CR3T"}, seen)
+
+		// deregistering observers keeps the encryption
+		assert.NoError(t, srv.DeregisterObserver(config.EventOnBeforeSet, "carriers/dhl/password"))
+		assert.NoError(t, srv.Set(pw, []byte(`n3w`)))
+		v, _, err = st.Get(pw)
+		assert.NoError(t, err)
+		assert.Exactly(t, "enc:n3w", string(v))
+	})
+
+	t.Run("FieldMeta", func(t *testing.T) {
+		st := storage.NewMap()
+		srv := config.MustNewService(st, config.Options{
+			Encryptor: prefixEncryptor{},
+		}, config.WithFieldMeta(&config.FieldMeta{
+			Route:     "payment/stripe/api_key",
+			Encrypted: true,
+		}))
+		defer func() { assert.NoError(t, srv.Close()) }()
+
+		p := config.MustNewPath("payment/stripe/api_key").BindStore(3)
+		assert.NoError(t, srv.Set(p, []byte(`sk_test`)))
+		v, _, err := st.Get(p)
+		assert.NoError(t, err)
+		assert.Exactly(t, "enc:sk_test", string(v))
+		assert.Exactly(t, `"sk_test"`, srv.Get(p).String())
+	})
+
+	t.Run("FieldMeta with ScopeID", func(t *testing.T) {
+		srv, err := config.NewService(storage.NewMap(), config.Options{
+			Encryptor: prefixEncryptor{},
+		}, config.WithFieldMeta(&config.FieldMeta{
+			Route:     "payment/stripe/api_key",
+			ScopeID:   scope.Website.WithID(1),
+			Encrypted: true,
+		}))
+		assert.Nil(t, srv)
+		assert.True(t, errors.NotAcceptable.Match(err), "%+v", err)
+	})
+}
+
+func TestScoped_Money(t *testing.T) {
+	srv := config.MustNewService(storage.NewMap(
+		"default/0/currency/options/base", "EUR",
+		"websites/1/currency/options/base", "CHF",
+		"websites/3/currency/options/base", "chf",
+		"default/0/carriers/flatrate/price", "5.00",
+		"stores/2/carriers/flatrate/price", "12.50",
+	), config.Options{})
+	defer func() { assert.NoError(t, srv.Close()) }()
+
+	t.Run("store value with website currency", func(t *testing.T) {
+		m, ok, err := srv.Scoped(1, 2).Money(scope.Absent, "carriers/flatrate/price")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Exactly(t, "12.50 CHF", m.String())
+	})
+	t.Run("default value with default currency", func(t *testing.T) {
+		m, ok, err := srv.Scoped(2, 4).Money(scope.Absent, "carriers/flatrate/price")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Exactly(t, "5.00 EUR", m.String())
+	})
+	t.Run("amount not found", func(t *testing.T) {
+		m, ok, err := srv.Scoped(1, 2).Money(scope.Absent, "carriers/flatrate/handling_fee")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Exactly(t, config.Money{}, m)
+	})
+	t.Run("invalid currency", func(t *testing.T) {
+		_, ok, err := srv.Scoped(3, 5).Money(scope.Absent, "carriers/flatrate/price")
+		assert.False(t, ok)
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+	t.Run("currency not found", func(t *testing.T) {
+		srv := config.MustNewService(storage.NewMap(
+			"default/0/carriers/flatrate/price", "5.00",
+		), config.Options{})
+		defer func() { assert.NoError(t, srv.Close()) }()
+
+		_, ok, err := srv.Scoped(1, 2).Money(scope.Absent, "carriers/flatrate/price")
+		assert.False(t, ok)
+		assert.True(t, errors.NotFound.Match(err), "%+v", err)
+	})
+}
+
+func TestService_ApplyDefaults(t *testing.T) {
+	srv := config.MustNewService(storage.NewMap(), config.Options{},
+		config.WithFieldMeta(
+			&config.FieldMeta{
+				Route:   "web/cors/max_age",
+				Default: "7200",
+			},
+		),
+	)
+	defer func() { assert.NoError(t, srv.Close()) }()
+
+	assert.NoError(t, srv.ApplyDefaults(config.MustMakeSectionsValidate(&config.Section{
+		ID: "web",
+		Groups: config.MakeGroups(&config.Group{
+			ID: "cors",
+			Fields: config.MakeFields(
+				&config.Field{ID: "allow_credentials", Default: "true"},
+				&config.Field{ID: "allowed_origins", ConfigRoute: "web/cors/origins", Default: "*"},
+				&config.Field{ID: "max_age", Default: "3600"},
+				&config.Field{ID: "exposed_headers"},
+			),
+		}),
+	})))
+
+	t.Run("default scope", func(t *testing.T) {
+		assert.Exactly(t, `"true"`, srv.Get(config.MustNewPath("web/cors/allow_credentials")).String())
+		assert.Exactly(t, `"*"`, srv.Get(config.MustNewPath("web/cors/origins")).String())
+		_, ok, err := srv.Get(config.MustNewPath("web/cors/exposed_headers")).Str()
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+	t.Run("FieldMeta default takes precedence", func(t *testing.T) {
+		assert.Exactly(t, `"7200"`, srv.Get(config.MustNewPath("web/cors/max_age")).String())
+	})
+	t.Run("not available in store scope", func(t *testing.T) {
+		_, ok, err := srv.Get(config.MustNewPath("web/cors/allow_credentials").BindStore(2)).Bool()
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+	t.Run("scoped fallback", func(t *testing.T) {
+		scpd := srv.Scoped(1, 2)
+		b, ok, err := scpd.Get(scope.Absent, "web/cors/allow_credentials").Bool()
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.True(t, b)
+
+		assert.NoError(t, srv.Set(config.MustNewPath("web/cors/allow_credentials").BindWebsite(1), []byte(`false`)))
+		b, ok, err = scpd.Get(scope.Absent, "web/cors/allow_credentials").Bool()
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.False(t, b)
+	})
+	t.Run("explain", func(t *testing.T) {
+		e, err := srv.Explain(config.MustNewPath("web/cors/origins"))
+		assert.NoError(t, err)
+		assert.Exactly(t, config.LayerDefaults, e.Source)
+	})
+	t.Run("later call wins", func(t *testing.T) {
+		assert.NoError(t, srv.ApplyDefaults(config.MustMakeSectionsValidate(&config.Section{
+			ID: "web",
+			Groups: config.MakeGroups(&config.Group{
+				ID:     "cors",
+				Fields: config.MakeFields(&config.Field{ID: "allow_credentials", Default: "false"}),
+			}),
+		})))
+		assert.Exactly(t, `"false"`, srv.Get(config.MustNewPath("web/cors/allow_credentials")).String())
+	})
+}
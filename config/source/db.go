@@ -0,0 +1,101 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build csall db
+
+package source
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/storage/null"
+)
+
+// DBOptions applies options to NewDB.
+type DBOptions struct {
+	// TTL defines how long the loaded options get cached. Zero caches them
+	// until Invalidate gets called.
+	TTL time.Duration
+}
+
+// DB implements Provider and loads the options from a SELECT query, for
+// example the list of CMS pages or customer groups. The query must return
+// two columns, the value and the label. The query executes lazily once
+// Options gets called and the result gets cached.
+type DB struct {
+	sel *dml.Select
+	o   DBOptions
+
+	mu       sync.Mutex
+	options  Slice
+	loadedAt time.Time
+}
+
+// NewDB creates a new database backed options provider. The Select must have a
+// database connection assigned.
+//		src := source.NewDB(dbc.SelectFrom("cms_page").AddColumns("identifier", "title").
+//			Where(dml.Column("is_active").Int(1)).OrderBy("title"), source.DBOptions{TTL: time.Hour})
+func NewDB(sel *dml.Select, o DBOptions) *DB {
+	return &DB{
+		sel: sel,
+		o:   o,
+	}
+}
+
+// Options implements Provider and queries the database if the options have
+// not yet been loaded or the TTL expired.
+func (db *DB) Options(ctx context.Context) (Slice, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.options != nil && (db.o.TTL == 0 || time.Since(db.loadedAt) < db.o.TTL) {
+		return db.options, nil
+	}
+
+	rows, err := db.sel.WithArgs().QueryContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "[config/source] DB.Options.QueryContext")
+	}
+	defer rows.Close()
+
+	var options Slice
+	for rows.Next() {
+		var value, label null.String
+		if err := rows.Scan(&value, &label); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		options = append(options, Pair{Value: value.String, Label: label.String})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if options == nil {
+		options = Slice{} // empty result is also a valid cached result
+	}
+	db.options = options
+	db.loadedAt = time.Now()
+	return options, nil
+}
+
+// Invalidate clears the cache and the next call to Options queries the
+// database again, for example after a CMS page has been saved.
+func (db *DB) Invalidate() {
+	db.mu.Lock()
+	db.options = nil
+	db.mu.Unlock()
+}
@@ -0,0 +1,87 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build csall db
+
+package source_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config/source"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestDB_Options(t *testing.T) {
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	const selectSQL = "SELECT `identifier`, `title` FROM `cms_page` ORDER BY `title`"
+
+	src := source.NewDB(dbc.SelectFrom("cms_page").AddColumns("identifier", "title").OrderBy("title"), source.DBOptions{})
+	ctx := context.Background()
+
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(selectSQL)).
+		WillReturnRows(sqlmock.NewRows([]string{"identifier", "title"}).AddRow("home", "Home Page").AddRow("no-route", nil))
+
+	want := source.Slice{
+		{Value: "home", Label: "Home Page"},
+		{Value: "no-route", Label: ""},
+	}
+	opts, err := src.Options(ctx)
+	assert.NoError(t, err)
+	assert.Exactly(t, want, opts)
+
+	opts, err = src.Options(ctx) // from cache, no query
+	assert.NoError(t, err)
+	assert.Exactly(t, want, opts)
+
+	src.Invalidate()
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(selectSQL)).
+		WillReturnError(errors.ConnectionFailed.Newf("DB gone"))
+	opts, err = src.Options(ctx)
+	assert.Nil(t, opts)
+	assert.True(t, errors.ConnectionFailed.Match(errors.Cause(err)), "%+v", err)
+}
+
+func TestDB_Options_TTL(t *testing.T) {
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	const selectSQL = "SELECT `customer_group_id`, `customer_group_code` FROM `customer_group`"
+
+	src := source.NewDB(dbc.SelectFrom("customer_group").AddColumns("customer_group_id", "customer_group_code"), source.DBOptions{
+		TTL: time.Millisecond * 20,
+	})
+	ctx := context.Background()
+
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(selectSQL)).
+		WillReturnRows(sqlmock.NewRows([]string{"customer_group_id", "customer_group_code"}).AddRow("0", "NOT LOGGED IN"))
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(selectSQL)).
+		WillReturnRows(sqlmock.NewRows([]string{"customer_group_id", "customer_group_code"}).AddRow("0", "NOT LOGGED IN").AddRow("1", "General"))
+
+	opts, err := src.Options(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, opts, 1)
+
+	time.Sleep(time.Millisecond * 30)
+	opts, err = src.Options(ctx)
+	assert.NoError(t, err)
+	assert.Exactly(t, []string{"0", "1"}, opts.Values())
+}
@@ -0,0 +1,59 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package source provides the options of select and multiselect fields of the
+// configuration element structure. The options can be static or loaded lazily
+// from the database.
+package source
+
+import "context"
+
+// Pair contains a value which gets stored in the configuration and its label
+// to display.
+type Pair struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// Slice contains the options of a select field in sort order.
+type Slice []Pair
+
+// Options implements Provider and returns a static list.
+func (s Slice) Options(_ context.Context) (Slice, error) {
+	return s, nil
+}
+
+// Contains returns true if the value is one of the options.
+func (s Slice) Contains(value string) bool {
+	for _, p := range s {
+		if p.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Values returns all option values in sort order.
+func (s Slice) Values() []string {
+	ret := make([]string, len(s))
+	for i, p := range s {
+		ret[i] = p.Value
+	}
+	return ret
+}
+
+// Provider returns the options of a field.
+type Provider interface {
+	Options(ctx context.Context) (Slice, error)
+}
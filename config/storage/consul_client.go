@@ -0,0 +1,231 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build csall consul
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+	"github.com/corestoreio/pkg/config"
+	"github.com/corestoreio/pkg/util/bufferpool"
+	"github.com/hashicorp/consul/api"
+)
+
+// ConsulDefaultKeyPrefix defines the global key prefix, which can be
+// overwritten.
+const ConsulDefaultKeyPrefix = "csv3/"
+
+// ConsulOptions applies optional settings to the Consul KV storage.
+type ConsulOptions struct {
+	RequestTimeout time.Duration
+	// KeyPrefix defines a global key prefix used for all keys
+	KeyPrefix string
+	// WaitTime defines the maximum duration of a blocking query in
+	// WatchConsul. Zero uses the default of the Consul server.
+	WaitTime time.Duration
+}
+
+func (o *ConsulOptions) setDefaults() {
+	if o.KeyPrefix == "" {
+		o.KeyPrefix = ConsulDefaultKeyPrefix
+	}
+}
+
+func (o ConsulOptions) queryOptions(ctx context.Context) (context.Context, context.CancelFunc, *api.QueryOptions) {
+	cancel := func() {}
+	if o.RequestTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.RequestTimeout)
+	}
+	return ctx, cancel, (&api.QueryOptions{}).WithContext(ctx)
+}
+
+// ConsulKV defines the functions of the Consul key/value store used by the
+// storage. Type *api.KV implements this interface.
+type ConsulKV interface {
+	Get(key string, q *api.QueryOptions) (*api.KVPair, *api.QueryMeta, error)
+	Put(p *api.KVPair, q *api.WriteOptions) (*api.WriteMeta, error)
+	List(prefix string, q *api.QueryOptions) (api.KVPairs, *api.QueryMeta, error)
+}
+
+// consulClient implements interface config.Storager.
+type consulClient struct {
+	options ConsulOptions
+	kv      ConsulKV
+}
+
+// NewConsulClient creates a new storage client with either a concrete or a
+// mocked object of the Consul KV store. The keys are the fully qualified paths
+// prefixed with ConsulOptions.KeyPrefix.
+//		client, err := api.NewClient(api.DefaultConfig())
+//		// handle error
+//		s, err := storage.NewConsulClient(client.KV(), storage.ConsulOptions{})
+func NewConsulClient(kv ConsulKV, o ConsulOptions) (config.Storager, error) {
+	o.setDefaults()
+	return &consulClient{
+		options: o,
+		kv:      kv,
+	}, nil
+}
+
+func (s *consulClient) toKey(p *config.Path) (string, error) {
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	buf.WriteString(s.options.KeyPrefix)
+	err := p.AppendFQ(buf)
+	return buf.String(), err
+}
+
+// Set puts a key to the Consul KV store.
+func (s *consulClient) Set(p *config.Path, value []byte) error {
+	key, err := s.toKey(p)
+	if err != nil {
+		return errors.Wrapf(err, "[storage/consul] toKey with key %q", key)
+	}
+
+	ctx, cancel, _ := s.options.queryOptions(context.Background())
+	defer cancel()
+
+	if _, err = s.kv.Put(&api.KVPair{Key: key, Value: value}, (&api.WriteOptions{}).WithContext(ctx)); err != nil {
+		return errors.Wrapf(err, "[storage/consul] Put failed with key %q", key)
+	}
+	return nil
+}
+
+// Get returns a value from the Consul KV store.
+func (s *consulClient) Get(p *config.Path) (v []byte, found bool, err error) {
+	key, err := s.toKey(p)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "[storage/consul] toKey with key %q", key)
+	}
+
+	_, cancel, qo := s.options.queryOptions(context.Background())
+	defer cancel()
+
+	pair, _, err := s.kv.Get(key, qo)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "[storage/consul] Get with key %q", key)
+	}
+	if pair == nil {
+		return nil, false, nil
+	}
+	return pair.Value, true, nil
+}
+
+// consulParseKey strips the key prefix and parses the remaining fully qualified
+// path.
+func consulParseKey(p *config.Path, key, keyPrefix string) error {
+	if err := p.Parse(strings.TrimPrefix(key, keyPrefix)); err != nil {
+		return errors.Wrapf(err, "[storage/consul] With key %q", key)
+	}
+	return nil
+}
+
+// WithLoadFromConsul reads all keys and their values with the current or
+// configured key prefix and applies it to the config.Service. This function
+// option can be set when creating a new config.Service or updating its internal
+// DB.
+func WithLoadFromConsul(kv ConsulKV, o ConsulOptions) config.LoadDataOption {
+	o.setDefaults()
+	return config.MakeLoadDataOption(func(s *config.Service) error {
+		_, cancel, qo := o.queryOptions(context.Background())
+		defer cancel()
+
+		pairs, _, err := kv.List(o.KeyPrefix, qo)
+		if err != nil {
+			return errors.Wrapf(err, "[storage/consul] List with key prefix %q", o.KeyPrefix)
+		}
+		p := new(config.Path)
+		for _, pair := range pairs {
+			if err := consulParseKey(p, pair.Key, o.KeyPrefix); err != nil {
+				return errors.WithStack(err)
+			}
+			if err := s.Set(p, pair.Value); err != nil {
+				return errors.Wrapf(err, "[storage/consul] With Path %q", p.String())
+			}
+			p.Reset()
+		}
+		return nil
+	}).WithUseStorageLevel(1)
+}
+
+// WatchConsul watches all keys with the current or configured key prefix via
+// blocking queries and writes each changed value via config.Service.Set into
+// the storage of the Service. Hence the Service triggers its observers and
+// sends the changed path to its subscribers, if PubSub has been enabled. The
+// first query only records the current state, use WithLoadFromConsul to load
+// the initial values. The Service must use a local storage and not
+// NewConsulClient or each change would be written back to Consul. Deleted keys
+// get ignored because the config.Storager cannot delete. Errors while setting
+// a value get logged and do not stop watching. WatchConsul blocks until the
+// context gets canceled, which returns nil, or a query fails. A response
+// without query meta data returns a NotValid error.
+func WatchConsul(ctx context.Context, srv *config.Service, kv ConsulKV, o ConsulOptions) error {
+	o.setDefaults()
+
+	var lastIndex uint64
+	first := true
+	modified := make(map[string]uint64)
+	p := new(config.Path)
+	for {
+		qo := (&api.QueryOptions{
+			WaitIndex: lastIndex,
+			WaitTime:  o.WaitTime,
+		}).WithContext(ctx)
+
+		pairs, meta, err := kv.List(o.KeyPrefix, qo)
+		if ctx.Err() == context.Canceled {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "[storage/consul] Watch with key prefix %q", o.KeyPrefix)
+		}
+		// Without the index each following query would return immediately.
+		if meta == nil {
+			return errors.NotValid.Newf("[storage/consul] Watch with key prefix %q: response contains no query meta data", o.KeyPrefix)
+		}
+
+		for _, pair := range pairs {
+			prev, ok := modified[pair.Key]
+			modified[pair.Key] = pair.ModifyIndex
+			if first || (ok && prev == pair.ModifyIndex) {
+				continue
+			}
+			err := consulParseKey(p, pair.Key, o.KeyPrefix)
+			if err == nil {
+				err = srv.Set(p, pair.Value)
+			}
+			if err != nil && srv.Log != nil && srv.Log.IsInfo() {
+				srv.Log.Info("config.storage.WatchConsul.Set", log.String("key", pair.Key), log.Err(err))
+			}
+			p.Reset()
+		}
+
+		first = false
+
+		// The index must be reset if it goes backwards, see the Consul
+		// documentation of blocking queries.
+		if meta.LastIndex < lastIndex {
+			lastIndex = 0
+		} else {
+			lastIndex = meta.LastIndex
+		}
+	}
+}
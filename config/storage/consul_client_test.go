@@ -0,0 +1,207 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build csall consul
+
+package storage
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config"
+	"github.com/corestoreio/pkg/store/scope"
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/hashicorp/consul/api"
+)
+
+var _ ConsulKV = (*api.KV)(nil)
+
+type consulFakeKV struct {
+	mu    sync.Mutex
+	pairs map[string]*api.KVPair
+	// listFn overwrites List if set.
+	listFn func(prefix string, q *api.QueryOptions) (api.KVPairs, *api.QueryMeta, error)
+}
+
+func newConsulFakeKV() *consulFakeKV {
+	return &consulFakeKV{pairs: make(map[string]*api.KVPair)}
+}
+
+func (kv *consulFakeKV) Get(key string, q *api.QueryOptions) (*api.KVPair, *api.QueryMeta, error) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	return kv.pairs[key], &api.QueryMeta{}, nil
+}
+
+func (kv *consulFakeKV) Put(p *api.KVPair, q *api.WriteOptions) (*api.WriteMeta, error) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	kv.pairs[p.Key] = p
+	return &api.WriteMeta{}, nil
+}
+
+func (kv *consulFakeKV) List(prefix string, q *api.QueryOptions) (api.KVPairs, *api.QueryMeta, error) {
+	if kv.listFn != nil {
+		return kv.listFn(prefix, q)
+	}
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	var ret api.KVPairs
+	for k, p := range kv.pairs {
+		if strings.HasPrefix(k, prefix) {
+			ret = append(ret, p)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Key < ret[j].Key })
+	return ret, &api.QueryMeta{}, nil
+}
+
+type consulPathReceiver chan config.Path
+
+func (pr consulPathReceiver) MessageConfig(p config.Path) error {
+	pr <- p
+	return nil
+}
+
+func TestConsulClient_SetGet(t *testing.T) {
+	kv := newConsulFakeKV()
+	s, err := NewConsulClient(kv, ConsulOptions{})
+	assert.NoError(t, err)
+
+	p := config.MustNewPathWithScope(scope.Website.WithID(3), "path/to/orion")
+	assert.NoError(t, s.Set(p, []byte(`You should turn it to eleven.`)))
+	assert.NotNil(t, kv.pairs[ConsulDefaultKeyPrefix+"websites/3/path/to/orion"])
+
+	v, found, err := s.Get(p)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Exactly(t, []byte(`You should turn it to eleven.`), v)
+
+	v, found, err = s.Get(p.BindStore(4))
+	assert.NoError(t, err)
+	assert.False(t, found)
+	assert.Nil(t, v)
+}
+
+func TestWithLoadFromConsul(t *testing.T) {
+	kv := newConsulFakeKV()
+	for key, val := range map[string]string{
+		ConsulDefaultKeyPrefix + `websites/2/payment/datatr/sha1`: `fc9d6fd2d8db223be4a7484a8619f26b`,
+		ConsulDefaultKeyPrefix + `stores/1/payment/datatr/sha1`:   `46aaccbebf47d8f8fce8c02d621aa573`,
+		ConsulDefaultKeyPrefix + `default/0/payment/datatr/sha1`:  `e30d8df9810bc36105c96ad3ae76ffd3`,
+	} {
+		kv.pairs[key] = &api.KVPair{Key: key, Value: []byte(val)}
+	}
+
+	cfgSrv, err := config.NewService(NewMap(), config.Options{}, WithLoadFromConsul(kv, ConsulOptions{}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	p := config.MustNewPathWithScope(scope.Website.WithID(2), "payment/datatr/sha1")
+	assert.Exactly(t, `"fc9d6fd2d8db223be4a7484a8619f26b"`, cfgSrv.Get(p).String())
+	assert.Exactly(t, `"46aaccbebf47d8f8fce8c02d621aa573"`, cfgSrv.Get(p.BindStore(1)).String())
+	assert.Exactly(t, `"e30d8df9810bc36105c96ad3ae76ffd3"`, cfgSrv.Get(p.BindDefault()).String())
+
+	t.Run("malformed key", func(t *testing.T) {
+		kv := newConsulFakeKV()
+		kv.pairs[ConsulDefaultKeyPrefix+"sha1"] = &api.KVPair{Key: ConsulDefaultKeyPrefix + "sha1"}
+		cfgSrv, err := config.NewService(NewMap(), config.Options{}, WithLoadFromConsul(kv, ConsulOptions{}))
+		assert.Nil(t, cfgSrv)
+		assert.Contains(t, err.Error(), "[storage/consul] With key")
+	})
+}
+
+func TestWatchConsul(t *testing.T) {
+	cfgSrv, err := config.NewService(NewMap(), config.Options{EnablePubSub: true})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer func() { assert.NoError(t, cfgSrv.Close()) }()
+
+	received := make(consulPathReceiver, 2)
+	_, err = cfgSrv.Subscribe("websites/2/payment/datatr", received)
+	assert.NoError(t, err)
+	_, err = cfgSrv.Subscribe("stores/1/payment/datatr", received)
+	assert.NoError(t, err)
+
+	const keyWS2 = ConsulDefaultKeyPrefix + `websites/2/payment/datatr/sha1`
+	const keyST1 = ConsulDefaultKeyPrefix + `stores/1/payment/datatr/sha1`
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var waitIndexes []uint64
+	responses := []api.KVPairs{
+		{ // initial state gets only recorded
+			{Key: keyWS2, Value: []byte(`v1`), ModifyIndex: 5},
+		},
+		{ // new key and a changed key
+			{Key: keyWS2, Value: []byte(`v2`), ModifyIndex: 7},
+			{Key: keyST1, Value: []byte(`s1`), ModifyIndex: 6},
+		},
+		{ // unchanged
+			{Key: keyWS2, Value: []byte(`v2`), ModifyIndex: 7},
+			{Key: keyST1, Value: []byte(`s1`), ModifyIndex: 6},
+		},
+	}
+	kv := newConsulFakeKV()
+	kv.listFn = func(prefix string, q *api.QueryOptions) (api.KVPairs, *api.QueryMeta, error) {
+		assert.Exactly(t, ConsulDefaultKeyPrefix, prefix)
+		waitIndexes = append(waitIndexes, q.WaitIndex)
+		i := len(waitIndexes) - 1
+		if i == len(responses) {
+			cancel()
+			return nil, nil, context.Canceled
+		}
+		return responses[i], &api.QueryMeta{LastIndex: uint64(10 + i)}, nil
+	}
+
+	assert.NoError(t, WatchConsul(ctx, cfgSrv, kv, ConsulOptions{}))
+	assert.Exactly(t, []uint64{0, 10, 11, 12}, waitIndexes)
+
+	p := config.MustNewPathWithScope(scope.Website.WithID(2), "payment/datatr/sha1")
+	assert.Exactly(t, `"v2"`, cfgSrv.Get(p).String())
+	assert.Exactly(t, `"s1"`, cfgSrv.Get(p.BindStore(1)).String())
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-received:
+		case <-time.After(time.Second):
+			t.Fatal("Subscriber has not been notified")
+		}
+	}
+
+	t.Run("error", func(t *testing.T) {
+		kv := newConsulFakeKV()
+		kv.listFn = func(prefix string, q *api.QueryOptions) (api.KVPairs, *api.QueryMeta, error) {
+			return nil, nil, errors.ConnectionFailed.Newf("Consul unreachable")
+		}
+		err := WatchConsul(context.Background(), cfgSrv, kv, ConsulOptions{})
+		assert.Contains(t, err.Error(), "Consul unreachable")
+	})
+	t.Run("missing query meta", func(t *testing.T) {
+		kv := newConsulFakeKV()
+		kv.listFn = func(prefix string, q *api.QueryOptions) (api.KVPairs, *api.QueryMeta, error) {
+			return nil, nil, nil
+		}
+		err := WatchConsul(context.Background(), cfgSrv, kv, ConsulOptions{})
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+}
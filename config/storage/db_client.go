@@ -18,6 +18,7 @@ package storage
 
 import (
 	"context"
+	"database/sql"
 	"sync"
 	"time"
 
@@ -117,6 +118,8 @@ type dbStats struct {
 // interface config.Storager.
 type DB struct {
 	cfg DBOptions
+	// db gets used to start transactions in SetBatch.
+	db dml.QueryExecPreparer
 
 	sqlRead  *dml.Select
 	sqlWrite *dml.Insert
@@ -190,6 +193,7 @@ func NewDB(tbls *ddl.Tables, o DBOptions) (*DB, error) {
 
 	dbs := &DB{
 		cfg:              o,
+		db:               tbl.DB,
 		tickerDaemonStop: make(chan struct{}),
 		sqlRead:          qryRead,
 		sqlWrite:         qryWrite,
@@ -323,6 +327,57 @@ func (dbs *DB) Set(p *config.Path, value []byte) error {
 	return err
 }
 
+// SetBatch implements config.BatchSetter and writes all values within a single
+// database transaction. On error the transaction gets rolled back and none of
+// the values have been written. The underlying database connection must be
+// able to start a transaction, like *sql.DB.
+func (dbs *DB) SetBatch(pvs []config.PathValue) (err error) {
+	txb, ok := dbs.db.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (*sql.Tx, error)
+	})
+	if !ok {
+		return errors.NotSupported.Newf("[config/storage] DB.SetBatch: Type %T cannot start a transaction", dbs.db)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbs.cfg.ContextTimeoutWrite)
+	defer cancel()
+
+	tx, err := txb.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err == nil {
+			err = errors.WithStack(tx.Commit())
+			return
+		}
+		if errR := tx.Rollback(); errR != nil && dbs.cfg.Log != nil && dbs.cfg.Log.IsInfo() {
+			dbs.cfg.Log.Info("config.storage.DB.SetBatch.Rollback", log.Err(errR), log.ErrWithKey("cause", err))
+		}
+	}()
+
+	stmt, err := dbs.sqlWrite.Clone().WithDB(tx).Prepare(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if errC := stmt.Close(); errC != nil && err == nil {
+			err = errors.WithStack(errC)
+		}
+	}()
+
+	a := stmt.WithArgs()
+	for _, pv := range pvs {
+		scp, path := pv.Path.ScopeRoute()
+		s, id := scp.Unpack()
+		if _, err = a.String(s.StrType()).Int64(id).String(path).Bytes(pv.Value).ExecContext(ctx); err != nil {
+			return errors.Wrapf(err, "[config/storage] DB.SetBatch Scope %q Path %q", scp.String(), path)
+		}
+		a.Reset()
+	}
+	return nil
+}
+
 // Get performs a read operation from the database and returns a value from
 // the table. The `ok` return argument can be true even if byte slice `v` is
 // nil, which means that the path and scope are stored in the database table.
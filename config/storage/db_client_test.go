@@ -269,6 +269,69 @@ func TestService_Set(t *testing.T) {
 	})
 }
 
+func TestDB_SetBatch(t *testing.T) {
+	defer leaktest.CheckTimeout(t, time.Second)()
+
+	const insertSQL = "INSERT INTO `core_config_data` (`scope`,`scope_id`,`path`,`value`) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE `value`=VALUES(`value`)"
+
+	pvs := make([]config.PathValue, 0, len(serviceMultiTests))
+	for _, test := range serviceMultiTests {
+		pvs = append(pvs, config.PathValue{
+			Path:  config.MustNewPathWithScope(test.scopeID, test.path),
+			Value: test.value,
+		})
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbs, err := storage.NewDB(storage.NewTableCollection(dbc.DB), storage.DBOptions{
+			SkipSchemaValidation: true,
+		})
+		assert.NoError(t, err)
+		defer dmltest.Close(t, dbs)
+
+		dbMock.ExpectBegin()
+		prepIns := dbMock.ExpectPrepare(dmltest.SQLMockQuoteMeta(insertSQL))
+		for i, test := range serviceMultiTests {
+			scp, sID := test.scopeID.Unpack()
+			prepIns.ExpectExec().
+				WithArgs(scp.StrType(), sID, test.path, test.value).
+				WillReturnResult(sqlmock.NewResult(int64(i+1), 1))
+		}
+		dbMock.ExpectCommit()
+
+		assert.NoError(t, dbs.SetBatch(pvs))
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbs, err := storage.NewDB(storage.NewTableCollection(dbc.DB), storage.DBOptions{
+			SkipSchemaValidation: true,
+		})
+		assert.NoError(t, err)
+		defer dmltest.Close(t, dbs)
+
+		dbMock.ExpectBegin()
+		prepIns := dbMock.ExpectPrepare(dmltest.SQLMockQuoteMeta(insertSQL))
+		scp, sID := serviceMultiTests[0].scopeID.Unpack()
+		prepIns.ExpectExec().
+			WithArgs(scp.StrType(), sID, serviceMultiTests[0].path, serviceMultiTests[0].value).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		scp, sID = serviceMultiTests[1].scopeID.Unpack()
+		prepIns.ExpectExec().
+			WithArgs(scp.StrType(), sID, serviceMultiTests[1].path, serviceMultiTests[1].value).
+			WillReturnError(errors.AlreadyClosed.Newf("Connection gone"))
+		dbMock.ExpectRollback()
+
+		err = dbs.SetBatch(pvs)
+		assert.True(t, errors.AlreadyClosed.Match(errors.Cause(err)), "%+v", err)
+	})
+}
+
 // Test_WithApplyCoreConfigData reads from the MySQL core_config_data table and applies
 // these value to the underlying storage. tries to get back the values from the
 // underlying storage
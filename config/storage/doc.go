@@ -17,6 +17,8 @@
 //
 // Use Go build tags to enable special storage clients or file format loading
 // functions. Supported tags are: bigcache (store in big cache), db (store in
-// MySQL/MariaDB), etcdv3 (store in etcd cluster/server), load from json and
-// yaml.
+// MySQL/MariaDB), etcdv3 (store in etcd cluster/server), consul (store in
+// Consul KV), load from json, yaml and toml, fsnotify (reload the
+// config.Service once files change). The etcdv3 and consul backends can watch
+// their keys and push changes to the subscribers of the config.Service.
 package storage
@@ -44,9 +44,13 @@ var (
 // in case of an error.
 //	scope.DefaultTypeID, etc/credentials/user_name => CONFIG__ETCD__CREDENTIALS__USER_NAME
 func ToEnvVar(p *config.Path) string {
+	return toEnvVar(Prefix, p)
+}
+
+func toEnvVar(prefix string, p *config.Path) string {
 	buf := bufferpool.Get()
 	defer bufferpool.Put(buf)
-	buf.WriteString(Prefix)
+	buf.WriteString(prefix)
 
 	if err := p.AppendFQ(buf); err != nil {
 		return ""
@@ -136,3 +140,59 @@ func WithLoadEnvironmentVariables(op EnvOp) config.LoadDataOption {
 		return
 	}).WithUseStorageLevel(1)
 }
+
+// envOverlay implements config.Storager.
+type envOverlay struct {
+	prefix string
+	config.Storager
+}
+
+// NewEnvOverlay wraps a storage and resolves for each Get call first the
+// environment variable of the path, for example
+//		CONFIG__WEBSITES__1__WEB__CORS__ALLOW_CREDENTIALS -> websites/1/web/cors/allow_credentials
+// before falling back to the wrapped storage. In contrast to
+// WithLoadEnvironmentVariables changed environment variables get applied
+// without reloading, which suits 12-factor deployments and container
+// overrides. Set writes into the wrapped storage; the environment variable
+// still wins. A nil storage reads only the environment variables, for example
+// as a layer of NewLayered, and Set returns a NotSupported error.
+func NewEnvOverlay(s config.Storager, op EnvOp) config.Storager {
+	if op.Prefix == "" {
+		op.Prefix = Prefix
+	}
+	return &envOverlay{
+		prefix:   op.Prefix,
+		Storager: s,
+	}
+}
+
+// Get returns the value of the environment variable, if set, otherwise the
+// value from the wrapped storage.
+func (eo *envOverlay) Get(p *config.Path) (v []byte, found bool, err error) {
+	if key := toEnvVar(eo.prefix, p); key != "" {
+		if val, ok := os.LookupEnv(key); ok {
+			return []byte(val), true, nil
+		}
+	}
+	if eo.Storager == nil {
+		return nil, false, nil
+	}
+	v, found, err = eo.Storager.Get(p)
+	return v, found, errors.WithStack(err)
+}
+
+// Set writes into the wrapped storage.
+func (eo *envOverlay) Set(p *config.Path, value []byte) error {
+	if eo.Storager == nil {
+		return errors.NotSupported.Newf("[config/storage] Environment variables are read only: %q", p.String())
+	}
+	return errors.WithStack(eo.Storager.Set(p, value))
+}
+
+// Flush flushes the wrapped storage, if supported.
+func (eo *envOverlay) Flush() error {
+	if f, ok := eo.Storager.(interface{ Flush() error }); ok {
+		return errors.WithStack(f.Flush())
+	}
+	return nil
+}
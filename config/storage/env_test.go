@@ -114,3 +114,34 @@ func TestWithLoadEnvironmentVariables(t *testing.T) {
 		assert.EqualError(t, err, "[config] Expecting: `aa/bb/cc` or `strScope/ID/aa/bb/cc` but got \"bb/cc\"`")
 	})
 }
+
+func TestNewEnvOverlay(t *testing.T) {
+	p := config.MustNewPathWithScope(scope.Website.WithID(1), "web/cors/allow_credentials")
+	p2 := config.MustNewPath("web/cors/max_age")
+
+	cfgSrv, err := config.NewService(storage.NewEnvOverlay(storage.NewMap(), storage.EnvOp{}), config.Options{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	assert.NoError(t, cfgSrv.Set(p, []byte(`0`)))
+	assert.NoError(t, cfgSrv.Set(p2, []byte(`3600`)))
+	assert.Exactly(t, `"0"`, cfgSrv.Get(p).String())
+
+	assert.NoError(t, os.Setenv("CONFIG__WEBSITES__1__WEB__CORS__ALLOW_CREDENTIALS", "1"))
+	assert.Exactly(t, `"1"`, cfgSrv.Get(p).String())
+	assert.Exactly(t, `"3600"`, cfgSrv.Get(p2).String(), "Falls back to the wrapped storage")
+
+	assert.NoError(t, os.Unsetenv("CONFIG__WEBSITES__1__WEB__CORS__ALLOW_CREDENTIALS"))
+	assert.Exactly(t, `"0"`, cfgSrv.Get(p).String())
+
+	t.Run("custom prefix", func(t *testing.T) {
+		s := storage.NewEnvOverlay(storage.NewMap(), storage.EnvOp{Prefix: "MYSHOP__"})
+		assert.NoError(t, os.Setenv("MYSHOP__WEB__CORS__MAX_AGE", "60"))
+		defer func() { assert.NoError(t, os.Unsetenv("MYSHOP__WEB__CORS__MAX_AGE")) }()
+
+		v, found, err := s.Get(p2)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Exactly(t, []byte(`60`), v)
+	})
+}
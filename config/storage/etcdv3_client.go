@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
 	"github.com/corestoreio/pkg/config"
 	"github.com/corestoreio/pkg/util/bufferpool"
 	"go.etcd.io/etcd/clientv3"
@@ -202,21 +203,77 @@ func WithLoadFromEtcdv3(c clientv3.KV, o Etcdv3Options) config.LoadDataOption {
 			return errors.WithStack(err)
 		}
 		p := new(config.Path)
-		var buf strings.Builder
 		for _, ev := range resp.Kvs {
-			buf.Write(ev.Key)
-
-			if err := p.Parse(buf.String()); err != nil {
-				return errors.Wrapf(err, "[storage/etcdv3] With Path %q", p.String())
+			if err := etcdv3ParseKey(p, ev.Key, o.KeyPrefix); err != nil {
+				return errors.WithStack(err)
 			}
-
 			if err := s.Set(p, ev.Value); err != nil {
 				return errors.Wrapf(err, "[storage/etcdv3] With Path %q", p.String())
 			}
-			buf.Reset()
 			p.Reset()
 		}
 
 		return nil
 	}).WithUseStorageLevel(1)
 }
+
+// etcdv3ParseKey strips the key prefix and parses the remaining fully qualified
+// path.
+func etcdv3ParseKey(p *config.Path, key []byte, keyPrefix string) error {
+	fq := strings.TrimPrefix(string(key), keyPrefix)
+	if err := p.Parse(fq); err != nil {
+		return errors.Wrapf(err, "[storage/etcdv3] With key %q", key)
+	}
+	return nil
+}
+
+// Etcdv3Watcher defines the function of clientv3.Watcher used by WatchEtcdv3.
+// Type *clientv3.Client implements this interface.
+type Etcdv3Watcher interface {
+	Watch(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan
+}
+
+// WatchEtcdv3 watches all keys with the current or configured etcd key prefix
+// and writes each changed value via config.Service.Set into the storage of the
+// Service. Hence the Service triggers its observers and sends the changed path
+// to its subscribers, if PubSub has been enabled. This enables centralized
+// runtime configuration across many instances. The Service must use a local
+// storage, usually loaded with WithLoadFromEtcdv3, and not NewEtcdv3Client or
+// each change would be written back to etcd. Deleted keys get ignored because
+// the config.Storager cannot delete. Errors while setting a value get logged
+// and do not stop watching. WatchEtcdv3 blocks until the context gets
+// canceled, which returns nil, or the watch fails.
+//		go func() {
+//			if err := storage.WatchEtcdv3(ctx, srv, etcdClient, storage.Etcdv3Options{}); err != nil {
+//				// handle error
+//			}
+//		}()
+func WatchEtcdv3(ctx context.Context, srv *config.Service, w Etcdv3Watcher, o Etcdv3Options) error {
+	if o.KeyPrefix == "" {
+		o.KeyPrefix = Etcdv3DefaultKeyPrefix
+	}
+
+	p := new(config.Path)
+	for resp := range w.Watch(ctx, o.KeyPrefix, clientv3.WithPrefix()) {
+		if err := resp.Err(); err != nil {
+			return errors.Wrapf(err, "[storage/etcdv3] Watch with key prefix %q", o.KeyPrefix)
+		}
+		for _, ev := range resp.Events {
+			if ev.Type != clientv3.EventTypePut {
+				continue
+			}
+			err := etcdv3ParseKey(p, ev.Kv.Key, o.KeyPrefix)
+			if err == nil {
+				err = srv.Set(p, ev.Kv.Value)
+			}
+			if err != nil && srv.Log != nil && srv.Log.IsInfo() {
+				srv.Log.Info("config.storage.WatchEtcdv3.Set", log.String("key", string(ev.Kv.Key)), log.Err(err))
+			}
+			p.Reset()
+		}
+	}
+	if err := ctx.Err(); err != nil && err != context.Canceled {
+		return errors.WithStack(err)
+	}
+	return nil
+}
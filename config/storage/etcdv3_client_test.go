@@ -218,3 +218,60 @@ func TestWithLoadData_Success(t *testing.T) {
 	assert.Exactly(t, `"e30d8df9810bc36105c96ad3ae76ffd3"`, cfgSrv.Get(p.BindDefault()).String())
 
 }
+
+type etcdv3FakeWatcher chan clientv3.WatchResponse
+
+func (w etcdv3FakeWatcher) Watch(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan {
+	return clientv3.WatchChan(w)
+}
+
+type etcdv3PathReceiver chan config.Path
+
+func (pr etcdv3PathReceiver) MessageConfig(p config.Path) error {
+	pr <- p
+	return nil
+}
+
+func TestWatchEtcdv3(t *testing.T) {
+	cfgSrv, err := config.NewService(NewMap(), config.Options{EnablePubSub: true})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer func() { assert.NoError(t, cfgSrv.Close()) }()
+
+	p := config.MustNewPathWithScope(scope.Website.WithID(2), "payment/datatr/sha1")
+	received := make(etcdv3PathReceiver, 1)
+	_, err = cfgSrv.Subscribe("websites/2/payment/datatr", received)
+	assert.NoError(t, err)
+
+	watchC := make(etcdv3FakeWatcher, 3)
+	watchC <- clientv3.WatchResponse{
+		Events: []*clientv3.Event{
+			{
+				Type: clientv3.EventTypePut,
+				Kv: &mvccpb.KeyValue{
+					Key:   []byte(Etcdv3DefaultKeyPrefix + `websites/2/payment/datatr/sha1`),
+					Value: []byte(`fc9d6fd2d8db223be4a7484a8619f26b`),
+				},
+			},
+			{
+				Type: clientv3.EventTypeDelete,
+				Kv: &mvccpb.KeyValue{
+					Key: []byte(Etcdv3DefaultKeyPrefix + `stores/1/payment/datatr/sha1`),
+				},
+			},
+		},
+	}
+	close(watchC)
+
+	assert.NoError(t, WatchEtcdv3(context.Background(), cfgSrv, watchC, Etcdv3Options{}))
+	assert.Exactly(t, `"fc9d6fd2d8db223be4a7484a8619f26b"`, cfgSrv.Get(p).String())
+	assert.False(t, cfgSrv.Get(p.BindStore(1)).IsValid())
+
+	select {
+	case rp := <-received:
+		assert.Exactly(t, p.String(), rp.String())
+	case <-time.After(time.Second):
+		t.Fatal("Subscriber has not been notified")
+	}
+}
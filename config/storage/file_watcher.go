@@ -0,0 +1,210 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build csall fsnotify
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+	"github.com/corestoreio/pkg/config"
+	"github.com/fsnotify/fsnotify"
+)
+
+// FileWatcherOptions applies optional settings to NewFileWatcher.
+type FileWatcherOptions struct {
+	// Debounce defines the duration to wait after the last file system event
+	// before the config.Service gets reloaded. Editors and deployment tools
+	// write files in several steps. Defaults to 100ms.
+	Debounce time.Duration
+	// OnReload gets called after each reload with the error of
+	// config.Service.Reload. Optional.
+	OnReload func(error)
+}
+
+// FileWatcher reloads a config.Service once a watched configuration file or
+// directory changes. It complements the hot reload via OS signals, see
+// config.Options.EnableHotReload. Safe for concurrent use.
+type FileWatcher struct {
+	srv     *config.Service
+	opts    FileWatcherOptions
+	watcher *fsnotify.Watcher
+	// files contains the watched files and dirs the recursively watched
+	// directories. Events of other files in the parent directory of a watched
+	// file get ignored.
+	files map[string]bool
+	dirs  []string
+	wg    sync.WaitGroup
+}
+
+// NewFileWatcher watches the files and directories for changes and calls
+// config.Service.Reload, which runs again all LoadDataOption functions, for
+// example the ones created by WithLoadYAML. Directories get watched
+// recursively, including sub directories created later. The parent directory of a file gets watched to detect files
+// which get replaced atomically by a rename. If a path contains the variable
+// from constant EnvNamePlaceHolder it gets replaced with the current
+// environment name. Close must be called to terminate the internal goroutine.
+//		fw, err := storage.NewFileWatcher(srv, storage.FileWatcherOptions{},
+//			"/var/www/site/config/{CS_ENV}/payment.yaml",
+//			"/etc/corestore/conf.d",
+//		)
+func NewFileWatcher(s *config.Service, o FileWatcherOptions, paths ...string) (*FileWatcher, error) {
+	if o.Debounce == 0 {
+		o.Debounce = 100 * time.Millisecond
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fw := &FileWatcher{
+		srv:     s,
+		opts:    o,
+		watcher: w,
+		files:   make(map[string]bool),
+	}
+	if err := fw.add(paths...); err != nil {
+		_ = w.Close()
+		return nil, errors.WithStack(err)
+	}
+	fw.wg.Add(1)
+	go fw.watch()
+	return fw, nil
+}
+
+func (fw *FileWatcher) add(paths ...string) error {
+	for _, p := range paths {
+		p = filepath.Clean(fw.srv.ReplaceEnvName(p))
+		fi, err := os.Stat(p)
+		if err != nil {
+			return errors.NotFound.New(err, "[config/storage] FileWatcher path %q", p)
+		}
+		if !fi.IsDir() {
+			fw.files[p] = true
+			if err := fw.watcher.Add(filepath.Dir(p)); err != nil {
+				return errors.Wrapf(err, "[config/storage] FileWatcher path %q", p)
+			}
+			continue
+		}
+		fw.dirs = append(fw.dirs, p+string(filepath.Separator))
+		if err := fw.addDir(p); err != nil {
+			return errors.Wrapf(err, "[config/storage] FileWatcher directory %q", p)
+		}
+	}
+	return nil
+}
+
+// addDir watches the directory and all its sub directories.
+func (fw *FileWatcher) addDir(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return err
+		}
+		return fw.watcher.Add(path)
+	})
+}
+
+func (fw *FileWatcher) isWatched(ev fsnotify.Event) bool {
+	if ev.Op == fsnotify.Chmod {
+		return false
+	}
+	name := filepath.Clean(ev.Name)
+	if fw.files[name] {
+		return true
+	}
+	for _, dir := range fw.dirs {
+		if strings.HasPrefix(name, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+func (fw *FileWatcher) watch() {
+	defer fw.wg.Done()
+
+	var reloadC <-chan time.Time
+	var timer *time.Timer
+	errC := fw.watcher.Errors
+	for {
+		select {
+		case ev, ok := <-fw.watcher.Events:
+			if !ok {
+				if timer != nil {
+					timer.Stop()
+				}
+				return
+			}
+			if !fw.isWatched(ev) {
+				continue
+			}
+			if fw.srv.Log != nil && fw.srv.Log.IsDebug() {
+				fw.srv.Log.Debug("config.storage.FileWatcher.Event", log.String("file", ev.Name), log.Stringer("op", ev.Op))
+			}
+			if ev.Op&fsnotify.Create == fsnotify.Create {
+				// A new sub directory of a watched directory must be watched
+				// too. Files created in it before the watch has been added get
+				// loaded by the upcoming reload.
+				if fi, err := os.Stat(ev.Name); err == nil && fi.IsDir() {
+					if err := fw.addDir(ev.Name); err != nil && fw.srv.Log != nil && fw.srv.Log.IsInfo() {
+						fw.srv.Log.Info("config.storage.FileWatcher.AddDir", log.String("dir", ev.Name), log.Err(err))
+					}
+				}
+			}
+			if timer == nil {
+				timer = time.NewTimer(fw.opts.Debounce)
+				reloadC = timer.C
+			} else {
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(fw.opts.Debounce)
+			}
+
+		case <-reloadC:
+			err := fw.srv.Reload()
+			if fw.srv.Log != nil && fw.srv.Log.IsInfo() && err != nil {
+				fw.srv.Log.Info("config.storage.FileWatcher.Reload", log.Err(err))
+			}
+			if fw.opts.OnReload != nil {
+				fw.opts.OnReload(err)
+			}
+
+		case err, ok := <-errC:
+			if !ok {
+				errC = nil
+				continue
+			}
+			if fw.srv.Log != nil && fw.srv.Log.IsInfo() {
+				fw.srv.Log.Info("config.storage.FileWatcher.Error", log.Err(err))
+			}
+		}
+	}
+}
+
+// Close stops watching the files and terminates the internal goroutine.
+func (fw *FileWatcher) Close() error {
+	err := fw.watcher.Close()
+	fw.wg.Wait()
+	return errors.WithStack(err)
+}
@@ -0,0 +1,128 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build csall fsnotify
+
+package storage_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config"
+	"github.com/corestoreio/pkg/config/storage"
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/fortytw2/leaktest"
+)
+
+func TestNewFileWatcher(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	dir, err := ioutil.TempDir("", "cs_file_watcher")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "gateway.txt")
+	assert.NoError(t, ioutil.WriteFile(file, []byte("v1"), 0644))
+
+	p := config.MustNewPath("payment/stripe/gateway")
+	srv := config.MustNewService(storage.NewMap(), config.Options{},
+		config.MakeLoadDataOption(func(s *config.Service) error {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			return s.Set(p, data)
+		}),
+	)
+	defer func() { assert.NoError(t, srv.Close()) }()
+	assert.Exactly(t, `"v1"`, srv.Get(p).String())
+
+	reloaded := make(chan error, 10)
+	fw, err := storage.NewFileWatcher(srv, storage.FileWatcherOptions{
+		Debounce: 10 * time.Millisecond,
+		OnReload: func(err error) { reloaded <- err },
+	}, file)
+	assert.NoError(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(file, []byte("v2"), 0644))
+
+	select {
+	case err := <-reloaded:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout waiting for the reload")
+	}
+	assert.Exactly(t, `"v2"`, srv.Get(p).String())
+
+	assert.NoError(t, fw.Close())
+
+	t.Run("new sub directory", func(t *testing.T) {
+		subFile := filepath.Join(dir, "sub", "gateway.txt")
+		pSub := config.MustNewPath("payment/stripe/sub_gateway")
+		srv := config.MustNewService(storage.NewMap(), config.Options{},
+			config.MakeLoadDataOption(func(s *config.Service) error {
+				data, err := ioutil.ReadFile(subFile)
+				if os.IsNotExist(err) {
+					return nil
+				}
+				if err != nil {
+					return errors.WithStack(err)
+				}
+				return s.Set(pSub, data)
+			}),
+		)
+		defer func() { assert.NoError(t, srv.Close()) }()
+
+		reloaded := make(chan error, 10)
+		fw, err := storage.NewFileWatcher(srv, storage.FileWatcherOptions{
+			Debounce: 10 * time.Millisecond,
+			OnReload: func(err error) { reloaded <- err },
+		}, dir)
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, fw.Close()) }()
+
+		waitReload := func() {
+			select {
+			case err := <-reloaded:
+				assert.NoError(t, err)
+			case <-time.After(5 * time.Second):
+				t.Fatal("Timeout waiting for the reload")
+			}
+		}
+
+		assert.NoError(t, os.Mkdir(filepath.Dir(subFile), 0755))
+		waitReload()
+		assert.NoError(t, ioutil.WriteFile(subFile, []byte("sub1"), 0644))
+		waitReload()
+		// The write event of the file in the new directory must trigger a
+		// reload too.
+		assert.NoError(t, ioutil.WriteFile(subFile, []byte("sub2"), 0644))
+		waitReload()
+		for len(reloaded) > 0 {
+			waitReload()
+		}
+		assert.Exactly(t, `"sub2"`, srv.Get(pSub).String())
+	})
+
+	t.Run("path not found", func(t *testing.T) {
+		fw, err := storage.NewFileWatcher(srv, storage.FileWatcherOptions{}, filepath.Join(dir, "missing.yaml"))
+		assert.Nil(t, fw)
+		assert.True(t, errors.NotFound.Match(err), "%+v", err)
+	})
+}
@@ -22,7 +22,6 @@ import (
 
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/pkg/config"
-	"github.com/corestoreio/pkg/util/conv"
 )
 
 // WithLoadJSON reads the configuration values from a JSON file and applies it
@@ -47,51 +46,5 @@ func loadJSON(s config.Setter, r io.Reader) error {
 		return errors.WithStack(err)
 	}
 
-	for route, v1 := range jd {
-		k2, ok := v1.(map[string]interface{})
-		if !ok {
-			return errors.CorruptData.Newf("[cfgfile] WithLoadJSON Unexpected data in %#v", v1)
-		}
-
-		for scp, v2 := range k2 {
-
-			var p = new(config.Path)
-			switch v2t := v2.(type) {
-			case map[string]interface{}:
-				for scpID, dataIF := range v2t {
-
-					data, err := conv.ToByteE(dataIF)
-					if err != nil {
-						return errors.CorruptData.New(err, "[cfgfile] WithLoadJSON failed to convert %v into a byte slice for path: %q %q %q", dataIF, route, scp, scpID)
-					}
-
-					if err := p.ParseStrings(scp, scpID, route); err != nil {
-						return errors.CorruptData.New(err, "[cfgfile] WithLoadJSON failed to create path: %q %q %q", route, scp, scpID)
-					}
-
-					if err := s.Set(p, data); err != nil {
-						return errors.Fatal.New(err, "[cfgfile] WithLoadJSON.Service.Set failed with %q", p.String())
-					}
-				}
-
-			case string, int, float64, bool:
-				data, err := conv.ToByteE(v2t)
-				if err != nil {
-					return errors.CorruptData.New(err, "[cfgfile] WithLoadJSON failed to convert %v into a byte slice for path: %q %q", v2t, route, scp)
-				}
-
-				if err := p.ParseStrings(scp, "0", route); err != nil {
-					return errors.CorruptData.New(err, "[cfgfile] WithLoadJSON failed to create path: %q %q", scp, route)
-				}
-
-				if err := s.Set(p, data); err != nil {
-					return errors.Fatal.New(err, "[cfgfile] WithLoadJSON.Service.Set failed with %q", p.String())
-				}
-
-			default:
-				return errors.CorruptData.Newf("[cfgfile] WithLoadJSON unexpected data in %#v", v2)
-			}
-		}
-	}
-	return nil
+	return setRouteScopeValues(s, jd, "WithLoadJSON")
 }
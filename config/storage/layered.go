@@ -0,0 +1,143 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config"
+)
+
+// Layer names a storage within a layered storage.
+type Layer struct {
+	Name string
+	config.Storager
+}
+
+// LayeredOptions provides options for function NewLayered.
+type LayeredOptions struct {
+	// WriteLayer names the layer which receives all Set calls. Defaults to
+	// the first layer.
+	WriteLayer string
+}
+
+// layered implements config.Storager and config.Explainer.
+type layered struct {
+	layers []Layer
+	write  config.Storager
+}
+
+// NewLayered stacks multiple storages with a defined precedence. The first
+// layer has the highest precedence: Get returns the value of the first layer
+// which contains the path. In contrast to MakeMulti, Set writes only into one
+// layer. The defaults from the element structure (config.FieldMeta) apply
+// after all layers. The Explain function of config.Service reports each layer,
+// to debug where a value comes from.
+//		s, err := storage.NewLayered(storage.LayeredOptions{WriteLayer: "db"},
+//			storage.Layer{Name: "env", Storager: storage.NewEnvOverlay(nil, storage.EnvOp{})},
+//			storage.Layer{Name: "db", Storager: dbStorage},
+//			storage.Layer{Name: "file", Storager: fileStorage},
+//		)
+func NewLayered(o LayeredOptions, layers ...Layer) (config.Storager, error) {
+	if len(layers) == 0 {
+		return nil, errors.Empty.Newf("[config/storage] NewLayered requires at least one layer")
+	}
+	l := &layered{
+		layers: layers,
+	}
+	names := make(map[string]bool, len(layers))
+	for _, lay := range layers {
+		if lay.Name == "" || lay.Storager == nil {
+			return nil, errors.Empty.Newf("[config/storage] NewLayered: Layer name and storage cannot be empty: %q", lay.Name)
+		}
+		if names[lay.Name] {
+			return nil, errors.AlreadyExists.Newf("[config/storage] NewLayered: Layer %q already exists", lay.Name)
+		}
+		names[lay.Name] = true
+		if lay.Name == o.WriteLayer {
+			l.write = lay.Storager
+		}
+	}
+	switch {
+	case o.WriteLayer == "":
+		l.write = layers[0].Storager
+	case l.write == nil:
+		return nil, errors.NotFound.Newf("[config/storage] NewLayered: WriteLayer %q not found", o.WriteLayer)
+	}
+	return l, nil
+}
+
+// Set writes into the WriteLayer.
+func (l *layered) Set(p *config.Path, value []byte) error {
+	return errors.WithStack(l.write.Set(p, value))
+}
+
+// SetBatch writes all values into the WriteLayer, if it implements
+// config.BatchSetter.
+func (l *layered) SetBatch(pvs []config.PathValue) error {
+	bs, ok := l.write.(config.BatchSetter)
+	if !ok {
+		return errors.NotSupported.Newf("[config/storage] Layered: WriteLayer %T does not implement config.BatchSetter", l.write)
+	}
+	return errors.WithStack(bs.SetBatch(pvs))
+}
+
+// Delete removes the path from the WriteLayer, if it implements
+// config.Deleter.
+func (l *layered) Delete(p *config.Path) error {
+	d, ok := l.write.(config.Deleter)
+	if !ok {
+		return errors.NotSupported.Newf("[config/storage] Layered: WriteLayer %T does not implement config.Deleter", l.write)
+	}
+	return errors.WithStack(d.Delete(p))
+}
+
+// Get returns the value of the first layer which contains the path.
+func (l *layered) Get(p *config.Path) (v []byte, found bool, err error) {
+	for _, lay := range l.layers {
+		v, found, err = lay.Get(p)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "[config/storage] Layer %q with path %q", lay.Name, p.String())
+		}
+		if found {
+			return v, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// Explain returns the value of each layer in the order of precedence.
+func (l *layered) Explain(p *config.Path) ([]config.LayerValue, error) {
+	lvs := make([]config.LayerValue, 0, len(l.layers))
+	for _, lay := range l.layers {
+		v, found, err := lay.Get(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[config/storage] Layer %q with path %q", lay.Name, p.String())
+		}
+		lvs = append(lvs, config.LayerValue{Layer: lay.Name, Data: v, Found: found})
+	}
+	return lvs, nil
+}
+
+// Flush flushes all layers which support it.
+func (l *layered) Flush() error {
+	for _, lay := range l.layers {
+		if f, ok := lay.Storager.(interface{ Flush() error }); ok {
+			if err := f.Flush(); err != nil {
+				return errors.Wrapf(err, "[config/storage] Layer %q", lay.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config"
+	"github.com/corestoreio/pkg/config/storage"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestNewLayered(t *testing.T) {
+	p := config.MustNewPath("web/cors/max_age").BindWebsite(1)
+
+	fileLayer := storage.NewMap()
+	assert.NoError(t, fileLayer.Set(p, []byte(`60`)))
+	dbLayer := storage.NewMap()
+
+	s, err := storage.NewLayered(storage.LayeredOptions{WriteLayer: "db"},
+		storage.Layer{Name: "env", Storager: storage.NewEnvOverlay(nil, storage.EnvOp{})},
+		storage.Layer{Name: "db", Storager: dbLayer},
+		storage.Layer{Name: "file", Storager: fileLayer},
+	)
+	assert.NoError(t, err)
+
+	srv, err := config.NewService(s, config.Options{},
+		config.WithFieldMeta(&config.FieldMeta{
+			Route:   "web/cors/max_age",
+			Default: "30",
+		}),
+	)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	assert.Exactly(t, `"60"`, srv.Get(p).String())
+
+	assert.NoError(t, srv.Set(p, []byte(`120`)))
+	v, found, err := dbLayer.Get(p)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Exactly(t, []byte(`120`), v, "Set writes into the WriteLayer")
+	assert.Exactly(t, `"120"`, srv.Get(p).String())
+
+	assert.NoError(t, os.Setenv("CONFIG__WEBSITES__1__WEB__CORS__MAX_AGE", "240"))
+	defer func() { assert.NoError(t, os.Unsetenv("CONFIG__WEBSITES__1__WEB__CORS__MAX_AGE")) }()
+	assert.Exactly(t, `"240"`, srv.Get(p).String())
+
+	e, err := srv.Explain(p)
+	assert.NoError(t, err)
+	assert.Exactly(t, "env", e.Source)
+	assert.Exactly(t, "env: \"240\" <- source\ndb: \"120\"\nfile: \"60\"\ndefaults: \"30\"\n", e.String())
+
+	e, err = srv.Explain(p.BindDefault())
+	assert.NoError(t, err)
+	assert.Exactly(t, config.LayerDefaults, e.Source)
+	assert.Exactly(t, "env: not found\ndb: not found\nfile: not found\ndefaults: \"30\" <- source\n", e.String())
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := storage.NewLayered(storage.LayeredOptions{})
+		assert.True(t, errors.Empty.Match(err), "%+v", err)
+
+		_, err = storage.NewLayered(storage.LayeredOptions{},
+			storage.Layer{Name: "db", Storager: storage.NewMap()},
+			storage.Layer{Name: "db", Storager: storage.NewMap()},
+		)
+		assert.True(t, errors.AlreadyExists.Match(err), "%+v", err)
+
+		_, err = storage.NewLayered(storage.LayeredOptions{WriteLayer: "file"},
+			storage.Layer{Name: "db", Storager: storage.NewMap()},
+		)
+		assert.True(t, errors.NotFound.Match(err), "%+v", err)
+
+		envOnly := storage.NewEnvOverlay(nil, storage.EnvOp{})
+		err = envOnly.Set(p, []byte(`1`))
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	})
+}
@@ -58,6 +58,25 @@ func (sp *kvmap) Set(p *config.Path, value []byte) error {
 	return nil
 }
 
+// SetBatch implements config.BatchSetter interface and writes all values
+// under one lock.
+func (sp *kvmap) SetBatch(pvs []config.PathValue) error {
+	sp.Lock()
+	for _, pv := range pvs {
+		sp.kv[makeCacheKey(pv.Path.ScopeRoute())] = string(pv.Value)
+	}
+	sp.Unlock()
+	return nil
+}
+
+// Delete implements config.Deleter interface.
+func (sp *kvmap) Delete(p *config.Path) error {
+	sp.Lock()
+	delete(sp.kv, makeCacheKey(p.ScopeRoute()))
+	sp.Unlock()
+	return nil
+}
+
 // Get implements Storager interface and returns a byte slice available for
 // modifications.
 func (sp *kvmap) Get(p *config.Path) (v []byte, found bool, err error) {
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// +build csall json yaml
+// +build csall json yaml toml
 
 package storage
 
@@ -20,10 +20,12 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/log"
 	"github.com/corestoreio/pkg/config"
+	"github.com/corestoreio/pkg/util/conv"
 )
 
 type option func(*config.Service, func(config.Setter, io.Reader) error) error
@@ -46,6 +48,61 @@ func processFile(file string, s *config.Service, cb func(config.Setter, io.Reade
 	return
 }
 
+// setRouteScopeValues writes the decoded nested map of a configuration file
+// into the Setter. The first level contains the routes, the second level the
+// scope names and the third level the scope IDs with their values. A scalar
+// value on the second level applies to scope ID zero. funcName gets used in
+// error messages.
+func setRouteScopeValues(s config.Setter, routes map[string]interface{}, funcName string) error {
+	for route, v1 := range routes {
+		k2, ok := v1.(map[string]interface{})
+		if !ok {
+			return errors.CorruptData.Newf("[cfgfile] %s Unexpected data in %#v", funcName, v1)
+		}
+
+		for scp, v2 := range k2 {
+
+			var p = new(config.Path)
+			switch v2t := v2.(type) {
+			case map[string]interface{}:
+				for scpID, dataIF := range v2t {
+
+					data, err := conv.ToByteE(dataIF)
+					if err != nil {
+						return errors.CorruptData.New(err, "[cfgfile] %s failed to convert %v into a byte slice for path: %q %q %q", funcName, dataIF, route, scp, scpID)
+					}
+
+					if err := p.ParseStrings(scp, scpID, route); err != nil {
+						return errors.CorruptData.New(err, "[cfgfile] %s failed to create path: %q %q %q", funcName, route, scp, scpID)
+					}
+
+					if err := s.Set(p, data); err != nil {
+						return errors.Fatal.New(err, "[cfgfile] %s.Service.Set failed with %q", funcName, p.String())
+					}
+				}
+
+			case string, int, int64, float64, bool:
+				data, err := conv.ToByteE(v2t)
+				if err != nil {
+					return errors.CorruptData.New(err, "[cfgfile] %s failed to convert %v into a byte slice for path: %q %q", funcName, v2t, route, scp)
+				}
+
+				if err := p.ParseStrings(scp, "0", route); err != nil {
+					return errors.CorruptData.New(err, "[cfgfile] %s failed to create path: %q %q", funcName, scp, route)
+				}
+
+				if err := s.Set(p, data); err != nil {
+					return errors.Fatal.New(err, "[cfgfile] %s.Service.Set failed with %q", funcName, p.String())
+				}
+
+			default:
+				return errors.CorruptData.Newf("[cfgfile] %s unexpected data in %#v", funcName, v2)
+			}
+		}
+	}
+	return nil
+}
+
 // If someone needs it, uncomment and add a test
 //func WithIOReader(r io.Reader) option {
 //	return func(s *config.Service, cb func(config.Setter, io.Reader) error) error {
@@ -104,3 +161,37 @@ func WithFile(filePath ...string) option {
 		return errors.WithStack(err)
 	}
 }
+
+// WithIncludeDirs loads recursively all files with the file name extension
+// `ext`, for example ".yaml", found in the directories. The files of a
+// directory get loaded in lexical order, so a file "20_payment.yaml" overwrites
+// the values of "10_defaults.yaml". If a directory contains the variable from
+// constant EnvNamePlaceHolder it gets replaced with the current environment
+// name. A missing directory returns a NotFound error.
+//		WithIncludeDirs(".yaml", "/etc/corestore/conf.d", "/var/www/site/config/{CS_ENV}")
+func WithIncludeDirs(ext string, dirs ...string) option {
+	return func(s *config.Service, cb func(config.Setter, io.Reader) error) error {
+		for _, dir := range dirs {
+			dir = s.ReplaceEnvName(dir)
+			err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+				if err != nil {
+					return errors.NotFound.New(err, "[config/storage] WithIncludeDirs")
+				}
+				if info.IsDir() || !strings.EqualFold(filepath.Ext(path), ext) {
+					return nil
+				}
+				if s.Log != nil && s.Log.IsDebug() {
+					s.Log.Debug("config.storage.WithIncludeDirs", log.String("dir", dir), log.String("file", path))
+				}
+				if err := processFile(path, s, cb); err != nil {
+					return errors.Wrapf(err, "[config/storage] WithIncludeDirs for file %q", path)
+				}
+				return nil
+			})
+			if err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		return nil
+	}
+}
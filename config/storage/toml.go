@@ -0,0 +1,46 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build csall toml
+
+package storage
+
+import (
+	"io"
+
+	"github.com/BurntSushi/toml"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config"
+)
+
+// WithLoadTOML reads the configuration values from a TOML file and applies it
+// to the config.service. "testdata/example.toml" provides an example TOML file.
+// The routes must be quoted table names because they contain slashes. Loads all
+// data into RAM before processing it.
+func WithLoadTOML(opts ...option) config.LoadDataOption {
+	return config.MakeLoadDataOption(func(s *config.Service) (err error) {
+		for i := 0; i < len(opts) && err == nil; i++ {
+			err = opts[i](s, loadTOML)
+		}
+		return
+	}).WithUseStorageLevel(1)
+}
+
+func loadTOML(s config.Setter, r io.Reader) error {
+	td := make(map[string]interface{})
+	if _, err := toml.DecodeReader(r, &td); err != nil {
+		return errors.WithStack(err)
+	}
+	return setRouteScopeValues(s, td, "WithLoadTOML")
+}
@@ -0,0 +1,69 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build csall toml
+
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config"
+	"github.com/corestoreio/pkg/config/storage"
+	"github.com/corestoreio/pkg/store/scope"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestWithLoadTOML(t *testing.T) {
+	pUserName := config.MustNewPath("payment/stripe/user_name")
+	pPort := config.MustNewPath("payment/stripe/port")
+
+	t.Run("success", func(t *testing.T) {
+		cfgSrv, err := config.NewService(
+			storage.NewMap(), config.Options{},
+			storage.WithLoadTOML(storage.WithFile("testdata", "example.toml")),
+		)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+
+		assert.Exactly(t, `"AUserName"`, cfgSrv.Get(pUserName).String())
+		assert.Exactly(t, `"WS1Username"`, cfgSrv.Get(pUserName.BindWebsite(1)).String())
+		assert.Exactly(t, `"SO11Username"`, cfgSrv.Get(pUserName.BindStore(11)).String())
+		assert.Exactly(t, `"1234"`, cfgSrv.Get(pPort).String())
+		assert.Exactly(t, `"true"`, cfgSrv.Get(config.MustNewPathWithScope(scope.Website.WithID(0), "payment/stripe/enable")).String())
+	})
+
+	t.Run("include dirs", func(t *testing.T) {
+		cfgSrv, err := config.NewService(
+			storage.NewMap(), config.Options{},
+			storage.WithLoadTOML(storage.WithIncludeDirs(".toml", "testdata/conf.d")),
+		)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		assert.Exactly(t, `"AUserName"`, cfgSrv.Get(pUserName).String())
+		assert.Exactly(t, `"4321"`, cfgSrv.Get(pPort).String())
+	})
+
+	t.Run("include dir not found", func(t *testing.T) {
+		cfgSrv, err := config.NewService(
+			storage.NewMap(), config.Options{},
+			storage.WithLoadTOML(storage.WithIncludeDirs(".toml", "testdata/conf.x")),
+		)
+		assert.Nil(t, cfgSrv)
+		assert.True(t, errors.NotFound.Match(err), "%+v", err)
+	})
+}
@@ -21,11 +21,14 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
 
 	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/storage/null"
 	"github.com/corestoreio/pkg/util/byteconv"
 )
 
@@ -153,6 +156,85 @@ func (v *Value) Strs(ret ...string) (_ []string, err error) {
 	return ret, nil
 }
 
+// StrMap parses multi-line `key=value` data, as entered in a textarea field,
+// into a map. Keys and values get trimmed. Empty lines and lines starting with
+// `#` get skipped. Each entry gets passed to the optional validators, for
+// example to check header names or redirect targets. Returns an error with
+// kind NotValid for lines without a key or without a `=` and kind Duplicated
+// if a key appears twice.
+func (v *Value) StrMap(validators ...func(key, value string) error) (_ map[string]string, err error) {
+	if _, err = v.init(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if v.data == nil {
+		return nil, nil
+	}
+	lines := strings.Split(string(v.data), "\n")
+	ret := make(map[string]string, len(lines))
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		pos := strings.IndexByte(line, '=')
+		if pos < 0 {
+			return nil, errors.NotValid.Newf("[config] Value.StrMap line %d %q does not contain a \"=\"", i+1, line)
+		}
+		key, val := strings.TrimSpace(line[:pos]), strings.TrimSpace(line[pos+1:])
+		if key == "" {
+			return nil, errors.NotValid.Newf("[config] Value.StrMap line %d %q has an empty key", i+1, line)
+		}
+		if _, ok := ret[key]; ok {
+			return nil, errors.Duplicated.Newf("[config] Value.StrMap line %d contains duplicate key %q", i+1, key)
+		}
+		for _, fn := range validators {
+			if err := fn(key, val); err != nil {
+				return nil, errors.Wrapf(err, "[config] Value.StrMap line %d with key %q", i+1, key)
+			}
+		}
+		ret[key] = val
+	}
+	return ret, nil
+}
+
+// JoinStrs joins the strings with separator sep, which defaults to
+// CSVColumnSeparator if zero. It is the counterpart of Value.Strs to write a
+// slice into the Service. Returns an error with kind NotValid if a string
+// contains the separator.
+func JoinStrs(sep rune, strs ...string) ([]byte, error) {
+	if sep == 0 {
+		sep = CSVColumnSeparator
+	}
+	var buf bytes.Buffer
+	for i, s := range strs {
+		if strings.ContainsRune(s, sep) {
+			return nil, errors.NotValid.Newf("[config] JoinStrs entry %q at index %d contains the separator %q", s, i, sep)
+		}
+		if i > 0 {
+			buf.WriteRune(sep)
+		}
+		buf.WriteString(s)
+	}
+	return buf.Bytes(), nil
+}
+
+// JoinInts joins the integers with separator sep, which defaults to
+// CSVColumnSeparator if zero. It is the counterpart of Value.Ints to write a
+// slice into the Service.
+func JoinInts(sep rune, ints ...int) []byte {
+	if sep == 0 {
+		sep = CSVColumnSeparator
+	}
+	buf := make([]byte, 0, len(ints)*4)
+	for i, v := range ints {
+		if i > 0 {
+			buf = append(buf, string(sep)...)
+		}
+		buf = strconv.AppendInt(buf, int64(v), 10)
+	}
+	return buf
+}
+
 // CSV reads a multiline CSV data value and appends it to ret.
 func (v *Value) CSV(ret ...[]string) (_ [][]string, err error) {
 	if _, err = v.init(); err != nil {
@@ -534,6 +616,122 @@ func (v *Value) Duration() (d time.Duration, ok bool, err error) {
 	return
 }
 
+// UnsafeDecimal same as Decimal but ignores all errors.
+func (v *Value) UnsafeDecimal() (d null.Decimal) {
+	d, _, _ = v.Decimal()
+	return
+}
+
+// Decimal parses the underlying data into a decimal without losing precision,
+// as required for prices and fees. Returns an error with kind NotValid if the
+// data is not a number.
+func (v *Value) Decimal() (d null.Decimal, ok bool, err error) {
+	if ok, err = v.init(); err != nil || !ok {
+		return d, false, errors.WithStack(err)
+	}
+	if v.IsEmpty() {
+		return d, false, nil
+	}
+	// MakeDecimalBytes modifies the slice, so pass a copy.
+	if d, err = null.MakeDecimalBytes(append([]byte(nil), bytes.TrimSpace(v.data)...)); err != nil {
+		return null.Decimal{}, false, errors.NotValid.New(err, "[config] Value.Decimal with path %q", v.Path.String())
+	}
+	return d, d.Valid, nil
+}
+
+// UnsafeURL same as URL but ignores all errors.
+func (v *Value) UnsafeURL() (u *url.URL) {
+	u, _, _ = v.URL()
+	return
+}
+
+// URL parses the underlying data into an absolute URL. The URL must contain a
+// scheme and a host, otherwise returns an error with kind NotValid.
+func (v *Value) URL() (u *url.URL, ok bool, err error) {
+	if ok, err = v.init(); err != nil || !ok {
+		return nil, false, errors.WithStack(err)
+	}
+	if v.IsEmpty() {
+		return nil, false, nil
+	}
+	if u, err = url.Parse(string(v.data)); err != nil {
+		return nil, false, errors.NotValid.New(err, "[config] Value.URL with path %q", v.Path.String())
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, false, errors.NotValid.Newf("[config] Value.URL with path %q requires a scheme and a host: %q", v.Path.String(), v.data)
+	}
+	return u, true, nil
+}
+
+// UnsafeByteSize same as ByteSize but ignores all errors.
+func (v *Value) UnsafeByteSize() (size uint64) {
+	size, _, _ = v.ByteSize()
+	return
+}
+
+// ByteSize parses a human readable size like "512", "64K", "1.5MB" or "2GiB"
+// into the number of bytes. The units are case insensitive and use the power
+// of 1024: B, K/KB/KiB, M/MB/MiB, G/GB/GiB, T/TB/TiB.
+func (v *Value) ByteSize() (size uint64, ok bool, err error) {
+	if ok, err = v.init(); err != nil || !ok {
+		return 0, false, errors.WithStack(err)
+	}
+	if v.IsEmpty() {
+		return 0, false, nil
+	}
+	if size, err = parseByteSize(string(v.data)); err != nil {
+		return 0, false, errors.NotValid.New(err, "[config] Value.ByteSize with path %q", v.Path.String())
+	}
+	return size, true, nil
+}
+
+func parseByteSize(str string) (uint64, error) {
+	str = strings.TrimSpace(str)
+	i := strings.IndexFunc(str, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(str)
+	}
+	num, unit := str[:i], strings.ToUpper(strings.TrimSpace(str[i:]))
+
+	var shift uint
+	switch unit {
+	case "", "B":
+		shift = 0
+	case "K", "KB", "KIB":
+		shift = 10
+	case "M", "MB", "MIB":
+		shift = 20
+	case "G", "GB", "GIB":
+		shift = 30
+	case "T", "TB", "TIB":
+		shift = 40
+	default:
+		return 0, errors.NotValid.Newf("[config] Unknown byte size unit %q", unit)
+	}
+
+	if strings.IndexByte(num, '.') < 0 {
+		n, err := strconv.ParseUint(num, 10, 64)
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		if n > (1<<64-1)>>shift {
+			return 0, errors.Overflowed.Newf("[config] Byte size %q overflows uint64", str)
+		}
+		return n << shift, nil
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	f *= float64(uint64(1) << shift)
+	if f >= 1<<64 {
+		return 0, errors.Overflowed.Newf("[config] Byte size %q overflows uint64", str)
+	}
+	return uint64(f), nil
+}
+
 // IsEqual does a constant time comparison of the underlying data with the input
 // data `d`. Useful for passwords. Only equal when no error occurs.
 func (v *Value) IsEqual(d []byte) bool {
@@ -489,6 +489,74 @@ func TestValue(t *testing.T) {
 		assert.Exactly(t, "0s", val.String())
 	})
 
+	t.Run("Decimal", func(t *testing.T) {
+		val, ok, err := NewValue([]byte(`-1234.5600`)).Decimal()
+		assert.True(t, ok)
+		assert.NoError(t, err)
+		assert.Exactly(t, "-1234.5600", val.String())
+
+		val, ok, err = NewValue([]byte(`12,50`)).Decimal()
+		assert.False(t, ok)
+		assert.False(t, val.Valid)
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+
+		val, ok, err = NewValue(nil).Decimal()
+		assert.False(t, ok)
+		assert.NoError(t, err)
+	})
+
+	t.Run("URL", func(t *testing.T) {
+		v := NewValue([]byte(`https://www.corestore.io:8443/checkout?a=b`))
+		val, ok, err := v.URL()
+		assert.True(t, ok)
+		assert.NoError(t, err)
+		assert.Exactly(t, "www.corestore.io:8443", val.Host)
+		assert.Exactly(t, "/checkout", val.Path)
+
+		val, ok, err = NewValue([]byte(`/checkout`)).URL()
+		assert.Nil(t, val)
+		assert.False(t, ok)
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+
+		val, ok, err = NewValue([]byte("http://a b.com/\x7f")).URL()
+		assert.Nil(t, val)
+		assert.False(t, ok)
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+
+		val, ok, err = NewValue(nil).URL()
+		assert.Nil(t, val)
+		assert.False(t, ok)
+		assert.NoError(t, err)
+	})
+
+	t.Run("ByteSize", func(t *testing.T) {
+		tests := []struct {
+			have string
+			want uint64
+		}{
+			{"512", 512},
+			{"512B", 512},
+			{"64k", 64 << 10},
+			{"64 KB", 64 << 10},
+			{"1.5MB", 3 << 19},
+			{"2GiB", 2 << 30},
+			{"1t", 1 << 40},
+		}
+		for _, test := range tests {
+			val, ok, err := NewValue([]byte(test.have)).ByteSize()
+			assert.True(t, ok, "%q", test.have)
+			assert.NoError(t, err, "%q", test.have)
+			assert.Exactly(t, test.want, val, "%q", test.have)
+		}
+
+		for _, have := range []string{"12PB", "MB", "1.2.3K", "99999999999999T"} {
+			val, ok, err := NewValue([]byte(have)).ByteSize()
+			assert.Exactly(t, uint64(0), val, "%q", have)
+			assert.False(t, ok, "%q", have)
+			assert.True(t, errors.NotValid.Match(err), "%q: %+v", have, err)
+		}
+	})
+
 	t.Run("IsEqual", func(t *testing.T) {
 		d := []byte(`5m2s`)
 		v := NewValue(d)
@@ -543,4 +611,96 @@ func TestValue_Unsafe(t *testing.T) {
 		assert.Exactly(t, time.Duration(0), NewValue(nil).UnsafeDuration())
 		assert.Exactly(t, time.Duration(0), NewValue([]byte(`A`)).UnsafeDuration())
 	})
+	t.Run("decimal", func(t *testing.T) {
+		assert.Exactly(t, "0.99", NewValue([]byte(`0.99`)).UnsafeDecimal().String())
+		assert.False(t, NewValue([]byte(`X`)).UnsafeDecimal().Valid)
+	})
+	t.Run("url", func(t *testing.T) {
+		assert.Exactly(t, "https://corestore.io", NewValue([]byte(`https://corestore.io`)).UnsafeURL().String())
+		assert.Nil(t, NewValue([]byte(`corestore`)).UnsafeURL())
+		assert.Nil(t, NewValue(nil).UnsafeURL())
+	})
+	t.Run("byte size", func(t *testing.T) {
+		assert.Exactly(t, uint64(8<<20), NewValue([]byte(`8M`)).UnsafeByteSize())
+		assert.Exactly(t, uint64(0), NewValue([]byte(`8X`)).UnsafeByteSize())
+		assert.Exactly(t, uint64(0), NewValue(nil).UnsafeByteSize())
+	})
+}
+
+func TestJoinStrs(t *testing.T) {
+	t.Parallel()
+
+	data, err := JoinStrs(0, "SitUps", "AirSquats", "PushUps")
+	assert.NoError(t, err)
+	assert.Exactly(t, `SitUps,AirSquats,PushUps`, string(data))
+
+	data, err = JoinStrs('|', "SitUps", "Air,Squats")
+	assert.NoError(t, err)
+	v := NewValue(data)
+	v.CSVComma = '|'
+	val, err := v.Strs()
+	assert.NoError(t, err)
+	assert.Exactly(t, []string{"SitUps", "Air,Squats"}, val)
+
+	data, err = JoinStrs(0, "SitUps", "Air,Squats")
+	assert.Nil(t, data)
+	assert.True(t, errors.NotValid.Match(err), "%+v", err)
+}
+
+func TestJoinInts(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, `3,27182,-2115`, string(JoinInts(0, 3, 27182, -2115)))
+	assert.Exactly(t, ``, string(JoinInts(0)))
+
+	v := NewValue(JoinInts('€', 3, 27182, -2115))
+	v.CSVComma = '€'
+	val, err := v.Ints()
+	assert.NoError(t, err)
+	assert.Exactly(t, []int{3, 27182, -2115}, val)
+}
+
+func TestValue_StrMap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parse", func(t *testing.T) {
+		v := NewValue([]byte("# security headers\nX-Frame-Options = DENY\r\n\n  X-Content-Type-Options=nosniff\nLink=</a.css>; rel=preload\n"))
+		m, err := v.StrMap()
+		assert.NoError(t, err)
+		assert.Exactly(t, map[string]string{
+			"X-Frame-Options":        "DENY",
+			"X-Content-Type-Options": "nosniff",
+			"Link":                   "</a.css>; rel=preload",
+		}, m)
+	})
+	t.Run("nil", func(t *testing.T) {
+		m, err := NewValue(nil).StrMap()
+		assert.NoError(t, err)
+		assert.Nil(t, m)
+	})
+	t.Run("missing equal sign", func(t *testing.T) {
+		m, err := NewValue([]byte("a=b\nc")).StrMap()
+		assert.Nil(t, m)
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+	t.Run("empty key", func(t *testing.T) {
+		m, err := NewValue([]byte(" =b")).StrMap()
+		assert.Nil(t, m)
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+	t.Run("duplicate key", func(t *testing.T) {
+		m, err := NewValue([]byte("a=b\na=c")).StrMap()
+		assert.Nil(t, m)
+		assert.True(t, errors.Duplicated.Match(err), "%+v", err)
+	})
+	t.Run("validator", func(t *testing.T) {
+		m, err := NewValue([]byte("/old=/new\n/shop=https://shop")).StrMap(func(key, value string) error {
+			if !strings.HasPrefix(value, "/") {
+				return errors.NotValid.Newf("redirect target %q must be relative", value)
+			}
+			return nil
+		})
+		assert.Nil(t, m)
+		assert.EqualError(t, err, "[config] Value.StrMap line 2 with key \"/shop\": redirect target \"https://shop\" must be relative")
+	})
 }
@@ -285,6 +285,26 @@ func TestParseConnection_General(t *testing.T) {
 			"scheme": {"memcache"},
 		},
 		false))
+	t.Run("Redis Sentinel default with additional servers", runner(
+		"redis-sentinel://?server=localhost:26380&master=mymaster",
+		"localhost:26379",
+		"",
+		"",
+		map[string][]string{
+			"scheme": {"redis-sentinel"},
+			"server": {"localhost:26380"},
+			"master": {"mymaster"},
+		},
+		false))
+	t.Run("Redis Cluster default", runner(
+		"redis-cluster://10.0.0.1",
+		"10.0.0.1:6379",
+		"",
+		"",
+		map[string][]string{
+			"scheme": {"redis-cluster"},
+		},
+		false))
 	t.Run("GRPC no port", runner(
 		"grpc://192.123.432.232",
 		"",
@@ -0,0 +1,212 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/storage/null"
+)
+
+// BootstrapOptions configures the initial snapshot of Canal.Bootstrap.
+type BootstrapOptions struct {
+	// Tables to take the snapshot from. Required.
+	Tables []string
+	// BatchSize defines the number of rows passed at once to the handlers.
+	// Defaults to 1000.
+	BatchSize int
+	// SkipGlobalReadLock disables FLUSH TABLES WITH READ LOCK, which requires
+	// the RELOAD privilege. Without the lock, writes between starting the
+	// snapshot and reading the master position might be applied twice.
+	SkipGlobalReadLock bool
+}
+
+// Bootstrap takes a consistent snapshot of the tables and passes all rows as
+// InsertAction events to the registered handlers. Afterwards it sets the
+// master position to the position of the snapshot, so the following call to
+// Start continues seamlessly with the streaming of the binlog. Bootstrap must
+// be called before Start. The snapshot runs in one transaction on a dedicated
+// connection:
+//		FLUSH TABLES WITH READ LOCK
+//		START TRANSACTION WITH CONSISTENT SNAPSHOT
+//		SHOW MASTER STATUS
+//		UNLOCK TABLES
+//		SELECT ... FROM table
+//		COMMIT
+func (c *Canal) Bootstrap(ctx context.Context, o BootstrapOptions) (err error) {
+	if len(o.Tables) == 0 {
+		return errors.Empty.Newf("[binlogsync] Bootstrap requires at least one table")
+	}
+	if o.BatchSize < 1 {
+		o.BatchSize = 1000
+	}
+
+	tables := make([]*ddl.Table, len(o.Tables))
+	for i, tn := range o.Tables {
+		if tables[i], err = c.FindTable(ctx, tn); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	conn, err := c.dbcp.Conn(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if errC := conn.Close(); err == nil {
+			err = errors.WithStack(errC)
+		}
+	}()
+
+	exec := func(query string) error {
+		_, err := conn.DB.ExecContext(ctx, query)
+		return errors.Wrapf(err, "[binlogsync] Bootstrap query %q", query)
+	}
+
+	locked := false
+	defer func() {
+		if locked {
+			// Runs also when ctx has been canceled, otherwise the global read
+			// lock would block all writes on the server.
+			const unlock = "UNLOCK TABLES"
+			if _, errU := conn.DB.ExecContext(context.Background(), unlock); err == nil && errU != nil {
+				err = errors.Wrapf(errU, "[binlogsync] Bootstrap query %q", unlock)
+			}
+		}
+	}()
+	if !o.SkipGlobalReadLock {
+		if err := exec("FLUSH TABLES WITH READ LOCK"); err != nil {
+			return errors.WithStack(err)
+		}
+		locked = true
+	}
+	if err := exec("SET SESSION TRANSACTION ISOLATION LEVEL REPEATABLE READ"); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := exec("START TRANSACTION WITH CONSISTENT SNAPSHOT"); err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = exec("ROLLBACK")
+		}
+	}()
+
+	var ms ddl.MasterStatus
+	if _, err := conn.WithQueryBuilder(&ms).Load(ctx, &ms); err != nil {
+		return errors.WithStack(err)
+	}
+	if locked {
+		if err := exec("UNLOCK TABLES"); err != nil {
+			return errors.WithStack(err)
+		}
+		locked = false
+	}
+
+	for _, t := range tables {
+		if err := c.bootstrapTable(ctx, conn.DB, t, o.BatchSize); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if err := exec("COMMIT"); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if c.opts.Log.IsInfo() {
+		c.opts.Log.Info("[binlogsync] Bootstrap snapshot finished", log.Strings("tables", o.Tables...), log.Stringer("position", ms))
+	}
+
+	c.masterMu.Lock()
+	defer c.masterMu.Unlock()
+	c.masterStatus = ms
+	return errors.WithStack(c.masterFlush(ctx))
+}
+
+func (c *Canal) bootstrapTable(ctx context.Context, db *sql.Conn, t *ddl.Table, batchSize int) error {
+	query, _, err := dml.NewSelect(t.Columns.FieldNames()...).From(t.Name).ToSQL()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return errors.Wrapf(err, "[binlogsync] Bootstrap query %q", query)
+	}
+	defer rows.Close()
+
+	colLen := len(t.Columns)
+	batch := make([][]interface{}, 0, batchSize)
+	scanArgs := make([]interface{}, colLen)
+	for rows.Next() {
+		row := make([]interface{}, colLen)
+		for i := range row {
+			scanArgs[i] = &row[i]
+		}
+		if err := rows.Scan(scanArgs...); err != nil {
+			return errors.WithStack(err)
+		}
+		for i, col := range t.Columns {
+			if row[i], err = snapshotValue(col, row[i]); err != nil {
+				return errors.Wrapf(err, "[binlogsync] Bootstrap table %q column %q", t.Name, col.Field)
+			}
+		}
+		if batch = append(batch, row); len(batch) == batchSize {
+			if err := c.dispatchTxEvents(ctx, []TxEvent{{Action: InsertAction, Table: t, Rows: batch}}); err != nil {
+				return errors.WithStack(err)
+			}
+			batch = make([][]interface{}, 0, batchSize)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.WithStack(err)
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	return errors.WithStack(c.dispatchTxEvents(ctx, []TxEvent{{Action: InsertAction, Table: t, Rows: batch}}))
+}
+
+// snapshotValue converts the raw bytes of the text protocol into the types
+// created by the binlog decoder, so the handlers receive the same types for
+// snapshot and streamed rows.
+func snapshotValue(c *ddl.Column, v interface{}) (interface{}, error) {
+	b, ok := v.([]byte)
+	if !ok {
+		return v, nil // nil or already decoded
+	}
+	switch c.DataType {
+	case "tinyint", "smallint", "mediumint", "int", "bigint", "year":
+		if c.IsUnsigned() {
+			u, err := strconv.ParseUint(string(b), 10, 64)
+			return int64(u), errors.WithStack(err)
+		}
+		i, err := strconv.ParseInt(string(b), 10, 64)
+		return i, errors.WithStack(err)
+	case "float", "double":
+		f, err := strconv.ParseFloat(string(b), 64)
+		return f, errors.WithStack(err)
+	case "decimal":
+		d, err := null.MakeDecimalBytes(b)
+		return d, errors.WithStack(err)
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob", "bit", "geometry", "point":
+		return append([]byte(nil), b...), nil
+	}
+	return string(b), nil
+}
@@ -1,7 +1,6 @@
 package binlogsync
 
 import (
-	"bytes"
 	"context"
 	"crypto/tls"
 	"database/sql"
@@ -51,7 +50,11 @@ type Canal struct {
 	dsn *mysql.Config
 
 	cfgScope config.Scoped // required
+	// syncerMu protects the syncer which gets replaced during a reconnect.
+	syncerMu sync.Mutex
 	syncer   *myreplicator.BinlogSyncer
+	// closing gets closed by Close to stop waiting for a reconnect.
+	closing chan struct{}
 
 	masterMu           sync.RWMutex
 	masterStatus       ddl.MasterStatus
@@ -61,13 +64,20 @@ type Canal struct {
 	// the empty map key declares event handler for all tables, filtered  by the regexes.
 	// Otherwise an event handler is only registered for a specific table.
 	rsHandlers map[string][]RowsEventHandler
+	// ddlHandlers get called for each CREATE, ALTER, RENAME and DROP TABLE
+	// statement. Protected by rsMu.
+	ddlHandlers []DDLEventHandler
+	// txActive gets set by a BEGIN statement and txEvents buffers the rows
+	// events until the transaction has been committed.
+	txActive bool
+	txEvents []TxEvent
 
 	// dbcp is a database connection pool
 	dbcp *dml.ConnPool
 
 	// Tables contains the overall SQL table cache. If a table gets modified
-	// during runtime of this program then somehow we must clear the cache to
-	// reload the table structures.
+	// during runtime of this program, the DDL statement from the binlog
+	// removes it from the cache and reloads the table structure.
 	tables *ddl.Tables
 	// tableSFG takes to only execute one SQL query per table in parallel
 	// situations. No need for a pointer because Canal is already a pointer. So
@@ -81,6 +91,14 @@ type Canal struct {
 
 	closed *int32
 	wg     sync.WaitGroup
+	// health contains the current HealthState, accessed atomically.
+	health int32
+	// receivedEvents gets set to 1 by the sync goroutine after receiving an
+	// event and reset by the reconnect loop.
+	receivedEvents int32
+	// workers calls the RowsEventHandler concurrently if
+	// Options.ParallelWorkers has been set. Nil means sequential processing.
+	workers *workerPool
 }
 
 // DBConFactory creates a new database connection.
@@ -111,17 +129,7 @@ func withIncludeTables(regexes []string) func(c *Canal) error {
 		if len(regexes) == 0 {
 			return nil
 		}
-		c.tableLock.Lock()
-		defer c.tableLock.Unlock()
-		c.includeTableRegex = make([]*regexp.Regexp, len(regexes))
-		for i, val := range regexes {
-			reg, err := regexp.Compile(val)
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			c.includeTableRegex[i] = reg
-		}
-		return nil
+		return c.AddIncludeTables(regexes...)
 	}
 }
 
@@ -130,17 +138,7 @@ func withExcludeTables(regexes []string) func(c *Canal) error {
 		if len(regexes) == 0 {
 			return nil
 		}
-		c.tableLock.Lock()
-		defer c.tableLock.Unlock()
-		c.excludeTableRegex = make([]*regexp.Regexp, len(regexes))
-		for i, val := range regexes {
-			reg, err := regexp.Compile(val)
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			c.excludeTableRegex[i] = reg
-		}
-		return nil
+		return c.AddExcludeTables(regexes...)
 	}
 }
 
@@ -158,13 +156,35 @@ func withUpdateBinlogStart(c *Canal) error {
 		c.opts.MasterStatusQueryTimeout = time.Second * 20
 	}
 
-	var ms ddl.MasterStatus
 	ctx, cancel := context.WithTimeout(context.Background(), c.opts.MasterStatusQueryTimeout)
 	defer cancel()
+
+	if c.opts.PositionStorer != nil {
+		ms, err := c.opts.PositionStorer.LoadPosition(ctx)
+		switch {
+		case err == nil:
+			c.masterStatus = ms
+			return nil
+		case !errors.Is(err, errors.NotFound):
+			return errors.WithStack(err)
+		}
+	}
+
+	var ms ddl.MasterStatus
 	if _, err := c.dbcp.WithQueryBuilder(&ms).Load(ctx, &ms); err != nil {
 		return errors.WithStack(err)
 	}
 
+	// MariaDB does not report the GTID position via SHOW MASTER STATUS.
+	if c.opts.Flavor == MariaDBFlavor && ms.ExecutedGTIDSet == "" {
+		const varName = "gtid_binlog_pos"
+		v := ddl.NewVariables(varName)
+		if _, err := c.dbcp.WithQueryBuilder(v).Load(ctx, v); err != nil {
+			return errors.WithStack(err)
+		}
+		ms.ExecutedGTIDSet, _ = v.String(varName)
+	}
+
 	c.masterStatus = ms
 
 	return nil
@@ -183,14 +203,15 @@ func withPrepareSyncer(c *Canal) error {
 	}
 
 	cfg := myreplicator.BinlogSyncerConfig{
-		ServerID:  uint32(c.opts.BinlogSlaveId),
-		Flavor:    c.opts.Flavor,
-		Host:      host,
-		Port:      uint16(conv.ToUint(port)),
-		User:      c.dsn.User,
-		Password:  c.dsn.Passwd,
-		Log:       c.opts.Log,
-		TLSConfig: c.opts.TLSConfig,
+		ServerID:       uint32(c.opts.BinlogSlaveId),
+		Flavor:         c.opts.Flavor,
+		Host:           host,
+		Port:           uint16(conv.ToUint(port)),
+		User:           c.dsn.User,
+		Password:       c.dsn.Passwd,
+		Log:            c.opts.Log,
+		TLSConfig:      c.opts.TLSConfig,
+		VerifyChecksum: c.opts.VerifyChecksum,
 	}
 
 	c.syncer = myreplicator.NewBinlogSyncer(&cfg)
@@ -219,9 +240,19 @@ type Options struct {
 	// If not set the data won't be loaded.
 	ConfigScoped config.Scoped
 	// ConfigSet used to persists the master position of the binlog stream.
+	// Gets only used when PositionStorer is nil. The position gets loaded via
+	// ConfigScoped.
 	ConfigSet config.Setter
-	Log       log.Logger
-	TLSConfig *tls.Config // Needs some rework
+	// PositionStorer persists the master position of the binlog stream. A
+	// stored position has precedence over the current master position when
+	// starting the sync but not over BinlogStartFile and BinlogStartPosition.
+	PositionStorer PositionStorer
+	// PositionFlushInterval defines the minimum duration between two saves of
+	// the master position. Close always saves the last position. Defaults to
+	// one second.
+	PositionFlushInterval time.Duration
+	Log                   log.Logger
+	TLSConfig             *tls.Config // Needs some rework
 	// IncludeTableRegex defines the regex which matches the allowed table
 	// names. Default state of WithIncludeTables is empty, this will include all
 	// tables.
@@ -235,9 +266,40 @@ type Options struct {
 	BinlogStartPosition uint64
 	BinlogSlaveId       uint64
 	// Flavor defines if `mariadb` or `mysql` should be used. Defaults to
-	// `mariadb`.
+	// `mariadb`. With `mariadb` the GTID position gets tracked in the
+	// ExecutedGTIDSet of the master position and a stored GTID position has
+	// precedence over the binlog file and position when resuming the sync.
 	Flavor                   string
 	MasterStatusQueryTimeout time.Duration
+	// VerifyChecksum verifies the CRC32 checksum of each binlog event if the
+	// server writes checksums. A corrupt event stops the sync.
+	VerifyChecksum bool
+	// ReconnectMaxRetries defines how often the binlog stream reconnects after
+	// a connection error before giving up. The stream continues at the last
+	// synced position. The counter resets once events are received again.
+	// Zero disables reconnecting.
+	ReconnectMaxRetries int
+	// ReconnectInitialBackoff defines the wait duration before the first
+	// reconnect, it doubles with each further attempt. Defaults to one second.
+	ReconnectInitialBackoff time.Duration
+	// ReconnectMaxBackoff limits the wait duration between two reconnects.
+	// Defaults to one minute.
+	ReconnectMaxBackoff time.Duration
+	// OnHealthChange gets called when the state of the binlog stream changes,
+	// for example when a connection error triggers a reconnect. Argument err
+	// contains the cause and might be nil.
+	OnHealthChange func(state HealthState, err error)
+	// ParallelWorkers enables the concurrent execution of the RowsEventHandler
+	// with the given amount of workers. Rows of different tables and primary
+	// keys get processed in parallel, rows with the same primary key keep their
+	// order. The master position gets only persisted after all workers have
+	// processed their rows. A TxEventHandler still runs in the sync goroutine.
+	// Zero disables parallel processing.
+	ParallelWorkers int
+	// ParallelQueueSize defines the amount of pending rows events per worker.
+	// If a queue is full, reading the binlog stream blocks until the handler
+	// catches up. Defaults to 64.
+	ParallelQueueSize int
 	// OnClose runs before the database connection gets closed and after the
 	// syncer has been closed. The syncer does not "see" the changes comming
 	// from the queries executed in the call back.
@@ -257,6 +319,18 @@ func (o *Options) loadFromConfigService() (err error) {
 	if o.Log == nil {
 		o.Log = log.BlackHole{}
 	}
+	if o.PositionFlushInterval == 0 {
+		o.PositionFlushInterval = time.Second
+	}
+	if o.ReconnectInitialBackoff == 0 {
+		o.ReconnectInitialBackoff = time.Second
+	}
+	if o.ReconnectMaxBackoff == 0 {
+		o.ReconnectMaxBackoff = time.Minute
+	}
+	if o.ParallelQueueSize == 0 {
+		o.ParallelQueueSize = 64
+	}
 
 	if !o.ConfigScoped.IsValid() {
 		return nil
@@ -316,6 +390,7 @@ func NewCanal(dsn string, db DBConFactory, opt *Options) (*Canal, error) {
 		configPathBackendPosition: config.MustNewPath(ConfigPathBackendPosition),
 		dsn:                       pDSN,
 		closed:                    new(int32),
+		closing:                   make(chan struct{}),
 		tables:                    ddl.MustNewTables(),
 	}
 
@@ -323,6 +398,14 @@ func NewCanal(dsn string, db DBConFactory, opt *Options) (*Canal, error) {
 
 	c.tables.Schema = c.dsn.DBName
 
+	if c.opts.PositionStorer == nil && c.opts.ConfigSet != nil {
+		c.opts.PositionStorer = positionConfig{
+			path:   c.configPathBackendPosition,
+			set:    c.opts.ConfigSet,
+			scoped: c.opts.ConfigScoped,
+		}
+	}
+
 	c.dbcp, err = db(dsn)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -338,40 +421,38 @@ func NewCanal(dsn string, db DBConFactory, opt *Options) (*Canal, error) {
 		}
 	}
 
-	c.tableLock.Lock()
-	if c.includeTableRegex != nil || c.excludeTableRegex != nil {
-		c.tableAllowedCache = make(map[string]bool)
-	}
-	c.tableLock.Unlock()
-
 	return c, nil
 }
 
-// TODO continue sync from last stored master position
-
-func (c *Canal) masterSave(fileName string, pos uint) error {
+func (c *Canal) masterSave(ctx context.Context, ms ddl.MasterStatus) error {
 	c.masterMu.Lock()
 	defer c.masterMu.Unlock()
 
-	c.masterStatus.File = fileName
-	c.masterStatus.Position = pos
+	c.masterStatus = ms
 
-	now := time.Now()
-	if now.Sub(c.masterLastSaveTime) < time.Second {
+	if time.Since(c.masterLastSaveTime) < c.opts.PositionFlushInterval {
 		return nil
 	}
+	// The position must not be persisted before the workers have processed all
+	// rows up to this position.
+	if err := c.workers.wait(); err != nil {
+		return errors.WithStack(err)
+	}
+	return c.masterFlush(ctx)
+}
 
-	if c.opts.ConfigSet == nil {
+// masterFlush writes the master position to the PositionStorer. masterMu must
+// be locked.
+func (c *Canal) masterFlush(ctx context.Context) error {
+	if c.opts.PositionStorer == nil {
 		if c.opts.Log.IsDebug() {
-			c.opts.Log.Debug("[binlogsync] Warning: Master Status cannot be saved because config.Setter is nil",
+			c.opts.Log.Debug("[binlogsync] Warning: Master Status cannot be saved because PositionStorer is nil",
 				log.String("database", c.dsn.DBName), log.Stringer("master_status", c.masterStatus))
 		}
 		return nil
 	}
 
-	var buf bytes.Buffer
-	c.masterStatus.WriteTo(&buf)
-	if err := c.opts.ConfigSet.Set(c.configPathBackendPosition, buf.Bytes()); err != nil {
+	if err := c.opts.PositionStorer.SavePosition(ctx, c.masterStatus); err != nil {
 		if c.opts.Log.IsInfo() {
 			c.opts.Log.Info("[binlogsync] Failed to store Master Status",
 				log.Time("master_last_save_time", c.masterLastSaveTime),
@@ -380,8 +461,7 @@ func (c *Canal) masterSave(fileName string, pos uint) error {
 		return errors.WithStack(err)
 	}
 
-	c.masterLastSaveTime = now
-
+	c.masterLastSaveTime = time.Now()
 	return nil
 }
 
@@ -401,25 +481,49 @@ func (c *Canal) Start(ctx context.Context) error {
 	return nil
 }
 
-// run gets executed in its own goroutine
-func (c *Canal) run(ctx context.Context) error {
-	// refactor for better error handling
+// run gets executed in its own goroutine. Connection errors of the binlog
+// stream trigger a reconnect, all other errors stop the sync.
+func (c *Canal) run(ctx context.Context) (err error) {
 	defer c.wg.Done()
+	defer func() { c.setHealth(HealthStopped, err) }()
+
+	if c.opts.ParallelWorkers > 0 {
+		c.workers = newWorkerPool(ctx, c, c.opts.ParallelWorkers, c.opts.ParallelQueueSize)
+		defer func() {
+			errW := c.workers.close()
+			c.workers = nil
+			if errW != nil && err == nil {
+				err = errors.WithStack(errW)
+			}
+		}()
+	}
 
-	if err := c.startSyncBinlog(ctx); err != nil {
-		if !c.isClosed() {
-			c.opts.Log.Info("[binlogsync] Canal start has encountered a sync binlog error", log.Err(err))
+	for attempt := 0; ; attempt++ {
+		err = c.startSyncBinlog(ctx)
+		if atomic.SwapInt32(&c.receivedEvents, 0) == 1 {
+			attempt = 0
+		}
+		switch {
+		case err == nil:
+			return nil
+		case c.isClosed():
+			return errors.WithStack(err)
+		case !errors.ConnectionFailed.Match(err) || attempt >= c.opts.ReconnectMaxRetries || ctx.Err() != nil:
+			c.opts.Log.Info("[binlogsync] Canal start has encountered a sync binlog error", log.Err(err), log.Int("reconnect_attempts", attempt))
+			return errors.WithStack(err)
+		}
+		if errR := c.reconnect(ctx, attempt, err); errR != nil {
+			return errors.WithStack(errR)
 		}
-		return errors.WithStack(err)
 	}
-	return nil
 }
 
 func (c *Canal) isClosed() bool {
 	return atomic.LoadInt32(c.closed) == int32(1)
 }
 
-// Close closes all underlying connections
+// Close closes all underlying connections. It waits until the sync goroutine
+// has been stopped and saves the last master position.
 func (c *Canal) Close() error {
 	c.mclose.Lock()
 	defer c.mclose.Unlock()
@@ -429,11 +533,24 @@ func (c *Canal) Close() error {
 	}
 
 	atomic.StoreInt32(c.closed, 1)
+	if c.closing != nil {
+		close(c.closing)
+	}
 
+	c.syncerMu.Lock()
 	if c.syncer != nil {
 		c.syncer.Close()
 		c.syncer = nil
 	}
+	c.syncerMu.Unlock()
+	c.wg.Wait()
+
+	c.masterMu.Lock()
+	err := c.masterFlush(context.Background())
+	c.masterMu.Unlock()
+	if err != nil {
+		return errors.WithStack(err)
+	}
 
 	if err := c.opts.OnClose(c.dbcp); err != nil {
 		return errors.WithStack(err)
@@ -442,35 +559,33 @@ func (c *Canal) Close() error {
 	if err := c.dbcp.Close(); err != nil {
 		return errors.WithStack(err)
 	}
-	c.wg.Wait()
 	return nil
 }
 
 func (c *Canal) isTableAllowed(tblName string) bool {
+	c.tableLock.RLock()
 	// no filter, return true
 	if c.tableAllowedCache == nil {
+		c.tableLock.RUnlock()
 		return true
 	}
-
-	c.tableLock.RLock()
 	isAllowed, ok := c.tableAllowedCache[tblName]
-	c.tableLock.RUnlock()
 	if ok {
 		// cache hit
+		c.tableLock.RUnlock()
 		return isAllowed
 	}
-	matchFlag := false
+	// an empty include list includes all tables
+	matchFlag := c.includeTableRegex == nil
 	// check include
-	if c.includeTableRegex != nil {
-		for _, reg := range c.includeTableRegex {
-			if reg.MatchString(tblName) {
-				matchFlag = true
-				break
-			}
+	for _, reg := range c.includeTableRegex {
+		if reg.MatchString(tblName) {
+			matchFlag = true
+			break
 		}
 	}
 	// check exclude
-	if matchFlag && c.excludeTableRegex != nil {
+	if matchFlag {
 		for _, reg := range c.excludeTableRegex {
 			if reg.MatchString(tblName) {
 				matchFlag = false
@@ -478,12 +593,101 @@ func (c *Canal) isTableAllowed(tblName string) bool {
 			}
 		}
 	}
+	c.tableLock.RUnlock()
+
 	c.tableLock.Lock()
-	c.tableAllowedCache[tblName] = matchFlag
+	if c.tableAllowedCache != nil { // filters might have been removed meanwhile
+		c.tableAllowedCache[tblName] = matchFlag
+	}
 	c.tableLock.Unlock()
 	return matchFlag
 }
 
+// AddIncludeTables adds regular expressions to the list of allowed tables
+// while the Canal is running. Tables already loaded into the table cache stay
+// there but their events get filtered.
+func (c *Canal) AddIncludeTables(regexes ...string) error {
+	regs, err := compileRegexes(regexes)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	c.tableLock.Lock()
+	defer c.tableLock.Unlock()
+	c.includeTableRegex = append(c.includeTableRegex, regs...)
+	c.resetTableAllowedCache()
+	return nil
+}
+
+// AddExcludeTables adds regular expressions to the list of excluded tables
+// while the Canal is running.
+func (c *Canal) AddExcludeTables(regexes ...string) error {
+	regs, err := compileRegexes(regexes)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	c.tableLock.Lock()
+	defer c.tableLock.Unlock()
+	c.excludeTableRegex = append(c.excludeTableRegex, regs...)
+	c.resetTableAllowedCache()
+	return nil
+}
+
+// RemoveIncludeTables removes the regular expressions from the list of allowed
+// tables. The regexes must match the previously added ones exactly. Removing
+// all include regexes allows all tables.
+func (c *Canal) RemoveIncludeTables(regexes ...string) {
+	c.tableLock.Lock()
+	defer c.tableLock.Unlock()
+	c.includeTableRegex = removeRegexes(c.includeTableRegex, regexes)
+	c.resetTableAllowedCache()
+}
+
+// RemoveExcludeTables removes the regular expressions from the list of
+// excluded tables. The regexes must match the previously added ones exactly.
+func (c *Canal) RemoveExcludeTables(regexes ...string) {
+	c.tableLock.Lock()
+	defer c.tableLock.Unlock()
+	c.excludeTableRegex = removeRegexes(c.excludeTableRegex, regexes)
+	c.resetTableAllowedCache()
+}
+
+// resetTableAllowedCache must be called with a locked tableLock.
+func (c *Canal) resetTableAllowedCache() {
+	c.tableAllowedCache = nil
+	if c.includeTableRegex != nil || c.excludeTableRegex != nil {
+		c.tableAllowedCache = make(map[string]bool)
+	}
+}
+
+func compileRegexes(regexes []string) ([]*regexp.Regexp, error) {
+	regs := make([]*regexp.Regexp, len(regexes))
+	for i, val := range regexes {
+		reg, err := regexp.Compile(val)
+		if err != nil {
+			return nil, errors.NotValid.New(err, "[binlogsync] Invalid table regex %q", val)
+		}
+		regs[i] = reg
+	}
+	return regs, nil
+}
+
+func removeRegexes(regs []*regexp.Regexp, remove []string) []*regexp.Regexp {
+	var kept []*regexp.Regexp
+	for _, reg := range regs {
+		found := false
+		for _, r := range remove {
+			if reg.String() == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			kept = append(kept, reg)
+		}
+	}
+	return kept
+}
+
 type errTableNotAllowed string
 
 func (t errTableNotAllowed) ErrorKind() errors.Kind { return errors.NotAllowed }
@@ -529,9 +733,24 @@ func (c *Canal) FindTable(ctx context.Context, tableName string) (*ddl.Table, er
 	return val.(*ddl.Table), nil
 }
 
-// ClearTableCache clear table cache
+// ClearTableCache removes the table from the table cache. An empty table name
+// clears the whole cache. Tables of other databases than the one from the DSN
+// get ignored because they are not cached.
 func (c *Canal) ClearTableCache(db string, table string) {
-	c.tables.DeleteAllFromCache()
+	switch {
+	case !c.isTableSchema(db):
+		return
+	case table == "":
+		c.tables.DeleteAllFromCache()
+	default:
+		c.tables.DeleteFromCache(table)
+	}
+}
+
+// isTableSchema reports whether the database name matches the database of the
+// DSN. An empty name matches always.
+func (c *Canal) isTableSchema(db string) bool {
+	return db == "" || c.tables.Schema == "" || db == c.tables.Schema
 }
 
 // CheckBinlogRowImage checks MySQL binlog row image, must be in FULL, MINIMAL, NOBLOB
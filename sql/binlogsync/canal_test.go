@@ -15,12 +15,21 @@
 package binlogsync
 
 import (
+	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
 	"github.com/corestoreio/pkg/config"
 	"github.com/corestoreio/pkg/config/storage"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dmltest"
 	"github.com/corestoreio/pkg/util/assert"
+	"github.com/go-sql-driver/mysql"
 )
 
 var (
@@ -60,3 +69,471 @@ func TestOptions_LoadFromConfigService(t *testing.T) {
 	assert.Exactly(t, uint64(4711), o.BinlogSlaveId, "BinlogSlaveId")
 	assert.Exactly(t, "mysql", o.Flavor, "Flavor")
 }
+
+func TestCanal_RefreshTableOnDDLStmt(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	c := &Canal{
+		opts: Options{Log: log.BlackHole{}},
+		dbcp: dbc,
+		tables: ddl.MustNewTables(
+			ddl.WithTable("core_config_data"),
+			ddl.WithTable("sales_order"),
+			ddl.WithTable("store"),
+		),
+	}
+	c.tables.Schema = "TestDB"
+	ctx := context.Background()
+	schema := []byte("TestDB")
+
+	t.Run("ALTER reloads table", func(t *testing.T) {
+		dbMock.ExpectQuery("SELECT.+FROM information_schema.COLUMNS WHERE.+TABLE_NAME IN \\('core_config_data'\\)").
+			WillReturnRows(dmltest.MustMockRows(dmltest.WithFile("testdata/core_config_data_columns.csv")))
+
+		c.refreshTableOnDDLStmt(ctx, schema, []byte("ALTER TABLE `core_config_data` ADD COLUMN `x` int"))
+		tbl, err := c.tables.Table("core_config_data")
+		assert.NoError(t, err)
+		assert.Exactly(t, []string{"config_id", "scope", "scope_id", "path", "value"}, tbl.Columns.FieldNames())
+		assert.Exactly(t, 3, c.tables.Len())
+	})
+	t.Run("other database ignored", func(t *testing.T) {
+		c.refreshTableOnDDLStmt(ctx, schema, []byte("ALTER TABLE `otherDB`.`sales_order` ADD COLUMN `x` int"))
+		assert.Exactly(t, 3, c.tables.Len())
+	})
+	t.Run("DROP removes table", func(t *testing.T) {
+		c.refreshTableOnDDLStmt(ctx, schema, []byte("DROP TABLE `sales_order`"))
+		_, err := c.tables.Table("sales_order")
+		assert.True(t, errors.Is(err, errors.NotFound), "%+v", err)
+		assert.Exactly(t, 2, c.tables.Len())
+	})
+	t.Run("RENAME clears cache", func(t *testing.T) {
+		c.refreshTableOnDDLStmt(ctx, schema, []byte("RENAME TABLE `store` TO `store_tmp`"))
+		assert.Exactly(t, 0, c.tables.Len())
+	})
+}
+
+type positionRecorder struct {
+	saved  []ddl.MasterStatus
+	stored ddl.MasterStatus
+}
+
+func (pr *positionRecorder) SavePosition(_ context.Context, ms ddl.MasterStatus) error {
+	pr.saved = append(pr.saved, ms)
+	return nil
+}
+
+func (pr *positionRecorder) LoadPosition(_ context.Context) (ddl.MasterStatus, error) {
+	if pr.stored.File == "" {
+		return pr.stored, errors.NotFound.Newf("not found")
+	}
+	return pr.stored, nil
+}
+
+func TestMergeMariaDBGTID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		set  string
+		gtid string
+		want string
+	}{
+		{"", "0-1-5", "0-1-5"},
+		{"0-1-4", "0-1-5", "0-1-5"},
+		{"0-1-4", "1-2-9", "0-1-4,1-2-9"},
+		{"0-1-4,1-2-9,10-1-3", "1-3-10", "0-1-4,1-3-10,10-1-3"},
+		{"0-1-4,10-1-3", "1-1-1", "0-1-4,10-1-3,1-1-1"},
+	}
+	for _, test := range tests {
+		assert.Exactly(t, test.want, mergeMariaDBGTID(test.set, test.gtid), "%q + %q", test.set, test.gtid)
+	}
+}
+
+func TestCanal_MasterSave(t *testing.T) {
+	t.Parallel()
+
+	pr := &positionRecorder{}
+	c := &Canal{
+		opts: Options{
+			Log:                   log.BlackHole{},
+			PositionStorer:        pr,
+			PositionFlushInterval: time.Hour,
+		},
+		dsn: &mysql.Config{DBName: "TestDB"},
+	}
+	ctx := context.Background()
+
+	assert.NoError(t, c.masterSave(ctx, ddl.MasterStatus{File: "mysql-bin.000001", Position: 4}))
+	assert.NoError(t, c.masterSave(ctx, ddl.MasterStatus{File: "mysql-bin.000001", Position: 120}))
+	assert.Exactly(t, []ddl.MasterStatus{{File: "mysql-bin.000001", Position: 4}}, pr.saved)
+	assert.Exactly(t, ddl.MasterStatus{File: "mysql-bin.000001", Position: 120}, c.SyncedPosition())
+
+	assert.NoError(t, c.masterFlush(ctx))
+	assert.Exactly(t, ddl.MasterStatus{File: "mysql-bin.000001", Position: 120}, pr.saved[1])
+}
+
+func TestWithUpdateBinlogStart_PositionStorer(t *testing.T) {
+	t.Parallel()
+
+	pr := &positionRecorder{
+		stored: ddl.MasterStatus{File: "mysql-bin.000002", Position: 4711},
+	}
+	c := &Canal{
+		opts: Options{
+			Log:            log.BlackHole{},
+			PositionStorer: pr,
+		},
+	}
+	assert.NoError(t, withUpdateBinlogStart(c))
+	assert.Exactly(t, pr.stored, c.SyncedPosition())
+}
+
+type txRecorder struct {
+	name    string
+	mu      sync.Mutex
+	actions []string
+	txs     [][]TxEvent
+}
+
+func (tr *txRecorder) Do(_ context.Context, action string, t *ddl.Table, _ [][]interface{}) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.actions = append(tr.actions, action+":"+t.Name)
+	return nil
+}
+func (tr *txRecorder) Complete(context.Context) error { return nil }
+func (tr *txRecorder) String() string                 { return tr.name }
+
+type txUnitRecorder struct {
+	txRecorder
+}
+
+func (tr *txUnitRecorder) DoTx(_ context.Context, events []TxEvent) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.txs = append(tr.txs, events)
+	return nil
+}
+
+func TestCanal_TxEvents(t *testing.T) {
+	t.Parallel()
+
+	rowHandler := &txRecorder{name: "rows"}
+	txAll := &txUnitRecorder{txRecorder{name: "tx_all"}}
+	txStore := &txUnitRecorder{txRecorder{name: "tx_store"}}
+
+	c := &Canal{
+		opts: Options{Log: log.BlackHole{}},
+		dsn:  &mysql.Config{DBName: "TestDB"},
+	}
+	c.RegisterRowsEventHandler("", rowHandler, txAll)
+	c.RegisterRowsEventHandler("store", txStore)
+
+	ctx := context.Background()
+	tblStore := ddl.NewTable("store")
+	tblOrder := ddl.NewTable("sales_order")
+	rows := [][]interface{}{{1}}
+
+	c.txBegin()
+	assert.NoError(t, c.addRowsEvent(ctx, InsertAction, tblStore, rows))
+	c.txRollback()
+	assert.Len(t, rowHandler.actions, 0)
+	assert.Len(t, txAll.txs, 0)
+
+	c.txBegin()
+	assert.NoError(t, c.addRowsEvent(ctx, InsertAction, tblStore, rows))
+	assert.NoError(t, c.addRowsEvent(ctx, DeleteAction, tblOrder, rows))
+	assert.Len(t, rowHandler.actions, 0, "events must be buffered until commit")
+	assert.NoError(t, c.txCommit(ctx))
+
+	assert.Exactly(t, []string{"insert:store", "delete:sales_order"}, rowHandler.actions)
+	assert.Len(t, txAll.actions, 0, "Do must not be called for a TxEventHandler")
+	assert.Len(t, txAll.txs, 1)
+	assert.Len(t, txAll.txs[0], 2)
+	assert.Len(t, txStore.txs, 1)
+	assert.Exactly(t, []TxEvent{{Action: InsertAction, Table: tblStore, Rows: rows}}, txStore.txs[0])
+
+	// without a transaction the event gets dispatched immediately
+	assert.NoError(t, c.addRowsEvent(ctx, UpdateAction, tblOrder, rows))
+	assert.Exactly(t, "update:sales_order", rowHandler.actions[2])
+	assert.Len(t, txAll.txs, 2)
+	assert.Len(t, txStore.txs, 1)
+}
+
+type ddlRecorder struct {
+	mu     sync.Mutex
+	events []DDLEvent
+}
+
+func (dr *ddlRecorder) DoDDL(_ context.Context, ev DDLEvent) error {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+	dr.events = append(dr.events, ev)
+	return nil
+}
+func (dr *ddlRecorder) String() string { return "ddl_recorder" }
+
+func TestCanal_DDLEvents(t *testing.T) {
+	t.Parallel()
+
+	c := &Canal{
+		opts:   Options{Log: log.BlackHole{}},
+		dsn:    &mysql.Config{DBName: "TestDB"},
+		tables: ddl.MustNewTables(ddl.WithTable("sales_order")),
+	}
+	c.tables.Schema = "TestDB"
+	dr := &ddlRecorder{}
+	c.RegisterDDLEventHandler(dr)
+
+	ctx := context.Background()
+	schema := []byte("TestDB")
+
+	for _, query := range []string{
+		"DROP TABLE `sales_order`",
+		"RENAME TABLE `store` TO `store_tmp`",
+		"DROP TABLE `otherDB`.`store`",
+		"INSERT INTO `store` VALUES (1)",
+	} {
+		ev, ok := c.refreshTableOnDDLStmt(ctx, schema, []byte(query))
+		if ok {
+			assert.NoError(t, c.processDDLEventHandler(ctx, ev))
+		}
+	}
+
+	assert.Exactly(t, []DDLEvent{
+		{Action: DropTableAction, Schema: "TestDB", Table: "sales_order", Query: "DROP TABLE `sales_order`"},
+		{Action: RenameTableAction, Schema: "TestDB", Table: "store", Query: "RENAME TABLE `store` TO `store_tmp`"},
+	}, dr.events)
+}
+
+type slowRowsHandler struct {
+	workerRecorder
+	delay time.Duration
+}
+
+func (sh *slowRowsHandler) Do(ctx context.Context, action string, t *ddl.Table, rows [][]interface{}) error {
+	time.Sleep(sh.delay)
+	return sh.workerRecorder.Do(ctx, action, t, rows)
+}
+
+// ddlRowsCounter records how many rows the row handler has processed at the
+// time the DDL event arrives.
+type ddlRowsCounter struct {
+	rh   *slowRowsHandler
+	rows int
+}
+
+func (dc *ddlRowsCounter) DoDDL(context.Context, DDLEvent) error {
+	dc.rh.mu.Lock()
+	defer dc.rh.mu.Unlock()
+	for _, rows := range dc.rh.rows {
+		dc.rows += len(rows)
+	}
+	return nil
+}
+func (dc *ddlRowsCounter) String() string { return "ddl_rows_counter" }
+
+func TestCanal_DDLEventWaitsForWorkers(t *testing.T) {
+	t.Parallel()
+
+	rh := &slowRowsHandler{delay: 20 * time.Millisecond}
+	c := newWorkerTestCanal(rh)
+	c.tables = ddl.MustNewTables(ddl.WithTable("sales_order"))
+	c.tables.Schema = "TestDB"
+	dc := &ddlRowsCounter{rh: rh}
+	c.RegisterDDLEventHandler(dc)
+
+	ctx := context.Background()
+	c.workers = newWorkerPool(ctx, c, 2, 4)
+	tbl := ddl.NewTable("sales_order", &ddl.Column{Field: "entity_id", DataType: "int", Key: "PRI"})
+	assert.NoError(t, c.addRowsEvent(ctx, InsertAction, tbl, [][]interface{}{{1}, {2}, {3}, {4}}))
+
+	pos := ddl.MasterStatus{File: "mysql-bin.000001", Position: 4711}
+	assert.NoError(t, c.handleQueryEvent(ctx, []byte("TestDB"), []byte("DROP TABLE `sales_order`"), pos))
+	assert.Exactly(t, 4, dc.rows, "the DDL handler must run after all rows have been processed")
+	assert.NoError(t, c.workers.close())
+
+	t.Run("worker error stops DDL", func(t *testing.T) {
+		rh := &slowRowsHandler{delay: 20 * time.Millisecond}
+		rh.err = errors.Interrupted.Newf("handler failed")
+		c := newWorkerTestCanal(rh)
+		c.tables = ddl.MustNewTables(ddl.WithTable("sales_order"))
+		dr := &ddlRecorder{}
+		c.RegisterDDLEventHandler(dr)
+		c.workers = newWorkerPool(ctx, c, 1, 1)
+
+		assert.NoError(t, c.addRowsEvent(ctx, DeleteAction, tbl, [][]interface{}{{1}}))
+		err := c.handleQueryEvent(ctx, []byte("TestDB"), []byte("DROP TABLE `sales_order`"), pos)
+		assert.True(t, errors.Interrupted.Match(err), "%+v", err)
+		assert.Len(t, dr.events, 0)
+		assert.Error(t, c.workers.close())
+	})
+}
+
+func TestCanal_UnregisterRowsEventHandler(t *testing.T) {
+	t.Parallel()
+
+	h1 := &txRecorder{name: "h1"}
+	h2 := &txRecorder{name: "h2"}
+	c := &Canal{
+		opts: Options{Log: log.BlackHole{}},
+		dsn:  &mysql.Config{DBName: "TestDB"},
+	}
+	c.RegisterRowsEventHandler("store", h1, h2)
+	c.UnregisterRowsEventHandler("store", h1)
+	c.UnregisterRowsEventHandler("", h2) // other table, no-op
+
+	ctx := context.Background()
+	tblStore := ddl.NewTable("store")
+	assert.NoError(t, c.processRowsEventHandler(ctx, InsertAction, tblStore, [][]interface{}{{1}}))
+	assert.Len(t, h1.actions, 0)
+	assert.Exactly(t, []string{"insert:store"}, h2.actions)
+
+	c.UnregisterRowsEventHandler("store", h2)
+	_, ok := c.rsHandlers["store"]
+	assert.False(t, ok, "empty handler list must be removed")
+}
+
+func TestCanal_RuntimeTableFilters(t *testing.T) {
+	t.Parallel()
+
+	c := &Canal{}
+	assert.True(t, c.isTableAllowed("sales_order"))
+
+	assert.NoError(t, c.AddExcludeTables("^core_"))
+	assert.True(t, c.isTableAllowed("sales_order"), "empty include list allows all tables")
+	assert.False(t, c.isTableAllowed("core_config_data"))
+
+	assert.NoError(t, c.AddIncludeTables("^sales_", "^core_"))
+	assert.True(t, c.isTableAllowed("sales_order"))
+	assert.False(t, c.isTableAllowed("catalog_product_entity"))
+	assert.False(t, c.isTableAllowed("core_config_data"))
+
+	c.RemoveExcludeTables("^core_")
+	assert.True(t, c.isTableAllowed("core_config_data"))
+
+	c.RemoveIncludeTables("^sales_", "^core_")
+	assert.True(t, c.isTableAllowed("catalog_product_entity"))
+	assert.Nil(t, c.tableAllowedCache)
+
+	err := c.AddIncludeTables("^sales_(")
+	assert.True(t, errors.NotValid.Match(err), "%+v", err)
+}
+
+func TestCanal_Bootstrap(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	pr := &positionRecorder{}
+	rr := &txUnitRecorder{txRecorder{name: "tx_all"}}
+	c := &Canal{
+		opts: Options{Log: log.BlackHole{}, PositionStorer: pr},
+		dsn:  &mysql.Config{DBName: "TestDB"},
+		dbcp: dbc,
+		tables: ddl.MustNewTables(
+			ddl.WithTable("store",
+				&ddl.Column{Field: "store_id", DataType: "smallint", ColumnType: "smallint(5) unsigned", Key: "PRI"},
+				&ddl.Column{Field: "code", DataType: "varchar", ColumnType: "varchar(32)"},
+			),
+		),
+	}
+	c.RegisterRowsEventHandler("store", rr)
+
+	t.Run("no tables", func(t *testing.T) {
+		err := c.Bootstrap(context.Background(), BootstrapOptions{})
+		assert.True(t, errors.Empty.Match(err), "%+v", err)
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		dbMock.ExpectExec("FLUSH TABLES WITH READ LOCK").WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec("SET SESSION TRANSACTION ISOLATION LEVEL REPEATABLE READ").WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec("START TRANSACTION WITH CONSISTENT SNAPSHOT").WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectQuery("SHOW MASTER STATUS").
+			WillReturnRows(sqlmock.NewRows([]string{"File", "Position"}).AddRow("mysql-bin.000004", 4711))
+		dbMock.ExpectExec("UNLOCK TABLES").WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `store_id`, `code` FROM `store`")).
+			WillReturnRows(sqlmock.NewRows([]string{"store_id", "code"}).
+				AddRow([]byte("1"), []byte("de")).AddRow([]byte("2"), []byte("at")).AddRow([]byte("3"), nil))
+		dbMock.ExpectExec("COMMIT").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.NoError(t, c.Bootstrap(context.Background(), BootstrapOptions{Tables: []string{"store"}, BatchSize: 2}))
+
+		assert.Len(t, rr.txs, 2)
+		assert.Exactly(t, [][]interface{}{{int64(1), "de"}, {int64(2), "at"}}, rr.txs[0][0].Rows)
+		assert.Exactly(t, [][]interface{}{{int64(3), nil}}, rr.txs[1][0].Rows)
+		assert.Exactly(t, InsertAction, rr.txs[1][0].Action)
+
+		want := ddl.MasterStatus{File: "mysql-bin.000004", Position: 4711}
+		assert.Exactly(t, want, c.SyncedPosition())
+		assert.Exactly(t, []ddl.MasterStatus{want}, pr.saved)
+	})
+}
+
+func TestCanal_ReconnectBackoff(t *testing.T) {
+	t.Parallel()
+
+	c := &Canal{
+		opts: Options{
+			ReconnectInitialBackoff: time.Second,
+			ReconnectMaxBackoff:     5 * time.Second,
+		},
+	}
+	var have []time.Duration
+	for attempt := 0; attempt < 5; attempt++ {
+		have = append(have, c.reconnectBackoff(attempt))
+	}
+	assert.Exactly(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}, have)
+}
+
+func TestCanal_SetHealth(t *testing.T) {
+	t.Parallel()
+
+	var states []HealthState
+	var errs []error
+	c := &Canal{
+		opts: Options{
+			Log: log.BlackHole{},
+			OnHealthChange: func(hs HealthState, err error) {
+				states = append(states, hs)
+				errs = append(errs, err)
+			},
+		},
+	}
+	assert.Exactly(t, HealthStarting, c.Health())
+
+	c.setHealth(HealthStarting, nil)
+	c.setHealth(HealthSyncing, nil)
+	c.setHealth(HealthSyncing, nil)
+	errConn := errors.ConnectionFailed.Newf("connection lost")
+	c.setHealth(HealthReconnecting, errConn)
+	c.setHealth(HealthSyncing, nil)
+
+	assert.Exactly(t, []HealthState{HealthSyncing, HealthReconnecting, HealthSyncing}, states)
+	assert.Exactly(t, []error{nil, errConn, nil}, errs)
+	assert.Exactly(t, "syncing", c.Health().String())
+}
+
+func TestCanal_ReconnectAbortsOnClose(t *testing.T) {
+	t.Parallel()
+
+	c := &Canal{
+		opts: Options{
+			Log:                     log.BlackHole{},
+			ReconnectInitialBackoff: time.Hour,
+			ReconnectMaxBackoff:     time.Hour,
+		},
+		closed:  new(int32),
+		closing: make(chan struct{}),
+	}
+	// same as Close does before waiting for the sync goroutine
+	atomic.StoreInt32(c.closed, 1)
+	close(c.closing)
+
+	err := c.reconnect(context.Background(), 0, errors.ConnectionFailed.Newf("connection lost"))
+	assert.True(t, errors.AlreadyClosed.Match(err), "%+v", err)
+	assert.Exactly(t, HealthReconnecting, c.Health())
+}
@@ -9,12 +9,9 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-// TODO(CyS) investigate what would happen in case of transaction? should all
-// the events be gathered together once a transaction starts? because on
-// RollBack all events must be invalidated or better RowsEventHandler should not
-// be called at all.
-
-// RowsEventHandler calls your code when an event gets dispatched.
+// RowsEventHandler calls your code when an event gets dispatched. Rows events
+// of a transaction get buffered and dispatched once the transaction has been
+// committed. Events of a rolled back transaction get dropped.
 type RowsEventHandler interface {
 	// Do function handles a RowsEvent bound to a specific database. If it
 	// returns an error behaviour of "Interrupted", the canal type will stop the
@@ -33,6 +30,23 @@ type RowsEventHandler interface {
 	String() string
 }
 
+// TxEvent contains the rows of one rows event of a transaction.
+type TxEvent struct {
+	Action string
+	Table  *ddl.Table
+	Rows   [][]interface{}
+}
+
+// TxEventHandler receives all rows events of a committed transaction as one
+// unit. A RowsEventHandler implementing TxEventHandler gets only DoTx called
+// and never Do. A handler registered for a specific table receives only the
+// events of that table. Events of a rolled back transaction get never
+// delivered. Same error rules apply here like for function Do().
+type TxEventHandler interface {
+	RowsEventHandler
+	DoTx(ctx context.Context, events []TxEvent) error
+}
+
 // RegisterRowsEventHandler adds a new event handler to the internal list. If a
 // table name gets provided the event handler is bound to that exact table name,
 // if the table has not been excluded via the global regexes. An empty tableName
@@ -48,6 +62,30 @@ func (c *Canal) RegisterRowsEventHandler(tableName string, h ...RowsEventHandler
 	c.rsHandlers[tableName] = append(hs, h...)
 }
 
+// UnregisterRowsEventHandler removes the event handlers bound to the table
+// name. A handler gets identified by the return value of its String function.
+func (c *Canal) UnregisterRowsEventHandler(tableName string, h ...RowsEventHandler) {
+	c.rsMu.Lock()
+	defer c.rsMu.Unlock()
+
+	hs := c.rsHandlers[tableName]
+	kept := make([]RowsEventHandler, 0, len(hs))
+	for _, rh := range hs {
+		remove := false
+		for _, uh := range h {
+			remove = remove || rh.String() == uh.String()
+		}
+		if !remove {
+			kept = append(kept, rh)
+		}
+	}
+	if len(kept) == 0 {
+		delete(c.rsHandlers, tableName)
+		return
+	}
+	c.rsHandlers[tableName] = kept
+}
+
 func (c *Canal) processRowsEventHandler(ctx context.Context, action string, table *ddl.Table, rows [][]interface{}) error {
 	c.rsMu.RLock()
 	defer c.rsMu.RUnlock()
@@ -70,18 +108,178 @@ func (c *Canal) processRowsEventHandler(ctx context.Context, action string, tabl
 	}
 	if hs, ok := c.rsHandlers[table.Name]; ok && table.Name != "" {
 		for _, h := range hs {
-			erg.Go(errGoFn(h))
+			if _, ok := h.(TxEventHandler); !ok {
+				erg.Go(errGoFn(h))
+			}
 		}
 	}
 
 	for _, h := range c.rsHandlers[""] {
-		erg.Go(errGoFn(h))
+		if _, ok := h.(TxEventHandler); !ok {
+			erg.Go(errGoFn(h))
+		}
+	}
+	return errors.WithStack(erg.Wait())
+}
+
+func (c *Canal) processTxEventHandler(ctx context.Context, events []TxEvent) error {
+	c.rsMu.RLock()
+	defer c.rsMu.RUnlock()
+
+	erg, ctx := errgroup.WithContext(ctx)
+
+	for tblName, hs := range c.rsHandlers {
+		evs := events
+		if tblName != "" {
+			evs = nil
+			for _, ev := range events {
+				if ev.Table.Name == tblName {
+					evs = append(evs, ev)
+				}
+			}
+		}
+		if len(evs) == 0 {
+			continue
+		}
+		for _, h := range hs {
+			h, ok := h.(TxEventHandler)
+			if !ok {
+				continue
+			}
+			tblName, evs := tblName, evs
+			erg.Go(func() error {
+				if err := h.DoTx(ctx, evs); err != nil {
+					isInterr := errors.Is(err, errors.Interrupted)
+					c.opts.Log.Info("binlogsync.Canal.processTxEventHandler.Go.DoTx.error", log.Err(err), log.Stringer("handler_name", h),
+						log.Bool("is_interrupted", isInterr), log.Int("events", len(evs)), log.String("table_name", tblName))
+					if isInterr {
+						return errors.WithStack(err)
+					}
+				}
+				return nil
+			})
+		}
+	}
+	return errors.WithStack(erg.Wait())
+}
+
+// txBegin starts to buffer the rows events until txCommit or txRollback gets
+// called. The transaction fields get only accessed by the sync goroutine.
+func (c *Canal) txBegin() {
+	c.txActive = true
+	c.txEvents = nil
+}
+
+// txRollback drops all buffered rows events.
+func (c *Canal) txRollback() {
+	c.txActive = false
+	c.txEvents = nil
+}
+
+// txCommit dispatches all buffered rows events to the handlers.
+func (c *Canal) txCommit(ctx context.Context) error {
+	events := c.txEvents
+	c.txRollback()
+	return c.dispatchTxEvents(ctx, events)
+}
+
+// addRowsEvent buffers the rows event while a transaction is active, otherwise
+// the rows event gets dispatched immediately.
+func (c *Canal) addRowsEvent(ctx context.Context, action string, table *ddl.Table, rows [][]interface{}) error {
+	ev := TxEvent{Action: action, Table: table, Rows: rows}
+	if c.txActive {
+		c.txEvents = append(c.txEvents, ev)
+		return nil
+	}
+	return c.dispatchTxEvents(ctx, []TxEvent{ev})
+}
+
+// dispatchTxEvents calls Do of the RowsEventHandler for each event in order
+// and afterwards DoTx of the TxEventHandler with all events. With parallel
+// workers enabled the Do calls run asynchronously.
+func (c *Canal) dispatchTxEvents(ctx context.Context, events []TxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	for _, ev := range events {
+		if c.workers != nil {
+			if err := c.workers.dispatch(ctx, ev); err != nil {
+				return errors.WithStack(err)
+			}
+			continue
+		}
+		if err := c.processRowsEventHandler(ctx, ev.Action, ev.Table, ev.Rows); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return errors.WithStack(c.processTxEventHandler(ctx, events))
+}
+
+// DDLEvent describes a schema change parsed from a binlog query event.
+type DDLEvent struct {
+	// Action is one of the *TableAction constants.
+	Action string
+	Schema string
+	// Table contains the name of the table. For a RENAME TABLE statement it
+	// contains the first old table name.
+	Table string
+	// Query contains the full DDL statement.
+	Query string
+	// Position of the binlog event.
+	Position ddl.MasterStatus
+}
+
+// DDLEventHandler calls your code when a table structure has changed. The
+// table cache of the Canal has already been refreshed when DoDDL gets called,
+// so FindTable returns the new structure. Only events of the database of the
+// DSN and of allowed tables get dispatched.
+type DDLEventHandler interface {
+	// DoDDL handles a schema change. If it returns an error behaviour of
+	// "Interrupted", the canal type will stop the syncer. The DoDDL function
+	// will run in its own Goroutine.
+	DoDDL(ctx context.Context, ev DDLEvent) error
+	// String returns the name of the handler
+	String() string
+}
+
+// RegisterDDLEventHandler adds a new DDL event handler to the internal list.
+func (c *Canal) RegisterDDLEventHandler(h ...DDLEventHandler) {
+	c.rsMu.Lock()
+	defer c.rsMu.Unlock()
+	c.ddlHandlers = append(c.ddlHandlers, h...)
+}
+
+func (c *Canal) processDDLEventHandler(ctx context.Context, ev DDLEvent) error {
+	if !c.isTableSchema(ev.Schema) || !c.isTableAllowed(ev.Table) {
+		return nil
+	}
+
+	c.rsMu.RLock()
+	defer c.rsMu.RUnlock()
+
+	erg, ctx := errgroup.WithContext(ctx)
+	for _, h := range c.ddlHandlers {
+		h := h
+		erg.Go(func() error {
+			if err := h.DoDDL(ctx, ev); err != nil {
+				isInterr := errors.Is(err, errors.Interrupted)
+				c.opts.Log.Info("binlogsync.Canal.processDDLEventHandler.Go.DoDDL.error", log.Err(err), log.Stringer("handler_name", h),
+					log.Bool("is_interrupted", isInterr), log.String("action", ev.Action), log.String("schema", ev.Schema), log.String("table", ev.Table))
+				if isInterr {
+					return errors.WithStack(err)
+				}
+			}
+			return nil
+		})
 	}
 	return errors.WithStack(erg.Wait())
 }
 
 func (c *Canal) flushEventHandlers(ctx context.Context) error {
 	defer log.WhenDone(c.opts.Log).Info("binlogsync.Canal.flushEventHandlers")
+	if err := c.workers.wait(); err != nil {
+		return errors.WithStack(err)
+	}
 	c.rsMu.RLock()
 	defer c.rsMu.RUnlock()
 
@@ -0,0 +1,289 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+)
+
+// ElasticsearchOptions configures the Elasticsearch/OpenSearch handler.
+type ElasticsearchOptions struct {
+	// Name of the handler, returned by String. Defaults to "elasticsearch".
+	Name string
+	// URL of the cluster, e.g. http://localhost:9200. Required.
+	URL string
+	// Client defaults to http.DefaultClient.
+	Client *http.Client
+	// Indexes maps a table name to the name of the index. Events of tables
+	// not in the map get ignored. Required.
+	Indexes map[string]string
+	// MaxRetries defines how often a bulk request or its single operations get
+	// retried when the cluster responds with 429 Too Many Requests. Defaults
+	// to 3.
+	MaxRetries int
+	// RetryWait defines the duration to wait before the first retry. It
+	// doubles with each further retry. Defaults to 500ms.
+	RetryWait time.Duration
+}
+
+type esHandler struct {
+	o ElasticsearchOptions
+}
+
+// NewElasticsearchHandler creates a RowsEventHandler which maps the rows
+// events to bulk operations: an insert creates an "index", an update an
+// "update" with upsert and a delete a "delete" operation. The document ID
+// consists of the primary key values joined by an underscore. If an update
+// changes the primary key, the old document gets deleted. One bulk request gets
+// sent per rows event.
+func NewElasticsearchHandler(o ElasticsearchOptions) (RowsEventHandler, error) {
+	switch {
+	case o.URL == "":
+		return nil, errors.Empty.Newf("[binlogsync] Elasticsearch URL cannot be empty")
+	case len(o.Indexes) == 0:
+		return nil, errors.Empty.Newf("[binlogsync] Elasticsearch index mapping cannot be empty")
+	}
+	if o.Name == "" {
+		o.Name = "elasticsearch"
+	}
+	if o.Client == nil {
+		o.Client = http.DefaultClient
+	}
+	if o.MaxRetries == 0 {
+		o.MaxRetries = 3
+	}
+	if o.RetryWait == 0 {
+		o.RetryWait = 500 * time.Millisecond
+	}
+	o.URL = strings.TrimRight(o.URL, "/")
+	return &esHandler{o: o}, nil
+}
+
+// esBulkOp contains the action/metadata line and the optional source line of a
+// bulk operation.
+type esBulkOp struct {
+	meta   []byte
+	source []byte
+}
+
+func (eh *esHandler) Do(ctx context.Context, action string, t *ddl.Table, rows [][]interface{}) error {
+	index, ok := eh.o.Indexes[t.Name]
+	if !ok {
+		return nil
+	}
+	ops, err := eh.bulkOps(action, t, index, rows)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	wait := eh.o.RetryWait
+	for retry := 0; ; retry++ {
+		if ops, err = eh.bulk(ctx, ops); err != nil {
+			return errors.WithStack(err)
+		}
+		if len(ops) == 0 {
+			return nil
+		}
+		if retry == eh.o.MaxRetries {
+			return errors.Timeout.Newf("[binlogsync] Elasticsearch rejected %d operations for index %q after %d retries", len(ops), index, retry)
+		}
+		select {
+		case <-ctx.Done():
+			return errors.WithStack(ctx.Err())
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+func (eh *esHandler) bulkOps(action string, t *ddl.Table, index string, rows [][]interface{}) ([]esBulkOp, error) {
+	if action == UpdateAction && len(rows)%2 != 0 {
+		return nil, errors.NotValid.Newf("[binlogsync] Table %q: update event requires an even number of rows, got %d", t.Name, len(rows))
+	}
+	if len(t.Columns.PrimaryKeys()) == 0 {
+		return nil, errors.NotSupported.Newf("[binlogsync] Table %q requires a primary key for Elasticsearch", t.Name)
+	}
+
+	var ops []esBulkOp
+	for i := 0; i < len(rows); i++ {
+		var before, after []interface{}
+		switch action {
+		case InsertAction:
+			after = rows[i]
+		case DeleteAction:
+			before = rows[i]
+		default:
+			before, after = rows[i], rows[i+1]
+			i++
+		}
+
+		var beforeID, afterID string
+		var err error
+		if before != nil {
+			if beforeID, err = esDocumentID(t, before); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+		if after != nil {
+			if afterID, err = esDocumentID(t, after); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+
+		if before != nil && beforeID != afterID {
+			op, err := esOperation("delete", index, beforeID, nil)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			ops = append(ops, op)
+		}
+		if after == nil {
+			continue
+		}
+		doc := esDocument(t, after)
+		var op esBulkOp
+		if action == UpdateAction && beforeID == afterID {
+			op, err = esOperation("update", index, afterID, map[string]interface{}{"doc": doc, "doc_as_upsert": true})
+		} else {
+			op, err = esOperation("index", index, afterID, doc)
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+func esOperation(op, index, id string, source interface{}) (o esBulkOp, err error) {
+	o.meta, err = json.Marshal(map[string]map[string]string{op: {"_index": index, "_id": id}})
+	if err != nil {
+		return o, errors.WithStack(err)
+	}
+	if source != nil {
+		if o.source, err = json.Marshal(source); err != nil {
+			return o, errors.WithStack(err)
+		}
+	}
+	return o, nil
+}
+
+func esDocumentID(t *ddl.Table, row []interface{}) (string, error) {
+	if lc, lr := len(t.Columns), len(row); lc != lr {
+		return "", errors.Mismatch.Newf("[binlogsync] Table %q has %d columns but the row contains %d values", t.Name, lc, lr)
+	}
+	var buf strings.Builder
+	for i, c := range t.Columns {
+		if !c.IsPK() {
+			continue
+		}
+		if buf.Len() > 0 {
+			buf.WriteByte('_')
+		}
+		if b, ok := row[i].([]byte); ok {
+			buf.Write(b)
+		} else {
+			fmt.Fprint(&buf, rowValue(c, row[i]))
+		}
+	}
+	return buf.String(), nil
+}
+
+func esDocument(t *ddl.Table, row []interface{}) map[string]interface{} {
+	doc := make(map[string]interface{}, len(row))
+	for i, v := range row {
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		doc[t.Columns[i].Field] = rowValue(t.Columns[i], v)
+	}
+	return doc
+}
+
+// bulk sends the operations and returns those which got rejected with status
+// 429 and must be retried.
+func (eh *esHandler) bulk(ctx context.Context, ops []esBulkOp) ([]esBulkOp, error) {
+	var body bytes.Buffer
+	for _, op := range ops {
+		body.Write(op.meta)
+		body.WriteByte('\n')
+		if op.source != nil {
+			body.Write(op.source)
+			body.WriteByte('\n')
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, eh.o.URL+"/_bulk", &body)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	resp, err := eh.o.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return ops, nil
+	case resp.StatusCode >= 300:
+		data, _ := ioutil.ReadAll(resp.Body)
+		return nil, errors.NotValid.Newf("[binlogsync] Elasticsearch bulk request failed with status %d: %s", resp.StatusCode, data)
+	}
+
+	var br struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&br); err != nil {
+		return nil, errors.BadEncoding.New(err, "[binlogsync] Elasticsearch bulk response")
+	}
+	if !br.Errors {
+		return nil, nil
+	}
+
+	var retry []esBulkOp
+	for i, item := range br.Items {
+		for op, res := range item {
+			switch {
+			case res.Status == http.StatusTooManyRequests && i < len(ops):
+				retry = append(retry, ops[i])
+			case op == "delete" && res.Status == http.StatusNotFound:
+				// document already deleted
+			case res.Status >= 300:
+				return nil, errors.NotValid.Newf("[binlogsync] Elasticsearch %s operation failed with status %d: %s", op, res.Status, res.Error)
+			}
+		}
+	}
+	return retry, nil
+}
+
+func (eh *esHandler) Complete(context.Context) error { return nil }
+
+func (eh *esHandler) String() string { return eh.o.Name }
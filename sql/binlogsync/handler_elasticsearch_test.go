@@ -0,0 +1,137 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/binlogsync"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestNewElasticsearchHandler(t *testing.T) {
+	t.Parallel()
+
+	tbl := ddl.NewTable("catalog_product_entity",
+		&ddl.Column{Field: "entity_id", DataType: "int", ColumnType: "int(10) unsigned", Key: "PRI"},
+		&ddl.Column{Field: "sku", DataType: "varchar", ColumnType: "varchar(64)"},
+	)
+	ctx := context.TODO()
+
+	t.Run("validation", func(t *testing.T) {
+		_, err := binlogsync.NewElasticsearchHandler(binlogsync.ElasticsearchOptions{})
+		assert.True(t, errors.Empty.Match(err), "%+v", err)
+	})
+
+	t.Run("bulk with retries", func(t *testing.T) {
+		var mu sync.Mutex
+		var bodies []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			data, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(data))
+			assert.Exactly(t, "/_bulk", r.URL.Path)
+			assert.Exactly(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+			switch len(bodies) {
+			case 1:
+				w.WriteHeader(http.StatusTooManyRequests)
+			case 2:
+				w.Write([]byte(`{"errors":true,"items":[{"delete":{"status":404}},{"index":{"status":429,"error":{"type":"es_rejected_execution_exception"}}}]}`))
+			default:
+				w.Write([]byte(`{"errors":false,"items":[{"index":{"status":201}}]}`))
+			}
+		}))
+		defer srv.Close()
+
+		h, err := binlogsync.NewElasticsearchHandler(binlogsync.ElasticsearchOptions{
+			URL:       srv.URL + "/",
+			Indexes:   map[string]string{"catalog_product_entity": "products"},
+			RetryWait: time.Millisecond,
+		})
+		assert.NoError(t, err)
+		assert.Exactly(t, "elasticsearch", h.String())
+
+		// primary key changes from 3 to 4
+		assert.NoError(t, h.Do(ctx, binlogsync.UpdateAction, tbl, [][]interface{}{
+			{int32(3), []byte("SKU-3")}, {int32(4), []byte("SKU-4")},
+		}))
+
+		const wantAll = `{"delete":{"_id":"3","_index":"products"}}
+{"index":{"_id":"4","_index":"products"}}
+{"entity_id":4,"sku":"SKU-4"}
+`
+		assert.Exactly(t, []string{wantAll, wantAll, `{"index":{"_id":"4","_index":"products"}}
+{"entity_id":4,"sku":"SKU-4"}
+`}, bodies)
+	})
+
+	t.Run("update and ignored table", func(t *testing.T) {
+		var body string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := ioutil.ReadAll(r.Body)
+			body = string(data)
+			w.Write([]byte(`{"errors":false,"items":[{"update":{"status":200}}]}`))
+		}))
+		defer srv.Close()
+
+		h, err := binlogsync.NewElasticsearchHandler(binlogsync.ElasticsearchOptions{
+			URL:     srv.URL,
+			Indexes: map[string]string{"catalog_product_entity": "products"},
+		})
+		assert.NoError(t, err)
+
+		assert.NoError(t, h.Do(ctx, binlogsync.InsertAction, ddl.NewTable("store"), [][]interface{}{{1}}))
+		assert.Exactly(t, "", body)
+
+		assert.NoError(t, h.Do(ctx, binlogsync.UpdateAction, tbl, [][]interface{}{
+			{int32(3), "SKU-3"}, {int32(3), "SKU-3a"},
+		}))
+		assert.Exactly(t, `{"update":{"_id":"3","_index":"products"}}
+{"doc":{"entity_id":3,"sku":"SKU-3a"},"doc_as_upsert":true}
+`, body)
+
+		// unsigned value above math.MaxInt32
+		assert.NoError(t, h.Do(ctx, binlogsync.UpdateAction, tbl, [][]interface{}{
+			{int32(-1), "SKU-X"}, {int32(-1), "SKU-Y"},
+		}))
+		assert.Exactly(t, `{"update":{"_id":"4294967295","_index":"products"}}
+{"doc":{"entity_id":4294967295,"sku":"SKU-Y"},"doc_as_upsert":true}
+`, body)
+	})
+
+	t.Run("operation error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"errors":true,"items":[{"delete":{"status":400,"error":{"type":"illegal_argument_exception"}}}]}`))
+		}))
+		defer srv.Close()
+
+		h, err := binlogsync.NewElasticsearchHandler(binlogsync.ElasticsearchOptions{
+			URL:     srv.URL,
+			Indexes: map[string]string{"catalog_product_entity": "products"},
+		})
+		assert.NoError(t, err)
+		err = h.Do(ctx, binlogsync.DeleteAction, tbl, [][]interface{}{{int32(3), "SKU-3"}})
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+}
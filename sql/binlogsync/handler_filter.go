@@ -0,0 +1,93 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync
+
+import (
+	"context"
+	"sync"
+
+	"github.com/corestoreio/pkg/sql/ddl"
+)
+
+type columnProjection struct {
+	src *ddl.Table
+	dst *ddl.Table
+	idx []int
+}
+
+type columnFilterHandler struct {
+	RowsEventHandler
+	columns map[string]bool
+	mu      sync.Mutex
+	// projections caches per table name the projected table. Gets rebuilt
+	// once the table structure has been reloaded.
+	projections map[string]columnProjection
+}
+
+// NewColumnFilterHandler wraps h so that h receives only the provided columns
+// of a table. The table passed to h.Do contains only the filtered columns in
+// the order of the original table and the rows get reduced accordingly. If a
+// table contains none of the columns, h.Do does not get called. The returned
+// handler does not implement TxEventHandler.
+func NewColumnFilterHandler(h RowsEventHandler, columns ...string) RowsEventHandler {
+	cf := &columnFilterHandler{
+		RowsEventHandler: h,
+		columns:          make(map[string]bool, len(columns)),
+		projections:      make(map[string]columnProjection),
+	}
+	for _, c := range columns {
+		cf.columns[c] = true
+	}
+	return cf
+}
+
+func (cf *columnFilterHandler) projection(t *ddl.Table) columnProjection {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+
+	if p, ok := cf.projections[t.Name]; ok && p.src == t {
+		return p
+	}
+	p := columnProjection{src: t}
+	var cols ddl.Columns
+	for i, c := range t.Columns {
+		if cf.columns[c.Field] {
+			p.idx = append(p.idx, i)
+			cols = append(cols, c)
+		}
+	}
+	p.dst = ddl.NewTable(t.Name, cols...)
+	p.dst.Schema = t.Schema
+	cf.projections[t.Name] = p
+	return p
+}
+
+func (cf *columnFilterHandler) Do(ctx context.Context, action string, t *ddl.Table, rows [][]interface{}) error {
+	p := cf.projection(t)
+	if len(p.idx) == 0 {
+		return nil
+	}
+	filtered := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		fr := make([]interface{}, 0, len(p.idx))
+		for _, idx := range p.idx {
+			if idx < len(row) {
+				fr = append(fr, row[idx])
+			}
+		}
+		filtered[i] = fr
+	}
+	return cf.RowsEventHandler.Do(ctx, action, p.dst, filtered)
+}
@@ -0,0 +1,63 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/corestoreio/pkg/sql/binlogsync"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+type rowsRecorder struct {
+	calls int
+	table *ddl.Table
+	rows  [][]interface{}
+}
+
+func (rr *rowsRecorder) Do(_ context.Context, _ string, t *ddl.Table, rows [][]interface{}) error {
+	rr.calls++
+	rr.table = t
+	rr.rows = rows
+	return nil
+}
+func (rr *rowsRecorder) Complete(context.Context) error { return nil }
+func (rr *rowsRecorder) String() string                 { return "rows_recorder" }
+
+func TestNewColumnFilterHandler(t *testing.T) {
+	t.Parallel()
+
+	tbl := newSalesOrderTable()
+	rr := &rowsRecorder{}
+	h := binlogsync.NewColumnFilterHandler(rr, "grand_total", "entity_id")
+	ctx := context.TODO()
+
+	assert.NoError(t, h.Do(ctx, binlogsync.UpdateAction, tbl, [][]interface{}{
+		{int32(1), "a@b.c", 4.5}, {int32(1), "d@e.f", 5.5},
+	}))
+	assert.Exactly(t, []string{"entity_id", "grand_total"}, rr.table.Columns.FieldNames())
+	assert.Exactly(t, [][]interface{}{{int32(1), 4.5}, {int32(1), 5.5}}, rr.rows)
+	tblFiltered := rr.table
+
+	assert.NoError(t, h.Do(ctx, binlogsync.DeleteAction, tbl, [][]interface{}{{int32(2), nil, nil}}))
+	assert.True(t, tblFiltered == rr.table, "projected table must be cached")
+
+	assert.NoError(t, h.Do(ctx, binlogsync.InsertAction, ddl.NewTable("store",
+		&ddl.Column{Field: "store_id"},
+	), [][]interface{}{{1}}))
+	assert.Exactly(t, 2, rr.calls, "table without filtered columns must be skipped")
+}
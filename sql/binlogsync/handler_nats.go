@@ -0,0 +1,131 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build nats csall
+
+package binlogsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"text/template"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/nats-io/nats.go"
+)
+
+// ChangeEvent gets published for each changed row. Before is nil for an insert
+// and After is nil for a delete. The maps contain the column names as keys.
+type ChangeEvent struct {
+	Schema string                 `json:"schema"`
+	Table  string                 `json:"table"`
+	Action string                 `json:"action"`
+	Before map[string]interface{} `json:"before,omitempty"`
+	After  map[string]interface{} `json:"after,omitempty"`
+}
+
+// NATSPublisher gets implemented by nats.JetStreamContext.
+type NATSPublisher interface {
+	Publish(subj string, data []byte, opts ...nats.PubOpt) (*nats.PubAck, error)
+}
+
+// NATSOptions configures the NATS JetStream handler.
+type NATSOptions struct {
+	// Name of the handler, returned by String. Defaults to "nats".
+	Name string
+	// SubjectTemplate defines the subject of a message as text/template. The
+	// template receives the ChangeEvent. Defaults to
+	// "binlogsync.{{.Schema}}.{{.Table}}.{{.Action}}".
+	SubjectTemplate string
+	// Encode converts the ChangeEvent into the message payload. Defaults to
+	// json.Marshal.
+	Encode func(ChangeEvent) ([]byte, error)
+	// PubOpts get applied to each publish, for example nats.ExpectStream.
+	PubOpts []nats.PubOpt
+}
+
+type natsHandler struct {
+	js      NATSPublisher
+	o       NATSOptions
+	subject *template.Template
+}
+
+// NewNATSHandler creates a RowsEventHandler which publishes one message per
+// changed row to NATS JetStream. The publish waits for the acknowledgement of
+// the server, so Complete has nothing to flush. Requires the build tag "nats"
+// or "csall".
+func NewNATSHandler(js NATSPublisher, o NATSOptions) (RowsEventHandler, error) {
+	if o.Name == "" {
+		o.Name = "nats"
+	}
+	if o.SubjectTemplate == "" {
+		o.SubjectTemplate = "binlogsync.{{.Schema}}.{{.Table}}.{{.Action}}"
+	}
+	if o.Encode == nil {
+		o.Encode = func(ce ChangeEvent) ([]byte, error) { return json.Marshal(ce) }
+	}
+	tpl, err := template.New(o.Name).Option("missingkey=error").Parse(o.SubjectTemplate)
+	if err != nil {
+		return nil, errors.NotValid.New(err, "[binlogsync] NATS subject template %q", o.SubjectTemplate)
+	}
+	return &natsHandler{js: js, o: o, subject: tpl}, nil
+}
+
+func (nh *natsHandler) Do(ctx context.Context, action string, t *ddl.Table, rows [][]interface{}) error {
+	if action == UpdateAction && len(rows)%2 != 0 {
+		return errors.NotValid.Newf("[binlogsync] Table %q: update event requires an even number of rows, got %d", t.Name, len(rows))
+	}
+
+	var buf bytes.Buffer
+	for i := 0; i < len(rows); i++ {
+		if err := ctx.Err(); err != nil {
+			return errors.WithStack(err)
+		}
+		ce := ChangeEvent{Schema: t.Schema, Table: t.Name, Action: action}
+		var err error
+		switch action {
+		case InsertAction:
+			ce.After, err = rowMap(t, rows[i])
+		case DeleteAction:
+			ce.Before, err = rowMap(t, rows[i])
+		default:
+			if ce.Before, err = rowMap(t, rows[i]); err == nil {
+				i++
+				ce.After, err = rowMap(t, rows[i])
+			}
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		buf.Reset()
+		if err := nh.subject.Execute(&buf, ce); err != nil {
+			return errors.NotValid.New(err, "[binlogsync] NATS subject template for table %q", t.Name)
+		}
+		data, err := nh.o.Encode(ce)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if _, err := nh.js.Publish(buf.String(), data, nh.o.PubOpts...); err != nil {
+			return errors.Wrapf(err, "[binlogsync] NATS publish to subject %q failed", buf.String())
+		}
+	}
+	return nil
+}
+
+func (nh *natsHandler) Complete(context.Context) error { return nil }
+
+func (nh *natsHandler) String() string { return nh.o.Name }
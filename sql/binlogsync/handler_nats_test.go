@@ -0,0 +1,103 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build nats csall
+
+package binlogsync_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/binlogsync"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/nats-io/nats.go"
+)
+
+type natsRecorder struct {
+	subjects []string
+	data     []string
+	err      error
+}
+
+func (nr *natsRecorder) Publish(subj string, data []byte, _ ...nats.PubOpt) (*nats.PubAck, error) {
+	nr.subjects = append(nr.subjects, subj)
+	nr.data = append(nr.data, string(data))
+	return &nats.PubAck{}, nr.err
+}
+
+func TestNewNATSHandler(t *testing.T) {
+	t.Parallel()
+
+	tbl := ddl.NewTable("store",
+		&ddl.Column{Field: "store_id", DataType: "smallint", ColumnType: "smallint(5) unsigned", Key: "PRI"},
+		&ddl.Column{Field: "code", DataType: "varchar", ColumnType: "varchar(32)"},
+	)
+	tbl.Schema = "magento"
+	ctx := context.TODO()
+
+	t.Run("default json encoding", func(t *testing.T) {
+		nr := &natsRecorder{}
+		h, err := binlogsync.NewNATSHandler(nr, binlogsync.NATSOptions{})
+		assert.NoError(t, err)
+		assert.Exactly(t, "nats", h.String())
+
+		assert.NoError(t, h.Do(ctx, binlogsync.UpdateAction, tbl, [][]interface{}{
+			{int16(1), "de"}, {int16(1), "at"},
+		}))
+		assert.Exactly(t, []string{"binlogsync.magento.store.update"}, nr.subjects)
+		assert.Exactly(t, `{"schema":"magento","table":"store","action":"update","before":{"code":"de","store_id":1},"after":{"code":"at","store_id":1}}`, nr.data[0])
+	})
+
+	t.Run("custom subject and encoder", func(t *testing.T) {
+		nr := &natsRecorder{}
+		h, err := binlogsync.NewNATSHandler(nr, binlogsync.NATSOptions{
+			SubjectTemplate: "cdc.{{.Table}}",
+			Encode: func(ce binlogsync.ChangeEvent) ([]byte, error) {
+				return json.Marshal(ce.After)
+			},
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, h.Do(ctx, binlogsync.InsertAction, tbl, [][]interface{}{
+			{int16(2), "fr"}, {int16(3), "ch"},
+		}))
+		assert.Exactly(t, []string{"cdc.store", "cdc.store"}, nr.subjects)
+		assert.Exactly(t, []string{`{"code":"fr","store_id":2}`, `{"code":"ch","store_id":3}`}, nr.data)
+	})
+
+	t.Run("unsigned values", func(t *testing.T) {
+		nr := &natsRecorder{}
+		h, err := binlogsync.NewNATSHandler(nr, binlogsync.NATSOptions{SubjectTemplate: "cdc.{{.Table}}.{{.After.store_id}}"})
+		assert.NoError(t, err)
+		assert.NoError(t, h.Do(ctx, binlogsync.InsertAction, tbl, [][]interface{}{{int16(-1), "xx"}}))
+		assert.Exactly(t, []string{"cdc.store.65535"}, nr.subjects)
+		assert.Exactly(t, `{"schema":"magento","table":"store","action":"insert","after":{"code":"xx","store_id":65535}}`, nr.data[0])
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := binlogsync.NewNATSHandler(&natsRecorder{}, binlogsync.NATSOptions{SubjectTemplate: "cdc.{{.Table"})
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+
+	t.Run("publish error", func(t *testing.T) {
+		h, err := binlogsync.NewNATSHandler(&natsRecorder{err: nats.ErrTimeout}, binlogsync.NATSOptions{})
+		assert.NoError(t, err)
+		err = h.Do(ctx, binlogsync.DeleteAction, tbl, [][]interface{}{{int16(2), "fr"}})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "binlogsync.magento.store.delete")
+	})
+}
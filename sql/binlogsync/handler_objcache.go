@@ -0,0 +1,136 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync
+
+import (
+	"context"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/storage/objcache"
+)
+
+// ObjcacheOptions configures the objcache invalidation handler.
+type ObjcacheOptions struct {
+	// Name of the handler, returned by String. Defaults to "objcache".
+	Name string
+	// KeyTemplates maps a table name to a text/template which creates the
+	// cache key of a row. The template receives the row as a map with the
+	// column names as keys, for example: "product_{{.entity_id}}". Events of
+	// tables not in the map get ignored. Required.
+	KeyTemplates map[string]string
+	// Update writes the new row, as map[string]interface{}, into the cache
+	// instead of deleting the key after an insert or update. Requires a
+	// configured Codec in the objcache.Service.
+	Update bool
+	// Expires applies to the updated keys. Zero uses the default expiration of
+	// the objcache.Service.
+	Expires time.Duration
+}
+
+type objcacheHandler struct {
+	svc  *objcache.Service
+	o    ObjcacheOptions
+	keys map[string]*template.Template
+}
+
+// NewObjcacheHandler creates a RowsEventHandler which keeps the cache
+// consistent with the database. The keys of the deleted rows and of the rows
+// before an update get deleted. The keys of the inserted and updated rows get
+// deleted or, with option Update, overwritten with the new row.
+func NewObjcacheHandler(svc *objcache.Service, o ObjcacheOptions) (RowsEventHandler, error) {
+	if len(o.KeyTemplates) == 0 {
+		return nil, errors.Empty.Newf("[binlogsync] objcache key templates cannot be empty")
+	}
+	if o.Name == "" {
+		o.Name = "objcache"
+	}
+	oh := &objcacheHandler{
+		svc:  svc,
+		o:    o,
+		keys: make(map[string]*template.Template, len(o.KeyTemplates)),
+	}
+	for tbl, tplStr := range o.KeyTemplates {
+		tpl, err := template.New(tbl).Option("missingkey=error").Parse(tplStr)
+		if err != nil {
+			return nil, errors.NotValid.New(err, "[binlogsync] objcache key template %q for table %q", tplStr, tbl)
+		}
+		oh.keys[tbl] = tpl
+	}
+	return oh, nil
+}
+
+func (oh *objcacheHandler) Do(ctx context.Context, action string, t *ddl.Table, rows [][]interface{}) error {
+	tpl, ok := oh.keys[t.Name]
+	if !ok {
+		return nil
+	}
+	if action == UpdateAction && len(rows)%2 != 0 {
+		return errors.NotValid.Newf("[binlogsync] Table %q: update event requires an even number of rows, got %d", t.Name, len(rows))
+	}
+
+	var delKeys, setKeys []string
+	var setRows []interface{}
+	var buf strings.Builder
+	key := func(row map[string]interface{}) (string, error) {
+		buf.Reset()
+		if err := tpl.Execute(&buf, row); err != nil {
+			return "", errors.NotValid.New(err, "[binlogsync] objcache key template for table %q", t.Name)
+		}
+		return buf.String(), nil
+	}
+
+	for i, row := range rows {
+		m, err := rowMap(t, row)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		k, err := key(m)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		isBefore := action == DeleteAction || (action == UpdateAction && i%2 == 0)
+		switch {
+		case isBefore || !oh.o.Update:
+			delKeys = append(delKeys, k)
+		default:
+			setKeys = append(setKeys, k)
+			setRows = append(setRows, m)
+		}
+	}
+
+	if len(delKeys) > 0 {
+		if err := oh.svc.Delete(ctx, delKeys...); err != nil {
+			return errors.Wrapf(err, "[binlogsync] objcache failed to delete keys %v", delKeys)
+		}
+	}
+	if len(setKeys) > 0 {
+		expires := make([]time.Duration, len(setKeys))
+		for i := range expires {
+			expires[i] = oh.o.Expires
+		}
+		if err := oh.svc.SetMulti(ctx, setKeys, setRows, expires); err != nil {
+			return errors.Wrapf(err, "[binlogsync] objcache failed to set keys %v", setKeys)
+		}
+	}
+	return nil
+}
+
+func (oh *objcacheHandler) Complete(context.Context) error { return nil }
+
+func (oh *objcacheHandler) String() string { return oh.o.Name }
@@ -0,0 +1,102 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/binlogsync"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/storage/objcache"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+type jsonCodec struct{}
+
+func (c jsonCodec) NewEncoder(w io.Writer) objcache.Encoder {
+	return json.NewEncoder(w)
+}
+
+func (c jsonCodec) NewDecoder(r io.Reader) objcache.Decoder {
+	return json.NewDecoder(r)
+}
+
+func TestNewObjcacheHandler(t *testing.T) {
+	t.Parallel()
+
+	tbl := ddl.NewTable("store",
+		&ddl.Column{Field: "store_id", DataType: "smallint", ColumnType: "smallint(5) unsigned", Key: "PRI"},
+		&ddl.Column{Field: "code", DataType: "varchar", ColumnType: "varchar(32)"},
+	)
+	ctx := context.TODO()
+	keyTemplates := map[string]string{"store": "store_{{.store_id}}"}
+
+	newService := func(t *testing.T) *objcache.Service {
+		svc, err := objcache.NewService(nil, objcache.NewCacheSimpleInmemory, &objcache.ServiceOptions{Codec: jsonCodec{}})
+		assert.NoError(t, err)
+		assert.NoError(t, svc.Set(ctx, "store_1", map[string]interface{}{"code": "de"}, 0))
+		assert.NoError(t, svc.Set(ctx, "store_2", map[string]interface{}{"code": "at"}, 0))
+		return svc
+	}
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := binlogsync.NewObjcacheHandler(newService(t), binlogsync.ObjcacheOptions{
+			KeyTemplates: map[string]string{"store": "store_{{.store_id"},
+		})
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+
+	t.Run("delete keys", func(t *testing.T) {
+		svc := newService(t)
+		h, err := binlogsync.NewObjcacheHandler(svc, binlogsync.ObjcacheOptions{KeyTemplates: keyTemplates})
+		assert.NoError(t, err)
+		assert.Exactly(t, "objcache", h.String())
+
+		assert.NoError(t, h.Do(ctx, binlogsync.UpdateAction, tbl, [][]interface{}{
+			{int16(1), "de"}, {int16(1), "ch"},
+		}))
+		assert.NoError(t, h.Do(ctx, binlogsync.InsertAction, ddl.NewTable("website"), [][]interface{}{{1}}))
+
+		var store1, store2 map[string]interface{}
+		assert.NoError(t, svc.Get(ctx, "store_1", &store1))
+		assert.NoError(t, svc.Get(ctx, "store_2", &store2))
+		assert.Nil(t, store1)
+		assert.Exactly(t, map[string]interface{}{"code": "at"}, store2)
+	})
+
+	t.Run("update keys", func(t *testing.T) {
+		svc := newService(t)
+		h, err := binlogsync.NewObjcacheHandler(svc, binlogsync.ObjcacheOptions{KeyTemplates: keyTemplates, Update: true})
+		assert.NoError(t, err)
+
+		assert.NoError(t, h.Do(ctx, binlogsync.InsertAction, tbl, [][]interface{}{{int16(3), "ch"}}))
+		assert.NoError(t, h.Do(ctx, binlogsync.DeleteAction, tbl, [][]interface{}{{int16(2), "at"}}))
+
+		var store2, store3 map[string]interface{}
+		assert.NoError(t, svc.Get(ctx, "store_2", &store2))
+		assert.NoError(t, svc.Get(ctx, "store_3", &store3))
+		assert.Nil(t, store2)
+		assert.Exactly(t, map[string]interface{}{"code": "ch", "store_id": 3.0}, store3)
+
+		assert.NoError(t, h.Do(ctx, binlogsync.InsertAction, tbl, [][]interface{}{{int16(-2), "zz"}}))
+		var store65534 map[string]interface{}
+		assert.NoError(t, svc.Get(ctx, "store_65534", &store65534))
+		assert.Exactly(t, map[string]interface{}{"code": "zz", "store_id": 65534.0}, store65534)
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/storage/objcache"
+	"github.com/corestoreio/pkg/store/scope"
+)
+
+// PositionStorer persists the master position of the binlog stream to continue
+// the sync after a restart. The file name, the position and the executed GTID
+// set must be written at once, so a reader never sees a partial update.
+type PositionStorer interface {
+	// SavePosition writes the current master position.
+	SavePosition(ctx context.Context, ms ddl.MasterStatus) error
+	// LoadPosition returns the last saved master position. It returns a
+	// NotFound error when no position has been saved yet.
+	LoadPosition(ctx context.Context) (ddl.MasterStatus, error)
+}
+
+type positionFile string
+
+// NewPositionFile stores the master position in a file. A save writes into a
+// temporary file in the same directory and renames it afterwards.
+func NewPositionFile(fileName string) PositionStorer {
+	return positionFile(fileName)
+}
+
+func (pf positionFile) SavePosition(_ context.Context, ms ddl.MasterStatus) error {
+	fileName := string(pf)
+	f, err := ioutil.TempFile(filepath.Dir(fileName), filepath.Base(fileName)+".tmp")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err = ms.WriteTo(f); err == nil {
+		err = f.Sync()
+	}
+	if errC := f.Close(); err == nil {
+		err = errC
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), fileName)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return errors.Wrapf(err, "[binlogsync] Failed to save master position to file %q", fileName)
+	}
+	return nil
+}
+
+func (pf positionFile) LoadPosition(_ context.Context) (ms ddl.MasterStatus, err error) {
+	data, err := ioutil.ReadFile(string(pf))
+	if os.IsNotExist(err) || (err == nil && len(data) == 0) {
+		return ms, errors.NotFound.Newf("[binlogsync] Master position file %q not found or empty", string(pf))
+	}
+	if err != nil {
+		return ms, errors.WithStack(err)
+	}
+	err = ms.UnmarshalText(data)
+	return ms, errors.WithStack(err)
+}
+
+type positionTable struct {
+	name string
+	save *dml.Artisan
+	load *dml.Artisan
+}
+
+// NewPositionTable stores the master position in a MySQL table. Argument name
+// identifies the row, which allows several Canals to share one table. The table
+// requires the following structure:
+//		CREATE TABLE `binlogsync_position` (
+//			`name` VARCHAR(64) NOT NULL PRIMARY KEY,
+//			`master_status` VARCHAR(2048) NOT NULL
+//		) ENGINE=InnoDB
+// The database should not be the one which gets synced, otherwise each save
+// triggers a new binlog event.
+func NewPositionTable(dbc *dml.ConnPool, tableName, name string) PositionStorer {
+	return positionTable{
+		name: name,
+		save: dbc.ReplaceInto(tableName).AddColumns("name", "master_status").BuildValues().WithArgs(),
+		load: dbc.SelectFrom(tableName).AddColumns("master_status").Where(dml.Column("name").Equal().PlaceHolder()).WithArgs(),
+	}
+}
+
+func (pt positionTable) SavePosition(ctx context.Context, ms ddl.MasterStatus) error {
+	if _, err := pt.save.ExecContext(ctx, pt.name, ms.String()); err != nil {
+		return errors.Wrapf(err, "[binlogsync] Failed to save master position %q", pt.name)
+	}
+	return nil
+}
+
+func (pt positionTable) LoadPosition(ctx context.Context) (ms ddl.MasterStatus, err error) {
+	str, found, err := pt.load.LoadNullString(ctx, pt.name)
+	switch {
+	case err != nil:
+		return ms, errors.Wrapf(err, "[binlogsync] Failed to load master position %q", pt.name)
+	case !found || str.String == "":
+		return ms, errors.NotFound.Newf("[binlogsync] Master position %q not found", pt.name)
+	}
+	err = ms.FromString(str.String)
+	return ms, errors.WithStack(err)
+}
+
+type positionObjcache struct {
+	svc *objcache.Service
+	key string
+}
+
+// NewPositionObjcache stores the master position under the provided key in a
+// cache service, for example Redis. The entry never expires.
+func NewPositionObjcache(svc *objcache.Service, key string) PositionStorer {
+	return positionObjcache{svc: svc, key: key}
+}
+
+func (po positionObjcache) SavePosition(ctx context.Context, ms ddl.MasterStatus) error {
+	return errors.WithStack(po.svc.Set(ctx, po.key, ms, 0))
+}
+
+func (po positionObjcache) LoadPosition(ctx context.Context) (ms ddl.MasterStatus, err error) {
+	if err = po.svc.Get(ctx, po.key, &ms); err != nil {
+		return ms, errors.WithStack(err)
+	}
+	if ms.File == "" {
+		return ms, errors.NotFound.Newf("[binlogsync] Master position with key %q not found", po.key)
+	}
+	return ms, nil
+}
+
+// positionConfig keeps the previous behaviour to persist the master position
+// via the configuration service.
+type positionConfig struct {
+	path   *config.Path
+	set    config.Setter
+	scoped config.Scoped
+}
+
+func (pc positionConfig) SavePosition(_ context.Context, ms ddl.MasterStatus) error {
+	data, _ := ms.MarshalText()
+	return errors.WithStack(pc.set.Set(pc.path, data))
+}
+
+func (pc positionConfig) LoadPosition(_ context.Context) (ms ddl.MasterStatus, err error) {
+	if !pc.scoped.IsValid() {
+		return ms, errors.NotFound.Newf("[binlogsync] Master position cannot be loaded without config.Scoped")
+	}
+	str, ok, err := pc.scoped.Get(scope.Default, ConfigPathBackendPosition).Str()
+	switch {
+	case err != nil:
+		return ms, errors.WithStack(err)
+	case !ok || str == "":
+		return ms, errors.NotFound.Newf("[binlogsync] Master position not found in configuration path %q", ConfigPathBackendPosition)
+	}
+	err = ms.FromString(str)
+	return ms, errors.WithStack(err)
+}
@@ -0,0 +1,106 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/binlogsync"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/storage/objcache"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+var testMasterStatus = ddl.MasterStatus{
+	File:            "mysql-bin.000004",
+	Position:        545460,
+	ExecutedGTIDSet: "3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5",
+}
+
+func TestNewPositionFile(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "binlogsync")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ps := binlogsync.NewPositionFile(filepath.Join(dir, "position.txt"))
+	ctx := context.TODO()
+
+	_, err = ps.LoadPosition(ctx)
+	assert.True(t, errors.NotFound.Match(err), "%+v", err)
+
+	assert.NoError(t, ps.SavePosition(ctx, testMasterStatus))
+	ms, err := ps.LoadPosition(ctx)
+	assert.NoError(t, err)
+	assert.Exactly(t, testMasterStatus, ms)
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1, "temporary file must be renamed")
+}
+
+func TestNewPositionTable(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	ps := binlogsync.NewPositionTable(dbc, "binlogsync_position", "canal1")
+	ctx := context.TODO()
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("REPLACE INTO `binlogsync_position` (`name`,`master_status`) VALUES (?,?)")).
+		WithArgs("canal1", "mysql-bin.000004;545460;3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, ps.SavePosition(ctx, testMasterStatus))
+
+	const selPos = "SELECT `master_status` FROM `binlogsync_position` WHERE (`name` = ?)"
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(selPos)).WithArgs("canal1").
+		WillReturnRows(sqlmock.NewRows([]string{"master_status"}).AddRow("mysql-bin.000004;545460;3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5"))
+	ms, err := ps.LoadPosition(ctx)
+	assert.NoError(t, err)
+	assert.Exactly(t, testMasterStatus, ms)
+
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(selPos)).WithArgs("canal1").
+		WillReturnRows(sqlmock.NewRows([]string{"master_status"}))
+	_, err = ps.LoadPosition(ctx)
+	assert.True(t, errors.NotFound.Match(err), "%+v", err)
+}
+
+func TestNewPositionObjcache(t *testing.T) {
+	t.Parallel()
+
+	svc, err := objcache.NewService(nil, objcache.NewCacheSimpleInmemory, nil)
+	assert.NoError(t, err)
+	defer svc.Close()
+
+	ps := binlogsync.NewPositionObjcache(svc, "binlogsync_position")
+	ctx := context.TODO()
+
+	_, err = ps.LoadPosition(ctx)
+	assert.True(t, errors.NotFound.Match(err), "%+v", err)
+
+	assert.NoError(t, ps.SavePosition(ctx, testMasterStatus))
+	ms, err := ps.LoadPosition(ctx)
+	assert.NoError(t, err)
+	assert.Exactly(t, testMasterStatus, ms)
+}
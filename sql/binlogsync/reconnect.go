@@ -0,0 +1,119 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+)
+
+// HealthState describes the state of the binlog stream.
+type HealthState int32
+
+// Health states reported to Options.OnHealthChange.
+const (
+	HealthStarting HealthState = iota
+	HealthSyncing
+	HealthReconnecting
+	HealthStopped
+)
+
+func (hs HealthState) String() string {
+	switch hs {
+	case HealthStarting:
+		return "starting"
+	case HealthSyncing:
+		return "syncing"
+	case HealthReconnecting:
+		return "reconnecting"
+	case HealthStopped:
+		return "stopped"
+	}
+	return "unknown"
+}
+
+// Health returns the current state of the binlog stream.
+func (c *Canal) Health() HealthState {
+	return HealthState(atomic.LoadInt32(&c.health))
+}
+
+// setHealth stores the state and calls Options.OnHealthChange if the state has
+// changed.
+func (c *Canal) setHealth(hs HealthState, err error) {
+	if old := atomic.SwapInt32(&c.health, int32(hs)); old == int32(hs) {
+		return
+	}
+	if c.opts.Log.IsInfo() {
+		fields := []log.Field{log.Stringer("state", hs)}
+		if err != nil {
+			fields = append(fields, log.Err(err))
+		}
+		c.opts.Log.Info("[binlogsync] Health state changed", fields...)
+	}
+	if c.opts.OnHealthChange != nil {
+		c.opts.OnHealthChange(hs, err)
+	}
+}
+
+// reconnectBackoff returns the exponential backoff duration for the attempt,
+// starting with zero.
+func (c *Canal) reconnectBackoff(attempt int) time.Duration {
+	wait := c.opts.ReconnectInitialBackoff
+	for i := 0; i < attempt && wait < c.opts.ReconnectMaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > c.opts.ReconnectMaxBackoff {
+		wait = c.opts.ReconnectMaxBackoff
+	}
+	return wait
+}
+
+// reconnect waits for the backoff duration and creates a new syncer. A still
+// buffered transaction gets dropped because the stream continues at the last
+// synced position, which is always at a transaction boundary.
+func (c *Canal) reconnect(ctx context.Context, attempt int, cause error) error {
+	c.setHealth(HealthReconnecting, cause)
+	wait := c.reconnectBackoff(attempt)
+	if c.opts.Log.IsInfo() {
+		c.opts.Log.Info("[binlogsync] Reconnecting binlog stream", log.Err(cause), log.Int("attempt", attempt+1),
+			log.Duration("backoff", wait), log.Stringer("position", c.SyncedPosition()))
+	}
+
+	select {
+	case <-ctx.Done():
+		return errors.WithStack(ctx.Err())
+	case <-c.closing:
+		return errors.AlreadyClosed.Newf("[binlogsync] Canal has been closed while reconnecting")
+	case <-time.After(wait):
+	}
+
+	c.syncerMu.Lock()
+	defer c.syncerMu.Unlock()
+	if c.isClosed() {
+		return errors.AlreadyClosed.Newf("[binlogsync] Canal has been closed while reconnecting")
+	}
+	if c.syncer != nil {
+		c.syncer.Close()
+	}
+	if err := withPrepareSyncer(c); err != nil {
+		return errors.WithStack(err)
+	}
+	c.txRollback()
+	return nil
+}
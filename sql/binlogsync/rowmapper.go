@@ -0,0 +1,185 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/storage/null"
+)
+
+// RowImage contains the typed image of a row before and after a change. Before
+// is nil for an insert and After is nil for a delete.
+type RowImage struct {
+	Before dml.ColumnMapper
+	After  dml.ColumnMapper
+}
+
+// TypedRowsEventHandler like RowsEventHandler but receives the rows decoded
+// into entities, for example generated by dmlgen. Register it via
+// NewTypedRowsEventHandler.
+type TypedRowsEventHandler interface {
+	// DoTyped handles the decoded rows of a rows event. Same rules apply here
+	// like for RowsEventHandler.Do.
+	DoTyped(ctx context.Context, action string, t *ddl.Table, rows []RowImage) error
+	// Complete runs before a binlog rotation event happens.
+	Complete(context.Context) error
+	// String returns the name of the handler
+	String() string
+}
+
+type typedRowsEventHandler struct {
+	TypedRowsEventHandler
+	newEntity func() dml.ColumnMapper
+}
+
+// NewTypedRowsEventHandler creates a RowsEventHandler which decodes each row via
+// DecodeRow into a new entity and calls h.DoTyped. Function newEntity must
+// return a new pointer to an entity of the table the handler gets registered
+// for.
+func NewTypedRowsEventHandler(newEntity func() dml.ColumnMapper, h TypedRowsEventHandler) RowsEventHandler {
+	return typedRowsEventHandler{TypedRowsEventHandler: h, newEntity: newEntity}
+}
+
+func (th typedRowsEventHandler) Do(ctx context.Context, action string, t *ddl.Table, rows [][]interface{}) error {
+	images, err := th.decodeRows(action, t, rows)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return th.DoTyped(ctx, action, t, images)
+}
+
+func (th typedRowsEventHandler) decodeRows(action string, t *ddl.Table, rows [][]interface{}) ([]RowImage, error) {
+	if action == UpdateAction && len(rows)%2 != 0 {
+		return nil, errors.NotValid.Newf("[binlogsync] Table %q: update event requires an even number of rows, got %d", t.Name, len(rows))
+	}
+	decode := func(row []interface{}) (dml.ColumnMapper, error) {
+		e := th.newEntity()
+		return e, errors.WithStack(DecodeRow(t, row, e))
+	}
+
+	var images []RowImage
+	for i := 0; i < len(rows); i++ {
+		var ri RowImage
+		var err error
+		switch action {
+		case InsertAction:
+			ri.After, err = decode(rows[i])
+		case DeleteAction:
+			ri.Before, err = decode(rows[i])
+		case UpdateAction:
+			if ri.Before, err = decode(rows[i]); err == nil {
+				i++
+				ri.After, err = decode(rows[i])
+			}
+		default:
+			return nil, errors.NotSupported.Newf("[binlogsync] Table %q: action %q not supported", t.Name, action)
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		images = append(images, ri)
+	}
+	return images, nil
+}
+
+// DecodeRow maps the positional values of a binlog row to the entity dst via
+// the dml.ColumnMapper interface. The values get assigned in the order of the
+// columns of the table. Signed integers of unsigned columns get converted to
+// their unsigned value.
+func DecodeRow(t *ddl.Table, row []interface{}, dst dml.ColumnMapper) error {
+	if lc, lr := len(t.Columns), len(row); lc != lr {
+		return errors.Mismatch.Newf("[binlogsync] Table %q has %d columns but the row contains %d values", t.Name, lc, lr)
+	}
+	values := make([]interface{}, len(row))
+	for i, v := range row {
+		values[i] = columnMapValue(t.Columns[i], v)
+	}
+	cm := dml.NewColumnMap(0)
+	if err := cm.ScanValues(t.Columns.FieldNames(), values); err != nil {
+		return errors.Wrapf(err, "[binlogsync] Table %q", t.Name)
+	}
+	return errors.WithStack(dst.MapColumns(cm))
+}
+
+// rowValue converts the signed integers created by the binlog decoder for
+// unsigned columns into their uint64 value. All other values are getting
+// returned unchanged.
+func rowValue(c *ddl.Column, v interface{}) interface{} {
+	unsigned := c.IsUnsigned()
+	switch val := v.(type) {
+	case int8:
+		if unsigned {
+			return uint64(uint8(val))
+		}
+		return int64(val)
+	case int16:
+		if unsigned {
+			return uint64(uint16(val))
+		}
+		return int64(val)
+	case int32:
+		if unsigned {
+			if c.DataType == "mediumint" {
+				return uint64(uint32(val) & 0xFFFFFF)
+			}
+			return uint64(uint32(val))
+		}
+		return int64(val)
+	case int64:
+		if unsigned {
+			return uint64(val)
+		}
+		return val
+	}
+	return v
+}
+
+// columnMapValue converts the types created by the binlog decoder into types
+// supported by dml.ColumnMap.
+func columnMapValue(c *ddl.Column, v interface{}) interface{} {
+	switch val := rowValue(c, v).(type) {
+	case uint64:
+		if val <= math.MaxInt64 {
+			return int64(val)
+		}
+		return []byte(strconv.FormatUint(val, 10))
+	case null.Decimal:
+		if !val.Valid {
+			return nil
+		}
+		return []byte(val.String())
+	default:
+		return val
+	}
+}
+
+// rowMap maps the positional values of a row to the column names of the table.
+// Unsigned integers are getting converted via rowValue.
+func rowMap(t *ddl.Table, row []interface{}) (map[string]interface{}, error) {
+	if lc, lr := len(t.Columns), len(row); lc != lr {
+		return nil, errors.Mismatch.Newf("[binlogsync] Table %q has %d columns but the row contains %d values", t.Name, lc, lr)
+	}
+	m := make(map[string]interface{}, len(row))
+	for i, v := range row {
+		m[t.Columns[i].Field] = rowValue(t.Columns[i], v)
+	}
+	return m, nil
+}
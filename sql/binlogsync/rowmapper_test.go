@@ -0,0 +1,116 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/binlogsync"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/storage/null"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+type salesOrder struct {
+	EntityID   uint32
+	Email      null.String
+	GrandTotal null.Decimal
+}
+
+func (e *salesOrder) MapColumns(cm *dml.ColumnMap) error {
+	for cm.Next() {
+		switch c := cm.Column(); c {
+		case "entity_id":
+			cm.Uint32(&e.EntityID)
+		case "customer_email":
+			cm.NullString(&e.Email)
+		case "grand_total":
+			cm.Decimal(&e.GrandTotal)
+		default:
+			return errors.NotFound.Newf("[binlogsync_test] salesOrder Column %q not found", c)
+		}
+	}
+	return cm.Err()
+}
+
+type typedRecorder struct {
+	action string
+	rows   []binlogsync.RowImage
+}
+
+func (tr *typedRecorder) DoTyped(_ context.Context, action string, _ *ddl.Table, rows []binlogsync.RowImage) error {
+	tr.action = action
+	tr.rows = rows
+	return nil
+}
+func (tr *typedRecorder) Complete(context.Context) error { return nil }
+func (tr *typedRecorder) String() string                 { return "typed_recorder" }
+
+func newSalesOrderTable() *ddl.Table {
+	return ddl.NewTable("sales_order",
+		&ddl.Column{Field: "entity_id", DataType: "int", ColumnType: "int(10) unsigned", Key: "PRI"},
+		&ddl.Column{Field: "customer_email", DataType: "varchar", ColumnType: "varchar(128)", Null: "YES"},
+		&ddl.Column{Field: "grand_total", DataType: "decimal", ColumnType: "decimal(20,4)", Null: "YES"},
+	)
+}
+
+func TestNewTypedRowsEventHandler(t *testing.T) {
+	t.Parallel()
+
+	tbl := newSalesOrderTable()
+	tr := &typedRecorder{}
+	h := binlogsync.NewTypedRowsEventHandler(func() dml.ColumnMapper { return new(salesOrder) }, tr)
+	ctx := context.TODO()
+
+	t.Run("insert", func(t *testing.T) {
+		err := h.Do(ctx, binlogsync.InsertAction, tbl, [][]interface{}{
+			{int32(-1), "a@b.c", null.MakeDecimalInt64(12345, 2)},
+		})
+		assert.NoError(t, err)
+		assert.Exactly(t, binlogsync.InsertAction, tr.action)
+		assert.Exactly(t, []binlogsync.RowImage{{
+			After: &salesOrder{EntityID: 4294967295, Email: null.MakeString("a@b.c"), GrandTotal: null.MakeDecimalInt64(12345, 2)},
+		}}, tr.rows)
+	})
+	t.Run("update", func(t *testing.T) {
+		err := h.Do(ctx, binlogsync.UpdateAction, tbl, [][]interface{}{
+			{int32(3), "a@b.c", nil},
+			{int32(3), nil, nil},
+		})
+		assert.NoError(t, err)
+		assert.Exactly(t, []binlogsync.RowImage{{
+			Before: &salesOrder{EntityID: 3, Email: null.MakeString("a@b.c")},
+			After:  &salesOrder{EntityID: 3},
+		}}, tr.rows)
+	})
+	t.Run("delete", func(t *testing.T) {
+		err := h.Do(ctx, binlogsync.DeleteAction, tbl, [][]interface{}{
+			{int32(4), nil, nil},
+		})
+		assert.NoError(t, err)
+		assert.Exactly(t, []binlogsync.RowImage{{Before: &salesOrder{EntityID: 4}}}, tr.rows)
+	})
+	t.Run("update odd rows", func(t *testing.T) {
+		err := h.Do(ctx, binlogsync.UpdateAction, tbl, [][]interface{}{{int32(3), nil, nil}})
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+	t.Run("column count mismatch", func(t *testing.T) {
+		err := h.Do(ctx, binlogsync.InsertAction, tbl, [][]interface{}{{int32(3), nil}})
+		assert.True(t, errors.Mismatch.Match(err), "%+v", err)
+	})
+}
@@ -1,14 +1,18 @@
 package binlogsync
 
 import (
+	"bytes"
 	"context"
 	"regexp"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/log"
 	"github.com/corestoreio/pkg/sql/ddl"
 	"github.com/corestoreio/pkg/sql/myreplicator"
+	"github.com/siddontang/go-mysql/mysql"
 )
 
 // Action constants to figure out the type of an event. Those constants will be
@@ -19,20 +23,34 @@ const (
 	DeleteAction = "delete"
 )
 
+// DDL action constants passed to the interface DDLEventHandler.
+const (
+	CreateTableAction = "create_table"
+	AlterTableAction  = "alter_table"
+	RenameTableAction = "rename_table"
+	DropTableAction   = "drop_table"
+)
+
 var (
+	queryBegin    = []byte("BEGIN")
+	queryCommit   = []byte("COMMIT")
+	queryRollback = []byte("ROLLBACK")
+
 	expCreateTable = regexp.MustCompile("(?i)^CREATE\\sTABLE(\\sIF\\sNOT\\sEXISTS)?\\s`{0,1}(.*?)`{0,1}\\.{0,1}`{0,1}([^`\\.]+?)`{0,1}\\s.*")
 	expAlterTable  = regexp.MustCompile("(?i)^ALTER\\sTABLE\\s.*?`{0,1}(.*?)`{0,1}\\.{0,1}`{0,1}([^`\\.]+?)`{0,1}\\s.*")
 	expRenameTable = regexp.MustCompile("(?i)^RENAME\\sTABLE\\s.*?`{0,1}(.*?)`{0,1}\\.{0,1}`{0,1}([^`\\.]+?)`{0,1}\\s{1,}TO\\s.*?")
 	expDropTable   = regexp.MustCompile("(?i)^DROP\\sTABLE(\\sIF\\sEXISTS){0,1}\\s`{0,1}(.*?)`{0,1}\\.{0,1}`{0,1}([^`\\.]+?)`{0,1}(?:$|\\s)")
 	ddlExpressions = [...]*regexp.Regexp{expCreateTable, expAlterTable, expRenameTable, expDropTable}
+	ddlActions     = [...]string{CreateTableAction, AlterTableAction, RenameTableAction, DropTableAction}
 )
 
-func extractTableFromQueryEvent(schema, query []byte) (dbName, tableName string) {
+func extractTableFromQueryEvent(schema, query []byte) (action, dbName, tableName string) {
 	var mb [][]byte
 
-	for _, reg := range ddlExpressions {
+	for i, reg := range ddlExpressions {
 		mb = reg.FindSubmatch(query)
 		if len(mb) != 0 {
+			action = ddlActions[i]
 			break
 		}
 	}
@@ -51,32 +69,111 @@ func extractTableFromQueryEvent(schema, query []byte) (dbName, tableName string)
 	return
 }
 
-func (c *Canal) clearTableCacheOnDDLStmt(schema, query []byte) {
-	defer log.WhenDone(c.opts.Log).Info("binlogsync.Canal.clearTableCacheOnDDLStmt")
-	if db, tbl := extractTableFromQueryEvent(schema, query); tbl != "" {
-		c.ClearTableCache(db, tbl)
-		if c.opts.Log.IsInfo() {
-			c.opts.Log.Info("[binlogsync] Table structure changed, clear table cache",
-				log.String("database", db), log.String("table", tbl), log.String("query", string(query)))
+// mergeMariaDBGTID replaces the GTID of the same domain in the comma separated
+// MariaDB GTID set or appends the GTID. A MariaDB GTID has the format
+// domain-server-sequence.
+func mergeMariaDBGTID(set, gtid string) string {
+	domain := gtid[:strings.IndexByte(gtid, '-')+1]
+	if set == "" {
+		return gtid
+	}
+	gtids := strings.Split(set, ",")
+	for i, g := range gtids {
+		if strings.HasPrefix(strings.TrimSpace(g), domain) {
+			gtids[i] = gtid
+			return strings.Join(gtids, ",")
 		}
 	}
+	return set + "," + gtid
+}
+
+// refreshTableOnDDLStmt removes the table of a CREATE, ALTER, RENAME or DROP
+// TABLE statement from the table cache. After a CREATE or ALTER statement the
+// table gets reloaded immediately, so that the following rows events use the
+// new column structure. A RENAME statement clears the whole cache because it
+// can swap several tables. Reload errors get only logged because FindTable
+// retries loading with the next rows event. Returns false if the query is not a
+// DDL statement.
+func (c *Canal) refreshTableOnDDLStmt(ctx context.Context, schema, query []byte) (DDLEvent, bool) {
+	defer log.WhenDone(c.opts.Log).Info("binlogsync.Canal.refreshTableOnDDLStmt")
+	action, db, tbl := extractTableFromQueryEvent(schema, query)
+	if tbl == "" {
+		return DDLEvent{}, false
+	}
+	ev := DDLEvent{Action: action, Schema: db, Table: tbl, Query: string(query)}
+
+	isRename := action == RenameTableAction
+	if isRename {
+		c.ClearTableCache(db, "")
+	} else {
+		c.ClearTableCache(db, tbl)
+	}
+	if c.opts.Log.IsInfo() {
+		c.opts.Log.Info("[binlogsync] Table structure changed, clear table cache",
+			log.String("database", db), log.String("table", tbl), log.String("query", string(query)))
+	}
+
+	if isRename || action == DropTableAction || !c.isTableSchema(db) || !c.isTableAllowed(tbl) {
+		return ev, true
+	}
+	if _, err := c.FindTable(ctx, tbl); err != nil && c.opts.Log.IsInfo() {
+		c.opts.Log.Info("[binlogsync] Failed to reload table structure",
+			log.Err(err), log.String("database", db), log.String("table", tbl))
+	}
+	return ev, true
+}
+
+// handleQueryEvent refreshes the table cache and calls the DDL event handlers
+// for a DDL statement. The parallel workers must have processed all previous
+// rows before, otherwise a worker might still apply rows of the old table
+// structure while the table gets reloaded or the DDL handlers run.
+func (c *Canal) handleQueryEvent(ctx context.Context, schema, query []byte, pos ddl.MasterStatus) error {
+	if err := c.workers.wait(); err != nil {
+		return errors.WithStack(err)
+	}
+	ev, ok := c.refreshTableOnDDLStmt(ctx, schema, query)
+	if !ok {
+		return nil
+	}
+	ev.Position = pos
+	return errors.WithStack(c.processDDLEventHandler(ctx, ev))
 }
 
 func (c *Canal) startSyncBinlog(ctxArg context.Context) error {
-	if c.syncer == nil {
+	c.syncerMu.Lock()
+	syncer := c.syncer
+	c.syncerMu.Unlock()
+	if syncer == nil {
 		return errors.AlreadyClosed.Newf("[binlogsync] Canal already closed and myreplicator.BinlogSyncer is nil")
 	}
-	pos := c.masterStatus
+	pos := c.SyncedPosition()
 
 	if c.opts.Log.IsInfo() {
 		c.opts.Log.Info("[binlogsync] Start syncing of binlog", log.Stringer("position", pos))
 	}
 
-	s, err := c.syncer.StartSync(pos)
-	if err != nil {
-		return errors.Fatal.Newf("[binlogsync] Start sync replication at %s error %v", pos, err)
+	var s *myreplicator.BinlogStreamer
+	var err error
+	if c.opts.Flavor == MariaDBFlavor && pos.ExecutedGTIDSet != "" {
+		gset, err := mysql.ParseMariadbGTIDSet(pos.ExecutedGTIDSet)
+		if err != nil {
+			return errors.NotValid.New(err, "[binlogsync] Failed to parse MariaDB GTID set %q", pos.ExecutedGTIDSet)
+		}
+		s, err = syncer.StartSyncGTID(gset)
+		if err != nil {
+			return errors.ConnectionFailed.New(err, "[binlogsync] Start sync replication at GTID %s", pos.ExecutedGTIDSet)
+		}
+	} else {
+		s, err = syncer.StartSync(pos)
+		if err != nil {
+			return errors.ConnectionFailed.New(err, "[binlogsync] Start sync replication at %s", pos)
+		}
 	}
 
+	// mariaGTID contains the GTID of the current MariaDB event group. It gets
+	// added to the position after the group has been completed.
+	var mariaGTID string
+
 	timeout := time.Second
 	for {
 		ctx, cancel := context.WithTimeout(ctxArg, 2*time.Second)
@@ -88,7 +185,13 @@ func (c *Canal) startSyncBinlog(ctxArg context.Context) error {
 			continue
 		}
 		if err != nil {
-			return errors.WithStack(err)
+			if ctxArg.Err() != nil {
+				return errors.WithStack(err)
+			}
+			return errors.ConnectionFailed.New(err, "[binlogsync] Failed to read binlog event at %s", pos)
+		}
+		if atomic.SwapInt32(&c.receivedEvents, 1) == 0 {
+			c.setHealth(HealthSyncing, nil)
 		}
 
 		timeout = time.Second
@@ -126,16 +229,27 @@ func (c *Canal) startSyncBinlog(ctxArg context.Context) error {
 				}
 				continue // to not save the master position, not necessary.
 			}
+			if c.txActive {
+				continue // the position within a transaction gets not saved
+			}
 		case *myreplicator.XIDEvent:
 			// TODO implement
 			// if e.GSet != nil {
 			// 	c.master.UpdateGTIDSet(e.GSet)
 			// }
 
-			// call event OnXID(pos)
+			if err := c.txCommit(ctxArg); err != nil {
+				return errors.WithStack(err)
+			}
 
 		case *myreplicator.MariadbGTIDEvent:
-			// call event OnGTID(gtid)
+			// MariaDB writes no BEGIN query event, the GTID event starts the
+			// transaction.
+			mariaGTID = e.GTID.String()
+			if !e.IsStandalone() {
+				c.txBegin()
+			}
+			continue
 
 		case *myreplicator.GTIDEvent:
 			// call event OnGTID(gtid)
@@ -146,11 +260,22 @@ func (c *Canal) startSyncBinlog(ctxArg context.Context) error {
 			// 	c.master.UpdateGTIDSet(e.GSet)
 			// }
 
-			// handle alert table query
-			c.clearTableCacheOnDDLStmt(e.Schema, e.Query)
-
-			// For now really necessary.
-			// TODO: call two more event handlers: on OnTableChanged(db,table) and OnDDL(pos, e)
+			switch {
+			case bytes.EqualFold(e.Query, queryBegin):
+				c.txBegin()
+				continue // the position within a transaction gets not saved
+			case bytes.EqualFold(e.Query, queryCommit):
+				if err := c.txCommit(ctxArg); err != nil {
+					return errors.WithStack(err)
+				}
+			case bytes.EqualFold(e.Query, queryRollback):
+				c.txRollback()
+			default:
+				// handle alter table query
+				if err := c.handleQueryEvent(ctxArg, e.Schema, e.Query, pos); err != nil {
+					return errors.WithStack(err)
+				}
+			}
 
 			// save master position, so no continue
 		case
@@ -162,7 +287,15 @@ func (c *Canal) startSyncBinlog(ctxArg context.Context) error {
 			continue
 		}
 
-		if err := c.masterSave(pos.File, pos.Position); err != nil {
+		if c.txActive {
+			continue // the position within a transaction gets not saved
+		}
+		if mariaGTID != "" {
+			pos.ExecutedGTIDSet = mergeMariaDBGTID(pos.ExecutedGTIDSet, mariaGTID)
+			mariaGTID = ""
+		}
+
+		if err := c.masterSave(ctxArg, pos); err != nil {
 			c.opts.Log.Info("[binlogsync] startSyncBinlog: Failed to save master position", log.Err(err), log.Stringer("position", pos))
 		}
 	}
@@ -215,7 +348,7 @@ func (c *Canal) handleRowsEvent(ctx context.Context, e *myreplicator.BinlogEvent
 	default:
 		return errors.NotSupported.Newf("[binlogsync] EventType %v not yet supported. Table %q.%q", e.Header.EventType, c.dsn.DBName, table)
 	}
-	return c.processRowsEventHandler(ctx, a, t, ev.Rows)
+	return c.addRowsEvent(ctx, a, t, ev.Rows)
 }
 
 func (c *Canal) GetMasterPos() (ms ddl.MasterStatus, err error) {
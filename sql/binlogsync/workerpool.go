@@ -0,0 +1,193 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+	"github.com/corestoreio/pkg/sql/ddl"
+)
+
+// workerJob contains a subset of the rows of one rows event.
+type workerJob struct {
+	action string
+	table  *ddl.Table
+	rows   [][]interface{}
+}
+
+// workerPool calls the RowsEventHandler concurrently. Rows with the same table
+// and primary key always end up in the same worker queue, so their order gets
+// preserved. Tables without a primary key get processed by a single worker. A
+// full queue blocks the sync goroutine until the worker catches up. The methods
+// dispatch, wait and close must only be called by the sync goroutine.
+type workerPool struct {
+	c       *Canal
+	ctx     context.Context
+	queues  []chan workerJob
+	pending sync.WaitGroup
+	workers sync.WaitGroup
+
+	errMu sync.Mutex
+	err   error
+}
+
+func newWorkerPool(ctx context.Context, c *Canal, workers, queueSize int) *workerPool {
+	wp := &workerPool{
+		c:      c,
+		ctx:    ctx,
+		queues: make([]chan workerJob, workers),
+	}
+	for i := range wp.queues {
+		wp.queues[i] = make(chan workerJob, queueSize)
+		wp.workers.Add(1)
+		go wp.work(wp.queues[i])
+	}
+	return wp
+}
+
+func (wp *workerPool) work(queue <-chan workerJob) {
+	defer wp.workers.Done()
+	for job := range queue {
+		if err := wp.c.processRowsEventHandler(wp.ctx, job.action, job.table, job.rows); err != nil {
+			wp.errMu.Lock()
+			if wp.err == nil {
+				wp.err = err
+			}
+			wp.errMu.Unlock()
+		}
+		wp.pending.Done()
+	}
+}
+
+// firstErr returns the first Interrupted error returned by a handler.
+func (wp *workerPool) firstErr() error {
+	wp.errMu.Lock()
+	defer wp.errMu.Unlock()
+	return wp.err
+}
+
+// queueIndex returns the worker queue for the row.
+func (wp *workerPool) queueIndex(t *ddl.Table, pkIdx []int, row []interface{}) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(t.Name))
+	for _, idx := range pkIdx {
+		if idx < len(row) {
+			_, _ = fmt.Fprintf(h, "\x00%v", row[idx])
+		}
+	}
+	return int(h.Sum32() % uint32(len(wp.queues)))
+}
+
+// dispatch splits the rows of the event by primary key and sends them to the
+// worker queues. An update event gets split into pairs of before and after
+// images, the pair gets routed by the primary key of the before image. If an
+// update changes the primary key and both keys map to different queues, the
+// pair acts as a barrier: all previous rows get processed, then the pair runs
+// in the calling goroutine, so no other row of the old or the new key can
+// overtake it.
+func (wp *workerPool) dispatch(ctx context.Context, ev TxEvent) error {
+	if err := wp.firstErr(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	var pkIdx []int
+	for i, col := range ev.Table.Columns {
+		if col.IsPK() {
+			pkIdx = append(pkIdx, i)
+		}
+	}
+	step := 1
+	if ev.Action == UpdateAction {
+		step = 2
+	}
+
+	jobRows := make([][][]interface{}, len(wp.queues))
+	for i := 0; i < len(ev.Rows); i += step {
+		end := i + step
+		if end > len(ev.Rows) {
+			end = len(ev.Rows)
+		}
+		qi := wp.queueIndex(ev.Table, pkIdx, ev.Rows[i])
+		if end-i == 2 && wp.queueIndex(ev.Table, pkIdx, ev.Rows[i+1]) != qi {
+			if err := wp.send(ctx, ev, jobRows); err != nil {
+				return errors.WithStack(err)
+			}
+			jobRows = make([][][]interface{}, len(wp.queues))
+			if err := wp.wait(); err != nil {
+				return errors.WithStack(err)
+			}
+			if err := wp.c.processRowsEventHandler(wp.ctx, ev.Action, ev.Table, ev.Rows[i:end]); err != nil {
+				return errors.WithStack(err)
+			}
+			continue
+		}
+		jobRows[qi] = append(jobRows[qi], ev.Rows[i:end]...)
+	}
+	return errors.WithStack(wp.send(ctx, ev, jobRows))
+}
+
+// send passes the rows, indexed by the queue, to the workers.
+func (wp *workerPool) send(ctx context.Context, ev TxEvent, jobRows [][][]interface{}) error {
+	for qi, rows := range jobRows {
+		if len(rows) == 0 {
+			continue
+		}
+		job := workerJob{action: ev.Action, table: ev.Table, rows: rows}
+		wp.pending.Add(1)
+		select {
+		case wp.queues[qi] <- job:
+			continue
+		default:
+		}
+		if wp.c.opts.Log.IsDebug() {
+			wp.c.opts.Log.Debug("[binlogsync] Worker queue is full, waiting for the handler",
+				log.Int("worker", qi), log.String("table", ev.Table.Name), log.String("action", ev.Action))
+		}
+		select {
+		case wp.queues[qi] <- job:
+		case <-ctx.Done():
+			wp.pending.Done()
+			return errors.WithStack(ctx.Err())
+		}
+	}
+	return nil
+}
+
+// wait blocks until all dispatched rows have been processed. A nil workerPool
+// returns immediately.
+func (wp *workerPool) wait() error {
+	if wp == nil {
+		return nil
+	}
+	wp.pending.Wait()
+	return errors.WithStack(wp.firstErr())
+}
+
+// close processes the remaining rows and stops the workers.
+func (wp *workerPool) close() error {
+	if wp == nil {
+		return nil
+	}
+	for _, q := range wp.queues {
+		close(q)
+	}
+	wp.workers.Wait()
+	return errors.WithStack(wp.firstErr())
+}
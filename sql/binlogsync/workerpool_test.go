@@ -0,0 +1,176 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/go-sql-driver/mysql"
+)
+
+type workerRecorder struct {
+	mu      sync.Mutex
+	rows    map[interface{}][][]interface{}
+	calls   [][][]interface{}
+	started chan struct{}
+	release chan struct{}
+	err     error
+}
+
+func (wr *workerRecorder) Do(_ context.Context, _ string, _ *ddl.Table, rows [][]interface{}) error {
+	if wr.started != nil {
+		wr.started <- struct{}{}
+		<-wr.release
+	}
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+	if wr.rows == nil {
+		wr.rows = make(map[interface{}][][]interface{})
+	}
+	wr.calls = append(wr.calls, rows)
+	for _, row := range rows {
+		wr.rows[row[0]] = append(wr.rows[row[0]], row)
+	}
+	return wr.err
+}
+func (wr *workerRecorder) Complete(context.Context) error { return nil }
+func (wr *workerRecorder) String() string                 { return "worker_recorder" }
+
+func newWorkerTestCanal(h RowsEventHandler) *Canal {
+	c := &Canal{
+		opts: Options{Log: log.BlackHole{}},
+		dsn:  &mysql.Config{DBName: "TestDB"},
+	}
+	c.RegisterRowsEventHandler("", h)
+	return c
+}
+
+func TestWorkerPool_Ordering(t *testing.T) {
+	t.Parallel()
+
+	wr := &workerRecorder{}
+	c := newWorkerTestCanal(wr)
+	ctx := context.Background()
+	wp := newWorkerPool(ctx, c, 4, 2)
+	tbl := ddl.NewTable("sales_order",
+		&ddl.Column{Field: "entity_id", DataType: "int", Key: "PRI"},
+		&ddl.Column{Field: "seq", DataType: "int"},
+	)
+
+	for seq := 0; seq < 50; seq++ {
+		assert.NoError(t, wp.dispatch(ctx, TxEvent{Action: InsertAction, Table: tbl, Rows: [][]interface{}{
+			{seq % 5, seq}, {seq%5 + 5, seq},
+		}}))
+	}
+	assert.NoError(t, wp.wait())
+
+	assert.Len(t, wr.rows, 10)
+	for pk, rows := range wr.rows {
+		assert.Len(t, rows, 25, "PK %v", pk)
+		for i := 1; i < len(rows); i++ {
+			assert.True(t, rows[i-1][1].(int) < rows[i][1].(int), "PK %v has invalid order: %v", pk, rows)
+		}
+	}
+
+	// before and after image of an update stay together
+	assert.NoError(t, wp.dispatch(ctx, TxEvent{Action: UpdateAction, Table: tbl, Rows: [][]interface{}{
+		{3, 100}, {33, 101},
+	}}))
+	assert.NoError(t, wp.close())
+	assert.Exactly(t, [][]interface{}{{3, 100}, {33, 101}}, wr.calls[len(wr.calls)-1])
+}
+
+func TestWorkerPool_PrimaryKeyChange(t *testing.T) {
+	t.Parallel()
+
+	wr := &workerRecorder{}
+	c := newWorkerTestCanal(wr)
+	ctx := context.Background()
+	wp := newWorkerPool(ctx, c, 4, 2)
+	tbl := ddl.NewTable("sales_order",
+		&ddl.Column{Field: "entity_id", DataType: "int", Key: "PRI"},
+		&ddl.Column{Field: "seq", DataType: "int"},
+	)
+	oldPK, newPK := 1, 2
+	for wp.queueIndex(tbl, []int{0}, []interface{}{newPK}) == wp.queueIndex(tbl, []int{0}, []interface{}{oldPK}) {
+		newPK++
+	}
+
+	for seq := 0; seq < 10; seq++ {
+		assert.NoError(t, wp.dispatch(ctx, TxEvent{Action: InsertAction, Table: tbl, Rows: [][]interface{}{{oldPK, seq}}}))
+	}
+	assert.NoError(t, wp.dispatch(ctx, TxEvent{Action: UpdateAction, Table: tbl, Rows: [][]interface{}{
+		{oldPK, 10}, {newPK, 11},
+	}}))
+	// the pair must have been processed before the next row of the new key
+	assert.Exactly(t, [][]interface{}{{oldPK, 10}, {newPK, 11}}, wr.calls[len(wr.calls)-1])
+	assert.Len(t, wr.rows[oldPK], 11)
+
+	assert.NoError(t, wp.dispatch(ctx, TxEvent{Action: DeleteAction, Table: tbl, Rows: [][]interface{}{{newPK, 12}}}))
+	assert.NoError(t, wp.close())
+	assert.Exactly(t, [][]interface{}{{newPK, 11}, {newPK, 12}}, wr.rows[newPK])
+}
+
+func TestWorkerPool_Interrupted(t *testing.T) {
+	t.Parallel()
+
+	wr := &workerRecorder{err: errors.Interrupted.Newf("handler failed")}
+	c := newWorkerTestCanal(wr)
+	ctx := context.Background()
+	wp := newWorkerPool(ctx, c, 2, 1)
+	tbl := ddl.NewTable("sales_order", &ddl.Column{Field: "entity_id", DataType: "int", Key: "PRI"})
+
+	assert.NoError(t, wp.dispatch(ctx, TxEvent{Action: DeleteAction, Table: tbl, Rows: [][]interface{}{{1}}}))
+	err := wp.wait()
+	assert.True(t, errors.Interrupted.Match(err), "%+v", err)
+
+	err = wp.dispatch(ctx, TxEvent{Action: DeleteAction, Table: tbl, Rows: [][]interface{}{{2}}})
+	assert.True(t, errors.Interrupted.Match(err), "%+v", err)
+	assert.Error(t, wp.close())
+}
+
+func TestWorkerPool_Backpressure(t *testing.T) {
+	t.Parallel()
+
+	wr := &workerRecorder{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	c := newWorkerTestCanal(wr)
+	wp := newWorkerPool(context.Background(), c, 1, 1)
+	tbl := ddl.NewTable("sales_order", &ddl.Column{Field: "entity_id", DataType: "int", Key: "PRI"})
+	ev := TxEvent{Action: InsertAction, Table: tbl, Rows: [][]interface{}{{1}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.NoError(t, wp.dispatch(ctx, ev))
+	<-wr.started // the worker blocks in the handler
+	assert.NoError(t, wp.dispatch(ctx, ev))
+
+	cancel()
+	err := wp.dispatch(ctx, ev) // queue is full
+	assert.True(t, errors.Cause(err) == context.Canceled, "%+v", err)
+
+	wr.release <- struct{}{}
+	<-wr.started
+	wr.release <- struct{}{}
+	assert.NoError(t, wp.close())
+	assert.Len(t, wr.rows[1], 2)
+}
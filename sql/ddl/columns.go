@@ -36,6 +36,7 @@ const (
 	columnAutoIncrement    = "auto_increment"
 	columnUnsigned         = "unsigned"
 	columnCurrentTimestamp = "CURRENT_TIMESTAMP"
+	columnGenerated        = " GENERATED" // VIRTUAL, STORED or PERSISTENT GENERATED
 )
 
 // Columns contains a slice of column types
@@ -149,6 +150,38 @@ func LoadColumns(ctx context.Context, db dml.Querier, tables ...string) (map[str
 	return tc, err
 }
 
+// LoadViewNames returns the names of all views in the current database. If
+// the argument `tables` has been provided, only those names which are views
+// are getting returned.
+func LoadViewNames(ctx context.Context, db dml.Querier, tables ...string) ([]string, error) {
+	const selViews = `SELECT TABLE_NAME FROM information_schema.VIEWS WHERE TABLE_SCHEMA=DATABASE()`
+	const selViewsWhere = selViews + ` AND TABLE_NAME IN ?`
+
+	sqlStr := selViews + ` ORDER BY TABLE_NAME`
+	if len(tables) > 0 {
+		var err error
+		sqlStr, _, err = dml.Interpolate(selViewsWhere + ` ORDER BY TABLE_NAME`).Strs(tables...).ToSQL()
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ddl] LoadViewNames dml.ExpandPlaceHolders for tables %v", tables)
+		}
+	}
+	rows, err := db.QueryContext(ctx, sqlStr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadViewNames QueryContext for tables %v", tables)
+	}
+	defer rows.Close()
+
+	var views []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, errors.Wrapf(err, "[ddl] LoadViewNames Scan for tables %v", tables)
+		}
+		views = append(views, name)
+	}
+	return views, errors.WithStack(rows.Err())
+}
+
 // Filter filters the columns by predicate f and appends the column pointers to
 // the optional argument `cols`.
 func (cs Columns) Filter(f func(*Column) bool, cols ...*Column) Columns {
@@ -457,6 +490,12 @@ func (c *Column) IsAutoIncrement() bool {
 	return c.Field != "" && c.Extra == columnAutoIncrement
 }
 
+// IsGenerated checks if the column gets computed from an expression, defined
+// via GENERATED ALWAYS AS. Generated columns cannot be written.
+func (c *Column) IsGenerated() bool {
+	return c.Field != "" && strings.Contains(c.Extra, columnGenerated)
+}
+
 // IsUnsigned checks if field TypeRaw contains the word unsigned.
 func (c *Column) IsUnsigned() bool {
 	return strings.Contains(c.ColumnType, columnUnsigned)
@@ -507,6 +546,40 @@ func (c *Column) IsBool() bool {
 	return isInt && columnTypes.byName.bool.ContainsReverse(c.Field)
 }
 
+// EnumValues returns the allowed values of a column of type enum or set as
+// defined in field ColumnType. Returns nil for all other types.
+func (c *Column) EnumValues() []string {
+	var list string
+	switch {
+	case strings.HasPrefix(c.ColumnType, "enum(") && strings.HasSuffix(c.ColumnType, ")"):
+		list = c.ColumnType[len("enum(") : len(c.ColumnType)-1]
+	case strings.HasPrefix(c.ColumnType, "set(") && strings.HasSuffix(c.ColumnType, ")"):
+		list = c.ColumnType[len("set(") : len(c.ColumnType)-1]
+	default:
+		return nil
+	}
+
+	var ret []string
+	var buf strings.Builder
+	inQuote := false
+	for i := 0; i < len(list); i++ {
+		switch ch := list[i]; {
+		case ch == '\'' && inQuote && i+1 < len(list) && list[i+1] == '\'':
+			buf.WriteByte('\'') // MySQL escapes a quote with a quote
+			i++
+		case ch == '\'' && inQuote:
+			ret = append(ret, buf.String())
+			buf.Reset()
+			inQuote = false
+		case ch == '\'':
+			inQuote = true
+		case inQuote:
+			buf.WriteByte(ch)
+		}
+	}
+	return ret
+}
+
 // columnTypes looks ugly but ... refactor later
 var columnTypes = struct { // the slices in this struct are only for reading. no mutex protection required
 	byName struct {
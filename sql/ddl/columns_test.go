@@ -20,6 +20,7 @@ import (
 	"sort"
 	"testing"
 
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/pkg/sql/ddl"
 	"github.com/corestoreio/pkg/sql/dml"
@@ -236,3 +237,47 @@ func TestColumn_IsCurrentTimestamp(t *testing.T) {
 	assert.True(t, adminUserColumns.ByField("modified").IsCurrentTimestamp())
 	assert.False(t, adminUserColumns.ByField("reload_acl_flag").IsCurrentTimestamp())
 }
+
+func TestColumn_EnumValues(t *testing.T) {
+	t.Parallel()
+	assert.Exactly(t, []string{"default", "websites", "stores"}, (&ddl.Column{ColumnType: "enum('default','websites','stores')"}).EnumValues())
+	assert.Exactly(t, []string{"a,b", "it's", ""}, (&ddl.Column{ColumnType: "set('a,b','it''s','')"}).EnumValues())
+	assert.Nil(t, (&ddl.Column{ColumnType: "varchar(8)"}).EnumValues())
+}
+
+func TestColumn_IsGenerated(t *testing.T) {
+	t.Parallel()
+	assert.True(t, (&ddl.Column{Field: "full_name", Extra: "VIRTUAL GENERATED"}).IsGenerated())
+	assert.True(t, (&ddl.Column{Field: "full_name", Extra: "STORED GENERATED"}).IsGenerated())
+	assert.True(t, (&ddl.Column{Field: "full_name", Extra: "PERSISTENT GENERATED"}).IsGenerated())
+	assert.False(t, (&ddl.Column{Field: "created_at", Extra: "DEFAULT_GENERATED"}).IsGenerated())
+	assert.False(t, adminUserColumns.ByField("user_id").IsGenerated())
+}
+
+func TestLoadViewNames(t *testing.T) {
+	t.Parallel()
+
+	t.Run("some tables", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT TABLE_NAME FROM information_schema.VIEWS WHERE TABLE_SCHEMA=DATABASE() AND TABLE_NAME IN ('customer_entity','view_customer') ORDER BY TABLE_NAME")).
+			WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME"}).AddRow("view_customer"))
+
+		views, err := ddl.LoadViewNames(context.TODO(), dbc.DB, "customer_entity", "view_customer")
+		assert.NoError(t, err)
+		assert.Exactly(t, []string{"view_customer"}, views)
+	})
+	t.Run("all tables", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT TABLE_NAME FROM information_schema.VIEWS WHERE TABLE_SCHEMA=DATABASE() ORDER BY TABLE_NAME")).
+			WillReturnError(errors.ConnectionFailed.Newf("Ups"))
+
+		views, err := ddl.LoadViewNames(context.TODO(), dbc.DB)
+		assert.Nil(t, views)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Ups")
+	})
+}
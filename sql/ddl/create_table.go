@@ -0,0 +1,217 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+	"strings"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/util/bufferpool"
+)
+
+// CreateTable renders a CREATE TABLE statement from the columns, indexes and
+// foreign keys of a Table. Create it with Table.CreateTable and chain the
+// table options.
+//		stmt, _, err := t.CreateTable().IfNotExists().Engine("InnoDB").
+//			Charset("utf8mb4").Collate("utf8mb4_unicode_ci").ToSQL()
+type CreateTable struct {
+	table       *Table
+	ifNotExists bool
+	indexes     Indexes
+	foreignKeys ForeignKeys
+	engine      string
+	rowFormat   string
+	charset     string
+	collation   string
+	comment     string
+	partition   string
+}
+
+// CreateTable creates a new CREATE TABLE builder. The indexes, the foreign
+// keys and the status of the table get used by default.
+func (t *Table) CreateTable() *CreateTable {
+	return &CreateTable{
+		table:       t,
+		indexes:     t.Indexes,
+		foreignKeys: t.ForeignKeys,
+		engine:      t.Status.Engine,
+		rowFormat:   t.Status.RowFormat,
+		charset:     t.Status.Charset,
+		collation:   t.Status.Collation,
+	}
+}
+
+// IfNotExists adds IF NOT EXISTS.
+func (ct *CreateTable) IfNotExists() *CreateTable {
+	ct.ifNotExists = true
+	return ct
+}
+
+// Index adds additional indexes. An index named PRIMARY gets ignored because
+// the primary key gets derived from the columns.
+func (ct *CreateTable) Index(is ...Index) *CreateTable {
+	ct.indexes = append(ct.indexes[:len(ct.indexes):len(ct.indexes)], is...)
+	return ct
+}
+
+// ForeignKey adds additional foreign key constraints.
+func (ct *CreateTable) ForeignKey(fks ...ForeignKey) *CreateTable {
+	ct.foreignKeys = append(ct.foreignKeys[:len(ct.foreignKeys):len(ct.foreignKeys)], fks...)
+	return ct
+}
+
+// Engine sets the storage engine, e.g. InnoDB.
+func (ct *CreateTable) Engine(engine string) *CreateTable {
+	ct.engine = engine
+	return ct
+}
+
+// RowFormat sets the row format, e.g. DYNAMIC.
+func (ct *CreateTable) RowFormat(rowFormat string) *CreateTable {
+	ct.rowFormat = rowFormat
+	return ct
+}
+
+// Charset sets the default character set, e.g. utf8mb4.
+func (ct *CreateTable) Charset(charset string) *CreateTable {
+	ct.charset = charset
+	return ct
+}
+
+// Collate sets the default collation, e.g. utf8mb4_unicode_ci.
+func (ct *CreateTable) Collate(collation string) *CreateTable {
+	ct.collation = collation
+	return ct
+}
+
+// Comment sets the table comment.
+func (ct *CreateTable) Comment(comment string) *CreateTable {
+	ct.comment = comment
+	return ct
+}
+
+// PartitionBy sets the partition options, without the leading PARTITION BY.
+// The argument gets written unquoted.
+//		PartitionBy("HASH(`entity_id`) PARTITIONS 4")
+//		PartitionBy("RANGE (YEAR(`created_at`)) (PARTITION p0 VALUES LESS THAN (2020), PARTITION p1 VALUES LESS THAN MAXVALUE)")
+func (ct *CreateTable) PartitionBy(partition string) *CreateTable {
+	ct.partition = partition
+	return ct
+}
+
+// ToSQL renders the CREATE TABLE statement. The returned arguments are always
+// nil. It returns a NotSupported error for views and generated columns and an
+// Empty error for a table without columns.
+func (ct *CreateTable) ToSQL() (string, []interface{}, error) {
+	t := ct.table
+	if err := dml.IsValidIdentifier(t.Name); err != nil {
+		return "", nil, errors.WithStack(err)
+	}
+	if t.IsView {
+		return "", nil, errors.NotSupported.Newf("[ddl] CREATE TABLE for view %q not supported", t.Name)
+	}
+	if len(t.Columns) == 0 {
+		return "", nil, errors.Empty.Newf("[ddl] Table %q has no columns", t.Name)
+	}
+
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	buf.WriteString("CREATE TABLE ")
+	if ct.ifNotExists {
+		buf.WriteString("IF NOT EXISTS ")
+	}
+	dml.Quoter.WriteQualifierName(buf, t.Schema, t.Name)
+	buf.WriteString(" (\n")
+	for i, c := range t.Columns {
+		def, err := columnDefinition(c)
+		if err != nil {
+			return "", nil, errors.WithStack(err)
+		}
+		if i > 0 {
+			buf.WriteString(",\n")
+		}
+		buf.WriteString("  ")
+		buf.WriteString(def)
+	}
+	if pks := t.Columns.PrimaryKeys(); len(pks) > 0 {
+		buf.WriteString(",\n  PRIMARY KEY (")
+		buf.WriteString(quoteNames(pks.FieldNames()))
+		buf.WriteByte(')')
+	}
+	for _, i := range ct.indexes {
+		if !i.IsPrimary() {
+			buf.WriteString(",\n  ")
+			buf.WriteString(indexDefinition(i))
+		}
+	}
+	for _, fk := range ct.foreignKeys {
+		buf.WriteString(",\n  ")
+		buf.WriteString(foreignKeyDefinition(fk))
+	}
+	buf.WriteString("\n)")
+
+	if ct.engine != "" {
+		buf.WriteString(" ENGINE=")
+		buf.WriteString(ct.engine)
+	}
+	if ct.rowFormat != "" {
+		buf.WriteString(" ROW_FORMAT=")
+		buf.WriteString(strings.ToUpper(ct.rowFormat))
+	}
+	if ct.charset != "" {
+		buf.WriteString(" DEFAULT CHARSET=")
+		buf.WriteString(ct.charset)
+	}
+	if ct.collation != "" {
+		buf.WriteString(" COLLATE=")
+		buf.WriteString(ct.collation)
+	}
+	if ct.comment != "" {
+		buf.WriteString(" COMMENT=")
+		buf.WriteString(quoteString(ct.comment))
+	}
+	if ct.partition != "" {
+		buf.WriteString("\nPARTITION BY ")
+		buf.WriteString(ct.partition)
+	}
+	return buf.String(), nil, nil
+}
+
+// Exec executes the CREATE TABLE statement.
+func (ct *CreateTable) Exec(ctx context.Context, db dml.Execer) error {
+	sqlStr, _, err := ct.ToSQL()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = db.ExecContext(ctx, sqlStr)
+	return errors.Wrapf(err, "[ddl] failed to create table %q", ct.table.Name)
+}
+
+// foreignKeyDefinition renders a foreign key constraint as used in CREATE
+// TABLE and ALTER TABLE statements.
+func foreignKeyDefinition(fk ForeignKey) string {
+	s := "CONSTRAINT " + dml.Quoter.Name(fk.Name) + " FOREIGN KEY (" + quoteNames(fk.Columns) +
+		") REFERENCES " + dml.Quoter.Name(fk.ReferencedTable) + " (" + quoteNames(fk.ReferencedColumns) + ")"
+	if fk.OnDelete != "" {
+		s += " ON DELETE " + fk.OnDelete
+	}
+	if fk.OnUpdate != "" {
+		s += " ON UPDATE " + fk.OnUpdate
+	}
+	return s
+}
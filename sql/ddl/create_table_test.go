@@ -0,0 +1,114 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"context"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/storage/null"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestTable_CreateTable(t *testing.T) {
+	t.Parallel()
+
+	newTable := func() *ddl.Table {
+		tbl := ddl.NewTable("catalog_product_website",
+			&ddl.Column{Field: "product_id", ColumnType: "int(10) unsigned", Null: "NO", Key: "PRI"},
+			&ddl.Column{Field: "website_id", ColumnType: "smallint(5) unsigned", Null: "NO", Key: "PRI"},
+			&ddl.Column{Field: "position", ColumnType: "int(11)", Null: "NO", Default: null.MakeString("0")},
+		)
+		tbl.Indexes = ddl.Indexes{
+			{Name: "PRIMARY", Columns: []string{"product_id", "website_id"}, Unique: true},
+			{Name: "CATALOG_PRODUCT_WEBSITE_WEBSITE_ID", Columns: []string{"website_id"}},
+		}
+		tbl.ForeignKeys = ddl.ForeignKeys{{
+			Name:              "CAT_PRD_WS_WS_ID_STORE_WS_WS_ID",
+			Columns:           []string{"website_id"},
+			ReferencedTable:   "store_website",
+			ReferencedColumns: []string{"website_id"},
+			OnDelete:          "CASCADE",
+		}}
+		return tbl
+	}
+
+	t.Run("all options", func(t *testing.T) {
+		tbl := newTable()
+		tbl.Schema = "magento"
+		sqlStr, args, err := tbl.CreateTable().
+			IfNotExists().
+			Index(ddl.Index{Name: "CATALOG_PRODUCT_WEBSITE_POSITION", Columns: []string{"position", "product_id"}, Unique: true}).
+			ForeignKey(ddl.ForeignKey{
+				Name:              "CAT_PRD_WS_PRD_ID_CAT_PRD_ENTT_ENTT_ID",
+				Columns:           []string{"product_id"},
+				ReferencedTable:   "catalog_product_entity",
+				ReferencedColumns: []string{"entity_id"},
+				OnDelete:          "CASCADE",
+				OnUpdate:          "RESTRICT",
+			}).
+			Engine("InnoDB").
+			Charset("utf8mb4").
+			Collate("utf8mb4_unicode_ci").
+			Comment("Product To Website Linkage Table").
+			PartitionBy("HASH(`website_id`) PARTITIONS 4").
+			ToSQL()
+		assert.NoError(t, err)
+		assert.Nil(t, args)
+		assert.Exactly(t, "CREATE TABLE IF NOT EXISTS `magento`.`catalog_product_website` (\n"+
+			"  `product_id` int(10) unsigned NOT NULL,\n"+
+			"  `website_id` smallint(5) unsigned NOT NULL,\n"+
+			"  `position` int(11) NOT NULL DEFAULT 0,\n"+
+			"  PRIMARY KEY (`product_id`,`website_id`),\n"+
+			"  INDEX `CATALOG_PRODUCT_WEBSITE_WEBSITE_ID` (`website_id`),\n"+
+			"  UNIQUE INDEX `CATALOG_PRODUCT_WEBSITE_POSITION` (`position`,`product_id`),\n"+
+			"  CONSTRAINT `CAT_PRD_WS_WS_ID_STORE_WS_WS_ID` FOREIGN KEY (`website_id`) REFERENCES `store_website` (`website_id`) ON DELETE CASCADE,\n"+
+			"  CONSTRAINT `CAT_PRD_WS_PRD_ID_CAT_PRD_ENTT_ENTT_ID` FOREIGN KEY (`product_id`) REFERENCES `catalog_product_entity` (`entity_id`) ON DELETE CASCADE ON UPDATE RESTRICT\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Product To Website Linkage Table'\n"+
+			"PARTITION BY HASH(`website_id`) PARTITIONS 4", sqlStr)
+		assert.Len(t, tbl.Indexes, 2, "Index must not modify the table")
+	})
+
+	t.Run("view not supported", func(t *testing.T) {
+		tbl := newTable()
+		tbl.IsView = true
+		sqlStr, _, err := tbl.CreateTable().ToSQL()
+		assert.Empty(t, sqlStr)
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	})
+
+	t.Run("no columns", func(t *testing.T) {
+		sqlStr, _, err := ddl.NewTable("catalog_product_website").CreateTable().ToSQL()
+		assert.Empty(t, sqlStr)
+		assert.True(t, errors.Empty.Match(err), "%+v", err)
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TABLE `catalog_product_website` ( `product_id` int(10) unsigned NOT NULL, `website_id` smallint(5) unsigned NOT NULL, `position` int(11) NOT NULL DEFAULT 0, PRIMARY KEY (`product_id`,`website_id`) ) ENGINE=InnoDB")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		tbl := newTable()
+		tbl.Indexes = nil
+		tbl.ForeignKeys = nil
+		assert.NoError(t, tbl.CreateTable().Engine("InnoDB").Exec(context.TODO(), dbc.DB))
+	})
+}
@@ -0,0 +1,264 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+)
+
+// Diff compares the tables and their columns of `current`, usually loaded from
+// the database, with the `desired` tables, usually from generated code, and
+// returns the statements to migrate `current` to `desired`. Each table which
+// differs results in one ALTER TABLE statement which drops, modifies and adds
+// columns and changes the primary key. Secondary indexes get compared when the
+// desired table contains Indexes. Engine, row format, charset and collation get
+// compared when they are set in the Status of the desired table; a changed
+// charset or collation converts the table. Tables which only exist in `desired`
+// result in a CREATE TABLE statement. Tables which only exist in `current` are
+// ignored. The statements are sorted by table name. Generated columns cannot
+// be added or modified because their expression is unknown and return a
+// NotSupported error.
+func Diff(current, desired *Tables) ([]string, error) {
+	if current == desired {
+		// Identical tables have no differences. Acquiring the read lock twice
+		// on the same mutex deadlocks when a writer waits in between.
+		return nil, nil
+	}
+	current.mu.RLock()
+	defer current.mu.RUnlock()
+	desired.mu.RLock()
+	defer desired.mu.RUnlock()
+
+	names := make([]string, 0, len(desired.tm))
+	for name := range desired.tm {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var stmts []string
+	for _, name := range names {
+		dt := desired.tm[name]
+		if dt.IsView {
+			continue
+		}
+		ct, ok := current.tm[name]
+		var stmt string
+		var err error
+		if ok {
+			stmt, err = diffTable(ct, dt)
+		} else {
+			stmt, err = createTable(dt)
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+	}
+	return stmts, nil
+}
+
+func createTable(t *Table) (string, error) {
+	sqlStr, _, err := t.CreateTable().ToSQL()
+	return sqlStr, errors.WithStack(err)
+}
+
+func diffTable(current, desired *Table) (string, error) {
+	var specs []string
+
+	engineChanged, rowFormatChanged, charsetChanged := current.Status.diff(desired.Status)
+	if charsetChanged {
+		charset := desired.Status.Charset
+		if charset == "" {
+			// a collation name starts always with its charset
+			charset = strings.SplitN(desired.Status.Collation, "_", 2)[0]
+		}
+		spec := "CONVERT TO CHARACTER SET " + charset
+		if desired.Status.Collation != "" {
+			spec += " COLLATE " + desired.Status.Collation
+		}
+		specs = append(specs, spec)
+	}
+
+	curPKs := current.Columns.PrimaryKeys().FieldNames()
+	desPKs := desired.Columns.PrimaryKeys().FieldNames()
+	pkChanged := strings.Join(curPKs, ",") != strings.Join(desPKs, ",")
+	if pkChanged && len(curPKs) > 0 {
+		specs = append(specs, "DROP PRIMARY KEY")
+	}
+
+	// Secondary indexes get only compared when the desired table knows them,
+	// otherwise all existing indexes would be dropped.
+	var addIdx []string
+	if desired.Indexes != nil {
+		for _, ci := range current.Indexes {
+			if ci.IsPrimary() {
+				continue
+			}
+			if di, ok := desired.Indexes.ByName(ci.Name); !ok || !di.equal(ci) {
+				specs = append(specs, "DROP INDEX "+dml.Quoter.Name(ci.Name))
+			}
+		}
+		for _, di := range desired.Indexes {
+			if di.IsPrimary() {
+				continue
+			}
+			if ci, ok := current.Indexes.ByName(di.Name); !ok || !ci.equal(di) {
+				addIdx = append(addIdx, "ADD "+indexDefinition(di))
+			}
+		}
+	}
+
+	for _, c := range current.Columns {
+		if !desired.Columns.Contains(c.Field) {
+			specs = append(specs, "DROP COLUMN "+dml.Quoter.Name(c.Field))
+		}
+	}
+
+	for i, dc := range desired.Columns {
+		cc := current.Columns.ByField(dc.Field)
+		if cc.Field != "" {
+			curDef, _ := columnDefinition(cc) // errors only for generated columns
+			desDef, err := columnDefinition(dc)
+			switch {
+			case curDef == desDef && err == nil:
+				continue
+			case cc.IsGenerated() && dc.IsGenerated():
+				continue // expression unknown, nothing to compare
+			case err != nil:
+				return "", errors.WithStack(err)
+			}
+			specs = append(specs, "MODIFY COLUMN "+desDef)
+			continue
+		}
+
+		def, err := columnDefinition(dc)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		pos := " FIRST"
+		if i > 0 {
+			pos = " AFTER " + dml.Quoter.Name(desired.Columns[i-1].Field)
+		}
+		specs = append(specs, "ADD COLUMN "+def+pos)
+	}
+
+	if pkChanged && len(desPKs) > 0 {
+		specs = append(specs, "ADD PRIMARY KEY ("+quoteNames(desPKs)+")")
+	}
+	specs = append(specs, addIdx...)
+	if engineChanged {
+		specs = append(specs, "ENGINE="+desired.Status.Engine)
+	}
+	if rowFormatChanged {
+		specs = append(specs, "ROW_FORMAT="+strings.ToUpper(desired.Status.RowFormat))
+	}
+
+	if len(specs) == 0 {
+		return "", nil
+	}
+	return "ALTER TABLE " + dml.Quoter.Name(desired.Name) + " " + strings.Join(specs, ", "), nil
+}
+
+// indexDefinition renders a non-primary index as used in CREATE TABLE and
+// ALTER TABLE statements.
+func indexDefinition(i Index) string {
+	var kind string
+	switch t := strings.ToUpper(i.Type); {
+	case t == "FULLTEXT" || t == "SPATIAL":
+		kind = t + " "
+	case i.Unique:
+		kind = "UNIQUE "
+	}
+	return kind + "INDEX " + dml.Quoter.Name(i.Name) + " (" + i.columnsDefinition() + ")"
+}
+
+// columnDefinition renders the column definition as used in CREATE TABLE and
+// ALTER TABLE statements.
+func columnDefinition(c *Column) (string, error) {
+	if c.IsGenerated() {
+		return "", errors.NotSupported.Newf("[ddl] Generated column %q cannot be defined, its expression is unknown", c.Field)
+	}
+	if c.ColumnType == "" {
+		return "", errors.Empty.Newf("[ddl] Column %q has no ColumnType", c.Field)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(dml.Quoter.Name(c.Field))
+	buf.WriteByte(' ')
+	buf.WriteString(c.ColumnType)
+	if c.IsNull() {
+		buf.WriteString(" NULL")
+	} else {
+		buf.WriteString(" NOT NULL")
+	}
+
+	switch d := c.Default.String; {
+	case !c.Default.Valid || strings.EqualFold(d, "NULL"):
+		// MariaDB reports NULL as string, MySQL as SQL NULL.
+		if c.IsNull() {
+			buf.WriteString(" DEFAULT NULL")
+		}
+	case strings.HasPrefix(strings.ToUpper(d), columnCurrentTimestamp):
+		// MariaDB reports current_timestamp(), MySQL CURRENT_TIMESTAMP.
+		buf.WriteString(" DEFAULT ")
+		buf.WriteString(strings.TrimSuffix(strings.ToUpper(d), "()"))
+	case strings.HasPrefix(d, "'"), isNumeric(d):
+		// MariaDB quotes string literals, MySQL does not.
+		buf.WriteString(" DEFAULT ")
+		buf.WriteString(d)
+	default:
+		buf.WriteString(" DEFAULT ")
+		buf.WriteString(quoteString(d))
+	}
+
+	extra := strings.ToLower(c.Extra)
+	if c.IsAutoIncrement() {
+		buf.WriteString(" AUTO_INCREMENT")
+	}
+	if i := strings.Index(extra, "on update "); i >= 0 {
+		buf.WriteString(" ON UPDATE ")
+		buf.WriteString(strings.TrimSuffix(strings.ToUpper(c.Extra[i+len("on update "):]), "()"))
+	}
+	if c.Comment != "" {
+		buf.WriteString(" COMMENT ")
+		buf.WriteString(quoteString(c.Comment))
+	}
+	return buf.String(), nil
+}
+
+func isNumeric(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+func quoteString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+func quoteNames(names []string) string {
+	qn := make([]string, len(names))
+	for i, n := range names {
+		qn[i] = dml.Quoter.Name(n)
+	}
+	return strings.Join(qn, ",")
+}
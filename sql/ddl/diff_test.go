@@ -0,0 +1,178 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/storage/null"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	current := ddl.MustNewTables(
+		ddl.WithTable("customer_entity",
+			&ddl.Column{Field: "entity_id", ColumnType: "int(10) unsigned", Null: "NO", Key: "PRI", Extra: "auto_increment"},
+			&ddl.Column{Field: "email", ColumnType: "varchar(255)", Null: "YES", Default: null.MakeString("NULL")},
+			&ddl.Column{Field: "group_id", ColumnType: "smallint(5) unsigned", Null: "NO", Default: null.MakeString("0")},
+			&ddl.Column{Field: "old_col", ColumnType: "text", Null: "YES"},
+		),
+		ddl.WithTable("catalog_category_product",
+			&ddl.Column{Field: "entity_id", ColumnType: "int(11)", Null: "NO", Key: "PRI", Extra: "auto_increment"},
+			&ddl.Column{Field: "category_id", ColumnType: "int(10) unsigned", Null: "NO", Default: null.MakeString("0")},
+			&ddl.Column{Field: "product_id", ColumnType: "int(10) unsigned", Null: "NO", Default: null.MakeString("0")},
+		),
+		ddl.WithTable("store",
+			&ddl.Column{Field: "store_id", ColumnType: "smallint(5) unsigned", Null: "NO", Key: "PRI", Extra: "auto_increment"},
+		),
+		ddl.WithTable("only_current",
+			&ddl.Column{Field: "id", ColumnType: "int(11)", Null: "NO"},
+		),
+	)
+
+	t.Run("alter and create", func(t *testing.T) {
+		desired := ddl.MustNewTables(
+			ddl.WithTable("customer_entity",
+				&ddl.Column{Field: "entity_id", ColumnType: "int(10) unsigned", Null: "NO", Key: "PRI", Extra: "auto_increment"},
+				&ddl.Column{Field: "email", ColumnType: "varchar(320)", Null: "YES", Comment: "E-Mail"},
+				&ddl.Column{Field: "group_id", ColumnType: "smallint(5) unsigned", Null: "NO", Default: null.MakeString("0")},
+				&ddl.Column{Field: "firstname", ColumnType: "varchar(255)", Null: "NO", Default: null.MakeString("")},
+			),
+			ddl.WithTable("catalog_category_product",
+				&ddl.Column{Field: "category_id", ColumnType: "int(10) unsigned", Null: "NO", Default: null.MakeString("0"), Key: "PRI"},
+				&ddl.Column{Field: "product_id", ColumnType: "int(10) unsigned", Null: "NO", Default: null.MakeString("0"), Key: "PRI"},
+			),
+			ddl.WithTable("store",
+				&ddl.Column{Field: "store_id", ColumnType: "smallint(5) unsigned", Null: "NO", Key: "PRI", Extra: "auto_increment"},
+			),
+			ddl.WithTable("sales_order_grid",
+				&ddl.Column{Field: "id", ColumnType: "int(10) unsigned", Null: "NO", Key: "PRI", Extra: "auto_increment", Comment: "It's ID"},
+				&ddl.Column{Field: "created_at", ColumnType: "timestamp", Null: "NO", Default: null.MakeString("current_timestamp()"), Extra: "on update current_timestamp()"},
+			),
+		)
+
+		stmts, err := ddl.Diff(current, desired)
+		assert.NoError(t, err)
+		assert.Exactly(t, []string{
+			"ALTER TABLE `catalog_category_product` DROP PRIMARY KEY, DROP COLUMN `entity_id`, ADD PRIMARY KEY (`category_id`,`product_id`)",
+			"ALTER TABLE `customer_entity` DROP COLUMN `old_col`, MODIFY COLUMN `email` varchar(320) NULL DEFAULT NULL COMMENT 'E-Mail', ADD COLUMN `firstname` varchar(255) NOT NULL DEFAULT '' AFTER `group_id`",
+			"CREATE TABLE `sales_order_grid` (\n  `id` int(10) unsigned NOT NULL AUTO_INCREMENT COMMENT 'It\\'s ID',\n  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n  PRIMARY KEY (`id`)\n)",
+		}, stmts)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		stmts, err := ddl.Diff(current, current)
+		assert.NoError(t, err)
+		assert.Nil(t, stmts)
+	})
+
+	t.Run("indexes", func(t *testing.T) {
+		cur := ddl.MustNewTables(
+			ddl.WithTable("customer_entity",
+				&ddl.Column{Field: "entity_id", ColumnType: "int(10) unsigned", Null: "NO", Key: "PRI", Extra: "auto_increment"},
+				&ddl.Column{Field: "email", ColumnType: "varchar(255)", Null: "YES"},
+				&ddl.Column{Field: "website_id", ColumnType: "smallint(5) unsigned", Null: "YES"},
+			),
+		)
+		cur.MustTable("customer_entity").Indexes = ddl.Indexes{
+			{Name: "PRIMARY", Columns: []string{"entity_id"}, Unique: true, Type: "BTREE"},
+			{Name: "CUSTOMER_ENTITY_EMAIL", Columns: []string{"email"}, Type: "BTREE"},
+			{Name: "CUSTOMER_ENTITY_WEBSITE_ID", Columns: []string{"website_id"}, Type: "BTREE"},
+		}
+
+		des := ddl.MustNewTables(
+			ddl.WithTable("customer_entity",
+				&ddl.Column{Field: "entity_id", ColumnType: "int(10) unsigned", Null: "NO", Key: "PRI", Extra: "auto_increment"},
+				&ddl.Column{Field: "email", ColumnType: "varchar(255)", Null: "YES"},
+				&ddl.Column{Field: "website_id", ColumnType: "smallint(5) unsigned", Null: "YES"},
+			),
+			ddl.WithTable("customer_grid_flat",
+				&ddl.Column{Field: "entity_id", ColumnType: "int(10) unsigned", Null: "NO", Key: "PRI"},
+				&ddl.Column{Field: "name", ColumnType: "text", Null: "YES"},
+			),
+		)
+		des.MustTable("customer_entity").Indexes = ddl.Indexes{
+			{Name: "PRIMARY", Columns: []string{"entity_id"}, Unique: true},
+			{Name: "CUSTOMER_ENTITY_EMAIL_WEBSITE_ID", Columns: []string{"email", "website_id"}, Unique: true},
+			{Name: "CUSTOMER_ENTITY_WEBSITE_ID", Columns: []string{"website_id"}},
+		}
+		des.MustTable("customer_grid_flat").Indexes = ddl.Indexes{
+			{Name: "CUSTOMER_GRID_FLAT_NAME", Columns: []string{"name"}, Type: "FULLTEXT"},
+		}
+
+		stmts, err := ddl.Diff(cur, des)
+		assert.NoError(t, err)
+		assert.Exactly(t, []string{
+			"ALTER TABLE `customer_entity` DROP INDEX `CUSTOMER_ENTITY_EMAIL`, ADD UNIQUE INDEX `CUSTOMER_ENTITY_EMAIL_WEBSITE_ID` (`email`,`website_id`)",
+			"CREATE TABLE `customer_grid_flat` (\n  `entity_id` int(10) unsigned NOT NULL,\n  `name` text NULL DEFAULT NULL,\n  PRIMARY KEY (`entity_id`),\n  FULLTEXT INDEX `CUSTOMER_GRID_FLAT_NAME` (`name`)\n)",
+		}, stmts)
+	})
+
+	t.Run("prefix index", func(t *testing.T) {
+		cols := func() []*ddl.Column {
+			return []*ddl.Column{
+				{Field: "entity_id", ColumnType: "int(10) unsigned", Null: "NO", Key: "PRI", Extra: "auto_increment"},
+				{Field: "email", ColumnType: "varchar(255)", Null: "YES"},
+			}
+		}
+		cur := ddl.MustNewTables(ddl.WithTable("customer_entity", cols()...))
+		cur.MustTable("customer_entity").Indexes = ddl.Indexes{
+			{Name: "CUSTOMER_ENTITY_EMAIL", Columns: []string{"email"}, SubParts: []int{0}, Type: "BTREE"},
+		}
+		des := ddl.MustNewTables(ddl.WithTable("customer_entity", cols()...))
+		des.MustTable("customer_entity").Indexes = ddl.Indexes{
+			{Name: "CUSTOMER_ENTITY_EMAIL", Columns: []string{"email"}, SubParts: []int{20}},
+		}
+
+		stmts, err := ddl.Diff(cur, des)
+		assert.NoError(t, err)
+		assert.Exactly(t, []string{
+			"ALTER TABLE `customer_entity` DROP INDEX `CUSTOMER_ENTITY_EMAIL`, ADD INDEX `CUSTOMER_ENTITY_EMAIL` (`email`(20))",
+		}, stmts)
+	})
+
+	t.Run("equal indexes", func(t *testing.T) {
+		newTables := func(idx ddl.Index) *ddl.Tables {
+			tbls := ddl.MustNewTables(ddl.WithTable("customer_entity",
+				&ddl.Column{Field: "entity_id", ColumnType: "int(10) unsigned", Null: "NO", Key: "PRI", Extra: "auto_increment"},
+				&ddl.Column{Field: "email", ColumnType: "varchar(255)", Null: "YES"},
+			))
+			tbls.MustTable("customer_entity").Indexes = ddl.Indexes{idx}
+			return tbls
+		}
+		stmts, err := ddl.Diff(
+			newTables(ddl.Index{Name: "CUSTOMER_ENTITY_EMAIL", Columns: []string{"email"}, SubParts: []int{0}, Type: "BTREE"}),
+			newTables(ddl.Index{Name: "CUSTOMER_ENTITY_EMAIL", Columns: []string{"email"}}),
+		)
+		assert.NoError(t, err)
+		assert.Nil(t, stmts)
+	})
+
+	t.Run("generated column not supported", func(t *testing.T) {
+		desired := ddl.MustNewTables(
+			ddl.WithTable("store",
+				&ddl.Column{Field: "store_id", ColumnType: "smallint(5) unsigned", Null: "NO", Key: "PRI", Extra: "auto_increment"},
+				&ddl.Column{Field: "code_upper", ColumnType: "varchar(32)", Null: "YES", Extra: "VIRTUAL GENERATED"},
+			),
+		)
+		stmts, err := ddl.Diff(current, desired)
+		assert.Nil(t, stmts)
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	})
+}
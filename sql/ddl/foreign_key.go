@@ -16,7 +16,6 @@ package ddl
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 
 	"github.com/corestoreio/errors"
@@ -189,78 +188,98 @@ func (cc KeyColumnUsageCollection) ColumnNames(ret ...string) []string {
 	return ret
 }
 
-// LoadKeyColumnUsage returns all foreign key columns from a list of table names in
-// the current database. Map key contains
-// REFERENCED_TABLE_NAME.REFERENCED_COLUMN_NAME. All columns from all tables
-// gets selected when you don't provide the argument `tables`.
-func LoadKeyColumnUsage(ctx context.Context, db dml.Querier, tables ...string) (tc map[string]KeyColumnUsageCollection, err error) {
-
-	const selFkWhere = ` AND REFERENCED_TABLE_NAME IN ?`
-	const selFkOrderBy = ` ORDER BY TABLE_SCHEMA,TABLE_NAME,ORDINAL_POSITION, COLUMN_NAME`
-
-	const selFkTablesColumns = `SELECT
-	CONSTRAINT_CATALOG, CONSTRAINT_SCHEMA, CONSTRAINT_NAME, TABLE_CATALOG, TABLE_SCHEMA,
-	TABLE_NAME, COLUMN_NAME, ORDINAL_POSITION, POSITION_IN_UNIQUE_CONSTRAINT,
-	REFERENCED_TABLE_SCHEMA, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME
-	 FROM information_schema.KEY_COLUMN_USAGE WHERE REFERENCED_TABLE_SCHEMA = DATABASE()` + selFkWhere + selFkOrderBy
-
-	const selFkAllTablesColumns = `SELECT
+// selKeyColumnUsage selects all columns of KEY_COLUMN_USAGE. The WHERE
+// condition must be appended.
+const selKeyColumnUsage = `SELECT
 	CONSTRAINT_CATALOG, CONSTRAINT_SCHEMA, CONSTRAINT_NAME, TABLE_CATALOG, TABLE_SCHEMA,
 	TABLE_NAME, COLUMN_NAME, ORDINAL_POSITION, POSITION_IN_UNIQUE_CONSTRAINT,
 	REFERENCED_TABLE_SCHEMA, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME
-	 FROM information_schema.KEY_COLUMN_USAGE WHERE REFERENCED_TABLE_SCHEMA = DATABASE()` + selFkOrderBy
+	 FROM information_schema.KEY_COLUMN_USAGE WHERE `
 
-	var rows *sql.Rows
-	if len(tables) == 0 {
-		rows, err = db.QueryContext(ctx, selFkAllTablesColumns)
-		if err != nil {
-			return nil, errors.Wrapf(err, "[ddl] LoadKeyColumnUsage QueryContext for tables %v", tables)
-		}
-	} else {
-		sqlStr, _, err := dml.Interpolate(selFkTablesColumns).Strs(tables...).ToSQL()
-		if err != nil {
-			return nil, errors.Wrapf(err, "[ddl] LoadKeyColumnUsage dml.ExpandPlaceHolders for tables %v", tables)
-		}
-		rows, err = db.QueryContext(ctx, sqlStr)
-		if err != nil {
-			return nil, errors.Wrapf(err, "[ddl] LoadKeyColumnUsage QueryContext for tables %v with WHERE clause", tables)
-		}
+// loadKeyColumnUsage queries the table KEY_COLUMN_USAGE and calls fn for each
+// foreign key column. The where clause gets extended by whereTables, if tables
+// have been provided. Rows whose referenced table or column are NULL trigger a
+// Fatal error.
+func loadKeyColumnUsage(ctx context.Context, db dml.Querier, where, whereTables, orderBy string, tables []string, fn func(*KeyColumnUsage)) (err error) {
+	rows, err := queryTables(ctx, db, selKeyColumnUsage+where, whereTables, orderBy, tables)
+	if err != nil {
+		return errors.WithStack(err)
 	}
-
 	defer func() {
 		// Not testable with the sqlmock package :-(
 		if err2 := rows.Close(); err2 != nil && err == nil {
-			err = errors.Wrap(err2, "[ddl] LoadKeyColumnUsage.Rows.Close")
+			err = errors.Wrap(err2, "[ddl] KeyColumnUsage.Rows.Close")
 		}
 	}()
 
-	tc = make(map[string]KeyColumnUsageCollection)
 	rc := new(dml.ColumnMap)
 	for rows.Next() {
 		if err = rc.Scan(rows); err != nil {
-			err = errors.Wrapf(err, "[ddl] LoadKeyColumnUsage Scan Query for tables: %v", tables) // due to the defer
-			return
+			return errors.WithStack(err)
 		}
 		kcu := NewKeyColumnUsage()
 		if err = kcu.MapColumns(rc); err != nil {
-			err = errors.WithStack(err)
-			return
+			return errors.WithStack(err)
 		}
 		if !kcu.ReferencedTableName.Valid || !kcu.ReferencedColumnName.Valid {
-			err = errors.Fatal.Newf("[ddl] LoadKeyColumnUsage: The columns ReferencedTableName or ReferencedColumnName cannot be null: %#v", kcu)
-			return
+			return errors.Fatal.Newf("[ddl] The columns ReferencedTableName or ReferencedColumnName cannot be null: %#v", kcu)
 		}
+		fn(kcu)
+	}
+	return errors.WithStack(rows.Err())
+}
+
+// LoadKeyColumnUsage returns all foreign key columns from a list of table names in
+// the current database. Map key contains
+// REFERENCED_TABLE_NAME.REFERENCED_COLUMN_NAME. All columns from all tables
+// gets selected when you don't provide the argument `tables`.
+func LoadKeyColumnUsage(ctx context.Context, db dml.Querier, tables ...string) (map[string]KeyColumnUsageCollection, error) {
+	const selFkWhere = `REFERENCED_TABLE_SCHEMA = DATABASE()`
+	const selFkWhereTables = ` AND REFERENCED_TABLE_NAME IN ?`
+	const selFkOrderBy = ` ORDER BY TABLE_SCHEMA,TABLE_NAME,ORDINAL_POSITION, COLUMN_NAME`
+
+	tc := make(map[string]KeyColumnUsageCollection)
+	err := loadKeyColumnUsage(ctx, db, selFkWhere, selFkWhereTables, selFkOrderBy, tables, func(kcu *KeyColumnUsage) {
 		key := fmt.Sprintf("%s.%s", kcu.ReferencedTableName.String, kcu.ReferencedColumnName.String)
-		if _, ok := tc[key]; !ok {
-			tc[key] = MakeKeyColumnUsageCollection()
+		kcuc, ok := tc[key]
+		if !ok {
+			kcuc = MakeKeyColumnUsageCollection()
 		}
-
-		kcuc := tc[key]
 		kcuc.Data = append(kcuc.Data, kcu)
 		tc[key] = kcuc
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadKeyColumnUsage for tables %v", tables)
 	}
-	if err = rows.Err(); err != nil {
-		err = errors.Wrapf(err, "[ddl] rows.Err Query")
+	return tc, nil
+}
+
+// LoadForeignKeys returns the foreign keys of the tables in the current
+// database, loaded from information_schema.KEY_COLUMN_USAGE. Map key contains
+// the table name. All foreign keys from all tables get selected when you don't
+// provide the argument `tables`.
+func LoadForeignKeys(ctx context.Context, db dml.Querier, tables ...string) (map[string]ForeignKeys, error) {
+	const selFKsWhere = `TABLE_SCHEMA=DATABASE() AND REFERENCED_TABLE_NAME IS NOT NULL`
+	const selFKsWhereTables = ` AND TABLE_NAME IN ?`
+	const selFKsOrderBy = ` ORDER BY TABLE_NAME, CONSTRAINT_NAME, ORDINAL_POSITION`
+
+	tfk := make(map[string]ForeignKeys)
+	err := loadKeyColumnUsage(ctx, db, selFKsWhere, selFKsWhereTables, selFKsOrderBy, tables, func(kcu *KeyColumnUsage) {
+		fks := tfk[kcu.TableName]
+		if l := len(fks); l > 0 && fks[l-1].Name == kcu.ConstraintName {
+			fks[l-1].Columns = append(fks[l-1].Columns, kcu.ColumnName)
+			fks[l-1].ReferencedColumns = append(fks[l-1].ReferencedColumns, kcu.ReferencedColumnName.String)
+			return
+		}
+		tfk[kcu.TableName] = append(fks, ForeignKey{
+			Name:              kcu.ConstraintName,
+			Columns:           []string{kcu.ColumnName},
+			ReferencedTable:   kcu.ReferencedTableName.String,
+			ReferencedColumns: []string{kcu.ReferencedColumnName.String},
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadForeignKeys for tables %v", tables)
 	}
-	return
+	return tfk, nil
 }
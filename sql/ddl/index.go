@@ -0,0 +1,222 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+)
+
+// IndexPrimary defines the name of the primary key index.
+const IndexPrimary = "PRIMARY"
+
+// Index represents an index of a table, as returned by SHOW INDEX.
+type Index struct {
+	// Name of the index. The primary key is always named PRIMARY.
+	Name string
+	// Columns contains the column names in the order of the index.
+	Columns []string
+	// SubParts contains for each column the number of indexed characters of a
+	// prefix index. 0 means the entire column has been indexed. A nil slice
+	// equals all zeros.
+	SubParts []int
+	Unique   bool
+	// Type can be BTREE, HASH, FULLTEXT or SPATIAL. Empty means BTREE.
+	Type string
+}
+
+// IsPrimary returns true if the index is the primary key.
+func (i Index) IsPrimary() bool {
+	return i.Name == IndexPrimary
+}
+
+func (i Index) equal(o Index) bool {
+	it, ot := i.Type, o.Type
+	if it == "" {
+		it = "BTREE"
+	}
+	if ot == "" {
+		ot = "BTREE"
+	}
+	if i.Unique != o.Unique || !strings.EqualFold(it, ot) ||
+		strings.Join(i.Columns, ",") != strings.Join(o.Columns, ",") {
+		return false
+	}
+	for idx := range i.Columns {
+		if i.subPart(idx) != o.subPart(idx) {
+			return false
+		}
+	}
+	return true
+}
+
+// subPart returns the prefix length of the column at index idx or 0.
+func (i Index) subPart(idx int) int {
+	if idx < len(i.SubParts) {
+		return i.SubParts[idx]
+	}
+	return 0
+}
+
+// columnsDefinition renders the quoted columns including their prefix lengths
+// as used in CREATE TABLE and ALTER TABLE statements.
+func (i Index) columnsDefinition() string {
+	qn := make([]string, len(i.Columns))
+	for idx, n := range i.Columns {
+		qn[idx] = dml.Quoter.Name(n)
+		if sp := i.subPart(idx); sp > 0 {
+			qn[idx] += "(" + strconv.Itoa(sp) + ")"
+		}
+	}
+	return strings.Join(qn, ",")
+}
+
+// Indexes contains all indexes of a table.
+type Indexes []Index
+
+// ByName returns the index with the name `name`. Case insensitive. The
+// returned bool is false if the index cannot be found.
+func (is Indexes) ByName(name string) (Index, bool) {
+	for _, i := range is {
+		if strings.EqualFold(i.Name, name) {
+			return i, true
+		}
+	}
+	return Index{}, false
+}
+
+// Uniques returns the primary key and all unique indexes.
+func (is Indexes) Uniques() Indexes {
+	var ret Indexes
+	for _, i := range is {
+		if i.Unique {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+// ForeignKey represents a foreign key constraint of a table.
+type ForeignKey struct {
+	// Name of the constraint.
+	Name string
+	// Columns contains the column names of the table in the order of the
+	// constraint.
+	Columns           []string
+	ReferencedTable   string
+	ReferencedColumns []string
+	// OnDelete and OnUpdate contain the referential action, e.g. CASCADE or
+	// SET NULL. Empty means the default RESTRICT. LoadForeignKeys does not
+	// load them.
+	OnDelete string
+	OnUpdate string
+}
+
+// ForeignKeys contains all foreign keys of a table.
+type ForeignKeys []ForeignKey
+
+// LoadIndexes returns the indexes of the tables in the current database. The
+// data equals SHOW INDEX but gets loaded from information_schema.STATISTICS
+// for all tables at once. Map key contains the table name. All indexes from
+// all tables get selected when you don't provide the argument `tables`.
+func LoadIndexes(ctx context.Context, db dml.Querier, tables ...string) (map[string]Indexes, error) {
+	const selIndexes = `SELECT TABLE_NAME, INDEX_NAME, NON_UNIQUE, COLUMN_NAME, SUB_PART, INDEX_TYPE
+	 FROM information_schema.STATISTICS WHERE TABLE_SCHEMA=DATABASE()`
+	const selIndexesWhere = ` AND TABLE_NAME IN ?`
+	const selIndexesOrderBy = ` ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX`
+
+	rows, err := queryTables(ctx, db, selIndexes, selIndexesWhere, selIndexesOrderBy, tables)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadIndexes for tables %v", tables)
+	}
+	defer rows.Close()
+
+	ti := make(map[string]Indexes)
+	for rows.Next() {
+		var tableName, indexName, columnName, indexType string
+		var nonUnique int64
+		var subPart sql.NullInt64
+		if err := rows.Scan(&tableName, &indexName, &nonUnique, &columnName, &subPart, &indexType); err != nil {
+			return nil, errors.Wrapf(err, "[ddl] LoadIndexes Scan for tables %v", tables)
+		}
+		is := ti[tableName]
+		if l := len(is); l > 0 && is[l-1].Name == indexName {
+			is[l-1].Columns = append(is[l-1].Columns, columnName)
+			is[l-1].SubParts = append(is[l-1].SubParts, int(subPart.Int64))
+			continue
+		}
+		ti[tableName] = append(is, Index{
+			Name:     indexName,
+			Columns:  []string{columnName},
+			SubParts: []int{int(subPart.Int64)},
+			Unique:   nonUnique == 0,
+			Type:     indexType,
+		})
+	}
+	return ti, errors.WithStack(rows.Err())
+}
+
+// queryTables runs the query with the optional WHERE clause, if tables have
+// been provided, and the ORDER BY clause.
+func queryTables(ctx context.Context, db dml.Querier, sel, where, orderBy string, tables []string) (*sql.Rows, error) {
+	sqlStr := sel + orderBy
+	if len(tables) > 0 {
+		var err error
+		sqlStr, _, err = dml.Interpolate(sel + where + orderBy).Strs(tables...).ToSQL()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	rows, err := db.QueryContext(ctx, sqlStr)
+	return rows, errors.WithStack(err)
+}
+
+// WithLoadKeys loads the indexes and the foreign keys of all tables, which
+// have been added beforehand, into the fields Indexes and ForeignKeys.
+func WithLoadKeys(ctx context.Context, db dml.Querier) TableOption {
+	return TableOption{
+		sortOrder: 20,
+		fn: func(tm *Tables) error {
+			tm.mu.Lock()
+			defer tm.mu.Unlock()
+
+			names := make([]string, 0, len(tm.tm))
+			for n := range tm.tm {
+				names = append(names, n)
+			}
+			if len(names) == 0 {
+				return nil
+			}
+			ti, err := LoadIndexes(ctx, db, names...)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			tfk, err := LoadForeignKeys(ctx, db, names...)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			for n, t := range tm.tm {
+				t.Indexes = ti[n]
+				t.ForeignKeys = tfk[n]
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"context"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestLoadIndexes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("some tables", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT TABLE_NAME, INDEX_NAME, NON_UNIQUE, COLUMN_NAME, SUB_PART, INDEX_TYPE FROM information_schema.STATISTICS WHERE TABLE_SCHEMA=DATABASE() AND TABLE_NAME IN ('customer_entity','store') ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX")).
+			WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "INDEX_NAME", "NON_UNIQUE", "COLUMN_NAME", "SUB_PART", "INDEX_TYPE"}).
+				AddRow("customer_entity", "CUSTOMER_ENTITY_EMAIL_WEBSITE_ID", 0, "email", nil, "BTREE").
+				AddRow("customer_entity", "CUSTOMER_ENTITY_EMAIL_WEBSITE_ID", 0, "website_id", nil, "BTREE").
+				AddRow("customer_entity", "CUSTOMER_ENTITY_FIRSTNAME", 1, "firstname", nil, "FULLTEXT").
+				AddRow("customer_entity", "CUSTOMER_ENTITY_LASTNAME_EMAIL", 1, "lastname", 10, "BTREE").
+				AddRow("customer_entity", "CUSTOMER_ENTITY_LASTNAME_EMAIL", 1, "email", nil, "BTREE").
+				AddRow("customer_entity", "PRIMARY", 0, "entity_id", nil, "BTREE").
+				AddRow("store", "PRIMARY", 0, "store_id", nil, "BTREE"))
+
+		tis, err := ddl.LoadIndexes(context.TODO(), dbc.DB, "customer_entity", "store")
+		assert.NoError(t, err)
+		assert.Exactly(t, map[string]ddl.Indexes{
+			"customer_entity": {
+				{Name: "CUSTOMER_ENTITY_EMAIL_WEBSITE_ID", Columns: []string{"email", "website_id"}, SubParts: []int{0, 0}, Unique: true, Type: "BTREE"},
+				{Name: "CUSTOMER_ENTITY_FIRSTNAME", Columns: []string{"firstname"}, SubParts: []int{0}, Type: "FULLTEXT"},
+				{Name: "CUSTOMER_ENTITY_LASTNAME_EMAIL", Columns: []string{"lastname", "email"}, SubParts: []int{10, 0}, Type: "BTREE"},
+				{Name: "PRIMARY", Columns: []string{"entity_id"}, SubParts: []int{0}, Unique: true, Type: "BTREE"},
+			},
+			"store": {
+				{Name: "PRIMARY", Columns: []string{"store_id"}, SubParts: []int{0}, Unique: true, Type: "BTREE"},
+			},
+		}, tis)
+
+		pk, ok := tis["customer_entity"].ByName("primary")
+		assert.True(t, ok)
+		assert.True(t, pk.IsPrimary())
+		assert.Len(t, tis["customer_entity"].Uniques(), 2)
+	})
+	t.Run("all tables", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT TABLE_NAME, INDEX_NAME, NON_UNIQUE, COLUMN_NAME, SUB_PART, INDEX_TYPE FROM information_schema.STATISTICS WHERE TABLE_SCHEMA=DATABASE() ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX")).
+			WillReturnError(errors.ConnectionFailed.Newf("Ups"))
+
+		tis, err := ddl.LoadIndexes(context.TODO(), dbc.DB)
+		assert.Nil(t, tis)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Ups")
+	})
+}
+
+const selKeyColumnUsage = "SELECT CONSTRAINT_CATALOG, CONSTRAINT_SCHEMA, CONSTRAINT_NAME, TABLE_CATALOG, TABLE_SCHEMA, TABLE_NAME, COLUMN_NAME, ORDINAL_POSITION, POSITION_IN_UNIQUE_CONSTRAINT, REFERENCED_TABLE_SCHEMA, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME FROM information_schema.KEY_COLUMN_USAGE WHERE "
+
+var keyColumnUsageColumns = []string{
+	"CONSTRAINT_CATALOG", "CONSTRAINT_SCHEMA", "CONSTRAINT_NAME", "TABLE_CATALOG", "TABLE_SCHEMA",
+	"TABLE_NAME", "COLUMN_NAME", "ORDINAL_POSITION", "POSITION_IN_UNIQUE_CONSTRAINT",
+	"REFERENCED_TABLE_SCHEMA", "REFERENCED_TABLE_NAME", "REFERENCED_COLUMN_NAME",
+}
+
+func TestLoadForeignKeys(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(selKeyColumnUsage + "TABLE_SCHEMA=DATABASE() AND REFERENCED_TABLE_NAME IS NOT NULL AND TABLE_NAME IN ('catalog_product_website') ORDER BY TABLE_NAME, CONSTRAINT_NAME, ORDINAL_POSITION")).
+		WillReturnRows(sqlmock.NewRows(keyColumnUsageColumns).
+			AddRow("def", "magento", "CAT_PRD_WS_PRD_ID_CAT_PRD_ENTT_ENTT_ID", "def", "magento", "catalog_product_website", "product_id", 1, 1, "magento", "catalog_product_entity", "entity_id").
+			AddRow("def", "magento", "CAT_PRD_WS_WS_ID_STORE_WS_WS_ID", "def", "magento", "catalog_product_website", "website_id", 1, 1, "magento", "store_website", "website_id").
+			AddRow("def", "magento", "CAT_PRD_WS_WS_ID_STORE_WS_WS_ID", "def", "magento", "catalog_product_website", "store_id", 2, 2, "magento", "store_website", "default_store_id"))
+
+	tfks, err := ddl.LoadForeignKeys(context.TODO(), dbc.DB, "catalog_product_website")
+	assert.NoError(t, err)
+	assert.Exactly(t, map[string]ddl.ForeignKeys{
+		"catalog_product_website": {
+			{
+				Name:              "CAT_PRD_WS_PRD_ID_CAT_PRD_ENTT_ENTT_ID",
+				Columns:           []string{"product_id"},
+				ReferencedTable:   "catalog_product_entity",
+				ReferencedColumns: []string{"entity_id"},
+			},
+			{
+				Name:              "CAT_PRD_WS_WS_ID_STORE_WS_WS_ID",
+				Columns:           []string{"website_id", "store_id"},
+				ReferencedTable:   "store_website",
+				ReferencedColumns: []string{"website_id", "default_store_id"},
+			},
+		},
+	}, tfks)
+}
+
+func TestWithLoadKeys(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT TABLE_NAME, INDEX_NAME, NON_UNIQUE, COLUMN_NAME, SUB_PART, INDEX_TYPE FROM information_schema.STATISTICS WHERE TABLE_SCHEMA=DATABASE() AND TABLE_NAME IN ('store') ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX")).
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "INDEX_NAME", "NON_UNIQUE", "COLUMN_NAME", "SUB_PART", "INDEX_TYPE"}).
+			AddRow("store", "PRIMARY", 0, "store_id", nil, "BTREE").
+			AddRow("store", "STORE_WEBSITE_ID", 1, "website_id", nil, "BTREE"))
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(selKeyColumnUsage + "TABLE_SCHEMA=DATABASE() AND REFERENCED_TABLE_NAME IS NOT NULL AND TABLE_NAME IN ('store') ORDER BY TABLE_NAME, CONSTRAINT_NAME, ORDINAL_POSITION")).
+		WillReturnRows(sqlmock.NewRows(keyColumnUsageColumns).
+			AddRow("def", "magento", "STORE_WEBSITE_ID_STORE_WEBSITE_WEBSITE_ID", "def", "magento", "store", "website_id", 1, 1, "magento", "store_website", "website_id"))
+
+	tables := ddl.MustNewTables(
+		ddl.WithTable("store"),
+		ddl.WithLoadKeys(context.TODO(), dbc.DB),
+	)
+	store := tables.MustTable("store")
+	assert.Len(t, store.Indexes, 2)
+	assert.Exactly(t, []string{"website_id"}, store.Indexes[1].Columns)
+	assert.Exactly(t, ddl.ForeignKeys{{
+		Name:              "STORE_WEBSITE_ID_STORE_WEBSITE_WEBSITE_ID",
+		Columns:           []string{"website_id"},
+		ReferencedTable:   "store_website",
+		ReferencedColumns: []string{"website_id"},
+	}}, store.ForeignKeys)
+}
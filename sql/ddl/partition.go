@@ -0,0 +1,250 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/util/bufferpool"
+)
+
+// Partition represents a partition of a table from
+// information_schema.PARTITIONS. Sub partitions get merged into their
+// partition.
+type Partition struct {
+	Name     string
+	Position uint64
+	// Method can be RANGE, LIST, HASH, KEY, LINEAR HASH, LINEAR KEY, RANGE
+	// COLUMNS or LIST COLUMNS.
+	Method string
+	// Expression contains the partitioning expression, e.g. YEAR(`created_at`)
+	// or the column names.
+	Expression string
+	// Description contains for RANGE the exclusive upper bound, e.g. 2020 or
+	// MAXVALUE, and for LIST the comma separated values. Empty for HASH and
+	// KEY.
+	Description string
+	// Rows contains the approximate row count.
+	Rows uint64
+}
+
+// Partitions contains all partitions of a table, ordered by their position.
+type Partitions []Partition
+
+// Names returns the names of all partitions.
+func (ps Partitions) Names() []string {
+	ret := make([]string, 0, len(ps))
+	for _, p := range ps {
+		ret = append(ret, p.Name)
+	}
+	return ret
+}
+
+// Hint returns the explicit partition selection, e.g. "PARTITION (`p0`,`p1`)",
+// which can be appended to the table name in a SELECT, UPDATE or DELETE
+// statement to restrict the statement to the partitions. Returns an empty
+// string when there are no partitions.
+func (ps Partitions) Hint() string {
+	if len(ps) == 0 {
+		return ""
+	}
+	return "PARTITION (" + quoteNames(ps.Names()) + ")"
+}
+
+// ByKey returns the partition to which the key belongs. The key must be the
+// already evaluated integer value of the partitioning expression. Supported
+// methods are RANGE, LIST, HASH and LINEAR HASH and their COLUMNS variants with
+// one integer column. KEY partitioning uses the internal hash function of the
+// server and returns a NotSupported error. A NotFound error gets returned if no
+// partition matches.
+func (ps Partitions) ByKey(key int64) (Partition, error) {
+	if len(ps) == 0 {
+		return Partition{}, errors.NotFound.Newf("[ddl] No partitions available for key %d", key)
+	}
+	switch method := strings.ToUpper(ps[0].Method); method {
+	case "RANGE", "RANGE COLUMNS":
+		for _, p := range ps {
+			if strings.EqualFold(p.Description, "MAXVALUE") {
+				return p, nil
+			}
+			bound, err := strconv.ParseInt(p.Description, 10, 64)
+			if err != nil {
+				return Partition{}, errors.NotSupported.New(err, "[ddl] Partition %q has a non-integer range %q", p.Name, p.Description)
+			}
+			if key < bound {
+				return p, nil
+			}
+		}
+	case "LIST", "LIST COLUMNS":
+		k := strconv.FormatInt(key, 10)
+		for _, p := range ps {
+			for _, v := range strings.Split(p.Description, ",") {
+				if strings.TrimSpace(v) == k {
+					return p, nil
+				}
+			}
+		}
+	case "HASH":
+		n := key % int64(len(ps))
+		if n < 0 {
+			n = -n
+		}
+		return ps[n], nil
+	case "LINEAR HASH":
+		return ps[linearHash(key, uint64(len(ps)))], nil
+	default:
+		return Partition{}, errors.NotSupported.Newf("[ddl] Partition method %q not supported", method)
+	}
+	return Partition{}, errors.NotFound.Newf("[ddl] No partition found for key %d", key)
+}
+
+// linearHash implements the powers-of-two algorithm of LINEAR HASH.
+// https://dev.mysql.com/doc/refman/5.7/en/partitioning-linear-hash.html
+func linearHash(key int64, num uint64) uint64 {
+	if key < 0 {
+		key = -key
+	}
+	v := uint64(1)
+	for v < num {
+		v <<= 1
+	}
+	n := uint64(key) & (v - 1)
+	for n >= num {
+		v >>= 1
+		n &= v - 1
+	}
+	return n
+}
+
+// RangeBelow returns all RANGE partitions which only contain values lower
+// than the key, for example to drop outdated data.
+func (ps Partitions) RangeBelow(key int64) Partitions {
+	var ret Partitions
+	for _, p := range ps {
+		if !strings.HasPrefix(strings.ToUpper(p.Method), "RANGE") {
+			continue
+		}
+		if bound, err := strconv.ParseInt(p.Description, 10, 64); err == nil && bound <= key {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// LoadPartitions loads the partitions of the tables in the current database
+// from information_schema.PARTITIONS. Map key contains the table name, tables
+// without partitions are not included. All tables get selected when you don't
+// provide the argument `tables`.
+func LoadPartitions(ctx context.Context, db dml.Querier, tables ...string) (map[string]Partitions, error) {
+	const selPartitions = `SELECT TABLE_NAME, PARTITION_NAME, PARTITION_ORDINAL_POSITION, PARTITION_METHOD,
+	 PARTITION_EXPRESSION, PARTITION_DESCRIPTION, TABLE_ROWS
+	 FROM information_schema.PARTITIONS WHERE TABLE_SCHEMA=DATABASE() AND PARTITION_NAME IS NOT NULL`
+	const selPartitionsWhere = ` AND TABLE_NAME IN ?`
+	const selPartitionsOrderBy = ` ORDER BY TABLE_NAME, PARTITION_ORDINAL_POSITION, SUBPARTITION_ORDINAL_POSITION`
+
+	rows, err := queryTables(ctx, db, selPartitions, selPartitionsWhere, selPartitionsOrderBy, tables)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadPartitions for tables %v", tables)
+	}
+	defer rows.Close()
+
+	tps := make(map[string]Partitions)
+	for rows.Next() {
+		var tableName, name, method string
+		var pos uint64
+		var expr, desc sql.NullString
+		var tRows sql.NullInt64
+		if err := rows.Scan(&tableName, &name, &pos, &method, &expr, &desc, &tRows); err != nil {
+			return nil, errors.Wrapf(err, "[ddl] LoadPartitions Scan for tables %v", tables)
+		}
+		ps := tps[tableName]
+		if l := len(ps); l > 0 && ps[l-1].Name == name {
+			ps[l-1].Rows += uint64(tRows.Int64) // sub partition
+			continue
+		}
+		tps[tableName] = append(ps, Partition{
+			Name:        name,
+			Position:    pos,
+			Method:      method,
+			Expression:  expr.String,
+			Description: desc.String,
+			Rows:        uint64(tRows.Int64),
+		})
+	}
+	return tps, errors.WithStack(rows.Err())
+}
+
+// WithLoadPartitions loads the partitions of all tables, which have been added
+// beforehand, into the field Partitions.
+func WithLoadPartitions(ctx context.Context, db dml.Querier) TableOption {
+	return TableOption{
+		sortOrder: 22,
+		fn: func(tm *Tables) error {
+			tm.mu.Lock()
+			defer tm.mu.Unlock()
+
+			names := make([]string, 0, len(tm.tm))
+			for n := range tm.tm {
+				names = append(names, n)
+			}
+			if len(names) == 0 {
+				return nil
+			}
+			tps, err := LoadPartitions(ctx, db, names...)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			for n, t := range tm.tm {
+				t.Partitions = tps[n]
+			}
+			return nil
+		},
+	}
+}
+
+// DropPartitions drops the partitions and their data. Only RANGE and LIST
+// partitions can be dropped.
+func (t *Table) DropPartitions(ctx context.Context, execer dml.Execer, names ...string) error {
+	return errors.WithStack(t.alterPartitions(ctx, execer, " DROP PARTITION ", names))
+}
+
+// TruncatePartitions removes all rows from the partitions.
+func (t *Table) TruncatePartitions(ctx context.Context, execer dml.Execer, names ...string) error {
+	return errors.WithStack(t.alterPartitions(ctx, execer, " TRUNCATE PARTITION ", names))
+}
+
+func (t *Table) alterPartitions(ctx context.Context, execer dml.Execer, op string, names []string) error {
+	if err := dml.IsValidIdentifier(t.Name); err != nil {
+		return errors.WithStack(err)
+	}
+	if len(names) == 0 {
+		return errors.Empty.Newf("[ddl] Table %q: no partitions provided", t.Name)
+	}
+
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+	buf.WriteString("ALTER TABLE ")
+	dml.Quoter.WriteQualifierName(buf, t.Schema, t.Name)
+	buf.WriteString(op)
+	buf.WriteString(quoteNames(names))
+
+	_, err := execer.ExecContext(ctx, buf.String())
+	return errors.Wrapf(err, "[ddl] failed to alter partitions %q", buf.String())
+}
@@ -0,0 +1,132 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"context"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestLoadPartitions(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT TABLE_NAME, PARTITION_NAME, PARTITION_ORDINAL_POSITION, PARTITION_METHOD, PARTITION_EXPRESSION, PARTITION_DESCRIPTION, TABLE_ROWS FROM information_schema.PARTITIONS WHERE TABLE_SCHEMA=DATABASE() AND PARTITION_NAME IS NOT NULL AND TABLE_NAME IN ('sales_order','store') ORDER BY TABLE_NAME, PARTITION_ORDINAL_POSITION, SUBPARTITION_ORDINAL_POSITION")).
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "PARTITION_NAME", "PARTITION_ORDINAL_POSITION", "PARTITION_METHOD", "PARTITION_EXPRESSION", "PARTITION_DESCRIPTION", "TABLE_ROWS"}).
+			AddRow("sales_order", "p2019", 1, "RANGE", "year(`created_at`)", "2020", 10).
+			AddRow("sales_order", "p2019", 1, "RANGE", "year(`created_at`)", "2020", 5). // sub partition
+			AddRow("sales_order", "pmax", 2, "RANGE", "year(`created_at`)", "MAXVALUE", 3).
+			AddRow("store", "p0", 1, "HASH", "`store_id`", nil, 7))
+
+	tps, err := ddl.LoadPartitions(context.TODO(), dbc.DB, "sales_order", "store")
+	assert.NoError(t, err)
+	assert.Exactly(t, map[string]ddl.Partitions{
+		"sales_order": {
+			{Name: "p2019", Position: 1, Method: "RANGE", Expression: "year(`created_at`)", Description: "2020", Rows: 15},
+			{Name: "pmax", Position: 2, Method: "RANGE", Expression: "year(`created_at`)", Description: "MAXVALUE", Rows: 3},
+		},
+		"store": {
+			{Name: "p0", Position: 1, Method: "HASH", Expression: "`store_id`", Rows: 7},
+		},
+	}, tps)
+}
+
+func TestPartitions_ByKey(t *testing.T) {
+	t.Parallel()
+
+	newPartitions := func(method string, descs ...string) ddl.Partitions {
+		ps := make(ddl.Partitions, len(descs))
+		for i, d := range descs {
+			ps[i] = ddl.Partition{Name: "p" + string('0'+byte(i)), Method: method, Description: d}
+		}
+		return ps
+	}
+	runner := func(ps ddl.Partitions, key int64, wantName string, wantKind errors.Kind) func(*testing.T) {
+		return func(t *testing.T) {
+			p, err := ps.ByKey(key)
+			if wantKind != errors.NoKind {
+				assert.True(t, wantKind.Match(err), "%+v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Exactly(t, wantName, p.Name)
+		}
+	}
+
+	rangePS := newPartitions("RANGE", "2018", "2020", "MAXVALUE")
+	t.Run("RANGE lower", runner(rangePS, 2017, "p0", errors.NoKind))
+	t.Run("RANGE bound", runner(rangePS, 2018, "p1", errors.NoKind))
+	t.Run("RANGE max", runner(rangePS, 2030, "p2", errors.NoKind))
+	t.Run("RANGE not found", runner(newPartitions("RANGE", "2018"), 2018, "", errors.NotFound))
+	t.Run("RANGE COLUMNS string", runner(newPartitions("RANGE COLUMNS", "'a'"), 1, "", errors.NotSupported))
+
+	listPS := newPartitions("LIST", "1,3,5", "2, 4")
+	t.Run("LIST", runner(listPS, 4, "p1", errors.NoKind))
+	t.Run("LIST not found", runner(listPS, 6, "", errors.NotFound))
+
+	hashPS := newPartitions("HASH", "", "", "", "")
+	t.Run("HASH", runner(hashPS, 7, "p3", errors.NoKind))
+	t.Run("HASH negative", runner(hashPS, -7, "p3", errors.NoKind))
+
+	// Examples from the MySQL documentation with 6 partitions.
+	linearPS := newPartitions("LINEAR HASH", "", "", "", "", "", "")
+	t.Run("LINEAR HASH 2003", runner(linearPS, 2003, "p3", errors.NoKind))
+	t.Run("LINEAR HASH 1998", runner(linearPS, 1998, "p2", errors.NoKind))
+
+	t.Run("KEY", runner(newPartitions("KEY", ""), 1, "", errors.NotSupported))
+	t.Run("empty", runner(nil, 1, "", errors.NotFound))
+}
+
+func TestPartitions_Helpers(t *testing.T) {
+	t.Parallel()
+
+	ps := ddl.Partitions{
+		{Name: "p2018", Method: "RANGE", Description: "2019"},
+		{Name: "p2019", Method: "RANGE", Description: "2020"},
+		{Name: "pmax", Method: "RANGE", Description: "MAXVALUE"},
+	}
+	assert.Exactly(t, []string{"p2018", "p2019", "pmax"}, ps.Names())
+	assert.Exactly(t, "PARTITION (`p2018`,`p2019`,`pmax`)", ps.Hint())
+	assert.Exactly(t, "", ddl.Partitions(nil).Hint())
+	assert.Exactly(t, []string{"p2018"}, ps.RangeBelow(2019).Names())
+	assert.Exactly(t, []string{"p2018", "p2019"}, ps.RangeBelow(2025).Names())
+}
+
+func TestTable_DropPartitions(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `sales_order` DROP PARTITION `p2018`,`p2019`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `sales_order` TRUNCATE PARTITION `pmax`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tbl := ddl.NewTable("sales_order")
+	assert.NoError(t, tbl.DropPartitions(context.TODO(), dbc.DB, "p2018", "p2019"))
+	assert.NoError(t, tbl.TruncatePartitions(context.TODO(), dbc.DB, "pmax"))
+
+	err := tbl.DropPartitions(context.TODO(), dbc.DB)
+	assert.True(t, errors.Empty.Match(err), "%+v", err)
+}
@@ -87,15 +87,14 @@ func (ms MasterStatus) Compare(other MasterStatus) int {
 }
 
 // String converts the file name and the position to a string, separated by a
+// semi-colon. A non-empty ExecutedGTIDSet gets appended, separated by another
 // semi-colon.
 func (ms MasterStatus) String() string {
 	if ms.File == "" {
 		return ""
 	}
 	var str strings.Builder
-	str.WriteString(ms.File)
-	str.WriteByte(';')
-	str.WriteString(strconv.FormatUint(uint64(ms.Position), 10))
+	ms.WriteTo(&str)
 	return str.String()
 }
 
@@ -116,22 +115,54 @@ func (ms MasterStatus) WriteTo(w io.Writer) (n int64, err error) {
 	var buf [16]byte
 	n2, _ = w.Write(strconv.AppendUint(buf[:0], uint64(ms.Position), 10))
 	n += int64(n2)
+
+	if ms.ExecutedGTIDSet != "" {
+		n2, _ = w.Write(semicolon)
+		n += int64(n2)
+		n2, _ = w.Write([]byte(ms.ExecutedGTIDSet))
+		n += int64(n2)
+	}
 	return
 }
 
 // FromString parses as string in the format: mysql-bin.000002;236423 means
-// filename;position.
+// filename;position. An optional third part gets assigned to ExecutedGTIDSet:
+// mysql-bin.000002;236423;3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5
 func (ms *MasterStatus) FromString(str string) error {
 	c := strings.IndexByte(str, ';')
 	if c < 1 {
 		return errors.NotFound.Newf("[ddl] MasterStatus FromString: Delimiter semi-colon not found.")
 	}
 
-	pos, err := strconv.ParseUint(str[c+1:], 10, 32)
+	posStr, gtidSet := str[c+1:], ""
+	if g := strings.IndexByte(posStr, ';'); g >= 0 {
+		posStr, gtidSet = posStr[:g], posStr[g+1:]
+	}
+
+	pos, err := strconv.ParseUint(posStr, 10, 32)
 	if err != nil {
 		return errors.NotValid.Newf("[ddl] MasterStatus FromString: %s", err)
 	}
 	ms.File = str[:c]
 	ms.Position = uint(pos)
+	ms.ExecutedGTIDSet = gtidSet
 	return nil
 }
+
+// MarshalText implements encoding.TextMarshaler and uses the format of
+// WriteTo.
+func (ms MasterStatus) MarshalText() ([]byte, error) {
+	var buf strings.Builder
+	ms.WriteTo(&buf)
+	return []byte(buf.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and uses the format of
+// FromString. Empty data resets the MasterStatus.
+func (ms *MasterStatus) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*ms = MasterStatus{}
+		return nil
+	}
+	return ms.FromString(string(data))
+}
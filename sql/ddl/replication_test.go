@@ -81,6 +81,8 @@ func TestMasterStatus_FromString(t *testing.T) {
 		wantString   string
 	}{
 		{"mysql-bin.000004;545460", "mysql-bin.000004", 545460, errors.NoKind, "mysql-bin.000004;545460"},
+		{"mysql-bin.000004;545460;3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5", "mysql-bin.000004", 545460, errors.NoKind, "mysql-bin.000004;545460;3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5"},
+		{"mysql-bin.000004;x;3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5", "", 0, errors.NotValid, ""},
 		{"mysql-bin.000004;", "", 0, errors.NotValid, ""},
 		{"mysql-bin.000004", "", 0, errors.NotFound, ""},
 	}
@@ -114,3 +116,23 @@ func TestMasterStatus_WriteTo(t *testing.T) {
 
 	assert.Exactly(t, "mysql-bin.000004;545460", buf.String())
 }
+
+func TestMasterStatus_MarshalText(t *testing.T) {
+	t.Parallel()
+
+	ms := ddl.MasterStatus{
+		File:            "mysql-bin.000004",
+		Position:        545460,
+		ExecutedGTIDSet: "3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5",
+	}
+	data, err := ms.MarshalText()
+	assert.NoError(t, err)
+	assert.Exactly(t, "mysql-bin.000004;545460;3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5", string(data))
+
+	var have ddl.MasterStatus
+	assert.NoError(t, have.UnmarshalText(data))
+	assert.Exactly(t, ms, have)
+
+	assert.NoError(t, have.UnmarshalText(nil))
+	assert.Exactly(t, ddl.MasterStatus{}, have)
+}
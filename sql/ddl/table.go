@@ -42,7 +42,19 @@ type Table struct {
 	// DML statement (SELECT, INSERT, UPDATE or DELETE).
 	Listeners dml.ListenerBucket
 	// IsView set to true to mark if the table is a view.
-	IsView       bool
+	IsView bool
+	// Indexes contains the primary key, unique and secondary indexes. Only
+	// set when loaded via WithLoadKeys or set manually.
+	Indexes Indexes
+	// ForeignKeys contains the foreign key constraints. Only set when loaded
+	// via WithLoadKeys or set manually.
+	ForeignKeys ForeignKeys
+	// Status contains the engine, row format, charset and collation. Only set
+	// when loaded via WithLoadTableStatus or set manually.
+	Status TableStatus
+	// Partitions contains the partitions of a partitioned table. Only set when
+	// loaded via WithLoadPartitions or set manually.
+	Partitions   Partitions
 	columnsPK    []string
 	columnsNonPK []string
 	columnsAll   []string
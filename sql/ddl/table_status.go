@@ -0,0 +1,117 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+)
+
+// TableStatus contains the table options of a base table, as returned by SHOW
+// TABLE STATUS. Empty fields are unknown and get ignored in comparisons.
+type TableStatus struct {
+	Engine    string // e.g. InnoDB
+	RowFormat string // e.g. Dynamic or Compressed
+	Charset   string // e.g. utf8mb4
+	Collation string // e.g. utf8mb4_unicode_ci
+}
+
+// IsZero returns true if no field has been set.
+func (ts TableStatus) IsZero() bool {
+	return ts == TableStatus{}
+}
+
+// diff reports which options are set in `desired` but differ from ts. Charset
+// and collation get reported together. Case insensitive.
+func (ts TableStatus) diff(desired TableStatus) (engine, rowFormat, charset bool) {
+	neq := func(have, want string) bool {
+		return want != "" && !strings.EqualFold(have, want)
+	}
+	return neq(ts.Engine, desired.Engine),
+		neq(ts.RowFormat, desired.RowFormat),
+		neq(ts.Charset, desired.Charset) || neq(ts.Collation, desired.Collation)
+}
+
+// LoadTableStatus loads the engine, row format, character set and collation of
+// the base tables in the current database from information_schema.TABLES. Map
+// key contains the table name. All base tables get selected when you don't
+// provide the argument `tables`. Views are not included.
+func LoadTableStatus(ctx context.Context, db dml.Querier, tables ...string) (map[string]TableStatus, error) {
+	const selStatus = `SELECT t.TABLE_NAME, t.ENGINE, t.ROW_FORMAT, c.CHARACTER_SET_NAME, t.TABLE_COLLATION
+	 FROM information_schema.TABLES t
+	 LEFT JOIN information_schema.COLLATION_CHARACTER_SET_APPLICABILITY c ON c.COLLATION_NAME = t.TABLE_COLLATION
+	 WHERE t.TABLE_SCHEMA=DATABASE() AND t.TABLE_TYPE='BASE TABLE'`
+	const selStatusWhere = ` AND t.TABLE_NAME IN ?`
+	const selStatusOrderBy = ` ORDER BY t.TABLE_NAME`
+
+	rows, err := queryTables(ctx, db, selStatus, selStatusWhere, selStatusOrderBy, tables)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadTableStatus for tables %v", tables)
+	}
+	defer rows.Close()
+
+	tss := make(map[string]TableStatus)
+	for rows.Next() {
+		var tableName string
+		var engine, rowFormat, charset, collation sql.NullString
+		if err := rows.Scan(&tableName, &engine, &rowFormat, &charset, &collation); err != nil {
+			return nil, errors.Wrapf(err, "[ddl] LoadTableStatus Scan for tables %v", tables)
+		}
+		tss[tableName] = TableStatus{
+			Engine:    engine.String,
+			RowFormat: rowFormat.String,
+			Charset:   charset.String,
+			Collation: collation.String,
+		}
+	}
+	return tss, errors.WithStack(rows.Err())
+}
+
+// WithLoadTableStatus loads the engine, row format, character set and
+// collation of all tables, which have been added beforehand, into the field
+// Status.
+func WithLoadTableStatus(ctx context.Context, db dml.Querier) TableOption {
+	return TableOption{
+		sortOrder: 21,
+		fn: func(tm *Tables) error {
+			tm.mu.Lock()
+			defer tm.mu.Unlock()
+
+			names := make([]string, 0, len(tm.tm))
+			for n, t := range tm.tm {
+				if !t.IsView {
+					names = append(names, n)
+				}
+			}
+			if len(names) == 0 {
+				return nil
+			}
+			tss, err := LoadTableStatus(ctx, db, names...)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			for n, t := range tm.tm {
+				if ts, ok := tss[n]; ok {
+					t.Status = ts
+				}
+			}
+			return nil
+		},
+	}
+}
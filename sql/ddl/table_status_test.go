@@ -0,0 +1,84 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"context"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestLoadTableStatus(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT t.TABLE_NAME, t.ENGINE, t.ROW_FORMAT, c.CHARACTER_SET_NAME, t.TABLE_COLLATION FROM information_schema.TABLES t LEFT JOIN information_schema.COLLATION_CHARACTER_SET_APPLICABILITY c ON c.COLLATION_NAME = t.TABLE_COLLATION WHERE t.TABLE_SCHEMA=DATABASE() AND t.TABLE_TYPE='BASE TABLE' AND t.TABLE_NAME IN ('customer_entity','store') ORDER BY t.TABLE_NAME")).
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "ENGINE", "ROW_FORMAT", "CHARACTER_SET_NAME", "TABLE_COLLATION"}).
+			AddRow("customer_entity", "InnoDB", "Dynamic", "utf8", "utf8_general_ci").
+			AddRow("store", "InnoDB", nil, "utf8mb4", "utf8mb4_unicode_ci"))
+
+	tss, err := ddl.LoadTableStatus(context.TODO(), dbc.DB, "customer_entity", "store")
+	assert.NoError(t, err)
+	assert.Exactly(t, map[string]ddl.TableStatus{
+		"customer_entity": {Engine: "InnoDB", RowFormat: "Dynamic", Charset: "utf8", Collation: "utf8_general_ci"},
+		"store":           {Engine: "InnoDB", Charset: "utf8mb4", Collation: "utf8mb4_unicode_ci"},
+	}, tss)
+}
+
+func TestDiff_TableStatus(t *testing.T) {
+	t.Parallel()
+
+	newTables := func(ts ddl.TableStatus) *ddl.Tables {
+		tbls := ddl.MustNewTables(
+			ddl.WithTable("customer_entity",
+				&ddl.Column{Field: "entity_id", ColumnType: "int(10) unsigned", Null: "NO", Key: "PRI", Extra: "auto_increment"},
+			),
+		)
+		tbls.MustTable("customer_entity").Status = ts
+		return tbls
+	}
+	current := newTables(ddl.TableStatus{Engine: "InnoDB", RowFormat: "Compact", Charset: "utf8", Collation: "utf8_general_ci"})
+
+	t.Run("utf8 to utf8mb4", func(t *testing.T) {
+		stmts, err := ddl.Diff(current, newTables(ddl.TableStatus{Collation: "utf8mb4_unicode_ci", RowFormat: "dynamic"}))
+		assert.NoError(t, err)
+		assert.Exactly(t, []string{
+			"ALTER TABLE `customer_entity` CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci, ROW_FORMAT=DYNAMIC",
+		}, stmts)
+	})
+	t.Run("engine", func(t *testing.T) {
+		stmts, err := ddl.Diff(current, newTables(ddl.TableStatus{Engine: "Aria", Charset: "UTF8"}))
+		assert.NoError(t, err)
+		assert.Exactly(t, []string{"ALTER TABLE `customer_entity` ENGINE=Aria"}, stmts)
+	})
+	t.Run("unknown status ignored", func(t *testing.T) {
+		stmts, err := ddl.Diff(current, newTables(ddl.TableStatus{}))
+		assert.NoError(t, err)
+		assert.Nil(t, stmts)
+	})
+	t.Run("create table", func(t *testing.T) {
+		stmts, err := ddl.Diff(ddl.MustNewTables(), current)
+		assert.NoError(t, err)
+		assert.Exactly(t, []string{
+			"CREATE TABLE `customer_entity` (\n  `entity_id` int(10) unsigned NOT NULL AUTO_INCREMENT,\n  PRIMARY KEY (`entity_id`)\n) ENGINE=InnoDB ROW_FORMAT=COMPACT DEFAULT CHARSET=utf8 COLLATE=utf8_general_ci",
+		}, stmts)
+	})
+}
@@ -435,6 +435,133 @@ func (tm *Tables) DeleteAllFromCache() {
 	tm.tm = make(map[string]*Table)
 }
 
+// TruncateAll truncates all tables, views get skipped. The foreign key checks
+// get disabled and at the end re-enabled because InnoDB cannot truncate a table
+// which is referenced by a foreign key. Argument `db` should be a single
+// connection, like *sql.Conn, so that the session variable applies to all
+// statements. Useful for resetting test databases.
+func (tm *Tables) TruncateAll(ctx context.Context, db dml.Execer) (err error) {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	if _, err = db.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS=0"); err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if _, err2 := db.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS=1"); err == nil && err2 != nil {
+			err = errors.WithStack(err2)
+		}
+	}()
+
+	names, _ := tm.dependencyOrder()
+	for _, name := range names {
+		if err = tm.tm[name].Truncate(ctx, db); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// DropAll drops all views and tables. Views get dropped first, then the tables
+// in the order of their foreign keys, which must have been loaded with
+// WithLoadKeys, so that a referencing table gets dropped before its referenced
+// table. Only with circular foreign keys the foreign key checks get disabled
+// and at the end re-enabled. Argument `db` should then be a single connection,
+// like *sql.Conn. The tables stay in the cache.
+func (tm *Tables) DropAll(ctx context.Context, db dml.Execer) (err error) {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	names, cyclic := tm.dependencyOrder()
+	if cyclic {
+		if _, err = db.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS=0"); err != nil {
+			return errors.WithStack(err)
+		}
+		defer func() {
+			if _, err2 := db.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS=1"); err == nil && err2 != nil {
+				err = errors.WithStack(err2)
+			}
+		}()
+	}
+
+	for _, isView := range [...]bool{true, false} {
+		for _, name := range names {
+			if t := tm.tm[name]; t.IsView == isView {
+				if err = t.Drop(ctx, db); err != nil {
+					return errors.WithStack(err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// dependencyOrder returns the table names sorted by their foreign keys, a
+// referencing table comes before its referenced table. Tables without
+// dependencies get sorted by name. If the foreign keys are circular, cyclic is
+// true and the remaining tables get appended in name order.
+func (tm *Tables) dependencyOrder() (names []string, cyclic bool) {
+	sorted := make([]string, 0, len(tm.tm))
+	for n := range tm.tm {
+		sorted = append(sorted, n)
+	}
+	sort.Strings(sorted)
+
+	// referencedBy counts for each table the number of tables referencing it.
+	referencedBy := make(map[string]int, len(tm.tm))
+	for _, n := range sorted {
+		for _, ref := range tm.referencedTables(n) {
+			referencedBy[ref]++
+		}
+	}
+
+	names = make([]string, 0, len(sorted))
+	done := make(map[string]bool, len(sorted))
+	for len(names) < len(sorted) {
+		progress := false
+		for _, n := range sorted {
+			if done[n] || referencedBy[n] > 0 {
+				continue
+			}
+			done[n] = true
+			progress = true
+			names = append(names, n)
+			for _, ref := range tm.referencedTables(n) {
+				referencedBy[ref]--
+			}
+		}
+		if !progress {
+			for _, n := range sorted {
+				if !done[n] {
+					names = append(names, n)
+				}
+			}
+			return names, true
+		}
+	}
+	return names, false
+}
+
+// referencedTables returns the unique names of the cached tables, which are
+// referenced by the foreign keys of table n. Self references get ignored.
+func (tm *Tables) referencedTables(n string) []string {
+	var refs []string
+	for _, fk := range tm.tm[n].ForeignKeys {
+		ref := fk.ReferencedTable
+		if _, ok := tm.tm[ref]; !ok || ref == n {
+			continue
+		}
+		found := false
+		for _, r := range refs {
+			found = found || r == ref
+		}
+		if !found {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
 // Validate validates the table names and their column against the current
 // database schema. The context is used to maybe cancel the "Load Columns"
 // query.
@@ -491,5 +618,32 @@ func (tm *Tables) Validate(ctx context.Context) error {
 		}
 	}
 
+	return errors.WithStack(tm.validateStatus(ctx, tblNames))
+}
+
+// validateStatus compares the engine, row format, charset and collation of the
+// tables with the database. Only when at least one table has a Status set, the
+// status gets loaded.
+func (tm *Tables) validateStatus(ctx context.Context, tblNames []string) error {
+	hasStatus := false
+	for _, tbl := range tm.tm {
+		hasStatus = hasStatus || !tbl.Status.IsZero()
+	}
+	if !hasStatus {
+		return nil
+	}
+	tss, err := LoadTableStatus(ctx, tm.DB, tblNames...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for tn, tbl := range tm.tm {
+		if tbl.Status.IsZero() {
+			continue
+		}
+		have := tss[tn]
+		if engine, rowFormat, charset := have.diff(tbl.Status); engine || rowFormat || charset {
+			return errors.Mismatch.Newf("[ddl] Table %q status does not match. MySQL: %#v Go: %#v", tn, have, tbl.Status)
+		}
+	}
 	return nil
 }
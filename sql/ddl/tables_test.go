@@ -545,3 +545,79 @@ func TestTables_Validate(t *testing.T) {
 	})
 
 }
+
+func TestTables_TruncateAll(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	tbls := ddl.MustNewTables(
+		ddl.WithTable("store"),
+		ddl.WithTable("customer_entity"),
+		ddl.WithTable("view_customer"),
+	)
+	tbls.MustTable("view_customer").IsView = true
+
+	dbMock.ExpectExec("SET FOREIGN_KEY_CHECKS=0").WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec("TRUNCATE TABLE `customer_entity`").WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec("TRUNCATE TABLE `store`").WillReturnError(errors.AlreadyClosed.Newf("Connection closed"))
+	dbMock.ExpectExec("SET FOREIGN_KEY_CHECKS=1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := tbls.TruncateAll(context.TODO(), dbc.DB)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Connection closed")
+}
+
+func TestTables_DropAll(t *testing.T) {
+	t.Parallel()
+
+	newTables := func() *ddl.Tables {
+		tbls := ddl.MustNewTables(
+			ddl.WithTable("customer_entity"),
+			ddl.WithTable("sales_order"),
+			ddl.WithTable("store"),
+			ddl.WithTable("view_sales_order"),
+		)
+		tbls.MustTable("view_sales_order").IsView = true
+		tbls.MustTable("sales_order").ForeignKeys = ddl.ForeignKeys{
+			{Name: "SALES_ORDER_CUSTOMER_ID", Columns: []string{"customer_id"}, ReferencedTable: "customer_entity", ReferencedColumns: []string{"entity_id"}},
+			{Name: "SALES_ORDER_STORE_ID", Columns: []string{"store_id"}, ReferencedTable: "store", ReferencedColumns: []string{"store_id"}},
+		}
+		tbls.MustTable("customer_entity").ForeignKeys = ddl.ForeignKeys{
+			{Name: "CUSTOMER_ENTITY_STORE_ID", Columns: []string{"store_id"}, ReferencedTable: "store", ReferencedColumns: []string{"store_id"}},
+		}
+		return tbls
+	}
+
+	t.Run("ordered by foreign keys", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectExec("DROP VIEW IF EXISTS `view_sales_order`").WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec("DROP TABLE IF EXISTS `sales_order`").WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec("DROP TABLE IF EXISTS `customer_entity`").WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec("DROP TABLE IF EXISTS `store`").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.NoError(t, newTables().DropAll(context.TODO(), dbc.DB))
+	})
+
+	t.Run("circular foreign keys", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		tbls := newTables()
+		tbls.MustTable("store").ForeignKeys = ddl.ForeignKeys{
+			{Name: "STORE_DEFAULT_CUSTOMER_ID", Columns: []string{"customer_id"}, ReferencedTable: "customer_entity", ReferencedColumns: []string{"entity_id"}},
+		}
+
+		dbMock.ExpectExec("SET FOREIGN_KEY_CHECKS=0").WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec("DROP VIEW IF EXISTS `view_sales_order`").WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec("DROP TABLE IF EXISTS `sales_order`").WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec("DROP TABLE IF EXISTS `customer_entity`").WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec("DROP TABLE IF EXISTS `store`").WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec("SET FOREIGN_KEY_CHECKS=1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.NoError(t, tbls.DropAll(context.TODO(), dbc.DB))
+	})
+}
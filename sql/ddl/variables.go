@@ -15,8 +15,12 @@
 package ddl
 
 import (
+	"context"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/pkg/sql/dml"
@@ -96,6 +100,47 @@ func (vs *Variables) Bool(key string) (val bool, ok bool) {
 	return
 }
 
+// Duration returns for a given key its duration value. The value must be in
+// seconds and can have a fraction, like long_query_time or wait_timeout. If the
+// key does not exists or string parsing fails, it returns false.
+func (vs *Variables) Duration(key string) (val time.Duration, ok bool) {
+	sec, ok := vs.Float64(key)
+	if !ok || sec < 0 {
+		return val, false
+	}
+	return time.Duration(sec * float64(time.Second)), true
+}
+
+// Size returns for a given key its size in bytes, like max_allowed_packet or
+// innodb_buffer_pool_size. The value can have the suffix K, M, G or T as used
+// in the configuration file. If the key does not exists or string parsing
+// fails, it returns false.
+func (vs *Variables) Size(key string) (val uint64, ok bool) {
+	vals, ok := vs.Data[key]
+	if !ok || vals == "" {
+		return val, false
+	}
+	var mul uint64 = 1
+	switch vals[len(vals)-1] {
+	case 'K', 'k':
+		mul = 1 << 10
+	case 'M', 'm':
+		mul = 1 << 20
+	case 'G', 'g':
+		mul = 1 << 30
+	case 'T', 't':
+		mul = 1 << 40
+	}
+	if mul > 1 {
+		vals = vals[:len(vals)-1]
+	}
+	val, err := strconv.ParseUint(vals, 10, 64)
+	if err != nil || val > (1<<64-1)/mul {
+		return 0, false
+	}
+	return val * mul, true
+}
+
 // String returns for a given key its string value. If the key does not exists,
 // it returns false.
 func (vs *Variables) String(key string) (val string, ok bool) {
@@ -156,3 +201,69 @@ func (vs *Variables) MapColumns(rc *dml.ColumnMap) error {
 	vs.Data[name] = value
 	return errors.WithStack(rc.Err())
 }
+
+// VariablesWatcher reloads periodically the selected variables, useful for
+// adapting to changed server settings, like max_allowed_packet. Thread safe.
+type VariablesWatcher struct {
+	db      *dml.ConnPool
+	names   []string
+	current atomic.Value // *Variables
+	mu      sync.Mutex
+	err     error
+}
+
+// WatchVariables loads the variables matching the names and reloads them in
+// the interval until the context gets canceled. The first load happens
+// immediately and its error gets returned. When a reload fails, the previously
+// loaded variables stay active and the error can be retrieved with Err. The
+// interval must be greater than zero.
+func WatchVariables(ctx context.Context, db *dml.ConnPool, interval time.Duration, names ...string) (*VariablesWatcher, error) {
+	if interval <= 0 {
+		return nil, errors.NotValid.Newf("[ddl] WatchVariables interval must be greater than zero, got %s", interval)
+	}
+	w := &VariablesWatcher{
+		db:    db,
+		names: names,
+	}
+	if err := w.reload(ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				err := w.reload(ctx)
+				w.mu.Lock()
+				w.err = err
+				w.mu.Unlock()
+			}
+		}
+	}()
+	return w, nil
+}
+
+func (w *VariablesWatcher) reload(ctx context.Context) error {
+	vs := NewVariables(w.names...)
+	if _, err := w.db.WithQueryBuilder(vs).Load(ctx, vs); err != nil {
+		return errors.Wrapf(err, "[ddl] VariablesWatcher failed to load variables %v", w.names)
+	}
+	w.current.Store(vs)
+	return nil
+}
+
+// Variables returns the latest loaded variables. The returned value must not
+// be modified.
+func (w *VariablesWatcher) Variables() *Variables {
+	return w.current.Load().(*Variables)
+}
+
+// Err returns the error of the last reload or nil.
+func (w *VariablesWatcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
@@ -20,6 +20,7 @@ import (
 	"sort"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/corestoreio/errors"
@@ -135,6 +136,12 @@ func TestVariables_Types(t *testing.T) {
 	v.Data["bool_OFF"] = "OFF"
 	v.Data["bool_nok1"] = "1"
 	v.Data["bool_nok0"] = "0"
+	v.Data["duration_ok"] = "10.500000"
+	v.Data["duration_nok"] = "-1"
+	v.Data["size_ok"] = "67108864"
+	v.Data["size_ok_m"] = "64M"
+	v.Data["size_nok"] = "64X"
+	v.Data["size_overflow"] = "18014398509481984T"
 
 	t.Run("string", func(t *testing.T) {
 		val, ok := v.String("float64_ok")
@@ -218,4 +225,85 @@ func TestVariables_Types(t *testing.T) {
 		assert.Exactly(t, uint64(0), val)
 	})
 
+	t.Run("duration", func(t *testing.T) {
+		val, ok := v.Duration("duration_ok")
+		assert.True(t, ok)
+		assert.Exactly(t, 10*time.Second+500*time.Millisecond, val)
+
+		val, ok = v.Duration("duration_nok")
+		assert.False(t, ok)
+		assert.Exactly(t, time.Duration(0), val)
+
+		val, ok = v.Duration("not_found")
+		assert.False(t, ok)
+		assert.Exactly(t, time.Duration(0), val)
+	})
+
+	t.Run("size", func(t *testing.T) {
+		val, ok := v.Size("size_ok")
+		assert.True(t, ok)
+		assert.Exactly(t, uint64(64<<20), val)
+
+		val, ok = v.Size("size_ok_m")
+		assert.True(t, ok)
+		assert.Exactly(t, uint64(64<<20), val)
+
+		val, ok = v.Size("size_nok")
+		assert.False(t, ok)
+		assert.Exactly(t, uint64(0), val)
+
+		val, ok = v.Size("size_overflow")
+		assert.False(t, ok)
+		assert.Exactly(t, uint64(0), val)
+
+		val, ok = v.Size("not_found")
+		assert.False(t, ok)
+		assert.Exactly(t, uint64(0), val)
+	})
+}
+
+func TestWatchVariables(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	const query = "SHOW VARIABLES WHERE (`Variable_name` IN ('max_allowed_packet','wait_timeout'))"
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(query)).
+		WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
+			FromCSVString("max_allowed_packet,16777216\nwait_timeout,28800"))
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(query)).
+		WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
+			FromCSVString("max_allowed_packet,67108864\nwait_timeout,28800"))
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(query)).
+		WillReturnError(errors.ConnectionFailed.Newf("Ups"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The interval is long enough to not trigger, reload gets called manually.
+	w, err := WatchVariables(ctx, dbc, time.Hour, "max_allowed_packet", "wait_timeout")
+	assert.NoError(t, err)
+	size, _ := w.Variables().Size("max_allowed_packet")
+	assert.Exactly(t, uint64(16<<20), size)
+	wt, _ := w.Variables().Duration("wait_timeout")
+	assert.Exactly(t, 8*time.Hour, wt)
+
+	assert.NoError(t, w.reload(ctx))
+	size, _ = w.Variables().Size("max_allowed_packet")
+	assert.Exactly(t, uint64(64<<20), size)
+
+	err = w.reload(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Ups")
+	size, _ = w.Variables().Size("max_allowed_packet")
+	assert.Exactly(t, uint64(64<<20), size, "previous variables must stay active")
+}
+
+func TestWatchVariables_InvalidInterval(t *testing.T) {
+	t.Parallel()
+
+	w, err := WatchVariables(context.Background(), nil, 0, "max_allowed_packet")
+	assert.Nil(t, w)
+	assert.True(t, errors.NotValid.Match(err), "%+v", err)
 }
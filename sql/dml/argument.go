@@ -74,7 +74,7 @@ func (arg *argument) set(v interface{}) {
 
 func (arg *argument) len() (l int) {
 	switch v := arg.value.(type) {
-	case nil, int, int64, uint64, float64, bool, string, []byte, time.Time, null.String, null.Int64, null.Float64, null.Bool, null.Time:
+	case nil, int, int64, uint64, float64, bool, string, []byte, time.Time, null.String, null.Int64, null.Uint64, null.Float64, null.Bool, null.Time, null.Decimal, Point:
 		l = 1
 	case []int:
 		l = len(v)
@@ -98,6 +98,12 @@ func (arg *argument) len() (l int) {
 		l = len(v)
 	case []null.Int64:
 		l = len(v)
+	case []null.Uint64:
+		l = len(v)
+	case []null.Decimal:
+		l = len(v)
+	case []Point:
+		l = len(v)
 	case []null.Float64:
 		l = len(v)
 	case []null.Bool:
@@ -169,6 +175,21 @@ func (arg argument) writeTo(w *bytes.Buffer, pos uint) (err error) {
 		}
 	case uint64:
 		err = writeUint64(w, v)
+	case null.Uint64:
+		err = v.WriteTo(dialect, w)
+	case []null.Uint64:
+		if requestPos {
+			err = v[pos].WriteTo(dialect, w)
+		} else {
+			w.WriteByte('(')
+			for l, i := len(v), 0; i < l && err == nil; i++ {
+				if i > 0 {
+					w.WriteByte(',')
+				}
+				err = v[i].WriteTo(dialect, w)
+			}
+			w.WriteByte(')')
+		}
 	case uint:
 		err = writeUint64(w, uint64(v))
 	case uint8:
@@ -233,6 +254,36 @@ func (arg argument) writeTo(w *bytes.Buffer, pos uint) (err error) {
 			}
 			w.WriteByte(')')
 		}
+	case null.Decimal:
+		err = v.WriteTo(dialect, w)
+	case []null.Decimal:
+		if requestPos {
+			err = v[pos].WriteTo(dialect, w)
+		} else {
+			w.WriteByte('(')
+			for l, i := len(v), 0; i < l && err == nil; i++ {
+				if i > 0 {
+					w.WriteByte(',')
+				}
+				err = v[i].WriteTo(dialect, w)
+			}
+			w.WriteByte(')')
+		}
+	case Point:
+		err = v.writeTo(w)
+	case []Point:
+		if requestPos {
+			err = v[pos].writeTo(w)
+		} else {
+			w.WriteByte('(')
+			for l, i := len(v), 0; i < l && err == nil; i++ {
+				if i > 0 {
+					w.WriteByte(',')
+				}
+				err = v[i].writeTo(w)
+			}
+			w.WriteByte(')')
+		}
 	case bool:
 		dialect.EscapeBool(w, v)
 	case []bool:
@@ -392,6 +443,19 @@ func (arg argument) GoString() string {
 		fmt.Fprintf(buf, ".Uint64(%d)", v)
 	case []uint64:
 		fmt.Fprintf(buf, ".Uint64s(%#v...)", v)
+	case null.Uint64:
+		buf.WriteString(".NullUint64(")
+		buf.WriteString(v.GoString())
+		buf.WriteByte(')')
+	case []null.Uint64:
+		buf.WriteString(".NullUint64s(")
+		for i, nv := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(nv.GoString())
+		}
+		buf.WriteByte(')')
 	case []uint:
 		fmt.Fprintf(buf, ".Uints(%#v...)", v)
 
@@ -413,6 +477,25 @@ func (arg argument) GoString() string {
 		}
 		buf.WriteByte(')')
 
+	case null.Decimal:
+		buf.WriteString(".Decimal(")
+		buf.WriteString(v.GoString())
+		buf.WriteByte(')')
+	case []null.Decimal:
+		buf.WriteString(".Decimals(")
+		for i, nv := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(nv.GoString())
+		}
+		buf.WriteByte(')')
+
+	case Point:
+		fmt.Fprintf(buf, ".Point(%#v)", v)
+	case []Point:
+		fmt.Fprintf(buf, ".Points(%#v...)", v)
+
 	case bool:
 		fmt.Fprintf(buf, ".Bool(%v)", v)
 	case []bool:
@@ -661,6 +744,19 @@ func (as arguments) Interfaces(args ...interface{}) []interface{} {
 				args = v.Append(args)
 			}
 
+		case null.Decimal:
+			args = vv.Append(args)
+		case []null.Decimal:
+			for _, v := range vv {
+				args = v.Append(args)
+			}
+		case Point:
+			args = vv.Append(args)
+		case []Point:
+			for _, v := range vv {
+				args = v.Append(args)
+			}
+
 		case []bool:
 			for _, v := range vv {
 				args = append(args, v)
@@ -834,6 +930,10 @@ func iFaceToArgs(args arguments, values ...interface{}) (arguments, error) {
 			args = args.add(int64(v))
 		case int8:
 			args = args.add(int64(v))
+		case uint64:
+			args = args.add(v)
+		case uint:
+			args = args.add(uint64(v))
 		case uint32:
 			args = args.add(int64(v))
 		case uint16:
@@ -852,6 +952,10 @@ func iFaceToArgs(args arguments, values ...interface{}) (arguments, error) {
 			if v != nil {
 				args = args.add(*v)
 			}
+		case null.Decimal:
+			args = args.add(v)
+		case Point:
+			args = args.add(v)
 		case nil:
 			args = args.add(nil)
 		default:
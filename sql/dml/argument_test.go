@@ -120,6 +120,28 @@ func TestIFaceToArgs(t *testing.T) {
 	})
 }
 
+func TestArguments_Decimal(t *testing.T) {
+	t.Parallel()
+	d := null.Decimal{Precision: 1234, Scale: 2, Valid: true}
+
+	t.Run("Interfaces", func(t *testing.T) {
+		args := MakeArgs(3).Decimal(d).Decimals(d, null.Decimal{}).arguments
+		assert.Exactly(t, []interface{}{"12.34", "12.34", nil}, args.Interfaces())
+		assert.Exactly(t, 3, args.Len())
+	})
+	t.Run("interpolate", func(t *testing.T) {
+		compareToSQL(t,
+			NewSelect("sku").From("products").Where(
+				Column("price").Decimal(d),
+				Column("special_price").In().Decimals(d, null.Decimal{Precision: 5, Valid: true}),
+			),
+			errors.NoKind,
+			"SELECT `sku` FROM `products` WHERE (`price` = 12.34) AND (`special_price` IN (12.34,5))",
+			"SELECT `sku` FROM `products` WHERE (`price` = 12.34) AND (`special_price` IN (12.34,5))",
+		)
+	})
+}
+
 func TestArguments_Clone(t *testing.T) {
 	t.Parallel()
 
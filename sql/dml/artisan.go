@@ -26,6 +26,7 @@ import (
 	"github.com/corestoreio/log"
 	"github.com/corestoreio/pkg/storage/null"
 	"github.com/corestoreio/pkg/util/bufferpool"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Artisan prepares the SQL string from a DML type, collects and build a list of
@@ -55,6 +56,22 @@ type Artisan struct {
 	insertColumnCount   uint
 	insertRowCount      uint
 	insertIsBuildValues bool
+	// insertODKColumnPos defines the position in the qualified columns where
+	// the place holders of the ON DUPLICATE KEY part start.
+	insertODKColumnPos int
+	// insertODKColumns contains the generated columns of the ON DUPLICATE KEY
+	// part which get written as `col`=VALUES(`col`). A record qualified with
+	// OnDuplicateKeyQualifier turns them into place holders.
+	insertODKColumns []string
+	// updateVersionColumn if set, enables the optimistic locking check of
+	// Update.WithVersionColumn.
+	updateVersionColumn string
+	// afterExec dispatches the OnAfterExec event of the DML type which created
+	// this Artisan. Nil if there are no listeners.
+	afterExec func() error
+	// cancelTimeout releases the resources of the context created by
+	// withTimeout. Gets called in Reset or before the next query.
+	cancelTimeout context.CancelFunc
 	//LimitValid            bool
 	// isPrepared if true the cachedSQL field in base gets ignored
 	isPrepared bool
@@ -246,7 +263,7 @@ func (a *Artisan) prepareArgs(extArgs ...interface{}) (_ string, _ []interface{}
 		}
 	}
 	if a.Options&argOptionInterpolate != 0 {
-		if err := writeInterpolateBytes(sqlBuf.Second, sqlBuf.First.Bytes(), collectedArgs); err != nil {
+		if err := writeInterpolateBytes(sqlBuf.Second, sqlBuf.First.Bytes(), a.base.timeFmt.convertArgs(collectedArgs)); err != nil {
 			return "", nil, errors.Wrapf(err, "[dml] Interpolation failed: %q", sqlBuf.String())
 		}
 		return sqlBuf.Second.String(), nil, nil
@@ -346,11 +363,22 @@ func (a *Artisan) prepareArgsInsert(extArgs ...interface{}) (string, []interface
 	defer bufferpool.PutTwin(sqlBuf)
 	//defer pooledBufferColumnMapPut(cm, sqlBuf, nil)
 
+	a.useODKRecordValues()
+	valueColumns := a.base.qualifiedColumns
+	var odkColumns []string
+	for _, qRec := range a.recs {
+		if qRec.Qualifier == OnDuplicateKeyQualifier && a.insertODKColumnPos <= len(valueColumns) {
+			valueColumns, odkColumns = valueColumns[:a.insertODKColumnPos], valueColumns[a.insertODKColumnPos:]
+			break
+		}
+	}
+
 	cm := NewColumnMap(16)
-	cm.setColumns(a.base.qualifiedColumns)
+	cm.setColumns(valueColumns)
 	//defer bufferpool.PutTwin(sqlBuf)
 	cm.arguments = append(cm.arguments, a.arguments...)
 	lenInsertCachedSQL := len(a.insertCachedSQL)
+	var odkArgCount int
 	{
 		cachedSQL := a.base.cachedSQL
 		if lenInsertCachedSQL > 0 {
@@ -361,7 +389,11 @@ func (a *Artisan) prepareArgsInsert(extArgs ...interface{}) (string, []interface
 		}
 
 		for _, qRec := range a.recs {
-			if qRec.Qualifier != "" {
+			switch qRec.Qualifier {
+			case "":
+			case OnDuplicateKeyQualifier:
+				continue
+			default:
 				return "", nil, errors.Fatal.Newf("[dml] Qualifier in %T is not supported and not needed.", qRec)
 			}
 
@@ -369,6 +401,20 @@ func (a *Artisan) prepareArgsInsert(extArgs ...interface{}) (string, []interface
 				return "", nil, errors.WithStack(err)
 			}
 		}
+
+		if len(odkColumns) > 0 {
+			lenArgs := len(cm.arguments)
+			cm.setColumns(odkColumns)
+			for _, qRec := range a.recs {
+				if qRec.Qualifier != OnDuplicateKeyQualifier {
+					continue
+				}
+				if err := qRec.Record.MapColumns(cm); err != nil {
+					return "", nil, errors.WithStack(err)
+				}
+			}
+			odkArgCount = len(cm.arguments) - lenArgs
+		}
 	}
 
 	if a.isPrepared {
@@ -377,7 +423,7 @@ func (a *Artisan) prepareArgsInsert(extArgs ...interface{}) (string, []interface
 	}
 
 	extArgs = append(extArgs, a.raw...)
-	totalArgLen := uint(len(cm.arguments) + len(extArgs))
+	totalArgLen := uint(len(cm.arguments) - odkArgCount + len(extArgs))
 
 	if !a.insertIsBuildValues && lenInsertCachedSQL == 0 { // Write placeholder list e.g. "VALUES (?,?),(?,?)"
 		odkPos := bytes.Index(a.base.cachedSQL, onDuplicateKeyPart)
@@ -409,7 +455,7 @@ func (a *Artisan) prepareArgsInsert(extArgs ...interface{}) (string, []interface
 		}
 
 		if a.Options&argOptionInterpolate != 0 {
-			if err := writeInterpolateBytes(sqlBuf.Second, sqlBuf.First.Bytes(), cm.arguments); err != nil {
+			if err := writeInterpolateBytes(sqlBuf.Second, sqlBuf.First.Bytes(), a.base.timeFmt.convertArgs(cm.arguments)); err != nil {
 				return "", nil, errors.Wrapf(err, "[dml] Interpolation failed: %q", sqlBuf.First.String())
 			}
 			return sqlBuf.Second.String(), nil, nil
@@ -419,6 +465,53 @@ func (a *Artisan) prepareArgsInsert(extArgs ...interface{}) (string, []interface
 	return sqlBuf.First.String(), cm.arguments.Interfaces(extArgs...), nil
 }
 
+// useODKRecordValues rewrites the generated `col`=VALUES(`col`) assignments of
+// the ON DUPLICATE KEY part into `col`=? if a record has been qualified with
+// OnDuplicateKeyQualifier. The columns get inserted into the qualified columns
+// at the start of the ON DUPLICATE KEY part, so the qualified record receives
+// them in its ColumnMapper. Runs only once per Artisan.
+func (a *Artisan) useODKRecordValues() {
+	if len(a.insertODKColumns) == 0 || a.insertODKColumnPos > len(a.base.qualifiedColumns) {
+		return
+	}
+	hasODKRecord := false
+	for _, qRec := range a.recs {
+		if qRec.Qualifier == OnDuplicateKeyQualifier {
+			hasODKRecord = true
+			break
+		}
+	}
+	odkPos := bytes.Index(a.base.cachedSQL, onDuplicateKeyPart)
+	if !hasODKRecord || odkPos < 0 {
+		return
+	}
+
+	var values, placeHolder bytes.Buffer
+	odkSQL := a.base.cachedSQL[odkPos:]
+	for _, c := range a.insertODKColumns {
+		values.Reset()
+		Quoter.quote(&values, c)
+		values.WriteByte('=')
+		writeValues(&values, c)
+		placeHolder.Reset()
+		Quoter.quote(&placeHolder, c)
+		placeHolder.WriteByte('=')
+		placeHolder.WriteByte(placeHolderRune)
+		odkSQL = bytes.Replace(odkSQL, values.Bytes(), placeHolder.Bytes(), 1)
+	}
+	cachedSQL := make([]byte, 0, odkPos+len(odkSQL))
+	cachedSQL = append(cachedSQL, a.base.cachedSQL[:odkPos]...)
+	a.base.cachedSQL = append(cachedSQL, odkSQL...)
+
+	qc := make([]string, 0, len(a.base.qualifiedColumns)+len(a.insertODKColumns))
+	qc = append(qc, a.base.qualifiedColumns[:a.insertODKColumnPos]...)
+	qc = append(qc, a.insertODKColumns...)
+	a.base.qualifiedColumns = append(qc, a.base.qualifiedColumns[a.insertODKColumnPos:]...)
+
+	a.insertODKColumns = nil
+	a.insertCachedSQL = a.insertCachedSQL[:0]
+}
+
 // nextUnnamedArg returns an unnamed argument by its position.
 func (a *Artisan) nextUnnamedArg() (argument, bool) {
 	var unnamedCounter int
@@ -511,10 +604,16 @@ func (a *Artisan) NullFloat64(nv null.Float64) *Artisan     { return a.add(nv) }
 func (a *Artisan) NullFloat64s(nv ...null.Float64) *Artisan { return a.add(nv) }
 func (a *Artisan) NullInt64(nv null.Int64) *Artisan         { return a.add(nv) }
 func (a *Artisan) NullInt64s(nv ...null.Int64) *Artisan     { return a.add(nv) }
+func (a *Artisan) NullUint64(nv null.Uint64) *Artisan       { return a.add(nv) }
+func (a *Artisan) NullUint64s(nv ...null.Uint64) *Artisan   { return a.add(nv) }
 func (a *Artisan) NullBool(nv null.Bool) *Artisan           { return a.add(nv) }
 func (a *Artisan) NullBools(nv ...null.Bool) *Artisan       { return a.add(nv) }
 func (a *Artisan) NullTime(nv null.Time) *Artisan           { return a.add(nv) }
 func (a *Artisan) NullTimes(nv ...null.Time) *Artisan       { return a.add(nv) }
+func (a *Artisan) Decimal(nv null.Decimal) *Artisan         { return a.add(nv) }
+func (a *Artisan) Decimals(nv ...null.Decimal) *Artisan     { return a.add(nv) }
+func (a *Artisan) Point(p Point) *Artisan                   { return a.add(p) }
+func (a *Artisan) Points(p ...Point) *Artisan               { return a.add(p) }
 
 // Name sets the name for the following argument. Calling Name two times after
 // each other sets the first call to Name to a NULL value. A call to Name should
@@ -540,9 +639,39 @@ func (a *Artisan) Reset() *Artisan {
 	a.nextUnnamedArgPos = 0
 	a.insertIsBuildValues = false
 	a.insertCachedSQL = a.insertCachedSQL[:0]
+	a.releaseTimeout()
 	return a
 }
 
+// withTimeout wraps the context with a deadline if a timeout has been set via
+// the WithTimeout functions of the DML types. For queries returning rows the
+// context must stay alive until the rows have been read, hence the cancel
+// function gets called in Reset.
+func (a *Artisan) withTimeout(ctx context.Context) context.Context {
+	if a.base.timeout <= 0 {
+		return ctx
+	}
+	a.releaseTimeout()
+	ctx, a.cancelTimeout = context.WithTimeout(ctx, a.base.timeout)
+	return ctx
+}
+
+// dispatchAfterExec dispatches the OnAfterExec event if err is nil. Queries
+// call it after all rows have been read and closed.
+func (a *Artisan) dispatchAfterExec(err error) error {
+	if err != nil || a.afterExec == nil {
+		return err
+	}
+	return errors.WithStack(a.afterExec())
+}
+
+func (a *Artisan) releaseTimeout() {
+	if a.cancelTimeout != nil {
+		a.cancelTimeout()
+		a.cancelTimeout = nil
+	}
+}
+
 // DriverValue adds multiple of the same underlying values to the argument
 // slice. When using different values, the last applied value wins and gets
 // added to the argument slice. For example driver.Values of type `int` will
@@ -669,6 +798,47 @@ func pooledArgumentsPut(a arguments, buf *bufferpool.TwinBuffer) {
 	}
 }
 
+var pooledArtisans = sync.Pool{
+	New: func() interface{} {
+		return MakeArgs(argumentPoolMaxSize)
+	},
+}
+
+// Args returns an Artisan from a pool for collecting arguments in hot paths.
+// The Artisan is bound to the connection pool. Call Artisan.Release when the
+// Artisan is not needed anymore to put it back into the pool.
+func (c *ConnPool) Args() *Artisan {
+	a := pooledArtisans.Get().(*Artisan)
+	a.base.DB = c.DB
+	a.base.Log = c.Log
+	return a
+}
+
+// Release resets the Artisan and puts it back into the pool used by
+// ConnPool.Args. The Artisan must not be used after calling Release. Artisans
+// with a large argument slice get discarded.
+func (a *Artisan) Release() {
+	a.Reset()
+	if cap(a.arguments) > argumentPoolMaxSize {
+		return
+	}
+	args := a.arguments[:cap(a.arguments)]
+	for i := range args {
+		args[i] = argument{} // remove pointers for GC
+	}
+	raw := a.raw[:cap(a.raw)]
+	for i := range raw {
+		raw[i] = nil
+	}
+	*a = Artisan{
+		arguments:       args[:0],
+		raw:             raw[:0],
+		recs:            a.recs,
+		insertCachedSQL: a.insertCachedSQL,
+	}
+	pooledArtisans.Put(a)
+}
+
 // ExecContext executes the statement represented by the Update/Insert object.
 // It returns the raw database/sql Result or an error if there was one.
 // Regarding LastInsertID(): If you insert multiple rows using a single INSERT
@@ -676,7 +846,9 @@ func pooledArgumentsPut(a arguments, buf *bufferpool.TwinBuffer) {
 // inserted row only. The reason for this at to make it possible to reproduce
 // easily the same INSERT statement against some other server. If a record resp.
 // and object implements the interface LastInsertIDAssigner then the
-// LastInsertID gets assigned incrementally to the objects.
+// LastInsertID gets assigned incrementally to the objects. A record
+// implementing LastInsertIDMapper gets called with mode ColumnMapLastInsertID,
+// which allows collections to receive the IDs for all of their entities.
 func (a *Artisan) ExecContext(ctx context.Context, args ...interface{}) (sql.Result, error) {
 	return a.exec(ctx, args...)
 }
@@ -692,7 +864,7 @@ func (a *Artisan) QueryRowContext(ctx context.Context, args ...interface{}) *sql
 	if a.base.Log != nil && a.base.Log.IsDebug() {
 		defer log.WhenDone(a.base.Log).Debug("QueryRowContext", log.String("sql", sqlStr), log.String("source", string(a.base.source)), log.Err(err))
 	}
-	return a.base.DB.QueryRowContext(ctx, sqlStr, args...)
+	return a.base.DB.QueryRowContext(a.withTimeout(ctx), sqlStr, args...)
 }
 
 // IterateSerial iterates in serial order over the result set by loading one row each
@@ -714,6 +886,8 @@ func (a *Artisan) IterateSerial(ctx context.Context, callBack func(*ColumnMap) e
 		if err2 := r.Close(); err2 != nil && err == nil {
 			err = errors.Wrap(err2, "[dml] IterateSerial.QueryContext.Rows.Close")
 		}
+		a.releaseTimeout()
+		err = a.dispatchAfterExec(err)
 	})
 
 	for r.Next() {
@@ -805,6 +979,7 @@ func (a *Artisan) IterateParallel(ctx context.Context, concurrencyLevel int, cal
 	if err2 := iterateParallelForNextLoop(r, rowChan, errChan); err2 != nil {
 		err = err2
 	}
+	a.releaseTimeout()
 	close(rowChan)
 	wg.Wait()
 	close(errChan)
@@ -821,16 +996,24 @@ func (a *Artisan) IterateParallel(ctx context.Context, concurrencyLevel int, cal
 	if multiErr != nil {
 		err = multiErr
 	}
+	err = a.dispatchAfterExec(err)
 	return
 }
 
 // Load loads data from a query into an object. Load can load a single row or
 // muliple-rows. It checks on top if ColumnMapper `s` implements io.Closer, to
 // call the custom close function. This is useful for e.g. unlocking a mutex.
+// If the query returns multiple result sets, the ColumnMapper gets called with
+// mode ColumnMapNextResultSet before the next result set gets scanned. The
+// returned rowCount contains the rows of all result sets. Use type ResultSets
+// to load each result set into its own ColumnMapper.
 func (a *Artisan) Load(ctx context.Context, s ColumnMapper, args ...interface{}) (rowCount uint64, err error) {
 	if a.base.Log != nil && a.base.Log.IsDebug() {
 		defer log.WhenDone(a.base.Log).Debug("Load", log.String("id", a.base.id), log.Err(err), log.ObjectTypeOf("ColumnMapper", s), log.Uint64("row_count", rowCount))
 	}
+	ctx, span := a.base.startSpan(ctx, "Load")
+	defer func() { endSpan(span, err, attribute.Int64("dml.row_count", int64(rowCount))) }()
+	defer func() { a.base.stats.addRows(a.base.source, "returned", int64(rowCount)) }()
 
 	r, err := a.query(ctx, args...)
 	if err != nil {
@@ -839,11 +1022,12 @@ func (a *Artisan) Load(ctx context.Context, s ColumnMapper, args ...interface{})
 	}
 	cm := pooledColumnMapGet()
 	defer pooledBufferColumnMapPut(cm, nil, func() {
-		a.Reset()
 		// Not testable with the sqlmock package :-(
 		if err2 := r.Close(); err2 != nil && err == nil {
 			err = errors.Wrap(err2, "[dml] Artisan.Load.Rows.Close")
 		}
+		err = a.dispatchAfterExec(err)
+		a.Reset() // after closing the rows, Reset cancels the timeout context
 		if rc, ok := s.(ioCloser); ok {
 			if err2 := rc.Close(); err2 != nil && err == nil {
 				err = errors.Wrap(err2, "[dml] Artisan.Load.ColumnMapper.Close")
@@ -851,33 +1035,59 @@ func (a *Artisan) Load(ctx context.Context, s ColumnMapper, args ...interface{})
 		}
 	})
 
-	for r.Next() {
-		if err = cm.Scan(r); err != nil {
+	for {
+		for r.Next() {
+			if err = cm.Scan(r); err != nil {
+				return 0, errors.WithStack(err)
+			}
+			if err = s.MapColumns(cm); err != nil {
+				return 0, errors.Wrapf(err, "[dml] Artisan.Load failed with queryID %q and ColumnMapper %T", a.base.id, s)
+			}
+		}
+		if err = r.Err(); err != nil {
 			return 0, errors.WithStack(err)
 		}
-		if err = s.MapColumns(cm); err != nil {
-			return 0, errors.Wrapf(err, "[dml] Artisan.Load failed with queryID %q and ColumnMapper %T", a.base.id, s)
+		if cm.HasRows {
+			cm.Count++ // because first row is zero but we want the actual row number
+		}
+		rowCount += cm.Count
+		if !r.NextResultSet() {
+			break
+		}
+		// Signal the ColumnMapper the start of the next result set.
+		cm.nextResultSet()
+		cm.isNextResultSet = true
+		err = s.MapColumns(cm)
+		cm.isNextResultSet = false
+		if err != nil {
+			return 0, errors.Wrapf(err, "[dml] Artisan.Load.NextResultSet failed with queryID %q and ColumnMapper %T", a.base.id, s)
 		}
 	}
 	if err = r.Err(); err != nil {
 		return 0, errors.WithStack(err)
 	}
-	if cm.HasRows {
-		cm.Count++ // because first row is zero but we want the actual row number
-	}
-	rowCount = cm.Count
 	return
 }
 
 // LoadNullInt64 executes the query and returns the first row parsed into the
-// current type. `Found` might be false if there are no matching rows.
+// current type. `Found` is false if there are no matching rows. A NULL value
+// returns `found` true and an invalid null type.
 func (a *Artisan) LoadNullInt64(ctx context.Context, args ...interface{}) (nv null.Int64, found bool, err error) {
 	found, err = a.loadPrimitive(ctx, &nv, args...)
 	return
 }
 
+// LoadNullBool executes the query and returns the first row parsed into the
+// current type. `Found` is false if there are no matching rows. A NULL value
+// returns `found` true and an invalid null type.
+func (a *Artisan) LoadNullBool(ctx context.Context, args ...interface{}) (nv null.Bool, found bool, err error) {
+	found, err = a.loadPrimitive(ctx, &nv, args...)
+	return
+}
+
 // LoadNullUint64 executes the query and returns the first row parsed into the
-// current type. `Found` might be false if there are no matching rows.
+// current type. `Found` is false if there are no matching rows. A NULL value
+// returns `found` true and an invalid null type.
 // This function with ptr type uint64 comes in handy when performing
 // a COUNT(*) query. See function `Select.Count`.
 func (a *Artisan) LoadNullUint64(ctx context.Context, args ...interface{}) (nv null.Uint64, found bool, err error) {
@@ -886,28 +1096,32 @@ func (a *Artisan) LoadNullUint64(ctx context.Context, args ...interface{}) (nv n
 }
 
 // LoadNullFloat64 executes the query and returns the first row parsed into the
-// current type. `Found` might be false if there are no matching rows.
+// current type. `Found` is false if there are no matching rows. A NULL value
+// returns `found` true and an invalid null type.
 func (a *Artisan) LoadNullFloat64(ctx context.Context, args ...interface{}) (nv null.Float64, found bool, err error) {
 	found, err = a.loadPrimitive(ctx, &nv, args...)
 	return
 }
 
 // LoadNullString executes the query and returns the first row parsed into the
-// current type. `Found` might be false if there are no matching rows.
+// current type. `Found` is false if there are no matching rows. A NULL value
+// returns `found` true and an invalid null type.
 func (a *Artisan) LoadNullString(ctx context.Context, args ...interface{}) (nv null.String, found bool, err error) {
 	found, err = a.loadPrimitive(ctx, &nv, args...)
 	return
 }
 
 // LoadNullTime executes the query and returns the first row parsed into the
-// current type. `Found` might be false if there are no matching rows.
+// current type. `Found` is false if there are no matching rows. A NULL value
+// returns `found` true and an invalid null type.
 func (a *Artisan) LoadNullTime(ctx context.Context, args ...interface{}) (nv null.Time, found bool, err error) {
 	found, err = a.loadPrimitive(ctx, &nv, args...)
 	return
 }
 
 // LoadDecimal executes the query and returns the first row parsed into the
-// current type. `Found` might be false if there are no matching rows.
+// current type. `Found` is false if there are no matching rows. A NULL value
+// returns `found` true and an invalid null type.
 func (a *Artisan) LoadDecimal(ctx context.Context, args ...interface{}) (nv null.Decimal, found bool, err error) {
 	found, err = a.loadPrimitive(ctx, &nv, args...)
 	return
@@ -925,10 +1139,11 @@ func (a *Artisan) loadPrimitive(ctx context.Context, ptr interface{}, args ...in
 		return
 	}
 	defer func() {
-		a.Reset() // reset the internal slices to avoid adding more and more arguments when a query gets executed
 		if errC := rows.Close(); err == nil && errC != nil {
 			err = errors.WithStack(errC)
 		}
+		err = a.dispatchAfterExec(err)
+		a.Reset() // reset the internal slices to avoid adding more and more arguments when a query gets executed
 	}()
 
 	for rows.Next() && !found {
@@ -959,10 +1174,11 @@ func (a *Artisan) LoadInt64s(ctx context.Context, dest []int64, args ...interfac
 		return
 	}
 	defer func() {
-		a.Reset() // reset the internal slices to avoid adding more and more arguments when a query gets executed
 		if cErr := r.Close(); err == nil && cErr != nil {
 			err = errors.WithStack(cErr)
 		}
+		err = a.dispatchAfterExec(err)
+		a.Reset() // reset the internal slices to avoid adding more and more arguments when a query gets executed
 	}()
 	for r.Next() {
 		var nv null.Int64
@@ -998,10 +1214,11 @@ func (a *Artisan) LoadUint64s(ctx context.Context, dest []uint64, args ...interf
 		return
 	}
 	defer func() {
-		a.Reset() // reset the internal slices to avoid adding more and more arguments when a query gets executed
 		if errC := rows.Close(); errC != nil && err == nil {
 			err = errors.WithStack(errC)
 		}
+		err = a.dispatchAfterExec(err)
+		a.Reset() // reset the internal slices to avoid adding more and more arguments when a query gets executed
 	}()
 
 	for rows.Next() {
@@ -1036,10 +1253,11 @@ func (a *Artisan) LoadFloat64s(ctx context.Context, dest []float64, args ...inte
 		return
 	}
 	defer func() {
-		a.Reset() // reset the internal slices to avoid adding more and more arguments when a query gets executed
 		if errC := rows.Close(); errC != nil && err == nil {
 			err = errors.WithStack(errC)
 		}
+		err = a.dispatchAfterExec(err)
+		a.Reset() // reset the internal slices to avoid adding more and more arguments when a query gets executed
 	}()
 
 	for rows.Next() {
@@ -1074,10 +1292,11 @@ func (a *Artisan) LoadStrings(ctx context.Context, dest []string, args ...interf
 		return
 	}
 	defer func() {
-		a.Reset() // reset the internal slices to avoid adding more and more arguments when a query gets executed
 		if errC := rows.Close(); errC != nil && err == nil {
 			err = errors.WithStack(errC)
 		}
+		err = a.dispatchAfterExec(err)
+		a.Reset() // reset the internal slices to avoid adding more and more arguments when a query gets executed
 	}()
 
 	for rows.Next() {
@@ -1098,7 +1317,97 @@ func (a *Artisan) LoadStrings(ctx context.Context, dest []string, args ...interf
 	return dest, err
 }
 
+// LoadInt64Map executes the query and returns a map where the first column of
+// the result set contains the key and the second column the value. Rows with
+// a NULL key or value get skipped. Later rows overwrite earlier rows with the
+// same key.
+func (a *Artisan) LoadInt64Map(ctx context.Context, args ...interface{}) (_ map[int64]int64, err error) {
+	var rowCount int
+	if a.base.Log != nil && a.base.Log.IsDebug() {
+		// do not use fullSQL because we might log sensitive data
+		defer log.WhenDone(a.base.Log).Debug("LoadInt64Map", log.Int("row_count", rowCount), log.String("id", a.base.id), log.Err(err))
+	}
+
+	rows, err := a.query(ctx, args...)
+	if err != nil {
+		err = errors.WithStack(err)
+		return
+	}
+	defer func() {
+		if errC := rows.Close(); errC != nil && err == nil {
+			err = errors.WithStack(errC)
+		}
+		err = a.dispatchAfterExec(err)
+		a.Reset() // reset the internal slices to avoid adding more and more arguments when a query gets executed
+	}()
+
+	dest := make(map[int64]int64)
+	for rows.Next() {
+		var key, value null.Int64
+		if err = rows.Scan(&key, &value); err != nil {
+			err = errors.WithStack(err)
+			return
+		}
+		if key.Valid && value.Valid {
+			dest[key.Int64] = value.Int64
+		}
+	}
+	if err = rows.Err(); err != nil {
+		err = errors.WithStack(err)
+		return
+	}
+	rowCount = len(dest)
+	return dest, err
+}
+
+// LoadStringMap executes the query and returns a map where the first column of
+// the result set contains the key and the second column the value. Rows with
+// a NULL key or value get skipped. Later rows overwrite earlier rows with the
+// same key.
+func (a *Artisan) LoadStringMap(ctx context.Context, args ...interface{}) (_ map[string]string, err error) {
+	var rowCount int
+	if a.base.Log != nil && a.base.Log.IsDebug() {
+		// do not use fullSQL because we might log sensitive data
+		defer log.WhenDone(a.base.Log).Debug("LoadStringMap", log.Int("row_count", rowCount), log.String("id", a.base.id), log.Err(err))
+	}
+
+	rows, err := a.query(ctx, args...)
+	if err != nil {
+		err = errors.WithStack(err)
+		return
+	}
+	defer func() {
+		if errC := rows.Close(); errC != nil && err == nil {
+			err = errors.WithStack(errC)
+		}
+		err = a.dispatchAfterExec(err)
+		a.Reset() // reset the internal slices to avoid adding more and more arguments when a query gets executed
+	}()
+
+	dest := make(map[string]string)
+	for rows.Next() {
+		var key, value null.String
+		if err = rows.Scan(&key, &value); err != nil {
+			err = errors.WithStack(err)
+			return
+		}
+		if key.Valid && value.Valid {
+			dest[key.String] = value.String
+		}
+	}
+	if err = rows.Err(); err != nil {
+		err = errors.WithStack(err)
+		return
+	}
+	rowCount = len(dest)
+	return dest, err
+}
+
 func (a *Artisan) query(ctx context.Context, args ...interface{}) (rows *sql.Rows, err error) {
+	ctx, span := a.base.startSpan(ctx, "Query")
+	defer func() { endSpan(span, err) }()
+	defer func(start time.Time) { a.base.stats.observe(a.base.source, "query", start, err) }(now())
+
 	sqlStr, args, err2 := a.prepareArgs(args...)
 	err = err2
 	if a.base.Log != nil && a.base.Log.IsDebug() {
@@ -1107,18 +1416,25 @@ func (a *Artisan) query(ctx context.Context, args ...interface{}) (rows *sql.Row
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	sqlStr = a.base.appendComment(ctx, sqlStr)
 
-	rows, err = a.base.DB.QueryContext(ctx, sqlStr, args...)
+	rows, err = a.base.DB.QueryContext(a.withTimeout(ctx), sqlStr, args...)
 	if err != nil {
 		if sqlStr == "" {
 			sqlStr = "PREPARED:" + string(a.base.cachedSQL)
 		}
+		a.releaseTimeout()
 		err = errors.Wrapf(err, "[dml] Query.QueryContext with query %q", sqlStr)
+		return
 	}
 	return
 }
 
 func (a *Artisan) exec(ctx context.Context, args ...interface{}) (result sql.Result, err error) {
+	ctx, span := a.base.startSpan(ctx, "Exec")
+	defer func() { endSpan(span, err) }()
+	defer func(start time.Time) { a.base.stats.observe(a.base.source, "exec", start, err) }(now())
+
 	sqlStr, args, err2 := a.prepareArgs(args...)
 	err = err2
 	if a.base.Log != nil && a.base.Log.IsDebug() {
@@ -1127,12 +1443,41 @@ func (a *Artisan) exec(ctx context.Context, args ...interface{}) (result sql.Res
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	sqlStr = a.base.appendComment(ctx, sqlStr)
 
-	result, err = a.base.DB.ExecContext(ctx, sqlStr, args...)
+	result, err = a.base.DB.ExecContext(a.withTimeout(ctx), sqlStr, args...)
+	a.releaseTimeout()
 	if err != nil {
 		err = errors.Wrapf(err, "[dml] ExecContext with query %q", sqlStr) // err gets catched by the defer
 		return
 	}
+	if span != nil || a.base.stats != nil {
+		if ra, errRA := result.RowsAffected(); errRA == nil {
+			if span != nil {
+				span.SetAttributes(attribute.Int64("dml.rows_affected", ra))
+			}
+			a.base.stats.addRows(a.base.source, "affected", ra)
+		}
+	}
+	if a.updateVersionColumn != "" {
+		var ra int64
+		if ra, err = result.RowsAffected(); err != nil {
+			err = errors.WithStack(err)
+			return
+		}
+		if ra == 0 {
+			err = errors.Mismatch.Newf("[dml] Update: Row has been modified concurrently; version column %q does not match. Query: %q", a.updateVersionColumn, sqlStr)
+			return
+		}
+		if err = a.assignNextVersion(); err != nil {
+			err = errors.WithStack(err)
+			return
+		}
+	}
+
+	if err = a.dispatchAfterExec(nil); err != nil {
+		return
+	}
 
 	if a.recs == nil {
 		return result, nil
@@ -1142,10 +1487,37 @@ func (a *Artisan) exec(ctx context.Context, args ...interface{}) (result sql.Res
 		err = errors.WithStack(err)
 		return
 	}
-	for i, rec := range a.recs {
-		if a, ok := rec.Record.(LastInsertIDAssigner); ok {
-			a.AssignLastInsertID(lID + int64(i))
+	var cm *ColumnMap
+	for _, rec := range a.recs {
+		if rec.Qualifier == OnDuplicateKeyQualifier {
+			continue
+		}
+		if lia, ok := rec.Record.(LastInsertIDAssigner); ok {
+			lia.AssignLastInsertID(lID)
+			lID++
+			continue
+		}
+		lim, ok := rec.Record.(LastInsertIDMapper)
+		if !ok || a.base.source != dmlSourceInsert {
+			continue
+		}
+		col := lim.LastInsertIDColumn()
+		if col == "" {
+			continue
+		}
+		if cm == nil {
+			cm = NewColumnMap(0)
+		}
+		cm.setLastInsertID(col, lID)
+		if err = lim.MapColumns(cm); err != nil {
+			if errors.NotSupported.Match(err) {
+				err = nil
+				continue
+			}
+			err = errors.WithStack(err)
+			return
 		}
+		lID = cm.lastInsertID
 	}
 	return
 }
@@ -463,3 +463,20 @@ func TestArtisan_OrderByLimit(t *testing.T) {
 		})
 	})
 }
+
+func TestConnPool_Args(t *testing.T) {
+	// Not parallel, testing.AllocsPerRun panics in parallel tests.
+	c := &ConnPool{}
+	a := c.Args().Int64(1).String("a")
+	assert.Exactly(t, []interface{}{int64(1), "a"}, a.Interfaces())
+	a.Release()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		a := c.Args().Int64(2).Bool(true)
+		if len(a.arguments) != 2 {
+			t.Fatalf("Expected two arguments, got %d", len(a.arguments))
+		}
+		a.Release()
+	})
+	assert.True(t, allocs <= 1, "Expected at most one allocation, got %f", allocs)
+}
@@ -20,10 +20,13 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 	"unicode/utf8"
 
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -35,6 +38,7 @@ const (
 	dmlSourceWith         = 'w'
 	dmlSourceUnion        = 'n'
 	dmlSourceShow         = 'h'
+	dmlSourceCall         = 'c'
 )
 
 type writer interface {
@@ -109,6 +113,22 @@ type builderCommon struct {
 	// qualifiedColumns gets collected before calling ToSQL, and clearing the all
 	// pointers, to know which columns need values from the QualifiedRecords
 	qualifiedColumns []string
+	// timeout if greater zero, wraps the context of each query execution with a
+	// deadline. See the WithTimeout functions of the DML types.
+	timeout time.Duration
+	// tracer starts a span for each query execution. Optional, see WithTracer.
+	tracer trace.Tracer
+	// stats collects the metrics of each query execution. Optional, see
+	// WithStats.
+	stats *StatsCollector
+	// timeFmt renders interpolated time values. Optional, see WithTimeZone and
+	// WithTimeFormat.
+	timeFmt *timeFormatter
+	// commenters append a comment to each executed statement. Optional, see
+	// WithSQLCommenter.
+	commenters []SQLCommenter
+	// table contains the table name used as span attribute.
+	table string
 }
 
 // estimatedCachedSQLSize 1024 bytes value got retrieved by analyzing and
@@ -139,6 +159,9 @@ type BuilderBase struct {
 	// has been requested. for every new iteration the propagation must stop at
 	// this position.
 	propagationStoppedAt int
+	// softDeleteColumn contains the column name used by the soft delete mode.
+	// Empty if the mode has not been enabled or Unscoped has been called.
+	softDeleteColumn string
 
 	rwmu sync.RWMutex // also protects the whole SQL string building process
 	builderCommon
@@ -150,6 +173,9 @@ func (bb BuilderBase) Clone() BuilderBase {
 	cc.Table = bb.Table.Clone()
 	cc.rwmu = sync.RWMutex{}
 	cc.builderCommon.qualifiedColumns = cloneStringSlice(bb.builderCommon.qualifiedColumns)
+	if bb.builderCommon.cachedSQL != nil {
+		cc.builderCommon.cachedSQL = append([]byte(nil), bb.builderCommon.cachedSQL...)
+	}
 	return cc
 }
 
@@ -186,6 +212,10 @@ func (bb *BuilderBase) buildToSQL(qb queryBuilder) ([]byte, error) {
 }
 
 func (bb *BuilderBase) prepare(ctx context.Context, db Preparer, qb queryBuilder, source rune) (_ *Stmt, err error) {
+	ctx, span := bb.startSpan(ctx, "Prepare", attribute.String("dml.table", bb.Table.Name))
+	defer func() { endSpan(span, err) }()
+	defer func(start time.Time) { bb.stats.observe(source, "prepare", start, err) }(now())
+
 	var rawQuery []byte
 	rawQuery, err = bb.buildToSQL(qb)
 	if bb.Log != nil && bb.Log.IsDebug() {
@@ -206,6 +236,8 @@ func (bb *BuilderBase) prepare(ctx context.Context, db Preparer, qb queryBuilder
 	stmt.base.cachedSQL = rawQuery
 	stmt.base.DB = stmtWrapper{stmt: sqlStmt}
 	stmt.base.source = source
+	stmt.base.table = bb.Table.Name
+	bb.stats.stmtOpened()
 	return stmt, nil
 }
 
@@ -214,6 +246,14 @@ func (bb *BuilderBase) readBuildCache() (sql []byte) {
 	return sql
 }
 
+// afterExecListener gets implemented by the DML types which support the
+// OnAfterExec event.
+type afterExecListener interface {
+	// afterExecListener returns a function which dispatches the OnAfterExec
+	// event or nil if no listeners have been set.
+	afterExecListener() func() error
+}
+
 // withArtisan builds the SQl string and creates a new Artisan object for
 // collecting arguments and later querying.
 func (bb *BuilderBase) withArtisan(qb queryBuilder) *Artisan {
@@ -227,6 +267,10 @@ func (bb *BuilderBase) withArtisan(qb queryBuilder) *Artisan {
 	bb.rwmu.Unlock()
 	a.base.cachedSQL = sqlBytes
 	a.base.ärgErr = errors.WithStack(err)
+	a.base.table = bb.Table.Name
+	if ael, ok := qb.(afterExecListener); ok {
+		a.afterExec = ael.afterExecListener()
+	}
 	return &a
 }
 
@@ -0,0 +1,363 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+)
+
+const (
+	callParamIn    = 'i'
+	callParamOut   = 'o'
+	callParamInOut = 'b'
+)
+
+type callParam struct {
+	mode byte
+	cnd  *Condition
+}
+
+// Call represents a CALL statement to execute a stored procedure. IN parameters
+// can be place holders or values. OUT and INOUT parameters are getting mapped
+// to session variables of the same name. To read the values of the OUT and
+// INOUT parameters, a follow-up SELECT gets executed. Because session
+// variables are bound to a connection, a Call must use a *sql.Conn or a *sql.Tx
+// when OUT or INOUT parameters are involved.
+//		CALL `proc`(?, 3, @out_a, @inout_b)
+type Call struct {
+	BuilderBase
+	// params contains the IN, OUT and INOUT parameters in the order of the
+	// procedure declaration.
+	params []callParam
+}
+
+// connPooler gets implemented by *sql.DB and by wrappers around it, e.g. for
+// tracing or metrics. Each statement might run on a different connection.
+type connPooler interface {
+	Conn(ctx context.Context) (*sql.Conn, error)
+}
+
+// NewCall creates a new CALL statement for the provided stored procedure.
+func NewCall(procedure string) *Call {
+	return &Call{
+		BuilderBase: BuilderBase{
+			Table: MakeIdentifier(procedure),
+		},
+	}
+}
+
+func newCall(db QueryExecPreparer, cCom *connCommon, procedure string) *Call {
+	id := cCom.makeUniqueID()
+	l := cCom.Log
+	if l != nil {
+		l = l.With(log.String("call_id", id), log.String("procedure", procedure))
+	}
+	return &Call{
+		BuilderBase: BuilderBase{
+			builderCommon: builderCommon{
+				id:         id,
+				Log:        l,
+				DB:         db,
+				tracer:     cCom.tracer,
+				stats:      cCom.stats,
+				timeFmt:    cCom.timeFmt,
+				commenters: cCom.commenters,
+			},
+			Table: MakeIdentifier(procedure),
+		},
+	}
+}
+
+// Call creates a new CALL statement with a random connection from the pool.
+// OUT and INOUT parameters return a NotSupported error because the follow-up
+// SELECT might run on a different connection.
+func (c *ConnPool) Call(procedure string) *Call {
+	return newCall(c.DB, &c.connCommon, procedure)
+}
+
+// Call creates a new CALL statement bound to a single connection.
+func (c *Conn) Call(procedure string) *Call {
+	return newCall(c.DB, &c.connCommon, procedure)
+}
+
+// Call creates a new CALL statement bound to a transaction.
+func (tx *Tx) Call(procedure string) *Call {
+	return newCall(tx.DB, &tx.connCommon, procedure)
+}
+
+// WithDB sets the database query object.
+func (b *Call) WithDB(db QueryExecPreparer) *Call {
+	b.DB = db
+	return b
+}
+
+// In adds IN parameters. A condition can have a value or a place holder, e.g.
+// Column("entity_id").PlaceHolder() or Column("store_id").Int(3).
+func (b *Call) In(params ...*Condition) *Call {
+	for _, p := range params {
+		b.params = append(b.params, callParam{mode: callParamIn, cnd: p})
+	}
+	return b
+}
+
+// Out adds OUT parameters. Each name gets written as a session variable, e.g.
+// name "total" becomes @total, and must be a valid identifier. The values can
+// be loaded with function Load.
+func (b *Call) Out(names ...string) *Call {
+	for _, n := range names {
+		b.params = append(b.params, callParam{mode: callParamOut, cnd: Column(n)})
+	}
+	return b
+}
+
+// InOut adds INOUT parameters. The left side of a condition defines the name
+// of the session variable and the right side its initial value, e.g.
+// Column("counter").Int(5) sets @counter to 5 before the CALL gets executed.
+// Place holders are not supported.
+func (b *Call) InOut(params ...*Condition) *Call {
+	for _, p := range params {
+		b.params = append(b.params, callParam{mode: callParamInOut, cnd: p})
+	}
+	return b
+}
+
+// WithArgs returns a new type to support multiple executions of the underlying
+// SQL statement and reuse of memory allocations for the arguments. WithArgs
+// builds the SQL string in a thread safe way. It copies the underlying
+// connection and settings from the current DML type (Delete, Insert, Select,
+// Update, Union, With, etc.). The field DB can still be overwritten.
+// Interpolation does not support the raw interfaces. It's an architecture bug
+// to use WithArgs inside a loop. WithArgs does support thread safety and can be
+// used in parallel. Each goroutine must have its own dedicated *Artisan
+// pointer. The SET statement for the INOUT parameters and the SELECT of the
+// OUT parameters are not part of the Artisan, use function Load.
+func (b *Call) WithArgs() *Artisan {
+	return b.withArtisan(b)
+}
+
+// ToSQL converts the CALL statement into a string and returns its arguments.
+func (b *Call) ToSQL() (string, []interface{}, error) {
+	b.source = dmlSourceCall
+	rawSQL, err := b.buildToSQL(b)
+	if err != nil {
+		return "", nil, errors.WithStack(err)
+	}
+	return string(rawSQL), nil, nil
+}
+
+func (b *Call) writeBuildCache(sql []byte, qualifiedColumns []string) {
+	b.qualifiedColumns = qualifiedColumns
+	if !b.IsBuildCacheDisabled {
+		b.cachedSQL = sql
+	}
+}
+
+// DisableBuildCache if enabled it does not cache the SQL string as a final
+// rendered byte slice. Allows you to rebuild the query with different
+// statements.
+func (b *Call) DisableBuildCache() *Call {
+	b.IsBuildCacheDisabled = true
+	return b
+}
+
+// checkSessionParams verifies that the OUT and INOUT parameters have valid
+// names and do not run on a connection pool, where each statement might use
+// a different connection and hence different session variables.
+func (b *Call) checkSessionParams() error {
+	for _, p := range b.params {
+		if p.mode == callParamIn {
+			continue
+		}
+		if _, ok := b.DB.(connPooler); ok {
+			return errors.NotSupported.Newf("[dml] Call: OUT and INOUT parameters require a *sql.Conn or *sql.Tx, got a connection pool %T for procedure %q", b.DB, b.Table.Name)
+		}
+		if v := isNameValid(p.cnd.Left); v != 0 {
+			return errors.NotValid.Newf("[dml] Call: Invalid parameter name %q (Case %d)", p.cnd.Left, v)
+		}
+	}
+	return nil
+}
+
+func (b *Call) toSQL(w *bytes.Buffer, placeHolders []string) (_ []string, err error) {
+	b.source = dmlSourceCall
+	if b.Table.Name == "" {
+		return nil, errors.Empty.Newf("[dml] Call: Procedure name is empty")
+	}
+	if err = b.checkSessionParams(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	w.WriteString("CALL ")
+	writeStmtID(w, b.id)
+	Quoter.quote(w, b.Table.Name)
+	w.WriteByte('(')
+	for i, p := range b.params {
+		if i > 0 {
+			w.WriteString(", ")
+		}
+		if p.cnd.previousErr != nil {
+			return nil, errors.WithStack(p.cnd.previousErr)
+		}
+		switch {
+		case p.mode == callParamOut, p.mode == callParamInOut:
+			w.WriteByte('@')
+			w.WriteString(p.cnd.Left)
+		case p.cnd.Right.arg.isSet:
+			if err = p.cnd.Right.arg.writeTo(w, 0); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		case p.cnd.Right.IsExpression:
+			if _, err = writeExpression(w, p.cnd.Right.Column, p.cnd.Right.args); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		default:
+			placeHolders = append(placeHolders, p.cnd.Left)
+			w.WriteByte(placeHolderRune)
+		}
+	}
+	w.WriteByte(')')
+	return placeHolders, nil
+}
+
+// setInOutSQL creates the SET statement for the initial values of the INOUT
+// parameters.
+func (b *Call) setInOutSQL() (string, error) {
+	var buf bytes.Buffer
+	for _, p := range b.params {
+		if p.mode != callParamInOut {
+			continue
+		}
+		if !p.cnd.Right.arg.isSet {
+			return "", errors.NotSupported.Newf("[dml] Call: INOUT parameter %q requires a value", p.cnd.Left)
+		}
+		if buf.Len() == 0 {
+			buf.WriteString("SET ")
+		} else {
+			buf.WriteString(", ")
+		}
+		buf.WriteByte('@')
+		buf.WriteString(p.cnd.Left)
+		buf.WriteByte('=')
+		if err := p.cnd.Right.arg.writeTo(&buf, 0); err != nil {
+			return "", errors.WithStack(err)
+		}
+	}
+	return buf.String(), nil
+}
+
+// selectOutSQL creates the SELECT statement to read the session variables of
+// the OUT and INOUT parameters.
+func (b *Call) selectOutSQL() string {
+	var buf bytes.Buffer
+	for _, p := range b.params {
+		if p.mode == callParamIn {
+			continue
+		}
+		if buf.Len() == 0 {
+			buf.WriteString("SELECT ")
+		} else {
+			buf.WriteString(", ")
+		}
+		buf.WriteByte('@')
+		buf.WriteString(p.cnd.Left)
+		buf.WriteString(" AS ")
+		Quoter.quote(&buf, p.cnd.Left)
+	}
+	return buf.String()
+}
+
+// Load executes the CALL statement. First the initial values of the INOUT
+// parameters are getting set. Each result set returned by the procedure gets
+// mapped into the ColumnMapper of argument resultSets in the same order.
+// Surplus result sets are getting ignored. Afterwards the values of the OUT and
+// INOUT parameters are getting loaded into argument `out`, which receives the
+// columns named like the parameters. `out` can be nil. The optional args are
+// getting passed to the CALL statement.
+func (b *Call) Load(ctx context.Context, out ColumnMapper, resultSets []ColumnMapper, args ...interface{}) (err error) {
+	if b.Log != nil && b.Log.IsDebug() {
+		defer log.WhenDone(b.Log).Debug("Load", log.String("id", b.id), log.Err(err), log.ObjectTypeOf("ColumnMapper", out))
+	}
+
+	if err = b.checkSessionParams(); err != nil {
+		return errors.WithStack(err)
+	}
+	setSQL, err := b.setInOutSQL()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if setSQL != "" {
+		if _, err = b.DB.ExecContext(ctx, setSQL); err != nil {
+			return errors.Wrapf(err, "[dml] Call.Load.ExecContext with query %q", setSQL)
+		}
+	}
+
+	a := b.WithArgs()
+	if len(resultSets) == 0 {
+		if _, err = a.ExecContext(ctx, args...); err != nil {
+			return errors.WithStack(err)
+		}
+	} else if _, err = a.Load(ctx, NewResultSets(resultSets...), args...); err != nil {
+		return errors.WithStack(err)
+	}
+
+	selSQL := b.selectOutSQL()
+	if out == nil || selSQL == "" {
+		return nil
+	}
+	r, err := b.DB.QueryContext(ctx, selSQL)
+	if err != nil {
+		return errors.Wrapf(err, "[dml] Call.Load.QueryContext with query %q", selSQL)
+	}
+	if _, err = loadRows(r, out); err != nil {
+		_ = r.Close()
+		return errors.Wrapf(err, "[dml] Call.Load failed with queryID %q and ColumnMapper %T", b.id, out)
+	}
+	return errors.WithStack(r.Close())
+}
+
+// loadRows scans all rows of the current result set into the ColumnMapper.
+// It does not close the rows.
+func loadRows(r *sql.Rows, s ColumnMapper) (rowCount uint64, err error) {
+	cm := pooledColumnMapGet()
+	defer pooledBufferColumnMapPut(cm, nil, nil)
+	for r.Next() {
+		if err = cm.Scan(r); err != nil {
+			return 0, errors.WithStack(err)
+		}
+		if err = s.MapColumns(cm); err != nil {
+			return 0, errors.WithStack(err)
+		}
+	}
+	if err = r.Err(); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	if cm.HasRows {
+		cm.Count++ // because first row is zero but we want the actual row number
+	}
+	return cm.Count, nil
+}
+
+// Prepare executes the statement represented by the Call to create a prepared
+// statement. It returns a custom statement type or an error if there was one.
+// The provided context is used for the preparation of the statement, not for
+// the execution of the statement.
+func (b *Call) Prepare(ctx context.Context) (*Stmt, error) {
+	return b.prepare(ctx, b.DB, b, dmlSourceCall)
+}
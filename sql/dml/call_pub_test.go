@@ -0,0 +1,80 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+type callOutParams struct {
+	Total int64
+	Count int64
+}
+
+func (o *callOutParams) MapColumns(cm *dml.ColumnMap) error {
+	for cm.Next() {
+		switch c := cm.Column(); c {
+		case "total":
+			cm.Int64(&o.Total)
+		case "cnt":
+			cm.Int64(&o.Count)
+		default:
+			return errors.NotFound.Newf("[dml_test] callOutParams Column %q not found", c)
+		}
+	}
+	return cm.Err()
+}
+
+func TestCall_Load(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("SET @cnt=5")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("CALL `proc`(?, @total, @cnt)")).WithArgs(7).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Alf").AddRow(2, "John"),
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "Karl"),
+		)
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT @total AS `total`, @cnt AS `cnt`")).
+		WillReturnRows(sqlmock.NewRows([]string{"total", "cnt"}).AddRow(3, 6))
+
+	conn, err := dbc.Conn(context.TODO())
+	assert.NoError(t, err)
+	defer dmltest.Close(t, conn)
+
+	var out callOutParams
+	header := &dmlPersonCollection{}
+	items := &dmlPersonCollection{}
+	err = conn.Call("proc").
+		In(dml.Column("store_id").PlaceHolder()).
+		Out("total").
+		InOut(dml.Column("cnt").Int(5)).
+		Load(context.TODO(), &out, []dml.ColumnMapper{header, items}, 7)
+	assert.NoError(t, err)
+
+	assert.Exactly(t, callOutParams{Total: 3, Count: 6}, out)
+	assert.Len(t, header.Data, 2)
+	assert.Len(t, items.Data, 1)
+	assert.Exactly(t, "Karl", items.Data[0].Name)
+}
@@ -0,0 +1,84 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestCall_ToSQL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all parameter types", func(t *testing.T) {
+		c := NewCall("proc").
+			In(Column("a").PlaceHolder(), Column("b").Int(3)).
+			Out("total").
+			InOut(Column("cnt").Int(5))
+		ca := c.WithArgs().Int(7)
+		compareToSQL(t, ca, errors.NoKind,
+			"CALL `proc`(?, 3, @total, @cnt)",
+			"CALL `proc`(7, 3, @total, @cnt)",
+			int64(7),
+		)
+		assert.Exactly(t, []string{"a"}, ca.base.qualifiedColumns)
+
+		setSQL, err := c.setInOutSQL()
+		assert.NoError(t, err)
+		assert.Exactly(t, "SET @cnt=5", setSQL)
+		assert.Exactly(t, "SELECT @total AS `total`, @cnt AS `cnt`", c.selectOutSQL())
+	})
+	t.Run("no parameters", func(t *testing.T) {
+		compareToSQL(t, NewCall("cleanup"), errors.NoKind,
+			"CALL `cleanup`()",
+			"CALL `cleanup`()",
+		)
+	})
+	t.Run("empty procedure", func(t *testing.T) {
+		compareToSQL(t, NewCall(""), errors.Empty, "", "")
+	})
+	t.Run("invalid OUT name", func(t *testing.T) {
+		_, _, err := NewCall("proc").Out("total; DROP TABLE x").ToSQL()
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+	t.Run("OUT on connection pool", func(t *testing.T) {
+		_, _, err := NewCall("proc").Out("total").WithDB(new(sql.DB)).ToSQL()
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	})
+	t.Run("OUT on wrapped connection pool", func(t *testing.T) {
+		_, _, err := NewCall("proc").Out("total").WithDB(wrappedConnPool{DB: new(sql.DB)}).ToSQL()
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	})
+	t.Run("OUT on connection", func(t *testing.T) {
+		_, _, err := NewCall("proc").Out("total").WithDB(new(sql.Conn)).ToSQL()
+		assert.NoError(t, err)
+	})
+	t.Run("IN on connection pool", func(t *testing.T) {
+		_, _, err := NewCall("proc").In(Column("a").Int(1)).WithDB(new(sql.DB)).ToSQL()
+		assert.NoError(t, err)
+	})
+	t.Run("INOUT with place holder", func(t *testing.T) {
+		_, err := NewCall("proc").InOut(Column("cnt").PlaceHolder()).setInOutSQL()
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	})
+}
+
+// wrappedConnPool simulates a wrapper around a *sql.DB, e.g. for tracing.
+type wrappedConnPool struct {
+	*sql.DB
+}
@@ -0,0 +1,115 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"sort"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SQLCommenter extracts a key/value pair from the context which gets appended
+// as a comment to each executed statement, following the sqlcommenter format:
+//		SELECT * FROM `core_store` /*application='shop',route='%2Fcheckout'*/
+// An empty key or value skips the pair. Prepared statements do not support
+// comments because the SQL string gets sent only once to the server.
+type SQLCommenter func(ctx context.Context) (key, value string)
+
+// WithSQLCommenter adds the SQLCommenter functions to the connection. The
+// pairs are sorted by key. The unique ID comment set via WithUniqueIDFn stays
+// at the beginning of each statement.
+func WithSQLCommenter(cs ...SQLCommenter) ConnPoolOption {
+	return ConnPoolOption{
+		sortOrder: 10,
+		fn: func(c *ConnPool) error {
+			c.commenters = append(c.commenters, cs...)
+			return nil
+		},
+	}
+}
+
+// SQLCommentApplication adds the application name with key `application`.
+func SQLCommentApplication(name string) SQLCommenter {
+	return func(context.Context) (string, string) {
+		return "application", name
+	}
+}
+
+// SQLCommentTraceParent adds the current OpenTelemetry span context in the
+// W3C format with key `traceparent`. The span started by WithTracer becomes the
+// parent.
+func SQLCommentTraceParent() SQLCommenter {
+	return func(ctx context.Context) (string, string) {
+		sc := trace.SpanContextFromContext(ctx)
+		if !sc.IsValid() {
+			return "", ""
+		}
+		return "traceparent", "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-" + sc.TraceFlags().String()
+	}
+}
+
+type ctxKeySQLCommentRoute struct{}
+
+// ContextWithSQLCommentRoute returns a new context which carries the route,
+// for example an HTTP path or a RPC method name, for SQLCommentRoute.
+func ContextWithSQLCommentRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, ctxKeySQLCommentRoute{}, route)
+}
+
+// SQLCommentRoute adds the route, set via ContextWithSQLCommentRoute, with key
+// `route`.
+func SQLCommentRoute() SQLCommenter {
+	return func(ctx context.Context) (string, string) {
+		route, _ := ctx.Value(ctxKeySQLCommentRoute{}).(string)
+		return "route", route
+	}
+}
+
+// appendComment appends the sqlcommenter comment to sqlStr. Keys and values
+// are URL encoded, hence they cannot terminate the comment.
+func (bc *builderCommon) appendComment(ctx context.Context, sqlStr string) string {
+	if len(bc.commenters) == 0 || sqlStr == "" {
+		return sqlStr
+	}
+	kvs := make([][2]string, 0, len(bc.commenters))
+	for _, c := range bc.commenters {
+		if k, v := c(ctx); k != "" && v != "" {
+			kvs = append(kvs, [2]string{url.PathEscape(k), url.PathEscape(v)})
+		}
+	}
+	if len(kvs) == 0 {
+		return sqlStr
+	}
+	sort.Slice(kvs, func(i, j int) bool { return kvs[i][0] < kvs[j][0] })
+
+	var buf bytes.Buffer
+	buf.Grow(len(sqlStr) + 64)
+	buf.WriteString(sqlStr)
+	buf.WriteString(" /*")
+	for i, kv := range kvs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(kv[0])
+		buf.WriteString("='")
+		buf.WriteString(kv[1])
+		buf.WriteByte('\'')
+	}
+	buf.WriteString("*/")
+	return buf.String()
+}
@@ -0,0 +1,66 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWithSQLCommenter(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t, dml.WithSQLCommenter(
+		dml.SQLCommentRoute(),
+		dml.SQLCommentApplication("shop*/ DROP"),
+		dml.SQLCommentTraceParent(),
+		func(context.Context) (string, string) { return "empty", "" },
+	))
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	t.Run("route and application", func(t *testing.T) {
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `customer_entity` WHERE (`entity_id` = ?) /*application='shop%2A%2F%20DROP',route='%2Fcheckout%2Fcart'*/")).
+			WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		ctx := dml.ContextWithSQLCommentRoute(context.Background(), "/checkout/cart")
+		_, err := dbc.DeleteFrom("customer_entity").Where(dml.Column("entity_id").PlaceHolder()).
+			WithArgs().ExecContext(ctx, 3)
+		assert.NoError(t, err)
+	})
+	t.Run("traceparent", func(t *testing.T) {
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `entity_id` FROM `customer_entity` /*application='shop%2A%2F%20DROP',traceparent='00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01'*/")).
+			WillReturnRows(sqlmock.NewRows([]string{"entity_id"}).AddRow(4))
+
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+			TraceFlags: trace.FlagsSampled,
+		}))
+		ids, err := dbc.SelectFrom("customer_entity").AddColumns("entity_id").WithArgs().LoadInt64s(ctx, nil)
+		assert.NoError(t, err)
+		assert.Exactly(t, []int64{4}, ids)
+	})
+	t.Run("ToSQL without comment", func(t *testing.T) {
+		sqlStr, _, err := dbc.SelectFrom("customer_entity").AddColumns("entity_id").ToSQL()
+		assert.NoError(t, err)
+		assert.Exactly(t, "SELECT `entity_id` FROM `customer_entity`", sqlStr)
+	})
+}
@@ -495,6 +495,27 @@ func (c *Condition) Sub(sub *Select) *Condition {
 	return c
 }
 
+// Subselect creates the right hand side of a SET clause in an UPDATE statement
+// from a SELECT statement. The sub-select can be correlated and its place
+// holders get propagated to the UPDATE statement in the order of appearance.
+//		dml.Column("stock").Set(dml.Subselect(sel))
+func Subselect(sub *Select) *Condition {
+	c := new(Condition)
+	c.Right.Sub = sub
+	return c
+}
+
+// Set assigns the right hand side of the provided condition, mostly created
+// with Subselect, to the current condition. Used in SET clauses of UPDATE
+// statements.
+func (c *Condition) Set(right *Condition) *Condition {
+	c.Right = right.Right
+	if right.previousErr != nil {
+		c.previousErr = right.previousErr
+	}
+	return c
+}
+
 // Expr compares the left hand side with the expression of the right hand
 // side.
 func (c *Condition) Expr(expression string) *Condition {
@@ -557,24 +578,45 @@ func (c *Condition) Uint64s(i ...uint64) *Condition {
 	return c
 }
 
-// Add when needed
-//func (c *Condition) Decimals(d ...Decimal) *Condition {}
-
+// Decimal sets the decimal value. An invalid decimal gets written as NULL. The
+// decimal value gets interpolated unquoted to retain its precision.
 func (c *Condition) Decimal(d null.Decimal) *Condition {
-	v := d.String()
 	if c.isExpression() {
-		if v == sqlStrNullUC {
-			c.Right.args = c.Right.args.add(nil)
-		} else {
-			c.Right.args = c.Right.args.add(v)
-		}
+		c.Right.args = c.Right.args.add(d)
+		return c
+	}
+	c.Right.arg.set(d)
+	return c
+}
+
+// Decimals uses the decimal values for comparison, e.g. with the IN operator.
+func (c *Condition) Decimals(d ...null.Decimal) *Condition {
+	if c.isExpression() {
+		c.Right.args = c.Right.args.add(d)
+		return c
+	}
+	c.Right.arg.set(d)
+	return c
+}
+
+// Point uses the spatial POINT value for comparison.
+func (c *Condition) Point(p Point) *Condition {
+	if c.isExpression() {
+		c.Right.args = c.Right.args.add(p)
 		return c
 	}
-	if v == sqlStrNullUC {
-		c.Right.arg.set(nil)
-	} else {
-		c.Right.arg.set(v)
+	c.Right.arg.set(p)
+	return c
+}
+
+// Points uses the spatial POINT values for comparison, e.g. with the IN
+// operator.
+func (c *Condition) Points(p ...Point) *Condition {
+	if c.isExpression() {
+		c.Right.args = c.Right.args.add(p)
+		return c
 	}
+	c.Right.arg.set(p)
 	return c
 }
 
@@ -723,6 +765,24 @@ func (c *Condition) NullInt64s(nv ...null.Int64) *Condition {
 	return c
 }
 
+func (c *Condition) NullUint64(nv null.Uint64) *Condition {
+	if c.isExpression() {
+		c.Right.args = c.Right.args.add(nv)
+		return c
+	}
+	c.Right.arg.set(nv)
+	return c
+}
+
+func (c *Condition) NullUint64s(nv ...null.Uint64) *Condition {
+	if c.isExpression() {
+		c.Right.args = c.Right.args.add(nv)
+		return c
+	}
+	c.Right.arg.set(nv)
+	return c
+}
+
 func (c *Condition) NullBool(nv null.Bool) *Condition {
 	if c.isExpression() {
 		c.Right.args = c.Right.args.add(nv)
@@ -26,6 +26,8 @@ import (
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/log"
 	"github.com/go-sql-driver/mysql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type uniqueIDFn func() string
@@ -51,6 +53,13 @@ type connCommon struct {
 	makeUniqueID uniqueIDFn
 	mapTableName func(oldName string) (newName string)
 	runOnClose   []ConnPoolOption
+	// softDeleteColumns maps a table name to its soft delete column. See
+	// WithSoftDelete.
+	softDeleteColumns map[string]string
+	tracer            trace.Tracer    // optional, see WithTracer
+	stats             *StatsCollector // optional, see WithStats
+	timeFmt           *timeFormatter  // optional, see WithTimeZone and WithTimeFormat
+	commenters        []SQLCommenter  // optional, see WithSQLCommenter
 }
 
 // ConnPool at a connection to the database with an EventReceiver to send
@@ -60,6 +69,10 @@ type ConnPool struct {
 	// DB must be set using one of the ConnPoolOption function.
 	DB  *sql.DB
 	dsn *mysql.Config
+	// onConnect and onDisconnect run for each physical connection. See
+	// WithOnConnect and WithOnDisconnect.
+	onConnect    []ConnHook
+	onDisconnect []ConnHook
 }
 
 // Conn represents a single database session rather a pool of database sessions.
@@ -90,6 +103,9 @@ type Conn struct {
 type Tx struct {
 	connCommon
 	DB *sql.Tx
+	// span covers the whole transaction and ends with Commit or Rollback. Nil
+	// if no tracer has been set.
+	span trace.Span
 }
 
 // ConnPoolOption can be used at an argument in NewConnPool to configure a
@@ -137,6 +153,27 @@ func WithLogger(l log.Logger, uniqueIDFn func() string) ConnPoolOption {
 	}
 }
 
+// WithSoftDelete enables the soft delete mode for the provided tables. Each
+// Select and Update on those tables appends the condition `column IS NULL` to
+// its WHERE clause and each Delete gets rewritten into an UPDATE which sets the
+// column to NOW(). The table names must be provided without applying the
+// TableNameMapper. Calling Unscoped on a statement disables the soft delete
+// mode for that statement.
+func WithSoftDelete(column string, tables ...string) ConnPoolOption {
+	return ConnPoolOption{
+		sortOrder: 11,
+		fn: func(c *ConnPool) error {
+			if c.softDeleteColumns == nil {
+				c.softDeleteColumns = make(map[string]string, len(tables))
+			}
+			for _, t := range tables {
+				c.softDeleteColumns[t] = column
+			}
+			return nil
+		},
+	}
+}
+
 // WithVerifyConnection checks if the connection to the server is valid and can
 // be established.
 func WithVerifyConnection() ConnPoolOption {
@@ -279,7 +316,7 @@ func WithDSN(dsn string, cb ...DriverCallBack) ConnPoolOption {
 			if len(cb) == 1 {
 				drv = wrapDriver(drv, cb[0])
 			}
-			c.DB = sql.OpenDB(dsnConnector{dsn: dsn, driver: drv})
+			c.DB = sql.OpenDB(dsnConnector{dsn: dsn, driver: drv, pool: c})
 			return nil
 		},
 	}
@@ -303,10 +340,15 @@ func WithDSNfromEnv(dsnEnvName string, cb ...DriverCallBack) ConnPoolOption {
 type dsnConnector struct {
 	dsn    string
 	driver driver.Driver
+	pool   *ConnPool // runs the connection hooks
 }
 
-func (t dsnConnector) Connect(_ context.Context) (driver.Conn, error) {
-	return t.driver.Open(t.dsn)
+func (t dsnConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := t.driver.Open(t.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return t.pool.runConnHooks(ctx, conn)
 }
 
 func (t dsnConnector) Driver() driver.Driver {
@@ -424,6 +466,24 @@ func (c *ConnPool) Close() error {
 	return c.DB.Close() // no stack wrap otherwise error is hard to compare
 }
 
+// HealthCheck verifies that a connection to the database server can be
+// established and is alive. Suitable for readiness probes. Returns a
+// ConnectionFailed error kind on failure.
+func (c *ConnPool) HealthCheck(ctx context.Context) error {
+	if c.DB == nil {
+		return errors.ConnectionFailed.Newf("[dml] HealthCheck: DB has not been set")
+	}
+	if err := c.DB.PingContext(ctx); err != nil {
+		return errors.ConnectionFailed.New(err, "[dml] HealthCheck failed")
+	}
+	return nil
+}
+
+// Stats returns the statistics of the underlying connection pool.
+func (c *ConnPool) Stats() sql.DBStats {
+	return c.DB.Stats()
+}
+
 // BeginTx starts a transaction.
 //
 // The provided context is used until the transaction is committed or rolled
@@ -439,8 +499,10 @@ func (c *ConnPool) Close() error {
 func (c *ConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
 	start := now()
 
+	ctx, span := c.startTxSpan(ctx)
 	dbTx, err := c.DB.BeginTx(ctx, opts)
 	if err != nil {
+		endSpan(span, err)
 		return nil, errors.WithStack(err)
 	}
 	l := c.Log
@@ -452,12 +514,18 @@ func (c *ConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error
 	}
 	return &Tx{
 		connCommon: connCommon{
-			start:        start,
-			Log:          l,
-			makeUniqueID: c.makeUniqueID,
-			mapTableName: c.mapTableName,
+			start:             start,
+			Log:               l,
+			makeUniqueID:      c.makeUniqueID,
+			mapTableName:      c.mapTableName,
+			softDeleteColumns: c.softDeleteColumns,
+			tracer:            newTxTracer(c.tracer, span),
+			stats:             c.stats,
+			timeFmt:           c.timeFmt,
+			commenters:        c.commenters,
 		},
-		DB: dbTx,
+		DB:   dbTx,
+		span: span,
 	}, nil
 }
 
@@ -503,11 +571,15 @@ func (c *ConnPool) WithQueryBuilder(qb QueryBuilder) *Artisan {
 	var args [defaultArgumentsCapacity]argument
 	return &Artisan{
 		base: builderCommon{
-			cachedSQL: []byte(sqlStr),
-			Log:       c.Log,
-			id:        c.makeUniqueID(),
-			DB:        c.DB,
-			ärgErr:    errors.WithStack(err),
+			cachedSQL:  []byte(sqlStr),
+			Log:        c.Log,
+			id:         c.makeUniqueID(),
+			DB:         c.DB,
+			tracer:     c.tracer,
+			stats:      c.stats,
+			timeFmt:    c.timeFmt,
+			commenters: c.commenters,
+			ärgErr:     errors.WithStack(err),
 		},
 		raw:       argsRaw,
 		arguments: args[:0],
@@ -529,10 +601,15 @@ func (c *ConnPool) Conn(ctx context.Context) (*Conn, error) {
 	}
 	return &Conn{
 		connCommon: connCommon{
-			start:        now(),
-			Log:          l,
-			makeUniqueID: c.makeUniqueID,
-			mapTableName: c.mapTableName,
+			start:             now(),
+			Log:               l,
+			makeUniqueID:      c.makeUniqueID,
+			mapTableName:      c.mapTableName,
+			softDeleteColumns: c.softDeleteColumns,
+			tracer:            c.tracer,
+			stats:             c.stats,
+			timeFmt:           c.timeFmt,
+			commenters:        c.commenters,
 		},
 		DB: dbc,
 	}, errors.WithStack(err)
@@ -549,10 +626,14 @@ func (c *ConnPool) WithRawSQL(query string) *Artisan {
 	var args [defaultArgumentsCapacity]argument
 	return &Artisan{
 		base: builderCommon{
-			cachedSQL: []byte(query),
-			Log:       l,
-			id:        id,
-			DB:        c.DB,
+			cachedSQL:  []byte(query),
+			Log:        l,
+			id:         id,
+			DB:         c.DB,
+			tracer:     c.tracer,
+			stats:      c.stats,
+			timeFmt:    c.timeFmt,
+			commenters: c.commenters,
 		},
 		arguments: args[:0],
 	}
@@ -576,10 +657,14 @@ func (c *ConnPool) WithPrepare(ctx context.Context, query string) *Artisan {
 	var args [defaultArgumentsCapacity]argument
 	a := &Artisan{
 		base: builderCommon{
-			id:     id,
-			ärgErr: err,
-			Log:    l,
-			DB:     stmtWrapper{stmt: stmt},
+			id:         id,
+			ärgErr:     err,
+			Log:        l,
+			DB:         stmtWrapper{stmt: stmt},
+			tracer:     c.tracer,
+			stats:      c.stats,
+			timeFmt:    c.timeFmt,
+			commenters: c.commenters,
 		},
 		arguments:  args[:0],
 		isPrepared: true,
@@ -600,8 +685,10 @@ func (c *ConnPool) WithPrepare(ctx context.Context, query string) *Artisan {
 func (c *Conn) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
 	start := now()
 
+	ctx, span := c.startTxSpan(ctx)
 	dbTx, err := c.DB.BeginTx(ctx, opts)
 	if err != nil {
+		endSpan(span, err)
 		return nil, errors.WithStack(err)
 	}
 	l := c.Log
@@ -613,12 +700,18 @@ func (c *Conn) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
 	}
 	return &Tx{
 		connCommon: connCommon{
-			start:        start,
-			Log:          l,
-			makeUniqueID: c.makeUniqueID,
-			mapTableName: c.mapTableName,
+			start:             start,
+			Log:               l,
+			makeUniqueID:      c.makeUniqueID,
+			mapTableName:      c.mapTableName,
+			softDeleteColumns: c.softDeleteColumns,
+			tracer:            newTxTracer(c.tracer, span),
+			stats:             c.stats,
+			timeFmt:           c.timeFmt,
+			commenters:        c.commenters,
 		},
-		DB: dbTx,
+		DB:   dbTx,
+		span: span,
 	}, nil
 }
 
@@ -681,11 +774,15 @@ func (c *Conn) WithQueryBuilder(qb QueryBuilder) *Artisan {
 	var args [defaultArgumentsCapacity]argument
 	return &Artisan{
 		base: builderCommon{
-			cachedSQL: []byte(sqlStr),
-			Log:       l,
-			id:        id,
-			DB:        c.DB,
-			ärgErr:    errors.WithStack(err),
+			cachedSQL:  []byte(sqlStr),
+			Log:        l,
+			id:         id,
+			DB:         c.DB,
+			tracer:     c.tracer,
+			stats:      c.stats,
+			timeFmt:    c.timeFmt,
+			commenters: c.commenters,
+			ärgErr:     errors.WithStack(err),
 		},
 		raw:       argsRaw,
 		arguments: args[:0],
@@ -703,10 +800,14 @@ func (c *Conn) WithRawSQL(sql string) *Artisan {
 	var args [defaultArgumentsCapacity]argument
 	return &Artisan{
 		base: builderCommon{
-			cachedSQL: []byte(sql),
-			Log:       l,
-			id:        id,
-			DB:        c.DB,
+			cachedSQL:  []byte(sql),
+			Log:        l,
+			id:         id,
+			DB:         c.DB,
+			tracer:     c.tracer,
+			stats:      c.stats,
+			timeFmt:    c.timeFmt,
+			commenters: c.commenters,
 		},
 		arguments: args[:0],
 	}
@@ -723,10 +824,14 @@ func (tx *Tx) WithRawSQL(sql string) *Artisan {
 	var args [defaultArgumentsCapacity]argument
 	return &Artisan{
 		base: builderCommon{
-			cachedSQL: []byte(sql),
-			Log:       l,
-			id:        id,
-			DB:        tx.DB,
+			cachedSQL:  []byte(sql),
+			Log:        l,
+			id:         id,
+			DB:         tx.DB,
+			tracer:     tx.tracer,
+			stats:      tx.stats,
+			timeFmt:    tx.timeFmt,
+			commenters: tx.commenters,
 		},
 		arguments: args[:0],
 	}
@@ -750,10 +855,14 @@ func (tx *Tx) WithPrepare(ctx context.Context, query string) *Artisan {
 	var args [defaultArgumentsCapacity]argument
 	a := &Artisan{
 		base: builderCommon{
-			id:     id,
-			ärgErr: err,
-			Log:    l,
-			DB:     stmtWrapper{stmt: stmt},
+			id:         id,
+			ärgErr:     err,
+			Log:        l,
+			DB:         stmtWrapper{stmt: stmt},
+			tracer:     tx.tracer,
+			stats:      tx.stats,
+			timeFmt:    tx.timeFmt,
+			commenters: tx.commenters,
 		},
 		arguments:  args[:0],
 		isPrepared: true,
@@ -767,7 +876,9 @@ func (tx *Tx) Commit() error {
 	if tx.Log != nil && tx.Log.IsDebug() {
 		defer tx.Log.Debug("Commit", log.Duration("duration", now().Sub(tx.start)))
 	}
-	return tx.DB.Commit()
+	err := tx.DB.Commit()
+	endSpan(tx.span, err)
+	return err
 }
 
 // Rollback cancels the transaction. It logs the time taken, if a logger has
@@ -776,7 +887,9 @@ func (tx *Tx) Rollback() error {
 	if tx.Log != nil && tx.Log.IsDebug() {
 		defer tx.Log.Debug("Rollback", log.Duration("duration", now().Sub(tx.start)))
 	}
-	return tx.DB.Rollback()
+	err := tx.DB.Rollback()
+	endSpan(tx.span, err, attribute.Bool("dml.tx_rollback", true))
+	return err
 }
 
 // WithQueryBuilder creates a new Artisan for handling the arguments with the
@@ -787,11 +900,15 @@ func (tx *Tx) WithQueryBuilder(qb QueryBuilder) *Artisan {
 	var args [defaultArgumentsCapacity]argument
 	return &Artisan{
 		base: builderCommon{
-			cachedSQL: []byte(sqlStr),
-			Log:       tx.Log,
-			id:        tx.makeUniqueID(),
-			DB:        tx.DB,
-			ärgErr:    errors.WithStack(err),
+			cachedSQL:  []byte(sqlStr),
+			Log:        tx.Log,
+			id:         tx.makeUniqueID(),
+			DB:         tx.DB,
+			tracer:     tx.tracer,
+			stats:      tx.stats,
+			timeFmt:    tx.timeFmt,
+			commenters: tx.commenters,
+			ärgErr:     errors.WithStack(err),
 		},
 		raw:       argsRaw,
 		arguments: args[:0],
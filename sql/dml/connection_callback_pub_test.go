@@ -19,13 +19,13 @@ import (
 	"context"
 	"database/sql/driver"
 	"fmt"
-	"io/ioutil"
 	"sync/atomic"
 	"testing"
 
 	"github.com/corestoreio/pkg/sql/dml"
 	"github.com/corestoreio/pkg/sql/dmltest"
 	"github.com/corestoreio/pkg/util/assert"
+	"github.com/corestoreio/pkg/util/cstesting"
 )
 
 func TestDriverCallBack(t *testing.T) {
@@ -80,11 +80,5 @@ func TestDriverCallBack(t *testing.T) {
 	assert.NoError(t, con.Close())
 
 	dmltest.Close(t, db)
-	//t.Log(buf.String())
-	//ioutil.WriteFile("testdata/TestDriverCallBack.want.txt", buf.Bytes(), 0644)
-	wantLog, err := ioutil.ReadFile("testdata/TestDriverCallBack.want.txt")
-	assert.NoError(t, err)
-	if !bytes.Equal(wantLog, buf.Bytes()) {
-		t.Error("testdata/TestDriverCallBack.want.txt does not match with `have`.")
-	}
+	cstesting.AssertGolden(t, "TestDriverCallBack.want.txt", buf.Bytes())
 }
@@ -0,0 +1,122 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/corestoreio/errors"
+)
+
+// ConnHook gets called for each physical connection to the database server.
+// `conn` allows to execute statements, for example to set session variables.
+// The args argument of conn.ExecContext can be nil.
+type ConnHook func(ctx context.Context, conn driver.ExecerContext) error
+
+// WithOnConnect adds hooks which run each time the connection pool opens a new
+// physical connection to the server, for example:
+//		func(ctx context.Context, conn driver.ExecerContext) error {
+//			_, err := conn.ExecContext(ctx, "SET SESSION sql_mode='STRICT_ALL_TABLES'", nil)
+//			return err
+//		}
+// An error closes the connection and gets returned to the caller of the query.
+// Hooks work only in conjunction with WithDSN.
+func WithOnConnect(hooks ...ConnHook) ConnPoolOption {
+	return ConnPoolOption{
+		sortOrder: 1, // must run before the first connection gets opened
+		fn: func(c *ConnPool) error {
+			c.onConnect = append(c.onConnect, hooks...)
+			return nil
+		},
+	}
+}
+
+// WithOnDisconnect adds hooks which run before the connection pool closes a
+// physical connection to the server. The connection stays closed even if a
+// hook returns an error. Hooks work only in conjunction with WithDSN.
+func WithOnDisconnect(hooks ...ConnHook) ConnPoolOption {
+	return ConnPoolOption{
+		sortOrder: 1, // must run before the first connection gets opened
+		fn: func(c *ConnPool) error {
+			c.onDisconnect = append(c.onDisconnect, hooks...)
+			return nil
+		},
+	}
+}
+
+// runConnHooks gets called by dsnConnector after a new connection has been
+// opened.
+func (c *ConnPool) runConnHooks(ctx context.Context, conn driver.Conn) (driver.Conn, error) {
+	if c == nil || (len(c.onConnect) == 0 && len(c.onDisconnect) == 0) {
+		return conn, nil
+	}
+	fc, ok := conn.(fullConner)
+	if !ok {
+		_ = conn.Close()
+		return nil, errors.NotSupported.Newf("[dml] Driver does not support all required interfaces (fullConner)")
+	}
+	for _, h := range c.onConnect {
+		if err := h(ctx, fc); err != nil {
+			_ = fc.Close()
+			return nil, errors.WithStack(err)
+		}
+	}
+	if len(c.onDisconnect) == 0 {
+		return conn, nil
+	}
+	return hookConn{fullConner: fc, onDisconnect: c.onDisconnect}, nil
+}
+
+// hookConn runs the disconnect hooks before closing the connection. The
+// optional interfaces of the wrapped connection are getting forwarded.
+type hookConn struct {
+	fullConner
+	onDisconnect []ConnHook
+}
+
+func (hc hookConn) Close() error {
+	var hookErr error
+	for _, h := range hc.onDisconnect {
+		if err := h(context.Background(), hc.fullConner); err != nil && hookErr == nil {
+			hookErr = errors.WithStack(err)
+		}
+	}
+	if err := hc.fullConner.Close(); err != nil {
+		return err
+	}
+	return hookErr
+}
+
+func (hc hookConn) ResetSession(ctx context.Context) error {
+	if sr, ok := hc.fullConner.(driver.SessionResetter); ok {
+		return sr.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (hc hookConn) IsValid() bool {
+	if v, ok := hc.fullConner.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (hc hookConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if nvc, ok := hc.fullConner.(driver.NamedValueChecker); ok {
+		return nvc.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
@@ -0,0 +1,136 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+var _ fullConner = (*hookConn)(nil)
+var _ driver.SessionResetter = (*hookConn)(nil)
+var _ driver.Validator = (*hookConn)(nil)
+var _ driver.NamedValueChecker = (*hookConn)(nil)
+
+type execRecorder struct {
+	queries []string
+}
+
+func (er *execRecorder) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	er.queries = append(er.queries, query)
+	return driver.ResultNoRows, nil
+}
+
+func TestConnPool_ConnHooks(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	execHook := func(query string) ConnHook {
+		return func(ctx context.Context, conn driver.ExecerContext) error {
+			queries = append(queries, query)
+			_, err := conn.ExecContext(ctx, query, nil)
+			return err
+		}
+	}
+
+	t.Run("connect and disconnect", func(t *testing.T) {
+		queries = nil
+		c, err := NewConnPool(
+			WithOnConnect(execHook("SET time_zone='+00:00'"), execHook("SET @a=1")),
+			WithOnDisconnect(execHook("SET @a=NULL")),
+		)
+		assert.NoError(t, err)
+
+		conn, err := dsnConnector{driver: SQLErrDriver{}, pool: c}.Connect(context.TODO())
+		assert.NoError(t, err)
+		assert.Exactly(t, []string{"SET time_zone='+00:00'", "SET @a=1"}, queries)
+		_, ok := conn.(hookConn)
+		assert.True(t, ok, "Should be of type hookConn but got %T", conn)
+
+		assert.NoError(t, conn.Close())
+		assert.Exactly(t, []string{"SET time_zone='+00:00'", "SET @a=1", "SET @a=NULL"}, queries)
+	})
+	t.Run("without disconnect hooks no wrapping", func(t *testing.T) {
+		queries = nil
+		c, err := NewConnPool(WithOnConnect(execHook("SET @b=2")))
+		assert.NoError(t, err)
+
+		conn, err := dsnConnector{driver: SQLErrDriver{}, pool: c}.Connect(context.TODO())
+		assert.NoError(t, err)
+		_, ok := conn.(SQLErrDriverCon)
+		assert.True(t, ok, "Should be of type SQLErrDriverCon but got %T", conn)
+		assert.Exactly(t, []string{"SET @b=2"}, queries)
+	})
+	t.Run("connect hook error", func(t *testing.T) {
+		c, err := NewConnPool(WithOnConnect(execHook("SET @c=3")))
+		assert.NoError(t, err)
+
+		conn, err := dsnConnector{
+			driver: SQLErrDriver{Con: SQLErrDriverCon{ExecError: errors.NotAcceptable.Newf("Unknown variable")}},
+			pool:   c,
+		}.Connect(context.TODO())
+		assert.Nil(t, conn)
+		assert.True(t, errors.NotAcceptable.Match(err), "%+v", err)
+	})
+	t.Run("time zone", func(t *testing.T) {
+		c, err := NewConnPool(WithTimeZone(time.FixedZone("CEST", 2*3600)))
+		assert.NoError(t, err)
+		assert.Len(t, c.onConnect, 1)
+
+		ec := &execRecorder{}
+		assert.NoError(t, c.onConnect[0](context.TODO(), ec))
+		assert.Exactly(t, []string{"SET time_zone = '+02:00'"}, ec.queries)
+	})
+	t.Run("disconnect hook error", func(t *testing.T) {
+		c, err := NewConnPool(WithOnDisconnect(func(context.Context, driver.ExecerContext) error {
+			return errors.WriteFailed.Newf("Disk full")
+		}))
+		assert.NoError(t, err)
+
+		conn, err := dsnConnector{driver: SQLErrDriver{}, pool: c}.Connect(context.TODO())
+		assert.NoError(t, err)
+		err = conn.Close()
+		assert.True(t, errors.WriteFailed.Match(err), "%+v", err)
+	})
+}
+
+func TestConnPool_HealthCheck(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ok", func(t *testing.T) {
+		c := &ConnPool{DB: sql.OpenDB(dsnConnector{driver: SQLErrDriver{}})}
+		assert.NoError(t, c.HealthCheck(context.TODO()))
+		assert.Exactly(t, 1, c.Stats().OpenConnections)
+		assert.NoError(t, c.DB.Close())
+	})
+	t.Run("ping fails", func(t *testing.T) {
+		c := &ConnPool{DB: sql.OpenDB(dsnConnector{driver: SQLErrDriver{
+			Con: SQLErrDriverCon{PingError: errors.AlreadyClosed.Newf("Server has gone away")},
+		}})}
+		err := c.HealthCheck(context.TODO())
+		assert.True(t, errors.ConnectionFailed.Match(err), "%+v", err)
+		assert.NoError(t, c.DB.Close())
+	})
+	t.Run("DB not set", func(t *testing.T) {
+		err := new(ConnPool).HealthCheck(context.TODO())
+		assert.True(t, errors.ConnectionFailed.Match(err), "%+v", err)
+	})
+}
@@ -17,6 +17,7 @@ package dml
 import (
 	"bytes"
 	"context"
+	"time"
 
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/log"
@@ -73,6 +74,7 @@ func NewDelete(from string) *Delete {
 func newDeleteFrom(db QueryExecPreparer, cCom *connCommon, from string) *Delete {
 	id := cCom.makeUniqueID()
 	l := cCom.Log
+	softDeleteColumn := cCom.softDeleteColumns[from]
 	from = cCom.mapTableName(from)
 	if l != nil {
 		l = l.With(log.String("delete_id", id), log.String("table", from))
@@ -80,11 +82,16 @@ func newDeleteFrom(db QueryExecPreparer, cCom *connCommon, from string) *Delete
 	return &Delete{
 		BuilderBase: BuilderBase{
 			builderCommon: builderCommon{
-				id:  id,
-				Log: l,
-				DB:  db,
+				id:         id,
+				Log:        l,
+				DB:         db,
+				tracer:     cCom.tracer,
+				stats:      cCom.stats,
+				timeFmt:    cCom.timeFmt,
+				commenters: cCom.commenters,
 			},
-			Table: MakeIdentifier(from),
+			Table:            MakeIdentifier(from),
+			softDeleteColumn: softDeleteColumn,
 		},
 		BuilderConditional: BuilderConditional{
 			Wheres: make(Conditions, 0, 2),
@@ -174,6 +181,24 @@ func (b *Delete) WithDB(db QueryExecPreparer) *Delete {
 	return b
 }
 
+// WithTimeout sets a timeout for each query execution of the Artisan created by
+// WithArgs. The context passed to the Artisan gets wrapped with a deadline.
+// The optimizer hint MAX_EXECUTION_TIME does not get written because MySQL
+// supports it only for SELECT statements.
+func (b *Delete) WithTimeout(d time.Duration) *Delete {
+	b.timeout = d
+	return b
+}
+
+// Unscoped disables the soft delete mode for this statement, see
+// WithSoftDelete. The rows get removed physically instead of setting the soft
+// delete column. This function must be called before generating the SQL
+// string.
+func (b *Delete) Unscoped() *Delete {
+	b.softDeleteColumn = ""
+	return b
+}
+
 // Unsafe see BuilderBase.IsUnsafe which weakens security when building the SQL
 // string. This function must be called before calling any other function.
 func (b *Delete) Unsafe() *Delete {
@@ -228,6 +253,66 @@ func (b *Delete) WithArgs() *Artisan {
 	return b.withArtisan(b)
 }
 
+// ExecChunked executes the DELETE statement repeatedly with a LIMIT of
+// chunkSize until the number of affected rows drops below chunkSize. This
+// avoids long running locks when purging huge tables. After each chunk the
+// optional progressFn gets called with the chunk number (starting at 1), the
+// affected rows of the current chunk and the total affected rows. Returning an
+// error from progressFn stops the execution. Argument sleep defines the pause
+// between two chunks and honors the cancellation of the context. ExecChunked
+// sets the LIMIT of the Delete object and must be called before the SQL string
+// gets cached. Multi table deletes are not supported because MySQL does not
+// allow a LIMIT clause for them. The optional args get passed to each
+// execution.
+func (b *Delete) ExecChunked(ctx context.Context, chunkSize uint64, sleep time.Duration, progressFn func(chunk int, rowsAffected, totalRowsAffected int64) error, args ...interface{}) (totalRowsAffected int64, err error) {
+	if b.Log != nil && b.Log.IsDebug() {
+		defer log.WhenDone(b.Log).Debug("ExecChunked", log.String("id", b.id), log.Uint64("chunk_size", chunkSize), log.Err(err))
+	}
+	switch {
+	case chunkSize == 0:
+		return 0, errors.NotValid.Newf("[dml] Delete.ExecChunked: chunkSize cannot be zero")
+	case len(b.MultiTables) > 0:
+		return 0, errors.NotSupported.Newf("[dml] Delete.ExecChunked: LIMIT not supported for multi table deletes")
+	}
+
+	a := b.Limit(chunkSize).WithArgs()
+	for chunk := 1; ; chunk++ {
+		res, err := a.ExecContext(ctx, args...)
+		if err != nil {
+			return totalRowsAffected, errors.WithStack(err)
+		}
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return totalRowsAffected, errors.WithStack(err)
+		}
+		totalRowsAffected += rowsAffected
+		if progressFn != nil {
+			if err := progressFn(chunk, rowsAffected, totalRowsAffected); err != nil {
+				return totalRowsAffected, errors.WithStack(err)
+			}
+		}
+		if rowsAffected < int64(chunkSize) {
+			return totalRowsAffected, nil
+		}
+		if sleep > 0 {
+			t := time.NewTimer(sleep)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return totalRowsAffected, errors.WithStack(ctx.Err())
+			case <-t.C:
+			}
+		}
+	}
+}
+
+func (b *Delete) afterExecListener() func() error {
+	if len(b.Listeners) == 0 {
+		return nil
+	}
+	return func() error { return b.Listeners.dispatch(OnAfterExec, b) }
+}
+
 // ToSQL generates the SQL string and might caches it internally, if not
 // disabled. The returned interface slice is always nil.
 func (b *Delete) ToSQL() (string, []interface{}, error) {
@@ -268,6 +353,9 @@ func (b *Delete) toSQL(w *bytes.Buffer, placeHolders []string) (_ []string, err
 	if b.Table.Name == "" {
 		return nil, errors.Empty.Newf("[dml] Delete: Table is missing")
 	}
+	if b.softDeleteColumn != "" {
+		return b.softDeleteToSQL(w, placeHolders)
+	}
 
 	w.WriteString("DELETE ")
 	writeStmtID(w, b.id)
@@ -333,6 +421,38 @@ func (b *Delete) toSQL(w *bytes.Buffer, placeHolders []string) (_ []string, err
 	return placeHolders, nil
 }
 
+// softDeleteToSQL rewrites the DELETE statement into an UPDATE which sets the
+// soft delete column to the current time. Only already soft deleted rows are
+// excluded.
+func (b *Delete) softDeleteToSQL(w *bytes.Buffer, placeHolders []string) (_ []string, err error) {
+	switch {
+	case len(b.MultiTables) > 0:
+		return nil, errors.NotSupported.Newf("[dml] Delete: Soft delete mode does not support multi-table DELETEs for table %q", b.Table.Name)
+	case len(b.Joins) > 0:
+		return nil, errors.NotSupported.Newf("[dml] Delete: Soft delete mode does not support JOINs for table %q", b.Table.Name)
+	case b.Returning != nil:
+		return nil, errors.NotSupported.Newf("[dml] Delete: Soft delete mode does not support RETURNING for table %q", b.Table.Name)
+	}
+
+	w.WriteString("UPDATE ")
+	writeStmtID(w, b.id)
+	if placeHolders, err = b.Table.writeQuoted(w, placeHolders); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	w.WriteString(" SET ")
+	Quoter.quote(w, b.softDeleteColumn)
+	w.WriteString("=NOW()")
+
+	wheres := softDeleteWheres(b.Wheres, b.Table.qualifier(), b.softDeleteColumn)
+	if placeHolders, err = wheres.write(w, 'w', placeHolders); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	sqlWriteOrderBy(w, b.OrderBys, false)
+	sqlWriteLimitOffset(w, b.LimitValid, false, 0, b.LimitCount)
+	return placeHolders, nil
+}
+
 // Prepare executes the statement represented by the Delete to create a prepared
 // statement. It returns a custom statement type or an error if there was one.
 // Provided arguments or records in the Delete are getting ignored. The provided
@@ -355,5 +475,6 @@ func (b *Delete) Clone() *Delete {
 	c.BuilderConditional = b.BuilderConditional.Clone()
 	c.MultiTables = b.MultiTables.Clone()
 	c.Returning = b.Returning.Clone()
+	c.Listeners = b.Listeners.Clone()
 	return &c
 }
@@ -17,6 +17,7 @@ package dml_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/corestoreio/errors"
@@ -304,3 +305,51 @@ func TestDelete_Clone(t *testing.T) {
 
 	})
 }
+
+func TestDelete_ExecChunked(t *testing.T) {
+	t.Parallel()
+
+	t.Run("three chunks", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		const wantSQL = "DELETE FROM `dml_person` WHERE (`store_id` = 3) LIMIT 2"
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta(wantSQL)).WillReturnResult(sqlmock.NewResult(0, 2))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta(wantSQL)).WillReturnResult(sqlmock.NewResult(0, 2))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta(wantSQL)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		var chunks []int64
+		total, err := dml.NewDelete("dml_person").
+			Where(dml.Column("store_id").Int(3)).
+			WithDB(dbc.DB).
+			ExecChunked(context.TODO(), 2, time.Millisecond, func(chunk int, rowsAffected, totalRowsAffected int64) error {
+				assert.Exactly(t, len(chunks)+1, chunk)
+				chunks = append(chunks, rowsAffected)
+				return nil
+			})
+		assert.NoError(t, err)
+		assert.Exactly(t, int64(5), total)
+		assert.Exactly(t, []int64{2, 2, 1}, chunks)
+	})
+
+	t.Run("progress error stops", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `dml_person` LIMIT 10")).WillReturnResult(sqlmock.NewResult(0, 10))
+
+		total, err := dml.NewDelete("dml_person").
+			WithDB(dbc.DB).
+			ExecChunked(context.TODO(), 10, 0, func(chunk int, rowsAffected, totalRowsAffected int64) error {
+				return errors.Aborted.Newf("Stop it")
+			})
+		assert.True(t, errors.Aborted.Match(err), "%+v", err)
+		assert.Exactly(t, int64(10), total)
+	})
+
+	t.Run("chunkSize zero", func(t *testing.T) {
+		total, err := dml.NewDelete("dml_person").ExecChunked(context.TODO(), 0, 0, nil)
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+		assert.Exactly(t, int64(0), total)
+	})
+}
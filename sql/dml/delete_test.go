@@ -16,6 +16,7 @@ package dml
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -317,3 +318,29 @@ func TestDelete_Bind(t *testing.T) {
 		)
 	})
 }
+
+type deadlineDBMock struct {
+	dbMock
+	ctx *context.Context
+}
+
+func (dm deadlineDBMock) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	*dm.ctx = ctx
+	return nil, nil
+}
+
+func TestDelete_WithTimeout(t *testing.T) {
+	t.Parallel()
+
+	var execCtx context.Context
+	_, err := NewDelete("a").Where(Column("b").Int(1)).
+		WithDB(deadlineDBMock{ctx: &execCtx}).
+		WithTimeout(time.Minute).
+		WithArgs().ExecContext(context.TODO())
+	assert.NoError(t, err)
+
+	dl, ok := execCtx.Deadline()
+	assert.True(t, ok, "Context should have a deadline")
+	assert.True(t, time.Until(dl) <= time.Minute, "Deadline %s too far in the future", dl)
+	assert.Exactly(t, context.Canceled, execCtx.Err(), "Context must get canceled after the execution")
+}
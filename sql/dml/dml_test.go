@@ -108,10 +108,12 @@ type dmlPersons struct {
 	Data []*dmlPerson
 }
 
+func (ps *dmlPersons) LastInsertIDColumn() string { return "id" }
+
 // MapColumns gets called in the `for rows.Next()` loop each time in case of IsNew
 func (ps *dmlPersons) MapColumns(cm *ColumnMap) error {
 	switch m := cm.Mode(); m {
-	case ColumnMapEntityReadAll, ColumnMapEntityReadSet:
+	case ColumnMapEntityReadAll, ColumnMapEntityReadSet, ColumnMapLastInsertID:
 		for _, p := range ps.Data {
 			if err := p.MapColumns(cm); err != nil {
 				return errors.WithStack(err)
@@ -179,13 +181,6 @@ func (ps *dmlPersons) Emails(ret ...null.String) []null.String {
 	return ret
 }
 
-//func (ps *dmlPersons) AssignLastInsertID(uint64) error {
-//	// todo iterate and assign to the last item in the slice and assign
-//	// decremented IDs to the previous items in the slice.
-//	return nil
-//}
-//
-
 var _ ColumnMapper = (*nullTypedRecord)(nil)
 
 type nullTypedRecord struct {
@@ -31,6 +31,15 @@ func (et EventType) String() string {
 // List of possible dispatched events.
 const (
 	OnBeforeToSQL EventType = iota + 65
+	// OnAfterExec gets dispatched after an Artisan, created by WithArgs, has
+	// successfully executed the statement or query. For queries it gets
+	// dispatched by the Load* and Iterate* functions after all rows have been
+	// read and closed. QueryContext and QueryRowContext do not dispatch it
+	// because the caller reads the rows. The SQL string has already been built
+	// and might be cached, hence modifications of the builder have no effect.
+	// A listener must be safe for concurrent use if multiple Artisans of the
+	// same builder run in parallel.
+	OnAfterExec
 )
 
 // ListenerBucket a type for embedding into other structs to define events for
@@ -141,6 +150,16 @@ func makeSelectListen(idx int, sl Listen) selectListen {
 // ListenersSelect contains multiple select event listener
 type ListenersSelect []selectListen
 
+// Clone creates a new clone of the current object.
+func (se ListenersSelect) Clone() ListenersSelect {
+	if se == nil {
+		return nil
+	}
+	c := make(ListenersSelect, len(se))
+	copy(c, se)
+	return c
+}
+
 // Add adds multiple listener to the listener stack and transforms the listener
 // functions according to the configuration.
 func (se *ListenersSelect) Add(sls ...Listen) ListenersSelect {
@@ -225,6 +244,16 @@ func makeInsertListen(idx int, sl Listen) insertListen {
 // ListenersInsert contains multiple insert event listener
 type ListenersInsert []insertListen
 
+// Clone creates a new clone of the current object.
+func (se ListenersInsert) Clone() ListenersInsert {
+	if se == nil {
+		return nil
+	}
+	c := make(ListenersInsert, len(se))
+	copy(c, se)
+	return c
+}
+
 // Add adds multiple listener to the listener stack and transforms the listener
 // functions according to the configuration.
 func (se *ListenersInsert) Add(sls ...Listen) ListenersInsert {
@@ -307,6 +336,16 @@ func makeUpdateListen(idx int, sl Listen) updateListen {
 // ListenersUpdate contains multiple update event listener
 type ListenersUpdate []updateListen
 
+// Clone creates a new clone of the current object.
+func (se ListenersUpdate) Clone() ListenersUpdate {
+	if se == nil {
+		return nil
+	}
+	c := make(ListenersUpdate, len(se))
+	copy(c, se)
+	return c
+}
+
 // Add adds multiple listener to the listener stack and transforms the listener
 // functions according to the configuration.
 func (se *ListenersUpdate) Add(sls ...Listen) ListenersUpdate {
@@ -389,6 +428,16 @@ func makeDeleteListen(idx int, sl Listen) deleteListen {
 // ListenersDelete contains multiple delete event listener
 type ListenersDelete []deleteListen
 
+// Clone creates a new clone of the current object.
+func (se ListenersDelete) Clone() ListenersDelete {
+	if se == nil {
+		return nil
+	}
+	c := make(ListenersDelete, len(se))
+	copy(c, se)
+	return c
+}
+
 // Add adds multiple listener to the listener stack and transforms the listener
 // functions according to the configuration.
 func (se *ListenersDelete) Add(sls ...Listen) ListenersDelete {
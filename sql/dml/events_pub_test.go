@@ -0,0 +1,92 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestListeners_OnAfterExec(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	var events []string
+	lb := dml.MustNewListenerBucket(
+		dml.Listen{
+			Name:           "audit",
+			EventType:      dml.OnAfterExec,
+			ListenSelectFn: func(b *dml.Select) { events = append(events, "select "+b.Table.Name) },
+			ListenInsertFn: func(b *dml.Insert) { events = append(events, "insert "+b.Into) },
+			ListenUpdateFn: func(b *dml.Update) { events = append(events, "update "+b.Table.Name) },
+			ListenDeleteFn: func(b *dml.Delete) { events = append(events, "delete "+b.Table.Name) },
+		},
+		dml.Listen{
+			Name:           "tenant",
+			EventType:      dml.OnBeforeToSQL,
+			ListenSelectFn: func(b *dml.Select) { b.Where(dml.Column("tenant_id").Int(5)) },
+		},
+	)
+
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `name` FROM `customer` WHERE (`tenant_id` = 5)")).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow([]byte("Gopher")))
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `name` FROM `customer` WHERE (`tenant_id` = 5)")).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow([]byte("Gopher")).AddRow([]byte("Rustacean")))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT INTO `customer` (`name`) VALUES (?)")).
+		WithArgs("Gopher").WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("UPDATE `customer` SET `name`='Karl'")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `customer` WHERE (`id` = 1)")).
+		WillReturnError(errors.ConnectionFailed.Newf("Con failed"))
+
+	sel := dbc.SelectFrom("customer").AddColumns("name")
+	sel.Listeners = lb.Select
+	names, err := sel.WithArgs().LoadStrings(context.TODO(), nil)
+	assert.NoError(t, err)
+	assert.Exactly(t, []string{"Gopher"}, names)
+
+	// the event gets dispatched after all rows have been read.
+	err = sel.WithArgs().IterateSerial(context.TODO(), func(cm *dml.ColumnMap) error {
+		events = append(events, "row")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	ins := dbc.InsertInto("customer").AddColumns("name")
+	ins.Listeners = lb.Insert
+	_, err = ins.WithArgs().String("Gopher").ExecContext(context.TODO())
+	assert.NoError(t, err)
+
+	upd := dbc.Update("customer").Set(dml.Column("name").Str("Karl"))
+	upd.Listeners = lb.Update
+	_, err = upd.WithArgs().ExecContext(context.TODO())
+	assert.NoError(t, err)
+
+	del := dbc.DeleteFrom("customer").Where(dml.Column("id").Int(1))
+	del.Listeners = lb.Delete
+	_, err = del.WithArgs().ExecContext(context.TODO())
+	assert.True(t, errors.ConnectionFailed.Match(err), "%+v", err)
+
+	// delete does not dispatch because of the failed execution.
+	assert.Exactly(t, []string{"select customer", "row", "row", "select customer", "insert customer", "update customer"}, events)
+}
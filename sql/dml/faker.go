@@ -0,0 +1,143 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/corestoreio/pkg/storage/null"
+)
+
+// fakeWords contains the dictionary for the generated fake texts.
+var fakeWords = [...]string{
+	"alpha", "amber", "anchor", "apple", "arrow", "autumn", "bakery", "basket",
+	"breeze", "bridge", "candle", "canyon", "castle", "cedar", "cherry", "cloud",
+	"copper", "coral", "cotton", "crystal", "delta", "desert", "dolphin", "eagle",
+	"ember", "falcon", "forest", "garden", "glacier", "harbor", "hazel", "island",
+	"jasmine", "lantern", "lemon", "maple", "meadow", "mirror", "nectar", "ocean",
+	"olive", "orchid", "pepper", "pillow", "planet", "prairie", "quartz", "raven",
+	"river", "saddle", "silver", "spruce", "summit", "thunder", "timber", "tulip",
+	"valley", "velvet", "walnut", "willow", "winter", "yellow", "zephyr", "zinc",
+}
+
+// fakeMaxTextLen limits the length of fake texts for columns with a large
+// maximum length like text or blob.
+const fakeMaxTextLen = 255
+
+// FakeString returns a random sentence of dictionary words with at most maxLen
+// runes. A maxLen of zero or less applies an internal limit of 255 runes. Used
+// in the generated fixture code.
+func FakeString(r *rand.Rand, maxLen int) string {
+	if maxLen <= 0 || maxLen > fakeMaxTextLen {
+		maxLen = fakeMaxTextLen
+	}
+	var buf strings.Builder
+	for i, n := 0, r.Intn(12)+1; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(fakeWords[r.Intn(len(fakeWords))])
+	}
+	return fakeTruncate(buf.String(), maxLen)
+}
+
+// FakeName returns a random capitalized first and last name with at most
+// maxLen runes.
+func FakeName(r *rand.Rand, maxLen int) string {
+	first := fakeWords[r.Intn(len(fakeWords))]
+	last := fakeWords[r.Intn(len(fakeWords))]
+	return fakeTruncate(strings.Title(first)+" "+strings.Title(last), maxLen)
+}
+
+// FakeEmail returns a random email address of the domain example.com with at
+// most maxLen runes.
+func FakeEmail(r *rand.Rand, maxLen int) string {
+	var buf strings.Builder
+	buf.WriteString(fakeWords[r.Intn(len(fakeWords))])
+	buf.WriteByte('.')
+	buf.WriteString(fakeWords[r.Intn(len(fakeWords))])
+	buf.WriteByte(byte('0' + r.Intn(10)))
+	buf.WriteByte(byte('0' + r.Intn(10)))
+	buf.WriteString("@example.com")
+	return fakeTruncate(buf.String(), maxLen)
+}
+
+func fakeTruncate(s string, maxLen int) string {
+	if maxLen <= 0 || utf8.RuneCountInString(s) <= maxLen {
+		return s
+	}
+	for i := range s {
+		if maxLen == 0 {
+			return strings.TrimSpace(s[:i])
+		}
+		maxLen--
+	}
+	return s
+}
+
+// FakeBytes returns a random byte slice with at least one and at most maxLen
+// bytes. A maxLen of zero or less applies an internal limit of 255 bytes.
+func FakeBytes(r *rand.Rand, maxLen int) []byte {
+	if maxLen <= 0 || maxLen > fakeMaxTextLen {
+		maxLen = fakeMaxTextLen
+	}
+	b := make([]byte, r.Intn(maxLen)+1)
+	r.Read(b)
+	return b
+}
+
+// FakeInt64 returns a random integer in the closed interval [min,max].
+func FakeInt64(r *rand.Rand, min, max int64) int64 {
+	if min >= max {
+		return min
+	}
+	span := uint64(max - min)
+	if span == math.MaxUint64 {
+		return int64(r.Uint64())
+	}
+	return min + int64(r.Uint64()%(span+1))
+}
+
+// FakeUint64 returns a random unsigned integer in the closed interval [0,max].
+func FakeUint64(r *rand.Rand, max uint64) uint64 {
+	if max == math.MaxUint64 {
+		return r.Uint64()
+	}
+	return r.Uint64() % (max + 1)
+}
+
+// FakeTime returns a random time between the years 2000 and 2030 in UTC with
+// a precision of seconds, as MySQL stores it by default.
+func FakeTime(r *rand.Rand) time.Time {
+	const from, to = 946684800, 1893456000 // 2000-01-01 and 2030-01-01
+	return time.Unix(from+r.Int63n(to-from), 0).UTC()
+}
+
+// FakeDecimal returns a random and valid decimal which fits into the column
+// definition DECIMAL(precision,scale). The precision gets limited to 18
+// digits.
+func FakeDecimal(r *rand.Rand, precision, scale int) null.Decimal {
+	if precision <= 0 || precision > 18 {
+		precision = 18
+	}
+	if scale < 0 || scale > precision {
+		scale = 0
+	}
+	return null.MakeDecimalInt64(r.Int63n(int64(math.Pow10(precision))), int32(scale))
+}
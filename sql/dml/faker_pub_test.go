@@ -0,0 +1,69 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestFakeString(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(4711))
+	for i := 0; i < 100; i++ {
+		s := dml.FakeString(r, 10)
+		assert.True(t, utf8.RuneCountInString(s) <= 10, "%q", s)
+		assert.True(t, s != "", "Index %d", i)
+		assert.True(t, utf8.RuneCountInString(dml.FakeName(r, 5)) <= 5)
+		assert.True(t, utf8.RuneCountInString(dml.FakeEmail(r, 20)) <= 20)
+		assert.True(t, utf8.RuneCountInString(dml.FakeString(r, 0)) <= 255)
+	}
+	assert.Contains(t, dml.FakeEmail(r, 0), "@example.com")
+}
+
+func TestFakeSeed(t *testing.T) {
+	t.Parallel()
+	r1 := rand.New(rand.NewSource(33))
+	r2 := rand.New(rand.NewSource(33))
+	assert.Exactly(t, dml.FakeString(r1, 64), dml.FakeString(r2, 64))
+	assert.Exactly(t, dml.FakeTime(r1), dml.FakeTime(r2))
+	assert.Exactly(t, dml.FakeBytes(r1, 16), dml.FakeBytes(r2, 16))
+}
+
+func TestFakeNumbers(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		v := dml.FakeInt64(r, -128, 127)
+		assert.True(t, v >= -128 && v <= 127, "%d", v)
+		assert.True(t, dml.FakeUint64(r, 255) <= 255)
+		d := dml.FakeDecimal(r, 5, 2)
+		assert.True(t, d.Valid)
+		assert.Exactly(t, int32(2), d.Scale)
+		assert.True(t, d.Precision < 100000, "%d", d.Precision)
+		tm := dml.FakeTime(r)
+		assert.True(t, tm.Year() >= 2000 && tm.Year() < 2030, "%s", tm)
+		b := dml.FakeBytes(r, 8)
+		assert.True(t, len(b) >= 1 && len(b) <= 8, "%d", len(b))
+	}
+	assert.Exactly(t, int64(5), dml.FakeInt64(r, 5, 5))
+	_ = dml.FakeInt64(r, math.MinInt64, math.MaxInt64)
+	_ = dml.FakeUint64(r, math.MaxUint64)
+}
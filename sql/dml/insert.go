@@ -17,7 +17,9 @@ package dml
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/log"
@@ -54,6 +56,33 @@ type LastInsertIDAssigner interface {
 	AssignLastInsertID(int64)
 }
 
+// LastInsertIDMapper gets implemented by a ColumnMapper, usually a collection,
+// which wants to receive the IDs of a multi-row INSERT. After the INSERT, the
+// function MapColumns gets called in mode ColumnMapLastInsertID with a
+// ColumnMap which contains only the column returned by LastInsertIDColumn. Each
+// entity receives its ID within its `for cm.Next()` loop; all other ColumnMap
+// calls are no-ops in this mode. An empty column name or a MapColumns error
+// with kind errors.NotSupported skips the assignment.
+type LastInsertIDMapper interface {
+	ColumnMapper
+	LastInsertIDColumn() string
+}
+
+// OnDuplicateKeyQualifier defines the qualifier for a record which provides
+// the values for the place holders in the ON DUPLICATE KEY UPDATE part of an
+// INSERT statement. All other records provide the values for the VALUES part.
+//		ins.AddColumns("name", "email").
+//			AddOnDuplicateKey(Column("name").PlaceHolder()).WithArgs().
+//			Record("", persons).Record(OnDuplicateKeyQualifier, person)
+// Without such a qualified record the place holders in the ON DUPLICATE KEY
+// part are getting requested from the unqualified records. If the ON DUPLICATE
+// KEY part gets generated via OnDuplicateKey or AddOnDuplicateKeyExclude, the
+// qualified record turns each `col`=VALUES(`col`) into `col`=? and provides
+// the values for those columns, no explicit conditions are needed.
+//		ins.AddColumns("name", "email").OnDuplicateKey().WithArgs().
+//			Record("", persons).Record(OnDuplicateKeyQualifier, person)
+const OnDuplicateKeyQualifier = "on_duplicate_key"
+
 // Insert contains the clauses for an INSERT statement
 type Insert struct {
 	BuilderBase
@@ -111,9 +140,15 @@ type Insert struct {
 	// VALUES do not need to get build by default because mostly WithArgs gets
 	// called to build the VALUES part dynamically.
 	IsBuildValues bool
+	// BatchFlushInterval if greater zero, ExecFromChan flushes the current
+	// batch after this interval even if the batch size has not been reached.
+	BatchFlushInterval time.Duration
 	// Listeners allows to dispatch certain functions in different
 	// situations.
 	Listeners ListenersInsert
+	// odkGeneratedColumns contains the columns written as `col`=VALUES(`col`)
+	// by the last build. Survives the build cache, see WithArgs.
+	odkGeneratedColumns []string
 }
 
 // NewInsert creates a new Insert object.
@@ -135,9 +170,13 @@ func newInsertInto(db QueryExecPreparer, cCom *connCommon, into string) *Insert
 	return &Insert{
 		BuilderBase: BuilderBase{
 			builderCommon: builderCommon{
-				id:  id,
-				Log: l,
-				DB:  db,
+				id:         id,
+				Log:        l,
+				DB:         db,
+				tracer:     cCom.tracer,
+				stats:      cCom.stats,
+				timeFmt:    cCom.timeFmt,
+				commenters: cCom.commenters,
 			},
 		},
 		Into: into,
@@ -162,12 +201,39 @@ func (tx *Tx) InsertInto(into string) *Insert {
 	return newInsertInto(tx.DB, &tx.connCommon, into)
 }
 
+// ReplaceInto instantiates a REPLACE statement for the given table. Mapping
+// the table name is supported. See Insert.Replace.
+func (c *ConnPool) ReplaceInto(into string) *Insert {
+	return newInsertInto(c.DB, &c.connCommon, into).Replace()
+}
+
+// ReplaceInto instantiates a REPLACE statement for the given table. Mapping
+// the table name is supported. See Insert.Replace.
+func (c *Conn) ReplaceInto(into string) *Insert {
+	return newInsertInto(c.DB, &c.connCommon, into).Replace()
+}
+
+// ReplaceInto instantiates a REPLACE statement for the given table bound to a
+// transaction. Mapping the table name is supported. See Insert.Replace.
+func (tx *Tx) ReplaceInto(into string) *Insert {
+	return newInsertInto(tx.DB, &tx.connCommon, into).Replace()
+}
+
 // WithDB sets the database query object.
 func (b *Insert) WithDB(db QueryExecPreparer) *Insert {
 	b.DB = db
 	return b
 }
 
+// WithTimeout sets a timeout for each query execution of the Artisan created by
+// WithArgs. The context passed to the Artisan gets wrapped with a deadline.
+// The optimizer hint MAX_EXECUTION_TIME does not get written because MySQL
+// supports it only for SELECT statements.
+func (b *Insert) WithTimeout(d time.Duration) *Insert {
+	b.timeout = d
+	return b
+}
+
 // Ignore modifier enables errors that occur while executing the INSERT
 // statement are getting ignored. For example, without IGNORE, a row that
 // duplicates an existing UNIQUE index or PRIMARY KEY value in the table causes
@@ -183,6 +249,8 @@ func (b *Insert) Ignore() *Insert {
 // to INSERT IGNORE in the treatment of new rows that contain unique key values
 // that duplicate old rows: The new rows are used to replace the old rows rather
 // than being discarded.
+// REPLACE cannot be combined with an ON DUPLICATE KEY UPDATE clause. All
+// record and ColumnMapper features of INSERT are supported.
 // https://dev.mysql.com/doc/refman/5.7/en/replace.html
 func (b *Insert) Replace() *Insert {
 	b.IsReplace = true
@@ -303,9 +371,20 @@ func (b *Insert) WithArgs() *Artisan {
 	}
 	a.insertRowCount = uint(b.RowCount)
 	a.insertIsBuildValues = b.IsBuildValues
+	b.rwmu.RLock()
+	a.insertODKColumnPos = len(b.Columns)
+	a.insertODKColumns = b.odkGeneratedColumns
+	b.rwmu.RUnlock()
 	return a
 }
 
+func (b *Insert) afterExecListener() func() error {
+	if len(b.Listeners) == 0 {
+		return nil
+	}
+	return func() error { return b.Listeners.dispatch(OnAfterExec, b) }
+}
+
 // ToSQL serialized the Insert to a SQL string
 // It returns the string with placeholders and a slice of query arguments
 func (b *Insert) ToSQL() (string, []interface{}, error) {
@@ -351,6 +430,10 @@ func (b *Insert) toSQL(buf *bytes.Buffer, placeHolders []string) ([]string, erro
 		return nil, errors.Empty.Newf("[dml] Inserted table is missing")
 	}
 
+	if b.IsReplace && (len(b.OnDuplicateKeys) > 0 || b.IsOnDuplicateKey) {
+		return nil, errors.NotAllowed.Newf("[dml] REPLACE INTO %q does not support ON DUPLICATE KEY UPDATE", b.Into)
+	}
+
 	ior := "INSERT "
 	if b.IsReplace {
 		ior = "REPLACE "
@@ -447,28 +530,40 @@ func (b *Insert) toSQL(buf *bytes.Buffer, placeHolders []string) ([]string, erro
 }
 
 func (b *Insert) writeOnDuplicateKey(buf *bytes.Buffer, placeHolders []string) ([]string, error) {
+	odk := b.OnDuplicateKeys
+	b.odkGeneratedColumns = nil
 	if len(b.OnDuplicateKeyExclude) > 0 || b.IsOnDuplicateKey {
-		if len(b.OnDuplicateKeys) == 0 {
-			b.OnDuplicateKeys = append(b.OnDuplicateKeys, &Condition{})
+		// The generated columns get written first and are not stored in
+		// OnDuplicateKeys to keep the builder unchanged for the next build.
+		b.odkGeneratedColumns = b.generatedODKColumns()
+		if len(b.odkGeneratedColumns) > 0 || len(odk) == 0 {
+			odk = append(Conditions{&Condition{Columns: b.odkGeneratedColumns}}, odk...)
 		}
-	ColumnsLoop:
-		for _, c := range b.Columns {
-			// Wow two times a comparison with a slice. That costs a bit
-			// performance but a reliable way to avoid writing duplicate ON
-			// DUPLICATE KEY UPDATE sets. If there is something faster, write us.
-			if strInSlice(c, b.OnDuplicateKeyExclude) {
-				continue
-			}
-			for _, cnd := range b.OnDuplicateKeys {
-				if c == cnd.Left || strInSlice(c, cnd.Columns) {
-					continue ColumnsLoop
-				}
+	}
+
+	return odk.writeOnDuplicateKey(buf, placeHolders)
+}
+
+// generatedODKColumns returns the columns which get written as
+// `col`=VALUES(`col`) into the ON DUPLICATE KEY part.
+func (b *Insert) generatedODKColumns() []string {
+	var cols []string
+ColumnsLoop:
+	for _, c := range b.Columns {
+		// Wow two times a comparison with a slice. That costs a bit
+		// performance but a reliable way to avoid writing duplicate ON
+		// DUPLICATE KEY UPDATE sets. If there is something faster, write us.
+		if strInSlice(c, b.OnDuplicateKeyExclude) {
+			continue
+		}
+		for _, cnd := range b.OnDuplicateKeys {
+			if c == cnd.Left || strInSlice(c, cnd.Columns) {
+				continue ColumnsLoop
 			}
-			b.OnDuplicateKeys[0].Columns = append(b.OnDuplicateKeys[0].Columns, c)
 		}
+		cols = append(cols, c)
 	}
-
-	return b.OnDuplicateKeys.writeOnDuplicateKey(buf, placeHolders)
+	return cols
 }
 
 func strInSlice(search string, sl []string) bool {
@@ -503,5 +598,88 @@ func (b *Insert) Clone() *Insert {
 	c.OnDuplicateKeys = b.OnDuplicateKeys.Clone()
 	c.Select = b.Select.Clone()
 	c.Pairs = b.Pairs.Clone()
+	c.Listeners = b.Listeners.Clone()
 	return &c
 }
+
+// BatchResult contains the outcome of a single batch executed by
+// ExecFromChan.
+type BatchResult struct {
+	// Records contains the number of records in the batch.
+	Records int
+	Result  sql.Result
+	Err     error
+}
+
+// ExecFromChan reads the records from the channel and inserts them with a
+// multi-row VALUES statement once batchSize records have been collected or the
+// BatchFlushInterval has passed. The outcome of each batch gets sent to the
+// returned channel, which the caller must drain. The returned channel gets
+// closed after the records channel has been closed and the last batch has been
+// written or when the context gets canceled. Records which implement
+// LastInsertIDAssigner receive their ID. The columns must be set via
+// AddColumns or SetRecordPlaceHolderCount.
+func (b *Insert) ExecFromChan(ctx context.Context, records <-chan ColumnMapper, batchSize int) <-chan BatchResult {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	results := make(chan BatchResult)
+	go func() {
+		defer close(results)
+
+		var tick <-chan time.Time
+		if b.BatchFlushInterval > 0 {
+			t := time.NewTicker(b.BatchFlushInterval)
+			defer t.Stop()
+			tick = t.C
+		}
+
+		batch := make([]ColumnMapper, 0, batchSize)
+		flush := func() bool {
+			if len(batch) == 0 {
+				return true
+			}
+			a := b.WithArgs()
+			for _, rec := range batch {
+				a.Record("", rec)
+			}
+			res, err := a.ExecContext(ctx)
+			br := BatchResult{
+				Records: len(batch),
+				Result:  res,
+				Err:     errors.WithStack(err),
+			}
+			for i := range batch {
+				batch[i] = nil // remove pointers for GC
+			}
+			batch = batch[:0]
+			select {
+			case results <- br:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case rec, ok := <-records:
+				if !ok {
+					flush()
+					return
+				}
+				batch = append(batch, rec)
+				if len(batch) >= batchSize && !flush() {
+					return
+				}
+			case <-tick:
+				if !flush() {
+					return
+				}
+			}
+		}
+	}()
+	return results
+}
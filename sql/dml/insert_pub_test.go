@@ -119,6 +119,37 @@ func TestInsert_Bind(t *testing.T) {
 	})
 }
 
+func TestInsert_ReplaceInto(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	t.Run("with records", func(t *testing.T) {
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("REPLACE INTO `a` (`something_id`,`user_id`,`other`) VALUES (?,?,?),(?,?,?)")).
+			WithArgs(1, 88, false, 2, 99, true).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+
+		res, err := dbc.ReplaceInto("a").AddColumns("something_id", "user_id", "other").
+			WithArgs().Record("", someRecord{1, 88, false}).Record("", someRecord{2, 99, true}).
+			ExecContext(context.TODO())
+		assert.NoError(t, err)
+		ra, err := res.RowsAffected()
+		assert.NoError(t, err)
+		assert.Exactly(t, int64(4), ra)
+	})
+	t.Run("ON DUPLICATE KEY not allowed", func(t *testing.T) {
+		compareToSQL(t,
+			dbc.ReplaceInto("a").AddColumns("something_id", "user_id").
+				AddOnDuplicateKey(dml.Column("user_id").Values()).
+				WithArgs().Record("", someRecord{1, 88, false}),
+			errors.NotAllowed,
+			"",
+			"",
+		)
+	})
+}
+
 func TestInsert_Prepare(t *testing.T) {
 	t.Parallel()
 
@@ -397,3 +428,179 @@ func TestInsert_Clone(t *testing.T) {
 		notEqualPointers(t, i.OnDuplicateKeys, i2.OnDuplicateKeys)
 	})
 }
+
+type dmlPersonCollection struct {
+	Data []*dmlPerson
+}
+
+func (pc *dmlPersonCollection) LastInsertIDColumn() string { return "id" }
+
+func (pc *dmlPersonCollection) MapColumns(cm *dml.ColumnMap) error {
+	switch m := cm.Mode(); m {
+	case dml.ColumnMapEntityReadAll:
+		for _, p := range pc.Data {
+			if err := cm.String(&p.Name).NullString(&p.Email).Err(); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	case dml.ColumnMapEntityReadSet:
+		for _, p := range pc.Data {
+			if err := p.MapColumns(cm); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	case dml.ColumnMapLastInsertID:
+		for _, p := range pc.Data {
+			for cm.Next() {
+				switch c := cm.Column(); c {
+				case "id":
+					cm.Int64(&p.ID)
+				default:
+					return errors.NotFound.Newf("[dml_test] dmlPersonCollection Column %q not found", c)
+				}
+			}
+		}
+	case dml.ColumnMapScan:
+		if cm.Count == 0 {
+			pc.Data = pc.Data[:0]
+		}
+		p := new(dmlPerson)
+		if err := p.MapColumns(cm); err != nil {
+			return errors.WithStack(err)
+		}
+		pc.Data = append(pc.Data, p)
+	default:
+		return errors.NotSupported.Newf("[dml] Unknown Mode: %q", string(m))
+	}
+	return cm.Err()
+}
+
+func TestInsert_LastInsertID_Collection(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT INTO `dml_person` (`name`,`email`) VALUES (?,?),(?,?),(?,?)")).
+		WithArgs("Muffin Hat", "Muffin@Hat.head", "Marianne Phyllis Finch", "marianne@phyllis.finch", "Daphne Augusta Perry", "daphne@augusta.perry").
+		WillReturnResult(sqlmock.NewResult(21, 3))
+
+	persons := &dmlPersonCollection{
+		Data: []*dmlPerson{
+			{Name: "Muffin Hat", Email: null.MakeString("Muffin@Hat.head")},
+			{Name: "Marianne Phyllis Finch", Email: null.MakeString("marianne@phyllis.finch")},
+			{Name: "Daphne Augusta Perry", Email: null.MakeString("daphne@augusta.perry")},
+		},
+	}
+
+	_, err := dml.NewInsert("dml_person").AddColumns("name", "email").SetRowCount(len(persons.Data)).
+		WithDB(dbc.DB).WithArgs().Record("", persons).ExecContext(context.TODO())
+	assert.NoError(t, err)
+
+	for i, p := range persons.Data {
+		assert.Exactly(t, int64(21+i), p.ID, "Index %d", i)
+	}
+}
+
+func TestInsert_ExecFromChan(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT INTO `dml_person` (`name`,`email`) VALUES (?,?),(?,?)")).
+		WithArgs("Peter Gopher", "peter@gopher.go", "John Doe", "john@doe.go").
+		WillReturnResult(sqlmock.NewResult(4, 2))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT INTO `dml_person` (`name`,`email`) VALUES (?,?)")).
+		WithArgs("Jane Doe", "jane@doe.go").
+		WillReturnResult(sqlmock.NewResult(6, 1))
+
+	persons := []*dmlPerson{
+		{Name: "Peter Gopher", Email: null.MakeString("peter@gopher.go")},
+		{Name: "John Doe", Email: null.MakeString("john@doe.go")},
+		{Name: "Jane Doe", Email: null.MakeString("jane@doe.go")},
+	}
+	records := make(chan dml.ColumnMapper)
+	go func() {
+		for _, p := range persons {
+			records <- p
+		}
+		close(records)
+	}()
+
+	var haveRecords []int
+	for br := range dbc.InsertInto("dml_person").AddColumns("name", "email").ExecFromChan(context.TODO(), records, 2) {
+		assert.NoError(t, br.Err)
+		haveRecords = append(haveRecords, br.Records)
+	}
+	assert.Exactly(t, []int{2, 1}, haveRecords)
+	assert.Exactly(t, int64(4), persons[0].ID)
+	assert.Exactly(t, int64(5), persons[1].ID)
+	assert.Exactly(t, int64(6), persons[2].ID)
+}
+
+// dmlPersonReadAllCollection does not opt into mode ColumnMapLastInsertID.
+type dmlPersonReadAllCollection struct {
+	Data []*dmlPerson
+}
+
+func (pc *dmlPersonReadAllCollection) MapColumns(cm *dml.ColumnMap) error {
+	switch m := cm.Mode(); m {
+	case dml.ColumnMapEntityReadAll:
+		for _, p := range pc.Data {
+			cm.Int64(&p.ID).String(&p.Name).NullString(&p.Email)
+		}
+	case dml.ColumnMapEntityReadSet:
+		for _, p := range pc.Data {
+			if err := p.MapColumns(cm); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	default:
+		return errors.NotSupported.Newf("[dml] Unknown Mode: %q", string(m))
+	}
+	return cm.Err()
+}
+
+// dmlPersonLazyCollection opts in but does not support the mode.
+type dmlPersonLazyCollection struct {
+	dmlPersonReadAllCollection
+}
+
+func (pc *dmlPersonLazyCollection) LastInsertIDColumn() string { return "id" }
+
+func TestInsert_LastInsertID_Collection_Opt_In(t *testing.T) {
+	t.Parallel()
+
+	newPersons := func() []*dmlPerson {
+		return []*dmlPerson{
+			{ID: 3, Name: "Muffin Hat", Email: null.MakeString("Muffin@Hat.head")},
+			{ID: 4, Name: "Marianne Phyllis Finch", Email: null.MakeString("marianne@phyllis.finch")},
+		}
+	}
+	runTest := func(t *testing.T, rec dml.ColumnMapper) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT INTO `dml_person` (`id`,`name`,`email`) VALUES (?,?,?),(?,?,?)")).
+			WithArgs(int64(3), "Muffin Hat", "Muffin@Hat.head", int64(4), "Marianne Phyllis Finch", "marianne@phyllis.finch").
+			WillReturnResult(sqlmock.NewResult(21, 2))
+
+		_, err := dml.NewInsert("dml_person").AddColumns("id", "name", "email").SetRowCount(2).
+			WithDB(dbc.DB).WithArgs().Record("", rec).ExecContext(context.TODO())
+		assert.NoError(t, err)
+	}
+
+	t.Run("not opted in", func(t *testing.T) {
+		pc := &dmlPersonReadAllCollection{Data: newPersons()}
+		runTest(t, pc)
+		assert.Exactly(t, int64(3), pc.Data[0].ID)
+		assert.Exactly(t, int64(4), pc.Data[1].ID)
+	})
+	t.Run("mode not supported", func(t *testing.T) {
+		pc := &dmlPersonLazyCollection{dmlPersonReadAllCollection{Data: newPersons()}}
+		runTest(t, pc)
+		assert.Exactly(t, int64(3), pc.Data[0].ID)
+		assert.Exactly(t, int64(4), pc.Data[1].ID)
+	})
+}
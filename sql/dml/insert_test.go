@@ -770,3 +770,90 @@ func TestInsert_Expressions_In_Values(t *testing.T) {
 		)
 	})
 }
+
+func TestInsert_OnDuplicateKey_Record(t *testing.T) {
+	t.Parallel()
+
+	persons := &dmlPersons{
+		Data: []*dmlPerson{
+			{Name: "Muffin Hat", Email: null.MakeString("Muffin@Hat.head")},
+			{Name: "Marianne Phyllis Finch", Email: null.MakeString("marianne@phyllis.finch")},
+		},
+	}
+
+	t.Run("collection with qualified record", func(t *testing.T) {
+		ins := NewInsert("dml_person").AddColumns("name", "email").
+			AddOnDuplicateKey(Column("name").PlaceHolder(), Column("email").Values()).
+			WithArgs().
+			Record("", persons).
+			Record(OnDuplicateKeyQualifier, &dmlPerson{Name: "Daphne Augusta Perry"})
+
+		compareToSQL(t, ins, errors.NoKind,
+			"INSERT INTO `dml_person` (`name`,`email`) VALUES (?,?),(?,?) ON DUPLICATE KEY UPDATE `name`=?, `email`=VALUES(`email`)",
+			"INSERT INTO `dml_person` (`name`,`email`) VALUES ('Muffin Hat','Muffin@Hat.head'),('Marianne Phyllis Finch','marianne@phyllis.finch') ON DUPLICATE KEY UPDATE `name`='Daphne Augusta Perry', `email`=VALUES(`email`)",
+			"Muffin Hat", "Muffin@Hat.head", "Marianne Phyllis Finch", "marianne@phyllis.finch", "Daphne Augusta Perry",
+		)
+	})
+
+	t.Run("generated columns without conditions", func(t *testing.T) {
+		ins := NewInsert("dml_person").AddColumns("name", "email").
+			OnDuplicateKey().
+			WithArgs().
+			Record("", persons).
+			Record(OnDuplicateKeyQualifier, &dmlPerson{Name: "Daphne Augusta Perry", Email: null.MakeString("daphne@augusta.perry")})
+
+		compareToSQL(t, ins, errors.NoKind,
+			"INSERT INTO `dml_person` (`name`,`email`) VALUES (?,?),(?,?) ON DUPLICATE KEY UPDATE `name`=?, `email`=?",
+			"INSERT INTO `dml_person` (`name`,`email`) VALUES ('Muffin Hat','Muffin@Hat.head'),('Marianne Phyllis Finch','marianne@phyllis.finch') ON DUPLICATE KEY UPDATE `name`='Daphne Augusta Perry', `email`='daphne@augusta.perry'",
+			"Muffin Hat", "Muffin@Hat.head", "Marianne Phyllis Finch", "marianne@phyllis.finch", "Daphne Augusta Perry", "daphne@augusta.perry",
+		)
+	})
+
+	t.Run("generated columns with exclude and condition", func(t *testing.T) {
+		ins := NewInsert("dml_person").AddColumns("id", "name", "email").
+			AddOnDuplicateKeyExclude("id").
+			AddOnDuplicateKey(Column("key").PlaceHolder()).
+			WithArgs().
+			Record("", persons.Data[0]).
+			Record(OnDuplicateKeyQualifier, &dmlPerson{Name: "Daphne Augusta Perry", Key: null.MakeString("dap")})
+
+		compareToSQL(t, ins, errors.NoKind,
+			"INSERT INTO `dml_person` (`id`,`name`,`email`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `name`=?, `email`=?, `key`=?",
+			"INSERT INTO `dml_person` (`id`,`name`,`email`) VALUES (0,'Muffin Hat','Muffin@Hat.head') ON DUPLICATE KEY UPDATE `name`='Daphne Augusta Perry', `email`=NULL, `key`='dap'",
+			int64(0), "Muffin Hat", "Muffin@Hat.head", "Daphne Augusta Perry", nil, "dap",
+		)
+	})
+
+	t.Run("generated columns without qualified record", func(t *testing.T) {
+		ins := NewInsert("dml_person").AddColumns("name", "email").
+			OnDuplicateKey().
+			WithArgs().
+			Record("", persons.Data[0])
+
+		compareToSQL(t, ins, errors.NoKind,
+			"INSERT INTO `dml_person` (`name`,`email`) VALUES (?,?) ON DUPLICATE KEY UPDATE `name`=VALUES(`name`), `email`=VALUES(`email`)",
+			"INSERT INTO `dml_person` (`name`,`email`) VALUES ('Muffin Hat','Muffin@Hat.head') ON DUPLICATE KEY UPDATE `name`=VALUES(`name`), `email`=VALUES(`email`)",
+			"Muffin Hat", "Muffin@Hat.head",
+		)
+	})
+
+	t.Run("single record without qualifier", func(t *testing.T) {
+		ins := NewInsert("dml_person").AddColumns("name", "email").
+			AddOnDuplicateKey(Column("name").PlaceHolder()).
+			WithArgs().
+			Record("", persons.Data[0])
+
+		compareToSQL(t, ins, errors.NoKind,
+			"INSERT INTO `dml_person` (`name`,`email`) VALUES (?,?) ON DUPLICATE KEY UPDATE `name`=?",
+			"INSERT INTO `dml_person` (`name`,`email`) VALUES ('Muffin Hat','Muffin@Hat.head') ON DUPLICATE KEY UPDATE `name`='Muffin Hat'",
+			"Muffin Hat", "Muffin@Hat.head", "Muffin Hat",
+		)
+	})
+
+	t.Run("unknown qualifier", func(t *testing.T) {
+		ins := NewInsert("dml_person").AddColumns("name", "email").
+			WithArgs().
+			Record("xx", persons)
+		compareToSQL(t, ins, errors.Fatal, "", "")
+	})
+}
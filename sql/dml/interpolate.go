@@ -180,6 +180,8 @@ func (in *ip) NullFloat64(nv null.Float64) *ip     { in.args = in.args.add(nv);
 func (in *ip) NullFloat64s(nv ...null.Float64) *ip { in.args = in.args.add(nv); return in }
 func (in *ip) NullInt64(nv null.Int64) *ip         { in.args = in.args.add(nv); return in }
 func (in *ip) NullInt64s(nv ...null.Int64) *ip     { in.args = in.args.add(nv); return in }
+func (in *ip) NullUint64(nv null.Uint64) *ip       { in.args = in.args.add(nv); return in }
+func (in *ip) NullUint64s(nv ...null.Uint64) *ip   { in.args = in.args.add(nv); return in }
 func (in *ip) NullBool(nv null.Bool) *ip           { in.args = in.args.add(nv); return in }
 func (in *ip) NullBools(nv ...null.Bool) *ip       { in.args = in.args.add(nv); return in }
 func (in *ip) NullTime(nv null.Time) *ip           { in.args = in.args.add(nv); return in }
@@ -18,6 +18,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -289,6 +290,40 @@ func TestInterpolate_Int64(t *testing.T) {
 	})
 }
 
+func TestInterpolate_Uint64(t *testing.T) {
+	t.Parallel()
+
+	t.Run("greater than MaxInt64", func(t *testing.T) {
+		compareToSQL2(t,
+			Interpolate("SELECT * FROM x WHERE a = ? AND b IN ? AND c = ? AND d IN ?").
+				Uint64(math.MaxUint64).
+				Uint64s(math.MaxInt64, math.MaxInt64+1).
+				NullUint64(null.MakeUint64(math.MaxUint64)).
+				NullUint64s(null.MakeUint64(1), null.Uint64{}, null.MakeUint64(math.MaxUint64-1)),
+			errors.NoKind,
+			"SELECT * FROM x WHERE a = 18446744073709551615 AND b IN (9223372036854775807,9223372036854775808) AND c = 18446744073709551615 AND d IN (1,NULL,18446744073709551614)",
+		)
+	})
+	t.Run("BIGINT UNSIGNED column", func(t *testing.T) {
+		// entity_id is of type BIGINT UNSIGNED. Values greater than
+		// math.MaxInt64 must be sent as text to the server, otherwise the
+		// driver rejects them.
+		compareToSQL(t,
+			NewInsert("catalog_product_entity").AddColumns("entity_id", "sku").
+				WithArgs().Uint64(math.MaxUint64).String("SKU1").NullUint64(null.MakeUint64(math.MaxInt64)).String("SKU2"),
+			errors.NoKind,
+			"INSERT INTO `catalog_product_entity` (`entity_id`,`sku`) VALUES (?,?),(?,?)",
+			"INSERT INTO `catalog_product_entity` (`entity_id`,`sku`) VALUES (18446744073709551615,'SKU1'),(9223372036854775807,'SKU2')",
+			[]byte(`18446744073709551615`), "SKU1", int64(math.MaxInt64), "SKU2",
+		)
+	})
+	t.Run("iFaceToArgs", func(t *testing.T) {
+		args, err := iFaceToArgs(arguments{}, uint64(math.MaxUint64), uint(1))
+		assert.NoError(t, err)
+		assert.Exactly(t, []interface{}{[]byte(`18446744073709551615`), int64(1)}, args.Interfaces())
+	})
+}
+
 func TestInterpolate_Bools(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,309 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+	"unicode/utf8"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/storage/null"
+	"github.com/corestoreio/pkg/util/byteconv"
+)
+
+// This file contains the reflection free JSON helper functions used by the
+// code generated with package dmlgen.
+
+const jsonHex = "0123456789abcdef"
+
+// JSONAppendString appends the quoted and escaped string s to buf. Contrary to
+// encoding/json, the characters <, > and & are not getting escaped. Invalid
+// UTF-8 gets replaced with U+FFFD.
+func JSONAppendString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' {
+				i++
+				continue
+			}
+			buf = append(buf, s[start:i]...)
+			switch b {
+			case '"', '\\':
+				buf = append(buf, '\\', b)
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', jsonHex[b>>4], jsonHex[b&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		// U+2028 and U+2029 are valid JSON but break JavaScript.
+		if r == '\u2028' || r == '\u2029' {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, '\\', 'u', '2', '0', '2', jsonHex[r&0xf])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf = append(buf, s[start:]...)
+	return append(buf, '"')
+}
+
+// JSONAppendTime appends the time in RFC3339 format with nanoseconds, as
+// encoding/json does, to buf.
+func JSONAppendTime(buf []byte, t time.Time) []byte {
+	buf = append(buf, '"')
+	buf = t.AppendFormat(buf, time.RFC3339Nano)
+	return append(buf, '"')
+}
+
+// JSONAppendBytes appends the base64 encoded byte slice, as encoding/json does,
+// to buf. A nil slice gets written as null.
+func JSONAppendBytes(buf []byte, b []byte) []byte {
+	if b == nil {
+		return append(buf, "null"...)
+	}
+	buf = append(buf, '"')
+	l := len(buf)
+	n := base64.StdEncoding.EncodedLen(len(b))
+	for cap(buf)-l < n+1 {
+		buf = append(buf[:cap(buf)], 0)
+	}
+	buf = buf[:l+n]
+	base64.StdEncoding.Encode(buf[l:], b)
+	return append(buf, '"')
+}
+
+// JSONValue contains the raw JSON value of an object key. Its methods convert
+// the value to the requested type. A JSON null value returns the zero value
+// for non-null types.
+type JSONValue []byte
+
+// IsNull returns true if the value is the JSON null literal.
+func (jv JSONValue) IsNull() bool {
+	return string(jv) == "null"
+}
+
+// String decodes a JSON string.
+func (jv JSONValue) String() (string, error) {
+	if jv.IsNull() {
+		return "", nil
+	}
+	if len(jv) < 2 || jv[0] != '"' || jv[len(jv)-1] != '"' {
+		return "", errors.NotValid.Newf("[dml] JSONValue: Cannot decode %q into a string", []byte(jv))
+	}
+	if bytes.IndexByte(jv, '\\') < 0 {
+		return string(jv[1 : len(jv)-1]), nil // fast path without escaped characters
+	}
+	var s string
+	err := json.Unmarshal(jv, &s)
+	return s, errors.WithStack(err)
+}
+
+// NullString decodes a JSON string or null.
+func (jv JSONValue) NullString() (null.String, error) {
+	if jv.IsNull() {
+		return null.String{}, nil
+	}
+	s, err := jv.String()
+	return null.MakeString(s), err
+}
+
+// Int64 decodes a JSON number into an int64.
+func (jv JSONValue) Int64() (int64, error) {
+	if jv.IsNull() {
+		return 0, nil
+	}
+	v, _, err := byteconv.ParseInt(jv)
+	return v, errors.WithStack(err)
+}
+
+// NullInt64 decodes a JSON number or null.
+func (jv JSONValue) NullInt64() (null.Int64, error) {
+	if jv.IsNull() {
+		return null.Int64{}, nil
+	}
+	v, err := jv.Int64()
+	return null.MakeInt64(v), err
+}
+
+// Uint64 decodes a JSON number into an uint64.
+func (jv JSONValue) Uint64() (uint64, error) {
+	if jv.IsNull() {
+		return 0, nil
+	}
+	v, _, err := byteconv.ParseUint(jv, 10, 64)
+	return v, errors.WithStack(err)
+}
+
+// Float64 decodes a JSON number into a float64.
+func (jv JSONValue) Float64() (float64, error) {
+	if jv.IsNull() {
+		return 0, nil
+	}
+	v, _, err := byteconv.ParseFloat(jv)
+	return v, errors.WithStack(err)
+}
+
+// NullFloat64 decodes a JSON number or null.
+func (jv JSONValue) NullFloat64() (null.Float64, error) {
+	if jv.IsNull() {
+		return null.Float64{}, nil
+	}
+	v, err := jv.Float64()
+	return null.MakeFloat64(v), err
+}
+
+// Bool decodes the JSON literals true and false.
+func (jv JSONValue) Bool() (bool, error) {
+	switch string(jv) {
+	case "true":
+		return true, nil
+	case "false", "null":
+		return false, nil
+	}
+	return false, errors.NotValid.Newf("[dml] JSONValue: Cannot decode %q into a bool", []byte(jv))
+}
+
+// NullBool decodes the JSON literals true, false and null.
+func (jv JSONValue) NullBool() (null.Bool, error) {
+	if jv.IsNull() {
+		return null.Bool{}, nil
+	}
+	v, err := jv.Bool()
+	return null.MakeBool(v), err
+}
+
+// Time decodes a JSON string in RFC3339 format.
+func (jv JSONValue) Time() (time.Time, error) {
+	s, err := jv.String()
+	if err != nil || s == "" {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	return t, errors.WithStack(err)
+}
+
+// NullTime decodes a JSON string in RFC3339 format or null.
+func (jv JSONValue) NullTime() (null.Time, error) {
+	if jv.IsNull() {
+		return null.Time{}, nil
+	}
+	t, err := jv.Time()
+	return null.MakeTime(t), err
+}
+
+// Decimal decodes a JSON number, a quoted number or null.
+func (jv JSONValue) Decimal() (d null.Decimal, err error) {
+	if jv.IsNull() {
+		return d, nil
+	}
+	err = d.UnmarshalJSON(jv)
+	return d, err
+}
+
+// Bytes decodes a base64 encoded JSON string.
+func (jv JSONValue) Bytes() ([]byte, error) {
+	s, err := jv.String()
+	if err != nil || jv.IsNull() {
+		return nil, err
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	return b, errors.WithStack(err)
+}
+
+// Unmarshal decodes the value into v with encoding/json. It's the fallback for
+// custom types.
+func (jv JSONValue) Unmarshal(v interface{}) error {
+	return errors.WithStack(json.Unmarshal(jv, v))
+}
+
+// JSONUnmarshalObject iterates over the keys of the JSON object in data and
+// calls fn for each key with its raw value. Keys unknown to fn should be
+// ignored by fn. A JSON null as data does not call fn.
+func JSONUnmarshalObject(data []byte, fn func(key string, value JSONValue) error) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil {
+		return errors.WithStack(err)
+	} else if d, ok := t.(json.Delim); !ok || d != '{' {
+		return errors.NotValid.Newf("[dml] JSONUnmarshalObject: Expecting a JSON object but got %v", t)
+	}
+	var raw json.RawMessage
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		key, _ := t.(string)
+		raw = raw[:0]
+		if err := dec.Decode(&raw); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := fn(key, JSONValue(raw)); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	_, err := dec.Token() // closing }
+	return errors.WithStack(err)
+}
+
+// JSONUnmarshalArray iterates over the elements of the JSON array in data and
+// calls fn for each raw element.
+func JSONUnmarshalArray(data []byte, fn func(element []byte) error) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil {
+		return errors.WithStack(err)
+	} else if d, ok := t.(json.Delim); !ok || d != '[' {
+		return errors.NotValid.Newf("[dml] JSONUnmarshalArray: Expecting a JSON array but got %v", t)
+	}
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := fn(raw); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	_, err := dec.Token() // closing ]
+	return errors.WithStack(err)
+}
@@ -0,0 +1,167 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/storage/null"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestJSONAppendString(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"", "Gopher", "a\"b\\c", "\n\t\r\x01\x1f", "Håi wörld", " x ", "<&>"} {
+		have := dml.JSONAppendString([]byte(`x`), s)
+		assert.Exactly(t, byte('x'), have[0])
+		var back string
+		assert.NoError(t, json.Unmarshal(have[1:], &back), "%q", have)
+		assert.Exactly(t, s, back)
+	}
+	assert.Exactly(t, `"bad`+"\ufffd"+`utf"`, string(dml.JSONAppendString(nil, "bad\xffutf")))
+	assert.Exactly(t, `"`+"\ufffd\ufffd"+`"`, string(dml.JSONAppendString(nil, "\xfe\xff")))
+}
+
+func TestJSONAppendBytesTime(t *testing.T) {
+	t.Parallel()
+
+	for _, b := range [][]byte{nil, {}, []byte("Gopher"), make([]byte, 100)} {
+		want, err := json.Marshal(b)
+		assert.NoError(t, err)
+		assert.Exactly(t, string(want), string(dml.JSONAppendBytes(nil, b)))
+	}
+
+	tm := time.Date(2019, 3, 4, 5, 6, 7, 8, time.UTC)
+	want, err := json.Marshal(tm)
+	assert.NoError(t, err)
+	assert.Exactly(t, string(want), string(dml.JSONAppendTime(nil, tm)))
+}
+
+func TestJSONUnmarshalObject(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all types", func(t *testing.T) {
+		var (
+			s   string
+			ns  null.String
+			i64 int64
+			ni  null.Int64
+			u64 uint64
+			f64 float64
+			nf  null.Float64
+			b   bool
+			nb  null.Bool
+			tm  time.Time
+			nt  null.Time
+			d   null.Decimal
+			bt  []byte
+			obj map[string]int
+		)
+		err := dml.JSONUnmarshalObject([]byte(`{
+			"s": "G\"opher", "ns": null, "i64": -4711, "ni": 42, "u64": 18446744073709551615,
+			"f64": 3.14159, "nf": null, "b": true, "nb": false, "tm": "2019-03-04T05:06:07.000000008Z",
+			"nt": null, "d": "12.345", "bt": "R29waGVy", "obj": {"a": 1}, "unknown": [1, {"x": 2}]
+		}`), func(key string, value dml.JSONValue) (err error) {
+			switch key {
+			case "s":
+				s, err = value.String()
+			case "ns":
+				ns, err = value.NullString()
+			case "i64":
+				i64, err = value.Int64()
+			case "ni":
+				ni, err = value.NullInt64()
+			case "u64":
+				u64, err = value.Uint64()
+			case "f64":
+				f64, err = value.Float64()
+			case "nf":
+				nf, err = value.NullFloat64()
+			case "b":
+				b, err = value.Bool()
+			case "nb":
+				nb, err = value.NullBool()
+			case "tm":
+				tm, err = value.Time()
+			case "nt":
+				nt, err = value.NullTime()
+			case "d":
+				d, err = value.Decimal()
+			case "bt":
+				bt, err = value.Bytes()
+			case "obj":
+				err = value.Unmarshal(&obj)
+			}
+			return err
+		})
+		assert.NoError(t, err)
+		assert.Exactly(t, `G"opher`, s)
+		assert.Exactly(t, null.String{}, ns)
+		assert.Exactly(t, int64(-4711), i64)
+		assert.Exactly(t, null.MakeInt64(42), ni)
+		assert.Exactly(t, uint64(18446744073709551615), u64)
+		assert.Exactly(t, 3.14159, f64)
+		assert.Exactly(t, null.Float64{}, nf)
+		assert.True(t, b)
+		assert.Exactly(t, null.MakeBool(false), nb)
+		assert.Exactly(t, time.Date(2019, 3, 4, 5, 6, 7, 8, time.UTC), tm.UTC())
+		assert.Exactly(t, null.Time{}, nt)
+		assert.Exactly(t, "12.345", d.String())
+		assert.Exactly(t, []byte("Gopher"), bt)
+		assert.Exactly(t, map[string]int{"a": 1}, obj)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		err := dml.JSONUnmarshalObject([]byte(` null `), func(key string, value dml.JSONValue) error {
+			return errors.NotAllowed.Newf("Should not get called")
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("not an object", func(t *testing.T) {
+		err := dml.JSONUnmarshalObject([]byte(`[1,2]`), func(key string, value dml.JSONValue) error {
+			return nil
+		})
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+
+	t.Run("decoding error", func(t *testing.T) {
+		err := dml.JSONUnmarshalObject([]byte(`{"b":"yes"}`), func(key string, value dml.JSONValue) error {
+			_, err := value.Bool()
+			return err
+		})
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+}
+
+func TestJSONUnmarshalArray(t *testing.T) {
+	t.Parallel()
+
+	var elements []string
+	err := dml.JSONUnmarshalArray([]byte(`[{"a":1}, "b", null]`), func(element []byte) error {
+		elements = append(elements, string(element))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Exactly(t, []string{`{"a":1}`, `"b"`, `null`}, elements)
+
+	err = dml.JSONUnmarshalArray([]byte(`{"a":1}`), func(element []byte) error { return nil })
+	assert.True(t, errors.NotValid.Match(err), "%+v", err)
+}
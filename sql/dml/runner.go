@@ -37,6 +37,36 @@ type ColumnMapper interface {
 	MapColumns(rc *ColumnMap) error
 }
 
+// ResultSets maps each result set of a query, which returns multiple result
+// sets, to its own ColumnMapper. The first result set gets loaded into the
+// first ColumnMapper, the second into the second, etc. Surplus result sets are
+// getting ignored. For example one round trip can load a header and its
+// items:
+//		rs := dml.NewResultSets(header, items)
+//		_, err := conn.WithRawSQL("CALL load_order(?)").Int(4711).Load(ctx, rs)
+type ResultSets struct {
+	ColumnMappers []ColumnMapper
+	current       int
+}
+
+// NewResultSets creates a new ResultSets type for the ColumnMappers in the
+// order of the expected result sets.
+func NewResultSets(cms ...ColumnMapper) *ResultSets {
+	return &ResultSets{ColumnMappers: cms}
+}
+
+// MapColumns implements interface ColumnMapper.
+func (rs *ResultSets) MapColumns(cm *ColumnMap) error {
+	if cm.Mode() == ColumnMapNextResultSet {
+		rs.current++
+		return nil
+	}
+	if rs.current >= len(rs.ColumnMappers) {
+		return nil
+	}
+	return rs.ColumnMappers[rs.current].MapColumns(cm)
+}
+
 // ColumnMap takes care that the table/view/identifiers are getting properly
 // mapped to ColumnMapper interface. ColumnMap has two run modes either collect
 // arguments from a type for running a SQL query OR to convert the sql.RawBytes
@@ -70,6 +100,13 @@ type ColumnMap struct {
 	// between chainable API and too verbose error checking.
 	scanErr error
 	index   int // current column index
+	// isLastInsertID enables the mode ColumnMapLastInsertID. lastInsertID
+	// contains the next ID to assign to the column autoIncColumn of an entity.
+	isLastInsertID bool
+	lastInsertID   int64
+	autoIncColumn  string
+	// isNextResultSet enables the mode ColumnMapNextResultSet.
+	isNextResultSet bool
 }
 
 // NewColumnMap exported for testing reasons.
@@ -99,6 +136,20 @@ func (b *ColumnMap) reset() {
 	b.columnsLen = 0
 	b.scanErr = nil
 	b.index = 0
+	b.isLastInsertID = false
+	b.lastInsertID = 0
+	b.autoIncColumn = ""
+	b.isNextResultSet = false
+}
+
+// nextResultSet prepares the ColumnMap for scanning the next result set of a
+// query returning multiple result sets.
+func (b *ColumnMap) nextResultSet() {
+	b.initialized = false
+	b.HasRows = false
+	b.Count = 0
+	b.scanErr = nil
+	b.index = -1
 }
 
 func (b *ColumnMap) setColumns(cols []string) {
@@ -115,16 +166,28 @@ func (m columnMapMode) String() string {
 	return string(m)
 }
 
-// Those four constants represents the modes for ColumnMap.Mode. An upper case
+// Those six constants represents the modes for ColumnMap.Mode. An upper case
 // letter defines a collection and a lower case letter an entity.
 const (
 	ColumnMapEntityReadAll     columnMapMode = 'a'
 	ColumnMapEntityReadSet     columnMapMode = 'r'
 	ColumnMapCollectionReadSet columnMapMode = 'R'
 	ColumnMapScan              columnMapMode = 'S' // can be used for both
+	// ColumnMapLastInsertID gets set after an INSERT statement has been
+	// executed, only for records implementing LastInsertIDMapper. The
+	// ColumnMap contains only the auto increment column. An entity must call
+	// the appropriate function (e.g. Uint64, Int64, Int) for that column in
+	// its `for cm.Next()` loop to receive the generated ID. A collection must
+	// pass the ColumnMap to each of its entities in the order of insertion.
+	ColumnMapLastInsertID columnMapMode = 'l' // can be used for both
+	// ColumnMapNextResultSet signals that the current result set has been
+	// fully scanned and the next result set of the same query starts. A
+	// ColumnMapper can switch to e.g. another collection. The ColumnMap does
+	// not contain any values in this mode. See type ResultSets.
+	ColumnMapNextResultSet columnMapMode = 'n' // can be used for both
 )
 
-// Mode returns a status byte of four different states. These states are getting
+// Mode returns a status byte of six different states. These states are getting
 // used in the implementation of ColumnMapper. Each state represents a different
 // action while scanning from the query or collecting arguments. ColumnMapper
 // can be implemented by either a single type or a slice/map type. Slice or not
@@ -134,6 +197,12 @@ const (
 // ColumnMapCollectionReadSet and ColumnMapScan. See the examples. Documentation
 // needs to be written better.
 func (b *ColumnMap) Mode() (m columnMapMode) {
+	if b.isLastInsertID {
+		return ColumnMapLastInsertID
+	}
+	if b.isNextResultSet {
+		return ColumnMapNextResultSet
+	}
 	if b.scanArgs != nil {
 		return ColumnMapScan // assign the column values from the DB to the structs and create new structs in a slice.
 	}
@@ -235,6 +304,31 @@ func (s *scannedColumn) Scan(src interface{}) (err error) {
 	return err
 }
 
+// setLastInsertID enables the mode ColumnMapLastInsertID. The ColumnMap
+// contains only the auto increment column, so an entity receives the ID within
+// its `for cm.Next()` loop.
+func (b *ColumnMap) setLastInsertID(autoIncColumn string, id int64) {
+	b.isLastInsertID = true
+	b.autoIncColumn = autoIncColumn
+	b.lastInsertID = id
+	b.setColumns([]string{autoIncColumn})
+}
+
+// assignLastInsertID reports in mode ColumnMapLastInsertID whether the current
+// column is the auto increment column and hence the pointer receives the next
+// ID. All other calls of the typed functions are no-ops in this mode.
+func (b *ColumnMap) assignLastInsertID() bool {
+	return b.index >= 0 && b.index < b.columnsLen && b.columns[b.index] == b.autoIncColumn
+}
+
+// nextLastInsertID returns the current last insert ID and increments it for the
+// next entity.
+func (b *ColumnMap) nextLastInsertID() int64 {
+	id := b.lastInsertID
+	b.lastInsertID++
+	return id
+}
+
 func (b *ColumnMap) shouldCollectArgs() bool {
 	return len(b.scanArgs) == 0 && cap(b.arguments) > 0
 }
@@ -254,20 +348,7 @@ func (b *ColumnMap) Scan(r *sql.Rows) error {
 			return errors.WithStack(err)
 		}
 
-		b.setColumns(cols)
-		if cap(b.scanCol) >= b.columnsLen { // reuse from pool!
-			b.scanCol = b.scanCol[:b.columnsLen]
-			b.scanArgs = b.scanArgs[:b.columnsLen]
-		} else {
-			b.scanCol = make([]scannedColumn, b.columnsLen)
-			b.scanArgs = make([]interface{}, b.columnsLen)
-			for i := 0; i < b.columnsLen; i++ {
-				b.scanArgs[i] = &b.scanCol[i]
-			}
-		}
-		b.initialized = true
-		b.Count = 0
-		b.HasRows = true
+		b.initScanColumns(cols)
 	} else {
 		b.Count++
 	}
@@ -277,6 +358,44 @@ func (b *ColumnMap) Scan(r *sql.Rows) error {
 	return nil
 }
 
+// ScanValues assigns already decoded values of one row, for example from a
+// binlog rows event, instead of scanning them from sql.Rows. The values must be
+// of type int, int64, float32, float64, bool, []byte, string, time.Time or nil.
+// The columns get only applied with the first call.
+func (b *ColumnMap) ScanValues(columns []string, values []interface{}) error {
+	if lc, lv := len(columns), len(values); lc != lv {
+		return errors.Mismatch.Newf("[dml] ColumnMap.ScanValues: Length of columns (%d) and values (%d) must be equal", lc, lv)
+	}
+	if !b.initialized {
+		b.initScanColumns(columns)
+	} else {
+		b.Count++
+	}
+	for i, v := range values {
+		if err := b.scanCol[i].Scan(v); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+func (b *ColumnMap) initScanColumns(cols []string) {
+	b.setColumns(cols)
+	if cap(b.scanCol) >= b.columnsLen { // reuse from pool!
+		b.scanCol = b.scanCol[:b.columnsLen]
+		b.scanArgs = b.scanArgs[:b.columnsLen]
+	} else {
+		b.scanCol = make([]scannedColumn, b.columnsLen)
+		b.scanArgs = make([]interface{}, b.columnsLen)
+		for i := 0; i < b.columnsLen; i++ {
+			b.scanArgs[i] = &b.scanCol[i]
+		}
+	}
+	b.initialized = true
+	b.Count = 0
+	b.HasRows = true
+}
+
 // Err returns the delayed error from one of the scans and parsings. Function is
 // idempotent.
 func (b *ColumnMap) Err() error {
@@ -307,6 +426,9 @@ func (b *ColumnMap) Next() bool {
 // bool value stored in sql.RawBytes to the pointer. See the documentation for
 // function Scan.
 func (b *ColumnMap) Bool(ptr *bool) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -343,6 +465,9 @@ func (b *ColumnMap) Bool(ptr *bool) *ColumnMap {
 // bool value stored in sql.RawBytes to the pointer. See the documentation for
 // function Scan.
 func (b *ColumnMap) NullBool(ptr *null.Bool) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -389,6 +514,12 @@ func (b *ColumnMap) NullBool(ptr *null.Bool) *ColumnMap {
 // int value stored in sql.RawBytes to the pointer. See the documentation for
 // function Scan.
 func (b *ColumnMap) Int(ptr *int) *ColumnMap {
+	if b.isLastInsertID {
+		if b.assignLastInsertID() {
+			*ptr = int(b.nextLastInsertID())
+		}
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -419,6 +550,12 @@ func (b *ColumnMap) Int(ptr *int) *ColumnMap {
 // the int64 value stored in sql.RawBytes to the pointer. See the documentation
 // for function Scan.
 func (b *ColumnMap) Int64(ptr *int64) *ColumnMap {
+	if b.isLastInsertID {
+		if b.assignLastInsertID() {
+			*ptr = b.nextLastInsertID()
+		}
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -447,6 +584,12 @@ func (b *ColumnMap) Int64(ptr *int64) *ColumnMap {
 // assigns the int64 value stored in sql.RawBytes to the pointer. See the
 // documentation for function Scan.
 func (b *ColumnMap) NullInt64(ptr *null.Int64) *ColumnMap {
+	if b.isLastInsertID {
+		if b.assignLastInsertID() {
+			*ptr = null.MakeInt64(b.nextLastInsertID())
+		}
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -479,6 +622,9 @@ func (b *ColumnMap) NullInt64(ptr *null.Int64) *ColumnMap {
 // assigns the float64 value stored in sql.RawBytes to the pointer. See the
 // documentation for function Scan.
 func (b *ColumnMap) Float64(ptr *float64) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -507,11 +653,14 @@ func (b *ColumnMap) Float64(ptr *float64) *ColumnMap {
 // assigns the numeric value stored in sql.RawBytes to the pointer. See the
 // documentation for function Scan.
 func (b *ColumnMap) Decimal(ptr *null.Decimal) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
 	if b.shouldCollectArgs() {
-		if v := ptr.String(); ptr == nil || v == sqlStrNullUC {
+		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
 		} else {
-			b.arguments = b.arguments.add(v)
+			b.arguments = b.arguments.add(*ptr)
 		}
 		return b
 	}
@@ -532,10 +681,44 @@ func (b *ColumnMap) Decimal(ptr *null.Decimal) *ColumnMap {
 	return b
 }
 
+// Point reads a spatial POINT value and appends it to the arguments slice or
+// assigns the parsed value stored in sql.RawBytes to the pointer. The column
+// can contain the internal MySQL geometry format, WKB or WKT. See the
+// documentation for function Scan.
+func (b *ColumnMap) Point(ptr *Point) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
+	if b.shouldCollectArgs() {
+		if ptr == nil {
+			b.arguments = b.arguments.add(nil)
+		} else {
+			b.arguments = b.arguments.add(*ptr)
+		}
+		return b
+	}
+	if b.scanErr == nil {
+		switch v := b.scanCol[b.index]; v.field {
+		case 'y':
+			*ptr, b.scanErr = parsePoint(v.byte)
+		case 's':
+			*ptr, b.scanErr = ParsePointWKT(v.string)
+		case 'n':
+			*ptr = Point{}
+		default:
+			b.scanErr = errors.NotSupported.Newf("[dml] Column %q does not support field type: %q", b.Column(), v.field)
+		}
+	}
+	return b
+}
+
 // NullFloat64 reads a float64 value and appends it to the arguments slice or
 // assigns the float64 value stored in sql.RawBytes to the pointer. See the
 // documentation for function Scan.
 func (b *ColumnMap) NullFloat64(ptr *null.Float64) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -567,6 +750,12 @@ func (b *ColumnMap) NullFloat64(ptr *null.Float64) *ColumnMap {
 // uint value stored in sql.RawBytes to the pointer. See the documentation for
 // function Scan.
 func (b *ColumnMap) Uint(ptr *uint) *ColumnMap {
+	if b.isLastInsertID {
+		if b.assignLastInsertID() {
+			*ptr = uint(b.nextLastInsertID())
+		}
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -597,6 +786,9 @@ func (b *ColumnMap) Uint(ptr *uint) *ColumnMap {
 // the uint8 value stored in sql.RawBytes to the pointer. See the documentation
 // for function Scan.
 func (b *ColumnMap) Uint8(ptr *uint8) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -627,6 +819,12 @@ func (b *ColumnMap) Uint8(ptr *uint8) *ColumnMap {
 // the uint16 value stored in sql.RawBytes to the pointer. See the documentation
 // for function Scan.
 func (b *ColumnMap) Uint16(ptr *uint16) *ColumnMap {
+	if b.isLastInsertID {
+		if b.assignLastInsertID() {
+			*ptr = uint16(b.nextLastInsertID())
+		}
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -657,6 +855,12 @@ func (b *ColumnMap) Uint16(ptr *uint16) *ColumnMap {
 // the uint32 value stored in sql.RawBytes to the pointer. See the documentation
 // for function Scan.
 func (b *ColumnMap) Uint32(ptr *uint32) *ColumnMap {
+	if b.isLastInsertID {
+		if b.assignLastInsertID() {
+			*ptr = uint32(b.nextLastInsertID())
+		}
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -687,6 +891,12 @@ func (b *ColumnMap) Uint32(ptr *uint32) *ColumnMap {
 // the uint64 value stored in sql.RawBytes to the pointer. See the documentation
 // for function Scan.
 func (b *ColumnMap) Uint64(ptr *uint64) *ColumnMap {
+	if b.isLastInsertID {
+		if b.assignLastInsertID() {
+			*ptr = uint64(b.nextLastInsertID())
+		}
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -741,6 +951,9 @@ func (b *ColumnMap) Debug(w ioWriter) (err error) {
 // the []byte value stored in sql.RawBytes to the pointer. See the documentation
 // for function Scan.
 func (b *ColumnMap) Byte(ptr *[]byte) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -772,6 +985,9 @@ func (b *ColumnMap) Text(enc interface {
 	encoding.TextMarshaler
 	encoding.TextUnmarshaler
 }) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
 	if b.scanErr != nil {
 		return b
 	}
@@ -807,6 +1023,9 @@ func (b *ColumnMap) Binary(enc interface {
 	encoding.BinaryMarshaler
 	encoding.BinaryUnmarshaler
 }) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
 	if b.scanErr != nil {
 		return b
 	}
@@ -832,6 +1051,9 @@ func (b *ColumnMap) Binary(enc interface {
 // the string value stored in sql.RawBytes to the pointer. See the documentation
 // for function Scan.
 func (b *ColumnMap) String(ptr *string) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -866,6 +1088,9 @@ func (b *ColumnMap) String(ptr *string) *ColumnMap {
 // assigns the string value stored in sql.RawBytes to the pointer. See the
 // documentation for function Scan.
 func (b *ColumnMap) NullString(ptr *null.String) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -906,6 +1131,9 @@ func (b *ColumnMap) NullString(ptr *null.String) *ColumnMap {
 // the time.Time value stored in sql.RawBytes to the pointer. See the
 // documentation for function Scan. It supports all MySQL/MariaDB date/time types.
 func (b *ColumnMap) Time(ptr *time.Time) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -940,6 +1168,9 @@ func (b *ColumnMap) Time(ptr *time.Time) *ColumnMap {
 // the NullTime value stored in sql.RawBytes to the pointer. See the
 // documentation for function Scan.
 func (b *ColumnMap) NullTime(ptr *null.Time) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
 	if b.shouldCollectArgs() {
 		if ptr == nil {
 			b.arguments = b.arguments.add(nil)
@@ -971,6 +1202,136 @@ func (b *ColumnMap) NullTime(ptr *null.Time) *ColumnMap {
 	return b
 }
 
+// BoolPtr reads a nullable bool value and appends it to the arguments slice or
+// assigns the value stored in sql.RawBytes to the pointer. A NULL value sets
+// the pointer to nil. See the documentation for function Scan.
+func (b *ColumnMap) BoolPtr(ptr **bool) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
+	if b.shouldCollectArgs() {
+		if ptr == nil || *ptr == nil {
+			b.arguments = b.arguments.add(nil)
+		} else {
+			b.arguments = b.arguments.add(**ptr)
+		}
+		return b
+	}
+	var v null.Bool
+	if b.NullBool(&v); b.scanErr == nil {
+		*ptr = nil
+		if v.Valid {
+			*ptr = &v.Bool
+		}
+	}
+	return b
+}
+
+// Int64Ptr reads a nullable int64 value and appends it to the arguments slice
+// or assigns the value stored in sql.RawBytes to the pointer. A NULL value sets
+// the pointer to nil. See the documentation for function Scan.
+func (b *ColumnMap) Int64Ptr(ptr **int64) *ColumnMap {
+	if b.isLastInsertID && !b.assignLastInsertID() {
+		return b
+	}
+	if !b.isLastInsertID && b.shouldCollectArgs() {
+		if ptr == nil || *ptr == nil {
+			b.arguments = b.arguments.add(nil)
+		} else {
+			b.arguments = b.arguments.add(**ptr)
+		}
+		return b
+	}
+	var v null.Int64
+	if b.NullInt64(&v); b.scanErr == nil {
+		*ptr = nil
+		if v.Valid {
+			*ptr = &v.Int64
+		}
+	}
+	return b
+}
+
+// Float64Ptr reads a nullable float64 value and appends it to the arguments
+// slice or assigns the value stored in sql.RawBytes to the pointer. A NULL
+// value sets the pointer to nil. See the documentation for function Scan.
+func (b *ColumnMap) Float64Ptr(ptr **float64) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
+	if b.shouldCollectArgs() {
+		if ptr == nil || *ptr == nil {
+			b.arguments = b.arguments.add(nil)
+		} else {
+			b.arguments = b.arguments.add(**ptr)
+		}
+		return b
+	}
+	var v null.Float64
+	if b.NullFloat64(&v); b.scanErr == nil {
+		*ptr = nil
+		if v.Valid {
+			*ptr = &v.Float64
+		}
+	}
+	return b
+}
+
+// StringPtr reads a nullable string value and appends it to the arguments
+// slice or assigns the value stored in sql.RawBytes to the pointer. A NULL
+// value sets the pointer to nil. See the documentation for function Scan.
+func (b *ColumnMap) StringPtr(ptr **string) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
+	if b.shouldCollectArgs() {
+		if ptr == nil || *ptr == nil {
+			b.arguments = b.arguments.add(nil)
+		} else {
+			b.arguments = b.arguments.add(**ptr)
+		}
+		return b
+	}
+	var v null.String
+	if b.NullString(&v); b.scanErr == nil {
+		*ptr = nil
+		if v.Valid {
+			*ptr = &v.String
+		}
+	}
+	return b
+}
+
+// TimePtr reads a nullable time value and appends it to the arguments slice or
+// assigns the value stored in sql.RawBytes to the pointer. A NULL value sets
+// the pointer to nil. See the documentation for function Scan.
+func (b *ColumnMap) TimePtr(ptr **time.Time) *ColumnMap {
+	if b.isLastInsertID {
+		return b
+	}
+	if b.shouldCollectArgs() {
+		if ptr == nil || *ptr == nil {
+			b.arguments = b.arguments.add(nil)
+		} else {
+			b.arguments = b.arguments.add(**ptr)
+		}
+		return b
+	}
+	var v null.Time
+	if b.NullTime(&v); b.scanErr == nil {
+		*ptr = nil
+		if v.Valid {
+			*ptr = &v.Time
+		}
+	}
+	return b
+}
+
+// The slice functions (Uint64s, Strings, Times, etc.) append all values of a
+// column of a collection as a single argument, for example for an IN clause.
+// They are only supported in mode ColumnMapCollectionReadSet. Generated
+// collections call them for their unique columns.
+
 const columnMapErrMsgSlices = "[dml] ColumnMap.%s does only support mode ColumnMapCollectionReadSet"
 
 func (b *ColumnMap) addSlice(fnName string, slice interface{}) *ColumnMap {
@@ -993,3 +1354,59 @@ func (b *ColumnMap) Strings(values ...string) *ColumnMap {
 func (b *ColumnMap) NullStrings(values ...null.String) *ColumnMap {
 	return b.addSlice("NullStrings", values)
 }
+
+func (b *ColumnMap) Ints(values ...int) *ColumnMap {
+	return b.addSlice("Ints", values)
+}
+
+func (b *ColumnMap) Int64s(values ...int64) *ColumnMap {
+	return b.addSlice("Int64s", values)
+}
+
+func (b *ColumnMap) NullInt64s(values ...null.Int64) *ColumnMap {
+	return b.addSlice("NullInt64s", values)
+}
+
+func (b *ColumnMap) Uints(values ...uint) *ColumnMap {
+	return b.addSlice("Uints", values)
+}
+
+func (b *ColumnMap) NullUint64s(values ...null.Uint64) *ColumnMap {
+	return b.addSlice("NullUint64s", values)
+}
+
+func (b *ColumnMap) Float64s(values ...float64) *ColumnMap {
+	return b.addSlice("Float64s", values)
+}
+
+func (b *ColumnMap) NullFloat64s(values ...null.Float64) *ColumnMap {
+	return b.addSlice("NullFloat64s", values)
+}
+
+func (b *ColumnMap) Decimals(values ...null.Decimal) *ColumnMap {
+	return b.addSlice("Decimals", values)
+}
+
+func (b *ColumnMap) Points(values ...Point) *ColumnMap {
+	return b.addSlice("Points", values)
+}
+
+func (b *ColumnMap) Bools(values ...bool) *ColumnMap {
+	return b.addSlice("Bools", values)
+}
+
+func (b *ColumnMap) NullBools(values ...null.Bool) *ColumnMap {
+	return b.addSlice("NullBools", values)
+}
+
+func (b *ColumnMap) Bytes(values ...[]byte) *ColumnMap {
+	return b.addSlice("Bytes", values)
+}
+
+func (b *ColumnMap) Times(values ...time.Time) *ColumnMap {
+	return b.addSlice("Times", values)
+}
+
+func (b *ColumnMap) NullTimes(values ...null.Time) *ColumnMap {
+	return b.addSlice("NullTimes", values)
+}
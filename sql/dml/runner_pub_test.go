@@ -889,3 +889,96 @@ func TestColumnMap_Prepared(t *testing.T) {
 	))
 
 }
+
+func TestArtisan_Load_ResultSets(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("CALL `load_people`(?)")).WithArgs(3).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Alf"),
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "John").AddRow(3, "Karl"),
+			sqlmock.NewRows([]string{"id"}).AddRow(4),
+		)
+
+	header := &dmlPersonCollection{}
+	items := &dmlPersonCollection{}
+	rowCount, err := dbc.WithRawSQL("CALL `load_people`(?)").Int(3).
+		Load(context.TODO(), dml.NewResultSets(header, items))
+	assert.NoError(t, err)
+	assert.Exactly(t, uint64(4), rowCount, "Row count of all result sets")
+	assert.Len(t, header.Data, 1)
+	assert.Exactly(t, "Alf", header.Data[0].Name)
+	assert.Len(t, items.Data, 2)
+	assert.Exactly(t, "Karl", items.Data[1].Name)
+}
+
+type storeLocation struct {
+	Name     string
+	Location dml.Point
+	Center   dml.Point
+}
+
+func (sl *storeLocation) MapColumns(cm *dml.ColumnMap) error {
+	for cm.Next() {
+		switch c := cm.Column(); c {
+		case "name":
+			cm.String(&sl.Name)
+		case "location":
+			cm.Point(&sl.Location)
+		case "center":
+			cm.Point(&sl.Center)
+		default:
+			return errors.NotFound.Newf("[dml_test] Column %q not found", c)
+		}
+	}
+	return cm.Err()
+}
+
+func TestColumnMap_Point(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	p := dml.Point{SRID: 4326, X: 13.4, Y: 52.5, Valid: true}
+	pv, err := p.Value()
+	assert.NoError(t, err)
+
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `name`, `location`, ST_AsText(center) AS `center` FROM `stores`")).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "location", "center"}).AddRow("Berlin", pv, "POINT(1 2)"))
+
+	sl := new(storeLocation)
+	_, err = dbc.WithRawSQL("SELECT `name`, `location`, ST_AsText(center) AS `center` FROM `stores`").
+		Load(context.TODO(), sl)
+	assert.NoError(t, err)
+	assert.Exactly(t, "Berlin", sl.Name)
+	assert.Exactly(t, p, sl.Location)
+	assert.Exactly(t, dml.MakePoint(1, 2), sl.Center)
+}
+
+func TestColumnMap_ScanValues(t *testing.T) {
+	t.Parallel()
+
+	cols := []string{"id", "name", "email", "store_id", "created_at", "total_income"}
+	now := time.Date(2019, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	cm := dml.NewColumnMap(0)
+	assert.NoError(t, cm.ScanValues(cols, []interface{}{int64(3), "Gopher", nil, 2, now, 4.5}))
+	assert.Exactly(t, dml.ColumnMapScan, cm.Mode())
+
+	var p dmlPerson
+	assert.NoError(t, p.MapColumns(cm))
+	assert.Exactly(t, dmlPerson{ID: 3, Name: "Gopher", StoreID: 2, CreatedAt: now, TotalIncome: 4.5}, p)
+
+	assert.NoError(t, cm.ScanValues(cols, []interface{}{int64(4), []byte("Go"), "go@pher.io", 1, now, []byte("1.25")}))
+	assert.Exactly(t, uint64(1), cm.Count)
+	p = dmlPerson{}
+	assert.NoError(t, p.MapColumns(cm))
+	assert.Exactly(t, dmlPerson{ID: 4, Name: "Go", Email: null.MakeString("go@pher.io"), StoreID: 1, CreatedAt: now, TotalIncome: 1.25}, p)
+
+	err := cm.ScanValues(cols, []interface{}{1})
+	assert.True(t, errors.Mismatch.Match(err), "%+v", err)
+}
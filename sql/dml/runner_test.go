@@ -54,6 +54,124 @@ func TestColumnMap_Nil_Pointers(t *testing.T) {
 		cm.arguments.GoString())
 }
 
+func TestColumnMap_Pointers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("collect arguments", func(t *testing.T) {
+		var (
+			b   = true
+			i64 = int64(3)
+			f64 = 2.5
+			s   = "x"
+			tm  = now()
+			nb  *bool
+			ni  *int64
+		)
+		pb, pi64, pf64, ps, ptm := &b, &i64, &f64, &s, &tm
+		cm := NewColumnMap(10)
+		cm.BoolPtr(&pb).BoolPtr(&nb).BoolPtr(nil).
+			Int64Ptr(&pi64).Int64Ptr(&ni).
+			Float64Ptr(&pf64).StringPtr(&ps).TimePtr(&ptm)
+		assert.NoError(t, cm.Err())
+		assert.Exactly(t,
+			[]interface{}{true, nil, nil, int64(3), nil, 2.5, "x", tm},
+			cm.arguments.Interfaces())
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		cm := NewColumnMap(0, "SomeColumn")
+		cm.scanCol = make([]scannedColumn, 1)
+		cm.index = 0
+
+		cm.scanCol[0] = scannedColumn{field: 'i', int64: 4711}
+		var i64 *int64
+		assert.NoError(t, cm.Int64Ptr(&i64).Err())
+		assert.Exactly(t, int64(4711), *i64)
+
+		cm.scanCol[0] = scannedColumn{field: 's', string: "Gopher"}
+		var s *string
+		assert.NoError(t, cm.StringPtr(&s).Err())
+		assert.Exactly(t, "Gopher", *s)
+
+		cm.scanCol[0] = scannedColumn{field: 'n'}
+		assert.NoError(t, cm.Int64Ptr(&i64).StringPtr(&s).Err())
+		assert.Nil(t, i64)
+		assert.Nil(t, s)
+	})
+
+	t.Run("last insert ID", func(t *testing.T) {
+		cm := NewColumnMap(0)
+		cm.setLastInsertID("id", 11)
+		var i64 *int64
+		assert.NoError(t, cm.Int64Ptr(&i64).Err())
+		assert.Nil(t, i64, "not within the auto increment column")
+		assert.True(t, cm.Next())
+		assert.NoError(t, cm.Int64Ptr(&i64).Err())
+		assert.Exactly(t, int64(11), *i64)
+	})
+}
+
+func TestColumnMap_Slices(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1568376000, 0)
+	cm := NewColumnMap(14, "x")
+	assert.Exactly(t, ColumnMapCollectionReadSet, cm.Mode())
+	cm.Ints(1).Int64s(2).NullInt64s(null.MakeInt64(3)).Uints(4).NullUint64s(null.MakeUint64(5)).
+		Float64s(6.1).NullFloat64s(null.MakeFloat64(7.2)).Bools(true).NullBools(null.MakeBool(false)).
+		Bytes([]byte("8")).Times(now).NullTimes(null.MakeTime(now)).Decimals(null.MakeDecimalInt64(9, 0)).
+		Points(Point{X: 1, Y: 2, Valid: true})
+	assert.NoError(t, cm.Err())
+	assert.Len(t, cm.arguments, 14)
+	assert.Exactly(t, []bool{true}, cm.arguments[7].value)
+	assert.Exactly(t, []time.Time{now}, cm.arguments[10].value)
+
+	cm = NewColumnMap(1, "x", "y")
+	err := cm.Times(now).Err()
+	assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+}
+
+func TestColumnMap_LastInsertID(t *testing.T) {
+	t.Parallel()
+
+	persons := &dmlPersons{
+		Data: []*dmlPerson{
+			{Name: "Muffin Hat"},
+			{Name: "Marianne Phyllis Finch"},
+			{Name: "Daphne Augusta Perry"},
+		},
+	}
+	cm := NewColumnMap(0)
+	cm.setLastInsertID("id", 11)
+	assert.Exactly(t, ColumnMapLastInsertID, cm.Mode())
+	assert.NoError(t, persons.MapColumns(cm))
+
+	assert.Exactly(t, []uint64{11, 12, 13}, persons.IDs())
+	assert.Exactly(t, int64(14), cm.lastInsertID)
+
+	t.Run("only the auto increment column", func(t *testing.T) {
+		var i int
+		var i64 int64
+		var ni64 null.Int64
+		var u32 uint32
+		var s string
+		var b bool
+		cm.Int(&i).Int64(&i64).NullInt64(&ni64).Uint32(&u32).String(&s).Bool(&b)
+		assert.NoError(t, cm.Err())
+		assert.Exactly(t, 0, i)
+		assert.Exactly(t, int64(0), i64)
+		assert.Exactly(t, null.Int64{}, ni64)
+		assert.Exactly(t, uint32(0), u32)
+		assert.Exactly(t, int64(14), cm.lastInsertID)
+
+		for cm.Next() {
+			cm.String(&s).Bool(&b).Int64(&i64)
+		}
+		assert.Exactly(t, "", s)
+		assert.Exactly(t, int64(14), i64)
+	})
+}
+
 func TestScannedColumn_String(t *testing.T) {
 	t.Parallel()
 	sc := scannedColumn{
@@ -0,0 +1,282 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/storage/null"
+)
+
+// Scan creates a ColumnMapper for a pointer to a struct or a pointer to a
+// slice of structs (or struct pointers) via reflection. It is a convenient
+// fallback for types which do not implement the ColumnMapper interface, e.g.
+// in one-off queries, and is much slower than a generated MapColumns function.
+//
+// The column names get derived from the `db` struct tag. A tag `db:"-"`
+// ignores the field and untagged exported fields use the lower cased field
+// name. Embedded structs without a tag get flattened. The tag option
+// `autoincrement`, e.g. `db:"entity_id,autoincrement"`, marks the field which
+// receives the last insert ID. Unknown columns in a result set return an error
+// with kind errors.NotFound.
+//		var people []*Person
+//		_, err := dbc.WithRawSQL("SELECT * FROM person").Load(ctx, dml.Scan(&people))
+// The mode ColumnMapCollectionReadSet is not supported.
+func Scan(structOrSlice interface{}) ColumnMapper {
+	rv := reflect.ValueOf(structOrSlice)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return reflectMapper{err: errors.NotValid.Newf("[dml] Scan requires a non-nil pointer, got %T", structOrSlice)}
+	}
+	rv = rv.Elem()
+
+	rm := reflectMapper{value: rv}
+	typ := rv.Type()
+	if typ.Kind() == reflect.Slice {
+		rm.isSlice = true
+		typ = typ.Elem()
+		if typ.Kind() == reflect.Ptr {
+			rm.isElemPtr = true
+			typ = typ.Elem()
+		}
+	}
+	if typ.Kind() != reflect.Struct {
+		rm.err = errors.NotSupported.Newf("[dml] Scan requires a pointer to a struct or a slice of structs, got %T", structOrSlice)
+		return rm
+	}
+	rm.elemType = typ
+	rm.fields, rm.err = reflectStructFields(typ)
+	return rm
+}
+
+type reflectMapper struct {
+	value     reflect.Value
+	elemType  reflect.Type
+	isSlice   bool
+	isElemPtr bool
+	fields    *reflectFields
+	err       error
+}
+
+// reflectFields contains the cached field index paths of a struct type.
+type reflectFields struct {
+	columns []string         // in the order of the struct fields
+	byName  map[string][]int // column name => field index path
+	autoInc []int            // field index path of the auto increment field
+	// autoIncName column name of the auto increment field.
+	autoIncName string
+}
+
+var reflectFieldsCache sync.Map // reflect.Type => *reflectFields
+
+func reflectStructFields(typ reflect.Type) (*reflectFields, error) {
+	if rf, ok := reflectFieldsCache.Load(typ); ok {
+		return rf.(*reflectFields), nil
+	}
+	rf := &reflectFields{
+		byName: make(map[string][]int),
+	}
+	if err := rf.collect(typ, nil); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	reflectFieldsCache.Store(typ, rf)
+	return rf, nil
+}
+
+func (rf *reflectFields) collect(typ reflect.Type, parentIndex []int) error {
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		tag, hasTag := sf.Tag.Lookup("db")
+		if tag == "-" || (sf.PkgPath != "" && !sf.Anonymous) {
+			continue
+		}
+		index := make([]int, len(parentIndex)+1)
+		copy(index, parentIndex)
+		index[len(parentIndex)] = i
+
+		if sf.Anonymous && !hasTag && sf.Type.Kind() == reflect.Struct {
+			if err := rf.collect(sf.Type, index); err != nil {
+				return err
+			}
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		name := strings.ToLower(sf.Name)
+		if hasTag {
+			opts := strings.Split(tag, ",")
+			if opts[0] != "" {
+				name = opts[0]
+			}
+			for _, o := range opts[1:] {
+				if o == "autoincrement" {
+					rf.autoInc = index
+					rf.autoIncName = name
+				}
+			}
+		}
+		if _, ok := rf.byName[name]; ok {
+			return errors.Duplicated.Newf("[dml] Scan: Column %q has been defined twice in type %s", name, typ)
+		}
+		rf.byName[name] = index
+		rf.columns = append(rf.columns, name)
+	}
+	return nil
+}
+
+// MapColumns implements interface ColumnMapper.
+func (rm reflectMapper) MapColumns(cm *ColumnMap) error {
+	if rm.err != nil {
+		return rm.err
+	}
+	switch m := cm.Mode(); m {
+	case ColumnMapNextResultSet:
+		return nil
+	case ColumnMapScan:
+		if !rm.isSlice {
+			return rm.mapEntity(cm, rm.value)
+		}
+		if cm.Count == 0 {
+			rm.value.Set(rm.value.Slice(0, 0))
+		}
+		ev := reflect.New(rm.elemType)
+		if err := rm.mapEntity(cm, ev.Elem()); err != nil {
+			return errors.WithStack(err)
+		}
+		if !rm.isElemPtr {
+			ev = ev.Elem()
+		}
+		rm.value.Set(reflect.Append(rm.value, ev))
+		return nil
+	case ColumnMapEntityReadAll, ColumnMapEntityReadSet, ColumnMapLastInsertID:
+		if !rm.isSlice {
+			return rm.mapEntity(cm, rm.value)
+		}
+		for i := 0; i < rm.value.Len(); i++ {
+			ev := rm.value.Index(i)
+			if rm.isElemPtr {
+				ev = ev.Elem()
+			}
+			if err := rm.mapEntity(cm, ev); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		return nil
+	default:
+		return errors.NotSupported.Newf("[dml] Scan: Mode %q not supported for type %s", string(m), rm.value.Type())
+	}
+}
+
+// LastInsertIDColumn implements LastInsertIDMapper and returns the column of the
+// field tagged with option autoincrement, if any.
+func (rm reflectMapper) LastInsertIDColumn() string {
+	if rm.fields == nil {
+		return ""
+	}
+	return rm.fields.autoIncName
+}
+
+func (rm reflectMapper) mapEntity(cm *ColumnMap, ev reflect.Value) error {
+	switch cm.Mode() {
+	case ColumnMapEntityReadAll:
+		for _, c := range rm.fields.columns {
+			if err := reflectMapField(cm, ev.FieldByIndex(rm.fields.byName[c])); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		return cm.Err()
+	}
+	for cm.Next() {
+		c := cm.Column()
+		index, ok := rm.fields.byName[c]
+		if !ok {
+			return errors.NotFound.Newf("[dml] Scan: Column %q not found in type %s", c, rm.elemType)
+		}
+		if err := reflectMapField(cm, ev.FieldByIndex(index)); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return cm.Err()
+}
+
+// reflectMapField maps a single struct field to the appropriate ColumnMap
+// function. Integer and float types without a ColumnMap function get converted
+// via a temporary variable.
+func reflectMapField(cm *ColumnMap, fv reflect.Value) error {
+	switch p := fv.Addr().Interface().(type) {
+	case *bool:
+		cm.Bool(p)
+	case *null.Bool:
+		cm.NullBool(p)
+	case *int:
+		cm.Int(p)
+	case *int8:
+		v := int64(*p)
+		cm.Int64(&v)
+		*p = int8(v)
+	case *int16:
+		v := int64(*p)
+		cm.Int64(&v)
+		*p = int16(v)
+	case *int32:
+		v := int64(*p)
+		cm.Int64(&v)
+		*p = int32(v)
+	case *int64:
+		cm.Int64(p)
+	case *null.Int64:
+		cm.NullInt64(p)
+	case *uint:
+		cm.Uint(p)
+	case *uint8:
+		cm.Uint8(p)
+	case *uint16:
+		cm.Uint16(p)
+	case *uint32:
+		cm.Uint32(p)
+	case *uint64:
+		cm.Uint64(p)
+	case *float32:
+		v := float64(*p)
+		cm.Float64(&v)
+		*p = float32(v)
+	case *float64:
+		cm.Float64(p)
+	case *null.Float64:
+		cm.NullFloat64(p)
+	case *null.Decimal:
+		cm.Decimal(p)
+	case *string:
+		cm.String(p)
+	case *null.String:
+		cm.NullString(p)
+	case *[]byte:
+		cm.Byte(p)
+	case *time.Time:
+		cm.Time(p)
+	case *null.Time:
+		cm.NullTime(p)
+	case *Point:
+		cm.Point(p)
+	default:
+		return errors.NotSupported.Newf("[dml] Scan: Type %T of column %q not supported", p, cm.Column())
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/storage/null"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+type scanBase struct {
+	StoreID int16 `db:"store_id"`
+}
+
+type scanPerson struct {
+	scanBase
+	ID       int64 `db:"id,autoincrement"`
+	Name     string
+	Email    null.String `db:"email"`
+	Score    float32     `db:"score"`
+	Internal string      `db:"-"`
+	ignored  string
+}
+
+func TestScan_Load(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "name", "email", "store_id", "score"}).
+			AddRow(1, "Alf", "alf@melmac.univ", 3, 2.5).
+			AddRow(2, "John", nil, 4, 1.25)
+	}
+
+	t.Run("slice of pointers", func(t *testing.T) {
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT * FROM `person`")).WillReturnRows(rows())
+
+		people := []*scanPerson{{Name: "Old Entry"}}
+		rowCount, err := dbc.WithRawSQL("SELECT * FROM `person`").Load(context.TODO(), dml.Scan(&people))
+		assert.NoError(t, err)
+		assert.Exactly(t, uint64(2), rowCount)
+		assert.Exactly(t, []*scanPerson{
+			{scanBase: scanBase{StoreID: 3}, ID: 1, Name: "Alf", Email: null.MakeString("alf@melmac.univ"), Score: 2.5},
+			{scanBase: scanBase{StoreID: 4}, ID: 2, Name: "John", Score: 1.25},
+		}, people)
+	})
+	t.Run("slice of structs", func(t *testing.T) {
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT * FROM `person`")).WillReturnRows(rows())
+
+		var people []scanPerson
+		_, err := dbc.WithRawSQL("SELECT * FROM `person`").Load(context.TODO(), dml.Scan(&people))
+		assert.NoError(t, err)
+		assert.Len(t, people, 2)
+		assert.Exactly(t, "John", people[1].Name)
+	})
+	t.Run("struct", func(t *testing.T) {
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `id`, `name` FROM `person`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(5, "Karl"))
+
+		var p scanPerson
+		_, err := dbc.WithRawSQL("SELECT `id`, `name` FROM `person`").Load(context.TODO(), dml.Scan(&p))
+		assert.NoError(t, err)
+		assert.Exactly(t, scanPerson{ID: 5, Name: "Karl"}, p)
+	})
+	t.Run("column not found", func(t *testing.T) {
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `id`, `age` FROM `person`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "age"}).AddRow(5, 42))
+
+		var p scanPerson
+		_, err := dbc.WithRawSQL("SELECT `id`, `age` FROM `person`").Load(context.TODO(), dml.Scan(&p))
+		assert.True(t, errors.NotFound.Match(err), "%+v", err)
+	})
+	t.Run("invalid destination", func(t *testing.T) {
+		var p scanPerson
+		err := dml.Scan(p).MapColumns(dml.NewColumnMap(0))
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+
+		var i []int
+		err = dml.Scan(&i).MapColumns(dml.NewColumnMap(0))
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	})
+}
+
+func TestScan_Insert_LastInsertID(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT INTO `person` (`name`,`email`) VALUES (?,?),(?,?)")).
+		WithArgs("Muffin Hat", "Muffin@Hat.head", "Daphne Augusta Perry", nil).
+		WillReturnResult(sqlmock.NewResult(21, 2))
+
+	people := []*scanPerson{
+		{Name: "Muffin Hat", Email: null.MakeString("Muffin@Hat.head")},
+		{Name: "Daphne Augusta Perry"},
+	}
+
+	_, err := dml.NewInsert("person").AddColumns("name", "email").SetRowCount(len(people)).
+		WithDB(dbc.DB).WithArgs().Record("", dml.Scan(&people)).ExecContext(context.TODO())
+	assert.NoError(t, err)
+	assert.Exactly(t, int64(21), people[0].ID)
+	assert.Exactly(t, int64(22), people[1].ID)
+}
@@ -18,6 +18,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/log"
@@ -86,6 +88,7 @@ func NewSelectWithDerivedTable(subSelect *Select, aliasName string) *Select {
 
 func newSelect(db QueryExecPreparer, cCom *connCommon, from []string) *Select {
 	id := cCom.makeUniqueID()
+	softDeleteColumn := cCom.softDeleteColumns[from[0]]
 	from[0] = cCom.mapTableName(from[0])
 	l := cCom.Log
 	if l != nil {
@@ -94,11 +97,16 @@ func newSelect(db QueryExecPreparer, cCom *connCommon, from []string) *Select {
 	s := &Select{
 		BuilderBase: BuilderBase{
 			builderCommon: builderCommon{
-				id:  id,
-				Log: l,
-				DB:  db,
+				id:         id,
+				Log:        l,
+				DB:         db,
+				tracer:     cCom.tracer,
+				stats:      cCom.stats,
+				timeFmt:    cCom.timeFmt,
+				commenters: cCom.commenters,
 			},
-			Table: MakeIdentifier(from[0]),
+			Table:            MakeIdentifier(from[0]),
+			softDeleteColumn: softDeleteColumn,
 		},
 	}
 	if len(from) > 1 {
@@ -131,6 +139,24 @@ func (b *Select) WithDB(db QueryExecPreparer) *Select {
 	return b
 }
 
+// WithTimeout sets a timeout for each query execution of the Artisan created by
+// WithArgs. The context passed to the Artisan gets wrapped with a deadline.
+// Additionally the optimizer hint `/*+ MAX_EXECUTION_TIME(n) */` gets written,
+// so that MySQL >= 8 aborts the query on the server side. Other servers treat
+// the hint as a comment. The timeout gets rounded down to milliseconds.
+func (b *Select) WithTimeout(d time.Duration) *Select {
+	b.timeout = d
+	return b
+}
+
+// Unscoped disables the soft delete mode for this statement, see
+// WithSoftDelete. This function must be called before generating the SQL
+// string.
+func (b *Select) Unscoped() *Select {
+	b.softDeleteColumn = ""
+	return b
+}
+
 // Distinct marks the statement at a DISTINCT SELECT. It specifies removal of
 // duplicate rows from the result set.
 func (b *Select) Distinct() *Select {
@@ -414,6 +440,13 @@ func (b *Select) WithArgs() *Artisan {
 	return b.withArtisan(b)
 }
 
+func (b *Select) afterExecListener() func() error {
+	if len(b.Listeners) == 0 {
+		return nil
+	}
+	return func() error { return b.Listeners.dispatch(OnAfterExec, b) }
+}
+
 // ToSQL generates the SQL string and might caches it internally, if not
 // disabled.
 func (b *Select) ToSQL() (string, []interface{}, error) {
@@ -456,6 +489,11 @@ func (b *Select) toSQL(w *bytes.Buffer, placeHolders []string) (_ []string, err
 	}
 
 	w.WriteString("SELECT ")
+	if b.timeout >= time.Millisecond {
+		w.WriteString("/*+ MAX_EXECUTION_TIME(")
+		w.WriteString(strconv.FormatInt(int64(b.timeout/time.Millisecond), 10))
+		w.WriteString(") */ ")
+	}
 	writeStmtID(w, b.id)
 	if b.IsDistinct {
 		w.WriteString("DISTINCT ")
@@ -520,7 +558,8 @@ func (b *Select) toSQL(w *bytes.Buffer, placeHolders []string) (_ []string, err
 		}
 	}
 
-	if placeHolders, err = b.Wheres.write(w, 'w', placeHolders); err != nil {
+	wheres := softDeleteWheres(b.Wheres, b.Table.qualifier(), b.softDeleteColumn)
+	if placeHolders, err = wheres.write(w, 'w', placeHolders); err != nil {
 		return nil, errors.WithStack(err)
 	}
 
@@ -571,7 +610,8 @@ func (b *Select) Prepare(ctx context.Context) (*Stmt, error) {
 }
 
 // Clone creates a clone of the current object, leaving fields DB and Log
-// untouched.
+// untouched. Conditions, joins and listeners get deep copied, hence a base
+// query can be forked per request and modified in its own goroutine.
 func (b *Select) Clone() *Select {
 	if b == nil {
 		return nil
@@ -582,5 +622,6 @@ func (b *Select) Clone() *Select {
 	c.Columns = b.Columns.Clone()
 	c.GroupBys = b.GroupBys.Clone()
 	c.Havings = b.Havings.Clone()
+	c.Listeners = b.Listeners.Clone()
 	return &c
 }
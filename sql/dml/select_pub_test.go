@@ -657,6 +657,28 @@ func TestSelect_Clone(t *testing.T) {
 		assert.Exactly(t, s.DB, s2.DB)
 		assert.Exactly(t, s.Log, s2.Log)
 	})
+
+	t.Run("fork per goroutine", func(t *testing.T) {
+		base := dml.NewSelect("entity_id").From("catalog_product_entity").
+			Where(dml.Column("type_id").Str("simple"))
+		base.Wheres = append(make(dml.Conditions, 0, 10), base.Wheres...) // spare capacity provokes shared appends
+		base.Listeners.Add(dml.Listen{
+			Name:           "noop",
+			EventType:      dml.OnBeforeToSQL,
+			ListenSelectFn: func(*dml.Select) {},
+		})
+
+		const concurrencyLevel = 10
+		bgwork.Wait(concurrencyLevel, func(index int) {
+			s := base.Clone()
+			notEqualPointers(t, base.Listeners, s.Listeners)
+			s.Where(dml.Column("entity_id").Int(index))
+			assert.Exactly(t,
+				fmt.Sprintf("SELECT `entity_id` FROM `catalog_product_entity` WHERE (`type_id` = 'simple') AND (`entity_id` = %d)", index),
+				s.String())
+		})
+		assert.Exactly(t, "SELECT `entity_id` FROM `catalog_product_entity` WHERE (`type_id` = 'simple')", base.String())
+	})
 }
 
 func TestSelect_When_Unless(t *testing.T) {
@@ -686,3 +708,70 @@ func TestSelect_When_Unless(t *testing.T) {
 		assert.Exactly(t, "SELECT `entity_id` FROM `catalog_product_entity` WHERE (`sku` LIKE '4713')", s.String())
 	})
 }
+
+func TestSelect_LoadMaps(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	t.Run("LoadInt64Map", func(t *testing.T) {
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `entity_id`, `parent_id` FROM `catalog_category_entity`")).
+			WillReturnRows(sqlmock.NewRows([]string{"entity_id", "parent_id"}).AddRow(1, 0).AddRow(2, 1).AddRow(3, nil).AddRow(4, 2))
+
+		m, err := dbc.SelectFrom("catalog_category_entity").AddColumns("entity_id", "parent_id").WithArgs().LoadInt64Map(context.TODO())
+		assert.NoError(t, err)
+		assert.Exactly(t, map[int64]int64{1: 0, 2: 1, 4: 2}, m)
+	})
+	t.Run("LoadStringMap", func(t *testing.T) {
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `path`, `value` FROM `core_config_data`")).
+			WillReturnRows(sqlmock.NewRows([]string{"path", "value"}).AddRow([]byte("general/locale/code"), []byte("en_US")).AddRow([]byte("web/cookie"), nil))
+
+		m, err := dbc.SelectFrom("core_config_data").AddColumns("path", "value").WithArgs().LoadStringMap(context.TODO())
+		assert.NoError(t, err)
+		assert.Exactly(t, map[string]string{"general/locale/code": "en_US"}, m)
+	})
+	t.Run("LoadStringMap query error", func(t *testing.T) {
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `path`, `value` FROM `core_config_data`")).
+			WillReturnError(errors.ConnectionFailed.Newf("Con failed"))
+
+		m, err := dbc.SelectFrom("core_config_data").AddColumns("path", "value").WithArgs().LoadStringMap(context.TODO())
+		assert.True(t, errors.ConnectionFailed.Match(err), "%+v", err)
+		assert.Nil(t, m)
+	})
+}
+
+func TestSelect_LoadNullTypes(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	const sqlStr = "SELECT `is_active` FROM `customer_entity` WHERE (`entity_id` = ?)"
+	sel := dbc.SelectFrom("customer_entity").AddColumns("is_active").Where(dml.Column("entity_id").PlaceHolder())
+
+	t.Run("value", func(t *testing.T) {
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(sqlStr)).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active"}).AddRow(true))
+		nv, found, err := sel.WithArgs().LoadNullBool(context.TODO(), 1)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Exactly(t, null.MakeBool(true), nv)
+	})
+	t.Run("NULL value", func(t *testing.T) {
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(sqlStr)).WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active"}).AddRow(nil))
+		nv, found, err := sel.WithArgs().LoadNullBool(context.TODO(), 2)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.False(t, nv.Valid)
+	})
+	t.Run("no rows", func(t *testing.T) {
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(sqlStr)).WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active"}))
+		nv, found, err := sel.WithArgs().LoadNullBool(context.TODO(), 3)
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.Exactly(t, null.Bool{}, nv)
+	})
+}
@@ -17,6 +17,7 @@ package dml
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/log"
@@ -1581,3 +1582,18 @@ func TestSelect_Artisan_Load_Slices_Null(t *testing.T) {
 		assert.Exactly(t, []string{"A1", "A2", "-A3"}, vals)
 	})
 }
+
+func TestSelect_WithTimeout(t *testing.T) {
+	t.Parallel()
+
+	compareToSQL2(t,
+		NewSelect("a").From("b").Where(Column("c").Int(1)).WithTimeout(1500*time.Millisecond),
+		errors.NoKind,
+		"SELECT /*+ MAX_EXECUTION_TIME(1500) */ `a` FROM `b` WHERE (`c` = 1)",
+	)
+	compareToSQL2(t,
+		NewSelect("a").From("b").WithTimeout(time.Microsecond),
+		errors.NoKind,
+		"SELECT `a` FROM `b`",
+	)
+}
@@ -56,9 +56,13 @@ func (c *ConnPool) Show() *Show {
 	return &Show{
 		BuilderBase: BuilderBase{
 			builderCommon: builderCommon{
-				id:  id,
-				Log: l,
-				DB:  c.DB,
+				id:         id,
+				Log:        l,
+				DB:         c.DB,
+				tracer:     c.tracer,
+				stats:      c.stats,
+				timeFmt:    c.timeFmt,
+				commenters: c.commenters,
 			},
 		},
 	}
@@ -74,9 +78,13 @@ func (c *Conn) Show() *Show {
 	return &Show{
 		BuilderBase: BuilderBase{
 			builderCommon: builderCommon{
-				id:  id,
-				Log: l,
-				DB:  c.DB,
+				id:         id,
+				Log:        l,
+				DB:         c.DB,
+				tracer:     c.tracer,
+				stats:      c.stats,
+				timeFmt:    c.timeFmt,
+				commenters: c.commenters,
 			},
 		},
 	}
@@ -92,9 +100,13 @@ func (tx *Tx) Show() *Show {
 	return &Show{
 		BuilderBase: BuilderBase{
 			builderCommon: builderCommon{
-				id:  id,
-				Log: l,
-				DB:  tx.DB,
+				id:         id,
+				Log:        l,
+				DB:         tx.DB,
+				tracer:     tx.tracer,
+				stats:      tx.stats,
+				timeFmt:    tx.timeFmt,
+				commenters: tx.commenters,
 			},
 		},
 	}
@@ -0,0 +1,47 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+// softDeleteWheres returns a new Conditions slice which restricts the
+// statement to the rows not yet soft deleted. The provided wheres get wrapped
+// in parenthesis if they contain OR or XOR conditions, so the additional
+// condition applies to the whole WHERE clause. The provided slice does not get
+// modified. If column is empty, wheres gets returned unchanged.
+func softDeleteWheres(wheres Conditions, qualifier, column string) Conditions {
+	if column == "" {
+		return wheres
+	}
+	if qualifier != "" {
+		column = qualifier + "." + column
+	}
+
+	var needsParenthesis bool
+	for _, w := range wheres {
+		if w.Logical == logicalOr || w.Logical == logicalXor {
+			needsParenthesis = true
+			break
+		}
+	}
+
+	cs := make(Conditions, 0, len(wheres)+3)
+	if needsParenthesis {
+		cs = append(cs, ParenthesisOpen())
+	}
+	cs = append(cs, wheres...)
+	if needsParenthesis {
+		cs = append(cs, ParenthesisClose())
+	}
+	return append(cs, Column(column).Null())
+}
@@ -0,0 +1,95 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestWithSoftDelete(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t, dml.WithSoftDelete("deleted_at", "customer_entity"))
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	t.Run("Select", func(t *testing.T) {
+		compareToSQL(t, dbc.SelectFrom("customer_entity", "ce").Star().Where(dml.Column("email").PlaceHolder()), errors.NoKind,
+			"SELECT * FROM `customer_entity` AS `ce` WHERE (`email` = ?) AND (`ce`.`deleted_at` IS NULL)",
+			"",
+		)
+	})
+	t.Run("Select with OR", func(t *testing.T) {
+		compareToSQL(t, dbc.SelectFrom("customer_entity").Star().Where(
+			dml.Column("email").PlaceHolder(),
+			dml.Column("name").PlaceHolder().Or(),
+		), errors.NoKind,
+			"SELECT * FROM `customer_entity` WHERE ((`email` = ?) OR (`name` = ?)) AND (`customer_entity`.`deleted_at` IS NULL)",
+			"",
+		)
+	})
+	t.Run("Select unregistered table", func(t *testing.T) {
+		compareToSQL(t, dbc.SelectFrom("customer_address").Star(), errors.NoKind,
+			"SELECT * FROM `customer_address`",
+			"",
+		)
+	})
+	t.Run("Select Unscoped", func(t *testing.T) {
+		compareToSQL(t, dbc.SelectFrom("customer_entity").Star().Unscoped(), errors.NoKind,
+			"SELECT * FROM `customer_entity`",
+			"",
+		)
+	})
+	t.Run("Update", func(t *testing.T) {
+		compareToSQL(t, dbc.Update("customer_entity").AddColumns("name").Where(dml.Column("entity_id").PlaceHolder()), errors.NoKind,
+			"UPDATE `customer_entity` SET `name`=? WHERE (`entity_id` = ?) AND (`customer_entity`.`deleted_at` IS NULL)",
+			"",
+		)
+	})
+	t.Run("Delete rewritten to Update", func(t *testing.T) {
+		compareToSQL(t, dbc.DeleteFrom("customer_entity").Where(dml.Column("entity_id").PlaceHolder()).Limit(1), errors.NoKind,
+			"UPDATE `customer_entity` SET `deleted_at`=NOW() WHERE (`entity_id` = ?) AND (`customer_entity`.`deleted_at` IS NULL) LIMIT 1",
+			"",
+		)
+	})
+	t.Run("Delete Unscoped", func(t *testing.T) {
+		compareToSQL(t, dbc.DeleteFrom("customer_entity").Where(dml.Column("entity_id").PlaceHolder()).Unscoped(), errors.NoKind,
+			"DELETE FROM `customer_entity` WHERE (`entity_id` = ?)",
+			"",
+		)
+	})
+	t.Run("Delete with JOIN not supported", func(t *testing.T) {
+		del := dbc.DeleteFrom("customer_entity").
+			Join(dml.MakeIdentifier("customer_address").Alias("ca"), dml.Column("ca.parent_id").Equal().Column("entity_id"))
+		compareToSQL(t, del, errors.NotSupported, "", "")
+	})
+	t.Run("Delete exec", func(t *testing.T) {
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("UPDATE `customer_entity` SET `deleted_at`=NOW() WHERE (`entity_id` = ?) AND (`customer_entity`.`deleted_at` IS NULL)")).
+			WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		res, err := dbc.DeleteFrom("customer_entity").Where(dml.Column("entity_id").PlaceHolder()).
+			WithArgs().ExecContext(context.TODO(), 3)
+		assert.NoError(t, err)
+		ra, err := res.RowsAffected()
+		assert.NoError(t, err)
+		assert.Exactly(t, int64(1), ra)
+	})
+}
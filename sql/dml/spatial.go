@@ -0,0 +1,243 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/bufferpool"
+)
+
+const (
+	// wkbPointLen defines the length of a WKB encoded POINT: one byte for the
+	// byte order, four bytes for the geometry type and two float64
+	// coordinates.
+	wkbPointLen = 21
+	// wkbTypePoint identifies a POINT in the WKB geometry type field.
+	wkbTypePoint = 1
+)
+
+// Point represents a spatial POINT value. For geographic calculations with
+// ST_Distance_Sphere, X contains the longitude and Y the latitude. SRID defines
+// the spatial reference system identifier, where 0 is the default of
+// MySQL/MariaDB. An invalid Point gets written as NULL.
+//
+// Point can be used as an argument, within a ColumnMap and implements the
+// sql.Scanner and driver.Valuer interfaces. It gets sent to the server in the
+// internal MySQL geometry format, which is the 4 byte SRID followed by the WKB
+// representation. Scanning supports the internal format, the WKB returned by
+// ST_AsBinary() and the WKT returned by ST_AsText().
+type Point struct {
+	SRID  uint32
+	X     float64
+	Y     float64
+	Valid bool
+}
+
+// MakePoint creates a new valid Point with SRID 0.
+func MakePoint(x, y float64) Point {
+	return Point{X: x, Y: y, Valid: true}
+}
+
+// ParsePointWKB parses the well known binary representation of a POINT. Both
+// byte orders are supported.
+func ParsePointWKB(data []byte) (p Point, err error) {
+	if len(data) != wkbPointLen {
+		return p, errors.BadEncoding.Newf("[dml] ParsePointWKB: invalid length %d, want %d", len(data), wkbPointLen)
+	}
+	var bo binary.ByteOrder
+	switch data[0] {
+	case 0:
+		bo = binary.BigEndian
+	case 1:
+		bo = binary.LittleEndian
+	default:
+		return p, errors.BadEncoding.Newf("[dml] ParsePointWKB: invalid byte order %d", data[0])
+	}
+	if gt := bo.Uint32(data[1:5]); gt != wkbTypePoint {
+		return p, errors.NotSupported.Newf("[dml] ParsePointWKB: geometry type %d not supported", gt)
+	}
+	p.X = math.Float64frombits(bo.Uint64(data[5:13]))
+	p.Y = math.Float64frombits(bo.Uint64(data[13:21]))
+	p.Valid = true
+	return p, nil
+}
+
+// ParsePointWKT parses the well known text representation of a POINT, e.g.
+// `POINT(13.404954 52.520008)`.
+func ParsePointWKT(text string) (p Point, err error) {
+	s := strings.TrimSpace(text)
+	if len(s) < 5 || !strings.EqualFold(s[:5], "POINT") {
+		return p, errors.BadEncoding.Newf("[dml] ParsePointWKT: invalid POINT %q", text)
+	}
+	s = strings.TrimSpace(s[5:])
+	if len(s) < 2 || s[0] != '(' || s[len(s)-1] != ')' {
+		return p, errors.BadEncoding.Newf("[dml] ParsePointWKT: invalid POINT %q", text)
+	}
+	coords := strings.Fields(s[1 : len(s)-1])
+	if len(coords) != 2 {
+		return p, errors.BadEncoding.Newf("[dml] ParsePointWKT: invalid POINT %q", text)
+	}
+	if p.X, err = strconv.ParseFloat(coords[0], 64); err != nil {
+		return Point{}, errors.BadEncoding.New(err, "[dml] ParsePointWKT: invalid X coordinate in %q", text)
+	}
+	if p.Y, err = strconv.ParseFloat(coords[1], 64); err != nil {
+		return Point{}, errors.BadEncoding.New(err, "[dml] ParsePointWKT: invalid Y coordinate in %q", text)
+	}
+	p.Valid = true
+	return p, nil
+}
+
+// WKT returns the well known text representation, e.g. `POINT(13.4 52.5)`.
+func (p Point) WKT() string {
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+	buf.WriteString("POINT(")
+	buf.WriteString(strconv.FormatFloat(p.X, 'f', -1, 64))
+	buf.WriteByte(' ')
+	buf.WriteString(strconv.FormatFloat(p.Y, 'f', -1, 64))
+	buf.WriteByte(')')
+	return buf.String()
+}
+
+// WKB returns the well known binary representation in little endian byte
+// order.
+func (p Point) WKB() []byte {
+	return p.appendWKB(make([]byte, 0, wkbPointLen))
+}
+
+func (p Point) appendWKB(b []byte) []byte {
+	var buf [8]byte
+	b = append(b, 1) // little endian
+	binary.LittleEndian.PutUint32(buf[:4], wkbTypePoint)
+	b = append(b, buf[:4]...)
+	binary.LittleEndian.PutUint64(buf[:], math.Float64bits(p.X))
+	b = append(b, buf[:]...)
+	binary.LittleEndian.PutUint64(buf[:], math.Float64bits(p.Y))
+	return append(b, buf[:]...)
+}
+
+// internal returns the MySQL internal geometry format: 4 byte SRID in little
+// endian order followed by the WKB.
+func (p Point) internal() []byte {
+	b := make([]byte, 4, 4+wkbPointLen)
+	binary.LittleEndian.PutUint32(b, p.SRID)
+	return p.appendWKB(b)
+}
+
+// Scan implements the sql.Scanner interface. It accepts the internal MySQL
+// geometry format, WKB and WKT.
+func (p *Point) Scan(value interface{}) (err error) {
+	switch v := value.(type) {
+	case nil:
+		*p = Point{}
+	case []byte:
+		*p, err = parsePoint(v)
+	case string:
+		*p, err = ParsePointWKT(v)
+	default:
+		err = errors.NotSupported.Newf("[dml] Point.Scan: type %T not supported", value)
+	}
+	return err
+}
+
+// isWKBPoint checks the byte order and geometry type header of a WKB POINT.
+func isWKBPoint(data []byte) bool {
+	if len(data) != wkbPointLen {
+		return false
+	}
+	switch data[0] {
+	case 0:
+		return binary.BigEndian.Uint32(data[1:5]) == wkbTypePoint
+	case 1:
+		return binary.LittleEndian.Uint32(data[1:5]) == wkbTypePoint
+	}
+	return false
+}
+
+// parsePoint detects the format by the WKB header and falls back to WKT.
+func parsePoint(data []byte) (Point, error) {
+	switch {
+	case len(data) > 4 && isWKBPoint(data[4:]):
+		p, err := ParsePointWKB(data[4:])
+		if err != nil {
+			return Point{}, errors.WithStack(err)
+		}
+		p.SRID = binary.LittleEndian.Uint32(data[:4])
+		return p, nil
+	case isWKBPoint(data):
+		return ParsePointWKB(data)
+	}
+	return ParsePointWKT(string(data))
+}
+
+// Value implements the driver.Valuer interface and returns the internal MySQL
+// geometry format.
+func (p Point) Value() (driver.Value, error) {
+	if !p.Valid {
+		return nil, nil
+	}
+	return p.internal(), nil
+}
+
+// Append appends the value or its nil type to the interface slice.
+func (p Point) Append(args []interface{}) []interface{} {
+	if p.Valid {
+		return append(args, p.internal())
+	}
+	return append(args, nil)
+}
+
+// writeTo writes the internal MySQL geometry format as a hexadecimal literal.
+func (p Point) writeTo(w *bytes.Buffer) error {
+	if !p.Valid {
+		_, err := w.WriteString(sqlStrNullUC)
+		return err
+	}
+	dialect.EscapeBinary(w, p.internal())
+	return nil
+}
+
+// SQLDistanceSphere creates an ST_Distance_Sphere expression which returns the
+// minimum spherical distance in meters between the POINT `column` and `p`. The
+// column can be an identifier or an expression. X of the points gets treated as
+// longitude and Y as latitude.
+//		ST_Distance_Sphere(`location`, 0x000000000101000000...)
+// The returned Condition can be used as a column with an alias or in a WHERE
+// or HAVING clause, e.g.:
+//		dml.SQLDistanceSphere("location", p).LessOrEqual().Float64(5000)
+func SQLDistanceSphere(column string, p Point) *Condition {
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+	buf.WriteString("ST_Distance_Sphere(")
+	if isValidIdentifier(column) == 0 {
+		Quoter.WriteIdentifier(buf, column)
+	} else {
+		buf.WriteString(column)
+	}
+	buf.WriteString(", ")
+	_ = p.writeTo(buf)
+	buf.WriteByte(')')
+	return &Condition{
+		Left:             buf.String(),
+		IsLeftExpression: true,
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+var (
+	_ sql.Scanner   = (*Point)(nil)
+	_ driver.Valuer = (*Point)(nil)
+)
+
+func TestPoint_WKB_WKT(t *testing.T) {
+	t.Parallel()
+
+	p := MakePoint(13.4, 52.5)
+	assert.Exactly(t, "POINT(13.4 52.5)", p.WKT())
+
+	p2, err := ParsePointWKB(p.WKB())
+	assert.NoError(t, err)
+	assert.Exactly(t, p, p2)
+
+	p2, err = ParsePointWKT(" point ( 13.4   52.5 ) ")
+	assert.NoError(t, err)
+	assert.Exactly(t, p, p2)
+
+	t.Run("big endian WKB", func(t *testing.T) {
+		p, err := ParsePointWKB([]byte{0, 0, 0, 0, 1, 63, 240, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 0})
+		assert.NoError(t, err)
+		assert.Exactly(t, MakePoint(1, 2), p)
+	})
+	t.Run("invalid WKB", func(t *testing.T) {
+		_, err := ParsePointWKB([]byte{1, 2})
+		assert.True(t, errors.BadEncoding.Match(err), "%+v", err)
+		wkb := p.WKB()
+		wkb[1] = 2 // LINESTRING
+		_, err = ParsePointWKB(wkb)
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	})
+	t.Run("invalid WKT", func(t *testing.T) {
+		for _, wkt := range []string{"", "LINESTRING(0 0,1 1)", "POINT(1)", "POINT(1 2", "POINT(a 2)", "POINT(1 b)"} {
+			_, err := ParsePointWKT(wkt)
+			assert.True(t, errors.BadEncoding.Match(err), "%q: %+v", wkt, err)
+		}
+	})
+}
+
+func TestPoint_Scan_Value(t *testing.T) {
+	t.Parallel()
+
+	p := Point{SRID: 4326, X: 13.4, Y: 52.5, Valid: true}
+	v, err := p.Value()
+	assert.NoError(t, err)
+	assert.Len(t, v, 25)
+
+	var p2 Point
+	assert.NoError(t, p2.Scan(v))
+	assert.Exactly(t, p, p2)
+
+	assert.NoError(t, p2.Scan(p.WKB()))
+	assert.Exactly(t, MakePoint(13.4, 52.5), p2)
+
+	assert.NoError(t, p2.Scan("POINT(1 2)"))
+	assert.Exactly(t, MakePoint(1, 2), p2)
+
+	// WKT with the same length as WKB or the internal format.
+	wkt21 := []byte("POINT(12.3456 7.8912)")
+	assert.Len(t, wkt21, wkbPointLen)
+	assert.NoError(t, p2.Scan(wkt21))
+	assert.Exactly(t, MakePoint(12.3456, 7.8912), p2)
+	wkt25 := []byte("POINT(12.345678 9.876543)")
+	assert.Len(t, wkt25, 4+wkbPointLen)
+	assert.NoError(t, p2.Scan(wkt25))
+	assert.Exactly(t, MakePoint(12.345678, 9.876543), p2)
+
+	assert.NoError(t, p2.Scan(nil))
+	assert.Exactly(t, Point{}, p2)
+	v, err = p2.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	err = p2.Scan(3.14)
+	assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+}
+
+func TestPoint_Argument(t *testing.T) {
+	t.Parallel()
+
+	p := MakePoint(13.4, 52.5)
+	compareToSQL(t,
+		NewInsert("stores").AddColumns("name", "location").WithArgs().String("Berlin").Point(p),
+		errors.NoKind,
+		"INSERT INTO `stores` (`name`,`location`) VALUES (?,?)",
+		"INSERT INTO `stores` (`name`,`location`) VALUES ('Berlin',0x000000000101000000cdcccccccccc2a400000000000404a40)",
+		"Berlin", p.internal(),
+	)
+	compareToSQL(t,
+		NewSelect("name").From("stores").Where(
+			Column("location").In().Points(MakePoint(1, 2), Point{}),
+		),
+		errors.NoKind,
+		"SELECT `name` FROM `stores` WHERE (`location` IN (0x000000000101000000000000000000f03f0000000000000040,NULL))",
+		"SELECT `name` FROM `stores` WHERE (`location` IN (0x000000000101000000000000000000f03f0000000000000040,NULL))",
+	)
+}
+
+func TestSQLDistanceSphere(t *testing.T) {
+	t.Parallel()
+
+	p := Point{SRID: 4326, X: 13.4, Y: 52.5, Valid: true}
+	compareToSQL(t,
+		NewSelect("name").AddColumnsConditions(SQLDistanceSphere("s.location", p).Alias("distance")).
+			FromAlias("stores", "s").
+			Where(SQLDistanceSphere("s.location", p).LessOrEqual().Float64(5000)),
+		errors.NoKind,
+		"SELECT `name`, ST_Distance_Sphere(`s`.`location`, 0xe61000000101000000cdcccccccccc2a400000000000404a40) AS `distance` FROM `stores` AS `s` WHERE (ST_Distance_Sphere(`s`.`location`, 0xe61000000101000000cdcccccccccc2a400000000000404a40) <= 5000)",
+		"SELECT `name`, ST_Distance_Sphere(`s`.`location`, 0xe61000000101000000cdcccccccccc2a400000000000404a40) AS `distance` FROM `stores` AS `s` WHERE (ST_Distance_Sphere(`s`.`location`, 0xe61000000101000000cdcccccccccc2a400000000000404a40) <= 5000)",
+	)
+}
@@ -0,0 +1,183 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/bufferpool"
+)
+
+// SQLArg defines a typed argument of a SQL function. It can be a column, a
+// place holder or a nested SQL function. Create it with SQLColumn,
+// SQLPlaceHolder, SQLNamedArg or SQLNested. Values never get written into the
+// expression, they get bound as arguments via the Artisan created by WithArgs.
+type SQLArg struct {
+	column      string
+	placeHolder string
+	nested      *Condition
+}
+
+// SQLColumn creates a function argument from a column name. The name gets
+// quoted and can contain a qualifier.
+func SQLColumn(name string) SQLArg {
+	return SQLArg{column: name}
+}
+
+// SQLPlaceHolder creates a function argument which writes the place holder
+// `?`. The value gets bound in the order of the place holders.
+//		dml.SQLCoalesce(dml.SQLColumn("price"), dml.SQLPlaceHolder())
+//		sel.WithArgs().Float64(0)
+func SQLPlaceHolder() SQLArg {
+	return SQLArg{placeHolder: placeHolderStr}
+}
+
+// SQLNamedArg creates a function argument which writes a named place holder.
+// The value gets bound via a named argument or a record with the same column
+// name.
+//		dml.SQLIfNullArg(dml.SQLColumn("name"), dml.SQLNamedArg("unknown"))
+//		sel.WithArgs().Name("unknown").String("n/a")
+func SQLNamedArg(name string) SQLArg {
+	if !strings.HasPrefix(name, namedArgStartStr) {
+		name = namedArgStartStr + name
+	}
+	return SQLArg{placeHolder: name}
+}
+
+// SQLNested creates a function argument from another SQL function.
+//		dml.SQLCoalesce(dml.SQLNested(dml.SQLDate(dml.SQLColumn("updated_at"))), dml.SQLPlaceHolder())
+func SQLNested(fn *Condition) SQLArg {
+	return SQLArg{nested: fn}
+}
+
+func (sa SQLArg) writeTo(w *bytes.Buffer) error {
+	switch {
+	case sa.nested != nil:
+		if sa.nested.previousErr != nil {
+			return sa.nested.previousErr
+		}
+		w.WriteString(sa.nested.Left)
+	case sa.column != "":
+		Quoter.WriteIdentifier(w, sa.column)
+	case sa.placeHolder != "":
+		w.WriteString(sa.placeHolder)
+	default:
+		w.WriteString(sqlStrNullUC)
+	}
+	return nil
+}
+
+// sqlFunc writes the function name and its arguments into a new expression
+// condition.
+func sqlFunc(name string, args ...SQLArg) *Condition {
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	c := &Condition{IsLeftExpression: true}
+	buf.WriteString(name)
+	buf.WriteByte('(')
+	for i, a := range args {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if err := a.writeTo(buf); err != nil {
+			c.previousErr = errors.Wrapf(err, "[dml] SQL function %s failed at argument %d", name, i)
+			return c
+		}
+	}
+	buf.WriteByte(')')
+	c.Left = buf.String()
+	return c
+}
+
+// SQLCoalesce creates a COALESCE expression which returns the first non-NULL
+// argument.
+//		COALESCE(`price`, 0)
+func SQLCoalesce(args ...SQLArg) *Condition {
+	return sqlFunc("COALESCE", args...)
+}
+
+// SQLIfNullArg creates an IFNULL expression with typed arguments. In contrast
+// to SQLIfNull the alternative value gets bound as an argument.
+//		IFNULL(`name`, ?)
+func SQLIfNullArg(expression, alternative SQLArg) *Condition {
+	return sqlFunc("IFNULL", expression, alternative)
+}
+
+// SQLConcatWS creates a CONCAT_WS expression which concatenates the arguments
+// with the separator. NULL arguments get skipped by the database.
+//		CONCAT_WS(?, `firstname`, `lastname`)
+func SQLConcatWS(separator SQLArg, args ...SQLArg) *Condition {
+	return sqlFunc("CONCAT_WS", append([]SQLArg{separator}, args...)...)
+}
+
+// SQLDate creates a DATE expression which extracts the date part.
+func SQLDate(date SQLArg) *Condition {
+	return sqlFunc("DATE", date)
+}
+
+// SQLDateFormat creates a DATE_FORMAT expression.
+//		DATE_FORMAT(`created_at`, ?)
+func SQLDateFormat(date, format SQLArg) *Condition {
+	return sqlFunc("DATE_FORMAT", date, format)
+}
+
+// SQLDateAdd creates a DATE_ADD expression. Argument unit must be a valid
+// temporal interval unit like DAY, HOUR or MONTH.
+//		DATE_ADD(`created_at`, INTERVAL 7 DAY)
+func SQLDateAdd(date SQLArg, interval int, unit string) *Condition {
+	return sqlDateInterval("DATE_ADD", date, interval, unit)
+}
+
+// SQLDateSub creates a DATE_SUB expression. Argument unit must be a valid
+// temporal interval unit like DAY, HOUR or MONTH.
+//		DATE_SUB(NOW(), INTERVAL 1 HOUR)
+func SQLDateSub(date SQLArg, interval int, unit string) *Condition {
+	return sqlDateInterval("DATE_SUB", date, interval, unit)
+}
+
+// sqlIntervalUnits contains the allowed units of an INTERVAL.
+var sqlIntervalUnits = map[string]bool{
+	"MICROSECOND": true, "SECOND": true, "MINUTE": true, "HOUR": true, "DAY": true,
+	"WEEK": true, "MONTH": true, "QUARTER": true, "YEAR": true,
+}
+
+func sqlDateInterval(name string, date SQLArg, interval int, unit string) *Condition {
+	unit = strings.ToUpper(unit)
+	if !sqlIntervalUnits[unit] {
+		return &Condition{
+			IsLeftExpression: true,
+			previousErr:      errors.NotValid.Newf("[dml] %s: Invalid interval unit %q", name, unit),
+		}
+	}
+	c := sqlFunc(name, date)
+	if c.previousErr != nil {
+		return c
+	}
+	// replace the closing parenthesis with the interval.
+	c.Left = c.Left[:len(c.Left)-1] + ", INTERVAL " + strconv.Itoa(interval) + " " + unit + ")"
+	return c
+}
+
+// SQLNow creates a NOW() expression for the usage as SQLNested argument.
+func SQLNow() *Condition {
+	return &Condition{
+		Left:             "NOW()",
+		IsLeftExpression: true,
+	}
+}
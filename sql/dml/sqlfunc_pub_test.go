@@ -0,0 +1,87 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml_test
+
+import (
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestSQLFunctions(t *testing.T) {
+	t.Parallel()
+
+	runner := func(want string, have *dml.Condition) func(*testing.T) {
+		return func(t *testing.T) {
+			assert.Exactly(t, want, have.Left)
+			assert.True(t, have.IsLeftExpression, "IsLeftExpression should be true")
+		}
+	}
+	t.Run("COALESCE", runner(
+		"COALESCE(`p`.`price`, `p`.`special_price`, ?)",
+		dml.SQLCoalesce(dml.SQLColumn("p.price"), dml.SQLColumn("p.special_price"), dml.SQLPlaceHolder()),
+	))
+	t.Run("IFNULL named", runner(
+		"IFNULL(`name`, :unknown)",
+		dml.SQLIfNullArg(dml.SQLColumn("name"), dml.SQLNamedArg("unknown")),
+	))
+	t.Run("CONCAT_WS", runner(
+		"CONCAT_WS(?, `firstname`, `lastname`)",
+		dml.SQLConcatWS(dml.SQLPlaceHolder(), dml.SQLColumn("firstname"), dml.SQLColumn("lastname")),
+	))
+	t.Run("DATE_FORMAT", runner(
+		"DATE_FORMAT(`created_at`, ?)",
+		dml.SQLDateFormat(dml.SQLColumn("created_at"), dml.SQLPlaceHolder()),
+	))
+	t.Run("DATE_SUB nested", runner(
+		"DATE_SUB(NOW(), INTERVAL 1 HOUR)",
+		dml.SQLDateSub(dml.SQLNested(dml.SQLNow()), 1, "hour"),
+	))
+	t.Run("COALESCE nested DATE", runner(
+		"COALESCE(DATE(`updated_at`), ?)",
+		dml.SQLCoalesce(dml.SQLNested(dml.SQLDate(dml.SQLColumn("updated_at"))), dml.SQLPlaceHolder()),
+	))
+
+	t.Run("in SELECT and WHERE", func(t *testing.T) {
+		sel := dml.NewSelect().AddColumnsConditions(
+			dml.SQLConcatWS(dml.SQLPlaceHolder(), dml.SQLColumn("firstname"), dml.SQLColumn("lastname")).Alias("name"),
+		).From("customer_entity").
+			Where(dml.SQLDateAdd(dml.SQLColumn("created_at"), 7, "DAY").GreaterOrEqual().Str("2019-01-01"))
+		compareToSQL(t, sel.WithArgs().String("O'Reilly"), errors.NoKind,
+			"SELECT CONCAT_WS(?, `firstname`, `lastname`) AS `name` FROM `customer_entity` WHERE (DATE_ADD(`created_at`, INTERVAL 7 DAY) >= '2019-01-01')",
+			"SELECT CONCAT_WS('O\\'Reilly', `firstname`, `lastname`) AS `name` FROM `customer_entity` WHERE (DATE_ADD(`created_at`, INTERVAL 7 DAY) >= '2019-01-01')",
+			"O'Reilly",
+		)
+	})
+
+	t.Run("named argument", func(t *testing.T) {
+		sel := dml.NewSelect().AddColumnsConditions(
+			dml.SQLIfNullArg(dml.SQLColumn("name"), dml.SQLNamedArg("unknown")).Alias("name"),
+		).From("customer_entity")
+		compareToSQL(t, sel.WithArgs().Name("unknown").String("n/a"), errors.NoKind,
+			"SELECT IFNULL(`name`, ?) AS `name` FROM `customer_entity`",
+			"SELECT IFNULL(`name`, 'n/a') AS `name` FROM `customer_entity`",
+			"n/a",
+		)
+	})
+
+	t.Run("invalid interval unit", func(t *testing.T) {
+		sel := dml.NewSelect("entity_id").From("customer_entity").
+			Where(dml.SQLDateAdd(dml.SQLColumn("created_at"), 7, "FORTNIGHT").Less().Str("2019-01-01"))
+		compareToSQL(t, sel, errors.NotValid, "", "")
+	})
+}
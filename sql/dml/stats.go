@@ -0,0 +1,156 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StatsCollector collects metrics of the executed statements: the duration of
+// queries, executions and preparations, the returned and affected rows, the
+// errors by kind and the number of open prepared statements. StatsCollector
+// implements prometheus.Collector and must be registered at a
+// prometheus.Registerer. Set it via WithStats. A nil StatsCollector is a no-op.
+type StatsCollector struct {
+	duration  *prometheus.HistogramVec
+	rows      *prometheus.CounterVec
+	errs      *prometheus.CounterVec
+	openStmts prometheus.Gauge
+}
+
+// NewStatsCollector creates a new StatsCollector. The namespace gets prefixed to
+// all metric names, e.g. "myapp" results in "myapp_dml_query_duration_seconds".
+func NewStatsCollector(namespace string) *StatsCollector {
+	return &StatsCollector{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "dml",
+			Name:      "query_duration_seconds",
+			Help:      "Duration of the queries, executions and preparations by source.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"source", "operation"}),
+		rows: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "dml",
+			Name:      "rows_total",
+			Help:      "Number of returned and affected rows by source.",
+		}, []string{"source", "type"}),
+		errs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "dml",
+			Name:      "errors_total",
+			Help:      "Number of errors by source and error kind.",
+		}, []string{"source", "kind"}),
+		openStmts: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "dml",
+			Name:      "open_prepared_statements",
+			Help:      "Number of prepared statements which have not yet been closed.",
+		}),
+	}
+}
+
+// WithStats sets the StatsCollector for all statements created by the
+// connection pool.
+func WithStats(sc *StatsCollector) ConnPoolOption {
+	return ConnPoolOption{
+		sortOrder: 10,
+		fn: func(c *ConnPool) error {
+			c.stats = sc
+			return nil
+		},
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (sc *StatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	sc.duration.Describe(ch)
+	sc.rows.Describe(ch)
+	sc.errs.Describe(ch)
+	sc.openStmts.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (sc *StatsCollector) Collect(ch chan<- prometheus.Metric) {
+	sc.duration.Collect(ch)
+	sc.rows.Collect(ch)
+	sc.errs.Collect(ch)
+	sc.openStmts.Collect(ch)
+}
+
+// observe records the duration since start and counts the error by its kind.
+func (sc *StatsCollector) observe(source rune, operation string, start time.Time, err error) {
+	if sc == nil {
+		return
+	}
+	src := sourceName(source)
+	sc.duration.WithLabelValues(src, operation).Observe(now().Sub(start).Seconds())
+	if err == nil {
+		return
+	}
+	kind := "unknown"
+	if k := errors.UnwrapKind(err); !k.Empty() {
+		kind = k.String()
+	}
+	sc.errs.WithLabelValues(src, kind).Inc()
+}
+
+// addRows adds the number of rows. Argument typ can be "returned" or
+// "affected".
+func (sc *StatsCollector) addRows(source rune, typ string, rows int64) {
+	if sc == nil || rows <= 0 {
+		return
+	}
+	sc.rows.WithLabelValues(sourceName(source), typ).Add(float64(rows))
+}
+
+func (sc *StatsCollector) stmtOpened() {
+	if sc != nil {
+		sc.openStmts.Inc()
+	}
+}
+
+func (sc *StatsCollector) stmtClosed() {
+	if sc != nil {
+		sc.openStmts.Dec()
+	}
+}
+
+func sourceName(source rune) string {
+	switch source {
+	case dmlSourceSelect:
+		return "select"
+	case dmlSourceInsert:
+		return "insert"
+	case dmlSourceInsertSelect:
+		return "insert_select"
+	case dmlSourceUpdate:
+		return "update"
+	case dmlSourceDelete:
+		return "delete"
+	case dmlSourceWith:
+		return "with"
+	case dmlSourceUnion:
+		return "union"
+	case dmlSourceShow:
+		return "show"
+	case dmlSourceCall:
+		return "call"
+	}
+	return "raw"
+}
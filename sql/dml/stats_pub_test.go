@@ -0,0 +1,71 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestWithStats(t *testing.T) {
+	t.Parallel()
+
+	sc := dml.NewStatsCollector("test")
+	dbc, dbMock := dmltest.MockDB(t, dml.WithStats(sc))
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	const delSQL = "DELETE FROM `customer_entity` WHERE (`entity_id` = ?)"
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta(delSQL)).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 2))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta(delSQL)).WithArgs(4).WillReturnError(errors.NotFound.Newf("Row not found"))
+	dbMock.ExpectPrepare(dmltest.SQLMockQuoteMeta("SELECT `entity_id` FROM `customer_entity`")).WillBeClosed()
+
+	del := dbc.DeleteFrom("customer_entity").Where(dml.Column("entity_id").PlaceHolder())
+	_, err := del.WithArgs().ExecContext(context.TODO(), 3)
+	assert.NoError(t, err)
+	_, err = del.WithArgs().ExecContext(context.TODO(), 4)
+	assert.True(t, errors.NotFound.Match(err), "%+v", err)
+
+	stmt, err := dbc.SelectFrom("customer_entity").AddColumns("entity_id").Prepare(context.TODO())
+	assert.NoError(t, err)
+
+	assert.NoError(t, testutil.CollectAndCompare(sc, strings.NewReader(`
+# HELP test_dml_errors_total Number of errors by source and error kind.
+# TYPE test_dml_errors_total counter
+test_dml_errors_total{kind="`+errors.NotFound.String()+`",source="delete"} 1
+# HELP test_dml_open_prepared_statements Number of prepared statements which have not yet been closed.
+# TYPE test_dml_open_prepared_statements gauge
+test_dml_open_prepared_statements 1
+# HELP test_dml_rows_total Number of returned and affected rows by source.
+# TYPE test_dml_rows_total counter
+test_dml_rows_total{source="delete",type="affected"} 2
+`), "test_dml_errors_total", "test_dml_open_prepared_statements", "test_dml_rows_total"))
+
+	assert.NoError(t, stmt.Close())
+	assert.NoError(t, stmt.Close())
+	assert.NoError(t, testutil.CollectAndCompare(sc, strings.NewReader(`
+# HELP test_dml_open_prepared_statements Number of prepared statements which have not yet been closed.
+# TYPE test_dml_open_prepared_statements gauge
+test_dml_open_prepared_statements 0
+`), "test_dml_open_prepared_statements"))
+	assert.Exactly(t, 2, testutil.CollectAndCount(sc, "test_dml_query_duration_seconds"))
+}
@@ -17,6 +17,7 @@ package dml
 import (
 	"context"
 	"database/sql"
+	"sync"
 
 	"github.com/corestoreio/errors"
 )
@@ -64,6 +65,9 @@ func (sw stmtWrapper) QueryRowContext(ctx context.Context, sql string, args ...i
 type Stmt struct {
 	base builderCommon
 	Stmt *sql.Stmt
+	// closeStats decrements the open statements gauge exactly once, even if
+	// Close gets called several times or returns an error.
+	closeStats sync.Once
 }
 
 // WithArgs creates a new argument handler.
@@ -79,4 +83,7 @@ func (st *Stmt) WithArgs() *Artisan {
 }
 
 // Close closes the statement in the database and frees its resources.
-func (st *Stmt) Close() error { return st.Stmt.Close() }
+func (st *Stmt) Close() error {
+	defer st.closeStats.Do(st.base.stats.stmtClosed)
+	return st.Stmt.Close()
+}
@@ -0,0 +1,148 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/storage/null"
+)
+
+// TimeFormatMicro renders time values with microsecond precision, suitable for
+// columns of type DATETIME(6) or TIMESTAMP(6).
+const TimeFormatMicro = "2006-01-02 15:04:05.000000"
+
+// timeFormatter defines how interpolated time values get written into the SQL
+// string. A nil *timeFormatter uses the default mysqlTimeFormat.
+type timeFormatter struct {
+	loc    *time.Location
+	layout string
+}
+
+func (tf *timeFormatter) format(t time.Time) string {
+	if tf.loc != nil {
+		t = t.In(tf.loc)
+	}
+	layout := tf.layout
+	if layout == "" {
+		layout = mysqlTimeFormat
+	}
+	return t.Format(layout)
+}
+
+// convertArgs returns a copy of args where all non-zero time values are
+// replaced by their formatted string representation. If args contains no time
+// values or tf is nil, args gets returned unchanged.
+func (tf *timeFormatter) convertArgs(args arguments) arguments {
+	if tf == nil {
+		return args
+	}
+	var converted arguments
+	for i, arg := range args {
+		var v interface{}
+		switch at := arg.value.(type) {
+		case time.Time:
+			if at.IsZero() {
+				continue
+			}
+			v = tf.format(at)
+		case []time.Time:
+			ss := make([]string, len(at))
+			for j, t := range at {
+				ss[j] = tf.format(t)
+			}
+			v = ss
+		case null.Time:
+			if !at.Valid || at.Time.IsZero() {
+				continue
+			}
+			v = tf.format(at.Time)
+		case []null.Time:
+			nss := make([]null.String, len(at))
+			for j, nt := range at {
+				if nt.Valid {
+					nss[j] = null.MakeString(tf.format(nt.Time))
+				}
+			}
+			v = nss
+		default:
+			continue
+		}
+		if converted == nil {
+			converted = make(arguments, len(args))
+			copy(converted, args)
+		}
+		converted[i].value = v
+	}
+	if converted == nil {
+		return args
+	}
+	return converted
+}
+
+// WithTimeZone sets the location in which interpolated time values get
+// rendered and sets the session variable `time_zone` of each new physical
+// connection to the offset of the location, see WithOnConnect. The offset gets
+// calculated when the connection opens, hence a change of the daylight saving
+// time does not change the session variable of an already open connection.
+// The session variable gets only set in conjunction with WithDSN. When using
+// WithDB, add the parameter `time_zone` to the DSN of the *sql.DB.
+func WithTimeZone(loc *time.Location) ConnPoolOption {
+	return ConnPoolOption{
+		sortOrder: 1, // must run before the first connection gets opened
+		fn: func(c *ConnPool) error {
+			if loc == nil {
+				return errors.Empty.Newf("[dml] WithTimeZone: location cannot be nil")
+			}
+			if c.timeFmt == nil {
+				c.timeFmt = new(timeFormatter)
+			}
+			c.timeFmt.loc = loc
+			c.onConnect = append(c.onConnect, timeZoneConnHook(loc))
+			return nil
+		},
+	}
+}
+
+func timeZoneConnHook(loc *time.Location) ConnHook {
+	return func(ctx context.Context, conn driver.ExecerContext) error {
+		offset := time.Now().In(loc).Format("-07:00")
+		_, err := conn.ExecContext(ctx, "SET time_zone = '"+offset+"'", nil)
+		return errors.WithStack(err)
+	}
+}
+
+// WithTimeFormat sets the layout, as used in package time, to render
+// interpolated time values. For example TimeFormatMicro writes the fractional
+// seconds of DATETIME(6) columns. Zero time values are still written as
+// '0000-00-00'.
+func WithTimeFormat(layout string) ConnPoolOption {
+	return ConnPoolOption{
+		sortOrder: 10,
+		fn: func(c *ConnPool) error {
+			if layout == "" {
+				return errors.Empty.Newf("[dml] WithTimeFormat: layout cannot be empty")
+			}
+			if c.timeFmt == nil {
+				c.timeFmt = new(timeFormatter)
+			}
+			c.timeFmt.layout = layout
+			return nil
+		},
+	}
+}
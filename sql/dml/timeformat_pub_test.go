@@ -0,0 +1,88 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/storage/null"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestWithTimeZone(t *testing.T) {
+	t.Parallel()
+
+	db, dbMock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	dbc, err := dml.NewConnPool(
+		dml.WithDB(db),
+		dml.WithTimeZone(time.FixedZone("CEST", 2*3600)),
+		dml.WithTimeFormat(dml.TimeFormatMicro),
+	)
+	assert.NoError(t, err)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	sel := dbc.SelectFrom("sales_order").Star().Where(
+		dml.Column("created_at").Greater().PlaceHolder(),
+		dml.Column("updated_at").Less().PlaceHolder(),
+		dml.Column("deleted_at").NotEqual().PlaceHolder(),
+	)
+	created := time.Date(2019, 3, 4, 5, 6, 7, 123456000, time.UTC)
+
+	t.Run("converted", func(t *testing.T) {
+		sqlStr, args, err := sel.WithArgs().Interpolate().
+			Time(created).NullTime(null.MakeTime(created.Add(time.Hour))).Time(time.Time{}).ToSQL()
+		assert.NoError(t, err)
+		assert.Nil(t, args)
+		assert.Exactly(t,
+			"SELECT * FROM `sales_order` WHERE (`created_at` > '2019-03-04 07:06:07.123456') AND (`updated_at` < '2019-03-04 08:06:07.123456') AND (`deleted_at` != '0000-00-00')",
+			sqlStr)
+	})
+	t.Run("invalid NullTime", func(t *testing.T) {
+		sqlStr, _, err := sel.WithArgs().Interpolate().
+			Time(created).NullTime(null.Time{}).Time(created).ToSQL()
+		assert.NoError(t, err)
+		assert.Exactly(t,
+			"SELECT * FROM `sales_order` WHERE (`created_at` > '2019-03-04 07:06:07.123456') AND (`updated_at` < NULL) AND (`deleted_at` != '2019-03-04 07:06:07.123456')",
+			sqlStr)
+	})
+}
+
+func TestWithTimeFormat_Default(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	sqlStr, _, err := dbc.SelectFrom("sales_order").Star().Where(
+		dml.Column("created_at").Greater().PlaceHolder(),
+	).WithArgs().Interpolate().Time(time.Date(2019, 3, 4, 5, 6, 7, 123456000, time.UTC)).ToSQL()
+	assert.NoError(t, err)
+	assert.Exactly(t, "SELECT * FROM `sales_order` WHERE (`created_at` > '2019-03-04 05:06:07')", sqlStr)
+}
+
+func TestWithTimeZone_Error(t *testing.T) {
+	t.Parallel()
+
+	dbc, err := dml.NewConnPool(dml.WithTimeZone(nil))
+	assert.Nil(t, dbc)
+	assert.True(t, errors.Empty.Match(err), "%+v", err)
+}
@@ -0,0 +1,107 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the instrumentation library in the spans.
+const tracerName = "github.com/corestoreio/pkg/sql/dml"
+
+// WithTracer sets the OpenTelemetry TracerProvider. Query, Exec, Load, Prepare
+// and transactions start a new span which contains the statement ID, the table
+// name and the row counts as attributes. A transaction span lasts from BeginTx
+// until Commit or Rollback and is the parent of the spans of its statements.
+func WithTracer(tp trace.TracerProvider) ConnPoolOption {
+	return ConnPoolOption{
+		sortOrder: 10,
+		fn: func(c *ConnPool) error {
+			c.tracer = tp.Tracer(tracerName)
+			return nil
+		},
+	}
+}
+
+// startSpan starts a new span if a tracer has been set, otherwise the returned
+// span is nil.
+func (bc *builderCommon) startSpan(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if bc.tracer == nil {
+		return ctx, nil
+	}
+	attrs = append(attrs,
+		attribute.String("db.system", "mysql"),
+		attribute.String("dml.id", bc.id),
+	)
+	if bc.source > 0 {
+		attrs = append(attrs, attribute.String("dml.source", string(bc.source)))
+	}
+	if bc.table != "" {
+		attrs = append(attrs, attribute.String("dml.table", bc.table))
+	}
+	return bc.tracer.Start(ctx, "dml."+operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// startTxSpan starts the span covering a whole transaction if a tracer has
+// been set, otherwise the returned span is nil.
+func (cc *connCommon) startTxSpan(ctx context.Context) (context.Context, trace.Span) {
+	if cc.tracer == nil {
+		return ctx, nil
+	}
+	return cc.tracer.Start(ctx, "dml.Tx", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("db.system", "mysql"),
+		attribute.String("dml.tx_id", cc.makeUniqueID()),
+	))
+}
+
+// txTracer starts the spans of all statements of a transaction as children of
+// the transaction span, even if the context passed to a statement does not
+// contain the transaction span.
+type txTracer struct {
+	trace.Tracer
+	span trace.Span
+}
+
+// newTxTracer wraps t if a transaction span has been started, otherwise it
+// returns t.
+func newTxTracer(t trace.Tracer, span trace.Span) trace.Tracer {
+	if t == nil || span == nil {
+		return t
+	}
+	return txTracer{Tracer: t, span: span}
+}
+
+// Start implements trace.Tracer.
+func (tt txTracer) Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return tt.Tracer.Start(trace.ContextWithSpan(ctx, tt.span), spanName, opts...)
+}
+
+// endSpan records the error and the attributes and ends the span. A nil span
+// gets ignored.
+func endSpan(span trace.Span, err error, attrs ...attribute.KeyValue) {
+	if span == nil {
+		return
+	}
+	span.SetAttributes(attrs...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
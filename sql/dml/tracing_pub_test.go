@@ -0,0 +1,76 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithTracer(t *testing.T) {
+	t.Parallel()
+
+	sr := tracetest.NewSpanRecorder()
+	dbc, dbMock := dmltest.MockDB(t, dml.WithTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))))
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `customer_entity` WHERE (`entity_id` = ?)")).
+		WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 2))
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `customer_entity` WHERE (`entity_id` = ?)")).
+		WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectRollback()
+
+	_, err := dbc.DeleteFrom("customer_entity").Where(dml.Column("entity_id").PlaceHolder()).
+		WithArgs().ExecContext(context.TODO(), 3)
+	assert.NoError(t, err)
+
+	tx, err := dbc.BeginTx(context.TODO(), nil)
+	assert.NoError(t, err)
+	_, err = tx.DeleteFrom("customer_entity").Where(dml.Column("entity_id").PlaceHolder()).
+		WithArgs().ExecContext(context.TODO(), 4)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Rollback())
+
+	spans := sr.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected three ended spans but got %d", len(spans))
+	}
+
+	assert.Exactly(t, "dml.Exec", spans[0].Name())
+	attrs := attribute.NewSet(spans[0].Attributes()...)
+	v, ok := attrs.Value("dml.table")
+	assert.True(t, ok, "Attribute dml.table not found")
+	assert.Exactly(t, "customer_entity", v.AsString())
+	v, ok = attrs.Value("dml.rows_affected")
+	assert.True(t, ok, "Attribute dml.rows_affected not found")
+	assert.Exactly(t, int64(2), v.AsInt64())
+
+	assert.Exactly(t, "dml.Exec", spans[1].Name())
+	assert.Exactly(t, "dml.Tx", spans[2].Name())
+	txAttrs := attribute.NewSet(spans[2].Attributes()...)
+	v, _ = txAttrs.Value("dml.tx_rollback")
+	assert.True(t, v.AsBool(), "Attribute dml.tx_rollback should be true")
+	assert.Exactly(t, spans[2].SpanContext().SpanID(), spans[1].Parent().SpanID(), "Tx statement must be a child of the Tx span")
+	assert.Exactly(t, spans[2].SpanContext().TraceID(), spans[1].SpanContext().TraceID())
+}
@@ -36,6 +36,9 @@ type Union struct {
 	IsAll       bool // IsAll enables UNION ALL
 	IsIntersect bool // See Intersect()
 	IsExcept    bool // See Except()
+	// EmulateColumns if not empty, INTERSECT and EXCEPT get emulated with IN
+	// and NOT IN sub-queries. See Emulate()
+	EmulateColumns []string
 
 	// When using Union as a template, only one *Select is required.
 	oldNew [][]string //use for string replacement with `repls` field
@@ -67,9 +70,13 @@ func (c *ConnPool) Union(selects ...*Select) *Union {
 	return &Union{
 		BuilderBase: BuilderBase{
 			builderCommon: builderCommon{
-				id:  id,
-				Log: unionInitLog(c.Log, selects, id),
-				DB:  c.DB,
+				id:         id,
+				Log:        unionInitLog(c.Log, selects, id),
+				DB:         c.DB,
+				tracer:     c.tracer,
+				stats:      c.stats,
+				timeFmt:    c.timeFmt,
+				commenters: c.commenters,
 			},
 		},
 		Selects: selects,
@@ -82,9 +89,13 @@ func (c *Conn) Union(selects ...*Select) *Union {
 	return &Union{
 		BuilderBase: BuilderBase{
 			builderCommon: builderCommon{
-				id:  id,
-				Log: unionInitLog(c.Log, selects, id),
-				DB:  c.DB,
+				id:         id,
+				Log:        unionInitLog(c.Log, selects, id),
+				DB:         c.DB,
+				tracer:     c.tracer,
+				stats:      c.stats,
+				timeFmt:    c.timeFmt,
+				commenters: c.commenters,
 			},
 		},
 		Selects: selects,
@@ -98,9 +109,13 @@ func (tx *Tx) Union(selects ...*Select) *Union {
 	return &Union{
 		BuilderBase: BuilderBase{
 			builderCommon: builderCommon{
-				id:  id,
-				Log: unionInitLog(tx.Log, selects, id),
-				DB:  tx.DB,
+				id:         id,
+				Log:        unionInitLog(tx.Log, selects, id),
+				DB:         tx.DB,
+				tracer:     tx.tracer,
+				stats:      tx.stats,
+				timeFmt:    tx.timeFmt,
+				commenters: tx.commenters,
 			},
 		},
 		Selects: selects,
@@ -182,7 +197,8 @@ func (u *Union) OrderByDesc(columns ...string) *Union {
 // records that are present in both result sets will be included in the result
 // of the operation. INTERSECT has higher precedence than UNION and EXCEPT. If
 // possible it will be executed linearly but if not it will be translated to a
-// subquery in the FROM clause. Only supported in MariaDB >=10.3
+// subquery in the FROM clause. Only supported in MariaDB >=10.3 and MySQL
+// >=8.0.31, for older servers see Emulate.
 func (u *Union) Intersect() *Union {
 	u.IsIntersect = true
 	return u
@@ -191,12 +207,33 @@ func (u *Union) Intersect() *Union {
 // Except switches the query from UNION to EXCEPT. The result of EXCEPT is all
 // records of the left SELECT result except records which are in right SELECT
 // result set, i.e. it is subtraction of two result sets. EXCEPT and UNION have
-// the same operation precedence. Only supported in MariaDB >=10.3
+// the same operation precedence. Only supported in MariaDB >=10.3 and MySQL
+// >=8.0.31, for older servers see Emulate.
 func (u *Union) Except() *Union {
 	u.IsExcept = true
 	return u
 }
 
+// Emulate enables the emulation of INTERSECT and EXCEPT for servers without
+// native support. The result set of the first SELECT gets wrapped into a
+// derived table and compared via IN (INTERSECT) or a correlated NOT EXISTS
+// (EXCEPT) with the other SELECTs. Argument columns must contain the names of
+// the columns of the result set. For EXCEPT all SELECTs must return these
+// column names, use aliases if necessary. NULL values get compared with the
+// NULL-safe operator <=> in EXCEPT, but in contrast to the native syntax, rows
+// containing NULL values won't match in INTERSECT. Emulate does not support the
+// template mode.
+//		SELECT DISTINCT * FROM (SELECT ...) AS `_emulated`
+//		WHERE (`_emulated`.`a`,`_emulated`.`b`) IN (SELECT ...)
+//
+//		SELECT DISTINCT * FROM (SELECT ...) AS `_emulated`
+//		WHERE NOT EXISTS (SELECT 1 FROM (SELECT ...) AS `_emulated1`
+//		WHERE (`_emulated1`.`a` <=> `_emulated`.`a`) AND (`_emulated1`.`b` <=> `_emulated`.`b`))
+func (u *Union) Emulate(columns ...string) *Union {
+	u.EmulateColumns = columns
+	return u
+}
+
 // StringReplace is only applicable when using *Union as a template.
 // StringReplace replaces the `key` with one of the `values`. Each value defines
 // a generated SELECT query. Repeating calls of StringReplace must provide the
@@ -271,6 +308,10 @@ func (u *Union) toSQL(w *bytes.Buffer, placeHolders []string) (_ []string, err e
 	u.source = dmlSourceUnion
 	u.Selects[0].id = u.id
 
+	if len(u.EmulateColumns) > 0 && (u.IsIntersect || u.IsExcept) {
+		return u.emulateToSQL(w, placeHolders)
+	}
+
 	if len(u.Selects) > 1 {
 		for i, s := range u.Selects {
 			if i > 0 {
@@ -313,6 +354,80 @@ func (u *Union) toSQL(w *bytes.Buffer, placeHolders []string) (_ []string, err e
 	return placeHolders, nil
 }
 
+// unionEmulatedAlias defines the alias of the derived table when emulating
+// INTERSECT and EXCEPT.
+const unionEmulatedAlias = "_emulated"
+
+// emulateToSQL writes INTERSECT as IN sub-queries and EXCEPT as correlated NOT
+// EXISTS sub-queries. NOT IN can't be used for EXCEPT because it returns no
+// rows as soon as the sub-query contains a NULL value.
+func (u *Union) emulateToSQL(w *bytes.Buffer, placeHolders []string) (_ []string, err error) {
+	if len(u.Selects) < 2 {
+		return nil, errors.NotSupported.Newf("[dml] Union.Emulate requires at least two Select statements, got %d. Template mode is not supported.", len(u.Selects))
+	}
+
+	w.WriteString("SELECT DISTINCT * FROM (")
+	if placeHolders, err = u.Selects[0].toSQL(w, placeHolders); err != nil {
+		return nil, errors.Wrap(err, "[dml] Union.Emulate at Select index 0")
+	}
+	w.WriteString(") AS ")
+	Quoter.quote(w, unionEmulatedAlias)
+
+	for i, s := range u.Selects[1:] {
+		if i == 0 {
+			w.WriteString(" WHERE ")
+		} else {
+			w.WriteString(" AND ")
+		}
+		if u.IsExcept {
+			if placeHolders, err = u.emulateExceptToSQL(w, placeHolders, s, i+1); err != nil {
+				return nil, errors.Wrapf(err, "[dml] Union.Emulate at Select index %d", i+1)
+			}
+			continue
+		}
+		w.WriteByte('(')
+		for j, c := range u.EmulateColumns {
+			if j > 0 {
+				w.WriteByte(',')
+			}
+			Quoter.writeQualifierName(w, unionEmulatedAlias, c)
+		}
+		w.WriteString(") IN (")
+		if placeHolders, err = s.toSQL(w, placeHolders); err != nil {
+			return nil, errors.Wrapf(err, "[dml] Union.Emulate at Select index %d", i+1)
+		}
+		w.WriteByte(')')
+	}
+	sqlWriteOrderBy(w, u.OrderBys, true)
+	return placeHolders, nil
+}
+
+// emulateExceptToSQL writes a NOT EXISTS sub-query which compares the columns
+// of Select s NULL-safe with the columns of the derived table.
+func (u *Union) emulateExceptToSQL(w *bytes.Buffer, placeHolders []string, s *Select, idx int) (_ []string, err error) {
+	alias := unionEmulatedAlias + strconv.Itoa(idx)
+	w.WriteString("NOT EXISTS (SELECT 1 FROM (")
+	if placeHolders, err = s.toSQL(w, placeHolders); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	w.WriteString(") AS ")
+	Quoter.quote(w, alias)
+	for j, c := range u.EmulateColumns {
+		if j == 0 {
+			w.WriteString(" WHERE ")
+		} else {
+			w.WriteString(" AND ")
+		}
+		w.WriteByte('(')
+		Quoter.writeQualifierName(w, alias, c)
+		w.WriteString(" <=> ")
+		Quoter.writeQualifierName(w, unionEmulatedAlias, c)
+		w.WriteByte(')')
+	}
+	w.WriteByte(')')
+	return placeHolders, nil
+}
+
 // Prepare executes the statement represented by the Union to create a prepared
 // statement. It returns a custom statement type or an error if there was one.
 // Provided arguments or records in the Union are getting ignored. The provided
@@ -340,5 +455,6 @@ func (u *Union) Clone() *Union {
 		}
 	}
 	c.OrderBys = u.OrderBys.Clone()
+	c.EmulateColumns = cloneStringSlice(u.EmulateColumns)
 	return &c
 }
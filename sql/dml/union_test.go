@@ -98,6 +98,41 @@ func TestUnion_Basics(t *testing.T) {
 			"(SELECT `a` FROM `tableAD`)\nEXCEPT\n(SELECT `b` FROM `tableAB`)",
 		)
 	})
+	t.Run("intersect emulated", func(t *testing.T) {
+		u := NewUnion(
+			NewSelect("a", "b").From("tableAD").Where(Column("a").Like().PlaceHolder()),
+			NewSelect("a", "b").From("tableAB"),
+			NewSelect("a", "b").From("tableAC"),
+		).Intersect().Emulate("a", "b").OrderBy("a").
+			WithArgs().String("XMEEN")
+
+		compareToSQL(t, u, errors.NoKind,
+			"SELECT DISTINCT * FROM (SELECT `a`, `b` FROM `tableAD` WHERE (`a` LIKE ?)) AS `_emulated` WHERE (`_emulated`.`a`,`_emulated`.`b`) IN (SELECT `a`, `b` FROM `tableAB`) AND (`_emulated`.`a`,`_emulated`.`b`) IN (SELECT `a`, `b` FROM `tableAC`)\nORDER BY `a`",
+			"SELECT DISTINCT * FROM (SELECT `a`, `b` FROM `tableAD` WHERE (`a` LIKE 'XMEEN')) AS `_emulated` WHERE (`_emulated`.`a`,`_emulated`.`b`) IN (SELECT `a`, `b` FROM `tableAB`) AND (`_emulated`.`a`,`_emulated`.`b`) IN (SELECT `a`, `b` FROM `tableAC`)\nORDER BY `a`",
+			"XMEEN",
+		)
+	})
+	t.Run("except emulated", func(t *testing.T) {
+		u := NewUnion(
+			NewSelect("a", "b").From("tableAD"),
+			NewSelect("a", "b").From("tableAB").Where(Column("c").PlaceHolder()),
+			NewSelect("a", "b").From("tableAC"),
+		).Except().Emulate("a", "b").
+			WithArgs().Int(3)
+
+		compareToSQL(t, u, errors.NoKind,
+			"SELECT DISTINCT * FROM (SELECT `a`, `b` FROM `tableAD`) AS `_emulated` WHERE NOT EXISTS (SELECT 1 FROM (SELECT `a`, `b` FROM `tableAB` WHERE (`c` = ?)) AS `_emulated1` WHERE (`_emulated1`.`a` <=> `_emulated`.`a`) AND (`_emulated1`.`b` <=> `_emulated`.`b`)) AND NOT EXISTS (SELECT 1 FROM (SELECT `a`, `b` FROM `tableAC`) AS `_emulated2` WHERE (`_emulated2`.`a` <=> `_emulated`.`a`) AND (`_emulated2`.`b` <=> `_emulated`.`b`))",
+			"SELECT DISTINCT * FROM (SELECT `a`, `b` FROM `tableAD`) AS `_emulated` WHERE NOT EXISTS (SELECT 1 FROM (SELECT `a`, `b` FROM `tableAB` WHERE (`c` = 3)) AS `_emulated1` WHERE (`_emulated1`.`a` <=> `_emulated`.`a`) AND (`_emulated1`.`b` <=> `_emulated`.`b`)) AND NOT EXISTS (SELECT 1 FROM (SELECT `a`, `b` FROM `tableAC`) AS `_emulated2` WHERE (`_emulated2`.`a` <=> `_emulated`.`a`) AND (`_emulated2`.`b` <=> `_emulated`.`b`))",
+			int64(3),
+		)
+	})
+	t.Run("emulated template not supported", func(t *testing.T) {
+		u := NewUnion(
+			NewSelect("a").From("tableAD"),
+		).Except().Emulate("a").StringReplace("tableAD", "tableAB", "tableAC")
+
+		compareToSQL(t, u, errors.NotSupported, "", "")
+	})
 
 	t.Run("placeholder question mark", func(t *testing.T) {
 		u := NewUnion(
@@ -17,9 +17,11 @@ package dml
 import (
 	"bytes"
 	"context"
+	"time"
 
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/log"
+	"github.com/corestoreio/pkg/storage/null"
 )
 
 // Update contains the logic for an UPDATE statement.
@@ -33,6 +35,8 @@ type Update struct {
 	// Listeners allows to dispatch certain functions in different
 	// situations.
 	Listeners ListenersUpdate
+	// versionColumn see function WithVersionColumn.
+	versionColumn string
 }
 
 // NewUpdate creates a new Update object.
@@ -47,6 +51,7 @@ func NewUpdate(table string) *Update {
 func newUpdate(db QueryExecPreparer, cComm *connCommon, table string) *Update {
 	id := cComm.makeUniqueID()
 	l := cComm.Log
+	softDeleteColumn := cComm.softDeleteColumns[table]
 	table = cComm.mapTableName(table)
 	if l != nil {
 		l = l.With(log.String("update_id", id), log.String("table", table))
@@ -54,11 +59,16 @@ func newUpdate(db QueryExecPreparer, cComm *connCommon, table string) *Update {
 	return &Update{
 		BuilderBase: BuilderBase{
 			builderCommon: builderCommon{
-				id:  id,
-				Log: l,
-				DB:  db,
+				id:         id,
+				Log:        l,
+				DB:         db,
+				tracer:     cComm.tracer,
+				stats:      cComm.stats,
+				timeFmt:    cComm.timeFmt,
+				commenters: cComm.commenters,
 			},
-			Table: MakeIdentifier(table),
+			Table:            MakeIdentifier(table),
+			softDeleteColumn: softDeleteColumn,
 		},
 	}
 }
@@ -91,6 +101,23 @@ func (b *Update) WithDB(db QueryExecPreparer) *Update {
 	return b
 }
 
+// WithTimeout sets a timeout for each query execution of the Artisan created by
+// WithArgs. The context passed to the Artisan gets wrapped with a deadline.
+// The optimizer hint MAX_EXECUTION_TIME does not get written because MySQL
+// supports it only for SELECT statements.
+func (b *Update) WithTimeout(d time.Duration) *Update {
+	b.timeout = d
+	return b
+}
+
+// Unscoped disables the soft delete mode for this statement, see
+// WithSoftDelete. This function must be called before generating the SQL
+// string.
+func (b *Update) Unscoped() *Update {
+	b.softDeleteColumn = ""
+	return b
+}
+
 // Unsafe see BuilderBase.IsUnsafe which weakens security when building the SQL
 // string. This function must be called before calling any other function.
 func (b *Update) Unsafe() *Update {
@@ -156,7 +183,97 @@ func (b *Update) Limit(limit uint64) *Update {
 // used in parallel. Each goroutine must have its own dedicated *Artisan
 // pointer.
 func (b *Update) WithArgs() *Artisan {
-	return b.withArtisan(b)
+	a := b.withArtisan(b)
+	a.updateVersionColumn = b.versionColumn
+	return a
+}
+
+// WithVersionColumn enables optimistic locking. It appends
+// `column`=`column`+1 to the SET clause and a WHERE condition `column` = ?
+// whose value gets derived from the record or the arguments. If the executed
+// statement affects zero rows, the row has been modified or deleted in the
+// meantime and the Artisan returns an error with kind errors.Mismatch. After a
+// successful update the incremented version gets written back into the
+// records via their ColumnMapper, see ColumnMapLastInsertID.
+//		UPDATE `customer` SET `name`=?, `version`=`version`+1 WHERE (`id` = ?) AND (`version` = ?)
+// This function must be called before WithArgs. Calling it again replaces the
+// previous version column.
+func (b *Update) WithVersionColumn(column string) *Update {
+	cnd := Column(column).PlaceHolder()
+	if b.versionColumn != "" {
+		for i, w := range b.Wheres {
+			if w.Left == b.versionColumn {
+				b.Wheres[i] = cnd
+				b.versionColumn = column
+				return b
+			}
+		}
+	}
+	b.versionColumn = column
+	b.Wheres = append(b.Wheres, cnd)
+	return b
+}
+
+// assignNextVersion writes the incremented version into the records of the
+// updated table. Records without the version column get skipped.
+func (a *Artisan) assignNextVersion() error {
+	col := a.updateVersionColumn
+	for _, rec := range a.recs {
+		if rec.Qualifier != "" && rec.Qualifier != a.base.defaultQualifier {
+			continue
+		}
+		cm := NewColumnMap(1, col)
+		if err := rec.Record.MapColumns(cm); err != nil {
+			if errors.NotFound.Match(err) || errors.NotSupported.Match(err) {
+				continue
+			}
+			return errors.WithStack(err)
+		}
+		if len(cm.arguments) == 0 {
+			continue
+		}
+		version, ok := versionValue(cm.arguments[0].value)
+		if !ok {
+			continue
+		}
+		cm.setLastInsertID(col, version+1)
+		if err := rec.Record.MapColumns(cm); err != nil && !errors.NotSupported.Match(err) {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// versionValue returns the value of an integer version column.
+func versionValue(v interface{}) (int64, bool) {
+	switch val := v.(type) {
+	case int64:
+		return val, true
+	case int:
+		return int64(val), true
+	case int32:
+		return int64(val), true
+	case uint64:
+		return int64(val), true
+	case uint:
+		return int64(val), true
+	case uint32:
+		return int64(val), true
+	case uint16:
+		return int64(val), true
+	case null.Int64:
+		return val.Int64, val.Valid
+	case null.Uint64:
+		return int64(val.Uint64), val.Valid
+	}
+	return 0, false
+}
+
+func (b *Update) afterExecListener() func() error {
+	if len(b.Listeners) == 0 {
+		return nil
+	}
+	return func() error { return b.Listeners.dispatch(OnAfterExec, b) }
 }
 
 // ToSQL converts the select statement into a string and returns its arguments.
@@ -211,9 +328,17 @@ func (b *Update) toSQL(buf *bytes.Buffer, placeHolders []string) ([]string, erro
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	if b.versionColumn != "" {
+		buf.WriteString(", ")
+		Quoter.quote(buf, b.versionColumn)
+		buf.WriteByte('=')
+		Quoter.quote(buf, b.versionColumn)
+		buf.WriteString("+1")
+	}
 
 	// Write WHERE clause if we have any fragments
-	placeHolders, err = b.Wheres.write(buf, 'w', placeHolders)
+	wheres := softDeleteWheres(b.Wheres, b.Table.qualifier(), b.softDeleteColumn)
+	placeHolders, err = wheres.write(buf, 'w', placeHolders)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -243,5 +368,121 @@ func (b *Update) Clone() *Update {
 	c.BuilderBase = b.BuilderBase.Clone()
 	c.BuilderConditional = b.BuilderConditional.Clone()
 	c.SetClauses = b.SetClauses.Clone()
+	c.Listeners = b.Listeners.Clone()
 	return &c
 }
+
+// WithCaseWhen creates a bulk UPDATE statement which updates all entities of
+// the provided ColumnMapper collection within one single query. Argument
+// primaryKey defines the column name to identify a row and columns the names of
+// the columns which should get updated. The collection must support the
+// ColumnMapEntityReadSet mode and must return the values in the order of
+// primaryKey followed by columns. The generated SQL looks like:
+//		UPDATE `table` SET `col1`=CASE `pk` WHEN ? THEN ? WHEN ? THEN ? ELSE `col1` END,
+//			`col2`=CASE `pk` WHEN ? THEN ? WHEN ? THEN ? ELSE `col2` END WHERE (`pk` IN (?,?))
+// Additional WHERE conditions and SET clauses of the Update object get
+// considered but must not contain place holders. The SQL string gets not
+// cached because it depends on the number of entities in the collection.
+func (b *Update) WithCaseWhen(primaryKey string, collection ColumnMapper, columns ...string) *Artisan {
+	var args [defaultArgumentsCapacity]argument
+	a := &Artisan{
+		arguments: args[:0],
+	}
+	b.rwmu.RLock()
+	a.base = b.builderCommon
+	sqlBytes, collectedArgs, err := b.caseWhenToSQL(primaryKey, collection, columns)
+	b.rwmu.RUnlock()
+	a.base.source = dmlSourceUpdate
+	a.base.cachedSQL = sqlBytes
+	a.base.qualifiedColumns = nil
+	a.base.ärgErr = errors.WithStack(err)
+	a.arguments = append(a.arguments, collectedArgs...)
+	return a
+}
+
+func (b *Update) caseWhenToSQL(primaryKey string, collection ColumnMapper, columns []string) ([]byte, arguments, error) {
+	if err := b.Listeners.dispatch(OnBeforeToSQL, b); err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	switch {
+	case len(b.Table.Name) == 0:
+		return nil, nil, errors.Empty.Newf("[dml] Update.WithCaseWhen: Table at empty")
+	case primaryKey == "":
+		return nil, nil, errors.Empty.Newf("[dml] Update.WithCaseWhen: Primary key column is empty")
+	case len(columns) == 0:
+		return nil, nil, errors.Empty.Newf("[dml] Update.WithCaseWhen: No columns specified")
+	case collection == nil:
+		return nil, nil, errors.Empty.Newf("[dml] Update.WithCaseWhen: ColumnMapper collection is nil")
+	}
+
+	allColumns := make([]string, 0, len(columns)+1)
+	allColumns = append(allColumns, primaryKey)
+	allColumns = append(allColumns, columns...)
+	cm := NewColumnMap(defaultArgumentsCapacity, allColumns...)
+	if err := collection.MapColumns(cm); err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	colCount := len(allColumns)
+	rowCount := len(cm.arguments) / colCount
+	switch {
+	case rowCount == 0:
+		return nil, nil, errors.Empty.Newf("[dml] Update.WithCaseWhen: ColumnMapper collection contains no entities")
+	case len(cm.arguments)%colCount != 0:
+		return nil, nil, errors.Mismatch.Newf("[dml] Update.WithCaseWhen: Argument count %d does not match column count %d", len(cm.arguments), colCount)
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString("UPDATE ")
+	writeStmtID(buf, b.id)
+	_, _ = b.Table.writeQuoted(buf, nil)
+	buf.WriteString(" SET ")
+
+	if len(b.SetClauses) > 0 {
+		ph, err := b.SetClauses.writeSetClauses(buf, nil)
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		if len(ph) > 0 {
+			return nil, nil, errors.NotSupported.Newf("[dml] Update.WithCaseWhen: SET clauses with place holders are not supported: %v", ph)
+		}
+		buf.WriteString(", ")
+	}
+
+	// for each column: (pk, value) pairs and at the end the primary keys for
+	// the IN clause.
+	collectedArgs := make(arguments, 0, len(columns)*rowCount*2+rowCount)
+	for ci, col := range columns {
+		if ci > 0 {
+			buf.WriteString(", ")
+		}
+		Quoter.quote(buf, col)
+		buf.WriteString("=CASE ")
+		Quoter.quote(buf, primaryKey)
+		for ri := 0; ri < rowCount; ri++ {
+			buf.WriteString(" WHEN ? THEN ?")
+			collectedArgs = append(collectedArgs, cm.arguments[ri*colCount], cm.arguments[ri*colCount+ci+1])
+		}
+		buf.WriteString(" ELSE ")
+		Quoter.quote(buf, col)
+		buf.WriteString(" END")
+	}
+	for ri := 0; ri < rowCount; ri++ {
+		collectedArgs = append(collectedArgs, cm.arguments[ri*colCount])
+	}
+
+	wheres := make(Conditions, 0, len(b.Wheres)+1)
+	wheres = append(wheres, b.Wheres...)
+	wheres = append(wheres, Column(primaryKey).In().PlaceHolders(rowCount))
+	ph, err := wheres.write(buf, 'w', nil)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	if len(ph) != 1 {
+		return nil, nil, errors.NotSupported.Newf("[dml] Update.WithCaseWhen: WHERE conditions with place holders are not supported: %v", ph)
+	}
+
+	sqlWriteOrderBy(buf, b.OrderBys, false)
+	sqlWriteLimitOffset(buf, b.LimitValid, false, 0, b.LimitCount)
+	return buf.Bytes(), collectedArgs, nil
+}
@@ -382,3 +382,53 @@ func TestUpdate_Clone(t *testing.T) {
 		assert.Exactly(t, d.Log, d2.Log)
 	})
 }
+
+type versionedCustomer struct {
+	ID      int64
+	Name    string
+	Version int64
+}
+
+func (vc *versionedCustomer) MapColumns(cm *dml.ColumnMap) error {
+	for cm.Next() {
+		switch c := cm.Column(); c {
+		case "id":
+			cm.Int64(&vc.ID)
+		case "name":
+			cm.String(&vc.Name)
+		case "version":
+			cm.Int64(&vc.Version)
+		default:
+			return errors.NotFound.Newf("[dml_test] Column %q not found", c)
+		}
+	}
+	return cm.Err()
+}
+
+func TestUpdate_WithVersionColumn(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	const wantSQL = "UPDATE `customer_entity` SET `name`=?, `version`=`version`+1 WHERE (`id` = ?) AND (`version` = ?)"
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta(wantSQL)).WithArgs("Gopher", 33, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta(wantSQL)).WithArgs("Gopher", 33, 5).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	upd := dbc.Update("customer_entity").AddColumns("name").
+		Where(dml.Column("id").PlaceHolder()).
+		WithVersionColumn("revision").
+		WithVersionColumn("version")
+
+	vc := &versionedCustomer{ID: 33, Name: "Gopher", Version: 4}
+	res, err := upd.WithArgs().Record("", vc).ExecContext(context.TODO())
+	assert.NoError(t, err)
+	ra, err := res.RowsAffected()
+	assert.NoError(t, err)
+	assert.Exactly(t, int64(1), ra)
+	assert.Exactly(t, int64(5), vc.Version, "version must be incremented")
+
+	_, err = upd.WithArgs().Record("", vc).ExecContext(context.TODO())
+	assert.True(t, errors.Mismatch.Match(err), "%+v", err)
+	assert.Exactly(t, int64(5), vc.Version, "version must not change")
+}
@@ -372,3 +372,93 @@ func TestUpdate_DisableBuildCache(t *testing.T) {
 	}
 
 }
+
+func TestUpdate_WithCaseWhen(t *testing.T) {
+	t.Parallel()
+
+	persons := &dmlPersons{
+		Data: []*dmlPerson{
+			{ID: 1, Name: "Alf", Email: null.MakeString("alf@m.el")},
+			{ID: 2, Name: "John", Email: null.MakeString("john@doe.com")},
+		},
+	}
+
+	t.Run("two columns", func(t *testing.T) {
+		u := NewUpdate("dml_person").WithCaseWhen("id", persons, "name", "email")
+		compareToSQL(t, u, errors.NoKind,
+			"UPDATE `dml_person` SET `name`=CASE `id` WHEN ? THEN ? WHEN ? THEN ? ELSE `name` END, `email`=CASE `id` WHEN ? THEN ? WHEN ? THEN ? ELSE `email` END WHERE (`id` IN (?,?))",
+			"UPDATE `dml_person` SET `name`=CASE `id` WHEN 1 THEN 'Alf' WHEN 2 THEN 'John' ELSE `name` END, `email`=CASE `id` WHEN 1 THEN 'alf@m.el' WHEN 2 THEN 'john@doe.com' ELSE `email` END WHERE (`id` IN (1,2))",
+			int64(1), "Alf", int64(2), "John", int64(1), "alf@m.el", int64(2), "john@doe.com", int64(1), int64(2),
+		)
+	})
+	t.Run("with additional SET and WHERE", func(t *testing.T) {
+		u := NewUpdate("dml_person").
+			Set(Column("store_id").Int(3)).
+			Where(Column("key").NotNull()).
+			WithCaseWhen("id", persons, "name")
+		compareToSQL(t, u, errors.NoKind,
+			"UPDATE `dml_person` SET `store_id`=3, `name`=CASE `id` WHEN ? THEN ? WHEN ? THEN ? ELSE `name` END WHERE (`key` IS NOT NULL) AND (`id` IN (?,?))",
+			"UPDATE `dml_person` SET `store_id`=3, `name`=CASE `id` WHEN 1 THEN 'Alf' WHEN 2 THEN 'John' ELSE `name` END WHERE (`key` IS NOT NULL) AND (`id` IN (1,2))",
+			int64(1), "Alf", int64(2), "John", int64(1), int64(2),
+		)
+	})
+	t.Run("WHERE with place holder fails", func(t *testing.T) {
+		u := NewUpdate("dml_person").
+			Where(Column("key").PlaceHolder()).
+			WithCaseWhen("id", persons, "name")
+		compareToSQL(t, u, errors.NotSupported, "", "")
+	})
+	t.Run("no columns", func(t *testing.T) {
+		u := NewUpdate("dml_person").WithCaseWhen("id", persons)
+		compareToSQL(t, u, errors.Empty, "", "")
+	})
+	t.Run("empty collection", func(t *testing.T) {
+		u := NewUpdate("dml_person").WithCaseWhen("id", &dmlPersons{}, "name")
+		compareToSQL(t, u, errors.Empty, "", "")
+	})
+	t.Run("column not found", func(t *testing.T) {
+		u := NewUpdate("dml_person").WithCaseWhen("id", persons, "nameXXX")
+		compareToSQL(t, u, errors.NotFound, "", "")
+	})
+}
+
+func TestUpdate_WithVersionColumn_ToSQL(t *testing.T) {
+	t.Parallel()
+
+	compareToSQL(t,
+		NewUpdate("customer").Set(Column("name").Str("Gopher")).
+			Where(Column("id").Int64(3)).
+			WithVersionColumn("version").
+			WithArgs().Int64(7),
+		errors.NoKind,
+		"UPDATE `customer` SET `name`='Gopher', `version`=`version`+1 WHERE (`id` = 3) AND (`version` = ?)",
+		"UPDATE `customer` SET `name`='Gopher', `version`=`version`+1 WHERE (`id` = 3) AND (`version` = 7)",
+		int64(7),
+	)
+}
+
+func TestUpdate_SetSubselect(t *testing.T) {
+	t.Parallel()
+
+	sel := NewSelect().AddColumnsConditions(Expr("SUM(`si`.`qty`)")).
+		FromAlias("cataloginventory_stock_item", "si").
+		Where(
+			Column("si.product_id").Equal().Column("cpe.entity_id"),
+			Column("si.website_id").PlaceHolder(),
+		)
+
+	u := NewUpdate("catalog_product_entity").Alias("cpe").
+		Set(
+			Column("stock").Set(Subselect(sel)),
+			Column("updated_by").PlaceHolder(),
+		).
+		Where(Column("cpe.entity_id").PlaceHolder()).
+		WithArgs().Int64(1).String("cron").Int64(3)
+
+	compareToSQL(t, u, errors.NoKind,
+		"UPDATE `catalog_product_entity` AS `cpe` SET `stock`=(SELECT SUM(`si`.`qty`) FROM `cataloginventory_stock_item` AS `si` WHERE (`si`.`product_id` = `cpe`.`entity_id`) AND (`si`.`website_id` = ?)), `updated_by`=? WHERE (`cpe`.`entity_id` = ?)",
+		"UPDATE `catalog_product_entity` AS `cpe` SET `stock`=(SELECT SUM(`si`.`qty`) FROM `cataloginventory_stock_item` AS `si` WHERE (`si`.`product_id` = `cpe`.`entity_id`) AND (`si`.`website_id` = 1)), `updated_by`='cron' WHERE (`cpe`.`entity_id` = 3)",
+		int64(1), "cron", int64(3),
+	)
+	assert.Exactly(t, []string{"si.website_id", "updated_by", "cpe.entity_id"}, u.base.qualifiedColumns)
+}
@@ -100,9 +100,13 @@ func (c *ConnPool) With(expressions ...WithCTE) *With {
 	return &With{
 		BuilderBase: BuilderBase{
 			builderCommon: builderCommon{
-				id:  id,
-				Log: withInitLog(c.Log, expressions, id),
-				DB:  c.DB,
+				id:         id,
+				Log:        withInitLog(c.Log, expressions, id),
+				DB:         c.DB,
+				tracer:     c.tracer,
+				stats:      c.stats,
+				timeFmt:    c.timeFmt,
+				commenters: c.commenters,
 			},
 		},
 		Subclauses: expressions,
@@ -115,9 +119,13 @@ func (c *Conn) With(expressions ...WithCTE) *With {
 	return &With{
 		BuilderBase: BuilderBase{
 			builderCommon: builderCommon{
-				id:  id,
-				Log: withInitLog(c.Log, expressions, id),
-				DB:  c.DB,
+				id:         id,
+				Log:        withInitLog(c.Log, expressions, id),
+				DB:         c.DB,
+				tracer:     c.tracer,
+				stats:      c.stats,
+				timeFmt:    c.timeFmt,
+				commenters: c.commenters,
 			},
 		},
 		Subclauses: expressions,
@@ -130,9 +138,13 @@ func (tx *Tx) With(expressions ...WithCTE) *With {
 	return &With{
 		BuilderBase: BuilderBase{
 			builderCommon: builderCommon{
-				id:  id,
-				Log: withInitLog(tx.Log, expressions, id),
-				DB:  tx.DB,
+				id:         id,
+				Log:        withInitLog(tx.Log, expressions, id),
+				DB:         tx.DB,
+				tracer:     tx.tracer,
+				stats:      tx.stats,
+				timeFmt:    tx.timeFmt,
+				commenters: tx.commenters,
 			},
 		},
 		Subclauses: expressions,
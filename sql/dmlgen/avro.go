@@ -0,0 +1,137 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlgen
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/util/strs"
+)
+
+// avroRecord represents an Avro schema of type record.
+type avroRecord struct {
+	Type      string      `json:"type"`
+	Name      string      `json:"name"`
+	Namespace string      `json:"namespace,omitempty"`
+	Doc       string      `json:"doc,omitempty"`
+	Fields    []avroField `json:"fields"`
+}
+
+type avroField struct {
+	Name    string      `json:"name"`
+	Type    interface{} `json:"type"`
+	Doc     string      `json:"doc,omitempty"`
+	Default interface{} `json:"default,omitempty"`
+}
+
+// avroNullDefault gets marshaled as JSON null because Default uses omitempty.
+type avroNullDefault struct{}
+
+func (avroNullDefault) MarshalJSON() ([]byte, error) { return []byte(`null`), nil }
+
+// avroLogicalType represents an Avro primitive type annotated with a logical
+// type like date, timestamp-micros or decimal.
+type avroLogicalType struct {
+	Type        string `json:"type"`
+	LogicalType string `json:"logicalType"`
+	Precision   int64  `json:"precision,omitempty"`
+	Scale       int64  `json:"scale,omitempty"`
+}
+
+// toAvroType maps the Go type of a column to an Avro type. The integers of
+// MySQL type bigint and unsigned int use long, times use the logical types
+// date and timestamp-micros and decimals the logical type decimal with the
+// precision and scale of the column. Custom Go types are mapped to string.
+// Nullable columns are a union with null.
+func (ct *customTypes) toAvroType(c *ddl.Column) interface{} {
+	goType := strings.TrimPrefix(ct.toGoTypeNull(c), "*")
+	goType = strings.TrimPrefix(goType, "null.")
+
+	var at interface{}
+	switch goType {
+	case "string", "String":
+		at = "string"
+	case "int64", "uint64", "Int64":
+		at = "long"
+		if _, ok := intRanges[c.DataType]; ok && !(c.IsUnsigned() && c.DataType == "int") {
+			at = "int"
+		}
+	case "float64", "Float64":
+		at = "double"
+	case "bool", "Bool":
+		at = "boolean"
+	case "time.Time", "Time":
+		at = avroLogicalType{Type: "long", LogicalType: "timestamp-micros"}
+		if c.DataType == "date" {
+			at = avroLogicalType{Type: "int", LogicalType: "date"}
+		}
+	case "Decimal":
+		at = avroLogicalType{Type: "bytes", LogicalType: "decimal", Precision: c.Precision.Int64, Scale: c.Scale.Int64}
+	case "[]byte":
+		at = "bytes"
+	default:
+		at = "string"
+	}
+	if c.IsNull() {
+		return []interface{}{"null", at}
+	}
+	return at
+}
+
+// WriteAvro writes the Avro schema of type record for table `tableName` into
+// `w`. The record contains a field, named like the column, for each column
+// and the types match the generated Go struct. Such schemas can be registered
+// in a schema registry to publish typed messages, for example in a binlogsync
+// handler. Only tables with the option Avro are getting written, all others
+// return a NotAcceptable error.
+func (ts *Tables) WriteAvro(w io.Writer, tableName string) error {
+	t, ok := ts.Tables[tableName]
+	switch {
+	case !ok:
+		return errors.NotFound.Newf("[dmlgen] WriteAvro: Table %q not found", tableName)
+	case !t.Avro:
+		return errors.NotAcceptable.Newf("[dmlgen] WriteAvro: Avro generation not enabled for table %q", tableName)
+	}
+
+	rec := avroRecord{
+		Type:      "record",
+		Name:      strs.ToGoCamelCase(t.TableName),
+		Namespace: ts.Package,
+		Doc:       "Represents a single row for DB table " + t.TableName + ". Auto generated.",
+		Fields:    make([]avroField, 0, len(t.Columns)),
+	}
+	for _, c := range t.Columns {
+		f := avroField{
+			Name: c.Field,
+			Type: ts.customTypes.toAvroType(c),
+			Doc:  c.Comment,
+		}
+		if c.IsNull() {
+			f.Default = avroNullDefault{}
+		}
+		rec.Fields = append(rec.Fields, f)
+	}
+
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = w.Write(append(data, '\n'))
+	return errors.WithStack(err)
+}
@@ -0,0 +1,125 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlgen
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/corestoreio/errors"
+)
+
+// checksumPrefix returns the start of the first line of a file written by
+// WriteFile. The line contains the SHA256 checksum of the remaining content.
+// GraphQL schema files use the hash sign as comment. Avro schema files are
+// JSON, which does not support comments, and get no checksum header.
+func checksumPrefix(fileName string) string {
+	switch filepath.Ext(fileName) {
+	case ".graphql":
+		return "# dmlgen checksum sha256:"
+	case ".avsc":
+		return ""
+	}
+	return "// dmlgen checksum sha256:"
+}
+
+func checksum(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+// splitChecksum returns the checksum of the header line and the content
+// below. The checksum is empty if the header line is missing. Without a prefix
+// the checksum of the whole content gets returned.
+func splitChecksum(prefix string, data []byte) (sum string, body []byte) {
+	if prefix == "" {
+		return checksum(data), data
+	}
+	nl := bytes.IndexByte(data, '\n')
+	if !bytes.HasPrefix(data, []byte(prefix)) || nl < 0 {
+		return "", data
+	}
+	return string(data[len(prefix):nl]), data[nl+1:]
+}
+
+// WriteFile renders the content via function w, for example Tables.WriteGo or
+// Tables.WriteProto, and writes it with a checksum header line into fileName.
+// The file does not get rewritten if its checksum matches the rendered
+// content and it has not been modified manually. This keeps the modification
+// time and VCS diffs stable when running go:generate repeatedly. Written
+// reports whether the file has been written.
+func WriteFile(fileName string, w func(io.Writer) error) (written bool, err error) {
+	var buf bytes.Buffer
+	if err := w(&buf); err != nil {
+		return false, errors.WithStack(err)
+	}
+	sum := checksum(buf.Bytes())
+	prefix := checksumPrefix(fileName)
+
+	if current, err := ioutil.ReadFile(fileName); err == nil {
+		if curSum, body := splitChecksum(prefix, current); curSum == sum && checksum(body) == sum {
+			return false, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return false, errors.ReadFailed.New(err, "[dmlgen] WriteFile: Failed to read file %q", fileName)
+	}
+
+	data := make([]byte, 0, len(prefix)+len(sum)+1+buf.Len())
+	if prefix != "" {
+		data = append(data, prefix...)
+		data = append(data, sum...)
+		data = append(data, '\n')
+	}
+	data = append(data, buf.Bytes()...)
+	if err := ioutil.WriteFile(fileName, data, 0644); err != nil {
+		return false, errors.WriteFailed.New(err, "[dmlgen] WriteFile: Failed to write file %q", fileName)
+	}
+	return true, nil
+}
+
+// VerifyFile renders the content via function w and checks whether the file,
+// written by WriteFile, is up to date. It returns a NotFound error if the file
+// does not exist and a Mismatch error if the file has been modified manually
+// or its content is outdated. The file does not get changed.
+func VerifyFile(fileName string, w func(io.Writer) error) error {
+	var buf bytes.Buffer
+	if err := w(&buf); err != nil {
+		return errors.WithStack(err)
+	}
+
+	current, err := ioutil.ReadFile(fileName)
+	switch {
+	case os.IsNotExist(err):
+		return errors.NotFound.New(err, "[dmlgen] VerifyFile: File %q does not exist", fileName)
+	case err != nil:
+		return errors.ReadFailed.New(err, "[dmlgen] VerifyFile: Failed to read file %q", fileName)
+	}
+
+	curSum, body := splitChecksum(checksumPrefix(fileName), current)
+	switch {
+	case curSum == "":
+		return errors.Mismatch.Newf("[dmlgen] VerifyFile: File %q has no checksum header", fileName)
+	case checksum(body) != curSum:
+		return errors.Mismatch.Newf("[dmlgen] VerifyFile: File %q has been modified manually", fileName)
+	case curSum != checksum(buf.Bytes()):
+		return errors.Mismatch.Newf("[dmlgen] VerifyFile: File %q is outdated", fileName)
+	}
+	return nil
+}
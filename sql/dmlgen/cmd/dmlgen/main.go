@@ -0,0 +1,319 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command dmlgen generates the Go source code, and optionally the protocol
+// buffer files, the GraphQL schema and the Avro schemas, for database tables. It wraps package
+// sql/dmlgen and can be used with go:generate. All settings can be loaded from
+// a TOML file and partially overwritten by flags.
+//
+// Example usage:
+//
+//	//go:generate dmlgen -config dmlgen.toml
+//	$ dmlgen -dsn "user:pass@tcp(localhost:3306)/magento?parseTime=true" \
+//		-package customer -tables customer_entity,customer_address_entity
+//
+// Example TOML file:
+//
+//	dsn = "user:pass@tcp(localhost:3306)/magento?parseTime=true"
+//	package = "customer"
+//	output_dir = "."
+//	tables = ["customer_entity", "customer_address_entity"]
+//	foreign_key_relations = true
+//
+//	[types.text] # overrides the mapping of a MySQL data type
+//	go = "[]byte"
+//	go_null = "[]byte"
+//	proto = "bytes"
+//	proto_null = "bytes"
+//
+//	[table.customer_entity]
+//	encoders = ["text", "protobuf"]
+//	struct_tags = ["json"]
+//	crud = true
+//	validate = true
+//	faker = true
+//	graphql = true
+//	avro = true
+//
+//	[table.customer_entity.custom_types."*_at datetime"]
+//	go = "time.Time"
+//	go_null = "null.Time"
+//
+// If the DSN is empty, it gets loaded from the environment variable CS_DSN.
+//
+// Each generated file starts with a checksum of its content. Unchanged files
+// do not get rewritten. The flag -verify checks, without writing, whether the
+// files are up to date with the database and have not been modified manually.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/sql/dmlgen"
+)
+
+var (
+	flagConfig    = flag.String("config", "", "path to the TOML configuration file")
+	flagDSN       = flag.String("dsn", "", "data source name, falls back to the environment variable "+dml.EnvDSN)
+	flagPackage   = flag.String("package", "", "name of the generated Go package")
+	flagTables    = flag.String("tables", "", "comma separated list of table names")
+	flagOutputDir = flag.String("output", "", "directory to write the generated files to (default current directory)")
+	flagRelations = flag.Bool("relations", false, "generate LoadRelated methods for the foreign key relations")
+	flagProtoc    = flag.Bool("protoc", false, "run protoc to generate the Go code of the proto files")
+	flagVerify    = flag.Bool("verify", false, "verify that the generated files are up to date, without writing them")
+)
+
+// typeDef configures a custom type. The signed and unsigned types are equal.
+type typeDef struct {
+	Go            string `toml:"go"`
+	GoNull        string `toml:"go_null"`
+	Proto         string `toml:"proto"`
+	ProtoNull     string `toml:"proto_null"`
+	ColumnMapFunc string `toml:"column_map_func"`
+}
+
+func (td typeDef) toTypeDef() *dmlgen.TypeDef {
+	if td.GoNull == "" {
+		td.GoNull = td.Go
+	}
+	if td.ProtoNull == "" {
+		td.ProtoNull = td.Proto
+	}
+	return &dmlgen.TypeDef{
+		MysqlUnsignedNull:       td.GoNull,
+		MysqlUnsignedNotNull:    td.Go,
+		MysqlSignedNull:         td.GoNull,
+		MysqlSignedNotNull:      td.Go,
+		ProtobufUnsignedNull:    td.ProtoNull,
+		ProtobufUnsignedNotNull: td.Proto,
+		ProtobufSignedNull:      td.ProtoNull,
+		ProtobufSignedNotNull:   td.Proto,
+		ColumnMapFunc:           td.ColumnMapFunc,
+	}
+}
+
+// tableConfig maps to dmlgen.TableOption.
+type tableConfig struct {
+	Encoders          []string            `toml:"encoders"`
+	StructTags        []string            `toml:"struct_tags"`
+	CustomStructTags  []string            `toml:"custom_struct_tags"`
+	Comment           string              `toml:"comment"`
+	ColumnAliases     map[string][]string `toml:"column_aliases"`
+	UniquifiedColumns []string            `toml:"uniquified_columns"`
+	CRUD              bool                `toml:"crud"`
+	Validate          bool                `toml:"validate"`
+	Faker             bool                `toml:"faker"`
+	GraphQL           bool                `toml:"graphql"`
+	Avro              bool                `toml:"avro"`
+	CustomTypes       map[string]typeDef  `toml:"custom_types"`
+}
+
+type config struct {
+	DSN                 string                 `toml:"dsn"`
+	Package             string                 `toml:"package"`
+	OutputDir           string                 `toml:"output_dir"`
+	Tables              []string               `toml:"tables"`
+	ForeignKeyRelations bool                   `toml:"foreign_key_relations"`
+	Protoc              bool                   `toml:"protoc"`
+	Types               map[string]typeDef     `toml:"types"`
+	Table               map[string]tableConfig `toml:"table"`
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  Either a config file or the flags package and tables are required.\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %+v\n", err)
+		os.Exit(1)
+	}
+}
+
+// loadConfig reads the optional TOML file and applies the flags on top.
+func loadConfig() (*config, error) {
+	cfg := new(config)
+	if *flagConfig != "" {
+		if _, err := toml.DecodeFile(*flagConfig, cfg); err != nil {
+			return nil, errors.NotValid.New(err, "[dmlgen] Failed to decode config file %q", *flagConfig)
+		}
+	}
+	if *flagDSN != "" {
+		cfg.DSN = *flagDSN
+	}
+	if cfg.DSN == "" {
+		cfg.DSN = os.Getenv(dml.EnvDSN)
+	}
+	if *flagPackage != "" {
+		cfg.Package = *flagPackage
+	}
+	if *flagTables != "" {
+		cfg.Tables = strings.Split(*flagTables, ",")
+	}
+	if *flagOutputDir != "" {
+		cfg.OutputDir = *flagOutputDir
+	}
+	if cfg.OutputDir == "" {
+		cfg.OutputDir = "."
+	}
+	cfg.ForeignKeyRelations = cfg.ForeignKeyRelations || *flagRelations
+	cfg.Protoc = cfg.Protoc || *flagProtoc
+
+	switch {
+	case cfg.DSN == "":
+		return nil, errors.Empty.Newf("[dmlgen] DSN cannot be empty. Set flag -dsn or the environment variable %q", dml.EnvDSN)
+	case cfg.Package == "":
+		return nil, errors.Empty.Newf("[dmlgen] Package name cannot be empty.")
+	case len(cfg.Tables) == 0:
+		return nil, errors.Empty.Newf("[dmlgen] Table names cannot be empty.")
+	}
+	return cfg, nil
+}
+
+// options converts the configuration into dmlgen options.
+func (cfg *config) options(ctx context.Context, db dml.Querier) []dmlgen.Option {
+	var opts []dmlgen.Option
+	if len(cfg.Types) > 0 {
+		types := make(map[string]*dmlgen.TypeDef, len(cfg.Types))
+		for dataType, td := range cfg.Types {
+			types[dataType] = td.toTypeDef()
+		}
+		opts = append(opts, dmlgen.WithCustomTypes(types))
+	}
+	for tableName, tc := range cfg.Table {
+		to := &dmlgen.TableOption{
+			Encoders:          tc.Encoders,
+			StructTags:        tc.StructTags,
+			CustomStructTags:  tc.CustomStructTags,
+			Comment:           tc.Comment,
+			ColumnAliases:     tc.ColumnAliases,
+			UniquifiedColumns: tc.UniquifiedColumns,
+			CRUD:              tc.CRUD,
+			Validate:          tc.Validate,
+			Faker:             tc.Faker,
+			GraphQL:           tc.GraphQL,
+			Avro:              tc.Avro,
+		}
+		if len(tc.CustomTypes) > 0 {
+			to.CustomTypes = make(map[string]*dmlgen.TypeDef, len(tc.CustomTypes))
+			for pattern, td := range tc.CustomTypes {
+				to.CustomTypes[pattern] = td.toTypeDef()
+			}
+		}
+		opts = append(opts, dmlgen.WithTableOption(tableName, to))
+	}
+	if cfg.ForeignKeyRelations {
+		opts = append(opts, dmlgen.WithForeignKeyRelations(ctx, db))
+	}
+	return opts
+}
+
+func run(ctx context.Context) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		flag.Usage()
+		return errors.WithStack(err)
+	}
+
+	dbc, err := dml.NewConnPool(dml.WithDSN(cfg.DSN))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer dbc.Close()
+
+	ts, err := dmlgen.NewGenerator(ctx, dbc.DB, cfg.Package, cfg.Tables, cfg.options(ctx, dbc.DB)...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	goFile := filepath.Join(cfg.OutputDir, cfg.Package+"_gen.go")
+	protoFile := filepath.Join(cfg.OutputDir, cfg.Package+"_gen.proto")
+	graphQLFile := filepath.Join(cfg.OutputDir, cfg.Package+"_gen.graphql")
+
+	if *flagVerify {
+		if err := dmlgen.VerifyFile(goFile, ts.WriteGo); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := dmlgen.VerifyFile(protoFile, ts.WriteProto); err != nil && !errors.NotAcceptable.Match(err) {
+			return errors.WithStack(err)
+		}
+		if err := dmlgen.VerifyFile(graphQLFile, ts.WriteGraphQL); err != nil && !errors.NotAcceptable.Match(err) {
+			return errors.WithStack(err)
+		}
+		for _, tableName := range cfg.Tables {
+			if err := dmlgen.VerifyFile(avroFile(cfg, tableName), avroWriter(ts, tableName)); err != nil && !errors.NotAcceptable.Match(err) {
+				return errors.WithStack(err)
+			}
+		}
+		return nil
+	}
+
+	if err := writeFile(goFile, ts.WriteGo); err != nil {
+		return errors.WithStack(err)
+	}
+	// NotAcceptable: no table uses the GraphQL option.
+	if err := writeFile(graphQLFile, ts.WriteGraphQL); err != nil && !errors.NotAcceptable.Match(err) {
+		return errors.WithStack(err)
+	}
+	for _, tableName := range cfg.Tables {
+		// NotAcceptable: the table does not use the Avro option.
+		if err := writeFile(avroFile(cfg, tableName), avroWriter(ts, tableName)); err != nil && !errors.NotAcceptable.Match(err) {
+			return errors.WithStack(err)
+		}
+	}
+
+	err = writeFile(protoFile, ts.WriteProto)
+	switch {
+	case errors.NotAcceptable.Match(err):
+		return nil // no table uses the protobuf encoder
+	case err != nil:
+		return errors.WithStack(err)
+	case cfg.Protoc:
+		return errors.WithStack(dmlgen.GenerateProto(cfg.OutputDir))
+	}
+	return nil
+}
+
+func avroFile(cfg *config, tableName string) string {
+	return filepath.Join(cfg.OutputDir, tableName+"_gen.avsc")
+}
+
+func avroWriter(ts *dmlgen.Tables, tableName string) func(io.Writer) error {
+	return func(w io.Writer) error {
+		return ts.WriteAvro(w, tableName)
+	}
+}
+
+func writeFile(fileName string, w func(io.Writer) error) error {
+	written, err := dmlgen.WriteFile(fileName, w)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if !written {
+		fmt.Fprintf(os.Stderr, "dmlgen: %s unchanged\n", fileName)
+	}
+	return nil
+}
@@ -18,29 +18,35 @@ func Make{{.Collection}}() {{.Collection}} {
 	}
 }
 
-func (cc {{.Collection}}) scanColumns(cm *dml.ColumnMap,e *{{.Entity}}, idx uint64) error {
-	if err := cc.BeforeMapColumns(idx, e); err != nil {
-		return errors.WithStack(err)
+func (cc *{{.Collection}}) scanColumns(cm *dml.ColumnMap,e *{{.Entity}}, idx uint64) error {
+	if cc.BeforeMapColumns != nil {
+		if err := cc.BeforeMapColumns(idx, e); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	if err := e.MapColumns(cm); err != nil {
 		return errors.WithStack(err)
 	}
-	if err := cc.AfterMapColumns(idx, e); err != nil {
-		return errors.WithStack(err)
+	if cc.AfterMapColumns != nil {
+		if err := cc.AfterMapColumns(idx, e); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	return nil
 }
 
 // MapColumns implements dml.ColumnMapper interface. Auto generated.
-func (cc {{.Collection}}) MapColumns(cm *dml.ColumnMap) error {
+func (cc *{{.Collection}}) MapColumns(cm *dml.ColumnMap) error {
 	switch m := cm.Mode(); m {
-	case dml.ColumnMapEntityReadAll, dml.ColumnMapEntityReadSet:
+	case dml.ColumnMapEntityReadAll, dml.ColumnMapEntityReadSet, dml.ColumnMapLastInsertID:
 		for i, e := range cc.Data {
 			if err := cc.scanColumns(cm, e, uint64(i)); err != nil {
 				return errors.WithStack(err)
 			}
 		}
 	case dml.ColumnMapScan:
+		// case for scanning when loading certain rows, hence we write data from
+		// the DB into the struct in each for-loop.
 		if cm.Count == 0 {
 			cc.Data = cc.Data[:0]
 		}
@@ -52,13 +58,13 @@ func (cc {{.Collection}}) MapColumns(cm *dml.ColumnMap) error {
 	case dml.ColumnMapCollectionReadSet:
 		for cm.Next() {
 			switch c := cm.Column(); c {
-			{{- range .Columns.UniqueColumns -}}
+			{{- range .Columns.UniqueColumns -}}{{if GoFuncNullSlice .}}
 			case "{{.Field}}"{{range .Aliases}},"{{.}}"{{end}}:
-				cm.Args = cm.Args.{{GoFuncNull .}}s(cc.{{ToGoCamelCase .Field}}s()...)
-			{{- end}}
-			{{- range .Columns.UniquifiedColumns}}
+				cm.{{GoFuncNullSlice .}}(cc.{{ToGoCamelCase .Field}}s()...)
+			{{- end}}{{end}}
+			{{- range .Columns.UniquifiedColumns}}{{if GoFuncSlice .}}
 			case "{{.Field}}"{{range .Aliases}},"{{.}}"{{end}}:
-				cm.Args = cm.Args.{{GoFunc .}}s(cc.{{ToGoCamelCase .Field}}s()...){{end}}
+				cm.{{GoFuncSlice .}}(cc.{{ToGoCamelCase .Field}}s()...){{end}}{{end}}
 			default:
 				return errors.NotFound.Newf("[{{.Package}}] {{.Collection}} Column %q not found", c)
 			}
@@ -96,9 +102,17 @@ func (cc {{$.Collection}}) {{ToGoCamelCase .Field}}s(ret ...{{GoType .}}) []{{Go
 	*/}}
 	dupCheck := make(map[{{GoType .}}]struct{}, len(cc.Data))
 	for _, e := range cc.Data {
-		if _, ok := dupCheck[e.{{GoPrimitive .}}]; !ok {
-			ret = append(ret, e.{{GoPrimitive .}})
-			dupCheck[e.{{GoPrimitive .}}] = struct{}{}
+		{{- if GoNullPointer .}}
+		if e.{{ToGoCamelCase .Field}} == nil {
+			continue
+		}
+		v := *e.{{ToGoCamelCase .Field}}
+		{{- else}}
+		v := e.{{GoPrimitive .}}
+		{{- end}}
+		if _, ok := dupCheck[v]; !ok {
+			ret = append(ret, v)
+			dupCheck[v] = struct{}{}
 		}
 	}
 	return ret
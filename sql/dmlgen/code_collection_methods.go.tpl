@@ -80,3 +80,65 @@ func (s *slice{{.Entity}}) Append(n ...*{{.Entity}}) {
 func (s *slice{{.Entity}}) Prepend(n *{{.Entity}}) {
 	s.Insert(n, 0)
 }
+
+// Map will run function f on all items in []*{{.Entity}} and returns a new
+// slice with the returned items.
+// Auto generated via dmlgen.
+func (s slice{{.Entity}}) Map(f func(*{{.Entity}}) *{{.Entity}}) slice{{.Entity}} {
+	sl := make(slice{{.Entity}}, len(s))
+	for i, e := range s {
+		sl[i] = f(e)
+	}
+	return sl
+}
+
+// Each will run function f on all items in the collection.
+// Auto generated via dmlgen.
+func (cc *{{.Collection}}) Each(f func(*{{.Entity}})) *{{.Collection}} {
+	cc.Data.Each(f)
+	return cc
+}
+
+// Filter returns a new collection filtered by predicate f. The hooks
+// BeforeMapColumns and AfterMapColumns are getting copied.
+// Auto generated via dmlgen.
+func (cc *{{.Collection}}) Filter(f func(*{{.Entity}}) bool) *{{.Collection}} {
+	return &{{.Collection}}{
+		Data:             cc.Data.Filter(f),
+		BeforeMapColumns: cc.BeforeMapColumns,
+		AfterMapColumns:  cc.AfterMapColumns,
+	}
+}
+
+// Map returns a new collection with the items returned by function f. The
+// hooks BeforeMapColumns and AfterMapColumns are getting copied.
+// Auto generated via dmlgen.
+func (cc *{{.Collection}}) Map(f func(*{{.Entity}}) *{{.Entity}}) *{{.Collection}} {
+	return &{{.Collection}}{
+		Data:             cc.Data.Map(f),
+		BeforeMapColumns: cc.BeforeMapColumns,
+		AfterMapColumns:  cc.AfterMapColumns,
+	}
+}
+
+// Cut will remove items i through j-1 from the collection.
+// Auto generated via dmlgen.
+func (cc *{{.Collection}}) Cut(i, j int) *{{.Collection}} {
+	cc.Data.Cut(i, j)
+	return cc
+}
+{{- with .Columns.PrimaryKeys}}{{if eq (len .) 1}}{{$pk := index . 0}}{{if ne (ToGoCamelCase $pk.Field) "ID"}}
+
+// IDs returns a slice or appends to a slice all primary key values of column
+// `{{$pk.Field}}`.
+// Auto generated via dmlgen.
+func (cc *{{$.Collection}}) IDs(ret ...{{GoTypeNull $pk}}) []{{GoTypeNull $pk}} {
+	if ret == nil {
+		ret = make([]{{GoTypeNull $pk}}, 0, len(cc.Data))
+	}
+	for _, e := range cc.Data {
+		ret = append(ret, e.{{ToGoCamelCase $pk.Field}})
+	}
+	return ret
+}
+{{- end}}{{end}}{{end}}
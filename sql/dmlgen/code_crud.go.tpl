@@ -0,0 +1,58 @@
+{{- $pks := .Columns.PrimaryKeys}}
+{{- if not .IsView}}
+// Insert inserts the entity into table `{{.TableName}}` and assigns the auto
+// increment ID. Auto generated.
+func (e *{{.Entity}}) Insert(ctx context.Context, dbc *dml.ConnPool) (sql.Result, error) {
+	res, err := dbc.InsertInto("{{.TableName}}").
+		AddColumns({{range .Columns}}{{if not (or .IsAutoIncrement .IsGenerated)}}"{{.Field}}",{{end}}{{end}}).
+		WithArgs().Record("", e).ExecContext(ctx)
+	return res, errors.WithStack(err)
+}
+
+// Upsert inserts the entity into table `{{.TableName}}` or updates all
+// non-primary key columns if the entity already exists. Auto generated.
+func (e *{{.Entity}}) Upsert(ctx context.Context, dbc *dml.ConnPool) (sql.Result, error) {
+	res, err := dbc.InsertInto("{{.TableName}}").
+		AddColumns({{range .Columns}}{{if not .IsGenerated}}"{{.Field}}",{{end}}{{end}}).
+		{{- if $pks}}
+		AddOnDuplicateKeyExclude({{range $pks}}"{{.Field}}",{{end}}).
+		{{- end}}
+		OnDuplicateKey().
+		WithArgs().Record("", e).ExecContext(ctx)
+	return res, errors.WithStack(err)
+}
+{{- end}}
+{{- if and $pks (not .IsView)}}
+
+// Update updates all non-primary key columns of the entity in table
+// `{{.TableName}}` identified by its primary key. Auto generated.
+func (e *{{.Entity}}) Update(ctx context.Context, dbc *dml.ConnPool) (sql.Result, error) {
+	res, err := dbc.Update("{{.TableName}}").
+		AddColumns({{range .Columns.NonPrimaryColumns}}{{if not .IsGenerated}}"{{.Field}}",{{end}}{{end}}).
+		Where({{range $pks}}dml.Column("{{.Field}}").PlaceHolder(),{{end}}).
+		WithArgs().Record("", e).ExecContext(ctx)
+	return res, errors.WithStack(err)
+}
+
+// Delete deletes the entity from table `{{.TableName}}` identified by its
+// primary key. Auto generated.
+func (e *{{.Entity}}) Delete(ctx context.Context, dbc *dml.ConnPool) (sql.Result, error) {
+	res, err := dbc.DeleteFrom("{{.TableName}}").
+		Where({{range $pks}}dml.Column("{{.Field}}").PlaceHolder(),{{end}}).
+		WithArgs().Record("", e).ExecContext(ctx)
+	return res, errors.WithStack(err)
+}
+{{- end}}
+{{- if $pks}}
+
+// LoadByPK loads all columns of table `{{.TableName}}` into the entity. The
+// primary key fields of the entity must be set. Found is false if no row
+// matches. Auto generated.
+func (e *{{.Entity}}) LoadByPK(ctx context.Context, dbc *dml.ConnPool) (found bool, err error) {
+	rowCount, err := dbc.SelectFrom("{{.TableName}}").
+		AddColumns({{range .Columns}}"{{.Field}}",{{end}}).
+		Where({{range $pks}}dml.Column("{{.Field}}").PlaceHolder(),{{end}}).
+		WithArgs().Record("", e).Load(ctx, e)
+	return rowCount > 0, errors.WithStack(err)
+}
+{{- end}}
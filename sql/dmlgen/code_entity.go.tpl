@@ -4,6 +4,8 @@
 type {{.Entity}} struct {
 {{range .Columns}}{{ToGoCamelCase .Field}} {{GoTypeNull .}}
 		{{- if ne .StructTag "" -}}`{{.StructTag}}`{{- end}} {{.GoComment}}
+{{end}}
+{{- range .Relations}}{{.FieldName}} *{{.Collection}} // {{.Kind}} {{.TargetTable}}
 {{end}} }
 
 // New{{.Entity}} creates a new pointer with pre-initialized fields. Auto
@@ -0,0 +1,36 @@
+
+// New{{.Entity}}Faked creates a new entity with random values which respect
+// the column definitions of table `{{.TableName}}`. The same seed always
+// creates the same values. Auto increment and generated columns stay empty.
+// Auto generated.
+func New{{.Entity}}Faked(seed int64) *{{.Entity}} {
+	return new{{.Entity}}Faked(rand.New(rand.NewSource(seed)))
+}
+
+func new{{.Entity}}Faked(r *rand.Rand) *{{.Entity}} {
+	e := new({{.Entity}})
+{{- range .Columns}}{{if not (or .IsAutoIncrement .IsGenerated)}}{{with GoFaker .}}
+	{{.}}
+{{- end}}{{end}}{{end}}
+	return e
+}
+{{- if not .IsView}}
+
+// Insert{{.Entity}}Faked inserts n entities with random values, derived from
+// seed, into table `{{.TableName}}` and returns them with their assigned auto
+// increment IDs. Useful for integration tests. Auto generated.
+func Insert{{.Entity}}Faked(ctx context.Context, dbc *dml.ConnPool, n int, seed int64) (*{{.Collection}}, error) {
+	r := rand.New(rand.NewSource(seed))
+	cc := &{{.Collection}}{Data: make(slice{{.Entity}}, 0, n)}
+	for i := 0; i < n; i++ {
+		e := new{{.Entity}}Faked(r)
+		if _, err := dbc.InsertInto("{{.TableName}}").
+			AddColumns({{range .Columns}}{{if not (or .IsAutoIncrement .IsGenerated)}}"{{.Field}}",{{end}}{{end}}).
+			WithArgs().Record("", e).ExecContext(ctx); err != nil {
+			return nil, errors.Wrapf(err, "[{{.Package}}] Insert{{.Entity}}Faked at index %d", i)
+		}
+		cc.Data = append(cc.Data, e)
+	}
+	return cc, nil
+}
+{{- end}}
@@ -0,0 +1,40 @@
+{{- $pks := .Columns.PrimaryKeys}}
+{{- if $pks}}
+
+// Resolve{{.Entity}} resolves the GraphQL query field `{{GraphQLName .TableName}}`
+// and loads the entity from table `{{.TableName}}` by its primary key. Returns
+// nil if the entity cannot be found. Auto generated.
+func Resolve{{.Entity}}(ctx context.Context, dbc *dml.ConnPool, {{range $pks}}{{GraphQLName .Field}} {{GoType .}}, {{end}}) (*{{.Entity}}, error) {
+	e := &{{.Entity}}{
+		{{- range $pks}}
+		{{ToGoCamelCase .Field}}: {{GraphQLName .Field}},
+		{{- end}}
+	}
+	rowCount, err := dbc.SelectFrom("{{.TableName}}").
+		AddColumns({{range .Columns}}"{{.Field}}",{{end}}).
+		Where({{range $pks}}dml.Column("{{.Field}}").PlaceHolder(),{{end}}).
+		WithArgs().Record("", e).Load(ctx, e)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if rowCount == 0 {
+		return nil, nil
+	}
+	return e, nil
+}
+{{- end}}
+
+// Resolve{{.Collection}} resolves the GraphQL query field
+// `{{GraphQLName .TableName}}Collection` and loads a page of entities from table
+// `{{.TableName}}`{{if $pks}} ordered by the primary key{{end}}. Auto generated.
+func Resolve{{.Collection}}(ctx context.Context, dbc *dml.ConnPool, limit, offset uint64) (*{{.Collection}}, error) {
+	cc := Make{{.Collection}}()
+	_, err := dbc.SelectFrom("{{.TableName}}").
+		AddColumns({{range .Columns}}"{{.Field}}",{{end}}).
+		{{- if $pks}}
+		OrderBy({{range $pks}}"{{.Field}}",{{end}}).
+		{{- end}}
+		Limit(offset, limit).
+		WithArgs().Load(ctx, &cc)
+	return &cc, errors.WithStack(err)
+}
@@ -0,0 +1,24 @@
+{{- if not .DisableFileHeader -}}
+# Auto generated via github.com/corestoreio/pkg/sql/dmlgen
+
+{{end -}}
+scalar Time
+scalar Decimal
+scalar Bytes
+scalar Int64
+{{range .Tables}}
+# {{ToGoCamelCase .TableName}} represents a single row for DB table `{{.TableName}}`. Auto generated.
+type {{ToGoCamelCase .TableName}} {
+{{- range .Columns}}
+	{{GraphQLName .Field}}: {{GraphQLType .}}
+{{- end}}
+}
+{{end}}
+type Query {
+{{- range .Tables}}{{$entity := ToGoCamelCase .TableName}}
+{{- with .Columns.PrimaryKeys}}
+	{{GraphQLName $entity}}({{range $i, $c := .}}{{if $i}}, {{end}}{{GraphQLName $c.Field}}: {{GraphQLType $c}}{{end}}): {{$entity}}
+{{- end}}
+	{{GraphQLName $entity}}Collection(limit: Int = 20, offset: Int = 0): [{{$entity}}!]!
+{{- end}}
+}
@@ -0,0 +1,81 @@
+{{- $fields := JSONFields .Columns}}
+// MarshalJSON implements interface json.Marshaler without using reflection.
+// Auto generated.
+func (e *{{.Entity}}) MarshalJSON() ([]byte, error) {
+	return e.AppendJSON(make([]byte, 0, {{len $fields}}*32))
+}
+
+// AppendJSON appends the JSON object of the entity to buf. Fields with the
+// struct tag option omitempty are omitted if their value is empty or NULL.
+// Auto generated.
+func (e *{{.Entity}}) AppendJSON(buf []byte) ([]byte, error) {
+	if e == nil {
+		return append(buf, "null"...), nil
+	}
+	start := len(buf)
+	{{- range $fields}}
+	{{- if .NotEmpty}}
+	if {{.NotEmpty}} {
+		buf = append(buf, `{{.Prefix}}`...)
+		{{.Append}}
+	}
+	{{- else}}
+	buf = append(buf, `{{.Prefix}}`...)
+	{{.Append}}
+	{{- end}}
+	{{- end}}
+	if len(buf) == start {
+		return append(buf, "{}"...), nil
+	}
+	buf[start] = '{' // replaces the leading comma
+	return append(buf, '}'), nil
+}
+
+// UnmarshalJSON implements interface json.Unmarshaler without using
+// reflection for the default types. The keys are case sensitive and unknown
+// keys are getting ignored. Auto generated.
+func (e *{{.Entity}}) UnmarshalJSON(b []byte) error {
+	return dml.JSONUnmarshalObject(b, func(key string, value dml.JSONValue) (err error) {
+		switch key {
+		{{- range $fields}}
+		case {{printf "%q" .Key}}:
+			{{.Decode}}
+		{{- end}}
+		}
+		return err
+	})
+}
+
+// MarshalJSON implements interface json.Marshaler without using reflection.
+// Auto generated.
+func (cc *{{.Collection}}) MarshalJSON() ([]byte, error) {
+	if cc == nil {
+		return []byte("null"), nil
+	}
+	buf := make([]byte, 0, 2+len(cc.Data)*{{len $fields}}*32)
+	buf = append(buf, '[')
+	for i, e := range cc.Data {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		var err error
+		if buf, err = e.AppendJSON(buf); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return append(buf, ']'), nil
+}
+
+// UnmarshalJSON implements interface json.Unmarshaler without using
+// reflection for the default types. Auto generated.
+func (cc *{{.Collection}}) UnmarshalJSON(b []byte) error {
+	cc.Data = cc.Data[:0]
+	return dml.JSONUnmarshalArray(b, func(element []byte) error {
+		e := New{{.Entity}}()
+		if err := e.UnmarshalJSON(element); err != nil {
+			return errors.WithStack(err)
+		}
+		cc.Data = append(cc.Data, e)
+		return nil
+	})
+}
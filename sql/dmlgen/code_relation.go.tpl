@@ -0,0 +1,25 @@
+{{- range .Relations}}
+// LoadRelated{{.FieldName}} loads the {{.Kind}} related rows of table
+// `{{.TargetTable}}` into the field {{.FieldName}}. Auto generated.
+func (e *{{$.Entity}}) LoadRelated{{.FieldName}}(ctx context.Context, dbc *dml.ConnPool) error {
+	cc := Make{{.Collection}}()
+	{{- if .LinkTable}}
+	_, err := dbc.SelectFrom("{{.TargetTable}}", "main").
+		AddColumns({{range .TargetColumns}}"main.{{.}}",{{end}}).
+		Join(dml.MakeIdentifier("{{.LinkTable}}").Alias("link"),
+			dml.Column("link.{{.LinkTargetColumn}}").Equal().Column("main.{{.TargetColumn}}")).
+		Where(dml.Column("link.{{.LinkLocalColumn}}").PlaceHolder()).
+		WithArgs().Load(ctx, &cc, e.{{.LocalField}})
+	{{- else}}
+	_, err := dbc.SelectFrom("{{.TargetTable}}").
+		AddColumns({{range .TargetColumns}}"{{.}}",{{end}}).
+		Where(dml.Column("{{.TargetColumn}}").PlaceHolder()).
+		WithArgs().Load(ctx, &cc, e.{{.LocalField}})
+	{{- end}}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	e.{{.FieldName}} = &cc
+	return nil
+}
+{{end}}
@@ -0,0 +1,15 @@
+
+// Validate checks the field values against the column constraints of table
+// `{{.TableName}}` like NOT NULL, maximum length, enum values and integer
+// ranges. It returns a NotValid error. Auto generated.
+func (e *{{.Entity}}) Validate() error {
+	if e == nil {
+		return errors.NotValid.Newf("[{{.Package}}] {{.Entity}}.Validate: Entity cannot be nil")
+	}
+{{- range $c := .Columns}}{{if not $c.IsGenerated}}{{range GoValidations $c}}
+	if {{.Cond}} {
+		return errors.NotValid.Newf("[{{$.Package}}] {{$.Entity}}.Validate: %s", {{printf "%q" .Msg}})
+	}
+{{- end}}{{end}}{{end}}
+	return nil
+}
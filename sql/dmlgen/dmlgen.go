@@ -27,6 +27,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -55,9 +56,11 @@ type Tables struct {
 	DisableTableSchemas bool
 	GogoProtoOptions    []string
 	// goTpl contains a parsed template to render a single table.
-	tpls       *template.Template
-	writeProto bool
-	lastError  error
+	tpls         *template.Template
+	writeProto   bool
+	writeGraphQL bool
+	customTypes  customTypes
+	lastError    error
 }
 
 // Option represents a sortable option for the NewTables function. Each option
@@ -73,8 +76,9 @@ type Option struct {
 type TableOption struct {
 	// Encoders add method receivers for, each struct, compatible with the
 	// interface declarations in the various encoding packages. Supported
-	// encoder names are: text, binary, and protobuf. Text includes JSON. Binary
-	// includes Gob.
+	// encoder names are: text, json, binary, and protobuf. Text includes JSON
+	// via encoding/json. Json generates MarshalJSON and UnmarshalJSON methods
+	// without reflection and honors the json struct tags. Binary includes Gob.
 	Encoders []string
 	// StructTags enables struct tags proactively for the whole struct. Allowed
 	// values are: bson, db, env, json, protobuf, toml, yaml and xml. For bson,
@@ -101,7 +105,35 @@ type TableOption struct {
 	// but should have a dedicated function to extract their unique primitive
 	// values as a slice.
 	UniquifiedColumns []string
-	lastErr           error
+	// CRUD generates the methods Insert, Upsert, Update, Delete and LoadByPK
+	// for the entity type using the dml builders. Update, Delete and LoadByPK
+	// are only available if the table has a primary key. Views, see
+	// WithLoadViews, get only LoadByPK. Generated columns are never written.
+	CRUD bool
+	// Validate generates the method Validate for the entity type which checks
+	// the constraints NOT NULL, maximum character length, enum values and
+	// integer ranges of the columns.
+	Validate bool
+	// Faker generates the functions New<Entity>Faked, which creates an entity
+	// with random values respecting the column definitions, and
+	// Insert<Entity>Faked, which inserts n of those entities. Both take a seed
+	// to create reproducible test fixtures.
+	Faker bool
+	// GraphQL adds the table to the GraphQL schema, see Tables.WriteGraphQL,
+	// and generates the resolver functions Resolve<Entity>, which loads an
+	// entity by its primary key, and Resolve<Collection>, which loads a page
+	// of entities.
+	GraphQL bool
+	// Avro enables the Avro schema of type record for the table, see
+	// Tables.WriteAvro.
+	Avro bool
+	// CustomTypes maps a column to a custom Go and Protocol Buffer type. It
+	// takes precedence over the mapping of the MySQL data type. The map key is
+	// a column name or a pattern as defined in path.Match, for example "*_at"
+	// for all columns ending with _at. An optional MySQL data type, separated
+	// by a space, restricts the pattern further: "*_at datetime".
+	CustomTypes map[string]*TypeDef
+	lastErr     error
 }
 
 func (to *TableOption) applyEncoders(ts *Tables, t *table) {
@@ -110,9 +142,15 @@ func (to *TableOption) applyEncoders(ts *Tables, t *table) {
 		switch enc := to.Encoders[i]; enc {
 		case "text":
 			t.TextMarshaler = true
+		case "json":
+			t.JSONMarshaler = true
 		case "binary":
 			t.BinaryMarshaler = true
 		case "protobuf":
+			if ts.customTypes.nullPointers {
+				to.lastErr = errors.NotSupported.Newf("[dmlgen] WithTableOption: Table %q Encoder %q cannot be used with WithNullPointers", t.TableName, enc)
+				return
+			}
 			// github.com/gogo/protobuf/protoc-gen-gogo/generator/generator.go#L1629 Generator.goTag
 			ts.writeProto = true
 			t.Protobuf = true // for now leave it in. maybe later PB gets added to the struct tags.
@@ -247,6 +285,41 @@ func (to *TableOption) applyUniquifiedColumns(t *table) {
 	}
 }
 
+func (to *TableOption) applyCustomTypes(ts *Tables, t *table) {
+	for key, td := range to.CustomTypes {
+		if to.lastErr != nil {
+			return
+		}
+		pattern, dataType := key, ""
+		if sp := strings.IndexByte(key, ' '); sp > 0 {
+			pattern, dataType = key[:sp], strings.TrimSpace(key[sp+1:])
+		}
+		if td == nil {
+			to.lastErr = errors.Empty.Newf("[dmlgen] WithTableOption:CustomTypes: For table %q the TypeDef of %q cannot be nil.", t.TableName, key)
+			return
+		}
+		found := false
+		for _, c := range t.Columns {
+			ok, err := path.Match(pattern, c.Field)
+			if err != nil {
+				to.lastErr = errors.NotValid.New(err, "[dmlgen] WithTableOption:CustomTypes: For table %q the pattern %q is invalid.", t.TableName, key)
+				return
+			}
+			if ok && (dataType == "" || dataType == c.DataType) {
+				if ts.customTypes.columns == nil {
+					ts.customTypes.columns = make(map[*ddl.Column]*TypeDef)
+				}
+				ts.customTypes.columns[c] = td
+				found = true
+			}
+		}
+		if !found {
+			to.lastErr = errors.NotFound.Newf("[dmlgen] WithTableOption:CustomTypes: For table %q the Column %q cannot be found.",
+				t.TableName, key)
+		}
+	}
+}
+
 // WithTableOption applies options to a table, identified by the table name used
 // as map key.
 func WithTableOption(tableName string, opt *TableOption) (o Option) {
@@ -266,11 +339,51 @@ func WithTableOption(tableName string, opt *TableOption) (o Option) {
 		opt.applyComments(t)
 		opt.applyColumnAliases(t)
 		opt.applyUniquifiedColumns(t)
+		opt.applyCustomTypes(ts, t)
+		t.CRUD = opt.CRUD
+		t.Validate = opt.Validate
+		t.Faker = opt.Faker
+		t.GraphQL = opt.GraphQL
+		ts.writeGraphQL = ts.writeGraphQL || opt.GraphQL
+		t.Avro = opt.Avro
 		return opt.lastErr
 	}
 	return
 }
 
+// WithCustomTypes overrides for the current generator the type mapping of the
+// global variable MysqlTypeToGo. The map key is the MySQL data type, for
+// example "text" or "datetime". Custom types for a column, see
+// TableOption.CustomTypes, take precedence.
+func WithCustomTypes(types map[string]*TypeDef) (opt Option) {
+	opt.sortOrder = 2
+	opt.fn = func(ts *Tables) error {
+		if ts.customTypes.dataTypes == nil {
+			ts.customTypes.dataTypes = make(map[string]*TypeDef, len(types))
+		}
+		for dataType, td := range types {
+			if td == nil {
+				return errors.Empty.Newf("[dmlgen] WithCustomTypes: The TypeDef of MySQL type %q cannot be nil.", dataType)
+			}
+			ts.customTypes.dataTypes[dataType] = td
+		}
+		return nil
+	}
+	return
+}
+
+// WithNullPointers generates pointer types, like *string or *int64, instead of
+// the types of package storage/null for nullable columns. A NULL value gets
+// represented by a nil pointer. Not supported by the protobuf encoder.
+func WithNullPointers() (opt Option) {
+	opt.sortOrder = 2
+	opt.fn = func(ts *Tables) error {
+		ts.customTypes.nullPointers = true
+		return nil
+	}
+	return
+}
+
 // WithColumnAliasesFromForeignKeys extracts similar column names from foreign
 // key definitions. For the list of tables and their primary/unique keys, this
 // function searches the foreign keys to other tables and uses the column name
@@ -347,6 +460,26 @@ func WithLoadColumns(ctx context.Context, db dml.Querier, tables ...string) (opt
 	return
 }
 
+// WithLoadViews queries the information_schema table and marks all loaded
+// tables which are views as read-only. For views the methods Insert, Upsert,
+// Update and Delete and the function Insert<Entity>Faked are not generated.
+func WithLoadViews(ctx context.Context, db dml.Querier) (opt Option) {
+	opt.sortOrder = 20 // after the tables have been loaded or added
+	opt.fn = func(ts *Tables) error {
+		views, err := ddl.LoadViewNames(ctx, db, ts.sortedTableNames()...)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for _, v := range views {
+			if t, ok := ts.Tables[v]; ok {
+				t.IsView = true
+			}
+		}
+		return nil
+	}
+	return
+}
+
 func (ts *Tables) sortedTableNames() []string {
 	sortedKeys := make(slices.String, 0, len(ts.Tables))
 	for k := range ts.Tables {
@@ -362,28 +495,42 @@ func NewTables(packageName string, opts ...Option) (*Tables, error) {
 		Tables:  make(map[string]*table),
 		Package: packageName,
 		ImportPaths: []string{
+			"context",
 			"database/sql",
 			"encoding/json",
 			"github.com/corestoreio/pkg/sql/dml",
 			"github.com/corestoreio/pkg/sql/ddl",
+			"github.com/corestoreio/pkg/storage/null",
 			"github.com/corestoreio/errors",
+			"math/rand",
+			"strconv",
 			"time",
+			"unicode/utf8",
 		},
 		FuncMap: make(template.FuncMap, 10),
 	}
 	ts.FuncMap["ToGoCamelCase"] = strs.ToGoCamelCase // net_http->NetHTTP entity_id->EntityID
-	ts.FuncMap["GoTypeNull"] = toGoTypeNull
-	ts.FuncMap["GoType"] = toGoType
-	ts.FuncMap["GoFuncNull"] = toGoFuncNull
-	ts.FuncMap["GoFunc"] = toGoFunc
-	ts.FuncMap["GoPrimitive"] = toGoPrimitive
-	ts.FuncMap["ProtoType"] = toProtoType
-	ts.FuncMap["ProtoCustomType"] = toProtoCustomType
+	ts.FuncMap["GoTypeNull"] = ts.customTypes.toGoTypeNull
+	ts.FuncMap["GoType"] = ts.customTypes.toGoType
+	ts.FuncMap["GoFuncNull"] = ts.customTypes.toGoFuncNull
+	ts.FuncMap["GoFunc"] = ts.customTypes.toGoFunc
+	ts.FuncMap["GoFuncNullSlice"] = ts.customTypes.toGoFuncNullSlice
+	ts.FuncMap["GoFuncSlice"] = ts.customTypes.toGoFuncSlice
+	ts.FuncMap["GoPrimitive"] = ts.customTypes.toGoPrimitive
+	ts.FuncMap["GoNullPointer"] = ts.customTypes.isNullPointer
+	ts.FuncMap["ProtoType"] = ts.customTypes.toProtoType
+	ts.FuncMap["ProtoCustomType"] = ts.customTypes.toProtoCustomType
+	ts.FuncMap["GoValidations"] = ts.customTypes.toValidations
+	ts.FuncMap["JSONFields"] = ts.customTypes.toJSONFields
+	ts.FuncMap["GoFaker"] = ts.customTypes.toFaker
+	ts.FuncMap["GraphQLType"] = ts.customTypes.toGraphQLType
+	ts.FuncMap["GraphQLName"] = toGraphQLName
 
 	if len(ts.GogoProtoOptions) == 0 {
 		ts.GogoProtoOptions = []string{
 			"(gogoproto.typedecl_all) = false",
 			"(gogoproto.goproto_getters_all) = false",
+			"(gogoproto.goproto_unrecognized_all) = false",
 			"(gogoproto.unmarshaler_all) = true",
 			"(gogoproto.marshaler_all) = true",
 			"(gogoproto.sizer_all) = true",
@@ -412,6 +559,24 @@ func NewTables(packageName string, opts ...Option) (*Tables, error) {
 	return ts, nil
 }
 
+// NewGenerator creates a new code generator for the provided tables and loads
+// the column definitions, including comments, keys and enum values, from the
+// information_schema of the current database. Views are read-only, see
+// WithLoadViews. Column aliases are derived from the foreign keys. Further
+// options can be applied and might overwrite the loaded columns.
+func NewGenerator(ctx context.Context, db dml.Querier, packageName string, tables []string, opts ...Option) (*Tables, error) {
+	if len(tables) == 0 {
+		return nil, errors.Empty.Newf("[dmlgen] NewGenerator: At least one table name is required.")
+	}
+	opts = append(opts,
+		WithLoadColumns(ctx, db, tables...),
+		WithLoadViews(ctx, db),
+		WithColumnAliasesFromForeignKeys(ctx, db),
+	)
+	ts, err := NewTables(packageName, opts...)
+	return ts, errors.WithStack(err)
+}
+
 // findUsedPackages checks for needed packages which we must import.
 func (ts *Tables) findUsedPackages(file []byte) ([]string, error) {
 
@@ -439,7 +604,9 @@ func (ts *Tables) findUsedPackages(file []byte) ([]string, error) {
 	return ret, nil
 }
 
-// WriteProto writes the protocol buffer specifications into `w`.
+// WriteProto writes the protocol buffer specifications into `w`. Only tables
+// with the encoder "protobuf" are getting written. Nullable columns use the
+// wrapper messages of package storage/null, see file null.proto.
 func (ts *Tables) WriteProto(w io.Writer) error {
 	buf := new(bytes.Buffer)
 	if !ts.writeProto {
@@ -454,6 +621,9 @@ func (ts *Tables) WriteProto(w io.Writer) error {
 
 	for _, tblName := range ts.sortedTableNames() {
 		t := ts.Tables[tblName] // must panic if table name not found
+		if !t.Protobuf {
+			continue
+		}
 		if err := t.writeTo(buf, ts.tpls.Lookup("code_proto.go.tpl").Funcs(ts.FuncMap)); err != nil {
 			return errors.WriteFailed.New(err, "[dmlgen] For Table %q", t.TableName)
 		}
@@ -501,11 +671,28 @@ func (ts *Tables) WriteGo(w io.Writer) error {
 		t := ts.Tables[tblname] // must panic if table name not found
 
 		ts.execTpl(buf, t, "code_entity.go.tpl")
+		if t.CRUD {
+			ts.execTpl(buf, t, "code_crud.go.tpl")
+		}
+		if t.Validate {
+			ts.execTpl(buf, t, "code_validate.go.tpl")
+		}
+		if t.Faker {
+			ts.execTpl(buf, t, "code_faker.go.tpl")
+		}
+		if t.GraphQL {
+			ts.execTpl(buf, t, "code_graphql.go.tpl")
+		}
+		if len(t.Relations) > 0 {
+			ts.execTpl(buf, t, "code_relation.go.tpl")
+		}
 		ts.execTpl(buf, t, "code_collection.go.tpl")
 		if !t.DisableCollectionMethods {
 			ts.execTpl(buf, t, "code_collection_methods.go.tpl")
 		}
-		if t.TextMarshaler {
+		if t.JSONMarshaler {
+			ts.execTpl(buf, t, "code_json.go.tpl")
+		} else if t.TextMarshaler {
 			ts.execTpl(buf, t, "code_text.go.tpl")
 		}
 		if t.BinaryMarshaler {
@@ -546,9 +733,19 @@ type table struct {
 	Comment                  string      // Comment above the struct type declaration
 	Columns                  ddl.Columns // all columns of a table
 	TextMarshaler            bool
+	JSONMarshaler            bool // writes reflection free JSON methods
 	BinaryMarshaler          bool
 	Protobuf                 bool // writes the .proto file if true
 	DisableCollectionMethods bool
+	CRUD                     bool // writes the Insert, Update, etc methods
+	Validate                 bool // writes the Validate method
+	Faker                    bool // writes the New<Entity>Faked functions
+	GraphQL                  bool // writes the GraphQL resolver functions
+	IsView                   bool // read-only, no write methods
+	Avro                     bool // writes the Avro schema
+	// Relations contains the foreign key relations to other tables. See
+	// WithForeignKeyRelations.
+	Relations []relation
 }
 
 // WriteTo implements io.WriterTo and writes the generated source code into w.
@@ -584,7 +781,7 @@ func GenerateProto(path string) error {
 	// To generate PHP Code replace `gogo_out` with `php_out`.
 	// Java bit similar. Java has ~15k LOC, Go ~3.7k
 	args := []string{
-		"--gogo_out", "Mgoogle/protobuf/timestamp.proto=github.com/gogo/protobuf/types:.",
+		"--gogo_out", "Mgoogle/protobuf/timestamp.proto=github.com/gogo/protobuf/types,Mgithub.com/corestoreio/pkg/storage/null/null.proto=github.com/corestoreio/pkg/storage/null:.",
 		"--proto_path", fmt.Sprintf("%s/src/:%s/src/github.com/gogo/protobuf/protobuf/:.", build.Default.GOPATH, build.Default.GOPATH),
 	}
 	args = append(args, protoFiles...)
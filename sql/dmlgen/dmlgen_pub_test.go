@@ -15,16 +15,23 @@
 package dmlgen_test
 
 import (
+	"bytes"
 	"context"
+	"go/format"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/pkg/sql/ddl"
 	"github.com/corestoreio/pkg/sql/dmlgen"
 	"github.com/corestoreio/pkg/sql/dmltest"
 	"github.com/corestoreio/pkg/storage/null"
+	"github.com/corestoreio/pkg/util/cstesting"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -63,10 +70,25 @@ func writeFile(t *testing.T, outFile string, w func(io.Writer) error) {
 	require.NoError(t, w(f))
 }
 
-// TestNewTables writes a Go and Proto file to the testdata directory for manual
-// review for different tables. This test also analyzes the foreign keys
-// pointing to customer_entity. No tests of the generated source code are
-// getting executed because API gets developed, still.
+// assertGolden renders into a buffer and compares it with the golden file in
+// the testdata directory. Go source code gets formatted before.
+func assertGolden(t *testing.T, name string, w func(io.Writer) error) {
+	var buf bytes.Buffer
+	require.NoError(t, w(&buf))
+	data := buf.Bytes()
+	if filepath.Ext(name) == ".go" {
+		var err error
+		data, err = format.Source(data)
+		require.NoError(t, err)
+	}
+	cstesting.AssertGolden(t, name, data)
+}
+
+// TestNewTables compares the generated Go and Proto file with the golden files
+// in the testdata directory for different tables. Run the test with the flag
+// -update to rewrite them. This test also analyzes the foreign keys pointing to
+// customer_entity. No tests of the generated source code are getting executed
+// because API gets developed, still.
 func TestNewTables(t *testing.T) {
 	t.Parallel()
 
@@ -93,6 +115,8 @@ func TestNewTables(t *testing.T) {
 					"path": {"storage_location", "config_directory"},
 				},
 				UniquifiedColumns: []string{"path"},
+				CRUD:              true,
+				Validate:          true,
 			}),
 		dmlgen.WithTableOption(
 			"dmlgen_types", &dmlgen.TableOption{
@@ -120,12 +144,45 @@ func TestNewTables(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	writeFile(t, "testdata/output_gen.go", ts.WriteGo)
-	writeFile(t, "testdata/output_gen.proto", ts.WriteProto)
+	assertGolden(t, "output_gen.go", ts.WriteGo)
+	assertGolden(t, "output_gen.proto", ts.WriteProto)
 	// Generates for all proto files the Go source code.
 	require.NoError(t, dmlgen.GenerateProto("./testdata"))
 }
 
+func TestNewGenerator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("load from information_schema", func(t *testing.T) {
+		db, mock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, db, mock)
+
+		mock.ExpectQuery("SELECT.+information_schema.COLUMNS.+").WillReturnRows(dmltest.MustMockRows(
+			dmltest.WithFile("testdata/INFORMATION_SCHEMA.COLUMNS.csv"),
+		))
+		mock.ExpectQuery("SELECT.+information_schema.VIEWS.+").WillReturnRows(
+			sqlmock.NewRows([]string{"TABLE_NAME"}).AddRow("customer_entity"),
+		)
+		mock.ExpectQuery("SELECT.+information_schema.KEY_COLUMN_USAGE.+").WillReturnRows(dmltest.MustMockRows(
+			dmltest.WithFile("testdata/INFORMATION_SCHEMA.KEY_COLUMN_USAGE.csv"),
+		))
+
+		ts, err := dmlgen.NewGenerator(context.Background(), db.DB, "testdata", []string{"dmlgen_types", "customer_entity"})
+		require.NoError(t, err)
+		require.Len(t, ts.Tables, 2)
+		assert.Len(t, ts.Tables["dmlgen_types"].Columns, 42)
+		assert.Len(t, ts.Tables["customer_entity"].Columns, 28)
+		assert.True(t, ts.Tables["dmlgen_types"].Columns.ByField("id").IsPK())
+		assert.True(t, ts.Tables["customer_entity"].IsView)
+		assert.False(t, ts.Tables["dmlgen_types"].IsView)
+	})
+	t.Run("no tables", func(t *testing.T) {
+		ts, err := dmlgen.NewGenerator(context.Background(), nil, "testdata", nil)
+		assert.Nil(t, ts)
+		assert.True(t, errors.Empty.Match(err), "%+v", err)
+	})
+}
+
 func TestInfoSchemaForeignKeys(t *testing.T) {
 
 	t.Skip("One time test. Use when needed to regenerate the code")
@@ -277,3 +334,323 @@ func TestWithUniquifiedColumns(t *testing.T) {
 		assert.True(t, errors.NotFound.Match(err), "%+v", err)
 	})
 }
+
+func TestTables_WriteProto(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not enabled", func(t *testing.T) {
+		tbls, err := dmlgen.NewTables("test",
+			dmlgen.WithTable("core_config_data", ddl.Columns{
+				&ddl.Column{Field: "config_id", DataType: "int"},
+			}),
+		)
+		require.NoError(t, err)
+		var buf strings.Builder
+		err = tbls.WriteProto(&buf)
+		assert.True(t, errors.NotAcceptable.Match(err), "%+v", err)
+	})
+
+	t.Run("only tables with protobuf encoder", func(t *testing.T) {
+		tbls, err := dmlgen.NewTables("test",
+			dmlgen.WithTableOption("catalog_product_entity_decimal", &dmlgen.TableOption{
+				Encoders: []string{"protobuf"},
+			}),
+			dmlgen.WithTable("core_config_data", ddl.Columns{
+				&ddl.Column{Field: "config_id", Pos: 1, DataType: "int", Null: "NO"},
+			}),
+			dmlgen.WithTable("catalog_product_entity_decimal", ddl.Columns{
+				&ddl.Column{Field: "value_id", Pos: 1, DataType: "int", ColumnType: "int(11)", Null: "NO"},
+				&ddl.Column{Field: "value", Pos: 2, DataType: "decimal", Null: "YES"},
+				&ddl.Column{Field: "updated_at", Pos: 3, DataType: "datetime", Null: "YES"},
+			}),
+		)
+		require.NoError(t, err)
+		var buf strings.Builder
+		require.NoError(t, tbls.WriteProto(&buf))
+		proto := buf.String()
+
+		assert.Contains(t, proto, `import "github.com/corestoreio/pkg/storage/null/null.proto";`)
+		assert.Contains(t, proto, "message CatalogProductEntityDecimal {")
+		assert.Contains(t, proto, `int64 value_id = 1 [(gogoproto.customname)="ValueID"];`)
+		assert.Contains(t, proto, `null.Decimal value = 2 [(gogoproto.customname)="Value",(gogoproto.nullable)=false];`)
+		assert.Contains(t, proto, `null.Time updated_at = 3 [(gogoproto.customname)="UpdatedAt",(gogoproto.nullable)=false];`)
+		assert.NotContains(t, proto, "CoreConfigData")
+	})
+}
+
+func TestWithCustomTypes(t *testing.T) {
+	t.Parallel()
+
+	newTables := func(opts ...dmlgen.Option) (*dmlgen.Tables, error) {
+		return dmlgen.NewTables("test", append(opts,
+			dmlgen.WithTable("core_config_data", ddl.Columns{
+				&ddl.Column{Field: "config_id", Pos: 1, DataType: "int", ColumnType: "int(10) unsigned", Null: "NO", Key: "PRI"},
+				&ddl.Column{Field: "value", Pos: 2, DataType: "text", Null: "YES"},
+				&ddl.Column{Field: "created_at", Pos: 3, DataType: "datetime", Null: "YES"},
+				&ddl.Column{Field: "updated_at", Pos: 4, DataType: "timestamp", Null: "YES"},
+			}),
+		)...)
+	}
+
+	t.Run("per data type and column", func(t *testing.T) {
+		tbls, err := newTables(
+			dmlgen.WithCustomTypes(map[string]*dmlgen.TypeDef{
+				"text": {
+					MysqlUnsignedNull: "[]byte", MysqlUnsignedNotNull: "[]byte",
+					MysqlSignedNull: "[]byte", MysqlSignedNotNull: "[]byte",
+					ProtobufUnsignedNull: "bytes", ProtobufUnsignedNotNull: "bytes",
+					ProtobufSignedNull: "bytes", ProtobufSignedNotNull: "bytes",
+				},
+			}),
+			dmlgen.WithTableOption("core_config_data", &dmlgen.TableOption{
+				CustomTypes: map[string]*dmlgen.TypeDef{
+					"*_at datetime": {
+						MysqlUnsignedNull: "time.Time", MysqlUnsignedNotNull: "time.Time",
+						MysqlSignedNull: "time.Time", MysqlSignedNotNull: "time.Time",
+						ColumnMapFunc: "Time",
+					},
+				},
+			}),
+		)
+		require.NoError(t, err)
+		var buf strings.Builder
+		require.NoError(t, tbls.WriteGo(&buf))
+		code := buf.String()
+
+		assert.Regexp(t, `\tValue\s+\[\]byte`, code)
+		assert.Regexp(t, `\tCreatedAt\s+time\.Time`, code)
+		assert.Regexp(t, `\tUpdatedAt\s+null\.Time`, code)
+		assert.Contains(t, code, ".Byte(&e.Value).Time(&e.CreatedAt).NullTime(&e.UpdatedAt)")
+	})
+
+	t.Run("column not found", func(t *testing.T) {
+		tbls, err := newTables(
+			dmlgen.WithTableOption("core_config_data", &dmlgen.TableOption{
+				CustomTypes: map[string]*dmlgen.TypeDef{"*_at int": {}},
+			}),
+		)
+		require.Nil(t, tbls)
+		assert.True(t, errors.NotFound.Match(err), "%+v", err)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		tbls, err := newTables(
+			dmlgen.WithTableOption("core_config_data", &dmlgen.TableOption{
+				CustomTypes: map[string]*dmlgen.TypeDef{"[_at": {}},
+			}),
+		)
+		require.Nil(t, tbls)
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+
+	t.Run("nil TypeDef", func(t *testing.T) {
+		tbls, err := newTables(
+			dmlgen.WithCustomTypes(map[string]*dmlgen.TypeDef{"text": nil}),
+		)
+		require.Nil(t, tbls)
+		assert.True(t, errors.Empty.Match(err), "%+v", err)
+	})
+}
+
+func TestWithNullPointers(t *testing.T) {
+	t.Parallel()
+
+	columns := ddl.Columns{
+		&ddl.Column{Field: "config_id", Pos: 1, DataType: "int", ColumnType: "int(10) unsigned", Null: "NO", Key: "PRI"},
+		&ddl.Column{Field: "scope_id", Pos: 2, DataType: "int", ColumnType: "int(11)", Null: "YES"},
+		&ddl.Column{Field: "value", Pos: 3, DataType: "text", Null: "YES"},
+		&ddl.Column{Field: "updated_at", Pos: 4, DataType: "timestamp", Null: "YES"},
+		&ddl.Column{Field: "price", Pos: 5, DataType: "decimal", Null: "YES"},
+	}
+
+	t.Run("pointer types", func(t *testing.T) {
+		tbls, err := dmlgen.NewTables("test",
+			dmlgen.WithNullPointers(),
+			dmlgen.WithTableOption("core_config_data", &dmlgen.TableOption{
+				UniquifiedColumns: []string{"value"},
+			}),
+			dmlgen.WithTable("core_config_data", columns),
+		)
+		require.NoError(t, err)
+		var buf strings.Builder
+		require.NoError(t, tbls.WriteGo(&buf))
+		code := buf.String()
+
+		assert.Regexp(t, `\tConfigID\s+uint64`, code)
+		assert.Regexp(t, `\tScopeID\s+\*int64`, code)
+		assert.Regexp(t, `\tValue\s+\*string`, code)
+		assert.Regexp(t, `\tUpdatedAt\s+\*time\.Time`, code)
+		assert.Regexp(t, `\tPrice\s+null\.Decimal`, code)
+		assert.Contains(t, code, ".Uint64(&e.ConfigID).Int64Ptr(&e.ScopeID).StringPtr(&e.Value).TimePtr(&e.UpdatedAt).Decimal(&e.Price)")
+		assert.Contains(t, code, "v := *e.Value")
+	})
+
+	t.Run("protobuf not supported", func(t *testing.T) {
+		tbls, err := dmlgen.NewTables("test",
+			dmlgen.WithNullPointers(),
+			dmlgen.WithTableOption("core_config_data", &dmlgen.TableOption{
+				Encoders: []string{"protobuf"},
+			}),
+			dmlgen.WithTable("core_config_data", columns),
+		)
+		require.Nil(t, tbls)
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	})
+}
+
+func TestWriteFile(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "dmlgen")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "test_gen.go")
+
+	content := "package test\n"
+	render := func(w io.Writer) error {
+		_, err := io.WriteString(w, content)
+		return err
+	}
+
+	err = dmlgen.VerifyFile(fileName, render)
+	assert.True(t, errors.NotFound.Match(err), "%+v", err)
+
+	written, err := dmlgen.WriteFile(fileName, render)
+	require.NoError(t, err)
+	assert.True(t, written)
+	data, err := ioutil.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "// dmlgen checksum sha256:"), "%s", data)
+	assert.True(t, strings.HasSuffix(string(data), "\npackage test\n"), "%s", data)
+	assert.NoError(t, dmlgen.VerifyFile(fileName, render))
+
+	written, err = dmlgen.WriteFile(fileName, render)
+	require.NoError(t, err)
+	assert.False(t, written, "Unchanged content must not be written")
+
+	content = "package test2\n"
+	err = dmlgen.VerifyFile(fileName, render)
+	assert.True(t, errors.Mismatch.Match(err), "%+v", err)
+	assert.Contains(t, err.Error(), "is outdated")
+
+	written, err = dmlgen.WriteFile(fileName, render)
+	require.NoError(t, err)
+	assert.True(t, written)
+
+	require.NoError(t, ioutil.WriteFile(fileName, append(data, "// edited\n"...), 0644))
+	err = dmlgen.VerifyFile(fileName, render)
+	assert.True(t, errors.Mismatch.Match(err), "%+v", err)
+	assert.Contains(t, err.Error(), "modified manually")
+
+	written, err = dmlgen.WriteFile(fileName, func(w io.Writer) error {
+		return errors.NotAcceptable.Newf("No content")
+	})
+	assert.False(t, written)
+	assert.True(t, errors.NotAcceptable.Match(err), "%+v", err)
+
+	t.Run("without checksum header", func(t *testing.T) {
+		fileName := filepath.Join(dir, "test_gen.avsc")
+		content := `{"type":"string"}`
+		render := func(w io.Writer) error {
+			_, err := io.WriteString(w, content)
+			return err
+		}
+		written, err := dmlgen.WriteFile(fileName, render)
+		require.NoError(t, err)
+		assert.True(t, written)
+		data, err := ioutil.ReadFile(fileName)
+		require.NoError(t, err)
+		assert.Exactly(t, content, string(data))
+		assert.NoError(t, dmlgen.VerifyFile(fileName, render))
+
+		written, err = dmlgen.WriteFile(fileName, render)
+		require.NoError(t, err)
+		assert.False(t, written)
+
+		content = `{"type":"long"}`
+		err = dmlgen.VerifyFile(fileName, render)
+		assert.True(t, errors.Mismatch.Match(err), "%+v", err)
+	})
+}
+
+func TestWithLoadViews(t *testing.T) {
+	t.Parallel()
+
+	db, mock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, db, mock)
+
+	mock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT TABLE_NAME FROM information_schema.VIEWS WHERE TABLE_SCHEMA=DATABASE() AND TABLE_NAME IN ('customer_entity','view_customer_name') ORDER BY TABLE_NAME")).
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME"}).AddRow("view_customer_name"))
+
+	tbls, err := dmlgen.NewTables("test",
+		dmlgen.WithTable("customer_entity", ddl.Columns{
+			&ddl.Column{Field: "entity_id", Pos: 1, DataType: "int", ColumnType: "int(10) unsigned", Key: "PRI", Extra: "auto_increment"},
+			&ddl.Column{Field: "firstname", Pos: 2, DataType: "varchar", CharMaxLength: null.MakeInt64(255)},
+			&ddl.Column{Field: "lastname", Pos: 3, DataType: "varchar", CharMaxLength: null.MakeInt64(255)},
+			&ddl.Column{Field: "fullname", Pos: 4, DataType: "varchar", CharMaxLength: null.MakeInt64(511), Extra: "VIRTUAL GENERATED"},
+		}),
+		dmlgen.WithTable("view_customer_name", ddl.Columns{
+			&ddl.Column{Field: "entity_id", Pos: 1, DataType: "int", ColumnType: "int(10) unsigned"},
+			&ddl.Column{Field: "fullname", Pos: 2, DataType: "varchar", CharMaxLength: null.MakeInt64(511)},
+		}),
+		dmlgen.WithTableOption("customer_entity", &dmlgen.TableOption{CRUD: true, Faker: true}),
+		dmlgen.WithTableOption("view_customer_name", &dmlgen.TableOption{CRUD: true, Faker: true}),
+		dmlgen.WithLoadViews(context.Background(), db.DB),
+	)
+	require.NoError(t, err)
+	assert.False(t, tbls.Tables["customer_entity"].IsView)
+	assert.True(t, tbls.Tables["view_customer_name"].IsView)
+
+	var buf strings.Builder
+	require.NoError(t, tbls.WriteGo(&buf))
+	code := buf.String()
+
+	assert.Contains(t, code, "func (e *CustomerEntity) Insert(")
+	assert.Contains(t, code, `AddColumns("firstname", "lastname").`)
+	assert.Contains(t, code, `AddColumns("entity_id", "firstname", "lastname", "fullname").`, "Generated columns must be loaded")
+	assert.Contains(t, code, `AddColumns("entity_id", "firstname", "lastname").`, "Upsert")
+	assert.Contains(t, code, "func NewViewCustomerNameFaked(")
+	assert.NotContains(t, code, "func (e *ViewCustomerName) Insert(")
+	assert.NotContains(t, code, "func (e *ViewCustomerName) Upsert(")
+	assert.NotContains(t, code, "func InsertViewCustomerNameFaked(")
+}
+
+func TestTables_WriteAvro(t *testing.T) {
+	t.Parallel()
+
+	tbls, err := dmlgen.NewTables("customer",
+		dmlgen.WithTable("customer_entity", ddl.Columns{
+			&ddl.Column{Field: "entity_id", Pos: 1, DataType: "int", ColumnType: "int(10) unsigned", Key: "PRI", Comment: "Entity ID"},
+			&ddl.Column{Field: "store_id", Pos: 2, DataType: "smallint", ColumnType: "smallint(5) unsigned", Null: "YES"},
+			&ddl.Column{Field: "dob", Pos: 3, DataType: "date", Null: "YES"},
+			&ddl.Column{Field: "updated_at", Pos: 4, DataType: "timestamp"},
+			&ddl.Column{Field: "balance", Pos: 5, DataType: "decimal", Precision: null.MakeInt64(12), Scale: null.MakeInt64(4)},
+		}),
+		dmlgen.WithTable("customer_address_entity", ddl.Columns{
+			&ddl.Column{Field: "entity_id", Pos: 1, DataType: "int", ColumnType: "int(10) unsigned", Key: "PRI"},
+		}),
+		dmlgen.WithTableOption("customer_entity", &dmlgen.TableOption{Avro: true}),
+	)
+	require.NoError(t, err)
+
+	var buf strings.Builder
+	require.NoError(t, tbls.WriteAvro(&buf, "customer_entity"))
+	assert.JSONEq(t, `{
+  "type": "record",
+  "name": "CustomerEntity",
+  "namespace": "customer",
+  "doc": "Represents a single row for DB table customer_entity. Auto generated.",
+  "fields": [
+    {"name": "entity_id", "type": "long", "doc": "Entity ID"},
+    {"name": "store_id", "type": ["null", "int"], "default": null},
+    {"name": "dob", "type": ["null", {"type": "int", "logicalType": "date"}], "default": null},
+    {"name": "updated_at", "type": {"type": "long", "logicalType": "timestamp-micros"}},
+    {"name": "balance", "type": {"type": "bytes", "logicalType": "decimal", "precision": 12, "scale": 4}}
+  ]
+}`, buf.String())
+
+	err = tbls.WriteAvro(&buf, "customer_address_entity")
+	assert.True(t, errors.NotAcceptable.Match(err), "%+v", err)
+	err = tbls.WriteAvro(&buf, "sales_order")
+	assert.True(t, errors.NotFound.Match(err), "%+v", err)
+}
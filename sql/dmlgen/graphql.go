@@ -0,0 +1,108 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlgen
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/util/strs"
+)
+
+// toGraphQLName converts a table or column name into a GraphQL field name in
+// lower camel case: customer_entity => customerEntity, entity_id => entityID,
+// id => id.
+func toGraphQLName(name string) string {
+	rs := []rune(strs.ToGoCamelCase(name))
+	for i := 0; i < len(rs) && unicode.IsUpper(rs[i]); i++ {
+		// Keeps the last upper case letter of an abbreviation followed by a
+		// lower case letter: URLKey => urlKey.
+		if i > 0 && i+1 < len(rs) && unicode.IsLower(rs[i+1]) {
+			break
+		}
+		rs[i] = unicode.ToLower(rs[i])
+	}
+	return string(rs)
+}
+
+// toGraphQLType maps the Go type of a column to a GraphQL type. The integers
+// of MySQL type bigint and unsigned int exceed the 32 bit GraphQL Int and use
+// the custom scalar Int64. Custom Go types are mapped to String. NOT NULL
+// columns are marked as non-null.
+func (ct *customTypes) toGraphQLType(c *ddl.Column) string {
+	goType := strings.TrimPrefix(ct.toGoTypeNull(c), "*")
+	goType = strings.TrimPrefix(goType, "null.")
+
+	var gt string
+	switch goType {
+	case "string", "String":
+		gt = "String"
+	case "int64", "uint64", "Int64":
+		gt = "Int64"
+		if _, ok := intRanges[c.DataType]; ok && !(c.IsUnsigned() && c.DataType == "int") {
+			gt = "Int"
+		}
+	case "float64", "Float64":
+		gt = "Float"
+	case "bool", "Bool":
+		gt = "Boolean"
+	case "time.Time", "Time":
+		gt = "Time"
+	case "Decimal":
+		gt = "Decimal"
+	case "[]byte":
+		gt = "Bytes"
+	default:
+		gt = "String"
+	}
+	if !c.IsNull() {
+		gt += "!"
+	}
+	return gt
+}
+
+// WriteGraphQL writes the GraphQL schema into `w`. Only tables with the option
+// GraphQL are getting written. For each table the schema contains an object
+// type and the query fields to load a single entity by its primary key and a
+// list of entities. The generated Go functions Resolve<Entity> and
+// Resolve<Collection> resolve those query fields.
+func (ts *Tables) WriteGraphQL(w io.Writer) error {
+	if !ts.writeGraphQL {
+		return errors.NotAcceptable.Newf("[dmlgen] GraphQL generation not enabled.")
+	}
+
+	data := struct {
+		DisableFileHeader bool
+		Tables            []*table
+	}{
+		DisableFileHeader: ts.DisableFileHeader,
+	}
+	for _, tblName := range ts.sortedTableNames() {
+		if t := ts.Tables[tblName]; t.GraphQL {
+			data.Tables = append(data.Tables, t)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := ts.tpls.Funcs(ts.FuncMap).ExecuteTemplate(buf, "code_graphql_schema.go.tpl", data); err != nil {
+		return errors.WriteFailed.New(err, "[dmlgen] For GraphQL schema")
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
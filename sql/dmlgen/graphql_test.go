@@ -0,0 +1,65 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlgen
+
+import (
+	"testing"
+
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/storage/null"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToGraphQLName(t *testing.T) {
+	t.Parallel()
+	for have, want := range map[string]string{
+		"id":               "id",
+		"entity_id":        "entityID",
+		"core_config_data": "coreConfigData",
+		"url_key":          "urlKey",
+		"value":            "value",
+	} {
+		assert.Exactly(t, want, toGraphQLName(have), "Name %q", have)
+	}
+}
+
+func TestCustomTypes_toGraphQLType(t *testing.T) {
+	t.Parallel()
+	ct := new(customTypes)
+	tests := []struct {
+		c    ddl.Column
+		want string
+	}{
+		{ddl.Column{Field: `entity_id`, DataType: `int`, ColumnType: `int(10) unsigned`}, "Int64!"},
+		{ddl.Column{Field: `store_id`, DataType: `smallint`, ColumnType: `smallint(5) unsigned`}, "Int!"},
+		{ddl.Column{Field: `parent_id`, DataType: `int`, ColumnType: `int(10)`, Null: "YES"}, "Int"},
+		{ddl.Column{Field: `row_id`, DataType: `bigint`, ColumnType: `bigint(20)`}, "Int64!"},
+		{ddl.Column{Field: `email`, DataType: `varchar`, Null: "YES"}, "String"},
+		{ddl.Column{Field: `is_active`, DataType: `tinyint`, ColumnType: `tinyint(1)`}, "Boolean!"},
+		{ddl.Column{Field: `weight`, DataType: `float`, Null: "YES"}, "Float"},
+		{ddl.Column{Field: `price`, DataType: `decimal`, Default: null.MakeString(`0.00`)}, "Decimal!"},
+		{ddl.Column{Field: `created_at`, DataType: `timestamp`}, "Time!"},
+		{ddl.Column{Field: `hash`, DataType: `varbinary`, Null: "YES"}, "Bytes"},
+	}
+	for _, test := range tests {
+		c := test.c
+		assert.Exactly(t, test.want, ct.toGraphQLType(&c), "Column %q", c.Field)
+	}
+
+	ct.nullPointers = true
+	assert.Exactly(t, "Time", ct.toGraphQLType(&ddl.Column{Field: `updated_at`, DataType: `datetime`, Null: "YES"}))
+	ct.dataTypes = map[string]*TypeDef{"text": {MysqlSignedNotNull: "json.RawMessage"}}
+	assert.Exactly(t, "String!", ct.toGraphQLType(&ddl.Column{Field: `payload`, DataType: `text`}))
+}
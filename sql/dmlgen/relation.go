@@ -0,0 +1,147 @@
+// Copyright 2015-2017, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlgen
+
+import (
+	"context"
+	"sort"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/util/strs"
+)
+
+const (
+	relationOneToMany  = "one-to-many"
+	relationManyToMany = "many-to-many"
+)
+
+// relation describes a foreign key relation from the table of the entity to a
+// target table. The target collection gets embedded in the entity and loaded
+// with the generated LoadRelated method.
+type relation struct {
+	Kind          string // relationOneToMany or relationManyToMany
+	FieldName     string // name of the struct field and suffix of the method
+	Collection    string // collection type of the target table
+	TargetTable   string
+	TargetColumns []string // all columns of the target table
+	// TargetColumn references LocalColumn in case of one-to-many. For
+	// many-to-many it is the column referenced by LinkTargetColumn.
+	TargetColumn string
+	LocalColumn  string // column of the entity, mostly the primary key
+	LocalField   string // Go field name of LocalColumn
+	// LinkTable contains the table which connects both tables in a
+	// many-to-many relation.
+	LinkTable        string
+	LinkLocalColumn  string
+	LinkTargetColumn string
+}
+
+// WithForeignKeyRelations queries the foreign keys between all loaded tables
+// and generates for each referenced table a field with the collection of the
+// referencing table plus a LoadRelated method. For example table
+// `customer_entity` gets the field `SalesOrder *SalesOrderCollection` and the
+// method `LoadRelatedSalesOrder`. A table whose primary key consists of exactly
+// two foreign keys gets treated as a link table and generates a many-to-many
+// relation between the two referenced tables. Only tables added to the
+// generator are considered.
+func WithForeignKeyRelations(ctx context.Context, db dml.Querier) (opt Option) {
+	opt.sortOrder = 210 // must run after WithColumnAliasesFromForeignKeys
+	opt.fn = func(ts *Tables) error {
+		tblFks, err := ddl.LoadKeyColumnUsage(ctx, db, ts.sortedTableNames()...)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		ts.applyRelations(tblFks)
+		return nil
+	}
+	return
+}
+
+func (ts *Tables) applyRelations(tblFks map[string]ddl.KeyColumnUsageCollection) {
+	var fks []*ddl.KeyColumnUsage
+	for _, kcuc := range tblFks {
+		for _, kcu := range kcuc.Data {
+			if ts.Tables[kcu.TableName] != nil && ts.Tables[kcu.ReferencedTableName.String] != nil {
+				fks = append(fks, kcu)
+			}
+		}
+	}
+	// deal with random map to guarantee the persistent code generation.
+	sort.Slice(fks, func(i, j int) bool {
+		if fks[i].TableName != fks[j].TableName {
+			return fks[i].TableName < fks[j].TableName
+		}
+		return fks[i].ColumnName < fks[j].ColumnName
+	})
+
+	fksByTable := make(map[string][]*ddl.KeyColumnUsage, len(fks))
+	for _, fk := range fks {
+		ts.Tables[fk.ReferencedTableName.String].addRelation(ts.Tables[fk.TableName], relation{
+			Kind:         relationOneToMany,
+			TargetColumn: fk.ColumnName,
+			LocalColumn:  fk.ReferencedColumnName.String,
+		})
+		fksByTable[fk.TableName] = append(fksByTable[fk.TableName], fk)
+	}
+
+	for _, fk := range fks {
+		linkFKs := fksByTable[fk.TableName]
+		if len(linkFKs) != 2 || linkFKs[0] != fk || !ts.Tables[fk.TableName].isLinkTable(linkFKs[0].ColumnName, linkFKs[1].ColumnName) {
+			continue
+		}
+		for i, fkA := range linkFKs {
+			fkB := linkFKs[1-i]
+			ts.Tables[fkA.ReferencedTableName.String].addRelation(ts.Tables[fkB.ReferencedTableName.String], relation{
+				Kind:             relationManyToMany,
+				TargetColumn:     fkB.ReferencedColumnName.String,
+				LocalColumn:      fkA.ReferencedColumnName.String,
+				LinkTable:        fk.TableName,
+				LinkLocalColumn:  fkA.ColumnName,
+				LinkTargetColumn: fkB.ColumnName,
+			})
+		}
+	}
+}
+
+// isLinkTable returns true if the primary key of the table consists of exactly
+// both columns.
+func (t *table) isLinkTable(colA, colB string) bool {
+	pks := t.Columns.PrimaryKeys()
+	return colA != colB && len(pks) == 2 && pks.Contains(colA) && pks.Contains(colB)
+}
+
+// addRelation adds the relation to the target table and sets the names. If
+// there are multiple relations to the same target table, the field name gets
+// the column name appended.
+func (t *table) addRelation(target *table, r relation) {
+	r.TargetTable = target.TableName
+	r.TargetColumns = target.Columns.FieldNames()
+	r.Collection = strs.ToGoCamelCase(target.TableName) + "Collection"
+	r.LocalField = strs.ToGoCamelCase(r.LocalColumn)
+	r.FieldName = strs.ToGoCamelCase(target.TableName)
+	for _, er := range t.Relations {
+		if er.FieldName == r.FieldName {
+			col := r.TargetColumn
+			if r.Kind == relationManyToMany {
+				col = r.LinkTargetColumn
+			}
+			r.FieldName += "By" + strs.ToGoCamelCase(col)
+			break
+		}
+	}
+	t.Relations = append(t.Relations, r)
+}
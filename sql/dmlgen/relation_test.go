@@ -0,0 +1,96 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlgen
+
+import (
+	"testing"
+
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/storage/null"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTables_applyRelations(t *testing.T) {
+	t.Parallel()
+
+	ts := &Tables{
+		Tables: map[string]*table{
+			"customer_entity": {TableName: "customer_entity", Columns: ddl.Columns{
+				{Field: "entity_id", Key: "PRI"}, {Field: "email"},
+			}},
+			"sales_order": {TableName: "sales_order", Columns: ddl.Columns{
+				{Field: "entity_id", Key: "PRI"}, {Field: "customer_id"}, {Field: "billing_customer_id"},
+			}},
+			"customer_group": {TableName: "customer_group", Columns: ddl.Columns{
+				{Field: "group_id", Key: "PRI"}, {Field: "code"},
+			}},
+			"customer_group_link": {TableName: "customer_group_link", Columns: ddl.Columns{
+				{Field: "customer_id", Key: "PRI"}, {Field: "group_id", Key: "PRI"},
+			}},
+		},
+	}
+	fk := func(tbl, col, refTbl, refCol string) *ddl.KeyColumnUsage {
+		return &ddl.KeyColumnUsage{
+			TableName: tbl, ColumnName: col,
+			ReferencedTableName: null.MakeString(refTbl), ReferencedColumnName: null.MakeString(refCol),
+		}
+	}
+	ts.applyRelations(map[string]ddl.KeyColumnUsageCollection{
+		"sales_order": {Data: []*ddl.KeyColumnUsage{
+			fk("sales_order", "customer_id", "customer_entity", "entity_id"),
+			fk("sales_order", "billing_customer_id", "customer_entity", "entity_id"),
+		}},
+		"customer_group_link": {Data: []*ddl.KeyColumnUsage{
+			fk("customer_group_link", "group_id", "customer_group", "group_id"),
+			fk("customer_group_link", "customer_id", "customer_entity", "entity_id"),
+		}},
+		"catalog_product_entity": {Data: []*ddl.KeyColumnUsage{
+			fk("catalog_product_entity", "customer_id", "customer_entity", "entity_id"),
+		}},
+	})
+
+	fieldNames := func(tbl string) (names []string) {
+		for _, r := range ts.Tables[tbl].Relations {
+			names = append(names, r.Kind+":"+r.FieldName)
+		}
+		return names
+	}
+	assert.Exactly(t, []string{
+		"one-to-many:CustomerGroupLink",
+		"one-to-many:SalesOrder",
+		"one-to-many:SalesOrderByCustomerID",
+		"many-to-many:CustomerGroup",
+	}, fieldNames("customer_entity"))
+	assert.Exactly(t, []string{
+		"one-to-many:CustomerGroupLink",
+		"many-to-many:CustomerEntity",
+	}, fieldNames("customer_group"))
+	assert.Empty(t, ts.Tables["sales_order"].Relations)
+
+	m2m := ts.Tables["customer_entity"].Relations[3]
+	assert.Exactly(t, relation{
+		Kind:             relationManyToMany,
+		FieldName:        "CustomerGroup",
+		Collection:       "CustomerGroupCollection",
+		TargetTable:      "customer_group",
+		TargetColumns:    []string{"group_id", "code"},
+		TargetColumn:     "group_id",
+		LocalColumn:      "entity_id",
+		LocalField:       "EntityID",
+		LinkTable:        "customer_group_link",
+		LinkLocalColumn:  "customer_id",
+		LinkTargetColumn: "group_id",
+	}, m2m)
+}
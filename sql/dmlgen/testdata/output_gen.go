@@ -3,12 +3,15 @@
 package testdata
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
-	"time"
-
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/pkg/sql/ddl"
 	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/storage/null"
+	"time"
+	"unicode/utf8"
 )
 
 // NewTables returns a goified version of the MySQL/MariaDB table schema for the
@@ -17,85 +20,85 @@ import (
 func NewTables(opts ...ddl.TableOption) (*ddl.Tables, error) {
 	tm, err := ddl.NewTables(
 		ddl.WithTable("core_config_data", ddl.Columns{
-			&ddl.Column{Field: "config_id", Pos: 1, Null: "NO", DataType: "int", Precision: dml.MakeNullInt64(10), Scale: dml.MakeNullInt64(0), ColumnType: "int(10) unsigned", Key: "PRI", Extra: "auto_increment", Comment: "Config Id", StructTag: "json:\"config_id,omitempty\""},
-			&ddl.Column{Field: "scope", Pos: 2, Default: dml.MakeNullString("'default'"), Null: "NO", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(8), ColumnType: "varchar(8)", Key: "MUL", Comment: "Config Scope", StructTag: "json:\"scope,omitempty\""},
-			&ddl.Column{Field: "scope_id", Pos: 3, Default: dml.MakeNullString("0"), Null: "NO", DataType: "int", Precision: dml.MakeNullInt64(10), Scale: dml.MakeNullInt64(0), ColumnType: "int(11)", Comment: "Config Scope Id", StructTag: "json:\"scope_id\" xml:\"scope_id\""},
-			&ddl.Column{Field: "path", Pos: 4, Default: dml.MakeNullString("'general'"), Null: "NO", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(255), ColumnType: "varchar(255)", Comment: "Config Path", Aliases: []string{"storage_location", "config_directory"}, Uniquified: true, StructTag: "json:\"x_path\" xml:\"y_path\""},
-			&ddl.Column{Field: "value", Pos: 5, Default: dml.MakeNullString("NULL"), Null: "YES", DataType: "text", CharMaxLength: dml.MakeNullInt64(65535), ColumnType: "text", Comment: "Config Value", StructTag: "json:\"value,omitempty\""},
+			&ddl.Column{Field: "config_id", Pos: 1, Null: "NO", DataType: "int", Precision: null.MakeInt64(10), Scale: null.MakeInt64(0), ColumnType: "int(10) unsigned", Key: "PRI", Extra: "auto_increment", Comment: "Config Id", StructTag: "json:\"config_id,omitempty\""},
+			&ddl.Column{Field: "scope", Pos: 2, Default: null.MakeString("'default'"), Null: "NO", DataType: "varchar", CharMaxLength: null.MakeInt64(8), ColumnType: "varchar(8)", Key: "MUL", Comment: "Config Scope", StructTag: "json:\"scope,omitempty\""},
+			&ddl.Column{Field: "scope_id", Pos: 3, Default: null.MakeString("0"), Null: "NO", DataType: "int", Precision: null.MakeInt64(10), Scale: null.MakeInt64(0), ColumnType: "int(11)", Comment: "Config Scope Id", StructTag: "json:\"scope_id\" xml:\"scope_id\""},
+			&ddl.Column{Field: "path", Pos: 4, Default: null.MakeString("'general'"), Null: "NO", DataType: "varchar", CharMaxLength: null.MakeInt64(255), ColumnType: "varchar(255)", Comment: "Config Path", Aliases: []string{"storage_location", "config_directory"}, Uniquified: true, StructTag: "json:\"x_path\" xml:\"y_path\""},
+			&ddl.Column{Field: "value", Pos: 5, Default: null.MakeString("NULL"), Null: "YES", DataType: "text", CharMaxLength: null.MakeInt64(65535), ColumnType: "text", Comment: "Config Value", StructTag: "json:\"value,omitempty\""},
 		}...),
 		ddl.WithTable("customer_entity", ddl.Columns{
-			&ddl.Column{Field: "entity_id", Pos: 1, Null: "NO", DataType: "int", Precision: dml.MakeNullInt64(10), Scale: dml.MakeNullInt64(0), ColumnType: "int(10) unsigned", Key: "PRI", Extra: "auto_increment", Comment: "Entity Id", Aliases: []string{"customer_id", "parent_id"}},
-			&ddl.Column{Field: "website_id", Pos: 2, Null: "YES", DataType: "smallint", Precision: dml.MakeNullInt64(5), Scale: dml.MakeNullInt64(0), ColumnType: "smallint(5) unsigned", Key: "MUL", Comment: "Website Id"},
-			&ddl.Column{Field: "email", Pos: 3, Null: "YES", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(255), ColumnType: "varchar(255)", Key: "MUL", Comment: "Email"},
-			&ddl.Column{Field: "group_id", Pos: 4, Default: dml.MakeNullString("0"), Null: "NO", DataType: "smallint", Precision: dml.MakeNullInt64(5), Scale: dml.MakeNullInt64(0), ColumnType: "smallint(5) unsigned", Comment: "Group Id"},
-			&ddl.Column{Field: "increment_id", Pos: 5, Null: "YES", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(50), ColumnType: "varchar(50)", Comment: "Increment Id"},
-			&ddl.Column{Field: "store_id", Pos: 6, Default: dml.MakeNullString("0"), Null: "YES", DataType: "smallint", Precision: dml.MakeNullInt64(5), Scale: dml.MakeNullInt64(0), ColumnType: "smallint(5) unsigned", Key: "MUL", Comment: "Store Id"},
-			&ddl.Column{Field: "created_at", Pos: 7, Default: dml.MakeNullString("current_timestamp()"), Null: "NO", DataType: "timestamp", ColumnType: "timestamp", Comment: "Created At"},
-			&ddl.Column{Field: "updated_at", Pos: 8, Default: dml.MakeNullString("current_timestamp()"), Null: "NO", DataType: "timestamp", ColumnType: "timestamp", Extra: "on update current_timestamp()", Comment: "Updated At"},
-			&ddl.Column{Field: "is_active", Pos: 9, Default: dml.MakeNullString("1"), Null: "NO", DataType: "smallint", Precision: dml.MakeNullInt64(5), Scale: dml.MakeNullInt64(0), ColumnType: "smallint(5) unsigned", Comment: "Is Active"},
-			&ddl.Column{Field: "disable_auto_group_change", Pos: 10, Default: dml.MakeNullString("0"), Null: "NO", DataType: "smallint", Precision: dml.MakeNullInt64(5), Scale: dml.MakeNullInt64(0), ColumnType: "smallint(5) unsigned", Comment: "Disable automatic group change based on VAT ID"},
-			&ddl.Column{Field: "created_in", Pos: 11, Null: "YES", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(255), ColumnType: "varchar(255)", Comment: "Created From"},
-			&ddl.Column{Field: "prefix", Pos: 12, Null: "YES", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(40), ColumnType: "varchar(40)", Comment: "Name Prefix"},
-			&ddl.Column{Field: "firstname", Pos: 13, Null: "YES", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(255), ColumnType: "varchar(255)", Key: "MUL", Comment: "First Name"},
-			&ddl.Column{Field: "middlename", Pos: 14, Null: "YES", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(255), ColumnType: "varchar(255)", Comment: "Middle Name/Initial"},
-			&ddl.Column{Field: "lastname", Pos: 15, Null: "YES", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(255), ColumnType: "varchar(255)", Key: "MUL", Comment: "Last Name"},
-			&ddl.Column{Field: "suffix", Pos: 16, Null: "YES", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(40), ColumnType: "varchar(40)", Comment: "Name Suffix"},
+			&ddl.Column{Field: "entity_id", Pos: 1, Null: "NO", DataType: "int", Precision: null.MakeInt64(10), Scale: null.MakeInt64(0), ColumnType: "int(10) unsigned", Key: "PRI", Extra: "auto_increment", Comment: "Entity Id", Aliases: []string{"customer_id", "parent_id"}},
+			&ddl.Column{Field: "website_id", Pos: 2, Null: "YES", DataType: "smallint", Precision: null.MakeInt64(5), Scale: null.MakeInt64(0), ColumnType: "smallint(5) unsigned", Key: "MUL", Comment: "Website Id"},
+			&ddl.Column{Field: "email", Pos: 3, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(255), ColumnType: "varchar(255)", Key: "MUL", Comment: "Email"},
+			&ddl.Column{Field: "group_id", Pos: 4, Default: null.MakeString("0"), Null: "NO", DataType: "smallint", Precision: null.MakeInt64(5), Scale: null.MakeInt64(0), ColumnType: "smallint(5) unsigned", Comment: "Group Id"},
+			&ddl.Column{Field: "increment_id", Pos: 5, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(50), ColumnType: "varchar(50)", Comment: "Increment Id"},
+			&ddl.Column{Field: "store_id", Pos: 6, Default: null.MakeString("0"), Null: "YES", DataType: "smallint", Precision: null.MakeInt64(5), Scale: null.MakeInt64(0), ColumnType: "smallint(5) unsigned", Key: "MUL", Comment: "Store Id"},
+			&ddl.Column{Field: "created_at", Pos: 7, Default: null.MakeString("current_timestamp()"), Null: "NO", DataType: "timestamp", ColumnType: "timestamp", Comment: "Created At"},
+			&ddl.Column{Field: "updated_at", Pos: 8, Default: null.MakeString("current_timestamp()"), Null: "NO", DataType: "timestamp", ColumnType: "timestamp", Extra: "on update current_timestamp()", Comment: "Updated At"},
+			&ddl.Column{Field: "is_active", Pos: 9, Default: null.MakeString("1"), Null: "NO", DataType: "smallint", Precision: null.MakeInt64(5), Scale: null.MakeInt64(0), ColumnType: "smallint(5) unsigned", Comment: "Is Active"},
+			&ddl.Column{Field: "disable_auto_group_change", Pos: 10, Default: null.MakeString("0"), Null: "NO", DataType: "smallint", Precision: null.MakeInt64(5), Scale: null.MakeInt64(0), ColumnType: "smallint(5) unsigned", Comment: "Disable automatic group change based on VAT ID"},
+			&ddl.Column{Field: "created_in", Pos: 11, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(255), ColumnType: "varchar(255)", Comment: "Created From"},
+			&ddl.Column{Field: "prefix", Pos: 12, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(40), ColumnType: "varchar(40)", Comment: "Name Prefix"},
+			&ddl.Column{Field: "firstname", Pos: 13, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(255), ColumnType: "varchar(255)", Key: "MUL", Comment: "First Name"},
+			&ddl.Column{Field: "middlename", Pos: 14, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(255), ColumnType: "varchar(255)", Comment: "Middle Name/Initial"},
+			&ddl.Column{Field: "lastname", Pos: 15, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(255), ColumnType: "varchar(255)", Key: "MUL", Comment: "Last Name"},
+			&ddl.Column{Field: "suffix", Pos: 16, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(40), ColumnType: "varchar(40)", Comment: "Name Suffix"},
 			&ddl.Column{Field: "dob", Pos: 17, Null: "YES", DataType: "date", ColumnType: "date", Comment: "Date of Birth"},
-			&ddl.Column{Field: "password_hash", Pos: 18, Null: "YES", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(128), ColumnType: "varchar(128)", Comment: "Password_hash"},
-			&ddl.Column{Field: "rp_token", Pos: 19, Null: "YES", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(128), ColumnType: "varchar(128)", Comment: "Reset password token"},
+			&ddl.Column{Field: "password_hash", Pos: 18, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(128), ColumnType: "varchar(128)", Comment: "Password_hash"},
+			&ddl.Column{Field: "rp_token", Pos: 19, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(128), ColumnType: "varchar(128)", Comment: "Reset password token"},
 			&ddl.Column{Field: "rp_token_created_at", Pos: 20, Null: "YES", DataType: "datetime", ColumnType: "datetime", Comment: "Reset password token creation time"},
-			&ddl.Column{Field: "default_billing", Pos: 21, Null: "YES", DataType: "int", Precision: dml.MakeNullInt64(10), Scale: dml.MakeNullInt64(0), ColumnType: "int(10) unsigned", Comment: "Default Billing Address"},
-			&ddl.Column{Field: "default_shipping", Pos: 22, Null: "YES", DataType: "int", Precision: dml.MakeNullInt64(10), Scale: dml.MakeNullInt64(0), ColumnType: "int(10) unsigned", Comment: "Default Shipping Address"},
-			&ddl.Column{Field: "taxvat", Pos: 23, Null: "YES", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(50), ColumnType: "varchar(50)", Comment: "Tax/VAT Number"},
-			&ddl.Column{Field: "confirmation", Pos: 24, Null: "YES", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(64), ColumnType: "varchar(64)", Comment: "Is Confirmed"},
-			&ddl.Column{Field: "gender", Pos: 25, Null: "YES", DataType: "smallint", Precision: dml.MakeNullInt64(5), Scale: dml.MakeNullInt64(0), ColumnType: "smallint(5) unsigned", Comment: "Gender"},
-			&ddl.Column{Field: "failures_num", Pos: 26, Default: dml.MakeNullString("0"), Null: "YES", DataType: "smallint", Precision: dml.MakeNullInt64(5), Scale: dml.MakeNullInt64(0), ColumnType: "smallint(6)", Comment: "Failure Number"},
+			&ddl.Column{Field: "default_billing", Pos: 21, Null: "YES", DataType: "int", Precision: null.MakeInt64(10), Scale: null.MakeInt64(0), ColumnType: "int(10) unsigned", Comment: "Default Billing Address"},
+			&ddl.Column{Field: "default_shipping", Pos: 22, Null: "YES", DataType: "int", Precision: null.MakeInt64(10), Scale: null.MakeInt64(0), ColumnType: "int(10) unsigned", Comment: "Default Shipping Address"},
+			&ddl.Column{Field: "taxvat", Pos: 23, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(50), ColumnType: "varchar(50)", Comment: "Tax/VAT Number"},
+			&ddl.Column{Field: "confirmation", Pos: 24, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(64), ColumnType: "varchar(64)", Comment: "Is Confirmed"},
+			&ddl.Column{Field: "gender", Pos: 25, Null: "YES", DataType: "smallint", Precision: null.MakeInt64(5), Scale: null.MakeInt64(0), ColumnType: "smallint(5) unsigned", Comment: "Gender"},
+			&ddl.Column{Field: "failures_num", Pos: 26, Default: null.MakeString("0"), Null: "YES", DataType: "smallint", Precision: null.MakeInt64(5), Scale: null.MakeInt64(0), ColumnType: "smallint(6)", Comment: "Failure Number"},
 			&ddl.Column{Field: "first_failure", Pos: 27, Null: "YES", DataType: "timestamp", ColumnType: "timestamp", Comment: "First Failure"},
 			&ddl.Column{Field: "lock_expires", Pos: 28, Null: "YES", DataType: "timestamp", ColumnType: "timestamp", Comment: "Lock Expiration Date"},
 		}...),
 		ddl.WithTable("dmlgen_types", ddl.Columns{
-			&ddl.Column{Field: "id", Pos: 1, Null: "NO", DataType: "int", Precision: dml.MakeNullInt64(10), Scale: dml.MakeNullInt64(0), ColumnType: "int(11)", Key: "PRI", Extra: "auto_increment", StructTag: "json:\"id,omitempty\" "},
-			&ddl.Column{Field: "col_bigint_1", Pos: 2, Null: "YES", DataType: "bigint", Precision: dml.MakeNullInt64(19), Scale: dml.MakeNullInt64(0), ColumnType: "bigint(20)", StructTag: "json:\"col_bigint_1,omitempty\" "},
-			&ddl.Column{Field: "col_bigint_2", Pos: 3, Default: dml.MakeNullString("0"), Null: "NO", DataType: "bigint", Precision: dml.MakeNullInt64(19), Scale: dml.MakeNullInt64(0), ColumnType: "bigint(20)", StructTag: "json:\"col_bigint_2,omitempty\" "},
-			&ddl.Column{Field: "col_bigint_3", Pos: 4, Null: "YES", DataType: "bigint", Precision: dml.MakeNullInt64(20), Scale: dml.MakeNullInt64(0), ColumnType: "bigint(20) unsigned", StructTag: "json:\"col_bigint_3,omitempty\" "},
-			&ddl.Column{Field: "col_bigint_4", Pos: 5, Default: dml.MakeNullString("0"), Null: "NO", DataType: "bigint", Precision: dml.MakeNullInt64(20), Scale: dml.MakeNullInt64(0), ColumnType: "bigint(20) unsigned", StructTag: "json:\"col_bigint_4,omitempty\" "},
-			&ddl.Column{Field: "col_blob", Pos: 6, Null: "YES", DataType: "blob", CharMaxLength: dml.MakeNullInt64(65535), ColumnType: "blob", Uniquified: true, StructTag: "json:\"col_blob,omitempty\" "},
+			&ddl.Column{Field: "id", Pos: 1, Null: "NO", DataType: "int", Precision: null.MakeInt64(10), Scale: null.MakeInt64(0), ColumnType: "int(11)", Key: "PRI", Extra: "auto_increment", StructTag: "json:\"id,omitempty\" "},
+			&ddl.Column{Field: "col_bigint_1", Pos: 2, Null: "YES", DataType: "bigint", Precision: null.MakeInt64(19), Scale: null.MakeInt64(0), ColumnType: "bigint(20)", StructTag: "json:\"col_bigint_1,omitempty\" "},
+			&ddl.Column{Field: "col_bigint_2", Pos: 3, Default: null.MakeString("0"), Null: "NO", DataType: "bigint", Precision: null.MakeInt64(19), Scale: null.MakeInt64(0), ColumnType: "bigint(20)", StructTag: "json:\"col_bigint_2,omitempty\" "},
+			&ddl.Column{Field: "col_bigint_3", Pos: 4, Null: "YES", DataType: "bigint", Precision: null.MakeInt64(20), Scale: null.MakeInt64(0), ColumnType: "bigint(20) unsigned", StructTag: "json:\"col_bigint_3,omitempty\" "},
+			&ddl.Column{Field: "col_bigint_4", Pos: 5, Default: null.MakeString("0"), Null: "NO", DataType: "bigint", Precision: null.MakeInt64(20), Scale: null.MakeInt64(0), ColumnType: "bigint(20) unsigned", StructTag: "json:\"col_bigint_4,omitempty\" "},
+			&ddl.Column{Field: "col_blob", Pos: 6, Null: "YES", DataType: "blob", CharMaxLength: null.MakeInt64(65535), ColumnType: "blob", Uniquified: true, StructTag: "json:\"col_blob,omitempty\" "},
 			&ddl.Column{Field: "col_date_1", Pos: 7, Null: "YES", DataType: "date", ColumnType: "date", StructTag: "json:\"col_date_1,omitempty\" "},
-			&ddl.Column{Field: "col_date_2", Pos: 8, Default: dml.MakeNullString("'0000-00-00'"), Null: "NO", DataType: "date", ColumnType: "date", Uniquified: true, StructTag: "json:\"col_date_2,omitempty\" "},
+			&ddl.Column{Field: "col_date_2", Pos: 8, Default: null.MakeString("'0000-00-00'"), Null: "NO", DataType: "date", ColumnType: "date", Uniquified: true, StructTag: "json:\"col_date_2,omitempty\" "},
 			&ddl.Column{Field: "col_datetime_1", Pos: 9, Null: "YES", DataType: "datetime", ColumnType: "datetime", StructTag: "json:\"col_datetime_1,omitempty\" "},
-			&ddl.Column{Field: "col_datetime_2", Pos: 10, Default: dml.MakeNullString("'0000-00-00 00:00:00'"), Null: "NO", DataType: "datetime", ColumnType: "datetime", StructTag: "json:\"col_datetime_2,omitempty\" "},
-			&ddl.Column{Field: "col_decimal_10_0", Pos: 11, Null: "YES", DataType: "decimal", Precision: dml.MakeNullInt64(10), Scale: dml.MakeNullInt64(0), ColumnType: "decimal(10,0) unsigned", StructTag: "json:\"col_decimal_10_0,omitempty\" "},
-			&ddl.Column{Field: "col_decimal_12_4", Pos: 12, Null: "YES", DataType: "decimal", Precision: dml.MakeNullInt64(12), Scale: dml.MakeNullInt64(4), ColumnType: "decimal(12,4)", StructTag: "json:\"col_decimal_12_4,omitempty\" "},
-			&ddl.Column{Field: "price_12_4a", Pos: 13, Null: "YES", DataType: "decimal", Precision: dml.MakeNullInt64(12), Scale: dml.MakeNullInt64(4), ColumnType: "decimal(12,4)", StructTag: "json:\"price_12_4a,omitempty\" "},
-			&ddl.Column{Field: "price_12_4b", Pos: 14, Default: dml.MakeNullString("0.0000"), Null: "NO", DataType: "decimal", Precision: dml.MakeNullInt64(12), Scale: dml.MakeNullInt64(4), ColumnType: "decimal(12,4)", StructTag: "json:\"price_12_4b,omitempty\" "},
-			&ddl.Column{Field: "col_decimal_12_3", Pos: 15, Default: dml.MakeNullString("0.000"), Null: "NO", DataType: "decimal", Precision: dml.MakeNullInt64(12), Scale: dml.MakeNullInt64(3), ColumnType: "decimal(12,3)", StructTag: "json:\"col_decimal_12_3,omitempty\" "},
-			&ddl.Column{Field: "col_decimal_20_6", Pos: 16, Default: dml.MakeNullString("0.000000"), Null: "NO", DataType: "decimal", Precision: dml.MakeNullInt64(20), Scale: dml.MakeNullInt64(6), ColumnType: "decimal(20,6)", StructTag: "json:\"col_decimal_20_6,omitempty\" "},
-			&ddl.Column{Field: "col_decimal_24_12", Pos: 17, Default: dml.MakeNullString("0.000000000000"), Null: "NO", DataType: "decimal", Precision: dml.MakeNullInt64(24), Scale: dml.MakeNullInt64(12), ColumnType: "decimal(24,12)", StructTag: "json:\"col_decimal_24_12,omitempty\" "},
-			&ddl.Column{Field: "col_float", Pos: 18, Default: dml.MakeNullString("1"), Null: "NO", DataType: "float", Precision: dml.MakeNullInt64(12), ColumnType: "float", StructTag: "json:\"col_float,omitempty\" "},
-			&ddl.Column{Field: "col_int_1", Pos: 19, Null: "YES", DataType: "int", Precision: dml.MakeNullInt64(10), Scale: dml.MakeNullInt64(0), ColumnType: "int(10)", Uniquified: true, StructTag: "json:\"col_int_1,omitempty\" "},
-			&ddl.Column{Field: "col_int_2", Pos: 20, Default: dml.MakeNullString("0"), Null: "NO", DataType: "int", Precision: dml.MakeNullInt64(10), Scale: dml.MakeNullInt64(0), ColumnType: "int(10)", Uniquified: true, StructTag: "json:\"col_int_2,omitempty\" "},
-			&ddl.Column{Field: "col_int_3", Pos: 21, Null: "YES", DataType: "int", Precision: dml.MakeNullInt64(10), Scale: dml.MakeNullInt64(0), ColumnType: "int(10) unsigned", StructTag: "json:\"col_int_3,omitempty\" "},
-			&ddl.Column{Field: "col_int_4", Pos: 22, Default: dml.MakeNullString("0"), Null: "NO", DataType: "int", Precision: dml.MakeNullInt64(10), Scale: dml.MakeNullInt64(0), ColumnType: "int(10) unsigned", StructTag: "json:\"col_int_4,omitempty\" "},
-			&ddl.Column{Field: "col_longtext_1", Pos: 23, Null: "YES", DataType: "longtext", CharMaxLength: dml.MakeNullInt64(4294967295), ColumnType: "longtext", StructTag: "json:\"col_longtext_1,omitempty\" "},
-			&ddl.Column{Field: "col_longtext_2", Pos: 24, Default: dml.MakeNullString("''"), Null: "NO", DataType: "longtext", CharMaxLength: dml.MakeNullInt64(4294967295), ColumnType: "longtext", Uniquified: true, StructTag: "json:\"col_longtext_2,omitempty\" "},
-			&ddl.Column{Field: "col_mediumblob", Pos: 25, Null: "YES", DataType: "mediumblob", CharMaxLength: dml.MakeNullInt64(16777215), ColumnType: "mediumblob", StructTag: "json:\"col_mediumblob,omitempty\" "},
-			&ddl.Column{Field: "col_mediumtext_1", Pos: 26, Null: "YES", DataType: "mediumtext", CharMaxLength: dml.MakeNullInt64(16777215), ColumnType: "mediumtext", StructTag: "json:\"col_mediumtext_1,omitempty\" "},
-			&ddl.Column{Field: "col_mediumtext_2", Pos: 27, Default: dml.MakeNullString("''"), Null: "NO", DataType: "mediumtext", CharMaxLength: dml.MakeNullInt64(16777215), ColumnType: "mediumtext", StructTag: "json:\"col_mediumtext_2,omitempty\" "},
-			&ddl.Column{Field: "col_smallint_1", Pos: 28, Null: "YES", DataType: "smallint", Precision: dml.MakeNullInt64(5), Scale: dml.MakeNullInt64(0), ColumnType: "smallint(5)", StructTag: "json:\"col_smallint_1,omitempty\" "},
-			&ddl.Column{Field: "col_smallint_2", Pos: 29, Default: dml.MakeNullString("0"), Null: "NO", DataType: "smallint", Precision: dml.MakeNullInt64(5), Scale: dml.MakeNullInt64(0), ColumnType: "smallint(5)", StructTag: "json:\"col_smallint_2,omitempty\" "},
-			&ddl.Column{Field: "col_smallint_3", Pos: 30, Null: "YES", DataType: "smallint", Precision: dml.MakeNullInt64(5), Scale: dml.MakeNullInt64(0), ColumnType: "smallint(5) unsigned", StructTag: "json:\"col_smallint_3,omitempty\" "},
-			&ddl.Column{Field: "col_smallint_4", Pos: 31, Default: dml.MakeNullString("0"), Null: "NO", DataType: "smallint", Precision: dml.MakeNullInt64(5), Scale: dml.MakeNullInt64(0), ColumnType: "smallint(5) unsigned", StructTag: "json:\"col_smallint_4,omitempty\" "},
-			&ddl.Column{Field: "has_smallint_5", Pos: 32, Default: dml.MakeNullString("0"), Null: "NO", DataType: "smallint", Precision: dml.MakeNullInt64(5), Scale: dml.MakeNullInt64(0), ColumnType: "smallint(5) unsigned", Uniquified: true, StructTag: "json:\"has_smallint_5,omitempty\" "},
-			&ddl.Column{Field: "is_smallint_5", Pos: 33, Null: "YES", DataType: "smallint", Precision: dml.MakeNullInt64(5), Scale: dml.MakeNullInt64(0), ColumnType: "smallint(5)", StructTag: "json:\"is_smallint_5,omitempty\" "},
-			&ddl.Column{Field: "col_text", Pos: 34, Null: "YES", DataType: "text", CharMaxLength: dml.MakeNullInt64(65535), ColumnType: "text", StructTag: "json:\"col_text,omitempty\" "},
-			&ddl.Column{Field: "col_timestamp_1", Pos: 35, Default: dml.MakeNullString("current_timestamp()"), Null: "NO", DataType: "timestamp", ColumnType: "timestamp", StructTag: "json:\"col_timestamp_1,omitempty\" "},
+			&ddl.Column{Field: "col_datetime_2", Pos: 10, Default: null.MakeString("'0000-00-00 00:00:00'"), Null: "NO", DataType: "datetime", ColumnType: "datetime", StructTag: "json:\"col_datetime_2,omitempty\" "},
+			&ddl.Column{Field: "col_decimal_10_0", Pos: 11, Null: "YES", DataType: "decimal", Precision: null.MakeInt64(10), Scale: null.MakeInt64(0), ColumnType: "decimal(10,0) unsigned", StructTag: "json:\"col_decimal_10_0,omitempty\" "},
+			&ddl.Column{Field: "col_decimal_12_4", Pos: 12, Null: "YES", DataType: "decimal", Precision: null.MakeInt64(12), Scale: null.MakeInt64(4), ColumnType: "decimal(12,4)", StructTag: "json:\"col_decimal_12_4,omitempty\" "},
+			&ddl.Column{Field: "price_12_4a", Pos: 13, Null: "YES", DataType: "decimal", Precision: null.MakeInt64(12), Scale: null.MakeInt64(4), ColumnType: "decimal(12,4)", StructTag: "json:\"price_12_4a,omitempty\" "},
+			&ddl.Column{Field: "price_12_4b", Pos: 14, Default: null.MakeString("0.0000"), Null: "NO", DataType: "decimal", Precision: null.MakeInt64(12), Scale: null.MakeInt64(4), ColumnType: "decimal(12,4)", StructTag: "json:\"price_12_4b,omitempty\" "},
+			&ddl.Column{Field: "col_decimal_12_3", Pos: 15, Default: null.MakeString("0.000"), Null: "NO", DataType: "decimal", Precision: null.MakeInt64(12), Scale: null.MakeInt64(3), ColumnType: "decimal(12,3)", StructTag: "json:\"col_decimal_12_3,omitempty\" "},
+			&ddl.Column{Field: "col_decimal_20_6", Pos: 16, Default: null.MakeString("0.000000"), Null: "NO", DataType: "decimal", Precision: null.MakeInt64(20), Scale: null.MakeInt64(6), ColumnType: "decimal(20,6)", StructTag: "json:\"col_decimal_20_6,omitempty\" "},
+			&ddl.Column{Field: "col_decimal_24_12", Pos: 17, Default: null.MakeString("0.000000000000"), Null: "NO", DataType: "decimal", Precision: null.MakeInt64(24), Scale: null.MakeInt64(12), ColumnType: "decimal(24,12)", StructTag: "json:\"col_decimal_24_12,omitempty\" "},
+			&ddl.Column{Field: "col_float", Pos: 18, Default: null.MakeString("1"), Null: "NO", DataType: "float", Precision: null.MakeInt64(12), ColumnType: "float", StructTag: "json:\"col_float,omitempty\" "},
+			&ddl.Column{Field: "col_int_1", Pos: 19, Null: "YES", DataType: "int", Precision: null.MakeInt64(10), Scale: null.MakeInt64(0), ColumnType: "int(10)", Uniquified: true, StructTag: "json:\"col_int_1,omitempty\" "},
+			&ddl.Column{Field: "col_int_2", Pos: 20, Default: null.MakeString("0"), Null: "NO", DataType: "int", Precision: null.MakeInt64(10), Scale: null.MakeInt64(0), ColumnType: "int(10)", Uniquified: true, StructTag: "json:\"col_int_2,omitempty\" "},
+			&ddl.Column{Field: "col_int_3", Pos: 21, Null: "YES", DataType: "int", Precision: null.MakeInt64(10), Scale: null.MakeInt64(0), ColumnType: "int(10) unsigned", StructTag: "json:\"col_int_3,omitempty\" "},
+			&ddl.Column{Field: "col_int_4", Pos: 22, Default: null.MakeString("0"), Null: "NO", DataType: "int", Precision: null.MakeInt64(10), Scale: null.MakeInt64(0), ColumnType: "int(10) unsigned", StructTag: "json:\"col_int_4,omitempty\" "},
+			&ddl.Column{Field: "col_longtext_1", Pos: 23, Null: "YES", DataType: "longtext", CharMaxLength: null.MakeInt64(4294967295), ColumnType: "longtext", StructTag: "json:\"col_longtext_1,omitempty\" "},
+			&ddl.Column{Field: "col_longtext_2", Pos: 24, Default: null.MakeString("''"), Null: "NO", DataType: "longtext", CharMaxLength: null.MakeInt64(4294967295), ColumnType: "longtext", Uniquified: true, StructTag: "json:\"col_longtext_2,omitempty\" "},
+			&ddl.Column{Field: "col_mediumblob", Pos: 25, Null: "YES", DataType: "mediumblob", CharMaxLength: null.MakeInt64(16777215), ColumnType: "mediumblob", StructTag: "json:\"col_mediumblob,omitempty\" "},
+			&ddl.Column{Field: "col_mediumtext_1", Pos: 26, Null: "YES", DataType: "mediumtext", CharMaxLength: null.MakeInt64(16777215), ColumnType: "mediumtext", StructTag: "json:\"col_mediumtext_1,omitempty\" "},
+			&ddl.Column{Field: "col_mediumtext_2", Pos: 27, Default: null.MakeString("''"), Null: "NO", DataType: "mediumtext", CharMaxLength: null.MakeInt64(16777215), ColumnType: "mediumtext", StructTag: "json:\"col_mediumtext_2,omitempty\" "},
+			&ddl.Column{Field: "col_smallint_1", Pos: 28, Null: "YES", DataType: "smallint", Precision: null.MakeInt64(5), Scale: null.MakeInt64(0), ColumnType: "smallint(5)", StructTag: "json:\"col_smallint_1,omitempty\" "},
+			&ddl.Column{Field: "col_smallint_2", Pos: 29, Default: null.MakeString("0"), Null: "NO", DataType: "smallint", Precision: null.MakeInt64(5), Scale: null.MakeInt64(0), ColumnType: "smallint(5)", StructTag: "json:\"col_smallint_2,omitempty\" "},
+			&ddl.Column{Field: "col_smallint_3", Pos: 30, Null: "YES", DataType: "smallint", Precision: null.MakeInt64(5), Scale: null.MakeInt64(0), ColumnType: "smallint(5) unsigned", StructTag: "json:\"col_smallint_3,omitempty\" "},
+			&ddl.Column{Field: "col_smallint_4", Pos: 31, Default: null.MakeString("0"), Null: "NO", DataType: "smallint", Precision: null.MakeInt64(5), Scale: null.MakeInt64(0), ColumnType: "smallint(5) unsigned", StructTag: "json:\"col_smallint_4,omitempty\" "},
+			&ddl.Column{Field: "has_smallint_5", Pos: 32, Default: null.MakeString("0"), Null: "NO", DataType: "smallint", Precision: null.MakeInt64(5), Scale: null.MakeInt64(0), ColumnType: "smallint(5) unsigned", Uniquified: true, StructTag: "json:\"has_smallint_5,omitempty\" "},
+			&ddl.Column{Field: "is_smallint_5", Pos: 33, Null: "YES", DataType: "smallint", Precision: null.MakeInt64(5), Scale: null.MakeInt64(0), ColumnType: "smallint(5)", StructTag: "json:\"is_smallint_5,omitempty\" "},
+			&ddl.Column{Field: "col_text", Pos: 34, Null: "YES", DataType: "text", CharMaxLength: null.MakeInt64(65535), ColumnType: "text", StructTag: "json:\"col_text,omitempty\" "},
+			&ddl.Column{Field: "col_timestamp_1", Pos: 35, Default: null.MakeString("current_timestamp()"), Null: "NO", DataType: "timestamp", ColumnType: "timestamp", StructTag: "json:\"col_timestamp_1,omitempty\" "},
 			&ddl.Column{Field: "col_timestamp_2", Pos: 36, Null: "YES", DataType: "timestamp", ColumnType: "timestamp", StructTag: "json:\"col_timestamp_2,omitempty\" "},
-			&ddl.Column{Field: "col_tinyint_1", Pos: 37, Default: dml.MakeNullString("0"), Null: "NO", DataType: "tinyint", Precision: dml.MakeNullInt64(3), Scale: dml.MakeNullInt64(0), ColumnType: "tinyint(1)", StructTag: "json:\"col_tinyint_1,omitempty\" "},
-			&ddl.Column{Field: "col_varchar_1", Pos: 38, Default: dml.MakeNullString("'0'"), Null: "NO", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(1), ColumnType: "varchar(1)", StructTag: "json:\"col_varchar_1,omitempty\" "},
-			&ddl.Column{Field: "col_varchar_100", Pos: 39, Null: "YES", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(100), ColumnType: "varchar(100)", StructTag: "json:\"col_varchar_100,omitempty\" "},
-			&ddl.Column{Field: "col_varchar_16", Pos: 40, Default: dml.MakeNullString("'de_DE'"), Null: "NO", DataType: "varchar", CharMaxLength: dml.MakeNullInt64(16), ColumnType: "varchar(16)", StructTag: "json:\"col_varchar_16,omitempty\" "},
-			&ddl.Column{Field: "col_char_1", Pos: 41, Null: "YES", DataType: "char", CharMaxLength: dml.MakeNullInt64(21), ColumnType: "char(21)", StructTag: "json:\"col_char_1,omitempty\" "},
-			&ddl.Column{Field: "col_char_2", Pos: 42, Default: dml.MakeNullString("'xchar'"), Null: "NO", DataType: "char", CharMaxLength: dml.MakeNullInt64(17), ColumnType: "char(17)", StructTag: "json:\"col_char_2,omitempty\" "},
+			&ddl.Column{Field: "col_tinyint_1", Pos: 37, Default: null.MakeString("0"), Null: "NO", DataType: "tinyint", Precision: null.MakeInt64(3), Scale: null.MakeInt64(0), ColumnType: "tinyint(1)", StructTag: "json:\"col_tinyint_1,omitempty\" "},
+			&ddl.Column{Field: "col_varchar_1", Pos: 38, Default: null.MakeString("'0'"), Null: "NO", DataType: "varchar", CharMaxLength: null.MakeInt64(1), ColumnType: "varchar(1)", StructTag: "json:\"col_varchar_1,omitempty\" "},
+			&ddl.Column{Field: "col_varchar_100", Pos: 39, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(100), ColumnType: "varchar(100)", StructTag: "json:\"col_varchar_100,omitempty\" "},
+			&ddl.Column{Field: "col_varchar_16", Pos: 40, Default: null.MakeString("'de_DE'"), Null: "NO", DataType: "varchar", CharMaxLength: null.MakeInt64(16), ColumnType: "varchar(16)", StructTag: "json:\"col_varchar_16,omitempty\" "},
+			&ddl.Column{Field: "col_char_1", Pos: 41, Null: "YES", DataType: "char", CharMaxLength: null.MakeInt64(21), ColumnType: "char(21)", StructTag: "json:\"col_char_1,omitempty\" "},
+			&ddl.Column{Field: "col_char_2", Pos: 42, Default: null.MakeString("'xchar'"), Null: "NO", DataType: "char", CharMaxLength: null.MakeInt64(17), ColumnType: "char(17)", StructTag: "json:\"col_char_2,omitempty\" "},
 		}...),
 	)
 	if err != nil {
@@ -110,11 +113,11 @@ func NewTables(opts ...ddl.TableOption) (*ddl.Tables, error) {
 // CoreConfigData represents a single row for DB table `core_config_data`.
 // Auto generated.
 type CoreConfigData struct {
-	ConfigID uint64         `json:"config_id,omitempty"`     // config_id int(10) unsigned NOT NULL PRI  auto_increment "Config Id"
-	Scope    string         `json:"scope,omitempty"`         // scope varchar(8) NOT NULL MUL DEFAULT ''default''  "Config Scope"
-	ScopeID  int64          `json:"scope_id" xml:"scope_id"` // scope_id int(11) NOT NULL  DEFAULT '0'  "Config Scope Id"
-	Path     string         `json:"x_path" xml:"y_path"`     // path varchar(255) NOT NULL  DEFAULT ''general''  "Config Path"
-	Value    dml.NullString `json:"value,omitempty"`         // value text NULL  DEFAULT 'NULL'  "Config Value"
+	ConfigID uint64      `json:"config_id,omitempty"`     // config_id int(10) unsigned NOT NULL PRI  auto_increment "Config Id"
+	Scope    string      `json:"scope,omitempty"`         // scope varchar(8) NOT NULL MUL DEFAULT ''default''  "Config Scope"
+	ScopeID  int64       `json:"scope_id" xml:"scope_id"` // scope_id int(11) NOT NULL  DEFAULT '0'  "Config Scope Id"
+	Path     string      `json:"x_path" xml:"y_path"`     // path varchar(255) NOT NULL  DEFAULT ''general''  "Config Path"
+	Value    null.String `json:"value,omitempty"`         // value text NULL  DEFAULT 'NULL'  "Config Value"
 }
 
 // NewCoreConfigData creates a new pointer with pre-initialized fields. Auto
@@ -153,6 +156,78 @@ func (e *CoreConfigData) MapColumns(cm *dml.ColumnMap) error {
 	return errors.WithStack(cm.Err())
 }
 
+// Insert inserts the entity into table `core_config_data` and assigns the auto
+// increment ID. Auto generated.
+func (e *CoreConfigData) Insert(ctx context.Context, dbc *dml.ConnPool) (sql.Result, error) {
+	res, err := dbc.InsertInto("core_config_data").
+		AddColumns("scope", "scope_id", "path", "value").
+		WithArgs().Record("", e).ExecContext(ctx)
+	return res, errors.WithStack(err)
+}
+
+// Upsert inserts the entity into table `core_config_data` or updates all
+// non-primary key columns if the entity already exists. Auto generated.
+func (e *CoreConfigData) Upsert(ctx context.Context, dbc *dml.ConnPool) (sql.Result, error) {
+	res, err := dbc.InsertInto("core_config_data").
+		AddColumns("config_id", "scope", "scope_id", "path", "value").
+		AddOnDuplicateKeyExclude("config_id").
+		OnDuplicateKey().
+		WithArgs().Record("", e).ExecContext(ctx)
+	return res, errors.WithStack(err)
+}
+
+// Update updates all non-primary key columns of the entity in table
+// `core_config_data` identified by its primary key. Auto generated.
+func (e *CoreConfigData) Update(ctx context.Context, dbc *dml.ConnPool) (sql.Result, error) {
+	res, err := dbc.Update("core_config_data").
+		AddColumns("scope", "scope_id", "path", "value").
+		Where(dml.Column("config_id").PlaceHolder()).
+		WithArgs().Record("", e).ExecContext(ctx)
+	return res, errors.WithStack(err)
+}
+
+// Delete deletes the entity from table `core_config_data` identified by its
+// primary key. Auto generated.
+func (e *CoreConfigData) Delete(ctx context.Context, dbc *dml.ConnPool) (sql.Result, error) {
+	res, err := dbc.DeleteFrom("core_config_data").
+		Where(dml.Column("config_id").PlaceHolder()).
+		WithArgs().Record("", e).ExecContext(ctx)
+	return res, errors.WithStack(err)
+}
+
+// LoadByPK loads all columns of table `core_config_data` into the entity. The
+// primary key fields of the entity must be set. Found is false if no row
+// matches. Auto generated.
+func (e *CoreConfigData) LoadByPK(ctx context.Context, dbc *dml.ConnPool) (found bool, err error) {
+	rowCount, err := dbc.SelectFrom("core_config_data").
+		AddColumns("config_id", "scope", "scope_id", "path", "value").
+		Where(dml.Column("config_id").PlaceHolder()).
+		WithArgs().Record("", e).Load(ctx, e)
+	return rowCount > 0, errors.WithStack(err)
+}
+
+// Validate checks the field values against the column constraints of table
+// `core_config_data` like NOT NULL, maximum length, enum values and integer
+// ranges. It returns a NotValid error. Auto generated.
+func (e *CoreConfigData) Validate() error {
+	if e == nil {
+		return errors.NotValid.Newf("[testdata] CoreConfigData.Validate: Entity cannot be nil")
+	}
+	if e.ConfigID > 4294967295 {
+		return errors.NotValid.Newf("[testdata] CoreConfigData.Validate: %s", "Column `config_id` is out of range [0,4294967295]")
+	}
+	if utf8.RuneCountInString(e.Scope) > 8 {
+		return errors.NotValid.Newf("[testdata] CoreConfigData.Validate: %s", "Column `scope` exceeds the maximum length of 8 characters")
+	}
+	if e.ScopeID < -2147483648 || e.ScopeID > 2147483647 {
+		return errors.NotValid.Newf("[testdata] CoreConfigData.Validate: %s", "Column `scope_id` is out of range [-2147483648,2147483647]")
+	}
+	if utf8.RuneCountInString(e.Path) > 255 {
+		return errors.NotValid.Newf("[testdata] CoreConfigData.Validate: %s", "Column `path` exceeds the maximum length of 255 characters")
+	}
+	return nil
+}
+
 // experimental, for now private but depends on later usage to make it public.
 type sliceCoreConfigData []*CoreConfigData
 
@@ -172,29 +247,35 @@ func MakeCoreConfigDataCollection() CoreConfigDataCollection {
 	}
 }
 
-func (cc CoreConfigDataCollection) scanColumns(cm *dml.ColumnMap, e *CoreConfigData, idx uint64) error {
-	if err := cc.BeforeMapColumns(idx, e); err != nil {
-		return errors.WithStack(err)
+func (cc *CoreConfigDataCollection) scanColumns(cm *dml.ColumnMap, e *CoreConfigData, idx uint64) error {
+	if cc.BeforeMapColumns != nil {
+		if err := cc.BeforeMapColumns(idx, e); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	if err := e.MapColumns(cm); err != nil {
 		return errors.WithStack(err)
 	}
-	if err := cc.AfterMapColumns(idx, e); err != nil {
-		return errors.WithStack(err)
+	if cc.AfterMapColumns != nil {
+		if err := cc.AfterMapColumns(idx, e); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	return nil
 }
 
 // MapColumns implements dml.ColumnMapper interface. Auto generated.
-func (cc CoreConfigDataCollection) MapColumns(cm *dml.ColumnMap) error {
+func (cc *CoreConfigDataCollection) MapColumns(cm *dml.ColumnMap) error {
 	switch m := cm.Mode(); m {
-	case dml.ColumnMapEntityReadAll, dml.ColumnMapEntityReadSet:
+	case dml.ColumnMapEntityReadAll, dml.ColumnMapEntityReadSet, dml.ColumnMapLastInsertID:
 		for i, e := range cc.Data {
 			if err := cc.scanColumns(cm, e, uint64(i)); err != nil {
 				return errors.WithStack(err)
 			}
 		}
 	case dml.ColumnMapScan:
+		// case for scanning when loading certain rows, hence we write data from
+		// the DB into the struct in each for-loop.
 		if cm.Count == 0 {
 			cc.Data = cc.Data[:0]
 		}
@@ -207,9 +288,9 @@ func (cc CoreConfigDataCollection) MapColumns(cm *dml.ColumnMap) error {
 		for cm.Next() {
 			switch c := cm.Column(); c {
 			case "config_id":
-				cm.Args = cm.Args.Uint64s(cc.ConfigIDs()...)
+				cm.Uint64s(cc.ConfigIDs()...)
 			case "path", "storage_location", "config_directory":
-				cm.Args = cm.Args.Strings(cc.Paths()...)
+				cm.Strings(cc.Paths()...)
 			default:
 				return errors.NotFound.Newf("[testdata] CoreConfigDataCollection Column %q not found", c)
 			}
@@ -243,9 +324,10 @@ func (cc CoreConfigDataCollection) Paths(ret ...string) []string {
 
 	dupCheck := make(map[string]struct{}, len(cc.Data))
 	for _, e := range cc.Data {
-		if _, ok := dupCheck[e.Path]; !ok {
-			ret = append(ret, e.Path)
-			dupCheck[e.Path] = struct{}{}
+		v := e.Path
+		if _, ok := dupCheck[v]; !ok {
+			ret = append(ret, v)
+			dupCheck[v] = struct{}{}
 		}
 	}
 	return ret
@@ -334,37 +416,97 @@ func (s *sliceCoreConfigData) Prepend(n *CoreConfigData) {
 	s.Insert(n, 0)
 }
 
+// Map will run function f on all items in []*CoreConfigData and returns a new
+// slice with the returned items.
+// Auto generated via dmlgen.
+func (s sliceCoreConfigData) Map(f func(*CoreConfigData) *CoreConfigData) sliceCoreConfigData {
+	sl := make(sliceCoreConfigData, len(s))
+	for i, e := range s {
+		sl[i] = f(e)
+	}
+	return sl
+}
+
+// Each will run function f on all items in the collection.
+// Auto generated via dmlgen.
+func (cc *CoreConfigDataCollection) Each(f func(*CoreConfigData)) *CoreConfigDataCollection {
+	cc.Data.Each(f)
+	return cc
+}
+
+// Filter returns a new collection filtered by predicate f. The hooks
+// BeforeMapColumns and AfterMapColumns are getting copied.
+// Auto generated via dmlgen.
+func (cc *CoreConfigDataCollection) Filter(f func(*CoreConfigData) bool) *CoreConfigDataCollection {
+	return &CoreConfigDataCollection{
+		Data:             cc.Data.Filter(f),
+		BeforeMapColumns: cc.BeforeMapColumns,
+		AfterMapColumns:  cc.AfterMapColumns,
+	}
+}
+
+// Map returns a new collection with the items returned by function f. The
+// hooks BeforeMapColumns and AfterMapColumns are getting copied.
+// Auto generated via dmlgen.
+func (cc *CoreConfigDataCollection) Map(f func(*CoreConfigData) *CoreConfigData) *CoreConfigDataCollection {
+	return &CoreConfigDataCollection{
+		Data:             cc.Data.Map(f),
+		BeforeMapColumns: cc.BeforeMapColumns,
+		AfterMapColumns:  cc.AfterMapColumns,
+	}
+}
+
+// Cut will remove items i through j-1 from the collection.
+// Auto generated via dmlgen.
+func (cc *CoreConfigDataCollection) Cut(i, j int) *CoreConfigDataCollection {
+	cc.Data.Cut(i, j)
+	return cc
+}
+
+// IDs returns a slice or appends to a slice all primary key values of column
+// `config_id`.
+// Auto generated via dmlgen.
+func (cc *CoreConfigDataCollection) IDs(ret ...uint64) []uint64 {
+	if ret == nil {
+		ret = make([]uint64, 0, len(cc.Data))
+	}
+	for _, e := range cc.Data {
+		ret = append(ret, e.ConfigID)
+	}
+	return ret
+}
+
 // CustomerEntity represents a single row for DB table `customer_entity`.
 // Auto generated.
 type CustomerEntity struct {
-	EntityID               uint64         // entity_id int(10) unsigned NOT NULL PRI  auto_increment "Entity Id"
-	WebsiteID              dml.NullInt64  // website_id smallint(5) unsigned NULL MUL   "Website Id"
-	Email                  dml.NullString // email varchar(255) NULL MUL   "Email"
-	GroupID                uint64         // group_id smallint(5) unsigned NOT NULL  DEFAULT '0'  "Group Id"
-	IncrementID            dml.NullString // increment_id varchar(50) NULL    "Increment Id"
-	StoreID                dml.NullInt64  // store_id smallint(5) unsigned NULL MUL DEFAULT '0'  "Store Id"
-	CreatedAt              time.Time      // created_at timestamp NOT NULL  DEFAULT 'current_timestamp()'  "Created At"
-	UpdatedAt              time.Time      // updated_at timestamp NOT NULL  DEFAULT 'current_timestamp()' on update current_timestamp() "Updated At"
-	IsActive               bool           // is_active smallint(5) unsigned NOT NULL  DEFAULT '1'  "Is Active"
-	DisableAutoGroupChange uint64         // disable_auto_group_change smallint(5) unsigned NOT NULL  DEFAULT '0'  "Disable automatic group change based on VAT ID"
-	CreatedIn              dml.NullString // created_in varchar(255) NULL    "Created From"
-	Prefix                 dml.NullString // prefix varchar(40) NULL    "Name Prefix"
-	Firstname              dml.NullString // firstname varchar(255) NULL MUL   "First Name"
-	Middlename             dml.NullString // middlename varchar(255) NULL    "Middle Name/Initial"
-	Lastname               dml.NullString // lastname varchar(255) NULL MUL   "Last Name"
-	Suffix                 dml.NullString // suffix varchar(40) NULL    "Name Suffix"
-	Dob                    dml.NullTime   // dob date NULL    "Date of Birth"
-	PasswordHash           dml.NullString // password_hash varchar(128) NULL    "Password_hash"
-	RpToken                dml.NullString // rp_token varchar(128) NULL    "Reset password token"
-	RpTokenCreatedAt       dml.NullTime   // rp_token_created_at datetime NULL    "Reset password token creation time"
-	DefaultBilling         dml.NullInt64  // default_billing int(10) unsigned NULL    "Default Billing Address"
-	DefaultShipping        dml.NullInt64  // default_shipping int(10) unsigned NULL    "Default Shipping Address"
-	Taxvat                 dml.NullString // taxvat varchar(50) NULL    "Tax/VAT Number"
-	Confirmation           dml.NullString // confirmation varchar(64) NULL    "Is Confirmed"
-	Gender                 dml.NullInt64  // gender smallint(5) unsigned NULL    "Gender"
-	FailuresNum            dml.NullInt64  // failures_num smallint(6) NULL  DEFAULT '0'  "Failure Number"
-	FirstFailure           dml.NullTime   // first_failure timestamp NULL    "First Failure"
-	LockExpires            dml.NullTime   // lock_expires timestamp NULL    "Lock Expiration Date"
+	EntityID               uint64      // entity_id int(10) unsigned NOT NULL PRI  auto_increment "Entity Id"
+	WebsiteID              null.Int64  // website_id smallint(5) unsigned NULL MUL   "Website Id"
+	Email                  null.String // email varchar(255) NULL MUL   "Email"
+	GroupID                uint64      // group_id smallint(5) unsigned NOT NULL  DEFAULT '0'  "Group Id"
+	IncrementID            null.String // increment_id varchar(50) NULL    "Increment Id"
+	StoreID                null.Int64  // store_id smallint(5) unsigned NULL MUL DEFAULT '0'  "Store Id"
+	CreatedAt              time.Time   // created_at timestamp NOT NULL  DEFAULT 'current_timestamp()'  "Created At"
+	UpdatedAt              time.Time   // updated_at timestamp NOT NULL  DEFAULT 'current_timestamp()' on update current_timestamp() "Updated At"
+	IsActive               bool        // is_active smallint(5) unsigned NOT NULL  DEFAULT '1'  "Is Active"
+	DisableAutoGroupChange uint64      // disable_auto_group_change smallint(5) unsigned NOT NULL  DEFAULT '0'  "Disable automatic group change based on VAT ID"
+	CreatedIn              null.String // created_in varchar(255) NULL    "Created From"
+	Prefix                 null.String // prefix varchar(40) NULL    "Name Prefix"
+	Firstname              null.String // firstname varchar(255) NULL MUL   "First Name"
+	Middlename             null.String // middlename varchar(255) NULL    "Middle Name/Initial"
+	Lastname               null.String // lastname varchar(255) NULL MUL   "Last Name"
+	Suffix                 null.String // suffix varchar(40) NULL    "Name Suffix"
+	Dob                    null.Time   // dob date NULL    "Date of Birth"
+	PasswordHash           null.String // password_hash varchar(128) NULL    "Password_hash"
+	RpToken                null.String // rp_token varchar(128) NULL    "Reset password token"
+	RpTokenCreatedAt       null.Time   // rp_token_created_at datetime NULL    "Reset password token creation time"
+	DefaultBilling         null.Int64  // default_billing int(10) unsigned NULL    "Default Billing Address"
+	DefaultShipping        null.Int64  // default_shipping int(10) unsigned NULL    "Default Shipping Address"
+	Taxvat                 null.String // taxvat varchar(50) NULL    "Tax/VAT Number"
+	Confirmation           null.String // confirmation varchar(64) NULL    "Is Confirmed"
+	Gender                 null.Int64  // gender smallint(5) unsigned NULL    "Gender"
+	FailuresNum            null.Int64  // failures_num smallint(6) NULL  DEFAULT '0'  "Failure Number"
+	FirstFailure           null.Time   // first_failure timestamp NULL    "First Failure"
+	LockExpires            null.Time   // lock_expires timestamp NULL    "Lock Expiration Date"
 }
 
 // NewCustomerEntity creates a new pointer with pre-initialized fields. Auto
@@ -468,29 +610,35 @@ func MakeCustomerEntityCollection() CustomerEntityCollection {
 	}
 }
 
-func (cc CustomerEntityCollection) scanColumns(cm *dml.ColumnMap, e *CustomerEntity, idx uint64) error {
-	if err := cc.BeforeMapColumns(idx, e); err != nil {
-		return errors.WithStack(err)
+func (cc *CustomerEntityCollection) scanColumns(cm *dml.ColumnMap, e *CustomerEntity, idx uint64) error {
+	if cc.BeforeMapColumns != nil {
+		if err := cc.BeforeMapColumns(idx, e); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	if err := e.MapColumns(cm); err != nil {
 		return errors.WithStack(err)
 	}
-	if err := cc.AfterMapColumns(idx, e); err != nil {
-		return errors.WithStack(err)
+	if cc.AfterMapColumns != nil {
+		if err := cc.AfterMapColumns(idx, e); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	return nil
 }
 
 // MapColumns implements dml.ColumnMapper interface. Auto generated.
-func (cc CustomerEntityCollection) MapColumns(cm *dml.ColumnMap) error {
+func (cc *CustomerEntityCollection) MapColumns(cm *dml.ColumnMap) error {
 	switch m := cm.Mode(); m {
-	case dml.ColumnMapEntityReadAll, dml.ColumnMapEntityReadSet:
+	case dml.ColumnMapEntityReadAll, dml.ColumnMapEntityReadSet, dml.ColumnMapLastInsertID:
 		for i, e := range cc.Data {
 			if err := cc.scanColumns(cm, e, uint64(i)); err != nil {
 				return errors.WithStack(err)
 			}
 		}
 	case dml.ColumnMapScan:
+		// case for scanning when loading certain rows, hence we write data from
+		// the DB into the struct in each for-loop.
 		if cm.Count == 0 {
 			cc.Data = cc.Data[:0]
 		}
@@ -503,7 +651,7 @@ func (cc CustomerEntityCollection) MapColumns(cm *dml.ColumnMap) error {
 		for cm.Next() {
 			switch c := cm.Column(); c {
 			case "entity_id", "customer_id", "parent_id":
-				cm.Args = cm.Args.Uint64s(cc.EntityIDs()...)
+				cm.Uint64s(cc.EntityIDs()...)
 			default:
 				return errors.NotFound.Newf("[testdata] CustomerEntityCollection Column %q not found", c)
 			}
@@ -609,6 +757,66 @@ func (s *sliceCustomerEntity) Prepend(n *CustomerEntity) {
 	s.Insert(n, 0)
 }
 
+// Map will run function f on all items in []*CustomerEntity and returns a new
+// slice with the returned items.
+// Auto generated via dmlgen.
+func (s sliceCustomerEntity) Map(f func(*CustomerEntity) *CustomerEntity) sliceCustomerEntity {
+	sl := make(sliceCustomerEntity, len(s))
+	for i, e := range s {
+		sl[i] = f(e)
+	}
+	return sl
+}
+
+// Each will run function f on all items in the collection.
+// Auto generated via dmlgen.
+func (cc *CustomerEntityCollection) Each(f func(*CustomerEntity)) *CustomerEntityCollection {
+	cc.Data.Each(f)
+	return cc
+}
+
+// Filter returns a new collection filtered by predicate f. The hooks
+// BeforeMapColumns and AfterMapColumns are getting copied.
+// Auto generated via dmlgen.
+func (cc *CustomerEntityCollection) Filter(f func(*CustomerEntity) bool) *CustomerEntityCollection {
+	return &CustomerEntityCollection{
+		Data:             cc.Data.Filter(f),
+		BeforeMapColumns: cc.BeforeMapColumns,
+		AfterMapColumns:  cc.AfterMapColumns,
+	}
+}
+
+// Map returns a new collection with the items returned by function f. The
+// hooks BeforeMapColumns and AfterMapColumns are getting copied.
+// Auto generated via dmlgen.
+func (cc *CustomerEntityCollection) Map(f func(*CustomerEntity) *CustomerEntity) *CustomerEntityCollection {
+	return &CustomerEntityCollection{
+		Data:             cc.Data.Map(f),
+		BeforeMapColumns: cc.BeforeMapColumns,
+		AfterMapColumns:  cc.AfterMapColumns,
+	}
+}
+
+// Cut will remove items i through j-1 from the collection.
+// Auto generated via dmlgen.
+func (cc *CustomerEntityCollection) Cut(i, j int) *CustomerEntityCollection {
+	cc.Data.Cut(i, j)
+	return cc
+}
+
+// IDs returns a slice or appends to a slice all primary key values of column
+// `entity_id`.
+// Auto generated via dmlgen.
+func (cc *CustomerEntityCollection) IDs(ret ...uint64) []uint64 {
+	if ret == nil {
+		ret = make([]uint64, 0, len(cc.Data))
+	}
+	for _, e := range cc.Data {
+		ret = append(ret, e.EntityID)
+	}
+	return ret
+}
+
 // UnmarshalJSON implements interface json.Unmarshaler.
 func (cc *CustomerEntityCollection) UnmarshalJSON(b []byte) (err error) {
 	return json.Unmarshal(b, cc.Data)
@@ -623,50 +831,51 @@ func (cc *CustomerEntityCollection) MarshalJSON() ([]byte, error) {
 // DmlgenTypes represents a single row for DB table `dmlgen_types`.
 // Auto generated.
 // Just another comment.
+//
 //easyjson:json
 type DmlgenTypes struct {
-	ID             int64          `json:"id,omitempty" `                // id int(11) NOT NULL PRI  auto_increment ""
-	ColBigint1     dml.NullInt64  `json:"col_bigint_1,omitempty" `      // col_bigint_1 bigint(20) NULL    ""
-	ColBigint2     int64          `json:"col_bigint_2,omitempty" `      // col_bigint_2 bigint(20) NOT NULL  DEFAULT '0'  ""
-	ColBigint3     dml.NullInt64  `json:"col_bigint_3,omitempty" `      // col_bigint_3 bigint(20) unsigned NULL    ""
-	ColBigint4     uint64         `json:"col_bigint_4,omitempty" `      // col_bigint_4 bigint(20) unsigned NOT NULL  DEFAULT '0'  ""
-	ColBlob        dml.NullString `json:"col_blob,omitempty" `          // col_blob blob NULL    ""
-	ColDate1       dml.NullTime   `json:"col_date_1,omitempty" `        // col_date_1 date NULL    ""
-	ColDate2       time.Time      `json:"col_date_2,omitempty" `        // col_date_2 date NOT NULL  DEFAULT ''0000-00-00''  ""
-	ColDatetime1   dml.NullTime   `json:"col_datetime_1,omitempty" `    // col_datetime_1 datetime NULL    ""
-	ColDatetime2   time.Time      `json:"col_datetime_2,omitempty" `    // col_datetime_2 datetime NOT NULL  DEFAULT ''0000-00-00 00:00:00''  ""
-	ColDecimal100  dml.Decimal    `json:"col_decimal_10_0,omitempty" `  // col_decimal_10_0 decimal(10,0) unsigned NULL    ""
-	ColDecimal124  dml.Decimal    `json:"col_decimal_12_4,omitempty" `  // col_decimal_12_4 decimal(12,4) NULL    ""
-	Price124a      dml.Decimal    `json:"price_12_4a,omitempty" `       // price_12_4a decimal(12,4) NULL    ""
-	Price124b      dml.Decimal    `json:"price_12_4b,omitempty" `       // price_12_4b decimal(12,4) NOT NULL  DEFAULT '0.0000'  ""
-	ColDecimal123  dml.Decimal    `json:"col_decimal_12_3,omitempty" `  // col_decimal_12_3 decimal(12,3) NOT NULL  DEFAULT '0.000'  ""
-	ColDecimal206  dml.Decimal    `json:"col_decimal_20_6,omitempty" `  // col_decimal_20_6 decimal(20,6) NOT NULL  DEFAULT '0.000000'  ""
-	ColDecimal2412 dml.Decimal    `json:"col_decimal_24_12,omitempty" ` // col_decimal_24_12 decimal(24,12) NOT NULL  DEFAULT '0.000000000000'  ""
-	ColFloat       float64        `json:"col_float,omitempty" `         // col_float float NOT NULL  DEFAULT '1'  ""
-	ColInt1        dml.NullInt64  `json:"col_int_1,omitempty" `         // col_int_1 int(10) NULL    ""
-	ColInt2        int64          `json:"col_int_2,omitempty" `         // col_int_2 int(10) NOT NULL  DEFAULT '0'  ""
-	ColInt3        dml.NullInt64  `json:"col_int_3,omitempty" `         // col_int_3 int(10) unsigned NULL    ""
-	ColInt4        uint64         `json:"col_int_4,omitempty" `         // col_int_4 int(10) unsigned NOT NULL  DEFAULT '0'  ""
-	ColLongtext1   dml.NullString `json:"col_longtext_1,omitempty" `    // col_longtext_1 longtext NULL    ""
-	ColLongtext2   string         `json:"col_longtext_2,omitempty" `    // col_longtext_2 longtext NOT NULL  DEFAULT ''''  ""
-	ColMediumblob  dml.NullString `json:"col_mediumblob,omitempty" `    // col_mediumblob mediumblob NULL    ""
-	ColMediumtext1 dml.NullString `json:"col_mediumtext_1,omitempty" `  // col_mediumtext_1 mediumtext NULL    ""
-	ColMediumtext2 string         `json:"col_mediumtext_2,omitempty" `  // col_mediumtext_2 mediumtext NOT NULL  DEFAULT ''''  ""
-	ColSmallint1   dml.NullInt64  `json:"col_smallint_1,omitempty" `    // col_smallint_1 smallint(5) NULL    ""
-	ColSmallint2   int64          `json:"col_smallint_2,omitempty" `    // col_smallint_2 smallint(5) NOT NULL  DEFAULT '0'  ""
-	ColSmallint3   dml.NullInt64  `json:"col_smallint_3,omitempty" `    // col_smallint_3 smallint(5) unsigned NULL    ""
-	ColSmallint4   uint64         `json:"col_smallint_4,omitempty" `    // col_smallint_4 smallint(5) unsigned NOT NULL  DEFAULT '0'  ""
-	HasSmallint5   bool           `json:"has_smallint_5,omitempty" `    // has_smallint_5 smallint(5) unsigned NOT NULL  DEFAULT '0'  ""
-	IsSmallint5    dml.NullBool   `json:"is_smallint_5,omitempty" `     // is_smallint_5 smallint(5) NULL    ""
-	ColText        dml.NullString `json:"col_text,omitempty" `          // col_text text NULL    ""
-	ColTimestamp1  time.Time      `json:"col_timestamp_1,omitempty" `   // col_timestamp_1 timestamp NOT NULL  DEFAULT 'current_timestamp()'  ""
-	ColTimestamp2  dml.NullTime   `json:"col_timestamp_2,omitempty" `   // col_timestamp_2 timestamp NULL    ""
-	ColTinyint1    int64          `json:"col_tinyint_1,omitempty" `     // col_tinyint_1 tinyint(1) NOT NULL  DEFAULT '0'  ""
-	ColVarchar1    string         `json:"col_varchar_1,omitempty" `     // col_varchar_1 varchar(1) NOT NULL  DEFAULT ''0''  ""
-	ColVarchar100  dml.NullString `json:"col_varchar_100,omitempty" `   // col_varchar_100 varchar(100) NULL    ""
-	ColVarchar16   string         `json:"col_varchar_16,omitempty" `    // col_varchar_16 varchar(16) NOT NULL  DEFAULT ''de_DE''  ""
-	ColChar1       dml.NullString `json:"col_char_1,omitempty" `        // col_char_1 char(21) NULL    ""
-	ColChar2       string         `json:"col_char_2,omitempty" `        // col_char_2 char(17) NOT NULL  DEFAULT ''xchar''  ""
+	ID             int64        `json:"id,omitempty" `                // id int(11) NOT NULL PRI  auto_increment ""
+	ColBigint1     null.Int64   `json:"col_bigint_1,omitempty" `      // col_bigint_1 bigint(20) NULL    ""
+	ColBigint2     int64        `json:"col_bigint_2,omitempty" `      // col_bigint_2 bigint(20) NOT NULL  DEFAULT '0'  ""
+	ColBigint3     null.Int64   `json:"col_bigint_3,omitempty" `      // col_bigint_3 bigint(20) unsigned NULL    ""
+	ColBigint4     uint64       `json:"col_bigint_4,omitempty" `      // col_bigint_4 bigint(20) unsigned NOT NULL  DEFAULT '0'  ""
+	ColBlob        null.String  `json:"col_blob,omitempty" `          // col_blob blob NULL    ""
+	ColDate1       null.Time    `json:"col_date_1,omitempty" `        // col_date_1 date NULL    ""
+	ColDate2       time.Time    `json:"col_date_2,omitempty" `        // col_date_2 date NOT NULL  DEFAULT ''0000-00-00''  ""
+	ColDatetime1   null.Time    `json:"col_datetime_1,omitempty" `    // col_datetime_1 datetime NULL    ""
+	ColDatetime2   time.Time    `json:"col_datetime_2,omitempty" `    // col_datetime_2 datetime NOT NULL  DEFAULT ''0000-00-00 00:00:00''  ""
+	ColDecimal100  null.Decimal `json:"col_decimal_10_0,omitempty" `  // col_decimal_10_0 decimal(10,0) unsigned NULL    ""
+	ColDecimal124  null.Decimal `json:"col_decimal_12_4,omitempty" `  // col_decimal_12_4 decimal(12,4) NULL    ""
+	Price124a      null.Decimal `json:"price_12_4a,omitempty" `       // price_12_4a decimal(12,4) NULL    ""
+	Price124b      null.Decimal `json:"price_12_4b,omitempty" `       // price_12_4b decimal(12,4) NOT NULL  DEFAULT '0.0000'  ""
+	ColDecimal123  null.Decimal `json:"col_decimal_12_3,omitempty" `  // col_decimal_12_3 decimal(12,3) NOT NULL  DEFAULT '0.000'  ""
+	ColDecimal206  null.Decimal `json:"col_decimal_20_6,omitempty" `  // col_decimal_20_6 decimal(20,6) NOT NULL  DEFAULT '0.000000'  ""
+	ColDecimal2412 null.Decimal `json:"col_decimal_24_12,omitempty" ` // col_decimal_24_12 decimal(24,12) NOT NULL  DEFAULT '0.000000000000'  ""
+	ColFloat       float64      `json:"col_float,omitempty" `         // col_float float NOT NULL  DEFAULT '1'  ""
+	ColInt1        null.Int64   `json:"col_int_1,omitempty" `         // col_int_1 int(10) NULL    ""
+	ColInt2        int64        `json:"col_int_2,omitempty" `         // col_int_2 int(10) NOT NULL  DEFAULT '0'  ""
+	ColInt3        null.Int64   `json:"col_int_3,omitempty" `         // col_int_3 int(10) unsigned NULL    ""
+	ColInt4        uint64       `json:"col_int_4,omitempty" `         // col_int_4 int(10) unsigned NOT NULL  DEFAULT '0'  ""
+	ColLongtext1   null.String  `json:"col_longtext_1,omitempty" `    // col_longtext_1 longtext NULL    ""
+	ColLongtext2   string       `json:"col_longtext_2,omitempty" `    // col_longtext_2 longtext NOT NULL  DEFAULT ''''  ""
+	ColMediumblob  null.String  `json:"col_mediumblob,omitempty" `    // col_mediumblob mediumblob NULL    ""
+	ColMediumtext1 null.String  `json:"col_mediumtext_1,omitempty" `  // col_mediumtext_1 mediumtext NULL    ""
+	ColMediumtext2 string       `json:"col_mediumtext_2,omitempty" `  // col_mediumtext_2 mediumtext NOT NULL  DEFAULT ''''  ""
+	ColSmallint1   null.Int64   `json:"col_smallint_1,omitempty" `    // col_smallint_1 smallint(5) NULL    ""
+	ColSmallint2   int64        `json:"col_smallint_2,omitempty" `    // col_smallint_2 smallint(5) NOT NULL  DEFAULT '0'  ""
+	ColSmallint3   null.Int64   `json:"col_smallint_3,omitempty" `    // col_smallint_3 smallint(5) unsigned NULL    ""
+	ColSmallint4   uint64       `json:"col_smallint_4,omitempty" `    // col_smallint_4 smallint(5) unsigned NOT NULL  DEFAULT '0'  ""
+	HasSmallint5   bool         `json:"has_smallint_5,omitempty" `    // has_smallint_5 smallint(5) unsigned NOT NULL  DEFAULT '0'  ""
+	IsSmallint5    null.Bool    `json:"is_smallint_5,omitempty" `     // is_smallint_5 smallint(5) NULL    ""
+	ColText        null.String  `json:"col_text,omitempty" `          // col_text text NULL    ""
+	ColTimestamp1  time.Time    `json:"col_timestamp_1,omitempty" `   // col_timestamp_1 timestamp NOT NULL  DEFAULT 'current_timestamp()'  ""
+	ColTimestamp2  null.Time    `json:"col_timestamp_2,omitempty" `   // col_timestamp_2 timestamp NULL    ""
+	ColTinyint1    int64        `json:"col_tinyint_1,omitempty" `     // col_tinyint_1 tinyint(1) NOT NULL  DEFAULT '0'  ""
+	ColVarchar1    string       `json:"col_varchar_1,omitempty" `     // col_varchar_1 varchar(1) NOT NULL  DEFAULT ''0''  ""
+	ColVarchar100  null.String  `json:"col_varchar_100,omitempty" `   // col_varchar_100 varchar(100) NULL    ""
+	ColVarchar16   string       `json:"col_varchar_16,omitempty" `    // col_varchar_16 varchar(16) NOT NULL  DEFAULT ''de_DE''  ""
+	ColChar1       null.String  `json:"col_char_1,omitempty" `        // col_char_1 char(21) NULL    ""
+	ColChar2       string       `json:"col_char_2,omitempty" `        // col_char_2 char(17) NOT NULL  DEFAULT ''xchar''  ""
 }
 
 // NewDmlgenTypes creates a new pointer with pre-initialized fields. Auto
@@ -785,6 +994,7 @@ type sliceDmlgenTypes []*DmlgenTypes
 // DmlgenTypesCollection represents a collection type for DB table dmlgen_types
 // Not thread safe. Auto generated.
 // Just another comment.
+//
 //easyjson:json
 type DmlgenTypesCollection struct {
 	// Data contains a slice of []*DmlgenTypes
@@ -800,29 +1010,35 @@ func MakeDmlgenTypesCollection() DmlgenTypesCollection {
 	}
 }
 
-func (cc DmlgenTypesCollection) scanColumns(cm *dml.ColumnMap, e *DmlgenTypes, idx uint64) error {
-	if err := cc.BeforeMapColumns(idx, e); err != nil {
-		return errors.WithStack(err)
+func (cc *DmlgenTypesCollection) scanColumns(cm *dml.ColumnMap, e *DmlgenTypes, idx uint64) error {
+	if cc.BeforeMapColumns != nil {
+		if err := cc.BeforeMapColumns(idx, e); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	if err := e.MapColumns(cm); err != nil {
 		return errors.WithStack(err)
 	}
-	if err := cc.AfterMapColumns(idx, e); err != nil {
-		return errors.WithStack(err)
+	if cc.AfterMapColumns != nil {
+		if err := cc.AfterMapColumns(idx, e); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	return nil
 }
 
 // MapColumns implements dml.ColumnMapper interface. Auto generated.
-func (cc DmlgenTypesCollection) MapColumns(cm *dml.ColumnMap) error {
+func (cc *DmlgenTypesCollection) MapColumns(cm *dml.ColumnMap) error {
 	switch m := cm.Mode(); m {
-	case dml.ColumnMapEntityReadAll, dml.ColumnMapEntityReadSet:
+	case dml.ColumnMapEntityReadAll, dml.ColumnMapEntityReadSet, dml.ColumnMapLastInsertID:
 		for i, e := range cc.Data {
 			if err := cc.scanColumns(cm, e, uint64(i)); err != nil {
 				return errors.WithStack(err)
 			}
 		}
 	case dml.ColumnMapScan:
+		// case for scanning when loading certain rows, hence we write data from
+		// the DB into the struct in each for-loop.
 		if cm.Count == 0 {
 			cc.Data = cc.Data[:0]
 		}
@@ -835,19 +1051,19 @@ func (cc DmlgenTypesCollection) MapColumns(cm *dml.ColumnMap) error {
 		for cm.Next() {
 			switch c := cm.Column(); c {
 			case "id":
-				cm.Args = cm.Args.Int64s(cc.IDs()...)
+				cm.Int64s(cc.IDs()...)
 			case "col_blob":
-				cm.Args = cm.Args.Strings(cc.ColBlobs()...)
+				cm.Strings(cc.ColBlobs()...)
 			case "col_date_2":
-				cm.Args = cm.Args.Times(cc.ColDate2s()...)
+				cm.Times(cc.ColDate2s()...)
 			case "col_int_1":
-				cm.Args = cm.Args.Int64s(cc.ColInt1s()...)
+				cm.Int64s(cc.ColInt1s()...)
 			case "col_int_2":
-				cm.Args = cm.Args.Int64s(cc.ColInt2s()...)
+				cm.Int64s(cc.ColInt2s()...)
 			case "col_longtext_2":
-				cm.Args = cm.Args.Strings(cc.ColLongtext2s()...)
+				cm.Strings(cc.ColLongtext2s()...)
 			case "has_smallint_5":
-				cm.Args = cm.Args.Bools(cc.HasSmallint5s()...)
+				cm.Bools(cc.HasSmallint5s()...)
 			default:
 				return errors.NotFound.Newf("[testdata] DmlgenTypesCollection Column %q not found", c)
 			}
@@ -881,9 +1097,10 @@ func (cc DmlgenTypesCollection) ColBlobs(ret ...string) []string {
 
 	dupCheck := make(map[string]struct{}, len(cc.Data))
 	for _, e := range cc.Data {
-		if _, ok := dupCheck[e.ColBlob.String]; !ok {
-			ret = append(ret, e.ColBlob.String)
-			dupCheck[e.ColBlob.String] = struct{}{}
+		v := e.ColBlob.String
+		if _, ok := dupCheck[v]; !ok {
+			ret = append(ret, v)
+			dupCheck[v] = struct{}{}
 		}
 	}
 	return ret
@@ -900,9 +1117,10 @@ func (cc DmlgenTypesCollection) ColDate2s(ret ...time.Time) []time.Time {
 
 	dupCheck := make(map[time.Time]struct{}, len(cc.Data))
 	for _, e := range cc.Data {
-		if _, ok := dupCheck[e.ColDate2]; !ok {
-			ret = append(ret, e.ColDate2)
-			dupCheck[e.ColDate2] = struct{}{}
+		v := e.ColDate2
+		if _, ok := dupCheck[v]; !ok {
+			ret = append(ret, v)
+			dupCheck[v] = struct{}{}
 		}
 	}
 	return ret
@@ -919,9 +1137,10 @@ func (cc DmlgenTypesCollection) ColInt1s(ret ...int64) []int64 {
 
 	dupCheck := make(map[int64]struct{}, len(cc.Data))
 	for _, e := range cc.Data {
-		if _, ok := dupCheck[e.ColInt1.Int64]; !ok {
-			ret = append(ret, e.ColInt1.Int64)
-			dupCheck[e.ColInt1.Int64] = struct{}{}
+		v := e.ColInt1.Int64
+		if _, ok := dupCheck[v]; !ok {
+			ret = append(ret, v)
+			dupCheck[v] = struct{}{}
 		}
 	}
 	return ret
@@ -938,9 +1157,10 @@ func (cc DmlgenTypesCollection) ColInt2s(ret ...int64) []int64 {
 
 	dupCheck := make(map[int64]struct{}, len(cc.Data))
 	for _, e := range cc.Data {
-		if _, ok := dupCheck[e.ColInt2]; !ok {
-			ret = append(ret, e.ColInt2)
-			dupCheck[e.ColInt2] = struct{}{}
+		v := e.ColInt2
+		if _, ok := dupCheck[v]; !ok {
+			ret = append(ret, v)
+			dupCheck[v] = struct{}{}
 		}
 	}
 	return ret
@@ -957,9 +1177,10 @@ func (cc DmlgenTypesCollection) ColLongtext2s(ret ...string) []string {
 
 	dupCheck := make(map[string]struct{}, len(cc.Data))
 	for _, e := range cc.Data {
-		if _, ok := dupCheck[e.ColLongtext2]; !ok {
-			ret = append(ret, e.ColLongtext2)
-			dupCheck[e.ColLongtext2] = struct{}{}
+		v := e.ColLongtext2
+		if _, ok := dupCheck[v]; !ok {
+			ret = append(ret, v)
+			dupCheck[v] = struct{}{}
 		}
 	}
 	return ret
@@ -976,9 +1197,10 @@ func (cc DmlgenTypesCollection) HasSmallint5s(ret ...bool) []bool {
 
 	dupCheck := make(map[bool]struct{}, len(cc.Data))
 	for _, e := range cc.Data {
-		if _, ok := dupCheck[e.HasSmallint5]; !ok {
-			ret = append(ret, e.HasSmallint5)
-			dupCheck[e.HasSmallint5] = struct{}{}
+		v := e.HasSmallint5
+		if _, ok := dupCheck[v]; !ok {
+			ret = append(ret, v)
+			dupCheck[v] = struct{}{}
 		}
 	}
 	return ret
@@ -1067,6 +1289,53 @@ func (s *sliceDmlgenTypes) Prepend(n *DmlgenTypes) {
 	s.Insert(n, 0)
 }
 
+// Map will run function f on all items in []*DmlgenTypes and returns a new
+// slice with the returned items.
+// Auto generated via dmlgen.
+func (s sliceDmlgenTypes) Map(f func(*DmlgenTypes) *DmlgenTypes) sliceDmlgenTypes {
+	sl := make(sliceDmlgenTypes, len(s))
+	for i, e := range s {
+		sl[i] = f(e)
+	}
+	return sl
+}
+
+// Each will run function f on all items in the collection.
+// Auto generated via dmlgen.
+func (cc *DmlgenTypesCollection) Each(f func(*DmlgenTypes)) *DmlgenTypesCollection {
+	cc.Data.Each(f)
+	return cc
+}
+
+// Filter returns a new collection filtered by predicate f. The hooks
+// BeforeMapColumns and AfterMapColumns are getting copied.
+// Auto generated via dmlgen.
+func (cc *DmlgenTypesCollection) Filter(f func(*DmlgenTypes) bool) *DmlgenTypesCollection {
+	return &DmlgenTypesCollection{
+		Data:             cc.Data.Filter(f),
+		BeforeMapColumns: cc.BeforeMapColumns,
+		AfterMapColumns:  cc.AfterMapColumns,
+	}
+}
+
+// Map returns a new collection with the items returned by function f. The
+// hooks BeforeMapColumns and AfterMapColumns are getting copied.
+// Auto generated via dmlgen.
+func (cc *DmlgenTypesCollection) Map(f func(*DmlgenTypes) *DmlgenTypes) *DmlgenTypesCollection {
+	return &DmlgenTypesCollection{
+		Data:             cc.Data.Map(f),
+		BeforeMapColumns: cc.BeforeMapColumns,
+		AfterMapColumns:  cc.AfterMapColumns,
+	}
+}
+
+// Cut will remove items i through j-1 from the collection.
+// Auto generated via dmlgen.
+func (cc *DmlgenTypesCollection) Cut(i, j int) *DmlgenTypesCollection {
+	cc.Data.Cut(i, j)
+	return cc
+}
+
 // UnmarshalJSON implements interface json.Unmarshaler.
 func (cc *DmlgenTypesCollection) UnmarshalJSON(b []byte) (err error) {
 	return json.Unmarshal(b, cc.Data)
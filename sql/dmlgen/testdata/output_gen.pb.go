@@ -1,32 +1,19 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
 // source: testdata/output_gen.proto
 
-/*
-	Package testdata is a generated protocol buffer package.
-
-	It is generated from these files:
-		testdata/output_gen.proto
-
-	It has these top-level messages:
-		CoreConfigData
-		CoreConfigDataCollection
-		CustomerEntity
-		CustomerEntityCollection
-		DmlgenTypes
-		DmlgenTypesCollection
-*/
 package testdata
 
 import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import null "github.com/corestoreio/pkg/storage/null"
 import _ "github.com/gogo/protobuf/gogoproto"
 import _ "github.com/gogo/protobuf/types"
 
 import time "time"
 
-import binary "encoding/binary"
-import types "github.com/gogo/protobuf/types"
+import encoding_binary "encoding/binary"
+import github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 
 import io "io"
 
@@ -42,126 +29,144 @@ var _ = time.Kitchen
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
-func (m *CoreConfigData) Reset()                    { *m = CoreConfigData{} }
-func (m *CoreConfigData) String() string            { return proto.CompactTextString(m) }
-func (*CoreConfigData) ProtoMessage()               {}
-func (*CoreConfigData) Descriptor() ([]byte, []int) { return fileDescriptorOutputGen, []int{0} }
-
-func (m *CoreConfigDataCollection) Reset()         { *m = CoreConfigDataCollection{} }
-func (m *CoreConfigDataCollection) String() string { return proto.CompactTextString(m) }
-func (*CoreConfigDataCollection) ProtoMessage()    {}
-func (*CoreConfigDataCollection) Descriptor() ([]byte, []int) {
-	return fileDescriptorOutputGen, []int{1}
+func (m *CustomerEntity) Reset()         { *m = CustomerEntity{} }
+func (m *CustomerEntity) String() string { return proto.CompactTextString(m) }
+func (*CustomerEntity) ProtoMessage()    {}
+func (*CustomerEntity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_output_gen_f6eaac3cc34161cb, []int{0}
+}
+func (m *CustomerEntity) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CustomerEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CustomerEntity.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *CustomerEntity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomerEntity.Merge(dst, src)
+}
+func (m *CustomerEntity) XXX_Size() int {
+	return m.Size()
+}
+func (m *CustomerEntity) XXX_DiscardUnknown() {
+	xxx_messageInfo_CustomerEntity.DiscardUnknown(m)
 }
 
-func (m *CustomerEntity) Reset()                    { *m = CustomerEntity{} }
-func (m *CustomerEntity) String() string            { return proto.CompactTextString(m) }
-func (*CustomerEntity) ProtoMessage()               {}
-func (*CustomerEntity) Descriptor() ([]byte, []int) { return fileDescriptorOutputGen, []int{2} }
+var xxx_messageInfo_CustomerEntity proto.InternalMessageInfo
 
 func (m *CustomerEntityCollection) Reset()         { *m = CustomerEntityCollection{} }
 func (m *CustomerEntityCollection) String() string { return proto.CompactTextString(m) }
 func (*CustomerEntityCollection) ProtoMessage()    {}
 func (*CustomerEntityCollection) Descriptor() ([]byte, []int) {
-	return fileDescriptorOutputGen, []int{3}
+	return fileDescriptor_output_gen_f6eaac3cc34161cb, []int{1}
 }
-
-func (m *DmlgenTypes) Reset()                    { *m = DmlgenTypes{} }
-func (m *DmlgenTypes) String() string            { return proto.CompactTextString(m) }
-func (*DmlgenTypes) ProtoMessage()               {}
-func (*DmlgenTypes) Descriptor() ([]byte, []int) { return fileDescriptorOutputGen, []int{4} }
-
-func (m *DmlgenTypesCollection) Reset()                    { *m = DmlgenTypesCollection{} }
-func (m *DmlgenTypesCollection) String() string            { return proto.CompactTextString(m) }
-func (*DmlgenTypesCollection) ProtoMessage()               {}
-func (*DmlgenTypesCollection) Descriptor() ([]byte, []int) { return fileDescriptorOutputGen, []int{5} }
-
-func init() {
-	proto.RegisterType((*CoreConfigData)(nil), "testdata.CoreConfigData")
-	proto.RegisterType((*CoreConfigDataCollection)(nil), "testdata.CoreConfigDataCollection")
-	proto.RegisterType((*CustomerEntity)(nil), "testdata.CustomerEntity")
-	proto.RegisterType((*CustomerEntityCollection)(nil), "testdata.CustomerEntityCollection")
-	proto.RegisterType((*DmlgenTypes)(nil), "testdata.DmlgenTypes")
-	proto.RegisterType((*DmlgenTypesCollection)(nil), "testdata.DmlgenTypesCollection")
+func (m *CustomerEntityCollection) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (m *CoreConfigData) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *CustomerEntityCollection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CustomerEntityCollection.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *CoreConfigData) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ConfigID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.ConfigID))
-	}
-	if len(m.Scope) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Scope)))
-		i += copy(dAtA[i:], m.Scope)
-	}
-	if m.ScopeID != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.ScopeID))
-	}
-	if len(m.Path) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Path)))
-		i += copy(dAtA[i:], m.Path)
-	}
-	dAtA[i] = 0x2a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Value.Size()))
-	n1, err := m.Value.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n1
-	return i, nil
+func (dst *CustomerEntityCollection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomerEntityCollection.Merge(dst, src)
+}
+func (m *CustomerEntityCollection) XXX_Size() int {
+	return m.Size()
+}
+func (m *CustomerEntityCollection) XXX_DiscardUnknown() {
+	xxx_messageInfo_CustomerEntityCollection.DiscardUnknown(m)
 }
 
-func (m *CoreConfigDataCollection) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_CustomerEntityCollection proto.InternalMessageInfo
+
+func (m *DmlgenTypes) Reset()         { *m = DmlgenTypes{} }
+func (m *DmlgenTypes) String() string { return proto.CompactTextString(m) }
+func (*DmlgenTypes) ProtoMessage()    {}
+func (*DmlgenTypes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_output_gen_f6eaac3cc34161cb, []int{2}
+}
+func (m *DmlgenTypes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DmlgenTypes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DmlgenTypes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (dst *DmlgenTypes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DmlgenTypes.Merge(dst, src)
+}
+func (m *DmlgenTypes) XXX_Size() int {
+	return m.Size()
+}
+func (m *DmlgenTypes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DmlgenTypes.DiscardUnknown(m)
 }
 
-func (m *CoreConfigDataCollection) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Data) > 0 {
-		for _, msg := range m.Data {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintOutputGen(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+var xxx_messageInfo_DmlgenTypes proto.InternalMessageInfo
+
+func (m *DmlgenTypesCollection) Reset()         { *m = DmlgenTypesCollection{} }
+func (m *DmlgenTypesCollection) String() string { return proto.CompactTextString(m) }
+func (*DmlgenTypesCollection) ProtoMessage()    {}
+func (*DmlgenTypesCollection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_output_gen_f6eaac3cc34161cb, []int{3}
+}
+func (m *DmlgenTypesCollection) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DmlgenTypesCollection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DmlgenTypesCollection.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (dst *DmlgenTypesCollection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DmlgenTypesCollection.Merge(dst, src)
+}
+func (m *DmlgenTypesCollection) XXX_Size() int {
+	return m.Size()
+}
+func (m *DmlgenTypesCollection) XXX_DiscardUnknown() {
+	xxx_messageInfo_DmlgenTypesCollection.DiscardUnknown(m)
 }
 
+var xxx_messageInfo_DmlgenTypesCollection proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*CustomerEntity)(nil), "testdata.CustomerEntity")
+	proto.RegisterType((*CustomerEntityCollection)(nil), "testdata.CustomerEntityCollection")
+	proto.RegisterType((*DmlgenTypes)(nil), "testdata.DmlgenTypes")
+	proto.RegisterType((*DmlgenTypesCollection)(nil), "testdata.DmlgenTypesCollection")
+}
 func (m *CustomerEntity) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -185,19 +190,19 @@ func (m *CustomerEntity) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.WebsiteID.Size()))
-	n2, err := m.WebsiteID.MarshalTo(dAtA[i:])
+	n1, err := m.WebsiteID.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n2
+	i += n1
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.Email.Size()))
-	n3, err := m.Email.MarshalTo(dAtA[i:])
+	n2, err := m.Email.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n3
+	i += n2
 	if m.GroupID != 0 {
 		dAtA[i] = 0x20
 		i++
@@ -206,35 +211,35 @@ func (m *CustomerEntity) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x2a
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.IncrementID.Size()))
-	n4, err := m.IncrementID.MarshalTo(dAtA[i:])
+	n3, err := m.IncrementID.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n4
+	i += n3
 	dAtA[i] = 0x32
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.StoreID.Size()))
-	n5, err := m.StoreID.MarshalTo(dAtA[i:])
+	n4, err := m.StoreID.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n5
+	i += n4
 	dAtA[i] = 0x3a
 	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(types.SizeOfStdTime(m.CreatedAt)))
-	n6, err := types.StdTimeMarshalTo(m.CreatedAt, dAtA[i:])
+	i = encodeVarintOutputGen(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.CreatedAt)))
+	n5, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.CreatedAt, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n6
+	i += n5
 	dAtA[i] = 0x42
 	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(types.SizeOfStdTime(m.UpdatedAt)))
-	n7, err := types.StdTimeMarshalTo(m.UpdatedAt, dAtA[i:])
+	i = encodeVarintOutputGen(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.UpdatedAt)))
+	n6, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.UpdatedAt, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n7
+	i += n6
 	if m.IsActive {
 		dAtA[i] = 0x48
 		i++
@@ -253,173 +258,173 @@ func (m *CustomerEntity) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x5a
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.CreatedIn.Size()))
-	n8, err := m.CreatedIn.MarshalTo(dAtA[i:])
+	n7, err := m.CreatedIn.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n8
+	i += n7
 	dAtA[i] = 0x62
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.Prefix.Size()))
-	n9, err := m.Prefix.MarshalTo(dAtA[i:])
+	n8, err := m.Prefix.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n9
+	i += n8
 	dAtA[i] = 0x6a
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.Firstname.Size()))
-	n10, err := m.Firstname.MarshalTo(dAtA[i:])
+	n9, err := m.Firstname.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n10
+	i += n9
 	dAtA[i] = 0x72
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.Middlename.Size()))
-	n11, err := m.Middlename.MarshalTo(dAtA[i:])
+	n10, err := m.Middlename.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n11
+	i += n10
 	dAtA[i] = 0x7a
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.Lastname.Size()))
-	n12, err := m.Lastname.MarshalTo(dAtA[i:])
+	n11, err := m.Lastname.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n12
+	i += n11
 	dAtA[i] = 0x82
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.Suffix.Size()))
-	n13, err := m.Suffix.MarshalTo(dAtA[i:])
+	n12, err := m.Suffix.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n13
+	i += n12
 	dAtA[i] = 0x8a
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.Dob.Size()))
-	n14, err := m.Dob.MarshalTo(dAtA[i:])
+	n13, err := m.Dob.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n14
+	i += n13
 	dAtA[i] = 0x92
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.PasswordHash.Size()))
-	n15, err := m.PasswordHash.MarshalTo(dAtA[i:])
+	n14, err := m.PasswordHash.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n15
+	i += n14
 	dAtA[i] = 0x9a
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.RpToken.Size()))
-	n16, err := m.RpToken.MarshalTo(dAtA[i:])
+	n15, err := m.RpToken.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n16
+	i += n15
 	dAtA[i] = 0xa2
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.RpTokenCreatedAt.Size()))
-	n17, err := m.RpTokenCreatedAt.MarshalTo(dAtA[i:])
+	n16, err := m.RpTokenCreatedAt.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n17
+	i += n16
 	dAtA[i] = 0xaa
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.DefaultBilling.Size()))
-	n18, err := m.DefaultBilling.MarshalTo(dAtA[i:])
+	n17, err := m.DefaultBilling.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n18
+	i += n17
 	dAtA[i] = 0xb2
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.DefaultShipping.Size()))
-	n19, err := m.DefaultShipping.MarshalTo(dAtA[i:])
+	n18, err := m.DefaultShipping.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n19
+	i += n18
 	dAtA[i] = 0xba
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.Taxvat.Size()))
-	n20, err := m.Taxvat.MarshalTo(dAtA[i:])
+	n19, err := m.Taxvat.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n20
+	i += n19
 	dAtA[i] = 0xc2
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.Confirmation.Size()))
-	n21, err := m.Confirmation.MarshalTo(dAtA[i:])
+	n20, err := m.Confirmation.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n21
+	i += n20
 	dAtA[i] = 0xca
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.Gender.Size()))
-	n22, err := m.Gender.MarshalTo(dAtA[i:])
+	n21, err := m.Gender.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n22
+	i += n21
 	dAtA[i] = 0xd2
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.FailuresNum.Size()))
-	n23, err := m.FailuresNum.MarshalTo(dAtA[i:])
+	n22, err := m.FailuresNum.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n23
+	i += n22
 	dAtA[i] = 0xda
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.FirstFailure.Size()))
-	n24, err := m.FirstFailure.MarshalTo(dAtA[i:])
+	n23, err := m.FirstFailure.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n24
+	i += n23
 	dAtA[i] = 0xe2
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.LockExpires.Size()))
-	n25, err := m.LockExpires.MarshalTo(dAtA[i:])
+	n24, err := m.LockExpires.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n25
+	i += n24
 	return i, nil
 }
 
@@ -476,11 +481,11 @@ func (m *DmlgenTypes) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColBigint1.Size()))
-	n26, err := m.ColBigint1.MarshalTo(dAtA[i:])
+	n25, err := m.ColBigint1.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n26
+	i += n25
 	if m.ColBigint2 != 0 {
 		dAtA[i] = 0x18
 		i++
@@ -489,11 +494,11 @@ func (m *DmlgenTypes) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x22
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColBigint3.Size()))
-	n27, err := m.ColBigint3.MarshalTo(dAtA[i:])
+	n26, err := m.ColBigint3.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n27
+	i += n26
 	if m.ColBigint4 != 0 {
 		dAtA[i] = 0x28
 		i++
@@ -502,109 +507,109 @@ func (m *DmlgenTypes) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x32
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColBlob.Size()))
-	n28, err := m.ColBlob.MarshalTo(dAtA[i:])
+	n27, err := m.ColBlob.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n28
+	i += n27
 	dAtA[i] = 0x3a
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColDate1.Size()))
-	n29, err := m.ColDate1.MarshalTo(dAtA[i:])
+	n28, err := m.ColDate1.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n29
+	i += n28
 	dAtA[i] = 0x42
 	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(types.SizeOfStdTime(m.ColDate2)))
-	n30, err := types.StdTimeMarshalTo(m.ColDate2, dAtA[i:])
+	i = encodeVarintOutputGen(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.ColDate2)))
+	n29, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ColDate2, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n30
+	i += n29
 	dAtA[i] = 0x4a
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColDatetime1.Size()))
-	n31, err := m.ColDatetime1.MarshalTo(dAtA[i:])
+	n30, err := m.ColDatetime1.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n31
+	i += n30
 	dAtA[i] = 0x52
 	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(types.SizeOfStdTime(m.ColDatetime2)))
-	n32, err := types.StdTimeMarshalTo(m.ColDatetime2, dAtA[i:])
+	i = encodeVarintOutputGen(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.ColDatetime2)))
+	n31, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ColDatetime2, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n32
+	i += n31
 	dAtA[i] = 0x5a
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColDecimal100.Size()))
-	n33, err := m.ColDecimal100.MarshalTo(dAtA[i:])
+	n32, err := m.ColDecimal100.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n33
+	i += n32
 	dAtA[i] = 0x62
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColDecimal124.Size()))
-	n34, err := m.ColDecimal124.MarshalTo(dAtA[i:])
+	n33, err := m.ColDecimal124.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n34
+	i += n33
 	dAtA[i] = 0x6a
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.Price124a.Size()))
-	n35, err := m.Price124a.MarshalTo(dAtA[i:])
+	n34, err := m.Price124a.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n35
+	i += n34
 	dAtA[i] = 0x72
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.Price124b.Size()))
-	n36, err := m.Price124b.MarshalTo(dAtA[i:])
+	n35, err := m.Price124b.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n36
+	i += n35
 	dAtA[i] = 0x7a
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColDecimal123.Size()))
-	n37, err := m.ColDecimal123.MarshalTo(dAtA[i:])
+	n36, err := m.ColDecimal123.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n37
+	i += n36
 	dAtA[i] = 0x82
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColDecimal206.Size()))
-	n38, err := m.ColDecimal206.MarshalTo(dAtA[i:])
+	n37, err := m.ColDecimal206.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n38
+	i += n37
 	dAtA[i] = 0x8a
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColDecimal2412.Size()))
-	n39, err := m.ColDecimal2412.MarshalTo(dAtA[i:])
+	n38, err := m.ColDecimal2412.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n39
+	i += n38
 	if m.ColFloat != 0 {
 		dAtA[i] = 0x91
 		i++
 		dAtA[i] = 0x1
 		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ColFloat))))
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ColFloat))))
 		i += 8
 	}
 	dAtA[i] = 0x9a
@@ -612,11 +617,11 @@ func (m *DmlgenTypes) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColInt1.Size()))
-	n40, err := m.ColInt1.MarshalTo(dAtA[i:])
+	n39, err := m.ColInt1.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n40
+	i += n39
 	if m.ColInt2 != 0 {
 		dAtA[i] = 0xa0
 		i++
@@ -629,11 +634,11 @@ func (m *DmlgenTypes) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColInt3.Size()))
-	n41, err := m.ColInt3.MarshalTo(dAtA[i:])
+	n40, err := m.ColInt3.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n41
+	i += n40
 	if m.ColInt4 != 0 {
 		dAtA[i] = 0xb0
 		i++
@@ -646,11 +651,11 @@ func (m *DmlgenTypes) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColLongtext1.Size()))
-	n42, err := m.ColLongtext1.MarshalTo(dAtA[i:])
+	n41, err := m.ColLongtext1.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n42
+	i += n41
 	if len(m.ColLongtext2) > 0 {
 		dAtA[i] = 0xc2
 		i++
@@ -664,21 +669,21 @@ func (m *DmlgenTypes) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColMediumblob.Size()))
-	n43, err := m.ColMediumblob.MarshalTo(dAtA[i:])
+	n42, err := m.ColMediumblob.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n43
+	i += n42
 	dAtA[i] = 0xd2
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColMediumtext1.Size()))
-	n44, err := m.ColMediumtext1.MarshalTo(dAtA[i:])
+	n43, err := m.ColMediumtext1.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n44
+	i += n43
 	if len(m.ColMediumtext2) > 0 {
 		dAtA[i] = 0xda
 		i++
@@ -692,11 +697,11 @@ func (m *DmlgenTypes) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColSmallint1.Size()))
-	n45, err := m.ColSmallint1.MarshalTo(dAtA[i:])
+	n44, err := m.ColSmallint1.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n45
+	i += n44
 	if m.ColSmallint2 != 0 {
 		dAtA[i] = 0xe8
 		i++
@@ -709,11 +714,11 @@ func (m *DmlgenTypes) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColSmallint3.Size()))
-	n46, err := m.ColSmallint3.MarshalTo(dAtA[i:])
+	n45, err := m.ColSmallint3.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n46
+	i += n45
 	if m.ColSmallint4 != 0 {
 		dAtA[i] = 0xf8
 		i++
@@ -738,41 +743,41 @@ func (m *DmlgenTypes) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x2
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.IsSmallint5.Size()))
-	n47, err := m.IsSmallint5.MarshalTo(dAtA[i:])
+	n46, err := m.IsSmallint5.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n47
+	i += n46
 	dAtA[i] = 0x92
 	i++
 	dAtA[i] = 0x2
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColText.Size()))
-	n48, err := m.ColText.MarshalTo(dAtA[i:])
+	n47, err := m.ColText.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n48
+	i += n47
 	dAtA[i] = 0x9a
 	i++
 	dAtA[i] = 0x2
 	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(types.SizeOfStdTime(m.ColTimestamp1)))
-	n49, err := types.StdTimeMarshalTo(m.ColTimestamp1, dAtA[i:])
+	i = encodeVarintOutputGen(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.ColTimestamp1)))
+	n48, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ColTimestamp1, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n49
+	i += n48
 	dAtA[i] = 0xa2
 	i++
 	dAtA[i] = 0x2
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColTimestamp2.Size()))
-	n50, err := m.ColTimestamp2.MarshalTo(dAtA[i:])
+	n49, err := m.ColTimestamp2.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n50
+	i += n49
 	if m.ColTinyint1 != 0 {
 		dAtA[i] = 0xa8
 		i++
@@ -793,11 +798,11 @@ func (m *DmlgenTypes) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x2
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColVarchar100.Size()))
-	n51, err := m.ColVarchar100.MarshalTo(dAtA[i:])
+	n50, err := m.ColVarchar100.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n51
+	i += n50
 	if len(m.ColVarchar16) > 0 {
 		dAtA[i] = 0xc2
 		i++
@@ -811,11 +816,11 @@ func (m *DmlgenTypes) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x2
 	i++
 	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColChar1.Size()))
-	n52, err := m.ColChar1.MarshalTo(dAtA[i:])
+	n51, err := m.ColChar1.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n52
+	i += n51
 	if len(m.ColChar2) > 0 {
 		dAtA[i] = 0xd2
 		i++
@@ -866,40 +871,6 @@ func encodeVarintOutputGen(dAtA []byte, offset int, v uint64) int {
 	dAtA[offset] = uint8(v)
 	return offset + 1
 }
-func (m *CoreConfigData) Size() (n int) {
-	var l int
-	_ = l
-	if m.ConfigID != 0 {
-		n += 1 + sovOutputGen(uint64(m.ConfigID))
-	}
-	l = len(m.Scope)
-	if l > 0 {
-		n += 1 + l + sovOutputGen(uint64(l))
-	}
-	if m.ScopeID != 0 {
-		n += 1 + sovOutputGen(uint64(m.ScopeID))
-	}
-	l = len(m.Path)
-	if l > 0 {
-		n += 1 + l + sovOutputGen(uint64(l))
-	}
-	l = m.Value.Size()
-	n += 1 + l + sovOutputGen(uint64(l))
-	return n
-}
-
-func (m *CoreConfigDataCollection) Size() (n int) {
-	var l int
-	_ = l
-	if len(m.Data) > 0 {
-		for _, e := range m.Data {
-			l = e.Size()
-			n += 1 + l + sovOutputGen(uint64(l))
-		}
-	}
-	return n
-}
-
 func (m *CustomerEntity) Size() (n int) {
 	var l int
 	_ = l
@@ -917,9 +888,9 @@ func (m *CustomerEntity) Size() (n int) {
 	n += 1 + l + sovOutputGen(uint64(l))
 	l = m.StoreID.Size()
 	n += 1 + l + sovOutputGen(uint64(l))
-	l = types.SizeOfStdTime(m.CreatedAt)
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.CreatedAt)
 	n += 1 + l + sovOutputGen(uint64(l))
-	l = types.SizeOfStdTime(m.UpdatedAt)
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.UpdatedAt)
 	n += 1 + l + sovOutputGen(uint64(l))
 	if m.IsActive {
 		n += 2
@@ -998,11 +969,11 @@ func (m *DmlgenTypes) Size() (n int) {
 	n += 1 + l + sovOutputGen(uint64(l))
 	l = m.ColDate1.Size()
 	n += 1 + l + sovOutputGen(uint64(l))
-	l = types.SizeOfStdTime(m.ColDate2)
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.ColDate2)
 	n += 1 + l + sovOutputGen(uint64(l))
 	l = m.ColDatetime1.Size()
 	n += 1 + l + sovOutputGen(uint64(l))
-	l = types.SizeOfStdTime(m.ColDatetime2)
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.ColDatetime2)
 	n += 1 + l + sovOutputGen(uint64(l))
 	l = m.ColDecimal100.Size()
 	n += 1 + l + sovOutputGen(uint64(l))
@@ -1062,7 +1033,7 @@ func (m *DmlgenTypes) Size() (n int) {
 	n += 2 + l + sovOutputGen(uint64(l))
 	l = m.ColText.Size()
 	n += 2 + l + sovOutputGen(uint64(l))
-	l = types.SizeOfStdTime(m.ColTimestamp1)
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.ColTimestamp1)
 	n += 2 + l + sovOutputGen(uint64(l))
 	l = m.ColTimestamp2.Size()
 	n += 2 + l + sovOutputGen(uint64(l))
@@ -1113,263 +1084,6 @@ func sovOutputGen(x uint64) (n int) {
 func sozOutputGen(x uint64) (n int) {
 	return sovOutputGen(uint64((x << 1) ^ uint64((int64(x) >> 63))))
 }
-func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowOutputGen
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CoreConfigData: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CoreConfigData: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConfigID", wireType)
-			}
-			m.ConfigID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOutputGen
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ConfigID |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Scope", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOutputGen
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthOutputGen
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Scope = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ScopeID", wireType)
-			}
-			m.ScopeID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOutputGen
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ScopeID |= (int64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOutputGen
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthOutputGen
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Path = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOutputGen
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthOutputGen
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Value.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipOutputGen(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthOutputGen
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CoreConfigDataCollection) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowOutputGen
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CoreConfigDataCollection: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CoreConfigDataCollection: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOutputGen
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthOutputGen
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data, &CoreConfigData{})
-			if err := m.Data[len(m.Data)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipOutputGen(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthOutputGen
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
 func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1583,7 +1297,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := types.StdTimeUnmarshal(&m.CreatedAt, dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.CreatedAt, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1613,7 +1327,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := types.StdTimeUnmarshal(&m.UpdatedAt, dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.UpdatedAt, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2530,7 +2244,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := types.StdTimeUnmarshal(&m.ColDate2, dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.ColDate2, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2590,7 +2304,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := types.StdTimeUnmarshal(&m.ColDatetime2, dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.ColDatetime2, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2812,7 +2526,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 			if (iNdEx + 8) > l {
 				return io.ErrUnexpectedEOF
 			}
-			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
 			iNdEx += 8
 			m.ColFloat = float64(math.Float64frombits(v))
 		case 19:
@@ -3265,7 +2979,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := types.StdTimeUnmarshal(&m.ColTimestamp1, dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.ColTimestamp1, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3672,126 +3386,120 @@ var (
 	ErrIntOverflowOutputGen   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("testdata/output_gen.proto", fileDescriptorOutputGen) }
+func init() {
+	proto.RegisterFile("testdata/output_gen.proto", fileDescriptor_output_gen_f6eaac3cc34161cb)
+}
 
-var fileDescriptorOutputGen = []byte{
-	// 1880 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x58, 0xd1, 0x72, 0xdb, 0xb8,
-	0x15, 0x8d, 0x6c, 0xc7, 0x96, 0x20, 0x59, 0x76, 0x90, 0xd8, 0x81, 0xdd, 0xd4, 0x72, 0xdd, 0x76,
-	0xd7, 0xd9, 0xd9, 0xda, 0x12, 0xa5, 0x75, 0x67, 0xba, 0xdd, 0xd9, 0x8d, 0xa4, 0x38, 0xd1, 0xd6,
-	0xc9, 0xba, 0xb0, 0x37, 0x9d, 0xd9, 0x99, 0x0e, 0x87, 0x22, 0x21, 0x89, 0x13, 0x8a, 0x60, 0x49,
-	0x28, 0x71, 0xfe, 0xa2, 0x9f, 0xd2, 0x4f, 0xe8, 0x63, 0xa6, 0x4f, 0x7d, 0xec, 0x93, 0xdb, 0xaa,
-	0x3f, 0xd2, 0xc1, 0x05, 0x48, 0x91, 0x32, 0x1d, 0xf9, 0x0d, 0x17, 0x3c, 0xe7, 0x00, 0x17, 0xb8,
-	0xc0, 0x81, 0x84, 0x76, 0x04, 0x8b, 0x84, 0x63, 0x09, 0xeb, 0x98, 0x4f, 0x44, 0x30, 0x11, 0xe6,
-	0x90, 0xf9, 0x47, 0x41, 0xc8, 0x05, 0xc7, 0xc5, 0xf8, 0xd3, 0xee, 0x6f, 0x86, 0xae, 0x18, 0x4d,
-	0xfa, 0x47, 0x36, 0x1f, 0x1f, 0x0f, 0xf9, 0x90, 0x1f, 0x03, 0xa0, 0x3f, 0x19, 0x40, 0x04, 0x01,
-	0xb4, 0x14, 0x71, 0xb7, 0x36, 0xe4, 0x7c, 0xe8, 0xb1, 0x19, 0x4a, 0xb8, 0x63, 0x16, 0x09, 0x6b,
-	0x1c, 0x68, 0x40, 0x33, 0xa5, 0x67, 0xf3, 0x90, 0x45, 0x82, 0x87, 0xcc, 0xe5, 0xc7, 0xc1, 0xdb,
-	0xe1, 0x71, 0xf4, 0x17, 0xef, 0xd8, 0x19, 0x7b, 0xc7, 0xe2, 0x43, 0xc0, 0x22, 0xd3, 0x9f, 0x78,
-	0x9e, 0x22, 0x1d, 0xfc, 0xab, 0x80, 0xaa, 0x1d, 0x1e, 0xb2, 0x0e, 0xf7, 0x07, 0xee, 0xb0, 0x6b,
-	0x09, 0x0b, 0x3f, 0x45, 0x25, 0x1b, 0x22, 0xd3, 0x75, 0x48, 0x61, 0xbf, 0x70, 0xb8, 0xd2, 0xae,
-	0x4c, 0xaf, 0x6b, 0x45, 0x05, 0xe9, 0x75, 0x69, 0x51, 0x7d, 0xee, 0x39, 0xb8, 0x86, 0xee, 0x47,
-	0x36, 0x0f, 0x18, 0x59, 0xda, 0x2f, 0x1c, 0x96, 0xda, 0xa5, 0xe9, 0x75, 0xed, 0xfe, 0x85, 0xec,
-	0xa0, 0xaa, 0x1f, 0x7f, 0x86, 0x8a, 0xd0, 0x90, 0x52, 0xcb, 0xfb, 0x85, 0xc3, 0xe5, 0x76, 0x79,
-	0x7a, 0x5d, 0x5b, 0x03, 0x4c, 0xaf, 0x4b, 0xd7, 0xe0, 0x63, 0xcf, 0xc1, 0x4f, 0xd0, 0x4a, 0x60,
-	0x89, 0x11, 0x59, 0x01, 0x9d, 0xe2, 0xf4, 0xba, 0xb6, 0x72, 0x6e, 0x89, 0x11, 0x85, 0x5e, 0xdc,
-	0x42, 0xf7, 0xdf, 0x59, 0xde, 0x84, 0x91, 0xfb, 0xfb, 0x85, 0xc3, 0xb2, 0xb1, 0x71, 0xe4, 0x8c,
-	0xbd, 0xa3, 0xd7, 0x13, 0xcf, 0xbb, 0x10, 0xa1, 0xeb, 0x0f, 0xdb, 0xeb, 0x1f, 0xaf, 0x6b, 0xf7,
-	0xe4, 0xd8, 0x6f, 0x24, 0x8a, 0x2a, 0xf0, 0xc1, 0x4b, 0x44, 0xb2, 0x99, 0x75, 0xb8, 0xe7, 0x31,
-	0x5b, 0xb8, 0xdc, 0xc7, 0x5f, 0xa2, 0x15, 0xd9, 0x43, 0x0a, 0xfb, 0xcb, 0x87, 0x65, 0x83, 0x1c,
-	0xc5, 0x9b, 0x72, 0x94, 0x65, 0x50, 0x40, 0x1d, 0xfc, 0xa3, 0x8a, 0xaa, 0x9d, 0x49, 0x24, 0xf8,
-	0x98, 0x85, 0xcf, 0x7d, 0xe1, 0x8a, 0x0f, 0x72, 0x91, 0x18, 0xb4, 0xe6, 0x16, 0x49, 0x7d, 0x96,
-	0x8b, 0xa4, 0x3e, 0xf7, 0x1c, 0xfc, 0x1d, 0x42, 0xef, 0x59, 0x3f, 0x72, 0x05, 0xac, 0xc2, 0x12,
-	0xa4, 0x50, 0x4d, 0x52, 0xe8, 0xf9, 0xe2, 0xa4, 0xd5, 0x7e, 0xa0, 0x33, 0x28, 0xfd, 0x49, 0x21,
-	0x7b, 0x5d, 0x5a, 0xd2, 0xa4, 0x9e, 0x23, 0xf3, 0x67, 0x63, 0xcb, 0xf5, 0x60, 0x09, 0x3f, 0x95,
-	0xff, 0x73, 0x89, 0xa2, 0x0a, 0x2c, 0xd7, 0x7e, 0x18, 0xf2, 0x49, 0x20, 0x47, 0x5d, 0x81, 0x19,
-	0xc2, 0xda, 0xbf, 0x90, 0x7d, 0x72, 0xed, 0xe1, 0x63, 0xcf, 0xc1, 0x2f, 0x50, 0xc5, 0xf5, 0xed,
-	0x90, 0x8d, 0x99, 0x2f, 0x24, 0xf6, 0x96, 0x45, 0x7e, 0xa8, 0x07, 0x29, 0xf7, 0x62, 0x70, 0xaf,
-	0x4b, 0xcb, 0x09, 0xb3, 0xe7, 0xe0, 0xdf, 0xa1, 0x22, 0xd4, 0x9c, 0x14, 0x59, 0xcd, 0x4d, 0x73,
-	0x43, 0x6b, 0xac, 0x5d, 0x48, 0x1c, 0x14, 0x00, 0x34, 0x1c, 0x7c, 0x8e, 0x90, 0x1d, 0x32, 0x4b,
-	0x30, 0xc7, 0xb4, 0x04, 0x59, 0x03, 0xf6, 0xee, 0x91, 0x2a, 0xf9, 0xa3, 0xb8, 0xe4, 0x8f, 0x2e,
-	0xe3, 0x92, 0x6f, 0x6f, 0xc5, 0x0b, 0xd6, 0x51, 0xac, 0x67, 0xe2, 0xaf, 0xff, 0xae, 0x15, 0x68,
-	0xc9, 0x8e, 0x43, 0xa9, 0x38, 0x09, 0x9c, 0x58, 0xb1, 0x78, 0x77, 0xc5, 0x1f, 0x15, 0x2b, 0x56,
-	0x9c, 0xc4, 0xa1, 0xdc, 0x73, 0x37, 0x32, 0x2d, 0x5b, 0xb8, 0xef, 0x18, 0x29, 0xed, 0x17, 0x0e,
-	0x8b, 0x6a, 0xcf, 0x7b, 0xd1, 0x33, 0xe8, 0xa3, 0x45, 0x57, 0xb7, 0xf0, 0x8f, 0x68, 0xc7, 0x71,
-	0x23, 0xab, 0xef, 0x31, 0xd3, 0x9a, 0x08, 0x6e, 0xaa, 0x8d, 0xb0, 0x47, 0x96, 0x3f, 0x64, 0x04,
-	0xc1, 0x66, 0xec, 0x4e, 0xaf, 0x6b, 0xdb, 0x5d, 0x05, 0x7a, 0x36, 0x11, 0x1c, 0xf6, 0xa5, 0x03,
-	0x08, 0xba, 0xed, 0xe4, 0xf6, 0xe3, 0x67, 0xb3, 0x55, 0x72, 0x7d, 0x52, 0xce, 0xdf, 0xa8, 0x07,
-	0x73, 0x4b, 0xd3, 0xf3, 0x93, 0x65, 0xe9, 0xf9, 0xf8, 0xb7, 0x68, 0x35, 0x08, 0xd9, 0xc0, 0xbd,
-	0x22, 0x95, 0x7c, 0x7a, 0x55, 0xd3, 0x57, 0xcf, 0x01, 0x46, 0x35, 0x1c, 0x7f, 0x87, 0x4a, 0x03,
-	0x37, 0x8c, 0x84, 0x6f, 0x8d, 0x19, 0x59, 0x5f, 0x30, 0xf4, 0x69, 0x8c, 0xa4, 0x33, 0x12, 0xee,
-	0x20, 0x34, 0x76, 0x1d, 0xc7, 0x63, 0x20, 0x51, 0xcd, 0x97, 0xc0, 0x5a, 0x02, 0xbd, 0x4a, 0xa0,
-	0x34, 0x45, 0xc3, 0xdf, 0xa0, 0xa2, 0x67, 0xe9, 0x59, 0x6c, 0xe4, 0x4b, 0x6c, 0x6a, 0x89, 0xe2,
-	0x99, 0x06, 0xd2, 0x84, 0x22, 0xd3, 0x8f, 0x26, 0x03, 0x99, 0xfe, 0xe6, 0x82, 0xf4, 0x2f, 0x00,
-	0x46, 0x35, 0x1c, 0x7f, 0x89, 0x96, 0x1d, 0xde, 0x27, 0x0f, 0x80, 0xb5, 0x9e, 0xb0, 0x64, 0xfd,
-	0xb4, 0xcb, 0x9a, 0xb3, 0xdc, 0xe5, 0x7d, 0x2a, 0x61, 0xf8, 0x7b, 0xb4, 0x1e, 0x58, 0x51, 0xf4,
-	0x9e, 0x87, 0x8e, 0x39, 0xb2, 0xa2, 0x11, 0xc1, 0xf9, 0xa3, 0x3d, 0xd2, 0xcc, 0xca, 0xb9, 0x46,
-	0xbf, 0xb4, 0xa2, 0x11, 0xad, 0x04, 0xa9, 0x08, 0x7f, 0x8d, 0x8a, 0x61, 0x60, 0x0a, 0xfe, 0x96,
-	0xf9, 0xe4, 0x61, 0xbe, 0x4c, 0x72, 0xae, 0x68, 0x70, 0x29, 0x71, 0x74, 0x2d, 0x54, 0x0d, 0xfc,
-	0x06, 0x3d, 0x8c, 0xc9, 0x66, 0xea, 0x80, 0x3d, 0xca, 0x4b, 0x83, 0x68, 0x95, 0x4d, 0xad, 0x92,
-	0x1c, 0x2d, 0xba, 0x19, 0xce, 0xf5, 0xe0, 0x1f, 0xd0, 0x86, 0xc3, 0x06, 0xd6, 0xc4, 0x13, 0x66,
-	0xdf, 0xf5, 0x3c, 0xd7, 0x1f, 0x92, 0xad, 0xdc, 0x23, 0xbf, 0xad, 0x45, 0xab, 0x5d, 0x05, 0x6f,
-	0x2b, 0x34, 0xad, 0x3a, 0x99, 0x18, 0x53, 0xb4, 0x19, 0x0b, 0x46, 0x23, 0x37, 0x08, 0xa4, 0xe2,
-	0x76, 0xae, 0xe2, 0x63, 0xad, 0xb8, 0xa1, 0x15, 0x2f, 0x34, 0x9c, 0xc6, 0x33, 0x8a, 0x3b, 0xe4,
-	0x66, 0x0b, 0xeb, 0xea, 0x9d, 0x25, 0xc8, 0xe3, 0x05, 0x9b, 0x7d, 0x09, 0x30, 0xaa, 0xe1, 0xb8,
-	0x87, 0x2a, 0xe0, 0x71, 0xe1, 0xd8, 0x92, 0x76, 0x41, 0xc8, 0x82, 0xdd, 0xeb, 0xa4, 0xc0, 0x34,
-	0x43, 0xc5, 0x27, 0x68, 0x75, 0xc8, 0x7c, 0x87, 0x85, 0x64, 0x27, 0x37, 0x9b, 0x64, 0x0a, 0x2f,
-	0x00, 0x45, 0x35, 0x1a, 0x9f, 0xa2, 0xca, 0xc0, 0x72, 0xbd, 0x49, 0x08, 0x7e, 0x3d, 0x26, 0xbb,
-	0xb9, 0xec, 0xe4, 0x52, 0x3e, 0xd5, 0xd8, 0xd7, 0x93, 0x31, 0x2d, 0x0f, 0x66, 0x01, 0x7e, 0x89,
-	0xd6, 0xe1, 0x04, 0x9a, 0xba, 0x93, 0xfc, 0x2c, 0x6f, 0xeb, 0x93, 0x4c, 0xe0, 0xe0, 0x6a, 0x31,
-	0x5a, 0x19, 0xa4, 0x22, 0xfc, 0x1c, 0x55, 0x3c, 0x6e, 0xbf, 0x35, 0xd9, 0x55, 0xe0, 0x86, 0x2c,
-	0x22, 0x4f, 0xf2, 0x84, 0x92, 0x09, 0x9d, 0x71, 0xfb, 0xed, 0x73, 0x85, 0xa4, 0x65, 0x6f, 0x16,
-	0x80, 0x2d, 0x67, 0xbc, 0xf4, 0x4e, 0xb6, 0x9c, 0x61, 0x68, 0x5b, 0xfe, 0xfb, 0x16, 0x2a, 0x77,
-	0xc7, 0xde, 0x90, 0xf9, 0x97, 0xf2, 0x59, 0x83, 0xb7, 0xd1, 0x92, 0x36, 0xe3, 0xe5, 0xf6, 0xea,
-	0xf4, 0xba, 0xb6, 0xd4, 0xeb, 0xd2, 0x25, 0xd7, 0xc1, 0x5d, 0xb9, 0x9b, 0x9e, 0xd9, 0x77, 0x87,
-	0xae, 0x2f, 0xcc, 0xc6, 0x2d, 0x16, 0x9c, 0x5c, 0x3c, 0x1d, 0xee, 0xb5, 0x01, 0xda, 0xa0, 0xc8,
-	0x4e, 0xda, 0xb8, 0x9e, 0x51, 0x31, 0xf4, 0x73, 0xa6, 0x9a, 0x61, 0x18, 0x29, 0x86, 0x31, 0x37,
-	0x6e, 0x13, 0x4c, 0xf8, 0x2e, 0xe3, 0x36, 0x53, 0x2a, 0xcd, 0xb9, 0x71, 0x5b, 0x60, 0xcf, 0x2b,
-	0x73, 0xe3, 0xb6, 0x52, 0x8c, 0x96, 0xbc, 0x30, 0x80, 0xe1, 0xf1, 0xbe, 0xf6, 0xe1, 0xdb, 0x2f,
-	0x0c, 0x29, 0xe1, 0xf1, 0x3e, 0x5d, 0xb3, 0x55, 0x03, 0x7f, 0x83, 0xa4, 0x94, 0x29, 0x3d, 0xcf,
-	0x6c, 0x68, 0x23, 0x9e, 0xdb, 0xe3, 0xe4, 0x7e, 0xed, 0x70, 0xaf, 0x6b, 0x09, 0xd6, 0x90, 0x2f,
-	0x42, 0xd5, 0xc2, 0xaf, 0x53, 0x74, 0xe3, 0x0e, 0xae, 0xfb, 0x68, 0x4e, 0xcb, 0x00, 0xd3, 0x8d,
-	0xf5, 0x0c, 0xdc, 0x43, 0xd5, 0x58, 0x4f, 0xbe, 0x77, 0xcd, 0x06, 0x18, 0xef, 0xed, 0xf5, 0xab,
-	0x65, 0x24, 0xb6, 0x21, 0x4f, 0xe2, 0x2c, 0xc2, 0x3f, 0xcd, 0x49, 0x19, 0x60, 0xc4, 0x9f, 0x9e,
-	0x1e, 0xc9, 0xd1, 0x55, 0x53, 0x4c, 0x6b, 0x1b, 0xf8, 0x0c, 0x6d, 0x82, 0x36, 0xb3, 0xdd, 0xb1,
-	0xe5, 0x99, 0x8d, 0xba, 0x59, 0xd7, 0xf6, 0x5c, 0x81, 0x89, 0x76, 0xd5, 0x87, 0xe4, 0x91, 0xb1,
-	0x2e, 0xf5, 0x54, 0x5f, 0xa3, 0x5e, 0xa7, 0xeb, 0x76, 0x3a, 0xbc, 0xa1, 0x66, 0x98, 0x2d, 0xed,
-	0xd6, 0x8b, 0xd5, 0x8c, 0x56, 0x46, 0xcd, 0x68, 0xe1, 0x6f, 0x51, 0x39, 0x08, 0x5d, 0x9b, 0x81,
-	0x8e, 0xa5, 0xad, 0x3b, 0x2b, 0x94, 0xf8, 0xf6, 0xb9, 0x04, 0x36, 0x8c, 0x96, 0x45, 0x4b, 0x41,
-	0xdc, 0xcc, 0x0a, 0xf4, 0xb5, 0x71, 0x2f, 0x10, 0xe8, 0xcf, 0x04, 0xfa, 0x39, 0xf9, 0x34, 0xb5,
-	0x77, 0xdf, 0x21, 0x9f, 0x66, 0x36, 0x9f, 0xe6, 0xbc, 0x9a, 0x51, 0x37, 0x4f, 0xb4, 0x99, 0x2f,
-	0x54, 0x33, 0xea, 0x27, 0x69, 0x35, 0xa3, 0x7e, 0x82, 0x7f, 0x40, 0x0f, 0x32, 0x6a, 0x2d, 0xb3,
-	0x61, 0x68, 0x97, 0xcf, 0xca, 0x25, 0x46, 0x96, 0x92, 0x6b, 0x35, 0x0c, 0x5a, 0xb5, 0x33, 0xb1,
-	0xfa, 0xf9, 0xe4, 0x99, 0x03, 0x8f, 0x5b, 0x02, 0x6c, 0xbf, 0x10, 0xff, 0x7c, 0xf2, 0x4e, 0x65,
-	0x1f, 0x14, 0x37, 0xb4, 0xf0, 0xd7, 0x0a, 0xaa, 0x6e, 0xa5, 0x87, 0x9f, 0x7e, 0x31, 0x77, 0xb8,
-	0xec, 0x69, 0xc0, 0x41, 0x95, 0x0d, 0xfc, 0xf9, 0x8c, 0x6c, 0x80, 0x9f, 0xeb, 0xdf, 0x56, 0x0a,
-	0x68, 0xc4, 0x40, 0x23, 0x3d, 0x4a, 0xf3, 0x16, 0x93, 0x9e, 0x1b, 0xa5, 0x19, 0x93, 0x9b, 0xe9,
-	0x51, 0x5a, 0xe0, 0xc7, 0x2b, 0xe9, 0x51, 0x5a, 0x31, 0xb0, 0x85, 0xff, 0xa0, 0x4e, 0x97, 0xc7,
-	0xfd, 0xa1, 0x60, 0x57, 0x32, 0xa1, 0xc7, 0x0b, 0x4d, 0xd3, 0x3b, 0xd3, 0x68, 0x75, 0x54, 0x93,
-	0x08, 0x9f, 0xcc, 0x89, 0x19, 0xe0, 0xc0, 0xa5, 0xf6, 0xe6, 0x1c, 0xcf, 0xc8, 0xf0, 0x0c, 0xfc,
-	0x4a, 0xf1, 0xc6, 0xcc, 0x71, 0x27, 0x63, 0xb8, 0xff, 0x76, 0xf2, 0x27, 0x91, 0xae, 0x8d, 0x57,
-	0x09, 0x1a, 0x6a, 0x63, 0x16, 0xe2, 0x3f, 0xaa, 0x4a, 0x53, 0x72, 0x3a, 0xab, 0xdd, 0x7c, 0xc1,
-	0x74, 0x75, 0xbc, 0x4a, 0xf0, 0x0d, 0xa8, 0x8e, 0x54, 0x8c, 0x7f, 0x7f, 0x43, 0xd2, 0x00, 0x47,
-	0x2e, 0xb5, 0xf1, 0x0d, 0xb6, 0x31, 0xc7, 0x36, 0xf0, 0xf7, 0x2a, 0xbf, 0x68, 0x6c, 0xc9, 0x47,
-	0x93, 0x9c, 0xce, 0x93, 0xdc, 0xfd, 0x4c, 0xaf, 0xf1, 0x85, 0x06, 0xab, 0x35, 0x4e, 0xa2, 0x78,
-	0x8d, 0x13, 0x2d, 0x83, 0xfc, 0x1c, 0x8a, 0x68, 0x73, 0x8e, 0x67, 0x64, 0x78, 0x37, 0xe7, 0xd0,
-	0x24, 0x7b, 0x77, 0x9e, 0x43, 0x33, 0xa3, 0xd5, 0xbc, 0x31, 0x87, 0x16, 0xa9, 0x41, 0x89, 0xcd,
-	0xcf, 0xa1, 0x95, 0xe1, 0xb5, 0x24, 0x6f, 0x64, 0x45, 0x33, 0xde, 0x57, 0x64, 0x1f, 0x7e, 0x8e,
-	0x01, 0xef, 0xa5, 0x15, 0xc5, 0xc8, 0xaf, 0x68, 0x65, 0x94, 0x8a, 0xf0, 0x29, 0x5a, 0x77, 0x33,
-	0xb4, 0x5f, 0xcc, 0x99, 0x49, 0x9b, 0x73, 0x2f, 0xf5, 0x4b, 0x37, 0x25, 0x54, 0x76, 0x53, 0x3a,
-	0xda, 0x61, 0xe5, 0xa6, 0x90, 0x83, 0xc5, 0x0e, 0x7b, 0xc9, 0xae, 0x04, 0x9c, 0x14, 0xd9, 0xc0,
-	0x7f, 0x46, 0x1b, 0x40, 0x8e, 0x4d, 0xc6, 0x6c, 0x90, 0x5f, 0x2e, 0x34, 0xa2, 0x9d, 0x54, 0xc1,
-	0x26, 0xbd, 0x0d, 0x70, 0x22, 0x59, 0xb4, 0xb3, 0x2e, 0x7c, 0x36, 0x2f, 0x6f, 0x90, 0x5f, 0xe5,
-	0x59, 0xe6, 0x56, 0x9e, 0xa2, 0x91, 0x55, 0x33, 0x70, 0x13, 0xad, 0x2b, 0x35, 0xff, 0x83, 0x2a,
-	0xb8, 0x5f, 0x43, 0x91, 0x6c, 0xc8, 0xe5, 0x01, 0x22, 0xf4, 0x37, 0x68, 0xd9, 0x9e, 0x05, 0x31,
-	0xe9, 0x9d, 0x15, 0xda, 0x23, 0x2b, 0x34, 0x1b, 0xe4, 0x33, 0xa8, 0xf0, 0x98, 0xf4, 0x46, 0xf5,
-	0x2b, 0x52, 0x1c, 0xe0, 0xd7, 0x6a, 0xde, 0x09, 0xa9, 0x5e, 0x27, 0x9f, 0x2f, 0x3e, 0xbc, 0x31,
-	0x5d, 0x9b, 0xe8, 0x2c, 0x8c, 0x6b, 0x2b, 0xd1, 0x3b, 0x21, 0x87, 0x99, 0x3b, 0x24, 0x86, 0x9e,
-	0x40, 0x6d, 0x25, 0x11, 0xfe, 0x56, 0xbd, 0x60, 0xf4, 0xcc, 0x9f, 0x2e, 0xf8, 0x89, 0xd9, 0xe1,
-	0x5e, 0x07, 0x72, 0x91, 0x05, 0x01, 0x2d, 0xfc, 0x45, 0x4a, 0xc0, 0x20, 0x5f, 0xc0, 0xa0, 0x95,
-	0x14, 0xd6, 0x48, 0xb0, 0xc6, 0x41, 0x1b, 0x6d, 0xa5, 0x5e, 0xb0, 0xa9, 0x97, 0xf0, 0xd3, 0xcc,
-	0x4b, 0x78, 0x6b, 0xf6, 0x12, 0x4e, 0xc1, 0xd5, 0x33, 0xb8, 0xbd, 0xf7, 0xf1, 0xbf, 0x7b, 0xf7,
-	0x3e, 0x4e, 0xf7, 0x0a, 0xff, 0x9c, 0xee, 0x15, 0xfe, 0x33, 0xdd, 0x2b, 0xfc, 0xed, 0x7f, 0x7b,
-	0xf7, 0x7e, 0x4a, 0xfe, 0x67, 0xec, 0xaf, 0x42, 0x39, 0x35, 0xff, 0x1f, 0x00, 0x00, 0xff, 0xff,
-	0x32, 0xf1, 0xd9, 0x95, 0x95, 0x14, 0x00, 0x00,
+var fileDescriptor_output_gen_f6eaac3cc34161cb = []byte{
+	// 1758 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x58, 0xdd, 0x6e, 0x1b, 0xb9,
+	0x15, 0x8e, 0x62, 0xc7, 0x96, 0xa8, 0x5f, 0x33, 0xb1, 0x43, 0xbb, 0x5b, 0xcb, 0x75, 0xdb, 0x5d,
+	0x67, 0xd1, 0xda, 0x12, 0xa5, 0x75, 0x17, 0x8b, 0xdd, 0x62, 0x23, 0x29, 0x4e, 0xd4, 0x26, 0xa9,
+	0x41, 0x7b, 0x5b, 0x60, 0x81, 0x62, 0x30, 0x9a, 0xa1, 0xa4, 0x41, 0x46, 0xc3, 0xc1, 0x0c, 0x95,
+	0x75, 0xde, 0xa0, 0x97, 0x7b, 0xd1, 0x07, 0xe9, 0x63, 0xe4, 0xb2, 0x4f, 0xe0, 0xb6, 0xce, 0x8b,
+	0x14, 0xfc, 0x99, 0x11, 0x47, 0x1a, 0xd8, 0xbe, 0x31, 0x78, 0x38, 0xdf, 0xf7, 0x9d, 0x43, 0x9e,
+	0x43, 0x1e, 0xca, 0x60, 0x97, 0xd3, 0x98, 0xbb, 0x36, 0xb7, 0x4f, 0xd8, 0x9c, 0x87, 0x73, 0x6e,
+	0x4d, 0x68, 0x70, 0x1c, 0x46, 0x8c, 0x33, 0x58, 0x4c, 0x3e, 0xed, 0xfd, 0x7e, 0xe2, 0xf1, 0xe9,
+	0x7c, 0x74, 0xec, 0xb0, 0xd9, 0xc9, 0x84, 0x4d, 0xd8, 0x89, 0x04, 0x8c, 0xe6, 0x63, 0x69, 0x49,
+	0x43, 0x8e, 0x14, 0x71, 0xaf, 0x39, 0x61, 0x6c, 0xe2, 0xd3, 0x05, 0x8a, 0x7b, 0x33, 0x1a, 0x73,
+	0x7b, 0x16, 0x6a, 0x00, 0x36, 0xf4, 0x1c, 0x16, 0xd1, 0x98, 0xb3, 0x88, 0x7a, 0xec, 0x24, 0x7c,
+	0x37, 0x39, 0x11, 0x63, 0x7b, 0x42, 0x4f, 0x82, 0xb9, 0xef, 0xcb, 0x3f, 0x8a, 0x73, 0xf8, 0x8f,
+	0x1a, 0xa8, 0xf5, 0xe7, 0x31, 0x67, 0x33, 0x1a, 0xbd, 0x08, 0xb8, 0xc7, 0x3f, 0xc0, 0x67, 0xa0,
+	0x44, 0xe5, 0xc8, 0xf2, 0x5c, 0x54, 0x38, 0x28, 0x1c, 0xad, 0xf7, 0x2a, 0x37, 0xd7, 0xcd, 0xa2,
+	0xfa, 0x3c, 0x1c, 0x90, 0xa2, 0xfa, 0x3c, 0x74, 0xe1, 0x77, 0x00, 0xfc, 0x44, 0x47, 0xb1, 0xc7,
+	0xa9, 0xc0, 0x3e, 0x3c, 0x28, 0x1c, 0x95, 0x71, 0xf9, 0x58, 0xca, 0x0f, 0x03, 0x7e, 0xda, 0xed,
+	0x6d, 0x7d, 0xbc, 0x6e, 0x3e, 0xb8, 0xb9, 0x6e, 0x96, 0xfe, 0xa6, 0x60, 0xc3, 0x01, 0x29, 0x69,
+	0xc6, 0xd0, 0x85, 0x6d, 0xf0, 0x88, 0xce, 0x6c, 0xcf, 0x47, 0x6b, 0x92, 0x59, 0x51, 0xcc, 0x0b,
+	0x1e, 0x79, 0xc1, 0xa4, 0x57, 0xd5, 0xd4, 0x47, 0x2f, 0x04, 0x84, 0x28, 0x24, 0xfc, 0x1c, 0x14,
+	0x27, 0x11, 0x9b, 0x87, 0xc2, 0xdf, 0xba, 0x8c, 0xad, 0x7c, 0x73, 0xdd, 0xdc, 0x7c, 0x29, 0xe6,
+	0x86, 0x03, 0xb2, 0x29, 0x3f, 0x0e, 0x5d, 0x38, 0x00, 0x15, 0x2f, 0x70, 0x22, 0x3a, 0xa3, 0x01,
+	0x17, 0xd8, 0x47, 0x39, 0x1e, 0x1e, 0x6b, 0x0f, 0xe5, 0x61, 0x82, 0x1c, 0x0e, 0x48, 0x39, 0xa5,
+	0x0d, 0x5d, 0xf8, 0x07, 0x50, 0x94, 0x9b, 0x28, 0x14, 0x36, 0x56, 0x57, 0x57, 0xd7, 0x02, 0x9b,
+	0x17, 0x02, 0x24, 0xdc, 0x4b, 0xf4, 0xd0, 0x85, 0xe7, 0x00, 0x38, 0x11, 0xb5, 0x39, 0x75, 0x2d,
+	0x9b, 0xa3, 0x4d, 0x49, 0xdd, 0x3b, 0x56, 0x09, 0x3c, 0x4e, 0x12, 0x78, 0x7c, 0x99, 0x24, 0xb0,
+	0xb7, 0x9d, 0xec, 0x53, 0x5f, 0xb1, 0x9e, 0xf3, 0x9f, 0xff, 0xd3, 0x2c, 0x90, 0x92, 0x93, 0x98,
+	0x42, 0x71, 0x1e, 0xba, 0x89, 0x62, 0xf1, 0xfe, 0x8a, 0x3f, 0x84, 0xae, 0xa9, 0x38, 0x4f, 0x4c,
+	0x91, 0x67, 0x2f, 0xb6, 0x6c, 0x87, 0x7b, 0xef, 0x29, 0x2a, 0x1d, 0x14, 0x8e, 0x8a, 0x2a, 0xcf,
+	0xc3, 0xf8, 0xb9, 0x9c, 0x23, 0x45, 0x4f, 0x8f, 0xe0, 0x0f, 0x60, 0xd7, 0xf5, 0x62, 0x7b, 0xe4,
+	0x53, 0xcb, 0x9e, 0x73, 0x66, 0xa9, 0x14, 0x38, 0x53, 0x3b, 0x98, 0x50, 0x04, 0x64, 0x1a, 0xf6,
+	0x6e, 0xae, 0x9b, 0x3b, 0x03, 0x05, 0x7a, 0x3e, 0xe7, 0x4c, 0x66, 0xa4, 0x2f, 0x11, 0x64, 0xc7,
+	0xcd, 0x9d, 0x87, 0x7f, 0x5c, 0xec, 0x92, 0x17, 0xa0, 0x72, 0x4e, 0x8a, 0xb6, 0x96, 0xf6, 0x65,
+	0x18, 0xa4, 0x7b, 0x32, 0x0c, 0x60, 0x17, 0x6c, 0x84, 0x11, 0x1d, 0x7b, 0x57, 0xa8, 0x92, 0xc3,
+	0xad, 0x69, 0xee, 0xc6, 0xb9, 0xc4, 0x10, 0x8d, 0x85, 0xdf, 0x81, 0xd2, 0xd8, 0x8b, 0x62, 0x1e,
+	0xd8, 0x33, 0x8a, 0xaa, 0xb7, 0x39, 0x3d, 0x4b, 0x60, 0x64, 0xc1, 0x80, 0xdf, 0x03, 0x30, 0xf3,
+	0x5c, 0xd7, 0xa7, 0x92, 0x5f, 0xcb, 0xe1, 0x43, 0xcd, 0x07, 0x6f, 0x52, 0x1c, 0x31, 0x38, 0xf0,
+	0x1b, 0x50, 0xf4, 0x6d, 0xed, 0xbf, 0x9e, 0xc3, 0x6f, 0x68, 0x7e, 0xf1, 0xb5, 0x46, 0x91, 0x14,
+	0x2f, 0x96, 0x1c, 0xcf, 0xc7, 0x62, 0xc9, 0x8d, 0xdb, 0x96, 0x7c, 0x21, 0x31, 0x44, 0x63, 0xe1,
+	0x33, 0xb0, 0xe6, 0xb2, 0x11, 0xda, 0x92, 0x14, 0xa0, 0x28, 0xa2, 0x54, 0x7a, 0x65, 0x4d, 0x58,
+	0x1b, 0xb0, 0x11, 0x11, 0x18, 0xf8, 0x12, 0x54, 0x43, 0x3b, 0x8e, 0x7f, 0x62, 0x91, 0x6b, 0x4d,
+	0xed, 0x78, 0x8a, 0x60, 0x8e, 0x9f, 0x27, 0x9a, 0x56, 0x39, 0xd7, 0xd0, 0x57, 0x76, 0x3c, 0x25,
+	0x95, 0xd0, 0xb0, 0xe0, 0xd7, 0xa0, 0x18, 0x85, 0x16, 0x67, 0xef, 0x68, 0x80, 0x1e, 0xe7, 0x68,
+	0xa4, 0x87, 0x87, 0x84, 0x97, 0x02, 0x44, 0x36, 0x23, 0x35, 0x80, 0x17, 0xe0, 0x71, 0xc2, 0xb4,
+	0x8c, 0x53, 0xf4, 0x64, 0x25, 0x7a, 0xa4, 0x25, 0x1a, 0x5a, 0x22, 0x3d, 0x3c, 0xa4, 0x11, 0x2d,
+	0xcd, 0xc0, 0xd7, 0xa0, 0xee, 0xd2, 0xb1, 0x3d, 0xf7, 0xb9, 0x35, 0xf2, 0x7c, 0xdf, 0x0b, 0x26,
+	0x68, 0x7b, 0xf5, 0x44, 0xef, 0x68, 0xc5, 0xda, 0x40, 0x61, 0x7b, 0x0a, 0x4a, 0x6a, 0x6e, 0xc6,
+	0x86, 0x7f, 0x01, 0x8d, 0x44, 0x2d, 0x9e, 0x7a, 0x61, 0x28, 0xe4, 0x76, 0x56, 0xe5, 0x9e, 0x6a,
+	0xb9, 0xba, 0x96, 0xbb, 0xd0, 0x58, 0x52, 0x77, 0xb3, 0x13, 0x22, 0xaf, 0xdc, 0xbe, 0x7a, 0x6f,
+	0x73, 0xf4, 0xf4, 0xb6, 0xbc, 0x5e, 0x4a, 0x0c, 0xd1, 0x58, 0x78, 0x06, 0x2a, 0x0e, 0x0b, 0xc6,
+	0x5e, 0x34, 0xb3, 0xb9, 0xc7, 0x02, 0x84, 0x6e, 0xcb, 0x55, 0xdf, 0x40, 0x92, 0x0c, 0x0f, 0x76,
+	0xc0, 0xc6, 0x84, 0x06, 0x2e, 0x8d, 0xd0, 0xee, 0xea, 0x22, 0x52, 0xe7, 0x2f, 0x25, 0x84, 0x68,
+	0x28, 0xec, 0x83, 0xca, 0xd8, 0xf6, 0xfc, 0x79, 0x44, 0x63, 0x2b, 0x98, 0xcf, 0xd0, 0xde, 0x2a,
+	0x35, 0xbd, 0x61, 0xcf, 0x34, 0xf0, 0xed, 0x7c, 0x46, 0xca, 0xe3, 0x85, 0x01, 0x5f, 0x80, 0xaa,
+	0x3c, 0x5a, 0x96, 0x9e, 0x44, 0xbf, 0x58, 0xc9, 0x72, 0xba, 0x00, 0x79, 0x1c, 0xb5, 0x12, 0xa9,
+	0x8c, 0x0d, 0x0b, 0xf6, 0x40, 0xc5, 0x67, 0xce, 0x3b, 0x8b, 0x5e, 0x85, 0x5e, 0x44, 0x63, 0xf4,
+	0xd9, 0x8a, 0x4a, 0x1a, 0xca, 0x6b, 0xe6, 0xbc, 0x7b, 0xa1, 0x60, 0xa4, 0xec, 0x2f, 0x8c, 0xc3,
+	0x57, 0x00, 0x65, 0x3b, 0x61, 0x9f, 0xf9, 0x3e, 0x75, 0xe4, 0x06, 0xfd, 0x0e, 0xac, 0x0f, 0x6c,
+	0x6e, 0xa3, 0xc2, 0xc1, 0xda, 0x51, 0x19, 0xa3, 0xe3, 0xa4, 0x87, 0x1f, 0x67, 0x19, 0x44, 0xa2,
+	0x0e, 0xff, 0xb9, 0x0d, 0xca, 0x83, 0x99, 0x3f, 0xa1, 0xc1, 0xe5, 0x87, 0x90, 0xc6, 0x70, 0x07,
+	0x3c, 0xd4, 0xad, 0x74, 0xad, 0xb7, 0x71, 0x73, 0xdd, 0x7c, 0x38, 0x1c, 0x90, 0x87, 0x9e, 0x0b,
+	0x9f, 0x8b, 0xf4, 0xf9, 0xd6, 0xc8, 0x9b, 0x78, 0x01, 0xb7, 0xda, 0x79, 0x0d, 0x34, 0xbd, 0x4b,
+	0xfa, 0xcc, 0xef, 0x49, 0x5c, 0x9b, 0x00, 0x27, 0x1d, 0xc3, 0x56, 0x46, 0x02, 0xcb, 0x4e, 0xba,
+	0xd6, 0xab, 0x65, 0x18, 0xd8, 0x60, 0xe0, 0x25, 0xa7, 0x1d, 0xb4, 0x7e, 0x1f, 0xa7, 0x1d, 0x43,
+	0xa2, 0xb3, 0xe4, 0xb4, 0x2b, 0x9b, 0xeb, 0xfa, 0x92, 0xd3, 0xae, 0xc1, 0xe8, 0x8a, 0xcb, 0x40,
+	0x32, 0x7c, 0x36, 0x42, 0x1b, 0x39, 0x45, 0x9a, 0x5e, 0x06, 0x82, 0xef, 0xb3, 0x11, 0xd9, 0x74,
+	0xd4, 0x00, 0x7e, 0x03, 0x84, 0x8e, 0x25, 0x9a, 0x96, 0xd5, 0x46, 0x9b, 0x2b, 0x79, 0x4d, 0x2f,
+	0xcb, 0x3e, 0xf3, 0x07, 0x36, 0xa7, 0x6d, 0x52, 0x74, 0xf4, 0x08, 0xbe, 0x35, 0xb8, 0xf8, 0x1e,
+	0x3d, 0xf3, 0xc9, 0x92, 0x16, 0x96, 0x2d, 0x33, 0xd1, 0xc3, 0xf0, 0x0c, 0xd4, 0x12, 0x3d, 0xf1,
+	0xf6, 0xb2, 0xda, 0xa8, 0xb4, 0x12, 0x8f, 0x71, 0xdc, 0xfc, 0x81, 0x06, 0xb6, 0xc5, 0x71, 0x5b,
+	0x58, 0xf0, 0xc7, 0x25, 0x1d, 0x8c, 0xc0, 0x9d, 0xb1, 0xa1, 0x1c, 0x5d, 0x15, 0x9f, 0xa9, 0x8d,
+	0xe1, 0x1b, 0xd0, 0x90, 0xda, 0xd4, 0xf1, 0x66, 0xb6, 0x6f, 0xb5, 0x5b, 0x56, 0x4b, 0x77, 0xd6,
+	0xaa, 0x8a, 0x72, 0xa0, 0xbe, 0xa4, 0x0f, 0x84, 0xaa, 0x10, 0x54, 0x73, 0xed, 0x56, 0x8b, 0x54,
+	0x1d, 0xd3, 0x5c, 0x91, 0xc3, 0x56, 0x17, 0x55, 0xee, 0x2b, 0x87, 0xbb, 0x19, 0x39, 0xdc, 0x85,
+	0xdf, 0x83, 0x72, 0x18, 0x79, 0x0e, 0x95, 0x42, 0x36, 0xaa, 0xe6, 0x29, 0xa5, 0xed, 0xf7, 0x5c,
+	0x20, 0xdb, 0xb8, 0x6b, 0x93, 0x52, 0x98, 0x0c, 0xb3, 0x0a, 0x23, 0x54, 0xbb, 0x97, 0xc2, 0x68,
+	0xa1, 0x30, 0xca, 0x59, 0x52, 0x07, 0xd5, 0xf3, 0x64, 0x72, 0x97, 0xd4, 0xc9, 0x2e, 0xa9, 0xb3,
+	0x2c, 0x87, 0x5b, 0xd6, 0x29, 0x6a, 0xdc, 0x53, 0x0e, 0xb7, 0x4e, 0x4d, 0x39, 0xdc, 0x3a, 0x85,
+	0xe7, 0x60, 0x2b, 0x23, 0xd7, 0xb5, 0xda, 0x18, 0x6d, 0xe5, 0xe9, 0xa5, 0xbd, 0xca, 0xd0, 0xeb,
+	0xb6, 0x31, 0xa9, 0x39, 0x19, 0x5b, 0xbc, 0xf3, 0x84, 0xe2, 0xd8, 0x67, 0x36, 0x97, 0xdd, 0xbc,
+	0xa0, 0xde, 0x79, 0x7d, 0xe6, 0x9f, 0x89, 0x39, 0x59, 0xe0, 0x72, 0x04, 0xbf, 0x56, 0x50, 0x75,
+	0x1b, 0x3d, 0xbe, 0xe5, 0xc1, 0xdb, 0x67, 0xfe, 0x50, 0x5c, 0x45, 0x9b, 0x8e, 0x1a, 0xc0, 0x2f,
+	0x16, 0x4c, 0x2c, 0x3b, 0xf5, 0x5a, 0xaf, 0xbc, 0x00, 0xe2, 0x04, 0x88, 0x4d, 0x17, 0x1d, 0xb4,
+	0x7d, 0xa7, 0x8b, 0x4e, 0xc2, 0xec, 0x98, 0x2e, 0xba, 0x68, 0x67, 0xf1, 0xf6, 0x57, 0xc0, 0x6e,
+	0x02, 0xec, 0xc2, 0x57, 0xea, 0x78, 0xf9, 0x2c, 0x98, 0x70, 0x7a, 0x25, 0x96, 0xf2, 0xf4, 0xf6,
+	0xbe, 0xe8, 0xbf, 0xd6, 0x50, 0x75, 0x50, 0x53, 0x0b, 0x9e, 0x2e, 0x29, 0x61, 0xd9, 0x61, 0x4b,
+	0xbd, 0xc6, 0x12, 0x0f, 0x67, 0x78, 0x18, 0xfe, 0x49, 0xf1, 0x66, 0xd4, 0xf5, 0xe6, 0x33, 0x79,
+	0xe9, 0xed, 0xe6, 0x44, 0x60, 0x16, 0xc4, 0x9b, 0x14, 0x2a, 0x0b, 0x62, 0x61, 0xc2, 0xb7, 0xa0,
+	0xb1, 0xd0, 0xd2, 0xeb, 0xd9, 0xcb, 0x51, 0x33, 0xcb, 0xe1, 0x4d, 0x0a, 0x6e, 0xcb, 0x72, 0x30,
+	0x6c, 0xf8, 0xed, 0x8a, 0x1e, 0x96, 0x4d, 0xb7, 0xd4, 0x83, 0x2b, 0x6c, 0xbc, 0xc4, 0xc6, 0xf0,
+	0xa5, 0x5a, 0x59, 0x3c, 0xb3, 0xc5, 0x43, 0x48, 0xc4, 0xf2, 0xd9, 0x6a, 0x0e, 0xcd, 0xad, 0xbd,
+	0xd0, 0x48, 0xb5, 0xb5, 0xa9, 0x05, 0x4f, 0x97, 0x84, 0x30, 0xfa, 0xa5, 0xac, 0x9a, 0xc6, 0x12,
+	0x0f, 0x67, 0x78, 0xab, 0x01, 0x74, 0xd0, 0xfe, 0xfd, 0x02, 0xe8, 0x64, 0x84, 0x3a, 0x2b, 0x01,
+	0x74, 0x51, 0x53, 0xd6, 0xd4, 0x72, 0x00, 0xdd, 0x0c, 0xaf, 0x2b, 0x78, 0x53, 0x3b, 0x5e, 0xf0,
+	0xbe, 0x42, 0x07, 0xf2, 0xb7, 0x93, 0xe4, 0xbd, 0xb2, 0xe3, 0x04, 0xf9, 0x15, 0xa9, 0x4c, 0x0d,
+	0x0b, 0xf6, 0x41, 0xd5, 0xcb, 0xd0, 0x7e, 0x65, 0xf6, 0x8e, 0x1e, 0x63, 0xbe, 0xf1, 0x83, 0xd4,
+	0x50, 0x29, 0x7b, 0x86, 0x88, 0xee, 0xa3, 0x22, 0x17, 0xe8, 0xf0, 0x8e, 0x3e, 0x7a, 0x49, 0xaf,
+	0xb8, 0x3c, 0x14, 0x62, 0x00, 0xff, 0x0e, 0xea, 0x92, 0x99, 0x34, 0x14, 0xab, 0x8d, 0x7e, 0x7d,
+	0x67, 0xd3, 0xd9, 0x35, 0x2a, 0x34, 0x9d, 0x6d, 0xcb, 0xae, 0x53, 0x75, 0xcc, 0x29, 0x38, 0x5c,
+	0x96, 0xc7, 0xe8, 0x37, 0x2b, 0xbd, 0x71, 0x3b, 0x4f, 0x0e, 0x67, 0xa5, 0x30, 0xec, 0x80, 0xaa,
+	0x92, 0x0a, 0x3e, 0xa8, 0x0a, 0xfb, 0xad, 0x2c, 0x8c, 0xba, 0xd8, 0x18, 0x49, 0x94, 0xf3, 0x6d,
+	0x52, 0x76, 0x16, 0x46, 0x42, 0x7a, 0x6f, 0x47, 0xce, 0xd4, 0x8e, 0xac, 0x36, 0xfa, 0x5c, 0x96,
+	0x74, 0x42, 0xfa, 0xab, 0x9a, 0x57, 0xa4, 0xc4, 0x80, 0x7f, 0x06, 0xf5, 0x0c, 0xa9, 0xd5, 0x42,
+	0x5f, 0xdc, 0x71, 0x4e, 0x13, 0xae, 0xee, 0x94, 0x0b, 0x33, 0xa9, 0xa7, 0x54, 0xec, 0x14, 0x1d,
+	0x65, 0xee, 0x8a, 0x04, 0x7a, 0x2a, 0xeb, 0x29, 0xb5, 0xe0, 0xb7, 0xea, 0x91, 0xa2, 0xc3, 0x7e,
+	0x76, 0xdb, 0xef, 0xc1, 0x3e, 0xf3, 0xfb, 0x72, 0x15, 0x45, 0x47, 0x8f, 0xe0, 0x97, 0x06, 0x1b,
+	0xa3, 0x2f, 0xa5, 0xc7, 0x8a, 0x81, 0xc5, 0x29, 0x16, 0x1f, 0xf6, 0xc0, 0xb6, 0xf1, 0x2a, 0x35,
+	0x5e, 0xb7, 0xcf, 0x32, 0xaf, 0xdb, 0xed, 0xc5, 0xeb, 0xd6, 0x80, 0xab, 0xa7, 0x6d, 0xef, 0xf0,
+	0xe3, 0xff, 0xf6, 0x1f, 0x7c, 0xbc, 0xd9, 0x2f, 0xfc, 0xfb, 0x66, 0xbf, 0xf0, 0xdf, 0x9b, 0xfd,
+	0xc2, 0xcf, 0x9f, 0xf6, 0x1f, 0xfc, 0xeb, 0xd3, 0xfe, 0x83, 0x1f, 0xd3, 0xff, 0x6b, 0x8d, 0x36,
+	0x64, 0x25, 0x75, 0xfe, 0x3f, 0x00, 0x7a, 0x12, 0x90, 0xfa, 0x05, 0x13, 0x00, 0x00,
 }
@@ -16,6 +16,9 @@ package dmlgen
 
 import (
 	"fmt"
+	"math"
+	"reflect"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -38,6 +41,12 @@ type TypeDef struct {
 	ProtobufUnsignedNotNull string
 	ProtobufSignedNull      string
 	ProtobufSignedNotNull   string
+
+	// ColumnMapFunc optional name of the dml.ColumnMap method which scans and
+	// writes a custom Go type, for example "Text" or "Binary" for types
+	// implementing the encoding.Text/BinaryMarshaler interfaces. If empty the
+	// name gets derived from the Go type.
+	ColumnMapFunc string
 }
 
 // These variables are mapping the un/signed and null/not-null types to the
@@ -111,15 +120,15 @@ var (
 		ProtobufSignedNotNull:   "bool",
 	}
 	goTypeDecimal = &TypeDef{
-		MysqlUnsignedNull:    "dml.Decimal",
-		MysqlUnsignedNotNull: "dml.Decimal",
-		MysqlSignedNull:      "dml.Decimal",
-		MysqlSignedNotNull:   "dml.Decimal",
-
-		ProtobufUnsignedNull:    "dml.Decimal", // Proto package and its type not the Go package!
-		ProtobufUnsignedNotNull: "dml.Decimal", // Proto package and its type not the Go package!
-		ProtobufSignedNull:      "dml.Decimal", // Proto package and its type not the Go package!
-		ProtobufSignedNotNull:   "dml.Decimal", // Proto package and its type not the Go package!
+		MysqlUnsignedNull:    "null.Decimal",
+		MysqlUnsignedNotNull: "null.Decimal",
+		MysqlSignedNull:      "null.Decimal",
+		MysqlSignedNotNull:   "null.Decimal",
+
+		ProtobufUnsignedNull:    "null.Decimal", // Proto package and its type not the Go package!
+		ProtobufUnsignedNotNull: "null.Decimal", // Proto package and its type not the Go package!
+		ProtobufSignedNull:      "null.Decimal", // Proto package and its type not the Go package!
+		ProtobufSignedNotNull:   "null.Decimal", // Proto package and its type not the Go package!
 	}
 	goTypeByte = &TypeDef{
 		MysqlUnsignedNull:    "[]byte",
@@ -166,15 +175,49 @@ var MysqlTypeToGo = map[string]*TypeDef{
 	"bit":        goTypeBool,
 }
 
-func toGoTypeNull(c *ddl.Column) string {
-	return mySQLToGoType(c, true)
+// customTypes contains the type mappings of a generator which override the
+// global MysqlTypeToGo. A type for a column takes precedence over a type for a
+// MySQL data type.
+type customTypes struct {
+	columns   map[*ddl.Column]*TypeDef
+	dataTypes map[string]*TypeDef
+	// nullPointers replaces the null types with pointer types, see
+	// WithNullPointers.
+	nullPointers bool
+}
+
+// nullPointerTypes maps the null types to their pointer equivalent.
+var nullPointerTypes = map[string]string{
+	"null.Bool":    "*bool",
+	"null.Float64": "*float64",
+	"null.Int64":   "*int64",
+	"null.String":  "*string",
+	"null.Time":    "*time.Time",
 }
 
-func toGoType(c *ddl.Column) string {
-	return mySQLToGoType(c, false)
+// isNullPointer reports whether the nullable column gets represented by a
+// pointer type.
+func (ct *customTypes) isNullPointer(c *ddl.Column) bool {
+	return strings.HasPrefix(ct.mySQLToGoType(c, true), "*")
 }
 
-func findType(c *ddl.Column) *TypeDef {
+func (ct *customTypes) toGoTypeNull(c *ddl.Column) string {
+	return ct.mySQLToGoType(c, true)
+}
+
+func (ct *customTypes) toGoType(c *ddl.Column) string {
+	return ct.mySQLToGoType(c, false)
+}
+
+func (ct *customTypes) findType(c *ddl.Column) *TypeDef {
+	if ct != nil {
+		if goType, ok := ct.columns[c]; ok {
+			return goType
+		}
+		if goType, ok := ct.dataTypes[c.DataType]; ok {
+			return goType
+		}
+	}
 
 	goType, ok := MysqlTypeToGo[c.DataType]
 	if !ok {
@@ -195,9 +238,9 @@ func findType(c *ddl.Column) *TypeDef {
 // mySQLToGoType calculates the data type of the field DataType. For example
 // bigint, smallint, tinyint will result in "int". If withNull is true the
 // returned type can store a null value.
-func mySQLToGoType(c *ddl.Column, withNull bool) string {
+func (ct *customTypes) mySQLToGoType(c *ddl.Column, withNull bool) string {
 
-	goType := findType(c)
+	goType := ct.findType(c)
 
 	var t string
 	switch {
@@ -210,37 +253,84 @@ func mySQLToGoType(c *ddl.Column, withNull bool) string {
 	case !c.IsUnsigned() && (!c.IsNull() || !withNull):
 		t = goType.MysqlSignedNotNull // signed not null
 	}
-
+	if ct != nil && ct.nullPointers && withNull {
+		if pt, ok := nullPointerTypes[t]; ok {
+			t = pt
+		}
+	}
 	return t
 }
 
 // toGoPrimitive returns for Go type or structure the final primitive:
-// int->int but NullInt->.Int
-func toGoPrimitive(c *ddl.Column) string {
-	t := mySQLToGoType(c, true)
+// int->int but null.Int64->.Int64
+func (ct *customTypes) toGoPrimitive(c *ddl.Column) string {
+	t := ct.mySQLToGoType(c, true)
 	field := strs.ToGoCamelCase(c.Field)
 	if strings.HasPrefix(t, "null.") {
-		t = field + "." + t[8:]
+		t = field + "." + t[5:]
 	} else {
 		t = field
 	}
 	return t
 }
 
-func toGoFuncNull(c *ddl.Column) string {
-	return mySQLToGoFunc(c, true)
+func (ct *customTypes) toGoFuncNull(c *ddl.Column) string {
+	return ct.mySQLToGoFunc(c, true)
+}
+
+func (ct *customTypes) toGoFunc(c *ddl.Column) string {
+	return ct.mySQLToGoFunc(c, false)
+}
+
+// columnMapSliceFuncs contains the slice functions provided by dml.ColumnMap
+// for mode ColumnMapCollectionReadSet.
+var columnMapSliceFuncs = map[string]bool{
+	"Ints": true, "Int64s": true, "NullInt64s": true, "Uints": true, "Uint64s": true,
+	"NullUint64s": true, "Float64s": true, "NullFloat64s": true, "Decimals": true,
+	"Points": true, "Bools": true, "NullBools": true, "Strings": true,
+	"NullStrings": true, "Bytes": true, "Times": true, "NullTimes": true,
 }
 
-func toGoFunc(c *ddl.Column) string {
-	return mySQLToGoFunc(c, false)
+// toGoFuncNullSlice returns the name of the dml.ColumnMap slice function or an
+// empty string if ColumnMap does not provide one for the type of the column.
+func (ct *customTypes) toGoFuncNullSlice(c *ddl.Column) string {
+	return columnMapSliceFunc(ct.mySQLToGoFunc(c, true))
 }
 
-func mySQLToGoFunc(c *ddl.Column, withNull bool) string {
+func (ct *customTypes) toGoFuncSlice(c *ddl.Column) string {
+	return columnMapSliceFunc(ct.mySQLToGoFunc(c, false))
+}
+
+func columnMapSliceFunc(fn string) string {
+	if fn += "s"; columnMapSliceFuncs[fn] {
+		return fn
+	}
+	return ""
+}
 
-	gt := mySQLToGoType(c, withNull)
+func (ct *customTypes) mySQLToGoFunc(c *ddl.Column, withNull bool) string {
+
+	if fn := ct.findType(c).ColumnMapFunc; fn != "" {
+		return fn
+	}
+
+	gt := ct.mySQLToGoType(c, withNull)
+	if strings.HasPrefix(gt, "*") {
+		gt = gt[1:] // *string => StringPtr, *time.Time => TimePtr
+		if dot := strings.IndexByte(gt, '.'); dot > 0 {
+			gt = gt[dot+1:]
+		}
+		r, n := utf8.DecodeRuneInString(gt)
+		return string(unicode.ToUpper(r)) + gt[n:] + "Ptr"
+	}
 	switch gt {
 	case "[]byte":
 		return "Byte"
+	case "null.Decimal":
+		return "Decimal"
+	}
+	if strings.HasPrefix(gt, "null.") {
+		return "Null" + gt[5:] // null.String => NullString
 	}
 
 	if dot := strings.IndexByte(gt, '.'); dot > 0 {
@@ -250,9 +340,9 @@ func mySQLToGoFunc(c *ddl.Column, withNull bool) string {
 	return string(unicode.ToUpper(r)) + gt[n:]
 }
 
-func toProto(c *ddl.Column, withNull bool) string {
+func (ct *customTypes) toProto(c *ddl.Column, withNull bool) string {
 
-	goType := findType(c)
+	goType := ct.findType(c)
 
 	var t string
 	switch {
@@ -269,16 +359,16 @@ func toProto(c *ddl.Column, withNull bool) string {
 	return t
 }
 
-func toProtoType(c *ddl.Column) string {
-	pt := toProto(c, true)
+func (ct *customTypes) toProtoType(c *ddl.Column) string {
+	pt := ct.toProto(c, true)
 	if strings.IndexByte(pt, '/') > 0 { // slash identifies an import path
 		return "bytes"
 	}
 	return pt
 }
 
-func toProtoCustomType(c *ddl.Column) string {
-	pt := toProto(c, true)
+func (ct *customTypes) toProtoCustomType(c *ddl.Column) string {
+	pt := ct.toProto(c, true)
 	var buf strings.Builder
 	if pt == "google.protobuf.Timestamp" {
 		fmt.Fprint(&buf, ",(gogoproto.stdtime)=true")
@@ -292,3 +382,293 @@ func toProtoCustomType(c *ddl.Column) string {
 	}
 	return buf.String()
 }
+
+// validation represents a single check of a column in the generated Validate
+// method. Cond contains the Go expression which reports an invalid value.
+type validation struct {
+	Cond string
+	Msg  string
+}
+
+// intRanges contains the signed minimum and the maximum values of the integer
+// data types. The unsigned maximum is (max*2)+1.
+var intRanges = map[string][2]int64{
+	"tinyint":   {-1 << 7, 1<<7 - 1},
+	"smallint":  {-1 << 15, 1<<15 - 1},
+	"mediumint": {-1 << 23, 1<<23 - 1},
+	"int":       {-1 << 31, 1<<31 - 1},
+}
+
+// toValidations derives the checks for the constraints NOT NULL, maximum
+// character length, enum membership and integer ranges from the column
+// definition. Only the default Go types are supported.
+func (ct *customTypes) toValidations(c *ddl.Column) (vs []validation) {
+	field := "e." + strs.ToGoCamelCase(c.Field)
+	goType := ct.toGoTypeNull(c)
+
+	switch goType {
+	case "[]byte":
+		if !c.IsNull() && !c.Default.Valid {
+			vs = append(vs, validation{
+				Cond: field + " == nil",
+				Msg:  fmt.Sprintf("Column `%s` cannot be NULL", c.Field),
+			})
+		}
+	case "string", "null.String", "*string":
+		strField, valid := field, ""
+		switch goType {
+		case "null.String":
+			strField, valid = field+".String", field+".Valid && "
+		case "*string":
+			strField, valid = "*"+field, field+" != nil && "
+		}
+		if (c.DataType == "char" || c.DataType == "varchar") && c.CharMaxLength.Valid && c.CharMaxLength.Int64 > 0 {
+			vs = append(vs, validation{
+				Cond: fmt.Sprintf("%sutf8.RuneCountInString(%s) > %d", valid, strField, c.CharMaxLength.Int64),
+				Msg:  fmt.Sprintf("Column `%s` exceeds the maximum length of %d characters", c.Field, c.CharMaxLength.Int64),
+			})
+		}
+		if enums := c.EnumValues(); c.DataType == "enum" && len(enums) > 0 {
+			var buf strings.Builder
+			buf.WriteString(valid)
+			buf.WriteString("!(")
+			for i, ev := range enums {
+				if i > 0 {
+					buf.WriteString(" || ")
+				}
+				fmt.Fprintf(&buf, "%s == %q", strField, ev)
+			}
+			buf.WriteByte(')')
+			vs = append(vs, validation{
+				Cond: buf.String(),
+				Msg:  fmt.Sprintf("Column `%s` must be one of: %s", c.Field, strings.Join(enums, ", ")),
+			})
+		}
+	case "uint64", "int64", "null.Int64", "*int64":
+		r, ok := intRanges[c.DataType]
+		if !ok || c.IsBool() {
+			return nil
+		}
+		min, max := r[0], r[1]
+		if c.IsUnsigned() {
+			min, max = 0, max*2+1
+		}
+		intField, valid := field, ""
+		switch goType {
+		case "null.Int64":
+			intField, valid = field+".Int64", field+".Valid && "
+		case "*int64":
+			intField, valid = "*"+field, field+" != nil && "
+		}
+		cond := fmt.Sprintf("%s < %d || %s > %d", intField, min, intField, max)
+		switch goType {
+		case "uint64":
+			cond = fmt.Sprintf("%s > %d", intField, max)
+		case "null.Int64", "*int64":
+			cond = valid + "(" + cond + ")"
+		}
+		vs = append(vs, validation{
+			Cond: cond,
+			Msg:  fmt.Sprintf("Column `%s` is out of range [%d,%d]", c.Field, min, max),
+		})
+	}
+	return vs
+}
+
+// jsonField contains the Go code snippets to encode and decode a column in
+// the generated MarshalJSON and UnmarshalJSON methods.
+type jsonField struct {
+	Key      string
+	Prefix   string // comma, quoted key and colon
+	NotEmpty string // condition for omitempty, empty if the key has no omitempty
+	Append   string // appends the value to the variable buf
+	Decode   string // decodes the variable value into the field and sets err
+}
+
+// jsonAppendFns contains the Go code to append the value %[1]s to buf and to
+// decode it from a dml.JSONValue.
+var jsonAppendFns = map[string][2]string{
+	"string":       {"buf = dml.JSONAppendString(buf, %[1]s)", "String"},
+	"int64":        {"buf = strconv.AppendInt(buf, %[1]s, 10)", "Int64"},
+	"uint64":       {"buf = strconv.AppendUint(buf, %[1]s, 10)", "Uint64"},
+	"float64":      {"buf = strconv.AppendFloat(buf, %[1]s, 'g', -1, 64)", "Float64"},
+	"bool":         {"buf = strconv.AppendBool(buf, %[1]s)", "Bool"},
+	"time.Time":    {"buf = dml.JSONAppendTime(buf, %[1]s)", "Time"},
+	"[]byte":       {"buf = dml.JSONAppendBytes(buf, %[1]s)", "Bytes"},
+	"null.String":  {"buf = dml.JSONAppendString(buf, %[1]s.String)", "NullString"},
+	"null.Int64":   {"buf = strconv.AppendInt(buf, %[1]s.Int64, 10)", "NullInt64"},
+	"null.Float64": {"buf = strconv.AppendFloat(buf, %[1]s.Float64, 'g', -1, 64)", "NullFloat64"},
+	"null.Bool":    {"buf = strconv.AppendBool(buf, %[1]s.Bool)", "NullBool"},
+	"null.Time":    {"buf = dml.JSONAppendTime(buf, %[1]s.Time)", "NullTime"},
+}
+
+// toJSONField derives the JSON key and the omitempty option from the json
+// struct tag of the column. Without a tag, the key is the Go field name, as
+// encoding/json does. A tag "-" returns false. Custom types fall back to
+// encoding/json.
+func (ct *customTypes) toJSONField(c *ddl.Column) (jf jsonField, ok bool) {
+	goField := strs.ToGoCamelCase(c.Field)
+	field := "e." + goField
+	jf.Key = goField
+	omitEmpty := false
+	if tag, has := reflect.StructTag(c.StructTag).Lookup("json"); has {
+		if tag == "-" {
+			return jf, false
+		}
+		opts := strings.Split(tag, ",")
+		if opts[0] != "" {
+			jf.Key = opts[0]
+		}
+		for _, o := range opts[1:] {
+			omitEmpty = omitEmpty || o == "omitempty"
+		}
+	}
+	jf.Prefix = "," + strconv.Quote(jf.Key) + ":"
+
+	goType := ct.toGoTypeNull(c)
+	var notEmpty string
+	switch {
+	case strings.HasPrefix(goType, "*"):
+		notEmpty = field + " != nil"
+		if fns, ok := jsonAppendFns[goType[1:]]; ok {
+			jf.Append = fmt.Sprintf("if %s == nil {\nbuf = append(buf, \"null\"...)\n} else {\n%s\n}", field, fmt.Sprintf(fns[0], "*"+field))
+			jf.Decode = fmt.Sprintf("%[1]s = nil\nif !value.IsNull() {\nvar v %[2]s\nv, err = value.%[3]s()\n%[1]s = &v\n}", field, goType[1:], fns[1])
+		}
+	case strings.HasPrefix(goType, "null.") && goType != "null.Decimal":
+		notEmpty = field + ".Valid"
+		if fns, ok := jsonAppendFns[goType]; ok {
+			jf.Append = fmt.Sprintf("if !%s.Valid {\nbuf = append(buf, \"null\"...)\n} else {\n%s\n}", field, fmt.Sprintf(fns[0], field))
+			jf.Decode = fmt.Sprintf("%s, err = value.%s()", field, fns[1])
+		}
+	default:
+		switch goType {
+		case "string":
+			notEmpty = field + ` != ""`
+		case "int64", "uint64", "float64":
+			notEmpty = field + " != 0"
+		case "bool":
+			notEmpty = field
+		case "time.Time":
+			notEmpty = "!" + field + ".IsZero()"
+		case "[]byte":
+			notEmpty = "len(" + field + ") > 0"
+		case "null.Decimal":
+			notEmpty = field + ".Valid"
+			jf.Append = fmt.Sprintf("{\nb, err := %s.MarshalJSON()\nif err != nil {\nreturn nil, errors.WithStack(err)\n}\nbuf = append(buf, b...)\n}", field)
+			jf.Decode = fmt.Sprintf("%s, err = value.Decimal()", field)
+		}
+		if fns, ok := jsonAppendFns[goType]; ok {
+			jf.Append = fmt.Sprintf(fns[0], field)
+			jf.Decode = fmt.Sprintf("%s, err = value.%s()", field, fns[1])
+		}
+	}
+	if jf.Append == "" {
+		// Custom types fall back to encoding/json.
+		jf.Append = fmt.Sprintf("{\nb, err := json.Marshal(%s)\nif err != nil {\nreturn nil, errors.WithStack(err)\n}\nbuf = append(buf, b...)\n}", field)
+	}
+	if jf.Decode == "" {
+		jf.Decode = fmt.Sprintf("err = value.Unmarshal(&%s)", field)
+	}
+	if omitEmpty {
+		jf.NotEmpty = notEmpty
+	}
+	return jf, true
+}
+
+// toJSONFields returns the JSON fields of all columns which are not excluded
+// via the struct tag `json:"-"`.
+func (ct *customTypes) toJSONFields(cols ddl.Columns) []jsonField {
+	jfs := make([]jsonField, 0, len(cols))
+	for _, c := range cols {
+		if jf, ok := ct.toJSONField(c); ok {
+			jfs = append(jfs, jf)
+		}
+	}
+	return jfs
+}
+
+// fakerNullTypes maps the null types to the constructor of the null package.
+var fakerNullTypes = map[string]string{
+	"null.Bool":    "null.MakeBool",
+	"null.Float64": "null.MakeFloat64",
+	"null.Int64":   "null.MakeInt64",
+	"null.String":  "null.MakeString",
+	"null.Time":    "null.MakeTime",
+}
+
+// toFaker returns the Go statement which assigns a random value, drawn from
+// the variable r of type *rand.Rand, to the field of the entity e. The value
+// respects the maximum length, the enum values, the integer range and the
+// precision of the column. Nullable columns stay NULL with a probability of
+// 20%. Custom types are not supported and return an empty string.
+func (ct *customTypes) toFaker(c *ddl.Column) string {
+	field := "e." + strs.ToGoCamelCase(c.Field)
+	goTypeNull := ct.toGoTypeNull(c)
+
+	var maxLen int64
+	if c.CharMaxLength.Valid {
+		maxLen = c.CharMaxLength.Int64
+	}
+	lcField := strings.ToLower(c.Field)
+
+	var value string
+	switch ct.toGoType(c) {
+	case "string":
+		switch enums := c.EnumValues(); {
+		case c.DataType == "enum" && len(enums) > 0:
+			value = fmt.Sprintf("%#v[r.Intn(%d)]", enums, len(enums))
+		case strings.Contains(lcField, "email"):
+			value = fmt.Sprintf("dml.FakeEmail(r, %d)", maxLen)
+		case strings.HasSuffix(lcField, "name"):
+			value = fmt.Sprintf("dml.FakeName(r, %d)", maxLen)
+		default:
+			value = fmt.Sprintf("dml.FakeString(r, %d)", maxLen)
+		}
+	case "int64", "uint64":
+		min, max := int64(math.MinInt64), int64(math.MaxInt64)
+		if r, ok := intRanges[c.DataType]; ok {
+			min, max = r[0], r[1]
+		}
+		switch {
+		case goTypeNull == "uint64":
+			umax := uint64(math.MaxUint64)
+			if _, ok := intRanges[c.DataType]; ok {
+				umax = uint64(max)*2 + 1
+			}
+			value = fmt.Sprintf("dml.FakeUint64(r, %d)", umax)
+		case c.IsUnsigned(): // null.Int64 or *int64
+			if _, ok := intRanges[c.DataType]; ok {
+				max = max*2 + 1
+			}
+			value = fmt.Sprintf("dml.FakeInt64(r, 0, %d)", max)
+		default:
+			value = fmt.Sprintf("dml.FakeInt64(r, %d, %d)", min, max)
+		}
+	case "float64":
+		value = "r.Float64() * 1000"
+	case "bool":
+		value = "r.Intn(2) == 1"
+	case "time.Time":
+		value = "dml.FakeTime(r)"
+		if c.DataType == "date" {
+			value += ".Truncate(24 * time.Hour)"
+		}
+	case "[]byte":
+		value = fmt.Sprintf("dml.FakeBytes(r, %d)", maxLen)
+	case "null.Decimal":
+		value = fmt.Sprintf("dml.FakeDecimal(r, %d, %d)", c.Precision.Int64, c.Scale.Int64)
+	default:
+		return ""
+	}
+
+	switch {
+	case !c.IsNull():
+		return fmt.Sprintf("%s = %s", field, value)
+	case strings.HasPrefix(goTypeNull, "*"):
+		return fmt.Sprintf("if r.Intn(5) > 0 {\nv := %s\n%s = &v\n}", value, field)
+	case fakerNullTypes[goTypeNull] != "":
+		return fmt.Sprintf("if r.Intn(5) > 0 {\n%s = %s(%s)\n}", field, fakerNullTypes[goTypeNull], value)
+	default:
+		return fmt.Sprintf("if r.Intn(5) > 0 {\n%s = %s\n}", field, value)
+	}
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/corestoreio/pkg/sql/ddl"
 	"github.com/corestoreio/pkg/storage/null"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -39,17 +40,17 @@ func TestGetGoPrimitive(t *testing.T) {
 		{ddl.Column{Field: `is_root_cat180`, DataType: `smallint`, Null: "YES", Default: null.MakeString(`0`)}, "null.Bool"},
 		{ddl.Column{Field: `product_name193`, DataType: `varchar`, Null: "YES", Default: null.MakeString(`0`)}, "null.String"},
 		{ddl.Column{Field: `product_name193`, DataType: `varchar`, Null: "YES"}, "null.String"},
-		{ddl.Column{Field: `_price_______`, DataType: `decimal`, Null: "YES"}, "dml.Decimal"},
-		{ddl.Column{Field: `price`, DataType: `double`, Null: "NO"}, "dml.Decimal"},
-		{ddl.Column{Field: `msrp`, DataType: `double`, Null: "NO"}, "dml.Decimal"},
-		{ddl.Column{Field: `shipping_adjustment_230`, DataType: `decimal`, Null: "YES"}, "dml.Decimal"},
-		{ddl.Column{Field: `shipping_adjustment_241`, DataType: `decimal`, Null: "NO"}, "dml.Decimal"},
-		{ddl.Column{Field: `shipping_adjstment_252`, DataType: `decimal`, Null: "YES"}, "dml.Decimal"},
-		{ddl.Column{Field: `rate__232`, DataType: `decimal`, Null: "NO"}, "dml.Decimal"},
-		{ddl.Column{Field: `rate__233`, DataType: `decimal`, ColumnType: `float unsigned`, Null: "NO"}, "dml.Decimal"},
-		{ddl.Column{Field: `grand_absot_233`, DataType: `decimal`, Null: "YES"}, "dml.Decimal"},
-		{ddl.Column{Field: `some_currencies_242`, DataType: `decimal`, Default: null.MakeString(`0.0000`)}, "dml.Decimal"},
-		{ddl.Column{Field: `weight_252`, DataType: `decimal`, Null: "YES", Default: null.MakeString(`0.0000`)}, "dml.Decimal"},
+		{ddl.Column{Field: `_price_______`, DataType: `decimal`, Null: "YES"}, "null.Decimal"},
+		{ddl.Column{Field: `price`, DataType: `double`, Null: "NO"}, "null.Decimal"},
+		{ddl.Column{Field: `msrp`, DataType: `double`, Null: "NO"}, "null.Decimal"},
+		{ddl.Column{Field: `shipping_adjustment_230`, DataType: `decimal`, Null: "YES"}, "null.Decimal"},
+		{ddl.Column{Field: `shipping_adjustment_241`, DataType: `decimal`, Null: "NO"}, "null.Decimal"},
+		{ddl.Column{Field: `shipping_adjstment_252`, DataType: `decimal`, Null: "YES"}, "null.Decimal"},
+		{ddl.Column{Field: `rate__232`, DataType: `decimal`, Null: "NO"}, "null.Decimal"},
+		{ddl.Column{Field: `rate__233`, DataType: `decimal`, ColumnType: `float unsigned`, Null: "NO"}, "null.Decimal"},
+		{ddl.Column{Field: `grand_absot_233`, DataType: `decimal`, Null: "YES"}, "null.Decimal"},
+		{ddl.Column{Field: `some_currencies_242`, DataType: `decimal`, Default: null.MakeString(`0.0000`)}, "null.Decimal"},
+		{ddl.Column{Field: `weight_252`, DataType: `decimal`, Null: "YES", Default: null.MakeString(`0.0000`)}, "null.Decimal"},
 		{ddl.Column{Field: `weight_263`, DataType: `double`, Default: null.MakeString(`0.0000`)}, "float64"},
 		{ddl.Column{Field: `created_at_674`, DataType: `date`, Default: null.MakeString(`0000-00-00`)}, "time.Time"},
 		{ddl.Column{Field: `created_at_774`, DataType: `date`, Null: "YES", Default: null.MakeString(`0000-00-00`)}, "null.Time"},
@@ -63,8 +64,166 @@ func TestGetGoPrimitive(t *testing.T) {
 		{ddl.Column{Field: `description_003`, DataType: `char`, Null: "YES"}, "null.String"},
 		{ddl.Column{Field: `description_004`, DataType: `char`, Null: "NO"}, "string"},
 	}
+	ct := new(customTypes)
 	for _, test := range tests {
-		have := toGoTypeNull(&test.c)
+		have := ct.toGoTypeNull(&test.c)
 		require.Exactly(t, test.want, have, "%#v", test)
 	}
 }
+
+func TestCustomTypes_toGoFuncNull(t *testing.T) {
+	t.Parallel()
+	jsonCol := &ddl.Column{Field: `attributes`, DataType: `text`, Null: "YES"}
+	ct := &customTypes{
+		columns: map[*ddl.Column]*TypeDef{
+			jsonCol: {MysqlSignedNull: "JSONAttributes", ColumnMapFunc: "Text"},
+		},
+	}
+	tests := []struct {
+		c    *ddl.Column
+		want string
+	}{
+		{&ddl.Column{Field: `entity_id`, DataType: `int`, ColumnType: `int(10) unsigned`}, "Uint64"},
+		{&ddl.Column{Field: `store_id`, DataType: `int`, Null: "YES"}, "NullInt64"},
+		{&ddl.Column{Field: `email`, DataType: `varchar`, Null: "YES"}, "NullString"},
+		{&ddl.Column{Field: `created_at`, DataType: `datetime`, Null: "YES"}, "NullTime"},
+		{&ddl.Column{Field: `price`, DataType: `decimal`, Null: "YES"}, "Decimal"},
+		{&ddl.Column{Field: `image`, DataType: `varbinary`, Null: "YES"}, "Byte"},
+		{jsonCol, "Text"},
+	}
+	for _, test := range tests {
+		assert.Exactly(t, test.want, ct.toGoFuncNull(test.c), "%#v", test.c)
+	}
+	assert.Exactly(t, "JSONAttributes", ct.toGoTypeNull(jsonCol))
+}
+
+func TestCustomTypes_toGoFuncNullSlice(t *testing.T) {
+	t.Parallel()
+	jsonCol := &ddl.Column{Field: `attributes`, DataType: `text`, Null: "YES"}
+	ct := &customTypes{
+		columns: map[*ddl.Column]*TypeDef{
+			jsonCol: {MysqlSignedNull: "JSONAttributes", ColumnMapFunc: "Text"},
+		},
+	}
+	tests := []struct {
+		c    *ddl.Column
+		want string
+	}{
+		{&ddl.Column{Field: `entity_id`, DataType: `int`, ColumnType: `int(10) unsigned`}, "Uint64s"},
+		{&ddl.Column{Field: `store_id`, DataType: `int`, Null: "YES"}, "NullInt64s"},
+		{&ddl.Column{Field: `created_at`, DataType: `datetime`, Null: "YES"}, "NullTimes"},
+		{&ddl.Column{Field: `created_at`, DataType: `datetime`}, "Times"},
+		{&ddl.Column{Field: `is_active`, DataType: `bit`}, "Bools"},
+		{&ddl.Column{Field: `price`, DataType: `decimal`, Null: "YES"}, "Decimals"},
+		{&ddl.Column{Field: `image`, DataType: `varbinary`, Null: "YES"}, "Bytes"},
+		{jsonCol, ""}, // ColumnMap has no function Texts
+	}
+	for _, test := range tests {
+		assert.Exactly(t, test.want, ct.toGoFuncNullSlice(test.c), "%#v", test.c)
+	}
+}
+
+func TestCustomTypes_toValidations(t *testing.T) {
+	t.Parallel()
+	ct := new(customTypes)
+	tests := []struct {
+		c    ddl.Column
+		want []validation
+	}{
+		{ddl.Column{Field: `entity_id`, DataType: `int`, ColumnType: `int(10) unsigned`}, []validation{
+			{Cond: "e.EntityID > 4294967295", Msg: "Column `entity_id` is out of range [0,4294967295]"},
+		}},
+		{ddl.Column{Field: `store_id`, DataType: `smallint`, ColumnType: `smallint(5) unsigned`, Null: "YES"}, []validation{
+			{Cond: "e.StoreID.Valid && (e.StoreID.Int64 < 0 || e.StoreID.Int64 > 65535)", Msg: "Column `store_id` is out of range [0,65535]"},
+		}},
+		{ddl.Column{Field: `position`, DataType: `tinyint`, ColumnType: `tinyint(3)`}, []validation{
+			{Cond: "e.Position < -128 || e.Position > 127", Msg: "Column `position` is out of range [-128,127]"},
+		}},
+		{ddl.Column{Field: `email`, DataType: `varchar`, Null: "YES", CharMaxLength: null.MakeInt64(255)}, []validation{
+			{Cond: "e.Email.Valid && utf8.RuneCountInString(e.Email.String) > 255", Msg: "Column `email` exceeds the maximum length of 255 characters"},
+		}},
+		{ddl.Column{Field: `status`, DataType: `enum`, ColumnType: `enum('new','done')`}, []validation{
+			{Cond: `!(e.Status == "new" || e.Status == "done")`, Msg: "Column `status` must be one of: new, done"},
+		}},
+		{ddl.Column{Field: `image`, DataType: `varbinary`, Null: "NO"}, []validation{
+			{Cond: "e.Image == nil", Msg: "Column `image` cannot be NULL"},
+		}},
+		{ddl.Column{Field: `entity_id`, DataType: `bigint`, ColumnType: `bigint(20) unsigned`}, nil},
+		{ddl.Column{Field: `description`, DataType: `text`, Null: "YES"}, nil},
+		{ddl.Column{Field: `created_at`, DataType: `datetime`}, nil},
+	}
+	for _, test := range tests {
+		assert.Exactly(t, test.want, ct.toValidations(&test.c), "%#v", test.c)
+	}
+}
+
+func TestCustomTypes_toJSONFields(t *testing.T) {
+	t.Parallel()
+	ct := new(customTypes)
+	jfs := ct.toJSONFields(ddl.Columns{
+		{Field: `entity_id`, DataType: `int`, ColumnType: `int(10) unsigned`, StructTag: `json:"entity_id,omitempty"`},
+		{Field: `email`, DataType: `varchar`, Null: "YES", StructTag: `json:",omitempty" xml:"email"`},
+		{Field: `password_hash`, DataType: `varchar`, StructTag: `json:"-"`},
+		{Field: `price`, DataType: `decimal`, Null: "YES"},
+	})
+	assert.Exactly(t, []jsonField{
+		{
+			Key:      "entity_id",
+			Prefix:   `,"entity_id":`,
+			NotEmpty: "e.EntityID != 0",
+			Append:   "buf = strconv.AppendUint(buf, e.EntityID, 10)",
+			Decode:   "e.EntityID, err = value.Uint64()",
+		},
+		{
+			Key:      "Email",
+			Prefix:   `,"Email":`,
+			NotEmpty: "e.Email.Valid",
+			Append:   "if !e.Email.Valid {\nbuf = append(buf, \"null\"...)\n} else {\nbuf = dml.JSONAppendString(buf, e.Email.String)\n}",
+			Decode:   "e.Email, err = value.NullString()",
+		},
+		{
+			Key:    "Price",
+			Prefix: `,"Price":`,
+			Append: "{\nb, err := e.Price.MarshalJSON()\nif err != nil {\nreturn nil, errors.WithStack(err)\n}\nbuf = append(buf, b...)\n}",
+			Decode: "e.Price, err = value.Decimal()",
+		},
+	}, jfs)
+
+	ct.nullPointers = true
+	jf, ok := ct.toJSONField(&ddl.Column{Field: `created_at`, DataType: `datetime`, Null: "YES"})
+	assert.True(t, ok)
+	assert.Exactly(t, "if e.CreatedAt == nil {\nbuf = append(buf, \"null\"...)\n} else {\nbuf = dml.JSONAppendTime(buf, *e.CreatedAt)\n}", jf.Append)
+	assert.Exactly(t, "e.CreatedAt = nil\nif !value.IsNull() {\nvar v time.Time\nv, err = value.Time()\ne.CreatedAt = &v\n}", jf.Decode)
+}
+
+func TestCustomTypes_toFaker(t *testing.T) {
+	t.Parallel()
+	ct := new(customTypes)
+	tests := []struct {
+		c    ddl.Column
+		want string
+	}{
+		{ddl.Column{Field: `email`, DataType: `varchar`, CharMaxLength: null.MakeInt64(255)}, "e.Email = dml.FakeEmail(r, 255)"},
+		{ddl.Column{Field: `firstname`, DataType: `varchar`, CharMaxLength: null.MakeInt64(64), Null: "YES"}, "if r.Intn(5) > 0 {\ne.Firstname = null.MakeString(dml.FakeName(r, 64))\n}"},
+		{ddl.Column{Field: `value`, DataType: `text`, CharMaxLength: null.MakeInt64(65535)}, "e.Value = dml.FakeString(r, 65535)"},
+		{ddl.Column{Field: `scope`, DataType: `enum`, ColumnType: `enum('default','websites')`}, `e.Scope = []string{"default", "websites"}[r.Intn(2)]`},
+		{ddl.Column{Field: `store_id`, DataType: `smallint`, ColumnType: `smallint(5) unsigned`}, "e.StoreID = dml.FakeUint64(r, 65535)"},
+		{ddl.Column{Field: `parent_id`, DataType: `int`, ColumnType: `int(10) unsigned`, Null: "YES"}, "if r.Intn(5) > 0 {\ne.ParentID = null.MakeInt64(dml.FakeInt64(r, 0, 4294967295))\n}"},
+		{ddl.Column{Field: `level`, DataType: `tinyint`, ColumnType: `tinyint(4)`}, "e.Level = dml.FakeInt64(r, -128, 127)"},
+		{ddl.Column{Field: `is_active`, DataType: `tinyint`, ColumnType: `tinyint(1)`}, "e.IsActive = r.Intn(2) == 1"},
+		{ddl.Column{Field: `weight`, DataType: `float`}, "e.Weight = r.Float64() * 1000"},
+		{ddl.Column{Field: `price`, DataType: `decimal`, Precision: null.MakeInt64(12), Scale: null.MakeInt64(4)}, "e.Price = dml.FakeDecimal(r, 12, 4)"},
+		{ddl.Column{Field: `dob`, DataType: `date`}, "e.Dob = dml.FakeTime(r).Truncate(24 * time.Hour)"},
+		{ddl.Column{Field: `hash`, DataType: `varbinary`, CharMaxLength: null.MakeInt64(32), Null: "YES"}, "if r.Intn(5) > 0 {\ne.Hash = dml.FakeBytes(r, 32)\n}"},
+	}
+	for _, test := range tests {
+		c := test.c
+		assert.Exactly(t, test.want, ct.toFaker(&c), "Column %q", c.Field)
+	}
+
+	ct.nullPointers = true
+	assert.Exactly(t, "if r.Intn(5) > 0 {\nv := dml.FakeTime(r)\ne.UpdatedAt = &v\n}",
+		ct.toFaker(&ddl.Column{Field: `updated_at`, DataType: `timestamp`, Null: "YES"}))
+	ct.dataTypes = map[string]*TypeDef{"text": {MysqlSignedNotNull: "json.RawMessage"}}
+	assert.Empty(t, ct.toFaker(&ddl.Column{Field: `payload`, DataType: `text`}))
+}
@@ -0,0 +1,146 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmltest
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/cstesting"
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQLContainerOptions configures MustStartMySQL.
+type MySQLContainerOptions struct {
+	// Image defaults to "mariadb:10.4". Any MySQL compatible image which
+	// supports the environment variables MYSQL_ROOT_PASSWORD and
+	// MYSQL_DATABASE can be used.
+	Image string
+	// Database defaults to "test".
+	Database string
+	// RootPassword defaults to "cs_test_pw".
+	RootPassword string
+	// FixturesGlob, optional, loads all DDL/DML files into the database.
+	// Files whose names contain the string "cleanup" run in the returned
+	// cleanup function, see SQLDumpLoad.
+	FixturesGlob string
+	// Docker gets passed to cstesting.StartDocker. Image, Env, Port and Ready
+	// get overwritten.
+	Docker cstesting.DockerOptions
+}
+
+// MustStartMySQL provides a MySQL/MariaDB database for integration tests and
+// returns its DSN. If the environment variable CS_DSN has been set, its DSN
+// gets used and the fixtures get loaded via SQLDumpLoad. Otherwise a
+// disposable container gets started via the docker command and the fixtures
+// get loaded with the mysql client of the container. The test gets skipped if
+// neither CS_DSN nor docker are available. The returned cleanup function
+// must be run in the defer part of a test; it removes the container. The
+// integration test for this function runs with the build tag docker.
+//		dsn, cleanup := dmltest.MustStartMySQL(t, dmltest.MySQLContainerOptions{
+//			FixturesGlob: "testdata/*.sql",
+//		})
+//		defer cleanup()
+func MustStartMySQL(t testing.TB, o MySQLContainerOptions) (dsn string, cleanup func()) {
+	t.Helper()
+
+	if dsn, err := getDSN(EnvDSN); err == nil {
+		cleanup = func() {}
+		if o.FixturesGlob != "" {
+			cleanup = SQLDumpLoad(t, o.FixturesGlob, &SQLDumpOptions{DSN: dsn})
+		}
+		return dsn, cleanup
+	}
+
+	if o.Image == "" {
+		o.Image = "mariadb:10.4"
+	}
+	if o.Database == "" {
+		o.Database = "test"
+	}
+	if o.RootPassword == "" {
+		o.RootPassword = "cs_test_pw"
+	}
+
+	cfg := mysql.NewConfig()
+	cfg.User = "root"
+	cfg.Passwd = o.RootPassword
+	cfg.Net = "tcp"
+	cfg.DBName = o.Database
+	cfg.ParseTime = true
+
+	do := o.Docker
+	do.Image = o.Image
+	do.Env = append(do.Env, "MYSQL_ROOT_PASSWORD="+o.RootPassword, "MYSQL_DATABASE="+o.Database)
+	do.Port = "3306/tcp"
+	do.Ready = func(ctx context.Context, addr string) error {
+		cfg.Addr = addr
+		db, err := sql.Open("mysql", cfg.FormatDSN())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		defer db.Close()
+		return db.PingContext(ctx)
+	}
+	c := cstesting.MustStartDocker(t, do)
+	dsn = cfg.FormatDSN()
+
+	var cleanUpFiles []string
+	if o.FixturesGlob != "" {
+		matches, err := filepath.Glob(o.FixturesGlob)
+		if err == nil && len(matches) == 0 {
+			err = errors.NotFound.Newf("No files found for glob pattern: %q", o.FixturesGlob)
+		}
+		if err != nil {
+			_ = c.Close()
+			FatalIfError(t, err)
+		}
+		for _, file := range matches {
+			if strings.Contains(file, "cleanup") {
+				cleanUpFiles = append(cleanUpFiles, file)
+			} else if err := mysqlContainerExec(c, o, file); err != nil {
+				_ = c.Close()
+				FatalIfError(t, err)
+			}
+		}
+	}
+
+	return dsn, func() {
+		for _, file := range cleanUpFiles {
+			if err := mysqlContainerExec(c, o, file); err != nil {
+				t.Errorf("%+v", err)
+			}
+		}
+		if err := c.Close(); err != nil {
+			t.Errorf("%+v", err)
+		}
+	}
+}
+
+// mysqlContainerExec pipes a SQL file into the mysql client of the container.
+func mysqlContainerExec(c *cstesting.DockerContainer, o MySQLContainerOptions, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+	_, err = c.Exec(context.TODO(), f, "mysql", "-uroot", "-p"+o.RootPassword, o.Database)
+	return errors.Wrapf(err, "[dmltest] MustStartMySQL failed to load file %q", file)
+}
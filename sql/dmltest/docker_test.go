@@ -0,0 +1,41 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build csall docker
+
+package dmltest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestMustStartMySQL(t *testing.T) {
+	dsn, cleanup := dmltest.MustStartMySQL(t, dmltest.MySQLContainerOptions{
+		FixturesGlob: "testdata/docker/*.sql",
+	})
+	defer cleanup()
+
+	dbc := dml.MustConnectAndVerify(dml.WithDSN(dsn))
+	defer dmltest.Close(t, dbc)
+
+	var count int
+	err := dbc.DB.QueryRowContext(context.Background(), "SELECT COUNT(*) FROM `dmltest_docker` WHERE `name` LIKE 'Go%'").Scan(&count)
+	assert.NoError(t, err)
+	assert.Exactly(t, 1, count)
+}
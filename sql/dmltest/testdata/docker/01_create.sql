@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS `dmltest_docker` (
+  `id` INT UNSIGNED NOT NULL AUTO_INCREMENT,
+  `name` VARCHAR(64) NOT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+INSERT INTO `dmltest_docker` (`name`) VALUES ('Gopher'), ('Rustacean');
@@ -0,0 +1,440 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+	"github.com/corestoreio/pkg/sql/binlogsync"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dml"
+)
+
+// OnlineAlterCanal defines the functions of *binlogsync.Canal used by
+// OnlineAlter. The canal must already be running.
+type OnlineAlterCanal interface {
+	RegisterRowsEventHandler(tableName string, h ...binlogsync.RowsEventHandler)
+	UnregisterRowsEventHandler(tableName string, h ...binlogsync.RowsEventHandler)
+	CatchMasterPos(timeout time.Duration) error
+}
+
+// OnlineAlter applies an ALTER TABLE statement to a large table without
+// locking it for the duration of the change, similar to gh-ost. The steps are:
+//	1. Create the shadow table `_<table>_gho` and apply the ALTER to it.
+//	2. Apply all binlog row events of the table to the shadow table.
+//	3. Copy the rows in chunks of the primary key into the shadow table.
+//	4. Lock the table, wait until the binlog has been applied and swap both
+//	   tables atomically with RENAME TABLE. The old table gets renamed to
+//	   `_<table>_del`.
+// The table must have a single integer primary key. Columns removed by the
+// ALTER get not copied, added columns receive their default value.
+type OnlineAlter struct {
+	DB    *dml.ConnPool
+	Canal OnlineAlterCanal
+	// Table contains the name, the columns and the primary key of the table.
+	Table *ddl.Table
+	// Alter contains the alter specification, e.g. "ADD COLUMN `note` text".
+	Alter string
+	// ChunkSize defines the number of rows copied per statement. Default 1000.
+	ChunkSize uint64
+	// CutOverTimeout defines how long the table gets locked to wait for the
+	// binlog during the cut-over. Default 10s.
+	CutOverTimeout time.Duration
+	// KeepOld keeps the old table `_<table>_del` after the cut-over.
+	KeepOld bool
+	Log     log.Logger
+}
+
+// ShadowTableName returns the name of the table to which the ALTER gets
+// applied.
+func (oa *OnlineAlter) ShadowTableName() string {
+	return "_" + oa.Table.Name + "_gho"
+}
+
+// OldTableName returns the name of the original table after the cut-over.
+func (oa *OnlineAlter) OldTableName() string {
+	return "_" + oa.Table.Name + "_del"
+}
+
+// Run executes the online schema change. In case of an error the original
+// table stays untouched and the shadow table must be dropped manually.
+func (oa *OnlineAlter) Run(ctx context.Context) error {
+	if oa.Log == nil {
+		oa.Log = log.BlackHole{}
+	}
+	if oa.ChunkSize == 0 {
+		oa.ChunkSize = 1000
+	}
+	if oa.CutOverTimeout == 0 {
+		oa.CutOverTimeout = 10 * time.Second
+	}
+	pk, err := oa.validate()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	pkUnsigned := oa.Table.Columns.PrimaryKeys()[0].IsUnsigned()
+	shadow := oa.ShadowTableName()
+
+	if err := oa.exec(ctx, "CREATE TABLE "+dml.Quoter.Name(shadow)+" LIKE "+dml.Quoter.Name(oa.Table.Name)); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := oa.exec(ctx, "ALTER TABLE "+dml.Quoter.Name(shadow)+" "+oa.Alter); err != nil {
+		return errors.WithStack(err)
+	}
+
+	columns, err := oa.sharedColumns(ctx, pk)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	h := &onlineAlterHandler{
+		db:      oa.DB,
+		shadow:  shadow,
+		pk:      pk,
+		columns: columns,
+	}
+	oa.Canal.RegisterRowsEventHandler(oa.Table.Name, h)
+	defer oa.Canal.UnregisterRowsEventHandler(oa.Table.Name, h)
+
+	if err := oa.copyRows(ctx, pk, pkUnsigned, columns); err != nil {
+		return errors.WithStack(err)
+	}
+	// catch up without a lock to keep the cut-over short.
+	if err := oa.Canal.CatchMasterPos(oa.CutOverTimeout); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := h.Err(); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := oa.cutOver(ctx, h); err != nil {
+		return errors.WithStack(err)
+	}
+	if !oa.KeepOld {
+		return errors.WithStack(oa.exec(ctx, "DROP TABLE IF EXISTS "+dml.Quoter.Name(oa.OldTableName())))
+	}
+	return nil
+}
+
+func (oa *OnlineAlter) validate() (string, error) {
+	switch {
+	case oa.DB == nil || oa.Canal == nil || oa.Table == nil:
+		return "", errors.Empty.Newf("[migration] OnlineAlter requires the fields DB, Canal and Table")
+	case strings.TrimSpace(oa.Alter) == "":
+		return "", errors.Empty.Newf("[migration] OnlineAlter for table %q requires an Alter specification", oa.Table.Name)
+	}
+	if err := dml.IsValidIdentifier(oa.OldTableName()); err != nil {
+		return "", errors.WithStack(err)
+	}
+	pks := oa.Table.Columns.PrimaryKeys()
+	if len(pks) != 1 {
+		return "", errors.NotSupported.Newf("[migration] OnlineAlter table %q requires exactly one primary key column, got %d", oa.Table.Name, len(pks))
+	}
+	switch pks[0].DataType {
+	case "tinyint", "smallint", "mediumint", "int", "bigint":
+	default:
+		return "", errors.NotSupported.Newf("[migration] OnlineAlter table %q requires an integer primary key, got %q", oa.Table.Name, pks[0].DataType)
+	}
+	return pks[0].Field, nil
+}
+
+func (oa *OnlineAlter) exec(ctx context.Context, query string) error {
+	if oa.Log.IsDebug() {
+		oa.Log.Debug("migration.OnlineAlter.exec", log.String("table", oa.Table.Name), log.String("query", query))
+	}
+	_, err := oa.DB.DB.ExecContext(ctx, query)
+	return errors.Wrapf(err, "[migration] OnlineAlter failed to execute %q", query)
+}
+
+// sharedColumns returns the columns which exist in the original and the
+// shadow table. Generated columns get skipped.
+func (oa *OnlineAlter) sharedColumns(ctx context.Context, pk string) ([]string, error) {
+	shadow := oa.ShadowTableName()
+	tc, err := ddl.LoadColumns(ctx, oa.DB.DB, shadow)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	shadowCols := tc[shadow]
+	if !shadowCols.Contains(pk) {
+		return nil, errors.NotSupported.Newf("[migration] OnlineAlter cannot drop or rename the primary key %q of table %q", pk, oa.Table.Name)
+	}
+	columns := make([]string, 0, len(oa.Table.Columns))
+	for _, c := range oa.Table.Columns {
+		if sc := shadowCols.ByField(c.Field); sc.Field != "" && !c.IsGenerated() && !sc.IsGenerated() {
+			columns = append(columns, c.Field)
+		}
+	}
+	return columns, nil
+}
+
+// copyRows copies the rows with INSERT IGNORE, so rows already written by the
+// binlog handler have precedence. The chunks start at the smallest primary key
+// and include their lower bound.
+func (oa *OnlineAlter) copyRows(ctx context.Context, pk string, pkUnsigned bool, columns []string) error {
+	selMin := oa.DB.SelectFrom(oa.Table.Name).AddColumnsConditions(dml.Expr("MIN(" + dml.Quoter.Name(pk) + ")"))
+
+	selBound := oa.DB.SelectFrom(oa.Table.Name).AddColumns(pk).
+		Where(dml.Column(pk).GreaterOrEqual().PlaceHolder()).
+		OrderBy(pk).
+		Limit(oa.ChunkSize, 1)
+
+	insChunk := oa.DB.InsertInto(oa.ShadowTableName()).Ignore().AddColumns(columns...).
+		FromSelect(dml.NewSelect(columns...).From(oa.Table.Name).
+			Where(dml.Column(pk).GreaterOrEqual().PlaceHolder(), dml.Column(pk).Less().PlaceHolder()))
+
+	insLast := oa.DB.InsertInto(oa.ShadowTableName()).Ignore().AddColumns(columns...).
+		FromSelect(dml.NewSelect(columns...).From(oa.Table.Name).
+			Where(dml.Column(pk).GreaterOrEqual().PlaceHolder()))
+
+	// loadPK returns the primary key as int64 or, for BIGINT UNSIGNED, as
+	// uint64 to avoid an overflow.
+	loadPK := func(a *dml.Artisan, args ...interface{}) (interface{}, bool, error) {
+		if pkUnsigned {
+			v, found, err := a.LoadNullUint64(ctx, args...)
+			return v.Uint64, found && v.Valid, errors.WithStack(err)
+		}
+		v, found, err := a.LoadNullInt64(ctx, args...)
+		return v.Int64, found && v.Valid, errors.WithStack(err)
+	}
+
+	lower, found, err := loadPK(selMin.WithArgs())
+	if err != nil || !found {
+		return errors.WithStack(err) // empty table
+	}
+	for {
+		upper, found, err := loadPK(selBound.WithArgs(), lower)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if !found {
+			_, err = insLast.WithArgs().ExecContext(ctx, lower)
+			return errors.WithStack(err)
+		}
+		if _, err = insChunk.WithArgs().ExecContext(ctx, lower, upper); err != nil {
+			return errors.WithStack(err)
+		}
+		if oa.Log.IsDebug() {
+			oa.Log.Debug("migration.OnlineAlter.copyRows", log.String("table", oa.Table.Name), log.Object("lower", lower), log.Object("upper", upper))
+		}
+		lower = upper
+	}
+}
+
+// cutOver swaps the tables atomically. Connection one locks the original
+// table and the sentry table `_<table>_del`, waits until the binlog has been
+// applied and stops the handler. Connection two issues the RENAME, which blocks
+// until connection one drops the sentry and unlocks the tables. A RENAME has
+// precedence over other blocked statements. On abort the tables get unlocked
+// while the sentry still exists, so a waiting RENAME fails and the original
+// table stays in place.
+func (oa *OnlineAlter) cutOver(ctx context.Context, h *onlineAlterHandler) (err error) {
+	table := dml.Quoter.Name(oa.Table.Name)
+	old := dml.Quoter.Name(oa.OldTableName())
+
+	conn, err := oa.DB.Conn(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err2 := conn.Close(); err == nil && err2 != nil {
+			err = errors.WithStack(err2)
+		}
+	}()
+	connExec := func(query string) error {
+		_, err := conn.DB.ExecContext(ctx, query)
+		return errors.Wrapf(err, "[migration] OnlineAlter failed to execute %q", query)
+	}
+
+	if err := connExec("CREATE TABLE " + old + " (id INT) ENGINE=InnoDB COMMENT='OnlineAlter sentry'"); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := connExec("LOCK TABLES " + table + " WRITE, " + old + " WRITE"); err != nil {
+		return errors.WithStack(err)
+	}
+	var renamed chan error
+	abort := func(err error) error {
+		// ctx might already be canceled but the lock must be released.
+		bgCtx := context.Background()
+		_, _ = conn.DB.ExecContext(bgCtx, "UNLOCK TABLES")
+		if renamed != nil {
+			<-renamed
+		}
+		_, _ = conn.DB.ExecContext(bgCtx, "DROP TABLE IF EXISTS "+old)
+		return errors.WithStack(err)
+	}
+
+	if err := oa.Canal.CatchMasterPos(oa.CutOverTimeout); err != nil {
+		return abort(err)
+	}
+	h.stop()
+	if err := h.Err(); err != nil {
+		return abort(err)
+	}
+
+	rename := "RENAME TABLE " + table + " TO " + old + ", " + dml.Quoter.Name(oa.ShadowTableName()) + " TO " + table
+	renamed = make(chan error, 1)
+	go func() {
+		renamed <- oa.exec(ctx, rename)
+	}()
+	if err := oa.waitForRename(ctx, conn, rename); err != nil {
+		return abort(err)
+	}
+
+	if err := connExec("DROP TABLE " + old); err != nil {
+		return abort(err)
+	}
+	if err := connExec("UNLOCK TABLES"); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(<-renamed)
+}
+
+// waitForRename polls the process list until the RENAME statement waits for
+// the metadata lock.
+func (oa *OnlineAlter) waitForRename(ctx context.Context, conn *dml.Conn, rename string) error {
+	const selRename = `SELECT COUNT(*) FROM information_schema.PROCESSLIST WHERE STATE LIKE 'Waiting for table metadata lock' AND INFO = ?`
+	deadline := time.Now().Add(oa.CutOverTimeout)
+	for {
+		var count int64
+		if err := conn.DB.QueryRowContext(ctx, selRename, rename).Scan(&count); err != nil {
+			return errors.WithStack(err)
+		}
+		if count > 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Timeout.Newf("[migration] OnlineAlter RENAME of table %q did not start within %s", oa.Table.Name, oa.CutOverTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return errors.WithStack(ctx.Err())
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+// onlineAlterHandler applies the binlog row events to the shadow table.
+type onlineAlterHandler struct {
+	db      *dml.ConnPool
+	shadow  string
+	pk      string
+	columns []string
+	stopped int32
+
+	mu  sync.Mutex
+	err error
+}
+
+func (h *onlineAlterHandler) stop() {
+	atomic.StoreInt32(&h.stopped, 1)
+}
+
+// Err returns the first error which occurred while applying an event.
+func (h *onlineAlterHandler) Err() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.err
+}
+
+// Do implements binlogsync.RowsEventHandler. Errors get stored because the
+// canal only logs them.
+func (h *onlineAlterHandler) Do(ctx context.Context, action string, t *ddl.Table, rows [][]interface{}) error {
+	if atomic.LoadInt32(&h.stopped) == 1 {
+		return nil
+	}
+	err := h.apply(ctx, action, t, rows)
+	if err != nil {
+		h.mu.Lock()
+		if h.err == nil {
+			h.err = err
+		}
+		h.mu.Unlock()
+	}
+	return errors.WithStack(err)
+}
+
+func (h *onlineAlterHandler) apply(ctx context.Context, action string, t *ddl.Table, rows [][]interface{}) error {
+	pkIdx := -1
+	colIdx := make([]int, len(h.columns))
+	for i, c := range h.columns {
+		colIdx[i] = -1
+		for j, tc := range t.Columns {
+			if tc.Field == c {
+				colIdx[i] = j
+			}
+		}
+		if colIdx[i] < 0 {
+			return errors.NotFound.Newf("[migration] OnlineAlter column %q not found in binlog table %q", c, t.Name)
+		}
+		if c == h.pk {
+			pkIdx = colIdx[i]
+		}
+	}
+
+	replace := h.db.ReplaceInto(h.shadow).AddColumns(h.columns...).BuildValues()
+	del := h.db.DeleteFrom(h.shadow).Where(dml.Column(h.pk).Equal().PlaceHolder())
+	replaceRow := func(row []interface{}) error {
+		args := make([]interface{}, len(colIdx))
+		for i, idx := range colIdx {
+			args[i] = row[idx]
+		}
+		_, err := replace.WithArgs().ExecContext(ctx, args...)
+		return errors.WithStack(err)
+	}
+	deleteRow := func(row []interface{}) error {
+		_, err := del.WithArgs().ExecContext(ctx, row[pkIdx])
+		return errors.WithStack(err)
+	}
+
+	switch action {
+	case binlogsync.InsertAction:
+		for _, row := range rows {
+			if err := replaceRow(row); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	case binlogsync.UpdateAction:
+		// rows contains pairs of the before and after image.
+		for i := 0; i+1 < len(rows); i += 2 {
+			before, after := rows[i], rows[i+1]
+			if before[pkIdx] != after[pkIdx] {
+				if err := deleteRow(before); err != nil {
+					return errors.WithStack(err)
+				}
+			}
+			if err := replaceRow(after); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	case binlogsync.DeleteAction:
+		for _, row := range rows {
+			if err := deleteRow(row); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+	return nil
+}
+
+// Complete implements binlogsync.RowsEventHandler.
+func (h *onlineAlterHandler) Complete(context.Context) error { return nil }
+
+// String implements binlogsync.RowsEventHandler.
+func (h *onlineAlterHandler) String() string { return "migration.OnlineAlter." + h.shadow }
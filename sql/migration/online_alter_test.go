@@ -0,0 +1,169 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/binlogsync"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/sql/migration"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+// fakeCanal emits the rows as insert event during the first catch up.
+type fakeCanal struct {
+	table    *ddl.Table
+	rows     [][]interface{}
+	handlers []binlogsync.RowsEventHandler
+	calls    int
+
+	unregistered []binlogsync.RowsEventHandler
+}
+
+func (fc *fakeCanal) RegisterRowsEventHandler(_ string, h ...binlogsync.RowsEventHandler) {
+	fc.handlers = append(fc.handlers, h...)
+}
+
+func (fc *fakeCanal) UnregisterRowsEventHandler(_ string, h ...binlogsync.RowsEventHandler) {
+	fc.unregistered = append(fc.unregistered, h...)
+}
+
+func (fc *fakeCanal) CatchMasterPos(time.Duration) error {
+	fc.calls++
+	if fc.calls > 1 {
+		return nil
+	}
+	for _, h := range fc.handlers {
+		if err := h.Do(context.Background(), binlogsync.InsertAction, fc.table, fc.rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newCustomerTable() *ddl.Table {
+	return ddl.NewTable("customer_entity",
+		&ddl.Column{Field: "entity_id", DataType: "int", ColumnType: "int(10) unsigned", Null: "NO", Key: "PRI", Extra: "auto_increment"},
+		&ddl.Column{Field: "email", DataType: "varchar", ColumnType: "varchar(255)", Null: "YES"},
+		&ddl.Column{Field: "old_col", DataType: "text", ColumnType: "text", Null: "YES"},
+	)
+}
+
+func TestOnlineAlter_Run(t *testing.T) {
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+	// The RENAME runs concurrently to the polling of the process list.
+	dbMock.MatchExpectationsInOrder(false)
+
+	tbl := newCustomerTable()
+	fc := &fakeCanal{
+		table: tbl,
+		rows:  [][]interface{}{{int64(3), "c@d.e", "old"}},
+	}
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TABLE `_customer_entity_gho` LIKE `customer_entity`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `_customer_entity_gho` DROP COLUMN `old_col`, ADD COLUMN `note` text")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectQuery("SELECT.+FROM information_schema.COLUMNS WHERE TABLE_SCHEMA=DATABASE\\(\\) AND TABLE_NAME IN \\('_customer_entity_gho'\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "COLUMN_NAME", "ORDINAL_POSITION", "COLUMN_DEFAULT", "IS_NULLABLE", "DATA_TYPE", "CHARACTER_MAXIMUM_LENGTH", "NUMERIC_PRECISION", "NUMERIC_SCALE", "COLUMN_TYPE", "COLUMN_KEY", "EXTRA", "COLUMN_COMMENT"}).
+			FromCSVString(`"_customer_entity_gho","entity_id",1,NULL,"NO","int",NULL,10,0,"int(10) unsigned","PRI","auto_increment",""
+"_customer_entity_gho","email",2,NULL,"YES","varchar",255,NULL,NULL,"varchar(255)","","",""
+"_customer_entity_gho","note",3,NULL,"YES","text",65535,NULL,NULL,"text","","",""
+`))
+
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT MIN(`entity_id`) FROM `customer_entity`")).
+		WillReturnRows(sqlmock.NewRows([]string{"MIN(`entity_id`)"}).AddRow(1))
+	const selBound = "SELECT `entity_id` FROM `customer_entity` WHERE (`entity_id` >= ?) ORDER BY `entity_id` LIMIT 2,1"
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(selBound)).WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"entity_id"}).AddRow(3))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT IGNORE INTO `_customer_entity_gho` (`entity_id`,`email`) SELECT `entity_id`, `email` FROM `customer_entity` WHERE (`entity_id` >= ?) AND (`entity_id` < ?)")).
+		WithArgs(int64(1), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(selBound)).WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT IGNORE INTO `_customer_entity_gho` (`entity_id`,`email`) SELECT `entity_id`, `email` FROM `customer_entity` WHERE (`entity_id` >= ?)")).
+		WithArgs(int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("REPLACE INTO `_customer_entity_gho` (`entity_id`,`email`) VALUES (?,?)")).
+		WithArgs(int64(3), "c@d.e").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TABLE `_customer_entity_del` (id INT) ENGINE=InnoDB COMMENT='OnlineAlter sentry'")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("LOCK TABLES `customer_entity` WRITE, `_customer_entity_del` WRITE")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	const rename = "RENAME TABLE `customer_entity` TO `_customer_entity_del`, `_customer_entity_gho` TO `customer_entity`"
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta(rename)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT COUNT(*) FROM information_schema.PROCESSLIST WHERE STATE LIKE 'Waiting for table metadata lock' AND INFO = ?")).
+		WithArgs(rename).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TABLE `_customer_entity_del`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("UNLOCK TABLES")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TABLE IF EXISTS `_customer_entity_del`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	oa := &migration.OnlineAlter{
+		DB:        dbc,
+		Canal:     fc,
+		Table:     tbl,
+		Alter:     "DROP COLUMN `old_col`, ADD COLUMN `note` text",
+		ChunkSize: 2,
+	}
+	assert.NoError(t, oa.Run(context.TODO()))
+	assert.Exactly(t, 2, fc.calls)
+	assert.Exactly(t, fc.handlers, fc.unregistered)
+}
+
+func TestOnlineAlter_Validate(t *testing.T) {
+	t.Parallel()
+
+	runner := func(oa *migration.OnlineAlter, wantKind errors.Kind) func(*testing.T) {
+		return func(t *testing.T) {
+			err := oa.Run(context.TODO())
+			assert.True(t, wantKind.Match(err), "%+v", err)
+		}
+	}
+
+	t.Run("missing fields", runner(&migration.OnlineAlter{}, errors.Empty))
+	t.Run("missing alter", runner(&migration.OnlineAlter{
+		DB: new(dml.ConnPool), Canal: &fakeCanal{}, Table: newCustomerTable(),
+	}, errors.Empty))
+
+	compositePK := newCustomerTable()
+	compositePK.Columns[1].Key = "PRI"
+	t.Run("composite primary key", runner(&migration.OnlineAlter{
+		DB: new(dml.ConnPool), Canal: &fakeCanal{}, Table: compositePK, Alter: "ADD COLUMN `note` text",
+	}, errors.NotSupported))
+
+	t.Run("varchar primary key", runner(&migration.OnlineAlter{
+		DB: new(dml.ConnPool), Canal: &fakeCanal{},
+		Table: ddl.NewTable("store",
+			&ddl.Column{Field: "code", DataType: "varchar", ColumnType: "varchar(32)", Null: "NO", Key: "PRI"},
+		),
+		Alter: "ADD COLUMN `note` text",
+	}, errors.NotSupported))
+}
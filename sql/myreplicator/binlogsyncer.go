@@ -46,6 +46,11 @@ type BinlogSyncerConfig struct {
 	// RawModeEanbled is for not parsing binlog event.
 	RawModeEanbled bool
 
+	// VerifyChecksum verifies the CRC32 checksum of each event if the binlog
+	// has been written with binlog_checksum=CRC32. A mismatch returns an error
+	// with behaviour Mismatch.
+	VerifyChecksum bool
+
 	Log log.Logger
 	// TLSConfig if not nil, use the provided tls.Config to connect to the
 	// database using TLS/SSL.
@@ -86,6 +91,7 @@ func NewBinlogSyncer(cfg *BinlogSyncerConfig) *BinlogSyncer {
 		parser: NewBinlogParser(),
 	}
 	b.parser.SetRawMode(b.cfg.RawModeEanbled)
+	b.parser.SetVerifyChecksum(b.cfg.VerifyChecksum)
 	b.ctx, b.cancel = context.WithCancel(context.Background())
 
 	return b
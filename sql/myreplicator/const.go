@@ -183,3 +183,12 @@ const (
 	BINLOG_CHECKSUM_ALG_UNDEF byte = 255 // special value to tag undetermined yet checksum
 	// or events from checksum-unaware servers
 )
+
+const (
+	// BINLOG_MARIADB_FL_STANDALONE marks a MariaDB GTID event group without a
+	// transaction, for example a DDL statement.
+	BINLOG_MARIADB_FL_STANDALONE byte = 1
+	// BINLOG_MARIADB_FL_GROUP_COMMIT_ID indicates that the GTID event contains
+	// a commit ID.
+	BINLOG_MARIADB_FL_GROUP_COMMIT_ID byte = 2
+)
@@ -441,20 +441,31 @@ func (e *MariadbBinlogCheckPointEvent) Dump(w io.Writer) {
 }
 
 type MariadbGTIDEvent struct {
-	GTID mysql.MariadbGTID
+	GTID  mysql.MariadbGTID
+	Flags byte
 }
 
 func (e *MariadbGTIDEvent) decode(data []byte) error {
 	e.GTID.SequenceNumber = binary.LittleEndian.Uint64(data)
 	e.GTID.DomainID = binary.LittleEndian.Uint32(data[8:])
+	if len(data) > 12 {
+		e.Flags = data[12]
+	}
 
 	// we don't care commit id now, maybe later
 
 	return nil
 }
 
+// IsStandalone reports whether the event group contains no transaction, like a
+// DDL statement. Otherwise the group ends with a XID or COMMIT event.
+func (e *MariadbGTIDEvent) IsStandalone() bool {
+	return e.Flags&BINLOG_MARIADB_FL_STANDALONE != 0
+}
+
 func (e *MariadbGTIDEvent) Dump(w io.Writer) {
 	fmt.Fprintf(w, "GTID: %v\n", e.GTID)
+	fmt.Fprintf(w, "Flags: %d\n", e.Flags)
 	fmt.Fprintln(w)
 }
 
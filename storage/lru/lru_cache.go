@@ -43,6 +43,7 @@ type LRUCache struct {
 
 	size      int64
 	capacity  int64
+	maxLength int64
 	evictions int64
 }
 
@@ -163,6 +164,17 @@ func (lru *LRUCache) SetCapacity(capacity int64) {
 	lru.checkCapacity()
 }
 
+// SetMaxLength limits the number of items in the cache additionally to the
+// capacity. Zero disables the limit. If the cache contains more items, the
+// least recently used items get evicted.
+func (lru *LRUCache) SetMaxLength(maxLength int64) {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+
+	lru.maxLength = maxLength
+	lru.checkCapacity()
+}
+
 // Stats returns a few stats on the cache.
 func (lru *LRUCache) Stats() (length, size, capacity, evictions int64, oldest time.Time) {
 	lru.mu.Lock()
@@ -273,7 +285,7 @@ func (lru *LRUCache) addNew(key string, value Value) {
 
 func (lru *LRUCache) checkCapacity() {
 	// Partially duplicated from Delete
-	for lru.size > lru.capacity {
+	for lru.size > lru.capacity || (lru.maxLength > 0 && int64(lru.list.Len()) > lru.maxLength) {
 		delElem := lru.list.Back()
 		delValue := delElem.Value.(*entry)
 		lru.list.Remove(delElem)
@@ -267,6 +267,26 @@ func TestCapacityIsObeyed(t *testing.T) {
 	}
 }
 
+func TestMaxLengthIsObeyed(t *testing.T) {
+	cache := NewLRUCache(100)
+	cache.Set("key1", &CacheValue{10})
+	cache.Set("key2", &CacheValue{10})
+	cache.Set("key3", &CacheValue{10})
+
+	cache.SetMaxLength(2)
+	if l := cache.Length(); l != 2 {
+		t.Errorf("cache.Length() = %v, expected 2", l)
+	}
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("Least recently used element was not evicted.")
+	}
+
+	cache.Set("key4", &CacheValue{10})
+	if _, sz, _, evictions, _ := cache.Stats(); sz != 20 || evictions != 2 {
+		t.Errorf("cache.Size() = %v, cache.evictions = %v, expected 20 and 2", sz, evictions)
+	}
+}
+
 func TestLRUIsEvicted(t *testing.T) {
 	size := int64(3)
 	cache := NewLRUCache(size)
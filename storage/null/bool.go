@@ -110,7 +110,8 @@ func (a *Bool) UnmarshalJSON(data []byte) error {
 		}{}
 		err = JSONUnMarshalFn(data, dto)
 		a.Bool = dto.Bool
-		a.Valid = dto.Valid
+		a.Valid = dto.Valid && err == nil
+		return err
 	case nil:
 		a.Valid = false
 		return nil
@@ -421,6 +421,24 @@ func (d Decimal) Value() (driver.Value, error) {
 	return d.String(), nil
 }
 
+// WriteTo uses a special dialect to encode the value and write it into w. w
+// cannot be replaced by io.Writer and shall not be replaced by an interface
+// because of inlining features of the compiler. The decimal gets written
+// unquoted to retain its precision.
+func (d Decimal) WriteTo(_ Dialecter, w *bytes.Buffer) error {
+	d.string(w)
+	return nil
+}
+
+// Append appends the value or its nil type to the interface slice. The value
+// gets appended as a string to retain the precision.
+func (d Decimal) Append(args []interface{}) []interface{} {
+	if d.Valid {
+		return append(args, d.String())
+	}
+	return append(args, nil)
+}
+
 // UnmarshalText implements the encoding.TextUnmarshaler interface for XML
 // deserialization.
 func (d *Decimal) UnmarshalText(text []byte) (err error) {
@@ -434,6 +452,9 @@ func (d *Decimal) UnmarshalText(text []byte) (err error) {
 // serialization. Does not support quoting. An invalid type returns an empty
 // string.
 func (d Decimal) MarshalText() (text []byte, err error) {
+	if !d.Valid {
+		return []byte{}, nil
+	}
 	var buf bytes.Buffer
 	d.string(&buf)
 	return buf.Bytes(), nil
@@ -15,6 +15,7 @@
 package null
 
 import (
+	"bytes"
 	"database/sql"
 	"database/sql/driver"
 	"encoding"
@@ -335,7 +336,7 @@ func TestDecimal_MarshalText(t *testing.T) {
 
 	// TODO: Fuzzy testing
 
-	t.Run("not valid", runner(Decimal{}, "NULL"))
+	t.Run("not valid", runner(Decimal{}, ""))
 
 	t.Run("quoted", runner(Decimal{
 		Valid:     true,
@@ -530,3 +531,18 @@ func TestDecimal_Equal(t *testing.T) {
 		assert.False(t, a.Equal(b))
 	})
 }
+
+func TestDecimal_WriteTo_Append(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT ")
+	assert.NoError(t, Decimal{Precision: 1234, Scale: 2, Negative: true, Valid: true}.WriteTo(nil, &buf))
+	buf.WriteString(", ")
+	assert.NoError(t, Decimal{}.WriteTo(nil, &buf))
+	assert.Exactly(t, "SELECT -12.34, NULL", buf.String())
+
+	args := Decimal{Precision: 1234, Scale: 2, Valid: true}.Append(nil)
+	args = Decimal{}.Append(args)
+	assert.Exactly(t, []interface{}{"12.34", nil}, args)
+}
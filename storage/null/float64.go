@@ -117,7 +117,8 @@ func (a *Float64) UnmarshalJSON(data []byte) error {
 		}{}
 		err = JSONUnMarshalFn(data, dto)
 		a.Float64 = dto.Float64
-		a.Valid = dto.Valid
+		a.Valid = dto.Valid && err == nil
+		return err
 	case nil:
 		a.Valid = false
 		return nil
@@ -133,7 +134,7 @@ func (a *Float64) UnmarshalJSON(data []byte) error {
 // It will return an error if the input is not an integer, blank, or "null".
 func (a *Float64) UnmarshalText(text []byte) error {
 	str := string(text)
-	if str == "" || str == sqlStrNullLC {
+	if str == "" || str == sqlStrNullLC || str == sqlStrNullUC {
 		a.Valid = false
 		return nil
 	}
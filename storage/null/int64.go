@@ -112,7 +112,8 @@ func (a *Int64) UnmarshalJSON(data []byte) error {
 		}{}
 		err = JSONUnMarshalFn(data, dto)
 		a.Int64 = dto.Int64
-		a.Valid = dto.Valid
+		a.Valid = dto.Valid && err == nil
+		return err
 	case nil:
 		a.Valid = false
 		return nil
@@ -128,7 +129,7 @@ func (a *Int64) UnmarshalJSON(data []byte) error {
 // It will return an error if the input is not an integer, blank, or sqlStrNullLC.
 func (a *Int64) UnmarshalText(text []byte) error {
 	str := string(text)
-	if str == "" || str == sqlStrNullLC {
+	if str == "" || str == sqlStrNullLC || str == sqlStrNullUC {
 		a.Valid = false
 		return nil
 	}
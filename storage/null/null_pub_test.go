@@ -16,6 +16,7 @@ package null_test
 
 import (
 	"context"
+	"encoding"
 	"encoding/json"
 	"testing"
 	"time"
@@ -154,6 +155,48 @@ func TestNullTypeJSONMarshal(t *testing.T) {
 	}
 }
 
+func TestNullTypeJSONUnmarshal_Object(t *testing.T) {
+
+	// the object notation of the database/sql types must respect the Valid field.
+	rec := &nullTypedRecord{}
+	err := json.Unmarshal([]byte(`{"StringVal":{"String":"","Valid":false},"Int64Val":{"Int64":0,"Valid":false},"Float64Val":{"Float64":0,"Valid":false},"BoolVal":{"Bool":false,"Valid":false}}`), rec)
+	assert.NoError(t, err)
+	assert.Equal(t, &nullTypedRecord{}, rec)
+
+	err = json.Unmarshal([]byte(`{"StringVal":{"String":"wow","Valid":true},"Int64Val":{"Int64":42,"Valid":true},"Float64Val":{"Float64":1.618,"Valid":true},"BoolVal":{"Bool":true,"Valid":true}}`), rec)
+	assert.NoError(t, err)
+	assert.Equal(t, &nullTypedRecord{
+		StringVal:  null.MakeString("wow"),
+		Int64Val:   null.MakeInt64(42),
+		Float64Val: null.MakeFloat64(1.618),
+		BoolVal:    null.MakeBool(true),
+	}, rec)
+
+	var u null.Uint64
+	assert.NoError(t, json.Unmarshal([]byte(`{"Uint64":18446744073709551615,"Valid":true}`), &u))
+	assert.Exactly(t, null.MakeUint64(18446744073709551615), u)
+}
+
+func TestNullTypeTextMarshal(t *testing.T) {
+
+	// all null types encode NULL as an empty text.
+	for _, m := range []encoding.TextMarshaler{
+		null.String{}, null.Int64{}, null.Uint64{}, null.Float64{}, null.Time{}, null.Bool{}, null.Decimal{},
+	} {
+		txt, err := m.MarshalText()
+		assert.NoError(t, err)
+		assert.Exactly(t, "", string(txt), "%T", m)
+	}
+
+	for _, u := range []encoding.TextUnmarshaler{
+		&null.Int64{}, &null.Uint64{}, &null.Float64{}, &null.Time{}, &null.Bool{}, &null.Decimal{},
+	} {
+		for _, txt := range []string{"", "null", "NULL"} {
+			assert.NoError(t, u.UnmarshalText([]byte(txt)), "%T with %q", u, txt)
+		}
+	}
+}
+
 var _ dml.ColumnMapper = (*nullTypedRecord)(nil)
 
 type nullTypedRecord struct {
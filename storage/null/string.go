@@ -107,7 +107,8 @@ func (a *String) UnmarshalJSON(data []byte) error {
 		}{}
 		err = JSONUnMarshalFn(data, dto)
 		a.String = dto.String
-		a.Valid = dto.Valid
+		a.Valid = dto.Valid && err == nil
+		return err
 	case nil:
 		a.Valid = false
 		return nil
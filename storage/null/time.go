@@ -93,9 +93,10 @@ func (nt *Time) UnmarshalJSON(data []byte) error {
 }
 
 // MarshalText transforms the time type into a byte slice.
+// It will encode a blank string if this Time is null.
 func (nt Time) MarshalText() ([]byte, error) {
 	if !nt.Valid {
-		return []byte(sqlStrNullLC), nil
+		return []byte{}, nil
 	}
 	return nt.Time.MarshalText()
 }
@@ -103,7 +104,7 @@ func (nt Time) MarshalText() ([]byte, error) {
 // UnmarshalText parses the byte slice to create a time type.
 func (nt *Time) UnmarshalText(text []byte) error {
 	str := string(text)
-	if str == "" || str == sqlStrNullLC {
+	if str == "" || str == sqlStrNullLC || str == sqlStrNullUC {
 		nt.Valid = false
 		return nil
 	}
@@ -118,7 +118,7 @@ func TestNullTime_UnmarshalText(t *testing.T) {
 	assertNullTime(t, null, "unmarshal null text")
 	txt, err = null.MarshalText()
 	maybePanic(err)
-	assertJSONEquals(t, txt, string(nullJSON), "marshal null text")
+	assertJSONEquals(t, txt, "", "marshal null text")
 
 	var invalid Time
 	err = invalid.UnmarshalText([]byte("hello world"))
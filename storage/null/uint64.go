@@ -106,12 +106,13 @@ func (a *Uint64) UnmarshalJSON(data []byte) error {
 		err = JSONUnMarshalFn(data, &a.Uint64)
 	case map[string]interface{}:
 		dto := &struct {
-			Uint64 int64
+			Uint64 uint64
 			Valid  bool
 		}{}
 		err = JSONUnMarshalFn(data, dto)
-		a.Uint64 = uint64(dto.Uint64)
-		a.Valid = dto.Valid
+		a.Uint64 = dto.Uint64
+		a.Valid = dto.Valid && err == nil
+		return err
 	case nil:
 		a.Valid = false
 		return nil
@@ -127,7 +128,7 @@ func (a *Uint64) UnmarshalJSON(data []byte) error {
 // It will return an error if the input is not an integer, blank, or sqlStrNullLC.
 func (a *Uint64) UnmarshalText(text []byte) (err error) {
 	str := string(text)
-	if str == "" || str == sqlStrNullLC {
+	if str == "" || str == sqlStrNullLC || str == sqlStrNullUC {
 		a.Valid = false
 		return nil
 	}
@@ -173,13 +174,14 @@ func (a Uint64) IsZero() bool {
 	return !a.Valid
 }
 
+const maxInt64 = 1<<63 - 1
+
 // Value implements the driver.Valuer interface.
 func (a Uint64) Value() (driver.Value, error) {
 	if !a.Valid {
 		return nil, nil
 	}
-	const maxInt64 = 1<<63 - 1
-	if a.Uint64 < maxInt64 {
+	if a.Uint64 <= maxInt64 {
 		return int64(a.Uint64), nil
 	}
 	return strconv.AppendUint([]byte{}, a.Uint64, 10), nil
@@ -255,10 +257,14 @@ func (a Uint64) WriteTo(_ Dialecter, w *bytes.Buffer) (err error) {
 	return err
 }
 
-// Append appends the value or its nil type to the interface slice.
+// Append appends the value or its nil type to the interface slice. Values
+// greater than math.MaxInt64 get appended as a byte slice, see Value.
 func (a Uint64) Append(args []interface{}) []interface{} {
-	if a.Valid {
-		return append(args, a.Uint64)
+	switch {
+	case a.Valid && a.Uint64 > maxInt64:
+		return append(args, strconv.AppendUint([]byte{}, a.Uint64, 10))
+	case a.Valid:
+		return append(args, int64(a.Uint64))
 	}
 	return append(args, nil)
 }
@@ -279,6 +279,17 @@ func TestNewNullUint64(t *testing.T) {
 	v, err := MakeUint64(1257894000).Value()
 	assert.NoError(t, err)
 	assert.EqualValues(t, 1257894000, v)
+
+	v, err = MakeUint64(math.MaxUint64).Value()
+	assert.NoError(t, err)
+	assert.Exactly(t, []byte(`18446744073709551615`), v)
+}
+
+func TestUint64_Append(t *testing.T) {
+	args := MakeUint64(math.MaxInt64).Append(nil)
+	args = MakeUint64(math.MaxUint64).Append(args)
+	args = Uint64{}.Append(args)
+	assert.Exactly(t, []interface{}{int64(math.MaxInt64), []byte(`18446744073709551615`), nil}, args)
 }
 
 func TestNullUint64_Scan(t *testing.T) {
@@ -0,0 +1,359 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build bolt csall
+
+package objcache
+
+import (
+	"context"
+	encbin "encoding/binary" // package level type binary exists
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/corestoreio/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+// BoltConfig allows to overwrite default values of the bbolt file backend.
+type BoltConfig struct {
+	// Path of the database file, required.
+	Path     string
+	FileMode os.FileMode // default 0600
+	// Options optional bbolt options, default a timeout of one second to
+	// acquire the file lock.
+	Options *bolt.Options
+	// NamespaceSeparator splits a key into the bucket name and the key within
+	// the bucket. For example the key "catalog:product:1" gets stored in bucket
+	// "catalog" with key "product:1". Keys without the separator get stored in
+	// the DefaultBucket. Default ":".
+	NamespaceSeparator string
+	// DefaultBucket stores all keys without a namespace, default "objcache".
+	DefaultBucket string
+	// CompactInterval defines the interval to delete expired keys in the
+	// background. Expired keys are never returned by Get, but they use disk
+	// space until they get deleted. Zero disables the background deletion.
+	CompactInterval time.Duration
+	// CompactOnClose deletes all expired keys and rewrites the database file
+	// when closing the client to reclaim the disk space of deleted keys. bbolt
+	// does not shrink the file on its own.
+	CompactOnClose bool
+}
+
+// NewBoltClient creates a persistent local disk cache backed by bbolt. The
+// cached objects survive a restart of the service. Argument `c` must contain
+// at least the Path to the database file.
+func NewBoltClient(c *BoltConfig) NewStorageFn {
+	if c == nil || c.Path == "" {
+		return func() (Storager, error) {
+			return nil, errors.Empty.Newf("[objcache] Bolt requires the path to the database file")
+		}
+	}
+	if c.FileMode == 0 {
+		c.FileMode = 0600
+	}
+	if c.Options == nil {
+		c.Options = &bolt.Options{Timeout: time.Second}
+	}
+	if c.NamespaceSeparator == "" {
+		c.NamespaceSeparator = ":"
+	}
+	if c.DefaultBucket == "" {
+		c.DefaultBucket = "objcache"
+	}
+	return func() (Storager, error) {
+		db, err := bolt.Open(c.Path, c.FileMode, c.Options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[objcache] Bolt failed to open %q", c.Path)
+		}
+		bs := &boltStorage{
+			cfg:  *c,
+			db:   db,
+			done: make(chan struct{}),
+		}
+		if c.CompactInterval > 0 {
+			bs.wg.Add(1)
+			go bs.compactLoop()
+		}
+		return bs, nil
+	}
+}
+
+// boltExpiresLength the first eight bytes of a value contain the expiration
+// time as unix nano seconds. Zero means the value never expires.
+const boltExpiresLength = 8
+
+type boltStorage struct {
+	cfg  BoltConfig
+	db   *bolt.DB
+	done chan struct{}
+	wg   sync.WaitGroup
+	// closeOnce makes Close idempotent, closeErr gets returned on each call.
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// split returns the bucket name and the key within the bucket.
+func (bs *boltStorage) split(key string) (bucket, bucketKey []byte) {
+	if pos := strings.Index(key, bs.cfg.NamespaceSeparator); pos > 0 && pos+len(bs.cfg.NamespaceSeparator) < len(key) {
+		return []byte(key[:pos]), []byte(key[pos+len(bs.cfg.NamespaceSeparator):])
+	}
+	return []byte(bs.cfg.DefaultBucket), []byte(key)
+}
+
+func (bs *boltStorage) Set(_ context.Context, keys []string, values [][]byte, expirations []time.Duration) error {
+	n := now()
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		for i, key := range keys {
+			bucket, bKey := bs.split(key)
+			b, err := tx.CreateBucketIfNotExists(bucket)
+			if err != nil {
+				return errors.Wrapf(err, "[objcache] Bolt with key %q", key)
+			}
+			var e int64
+			if len(expirations) > 0 && expirations[i] > 0 {
+				e = n.Add(expirations[i]).UnixNano()
+			}
+			val := make([]byte, boltExpiresLength, boltExpiresLength+len(values[i]))
+			encbin.BigEndian.PutUint64(val, uint64(e))
+			if err := b.Put(bKey, append(val, values[i]...)); err != nil {
+				return errors.Wrapf(err, "[objcache] Bolt with key %q", key)
+			}
+		}
+		return nil
+	})
+}
+
+// boltIsExpired reports whether the stored value has been expired.
+func boltIsExpired(val []byte, n int64) bool {
+	if len(val) < boltExpiresLength {
+		return true
+	}
+	e := int64(encbin.BigEndian.Uint64(val))
+	return e > 0 && e <= n
+}
+
+func (bs *boltStorage) Get(_ context.Context, keys []string) (values [][]byte, err error) {
+	n := now().UnixNano()
+	err = bs.db.View(func(tx *bolt.Tx) error {
+		for _, key := range keys {
+			bucket, bKey := bs.split(key)
+			var val []byte
+			if b := tx.Bucket(bucket); b != nil {
+				if v := b.Get(bKey); v != nil && !boltIsExpired(v, n) {
+					// the data of bbolt is only valid during the transaction.
+					val = append([]byte{}, v[boltExpiresLength:]...)
+				}
+			}
+			values = append(values, val)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "[objcache] Bolt with keys %v", keys)
+	}
+	return values, nil
+}
+
+func (bs *boltStorage) CompareAndSwap(_ context.Context, key string, version uint64, value []byte, expiration time.Duration) (swapped bool, err error) {
+	n := now()
+	err = bs.db.Update(func(tx *bolt.Tx) error {
+		bucket, bKey := bs.split(key)
+		b, err := tx.CreateBucketIfNotExists(bucket)
+		if err != nil {
+			return errors.Wrapf(err, "[objcache] Bolt with key %q", key)
+		}
+		var current []byte
+		if v := b.Get(bKey); v != nil && !boltIsExpired(v, n.UnixNano()) {
+			current = v[boltExpiresLength:]
+		}
+		if ValueVersion(current) != version {
+			return nil
+		}
+		var e int64
+		if expiration > 0 {
+			e = n.Add(expiration).UnixNano()
+		}
+		val := make([]byte, boltExpiresLength, boltExpiresLength+len(value))
+		encbin.BigEndian.PutUint64(val, uint64(e))
+		if err := b.Put(bKey, append(val, value...)); err != nil {
+			return errors.Wrapf(err, "[objcache] Bolt with key %q", key)
+		}
+		swapped = true
+		return nil
+	})
+	return swapped, err
+}
+
+func (bs *boltStorage) Delete(_ context.Context, keys []string) error {
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		for _, key := range keys {
+			bucket, bKey := bs.split(key)
+			b := tx.Bucket(bucket)
+			if b == nil {
+				continue
+			}
+			if err := b.Delete(bKey); err != nil {
+				return errors.Wrapf(err, "[objcache] Bolt with key %q", key)
+			}
+		}
+		return nil
+	})
+}
+
+// Truncate deletes all buckets.
+func (bs *boltStorage) Truncate(_ context.Context) error {
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		var buckets [][]byte
+		if err := tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+			buckets = append(buckets, append([]byte{}, name...))
+			return nil
+		}); err != nil {
+			return errors.WithStack(err)
+		}
+		for _, name := range buckets {
+			if err := tx.DeleteBucket(name); err != nil {
+				return errors.Wrapf(err, "[objcache] Bolt with bucket %q", name)
+			}
+		}
+		return nil
+	})
+}
+
+// TruncatePrefix deletes all keys starting with prefix. Buckets whose
+// namespace matches the prefix get dropped completely, for example the prefix
+// "catalog:" drops the bucket "catalog".
+func (bs *boltStorage) TruncatePrefix(_ context.Context, prefix string) error {
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		var buckets [][]byte
+		type bucketKey struct {
+			bucket, key []byte
+		}
+		var keys []bucketKey
+		if err := tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			ns := string(name) + bs.cfg.NamespaceSeparator
+			isDefault := string(name) == bs.cfg.DefaultBucket
+			if !isDefault && strings.HasPrefix(ns, prefix) {
+				buckets = append(buckets, append([]byte{}, name...))
+				return nil
+			}
+			if !isDefault && !strings.HasPrefix(prefix, ns) {
+				return nil
+			}
+			return b.ForEach(func(k, _ []byte) error {
+				key := ns + string(k)
+				if (isDefault && strings.HasPrefix(string(k), prefix)) || strings.HasPrefix(key, prefix) {
+					keys = append(keys, bucketKey{bucket: append([]byte{}, name...), key: append([]byte{}, k...)})
+				}
+				return nil
+			})
+		}); err != nil {
+			return errors.WithStack(err)
+		}
+		for _, name := range buckets {
+			if err := tx.DeleteBucket(name); err != nil {
+				return errors.Wrapf(err, "[objcache] Bolt with bucket %q", name)
+			}
+		}
+		for _, bk := range keys {
+			if err := tx.Bucket(bk.bucket).Delete(bk.key); err != nil {
+				return errors.Wrapf(err, "[objcache] Bolt with bucket %q and key %q", bk.bucket, bk.key)
+			}
+		}
+		return nil
+	})
+}
+
+// deleteExpired removes all expired keys from all buckets.
+func (bs *boltStorage) deleteExpired() error {
+	n := now().UnixNano()
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			var expired [][]byte
+			if err := b.ForEach(func(k, v []byte) error {
+				if v != nil && boltIsExpired(v, n) {
+					expired = append(expired, append([]byte{}, k...))
+				}
+				return nil
+			}); err != nil {
+				return errors.WithStack(err)
+			}
+			for _, k := range expired {
+				if err := b.Delete(k); err != nil {
+					return errors.Wrapf(err, "[objcache] Bolt with bucket %q", name)
+				}
+			}
+			return nil
+		})
+	})
+}
+
+func (bs *boltStorage) compactLoop() {
+	defer bs.wg.Done()
+	t := time.NewTicker(bs.cfg.CompactInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-bs.done:
+			return
+		case <-t.C:
+			// errors can be ignored because the next run tries it again.
+			_ = bs.deleteExpired()
+		}
+	}
+}
+
+// compact writes the database into a new file which replaces the old file
+// after the database has been closed.
+func (bs *boltStorage) compact(tmpPath string) error {
+	dst, err := bolt.Open(tmpPath, bs.cfg.FileMode, bs.cfg.Options)
+	if err != nil {
+		return errors.Wrapf(err, "[objcache] Bolt failed to open %q", tmpPath)
+	}
+	if err := bolt.Compact(dst, bs.db, 0); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(tmpPath)
+		return errors.Wrapf(err, "[objcache] Bolt failed to compact %q", bs.cfg.Path)
+	}
+	return errors.WithStack(dst.Close())
+}
+
+func (bs *boltStorage) Close() error {
+	bs.closeOnce.Do(func() { bs.closeErr = bs.close() })
+	return bs.closeErr
+}
+
+func (bs *boltStorage) close() (err error) {
+	close(bs.done)
+	bs.wg.Wait()
+
+	tmpPath := bs.cfg.Path + ".compact"
+	if bs.cfg.CompactOnClose {
+		if err = bs.deleteExpired(); err == nil {
+			err = bs.compact(tmpPath)
+		}
+	}
+	if err2 := bs.db.Close(); err2 != nil && err == nil {
+		err = err2
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if bs.cfg.CompactOnClose {
+		return errors.WithStack(os.Rename(tmpPath, bs.cfg.Path))
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build bolt csall
+
+package objcache_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/storage/objcache"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func newBoltTestPath(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "objcache_bolt")
+	assert.NoError(t, err)
+	return filepath.Join(dir, "objcache.db"), func() { assert.NoError(t, os.RemoveAll(dir)) }
+}
+
+func TestNewBoltClient_PathRequired(t *testing.T) {
+	p, err := objcache.NewService(nil, objcache.NewBoltClient(&objcache.BoltConfig{}), newSrvOpt(JSONCodec{}))
+	assert.Nil(t, p)
+	assert.True(t, errors.Empty.Match(err), "%+v", err)
+}
+
+func TestNewBoltClient_Delete(t *testing.T) {
+	path, clean := newBoltTestPath(t)
+	defer clean()
+	newTestServiceDelete(t, objcache.NewBoltClient(&objcache.BoltConfig{Path: path}))
+}
+
+func TestNewBoltClient_Expires(t *testing.T) {
+	path, clean := newBoltTestPath(t)
+	defer clean()
+	testExpiration(t, func() {
+		time.Sleep(time.Second * 2)
+	}, objcache.NewBoltClient(&objcache.BoltConfig{Path: path}), newSrvOpt(JSONCodec{}))
+}
+
+func TestNewBoltClient_Persistent(t *testing.T) {
+	path, clean := newBoltTestPath(t)
+	defer clean()
+	cfg := &objcache.BoltConfig{Path: path, CompactOnClose: true}
+	ctx := context.Background()
+
+	p, err := objcache.NewService(nil, objcache.NewBoltClient(cfg), newSrvOpt(JSONCodec{}))
+	assert.NoError(t, err)
+	assert.NoError(t, p.Set(ctx, "catalog:product:1", "Gopher Plush", 0))
+	assert.NoError(t, p.Set(ctx, "sku_1", 3.1415, 0))
+	assert.NoError(t, p.Close())
+	assert.NoError(t, p.Close(), "a second Close must not panic")
+
+	p, err = objcache.NewService(nil, objcache.NewBoltClient(cfg), newSrvOpt(JSONCodec{}))
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+
+	var name string
+	var price float64
+	assert.NoError(t, p.Get(ctx, "catalog:product:1", &name))
+	assert.NoError(t, p.Get(ctx, "sku_1", &price))
+	assert.Exactly(t, "Gopher Plush", name)
+	assert.Exactly(t, 3.1415, price)
+}
+
+func TestNewBoltClient_ComplexParallel(t *testing.T) {
+	path, clean := newBoltTestPath(t)
+	defer clean()
+	newServiceComplexParallelTest(t, objcache.NewBoltClient(&objcache.BoltConfig{Path: path}), &objcache.ServiceOptions{
+		Codec: JSONCodec{},
+	})
+}
+
+func TestNewBoltClient_TruncatePrefix(t *testing.T) {
+	path, clean := newBoltTestPath(t)
+	defer clean()
+	ctx := context.Background()
+
+	p, err := objcache.NewService(nil, objcache.NewBoltClient(&objcache.BoltConfig{Path: path}), newSrvOpt(JSONCodec{}))
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+
+	keys := []string{"catalog:product:1", "catalog:product:2", "cms:page:1", "cms:block:1", "sku_1", "sku_2", "other"}
+	assert.NoError(t, p.SetMulti(ctx, keys, []interface{}{1, 2, 3, 4, 5, 6, 7}, nil))
+
+	assert.NoError(t, p.TruncatePrefix(ctx, "catalog:"))
+	assert.NoError(t, p.TruncatePrefix(ctx, "cms:page:"))
+	assert.NoError(t, p.TruncatePrefix(ctx, "sku_"))
+
+	vals := make([]int, len(keys))
+	dst := make([]interface{}, len(keys))
+	for i := range vals {
+		dst[i] = &vals[i]
+	}
+	assert.NoError(t, p.GetMulti(ctx, keys, dst))
+	assert.Exactly(t, []int{0, 0, 0, 4, 0, 0, 7}, vals)
+}
+
+func TestNewBoltClient_CompareAndSet(t *testing.T) {
+	path, clean := newBoltTestPath(t)
+	defer clean()
+	testCompareAndSet(t, objcache.NewBoltClient(&objcache.BoltConfig{Path: path}))
+}
@@ -0,0 +1,81 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build bolt csall
+
+package objcache
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/pkg/util/assert"
+	bolt "go.etcd.io/bbolt"
+)
+
+var _ Storager = (*boltStorage)(nil)
+
+func TestBoltStorage_Namespaces(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objcache_bolt")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := NewBoltClient(&BoltConfig{Path: filepath.Join(dir, "ns.db")})()
+	assert.NoError(t, err)
+	bs := s.(*boltStorage)
+	defer func() { assert.NoError(t, bs.Close()) }()
+	ctx := context.Background()
+
+	assert.NoError(t, bs.Set(ctx,
+		[]string{"catalog:product:1", "customer:2", "plain", ":leading", "trailing:"},
+		[][]byte{[]byte("p1"), []byte("c2"), []byte("pl"), []byte("le"), []byte("tr")},
+		[]time.Duration{0, 0, 0, time.Nanosecond, 0},
+	))
+
+	layout := map[string][]string{}
+	assert.NoError(t, bs.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			return b.ForEach(func(k, _ []byte) error {
+				layout[string(name)] = append(layout[string(name)], string(k))
+				return nil
+			})
+		})
+	}))
+	assert.Exactly(t, map[string][]string{
+		"catalog":  {"product:1"},
+		"customer": {"2"},
+		"objcache": {":leading", "plain", "trailing:"},
+	}, layout)
+
+	time.Sleep(time.Millisecond)
+	vals, err := bs.Get(ctx, []string{"catalog:product:1", ":leading", "missing:key"})
+	assert.NoError(t, err)
+	assert.Exactly(t, [][]byte{[]byte("p1"), nil, nil}, vals)
+
+	assert.NoError(t, bs.deleteExpired())
+	assert.NoError(t, bs.db.View(func(tx *bolt.Tx) error {
+		assert.Nil(t, tx.Bucket([]byte("objcache")).Get([]byte(":leading")))
+		assert.NotNil(t, tx.Bucket([]byte("objcache")).Get([]byte("plain")))
+		return nil
+	}))
+
+	assert.NoError(t, bs.Truncate(ctx))
+	vals, err = bs.Get(ctx, []string{"catalog:product:1", "plain"})
+	assert.NoError(t, err)
+	assert.Exactly(t, [][]byte{nil, nil}, vals)
+}
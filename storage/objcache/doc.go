@@ -16,8 +16,15 @@
 // a cache reducing GC.
 //
 // A Cache can be either in memory or a persistent one. Cache adapters are
-// available for bigcache or Redis. To enable the cache adapter use build tags
-// "bigcache" or "redis" or "csall". More cache adapters might follow.
+// available for bigcache, Redis (also Sentinel and Cluster), Memcached or a
+// bbolt file. To enable the cache adapter use build tags "bigcache", "redis",
+// "memcache", "bolt" or "csall".
+// More cache adapters might follow.
+//
+// Besides custom codecs the build tags "gob", "msgpack" and "proto" enable the
+// GobCodec, MsgpackCodec and ProtoCodec.
+// The AESGCMCodec wraps another codec and encrypts the values, for example
+// to store tokens in a shared Redis.
 //
 // Use case: Caching millions of Go types as a byte slice reduces the pressure
 // to the GC.
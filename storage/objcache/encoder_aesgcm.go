@@ -0,0 +1,145 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objcache
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+	"io/ioutil"
+
+	"github.com/corestoreio/errors"
+)
+
+var _ Codecer = (*AESGCMCodec)(nil)
+
+// AESGCMCodec encrypts and authenticates the data of another Codec with
+// AES-GCM. Each payload starts with the ID of the key used for encryption,
+// followed by a random nonce and the sealed data. Keys can be rotated by adding
+// a new key and making it the active one; values encrypted with older keys can
+// be decrypted as long as the older keys are still available.
+//
+// The AESGCMCodec encrypts all types, also those implementing a Marshal
+// function or one of the interfaces of package "encoding". Such types get
+// marshaled by themselves before encryption; all other types get encoded by
+// the wrapped Codec.
+type AESGCMCodec struct {
+	codec       Codecer
+	activeKeyID string
+	aeads       map[string]cipher.AEAD
+}
+
+// NewAESGCMCodec creates a new encrypting codec which wraps `codec`. The map
+// `keys` contains the key ID and the AES key which must have a length of 16,
+// 24 or 32 bytes. A key ID can have up to 255 bytes. `activeKeyID` selects the
+// key for the encryption. `codec` can be nil, if all types implement the
+// Marshal and Unmarshal functions.
+func NewAESGCMCodec(codec Codecer, activeKeyID string, keys map[string][]byte) (*AESGCMCodec, error) {
+	if _, ok := keys[activeKeyID]; !ok {
+		return nil, errors.NotFound.Newf("[objcache] AESGCMCodec active key ID %q not found in keys", activeKeyID)
+	}
+	c := &AESGCMCodec{
+		codec:       codec,
+		activeKeyID: activeKeyID,
+		aeads:       make(map[string]cipher.AEAD, len(keys)),
+	}
+	for id, key := range keys {
+		if l := len(id); l == 0 || l > 255 {
+			return nil, errors.NotValid.Newf("[objcache] AESGCMCodec key ID %q must have a length between 1 and 255, have %d", id, l)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, errors.NotValid.New(err, "[objcache] AESGCMCodec key ID %q", id)
+		}
+		if c.aeads[id], err = cipher.NewGCM(block); err != nil {
+			return nil, errors.NotValid.New(err, "[objcache] AESGCMCodec key ID %q", id)
+		}
+	}
+	return c, nil
+}
+
+func (c *AESGCMCodec) exclusiveCodec() {}
+
+// NewEncoder returns a new encoder which writes the encrypted data to w.
+func (c *AESGCMCodec) NewEncoder(w io.Writer) Encoder {
+	return aesGCMEncoder{c: c, w: w}
+}
+
+// NewDecoder returns a new decoder which reads the encrypted data from r.
+func (c *AESGCMCodec) NewDecoder(r io.Reader) Decoder {
+	return aesGCMDecoder{c: c, r: r}
+}
+
+type aesGCMEncoder struct {
+	c *AESGCMCodec
+	w io.Writer
+}
+
+func (e aesGCMEncoder) Encode(src interface{}) error {
+	var plain bytes.Buffer
+	if err := encodeOne(e.c.codec, &plain, "", src); err != nil {
+		return errors.WithStack(err)
+	}
+
+	id := e.c.activeKeyID
+	aead := e.c.aeads[id]
+	payload := make([]byte, 1+len(id)+aead.NonceSize(), 1+len(id)+aead.NonceSize()+plain.Len()+aead.Overhead())
+	payload[0] = byte(len(id))
+	copy(payload[1:], id)
+	nonce := payload[1+len(id):]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return errors.Fatal.New(err, "[objcache] AESGCMCodec failed to create nonce")
+	}
+	// the key ID gets authenticated as additional data.
+	payload = aead.Seal(payload, nonce, plain.Bytes(), payload[1:1+len(id)])
+	_, err := e.w.Write(payload)
+	return errors.WithStack(err)
+}
+
+type aesGCMDecoder struct {
+	c *AESGCMCodec
+	r io.Reader
+}
+
+func (d aesGCMDecoder) Decode(dst interface{}) error {
+	payload, err := ioutil.ReadAll(d.r)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if len(payload) == 0 {
+		return errors.CorruptData.Newf("[objcache] AESGCMCodec payload is empty")
+	}
+	idLen := int(payload[0])
+	if len(payload) < 1+idLen {
+		return errors.CorruptData.Newf("[objcache] AESGCMCodec payload too short for the key ID")
+	}
+	id := payload[1 : 1+idLen]
+	aead, ok := d.c.aeads[string(id)]
+	if !ok {
+		return errors.NotFound.Newf("[objcache] AESGCMCodec key ID %q not found", id)
+	}
+	ns := aead.NonceSize()
+	if len(payload) < 1+idLen+ns+aead.Overhead() {
+		return errors.CorruptData.Newf("[objcache] AESGCMCodec payload too short with key ID %q", id)
+	}
+	nonce := payload[1+idLen : 1+idLen+ns]
+	plain, err := aead.Open(nil, nonce, payload[1+idLen+ns:], id)
+	if err != nil {
+		return errors.DecryptionFailed.New(err, "[objcache] AESGCMCodec with key ID %q", id)
+	}
+	return errors.WithStack(decodeOne(d.c.codec, plain, "", dst))
+}
@@ -0,0 +1,130 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objcache_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/storage/objcache"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+var (
+	testAESKey1 = []byte("0123456789abcdef0123456789abcdef")
+	testAESKey2 = []byte("fedcba9876543210")
+)
+
+func newTestAESGCMCodec(t *testing.T, activeKeyID string, keys map[string][]byte) *objcache.AESGCMCodec {
+	c, err := objcache.NewAESGCMCodec(JSONCodec{}, activeKeyID, keys)
+	assert.NoError(t, err)
+	return c
+}
+
+func TestAESGCMCodec_ComplexParallel(t *testing.T) {
+	newServiceComplexParallelTest(t, objcache.NewCacheSimpleInmemory, &objcache.ServiceOptions{
+		Codec: newTestAESGCMCodec(t, "k1", map[string][]byte{"k1": testAESKey1}),
+	})
+}
+
+func TestAESGCMCodec_Encryption(t *testing.T) {
+	level2, err := objcache.NewCacheSimpleInmemory()
+	assert.NoError(t, err)
+	newSrv := func(c objcache.Codecer) *objcache.Service {
+		p, err := objcache.NewService(nil, func() (objcache.Storager, error) { return level2, nil }, &objcache.ServiceOptions{Codec: c})
+		assert.NoError(t, err)
+		return p
+	}
+	ctx := context.Background()
+
+	p1 := newSrv(newTestAESGCMCodec(t, "k1", map[string][]byte{"k1": testAESKey1}))
+	assert.NoError(t, p1.Set(ctx, "token", "secret-token", 0))
+	// types implementing Marshal must not bypass the encryption.
+	assert.NoError(t, p1.Set(ctx, "binary", objcache.MakeBinary(), 0))
+
+	raw, err := level2.Get(ctx, []string{"token", "binary"})
+	assert.NoError(t, err)
+	assert.False(t, bytes.Contains(raw[0], []byte("secret-token")), "value must be encrypted")
+	assert.True(t, bytes.HasPrefix(raw[0], []byte("\x02k1")), "payload must start with the key ID")
+	assert.True(t, bytes.HasPrefix(raw[1], []byte("\x02k1")), "payload must start with the key ID")
+
+	t.Run("key rotation", func(t *testing.T) {
+		p2 := newSrv(newTestAESGCMCodec(t, "k2", map[string][]byte{"k1": testAESKey1, "k2": testAESKey2}))
+		var token string
+		assert.NoError(t, p2.Get(ctx, "token", &token))
+		assert.Exactly(t, "secret-token", token)
+		bin := objcache.MakeBinary()
+		bin = 0 // Unmarshal must set it again to valid
+		assert.NoError(t, p2.Get(ctx, "binary", &bin))
+		assert.True(t, bin.IsValid())
+
+		assert.NoError(t, p2.Set(ctx, "token", "rotated-token", 0))
+		raw, err := level2.Get(ctx, []string{"token"})
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(raw[0], []byte("\x02k2")), "payload must start with the key ID")
+		assert.NoError(t, p2.Get(ctx, "token", &token))
+		assert.Exactly(t, "rotated-token", token)
+	})
+
+	t.Run("unknown key ID", func(t *testing.T) {
+		p3 := newSrv(newTestAESGCMCodec(t, "k3", map[string][]byte{"k3": testAESKey1}))
+		var token string
+		err := p3.Get(ctx, "token", &token)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `key ID "k2" not found`) // token got rotated to k2
+		assert.Empty(t, token)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		raw, err := level2.Get(ctx, []string{"binary"})
+		assert.NoError(t, err)
+		raw[0][len(raw[0])-1] ^= 0xff
+		assert.NoError(t, level2.Set(ctx, []string{"binary"}, raw, nil))
+
+		var bin = objcache.MakeBinary()
+		err = p1.Get(ctx, "binary", &bin)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "message authentication failed")
+	})
+
+	t.Run("empty payload", func(t *testing.T) {
+		c := newTestAESGCMCodec(t, "k1", map[string][]byte{"k1": testAESKey1})
+		var token string
+		err := c.NewDecoder(bytes.NewReader(nil)).Decode(&token)
+		assert.True(t, errors.CorruptData.Match(err), "%+v", err)
+		assert.Empty(t, token)
+
+		assert.NoError(t, level2.Set(ctx, []string{"empty"}, [][]byte{{}}, nil))
+		err = p1.Get(ctx, "empty", &token)
+		assert.Contains(t, err.Error(), "payload is empty")
+		assert.Empty(t, token)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		var token string
+		assert.NoError(t, p1.Get(ctx, "missing", &token))
+		assert.Empty(t, token)
+	})
+}
+
+func TestNewAESGCMCodec_Errors(t *testing.T) {
+	_, err := objcache.NewAESGCMCodec(JSONCodec{}, "k2", map[string][]byte{"k1": testAESKey1})
+	assert.True(t, errors.NotFound.Match(err), "%+v", err)
+
+	_, err = objcache.NewAESGCMCodec(JSONCodec{}, "k1", map[string][]byte{"k1": []byte("short")})
+	assert.True(t, errors.NotValid.Match(err), "%+v", err)
+}
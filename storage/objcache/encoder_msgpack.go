@@ -0,0 +1,39 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build msgpack csall
+
+package objcache
+
+import (
+	"io"
+
+	"github.com/vmihailenco/msgpack"
+)
+
+var _ Codecer = MsgpackCodec{}
+
+// MsgpackCodec encodes and decodes using the MessagePack format. It creates
+// smaller payloads than JSON and needs no type registration like gob.
+type MsgpackCodec struct{}
+
+// NewEncoder returns a new msgpack encoder which writes to w
+func (c MsgpackCodec) NewEncoder(w io.Writer) Encoder {
+	return msgpack.NewEncoder(w)
+}
+
+// NewDecoder returns a new msgpack decoder which reads from r
+func (c MsgpackCodec) NewDecoder(r io.Reader) Decoder {
+	return msgpack.NewDecoder(r)
+}
@@ -0,0 +1,77 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build proto csall
+
+package objcache
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/corestoreio/errors"
+	"github.com/gogo/protobuf/proto"
+)
+
+var _ Codecer = ProtoCodec{}
+
+// ProtoCodec encodes and decodes types implementing proto.Message. Other types
+// return an error with behaviour NotSupported. Types generated with gogo
+// protobuf implement Marshal and Unmarshal and get therefore always encoded
+// with protobuf, regardless of the codec of the Service. This allows to mix
+// protobuf messages with other types in one Service.
+type ProtoCodec struct{}
+
+// NewEncoder returns a new protobuf encoder which writes to w
+func (c ProtoCodec) NewEncoder(w io.Writer) Encoder {
+	return protoEncoder{w: w}
+}
+
+// NewDecoder returns a new protobuf decoder which reads from r
+func (c ProtoCodec) NewDecoder(r io.Reader) Decoder {
+	return protoDecoder{r: r}
+}
+
+type protoEncoder struct {
+	w io.Writer
+}
+
+func (pe protoEncoder) Encode(src interface{}) error {
+	pm, ok := src.(proto.Message)
+	if !ok {
+		return errors.NotSupported.Newf("[objcache] Type %T does not implement proto.Message", src)
+	}
+	data, err := proto.Marshal(pm)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = pe.w.Write(data)
+	return errors.WithStack(err)
+}
+
+type protoDecoder struct {
+	r io.Reader
+}
+
+func (pd protoDecoder) Decode(dst interface{}) error {
+	pm, ok := dst.(proto.Message)
+	if !ok {
+		return errors.NotSupported.Newf("[objcache] Type %T does not implement proto.Message", dst)
+	}
+	data, err := ioutil.ReadAll(pd.r)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(proto.Unmarshal(data, pm))
+}
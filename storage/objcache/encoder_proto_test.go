@@ -0,0 +1,50 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build proto csall
+
+package objcache_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/storage/objcache"
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/gogo/protobuf/types"
+)
+
+func TestProtoCodec(t *testing.T) {
+	t.Parallel()
+
+	p, err := objcache.NewService(nil, objcache.NewCacheSimpleInmemory, &objcache.ServiceOptions{
+		Codec: objcache.ProtoCodec{},
+	})
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+	ctx := context.TODO()
+
+	t.Run("proto.Message", func(t *testing.T) {
+		assert.NoError(t, p.Set(ctx, "ts", &types.Timestamp{Seconds: 1539262212, Nanos: 4711}, 0))
+		var ts types.Timestamp
+		assert.NoError(t, p.Get(ctx, "ts", &ts))
+		assert.Exactly(t, types.Timestamp{Seconds: 1539262212, Nanos: 4711}, ts)
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+		err := p.Set(ctx, "float", 3.14159, 0)
+		assert.True(t, errors.NotSupported.Match(errors.Cause(err)), "%+v", err)
+	})
+}
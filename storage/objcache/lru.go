@@ -16,6 +16,8 @@ package objcache
 
 import (
 	"context"
+	"hash/fnv"
+	"strings"
 	"time"
 
 	"github.com/corestoreio/pkg/storage/lru"
@@ -28,16 +30,26 @@ type LRUOptions struct {
 	Capacity           int64 // default 5000 objects
 	TrackBySize        bool
 	TrackByObjectCount bool // default
-	LRUCache           *lru.LRUCache
+	// MaxEntries limits additionally the number of objects when tracking by
+	// size. Zero means no limit.
+	MaxEntries int64
+	// Shards splits the cache into several LRU caches to reduce lock
+	// contention. Capacity and MaxEntries get divided by the number of shards
+	// and rounded up, so each shard holds at least one entry. Default 1. Gets
+	// ignored if LRUCache has been set.
+	Shards   int
+	LRUCache *lru.LRUCache
 }
 
 // lruCache is an LRU cache. It is safe for concurrent access.
 type lruCache struct {
-	opt LRUOptions
+	opt    LRUOptions
+	shards []*lru.LRUCache
 }
 
-// NewLRU creates a new LRU Storage. Expirations are not supported. Argument `o`
-// can be nil, if so default values get applied.
+// NewLRU creates a new LRU Storage which supports expirations per item. The
+// LRU can be used standalone or as level1 of the Service in front of a slower
+// cache. Argument `o` can be nil, if so default values get applied.
 func NewLRU(o *LRUOptions) NewStorageFn {
 	if o == nil {
 		o = &LRUOptions{}
@@ -55,65 +67,126 @@ func NewLRU(o *LRUOptions) NewStorageFn {
 		o.TrackByObjectCount = true
 		o.Capacity = 5000
 	}
+	if o.Shards < 1 || o.LRUCache != nil {
+		o.Shards = 1
+	}
+	shardCapacity := perShard(o.Capacity, o.Shards)
+	shardMaxEntries := perShard(o.MaxEntries, o.Shards)
 	if o.LRUCache == nil {
-		o.LRUCache = lru.NewLRUCache(o.Capacity)
+		o.LRUCache = newLRUShard(shardCapacity, shardMaxEntries)
 	}
 	return func() (Storager, error) {
-		return lruCache{
-			opt: *o,
-		}, nil
+		c := lruCache{
+			opt:    *o,
+			shards: make([]*lru.LRUCache, o.Shards),
+		}
+		c.shards[0] = o.LRUCache
+		for i := 1; i < o.Shards; i++ {
+			c.shards[i] = newLRUShard(shardCapacity, shardMaxEntries)
+		}
+		return c, nil
 	}
 }
 
-type itemBySize []byte
+// perShard divides v by the number of shards and rounds up. A limit of e.g. 3
+// entries with 4 shards would otherwise become 0, which means no limit.
+func perShard(v int64, shards int) int64 {
+	if v <= 0 {
+		return v
+	}
+	return (v + int64(shards) - 1) / int64(shards)
+}
 
-func (li itemBySize) Size() int { return len(li) }
+func newLRUShard(capacity, maxEntries int64) *lru.LRUCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	c := lru.NewLRUCache(capacity)
+	if maxEntries > 0 {
+		c.SetMaxLength(maxEntries)
+	}
+	return c
+}
 
-type itemByCount []byte
+// lruItem stores the data and its expiration time as unix nano seconds. Zero
+// means no expiration.
+type lruItem struct {
+	data    []byte
+	expires int64
+	bySize  bool
+}
+
+func (li *lruItem) Size() int {
+	if li.bySize {
+		return len(li.data)
+	}
+	return 1
+}
 
-func (li itemByCount) Size() int { return 1 }
+func (c lruCache) shard(key string) *lru.LRUCache {
+	if len(c.shards) == 1 {
+		return c.shards[0]
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return c.shards[h.Sum32()%uint32(len(c.shards))]
+}
 
-func (c lruCache) Set(_ context.Context, keys []string, values [][]byte, _ []time.Duration) (err error) {
+func (c lruCache) Set(_ context.Context, keys []string, values [][]byte, expirations []time.Duration) (err error) {
+	n := now()
 	for i, key := range keys {
-		var v lru.Value = itemByCount(values[i])
-		if c.opt.TrackBySize {
-			v = itemBySize(values[i])
+		itm := &lruItem{data: values[i], bySize: c.opt.TrackBySize}
+		if len(expirations) > 0 && expirations[i] > 0 {
+			itm.expires = n.Add(expirations[i]).UnixNano()
 		}
-		c.opt.LRUCache.Set(key, v)
+		c.shard(key).Set(key, itm)
 	}
 	return nil
 }
 
-// Get looks up a key's value from the cache.
+// Get looks up a key's value from the cache. Expired items get removed.
 func (c lruCache) Get(_ context.Context, keys []string) (values [][]byte, err error) {
+	n := now().UnixNano()
 	for _, key := range keys {
-		itm, ok := c.opt.LRUCache.Get(key)
-		if ok {
-			if c.opt.TrackByObjectCount {
-				values = append(values, []byte(itm.(itemByCount)))
+		s := c.shard(key)
+		var val []byte
+		if v, ok := s.Get(key); ok {
+			if itm := v.(*lruItem); itm.expires > 0 && itm.expires <= n {
+				s.Delete(key)
 			} else {
-				values = append(values, []byte(itm.(itemBySize)))
+				val = itm.data
 			}
-		} else {
-			values = append(values, nil)
 		}
+		values = append(values, val)
 	}
 	return
 }
 
 func (c lruCache) Truncate(_ context.Context) (err error) {
-	c.opt.LRUCache.Clear()
+	for _, s := range c.shards {
+		s.Clear()
+	}
+	return nil
+}
+
+func (c lruCache) TruncatePrefix(_ context.Context, prefix string) (err error) {
+	for _, s := range c.shards {
+		for _, key := range s.Keys() {
+			if strings.HasPrefix(key, prefix) {
+				s.Delete(key)
+			}
+		}
+	}
 	return nil
 }
 
 func (c lruCache) Delete(_ context.Context, keys []string) (err error) {
 	for _, key := range keys {
-		c.opt.LRUCache.Delete(key)
+		c.shard(key).Delete(key)
 	}
 	return nil
 }
 
 func (c lruCache) Close() error {
-	c.opt.LRUCache.Clear()
-	return nil
+	return c.Truncate(context.Background())
 }
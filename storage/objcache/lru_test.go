@@ -15,9 +15,13 @@
 package objcache_test
 
 import (
+	"context"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/corestoreio/pkg/storage/objcache"
+	"github.com/corestoreio/pkg/util/assert"
 )
 
 func TestNewCacheLRU_Delete(t *testing.T) {
@@ -35,3 +39,75 @@ func TestNewCacheLRU_ComplexParallel(t *testing.T) {
 		})
 	})
 }
+
+func TestNewCacheLRU_Expires(t *testing.T) {
+	testExpiration(t, func() {
+		time.Sleep(time.Second * 2)
+	}, objcache.NewLRU(&objcache.LRUOptions{Shards: 4}), newSrvOpt(JSONCodec{}))
+}
+
+func TestNewCacheLRU_MaxEntries(t *testing.T) {
+	s, err := objcache.NewLRU(&objcache.LRUOptions{
+		TrackBySize: true,
+		Capacity:    1 << 20,
+		MaxEntries:  2,
+	})()
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	assert.NoError(t, s.Set(ctx, []string{"a", "b", "c"}, [][]byte{[]byte("1"), []byte("2"), []byte("3")}, nil))
+	vals, err := s.Get(ctx, []string{"a", "b", "c"})
+	assert.NoError(t, err)
+	assert.Exactly(t, [][]byte{nil, []byte("2"), []byte("3")}, vals)
+}
+
+func TestNewCacheLRU_Shards(t *testing.T) {
+	s, err := objcache.NewLRU(&objcache.LRUOptions{Shards: 8, Capacity: 800})()
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, s.Close()) }()
+	ctx := context.Background()
+
+	var keys []string
+	var values [][]byte
+	for i := 0; i < 100; i++ {
+		keys = append(keys, "key_"+strconv.Itoa(i))
+		values = append(values, []byte(strconv.Itoa(i)))
+	}
+	assert.NoError(t, s.Set(ctx, keys, values, nil))
+	vals, err := s.Get(ctx, keys)
+	assert.NoError(t, err)
+	assert.Exactly(t, values, vals)
+
+	assert.NoError(t, s.Delete(ctx, keys[:50]))
+	vals, err = s.Get(ctx, keys[:51])
+	assert.NoError(t, err)
+	assert.Nil(t, vals[0])
+	assert.Nil(t, vals[49])
+	assert.Exactly(t, values[50], vals[50])
+}
+
+func TestNewCacheLRU_ShardsRoundUp(t *testing.T) {
+	// MaxEntries 2 divided by 4 shards must not round down to 0, which would
+	// disable the limit.
+	s, err := objcache.NewLRU(&objcache.LRUOptions{Shards: 4, TrackBySize: true, MaxEntries: 2})()
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, s.Close()) }()
+	ctx := context.Background()
+
+	var keys []string
+	var values [][]byte
+	for i := 0; i < 100; i++ {
+		keys = append(keys, "key_"+strconv.Itoa(i))
+		values = append(values, []byte(strconv.Itoa(i)))
+	}
+	assert.NoError(t, s.Set(ctx, keys, values, nil))
+	vals, err := s.Get(ctx, keys)
+	assert.NoError(t, err)
+	var found int
+	for _, v := range vals {
+		if v != nil {
+			found++
+		}
+	}
+	assert.True(t, found > 0 && found <= 4, "found %d entries", found)
+}
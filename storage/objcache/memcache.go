@@ -0,0 +1,225 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build memcache csall
+
+package objcache
+
+import (
+	"context"
+	"hash/crc32"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/corestoreio/errors"
+)
+
+// memcacheVirtualNodes defines the amount of points per server on the hash
+// ring.
+const memcacheVirtualNodes = 160
+
+// NewMemcachedClient creates a new Memcached client for the provided servers.
+// A server can be a "host:port" TCP address or a path to a unix socket. The
+// keys get distributed across the servers with consistent hashing, so adding
+// or removing a server moves only a small part of the keys. Memcached does not
+// support a context, the operations return once the deadline of the context has
+// been exceeded but finish in the background within the client timeout.
+func NewMemcachedClient(servers ...string) NewStorageFn {
+	return func() (Storager, error) {
+		if len(servers) == 0 {
+			return nil, errors.Empty.Newf("[objcache] Memcached requires at least one server")
+		}
+		ring, err := newMemcacheRing(servers...)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return memcacheWrapper{Client: memcache.NewFromSelector(ring)}, nil
+	}
+}
+
+// memcacheRing implements memcache.ServerSelector with consistent hashing.
+type memcacheRing struct {
+	hashes []uint32
+	addrs  map[uint32]net.Addr
+	all    []net.Addr
+}
+
+func newMemcacheRing(servers ...string) (*memcacheRing, error) {
+	r := &memcacheRing{
+		addrs: make(map[uint32]net.Addr, len(servers)*memcacheVirtualNodes),
+	}
+	for _, server := range servers {
+		addr, err := resolveMemcacheAddr(server)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		r.all = append(r.all, addr)
+		for i := 0; i < memcacheVirtualNodes; i++ {
+			h := crc32.ChecksumIEEE([]byte(server + "-" + strconv.Itoa(i)))
+			if _, ok := r.addrs[h]; ok {
+				continue // collision, first server wins
+			}
+			r.addrs[h] = addr
+			r.hashes = append(r.hashes, h)
+		}
+	}
+	sort.Slice(r.hashes, func(i, j int) bool { return r.hashes[i] < r.hashes[j] })
+	return r, nil
+}
+
+func resolveMemcacheAddr(server string) (net.Addr, error) {
+	if strings.Contains(server, "/") {
+		addr, err := net.ResolveUnixAddr("unix", server)
+		if err != nil {
+			return nil, errors.NotValid.New(err, "[objcache] Memcached invalid unix socket %q", server)
+		}
+		return addr, nil
+	}
+	addr, err := net.ResolveTCPAddr("tcp", server)
+	if err != nil {
+		return nil, errors.NotValid.New(err, "[objcache] Memcached invalid server address %q", server)
+	}
+	return addr, nil
+}
+
+// PickServer returns the first server on the ring after the hash of the key.
+func (r *memcacheRing) PickServer(key string) (net.Addr, error) {
+	if len(r.hashes) == 0 {
+		return nil, memcache.ErrNoServers
+	}
+	h := crc32.ChecksumIEEE([]byte(key))
+	i := sort.Search(len(r.hashes), func(i int) bool { return r.hashes[i] >= h })
+	if i == len(r.hashes) {
+		i = 0
+	}
+	return r.addrs[r.hashes[i]], nil
+}
+
+// Each iterates over each server.
+func (r *memcacheRing) Each(fn func(net.Addr) error) error {
+	for _, a := range r.all {
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type memcacheWrapper struct {
+	*memcache.Client
+}
+
+// withContext runs fn in its own goroutine and returns early if the context
+// gets cancelled.
+func (w memcacheWrapper) withContext(ctx context.Context, fn func() ([][]byte, error)) ([][]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if ctx.Done() == nil {
+		return fn()
+	}
+	type result struct {
+		values [][]byte
+		err    error
+	}
+	resC := make(chan result, 1)
+	go func() {
+		values, err := fn()
+		resC <- result{values: values, err: err}
+	}()
+	select {
+	case res := <-resC:
+		return res.values, res.err
+	case <-ctx.Done():
+		return nil, errors.WithStack(ctx.Err())
+	}
+}
+
+// memcacheMaxRelativeExpiration defines the largest expiration in seconds which
+// Memcached treats as relative to the current time. Larger values are getting
+// interpreted as an absolute Unix timestamp.
+const memcacheMaxRelativeExpiration = 30 * 24 * 60 * 60
+
+// memcacheExpiration converts the duration into the expiration of an item.
+// Durations below one second get rounded up, because zero means no expiration.
+func memcacheExpiration(d time.Duration, now time.Time) int32 {
+	if d <= 0 {
+		return 0
+	}
+	secs := (d + time.Second - 1) / time.Second
+	if secs > memcacheMaxRelativeExpiration {
+		return int32(now.Add(d).Unix())
+	}
+	return int32(secs)
+}
+
+func (w memcacheWrapper) Set(ctx context.Context, keys []string, values [][]byte, expirations []time.Duration) error {
+	_, err := w.withContext(ctx, func() ([][]byte, error) {
+		for i, key := range keys {
+			item := &memcache.Item{Key: key, Value: values[i]}
+			if len(expirations) > 0 {
+				item.Expiration = memcacheExpiration(expirations[i], time.Now())
+			}
+			if err := w.Client.Set(item); err != nil {
+				return nil, errors.Wrapf(err, "[objcache] Memcached with key %q", key)
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
+
+func (w memcacheWrapper) Get(ctx context.Context, keys []string) ([][]byte, error) {
+	return w.withContext(ctx, func() ([][]byte, error) {
+		items, err := w.Client.GetMulti(keys)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[objcache] Memcached with keys %v", keys)
+		}
+		values := make([][]byte, 0, len(keys))
+		for _, key := range keys {
+			var val []byte
+			if it, ok := items[key]; ok {
+				val = it.Value
+			}
+			values = append(values, val)
+		}
+		return values, nil
+	})
+}
+
+func (w memcacheWrapper) Delete(ctx context.Context, keys []string) error {
+	_, err := w.withContext(ctx, func() ([][]byte, error) {
+		for _, key := range keys {
+			if err := w.Client.Delete(key); err != nil && err != memcache.ErrCacheMiss {
+				return nil, errors.Wrapf(err, "[objcache] Memcached with key %q", key)
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// Truncate flushes all keys on all servers.
+func (w memcacheWrapper) Truncate(ctx context.Context) error {
+	_, err := w.withContext(ctx, func() ([][]byte, error) {
+		return nil, errors.WithStack(w.Client.FlushAll())
+	})
+	return err
+}
+
+func (w memcacheWrapper) Close() error { return nil }
@@ -0,0 +1,76 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build memcache csall
+
+package objcache_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/storage/objcache"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func lookupMemcacheEnv(t testing.TB) []string {
+	servers := os.Getenv("CS_MEMCACHE_TEST")
+	if servers == "" {
+		t.Skip(`Skipping live test because environment CS_MEMCACHE_TEST variable not found.
+	export CS_MEMCACHE_TEST="127.0.0.1:11211"
+		`)
+	}
+	return strings.Split(servers, ",")
+}
+
+func TestNewMemcachedClient_Integration(t *testing.T) {
+	t.Parallel()
+
+	servers := lookupMemcacheEnv(t)
+
+	t.Run("expiration", func(t *testing.T) {
+		testExpiration(t, func() {
+			time.Sleep(time.Second * 2)
+		}, objcache.NewMemcachedClient(servers...), newSrvOpt(JSONCodec{}))
+	})
+	t.Run("delete", func(t *testing.T) {
+		newTestServiceDelete(t, objcache.NewMemcachedClient(servers...))
+	})
+}
+
+func TestNewMemcachedClient_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no servers", func(t *testing.T) {
+		p, err := objcache.NewService(nil, objcache.NewMemcachedClient(), newSrvOpt(JSONCodec{}))
+		assert.True(t, errors.Empty.Match(err), "%+v", err)
+		assert.True(t, p == nil, "p is not nil")
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		p, err := objcache.NewService(nil, objcache.NewMemcachedClient("127.0.0.1:53344"), newSrvOpt(JSONCodec{}))
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, p.Close()) }()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var val float64
+		err = p.Get(ctx, "key", &val)
+		assert.True(t, errors.Cause(err) == context.Canceled, "%+v", err)
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build memcache csall
+
+package objcache
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+var _ Storager = (*memcacheWrapper)(nil)
+
+func TestMemcacheRing_Consistent(t *testing.T) {
+	t.Parallel()
+
+	servers := []string{"127.0.0.1:11211", "127.0.0.1:11212", "127.0.0.1:11213"}
+	r3, err := newMemcacheRing(servers...)
+	assert.NoError(t, err)
+	r4, err := newMemcacheRing(append(servers, "127.0.0.1:11214")...)
+	assert.NoError(t, err)
+
+	const keys = 3000
+	perServer := map[string]int{}
+	moved := 0
+	for i := 0; i < keys; i++ {
+		key := "key_" + strconv.Itoa(i)
+		a3, err := r3.PickServer(key)
+		assert.NoError(t, err)
+		a4, err := r4.PickServer(key)
+		assert.NoError(t, err)
+		perServer[a3.String()]++
+		if a3.String() != a4.String() {
+			moved++
+			assert.Exactly(t, "127.0.0.1:11214", a4.String(), "Key %q must only move to the new server", key)
+		}
+	}
+	assert.Len(t, perServer, 3)
+	for addr, n := range perServer {
+		assert.True(t, n > keys/6, "Server %q has only %d keys", addr, n)
+	}
+	assert.True(t, moved > 0 && moved < keys/2, "Moved keys: %d", moved)
+}
+
+func TestMemcacheExpiration(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1600000000, 0)
+	assert.Exactly(t, int32(0), memcacheExpiration(0, now))
+	assert.Exactly(t, int32(1), memcacheExpiration(time.Millisecond, now))
+	assert.Exactly(t, int32(2), memcacheExpiration(1500*time.Millisecond, now))
+	assert.Exactly(t, int32(3600), memcacheExpiration(time.Hour, now))
+	assert.Exactly(t, int32(30*24*3600), memcacheExpiration(30*24*time.Hour, now))
+	assert.Exactly(t, int32(1600000000+31*24*3600), memcacheExpiration(31*24*time.Hour, now))
+}
+
+func TestMemcacheRing_Each(t *testing.T) {
+	t.Parallel()
+
+	r, err := newMemcacheRing("127.0.0.1:11211", "/tmp/memcached.sock")
+	assert.NoError(t, err)
+	var addrs []string
+	assert.NoError(t, r.Each(func(a net.Addr) error {
+		addrs = append(addrs, a.Network()+"://"+a.String())
+		return nil
+	}))
+	assert.Exactly(t, []string{"tcp://127.0.0.1:11211", "unix:///tmp/memcached.sock"}, addrs)
+
+	_, err = newMemcacheRing("localhost:port")
+	assert.True(t, errors.NotValid.Match(err), "%+v", err)
+}
@@ -16,6 +16,7 @@ package objcache
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 )
@@ -35,6 +36,28 @@ type ServiceOptions struct {
 	// information in the cache.
 	PrimeObjects   []interface{}
 	DefaultExpires time.Duration
+	// Invalidator optionally broadcasts the keys of Set and Delete operations
+	// to other nodes, which evict those keys from their level1 cache. Requires
+	// a level1 cache.
+	Invalidator Invalidator
+	// Level1Expires defines the expiration of items copied from level2 into
+	// level1 after a level1 miss. Zero means no expiration.
+	Level1Expires time.Duration
+	// KeyPrefix gets prepended to all keys, for example "app:v2:". Changing
+	// the prefix invalidates all previously stored keys. Truncate removes
+	// then only the keys starting with the KeyPrefix, which requires that all
+	// storages implement PrefixTruncater. The in-memory map, LRU, bolt and
+	// Redis storages do, bigcache and memcache don't, so Truncate returns an
+	// error with kind NotSupported for them. A full flush of those storages
+	// must be done via a Service without KeyPrefix.
+	KeyPrefix string
+	// OnEvent gets called after each call to a storage backend, for example to
+	// export metrics. It must be safe for concurrent use and should return
+	// quickly. Service.Stats provides the aggregated counters.
+	OnEvent func(Event)
+	// FetchTimeout limits the duration of a loader called by Fetch, which
+	// runs detached from the contexts of the waiting callers. Defaults to 30s.
+	FetchTimeout time.Duration
 }
 
 // NewCacheSimpleInmemory creates an in-memory map map[string]string as cache
@@ -51,9 +74,19 @@ type mapCacheItem struct {
 
 type mapCache struct {
 	items sync.Map
+	// mu serializes all writes, so CompareAndSwap is atomic in relation to
+	// Set, Delete and Truncate. Reads stay lock free.
+	mu sync.Mutex
 }
 
 func (mc *mapCache) Set(_ context.Context, keys []string, values [][]byte, expirations []time.Duration) (err error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.set(keys, values, expirations)
+	return nil
+}
+
+func (mc *mapCache) set(keys []string, values [][]byte, expirations []time.Duration) {
 	hasExp := len(expirations) > 0
 	n := now()
 	for i, key := range keys {
@@ -65,7 +98,6 @@ func (mc *mapCache) Set(_ context.Context, keys []string, values [][]byte, expir
 		}
 		mc.items.Store(key, &mapCacheItem{value: string(values[i]), expiration: e})
 	}
-	return nil
 }
 
 func (mc *mapCache) Get(_ context.Context, keys []string) (values [][]byte, err error) {
@@ -81,13 +113,31 @@ func (mc *mapCache) Get(_ context.Context, keys []string) (values [][]byte, err
 	return values, nil
 }
 
+func (mc *mapCache) CompareAndSwap(ctx context.Context, key string, version uint64, value []byte, expiration time.Duration) (bool, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	current, err := mc.Get(ctx, []string{key})
+	if err != nil {
+		return false, err
+	}
+	if ValueVersion(current[0]) != version {
+		return false, nil
+	}
+	mc.set([]string{key}, [][]byte{value}, []time.Duration{expiration})
+	return true, nil
+}
+
 func (mc *mapCache) Delete(_ context.Context, keys []string) (err error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
 	for _, key := range keys {
 		mc.items.Delete(key)
 	}
 	return nil
 }
 func (mc *mapCache) Truncate(ctx context.Context) (err error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
 	mc.items.Range(func(key, value interface{}) bool {
 		value = nil
 		mc.items.Delete(key)
@@ -96,6 +146,18 @@ func (mc *mapCache) Truncate(ctx context.Context) (err error) {
 	mc.items = sync.Map{}
 	return nil
 }
+
+func (mc *mapCache) TruncatePrefix(_ context.Context, prefix string) (err error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.items.Range(func(key, _ interface{}) bool {
+		if k, ok := key.(string); ok && strings.HasPrefix(k, prefix) {
+			mc.items.Delete(key)
+		}
+		return true
+	})
+	return nil
+}
 func (mc *mapCache) Close() error { return nil }
 
 // NewBlackHoleClient creates a black hole client for testing with the ability
@@ -118,6 +180,7 @@ func (mc blackHole) Get(_ context.Context, keys []string) (values [][]byte, err
 
 func (mc blackHole) Delete(_ context.Context, keys []string) (err error) { return mc.err }
 func (mc blackHole) Truncate(ctx context.Context) (err error)            { return mc.err }
+func (mc blackHole) TruncatePrefix(_ context.Context, _ string) error    { return mc.err }
 func (mc blackHole) Close() error                                        { return mc.err }
 
 // binary a simple type to use the Service as a set-algorithm to e.g. check if a
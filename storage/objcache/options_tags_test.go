@@ -62,3 +62,23 @@ func lookupRedisEnv(t testing.TB) string {
 	}
 	return redConURL
 }
+
+func lookupRedisSentinelEnv(t testing.TB) string {
+	redConURL := os.Getenv("CS_REDIS_SENTINEL_TEST")
+	if redConURL == "" {
+		t.Skip(`Skipping live test because environment CS_REDIS_SENTINEL_TEST variable not found.
+	export CS_REDIS_SENTINEL_TEST="redis-sentinel://127.0.0.1:26379/?master=mymaster&db=3"
+		`)
+	}
+	return redConURL
+}
+
+func lookupRedisClusterEnv(t testing.TB) string {
+	redConURL := os.Getenv("CS_REDIS_CLUSTER_TEST")
+	if redConURL == "" {
+		t.Skip(`Skipping live test because environment CS_REDIS_CLUSTER_TEST variable not found.
+	export CS_REDIS_CLUSTER_TEST="redis-cluster://127.0.0.1:7000/?server=127.0.0.1:7001&server=127.0.0.1:7002"
+		`)
+	}
+	return redConURL
+}
@@ -12,6 +12,7 @@ import (
 // the pool must have a maximum size.
 
 type pooledCodec struct {
+	codec       Codecer
 	encoderPool sync.Pool
 	decoderPool sync.Pool
 }
@@ -30,6 +31,7 @@ type pooledCodec struct {
 // encoded nor decoded.
 func newPooledCodec(codec Codecer, types ...interface{}) Codecer {
 	return &pooledCodec{
+		codec: codec,
 		encoderPool: sync.Pool{New: func() interface{} {
 			var enc delegateEncoder
 			enc.Encoder = codec.NewEncoder(&enc)
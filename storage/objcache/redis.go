@@ -18,13 +18,17 @@ package objcache
 
 import (
 	"context"
+	"fmt"
 	gourl "net/url"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/FZambia/sentinel"
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/pkg/net/url"
 	"github.com/gomodule/redigo/redis"
+	"github.com/mna/redisc"
 )
 
 // RedisOption applies several options for the Redis client.
@@ -79,66 +83,136 @@ func parseInt(keys []string, ds []*int, params gourl.Values) (err error) {
 // On error, while parsing the rawURL, this function will leak sensitive data,
 // for now.
 //
+// The scheme "redis-sentinel" asks the Sentinels for the address of the master
+// whose name must be set with parameter "master". The scheme "redis-cluster"
+// connects to a Redis Cluster, see NewRedisClusterClient. Further Sentinel or
+// Cluster nodes can be added with the parameter "server".
+//
 // For example:
 // 		redis://localhost:6379/?db=3
 // 		redis://localhost:6379/?max_active=50&max_idle=5&idle_timeout=10s&max_conn_lifetime=1m&key_prefix=xcache_
+// 		redis-sentinel://localhost:26379/?server=localhost:26380&master=mymaster&db=3
+// 		redis-cluster://localhost:7000/?server=localhost:7001&server=localhost:7002
 func NewRedisByURLClient(rawURL string) NewStorageFn {
 	return func() (Storager, error) {
 		addr, _, password, params, err := url.ParseConnection(rawURL)
 		if err != nil {
 			return nil, errors.Wrapf(err, "[objcache] Redis error parsing URL %q", rawURL)
 		}
-		pool := &redis.Pool{
-			Dial: func() (redis.Conn, error) {
-				c, err := redis.Dial("tcp", addr)
+		addrs := append([]string{addr}, params["server"]...)
+		ro := &RedisOption{
+			KeyPrefix: params.Get("key_prefix"),
+		}
+
+		switch params.Get("scheme") {
+		case "redis-cluster":
+			cluster := &redisc.Cluster{
+				StartupNodes: addrs,
+				CreatePool: func(addr string, _ ...redis.DialOption) (*redis.Pool, error) {
+					return newRedisPool(func() (redis.Conn, error) {
+						return redisDial(addr, password, "")
+					}, params)
+				},
+			}
+			return NewRedisClusterClient(cluster, ro)()
+		case "redis-sentinel":
+			master := params.Get("master")
+			if master == "" {
+				return nil, errors.Empty.Newf("[objcache] Redis Sentinel requires the parameter master in URL %q", rawURL)
+			}
+			snt := &sentinel.Sentinel{
+				Addrs:      addrs,
+				MasterName: master,
+				Dial: func(addr string) (redis.Conn, error) {
+					return redis.Dial("tcp", addr)
+				},
+			}
+			pool, err := newRedisPool(func() (redis.Conn, error) {
+				masterAddr, err := snt.MasterAddr()
 				if err != nil {
-					return nil, errors.Fatal.New(err, "[objcache] Redis Dial failed")
-				}
-				if password != "" {
-					if _, err := c.Do("AUTH", password); err != nil {
-						c.Close()
-						return nil, errors.Unauthorized.New(err, "[objcache] Redis AUTH failed")
-					}
+					return nil, errors.ConnectionFailed.New(err, "[objcache] Redis Sentinel master %q lookup failed", master)
 				}
-				if _, err := c.Do("SELECT", params.Get("db")); err != nil {
-					c.Close()
-					return nil, errors.Fatal.New(err, "[objcache] Redis DB select failed")
+				return redisDial(masterAddr, password, params.Get("db"))
+			}, params)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			// a failover turns the old master into a replica, so the pooled
+			// connection must be dropped.
+			pool.TestOnBorrow = func(c redis.Conn, _ time.Time) error {
+				if !sentinel.TestRole(c, "master") {
+					return errors.NotValid.Newf("[objcache] Redis connection is not a master")
 				}
-				return c, nil
-			},
+				return nil
+			}
+			return NewRedisClient(pool, ro)()
 		}
 
-		// add some more params if missing
-		err = parseDuration([]string{
-			"max_conn_lifetime",
-			"idle_timeout",
-		}, []*time.Duration{
-			&pool.MaxConnLifetime,
-			&pool.IdleTimeout,
+		pool, err := newRedisPool(func() (redis.Conn, error) {
+			return redisDial(addr, password, params.Get("db"))
 		}, params)
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
+		return NewRedisClient(pool, ro)()
+	}
+}
 
-		err = parseInt([]string{
-			"max_idle",
-			"max_active",
-		}, []*int{
-			&pool.MaxIdle,
-			&pool.MaxActive,
-		}, params)
-		if err != nil {
-			return nil, errors.WithStack(err)
+// redisDial connects to addr, authenticates if a password has been provided
+// and selects the database, if db is not empty.
+func redisDial(addr, password, db string) (redis.Conn, error) {
+	c, err := redis.Dial("tcp", addr)
+	if err != nil {
+		return nil, errors.Fatal.New(err, "[objcache] Redis Dial failed")
+	}
+	if password != "" {
+		if _, err := c.Do("AUTH", password); err != nil {
+			c.Close()
+			return nil, errors.Unauthorized.New(err, "[objcache] Redis AUTH failed")
 		}
+	}
+	if db != "" {
+		if _, err := c.Do("SELECT", db); err != nil {
+			c.Close()
+			return nil, errors.Fatal.New(err, "[objcache] Redis DB select failed")
+		}
+	}
+	return c, nil
+}
 
-		// if params.Get("tls") == "1" {
-		// 	o.TLSConfig = &tls.Config{ServerName: o.Addr} // TODO check if might be wrong the Addr,
-		// }
+// newRedisPool creates a new pool and applies the pool parameters of the URL.
+func newRedisPool(dial func() (redis.Conn, error), params gourl.Values) (*redis.Pool, error) {
+	pool := &redis.Pool{
+		Dial: dial,
+	}
 
-		return NewRedisClient(pool, &RedisOption{
-			KeyPrefix: params.Get("key_prefix"),
-		})()
+	// add some more params if missing
+	err := parseDuration([]string{
+		"max_conn_lifetime",
+		"idle_timeout",
+	}, []*time.Duration{
+		&pool.MaxConnLifetime,
+		&pool.IdleTimeout,
+	}, params)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	err = parseInt([]string{
+		"max_idle",
+		"max_active",
+	}, []*int{
+		&pool.MaxIdle,
+		&pool.MaxActive,
+	}, params)
+	if err != nil {
+		return nil, errors.WithStack(err)
 	}
+
+	// if params.Get("tls") == "1" {
+	// 	o.TLSConfig = &tls.Config{ServerName: o.Addr} // TODO check if might be wrong the Addr,
+	// }
+	return pool, nil
 }
 
 func doPing(w redisWrapper) error {
@@ -185,27 +259,44 @@ func (w redisWrapper) Set(_ context.Context, keys []string, values [][]byte, exp
 			err = err2
 		}
 	}()
+	return redisSet(conn, keys, values, expirations)
+}
 
-	args := make([]interface{}, 0, len(keys)*3)
+// redisSet writes the keys with one round trip. Keys without expiration get
+// written with one MSET, keys with an expiration get pipelined with SETEX.
+func redisSet(conn redis.Conn, keys []string, values [][]byte, expirations []time.Duration) (err error) {
+	args := make([]interface{}, 0, len(keys)*2)
+	pipelined := 0
 	for i, key := range keys {
-		e := expirations[i].Seconds() // e = expires in x seconds
+		var e int64 // e = expires in x seconds
+		if len(expirations) > 0 {
+			e = int64(expirations[i].Seconds())
+		}
 		if e < 1 {
 			args = append(args, key, values[i])
-		} else {
-			if _, err2 := conn.Do("SETEX", key, e, values[i]); err2 != nil {
-				err = errors.Wrapf(err2, "[objcache] With key %q", key)
-				return
-			}
+			continue
+		}
+		if err2 := conn.Send("SETEX", key, e, values[i]); err2 != nil {
+			return errors.Wrapf(err2, "[objcache] With key %q", key)
 		}
+		pipelined++
 	}
 
 	if la := len(args); la > 0 && la%2 == 0 {
-		if _, err2 := conn.Do("MSET", args...); err2 != nil {
-			err = errors.Wrapf(err2, "[objcache] With keys %v", keys)
-			return
+		if err2 := conn.Send("MSET", args...); err2 != nil {
+			return errors.Wrapf(err2, "[objcache] With keys %v", keys)
 		}
+		pipelined++
 	}
 
+	if err2 := conn.Flush(); err2 != nil {
+		return errors.Wrapf(err2, "[objcache] With keys %v", keys)
+	}
+	for i := 0; i < pipelined; i++ {
+		if _, err2 := conn.Receive(); err2 != nil && err == nil {
+			err = errors.Wrapf(err2, "[objcache] With keys %v", keys)
+		}
+	}
 	return err
 }
 
@@ -216,7 +307,11 @@ func (w redisWrapper) Get(_ context.Context, keys []string) (values [][]byte, er
 			err = err2
 		}
 	}()
+	return redisGet(conn, keys)
+}
 
+// redisGet reads the keys with GET or MGET. Missing keys have a nil value.
+func redisGet(conn redis.Conn, keys []string) (values [][]byte, err error) {
 	if len(keys) == 1 {
 		var val []byte
 		val, err = redis.Bytes(conn.Do("GET", keys[0]))
@@ -254,6 +349,39 @@ func strSliceToIFaces(ret []interface{}, sl []string) []interface{} {
 	return ret
 }
 
+func (w redisWrapper) CompareAndSwap(_ context.Context, key string, version uint64, value []byte, expiration time.Duration) (swapped bool, err error) {
+	conn := w.Pool.Get()
+	defer func() {
+		if err2 := conn.Close(); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+	return redisCompareAndSwap(conn, key, version, value, expiration)
+}
+
+// redisCASScript compares the version of the current value, see ValueVersion,
+// and writes the new value atomically.
+var redisCASScript = redis.NewScript(1, `
+local v = redis.call('GET', KEYS[1])
+local cur = '0000000000000000'
+if v then cur = string.sub(redis.sha1hex(v), 1, 16) end
+if cur ~= ARGV[1] then return 0 end
+if tonumber(ARGV[3]) > 0 then
+	redis.call('SET', KEYS[1], ARGV[2], 'EX', ARGV[3])
+else
+	redis.call('SET', KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+func redisCompareAndSwap(conn redis.Conn, key string, version uint64, value []byte, expiration time.Duration) (bool, error) {
+	swapped, err := redis.Bool(redisCASScript.Do(conn, key, fmt.Sprintf("%016x", version), value, int64(expiration.Seconds())))
+	if err != nil {
+		return false, errors.Wrapf(err, "[objcache] Redis CompareAndSwap with key %q", key)
+	}
+	return swapped, nil
+}
+
 func (w redisWrapper) Delete(_ context.Context, keys []string) (err error) {
 	conn := w.Pool.Get()
 	defer func() {
@@ -267,11 +395,67 @@ func (w redisWrapper) Delete(_ context.Context, keys []string) (err error) {
 	return
 }
 
-func (w redisWrapper) Truncate(ctx context.Context) (err error) {
-	// TODO flush redis by key prefix
-	return nil
+// Truncate removes all keys of the selected database with FLUSHDB. Use a
+// Service with a KeyPrefix to remove only its own keys.
+func (w redisWrapper) Truncate(_ context.Context) (err error) {
+	conn := w.Pool.Get()
+	defer func() {
+		if err2 := conn.Close(); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+	if _, err = conn.Do("FLUSHDB"); err != nil {
+		err = errors.Wrap(err, "[objcache] Redis FLUSHDB")
+	}
+	return
 }
 
+// TruncatePrefix deletes all keys starting with prefix. The keys get collected
+// with SCAN, hence it does not block the Redis server like KEYS.
+func (w redisWrapper) TruncatePrefix(ctx context.Context, prefix string) (err error) {
+	conn := w.Pool.Get()
+	defer func() {
+		if err2 := conn.Close(); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+	return redisScanDelete(ctx, conn, prefix, func(keys []string) error {
+		_, err := conn.Do("DEL", strSliceToIFaces(nil, keys)...)
+		return err
+	})
+}
+
+// redisScanDelete iterates with SCAN over all keys starting with prefix and
+// calls del for each batch of found keys.
+func redisScanDelete(ctx context.Context, conn redis.Conn, prefix string, del func(keys []string) error) error {
+	match := redisGlobEscaper.Replace(prefix) + "*"
+	cursor := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return errors.WithStack(err)
+		}
+		reply, err := redis.Values(conn.Do("SCAN", cursor, "MATCH", match, "COUNT", 1000))
+		if err != nil {
+			return errors.Wrapf(err, "[objcache] Redis SCAN with prefix %q", prefix)
+		}
+		var keys []string
+		if _, err = redis.Scan(reply, &cursor, &keys); err != nil {
+			return errors.Wrapf(err, "[objcache] Redis SCAN with prefix %q", prefix)
+		}
+		if len(keys) > 0 {
+			if err = del(keys); err != nil {
+				return errors.Wrapf(err, "[objcache] With keys %v", keys)
+			}
+		}
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// redisGlobEscaper escapes the special characters of the MATCH pattern.
+var redisGlobEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
 func (w redisWrapper) Close() error {
 	return w.Pool.Close()
 }
@@ -0,0 +1,203 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build redis csall
+
+package objcache
+
+import (
+	"context"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/gomodule/redigo/redis"
+	"github.com/mna/redisc"
+)
+
+// NewRedisClusterClient connects to a Redis Cluster and loads the mapping of
+// the hash slots to the nodes. Operations with several keys get split into
+// batches per hash slot, hence MGET and MSET work without CROSSSLOT errors.
+// Keys sharing a hash tag, like "{product}:1" and "{product}:2", end up in the
+// same batch.
+func NewRedisClusterClient(cluster *redisc.Cluster, ro *RedisOption) NewStorageFn {
+	return func() (Storager, error) {
+		if err := cluster.Refresh(); err != nil {
+			return nil, errors.ConnectionFailed.New(err, "[objcache] Redis Cluster refresh failed with nodes %v", cluster.StartupNodes)
+		}
+		w := redisClusterWrapper{
+			cluster: cluster,
+		}
+		if ro != nil {
+			w.keyPrefix = ro.KeyPrefix
+		}
+		return w, nil
+	}
+}
+
+type redisClusterWrapper struct {
+	cluster   *redisc.Cluster
+	keyPrefix string
+}
+
+// redisSlotGroup contains the indexes of all keys belonging to the same hash
+// slot.
+type redisSlotGroup struct {
+	slot int
+	idx  []int
+}
+
+// groupBySlot groups the keys by their hash slot. The order of the groups
+// follows the first occurrence of a slot in keys.
+func groupBySlot(keys []string) []redisSlotGroup {
+	var groups []redisSlotGroup
+	pos := make(map[int]int, len(keys))
+	for i, key := range keys {
+		slot := redisc.Slot(key)
+		gi, ok := pos[slot]
+		if !ok {
+			gi = len(groups)
+			pos[slot] = gi
+			groups = append(groups, redisSlotGroup{slot: slot})
+		}
+		groups[gi].idx = append(groups[gi].idx, i)
+	}
+	return groups
+}
+
+// withSlotConn calls fn with a connection bound to the node serving the hash
+// slot of key.
+func (w redisClusterWrapper) withSlotConn(key string, fn func(conn redis.Conn) error) (err error) {
+	conn := w.cluster.Get()
+	defer func() {
+		if err2 := conn.Close(); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+	if err = redisc.BindConn(conn, key); err != nil {
+		return errors.Wrapf(err, "[objcache] Redis Cluster bind with key %q", key)
+	}
+	return fn(conn)
+}
+
+func (w redisClusterWrapper) Set(_ context.Context, keys []string, values [][]byte, expirations []time.Duration) error {
+	for _, g := range groupBySlot(keys) {
+		gKeys := make([]string, len(g.idx))
+		gValues := make([][]byte, len(g.idx))
+		var gExpires []time.Duration
+		if len(expirations) > 0 {
+			gExpires = make([]time.Duration, len(g.idx))
+		}
+		for i, idx := range g.idx {
+			gKeys[i] = keys[idx]
+			gValues[i] = values[idx]
+			if gExpires != nil {
+				gExpires[i] = expirations[idx]
+			}
+		}
+		if err := w.withSlotConn(gKeys[0], func(conn redis.Conn) error {
+			return redisSet(conn, gKeys, gValues, gExpires)
+		}); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+func (w redisClusterWrapper) Get(_ context.Context, keys []string) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+	for _, g := range groupBySlot(keys) {
+		gKeys := make([]string, len(g.idx))
+		for i, idx := range g.idx {
+			gKeys[i] = keys[idx]
+		}
+		if err := w.withSlotConn(gKeys[0], func(conn redis.Conn) error {
+			gValues, err := redisGet(conn, gKeys)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			for i, idx := range g.idx {
+				values[idx] = gValues[i]
+			}
+			return nil
+		}); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return values, nil
+}
+
+func (w redisClusterWrapper) CompareAndSwap(_ context.Context, key string, version uint64, value []byte, expiration time.Duration) (swapped bool, err error) {
+	err = w.withSlotConn(key, func(conn redis.Conn) (err error) {
+		swapped, err = redisCompareAndSwap(conn, key, version, value, expiration)
+		return err
+	})
+	return swapped, errors.WithStack(err)
+}
+
+func (w redisClusterWrapper) Delete(_ context.Context, keys []string) error {
+	for _, g := range groupBySlot(keys) {
+		gKeys := make([]string, len(g.idx))
+		for i, idx := range g.idx {
+			gKeys[i] = keys[idx]
+		}
+		if err := w.withSlotConn(gKeys[0], func(conn redis.Conn) error {
+			if _, err := conn.Do("DEL", strSliceToIFaces(nil, gKeys)...); err != nil {
+				return errors.Wrapf(err, "[objcache] With keys %v", gKeys)
+			}
+			return nil
+		}); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// Truncate removes all keys on all master nodes with FLUSHDB. Use a Service
+// with a KeyPrefix to remove only its own keys.
+func (w redisClusterWrapper) Truncate(_ context.Context) error {
+	return w.cluster.EachNode(false, func(addr string, conn redis.Conn) error {
+		if _, err := conn.Do("FLUSHDB"); err != nil {
+			return errors.Wrapf(err, "[objcache] Redis Cluster FLUSHDB on node %q", addr)
+		}
+		return nil
+	})
+}
+
+// TruncatePrefix deletes on all master nodes the keys starting with prefix.
+func (w redisClusterWrapper) TruncatePrefix(ctx context.Context, prefix string) error {
+	return w.cluster.EachNode(false, func(addr string, conn redis.Conn) error {
+		err := redisScanDelete(ctx, conn, prefix, func(keys []string) error {
+			// a node serves several hash slots and DEL with keys of different
+			// slots fails with CROSSSLOT.
+			for _, g := range groupBySlot(keys) {
+				args := make([]interface{}, len(g.idx))
+				for i, idx := range g.idx {
+					args[i] = keys[idx]
+				}
+				if _, err := conn.Do("DEL", args...); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "[objcache] Redis Cluster node %q", addr)
+		}
+		return nil
+	})
+}
+
+func (w redisClusterWrapper) Close() error {
+	return w.cluster.Close()
+}
@@ -0,0 +1,43 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build redis csall
+
+package objcache
+
+import (
+	"testing"
+
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/mna/redisc"
+)
+
+var _ Storager = (*redisClusterWrapper)(nil)
+var _ PrefixTruncater = (*redisClusterWrapper)(nil)
+
+func TestGroupBySlot(t *testing.T) {
+	keys := []string{"{product}:1", "cms:1", "{product}:2", "cms:1:de", "{product}:3"}
+	groups := groupBySlot(keys)
+
+	assert.Exactly(t, redisSlotGroup{slot: redisc.Slot("{product}:1"), idx: []int{0, 2, 4}}, groups[0])
+	var total int
+	for _, g := range groups {
+		for _, idx := range g.idx {
+			assert.Exactly(t, g.slot, redisc.Slot(keys[idx]))
+		}
+		total += len(g.idx)
+	}
+	assert.Exactly(t, len(keys), total)
+	assert.Nil(t, groupBySlot(nil))
+}
@@ -17,8 +17,13 @@
 package objcache_test
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -148,3 +153,295 @@ func TestWithRedisURLReal_Delete(t *testing.T) {
 	redConURL := lookupRedisEnv(t)
 	newTestServiceDelete(t, objcache.NewRedisByURLClient(redConURL))
 }
+
+func TestNewRedisInvalidator_Integration(t *testing.T) {
+	t.Parallel()
+
+	redConURL := lookupRedisEnv(t)
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) { return redis.DialURL(redConURL) },
+	}
+	defer pool.Close()
+	channel := "objcache_test_" + strs.RandAlnum(8)
+
+	invA := objcache.NewRedisInvalidator(pool, channel)
+	invB := objcache.NewRedisInvalidator(pool, channel)
+	received := make(chan []string, 2)
+	assert.NoError(t, invA.Subscribe(func(keys []string) { received <- keys }))
+	assert.NoError(t, invB.Subscribe(func(keys []string) { t.Errorf("Node B must not receive its own keys: %v", keys) }))
+
+	ctx := context.Background()
+	assert.NoError(t, invB.Publish(ctx, []string{"product:1", "product:2"}))
+	select {
+	case keys := <-received:
+		assert.Exactly(t, []string{"product:1", "product:2"}, keys)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for the invalidation message")
+	}
+
+	assert.NoError(t, invA.Close())
+	assert.NoError(t, invB.Close())
+}
+
+func TestWithRedisURL_SetMulti_Pipelined(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.NewMiniRedis()
+	assert.NoError(t, mr.Start())
+	defer mr.Close()
+
+	p, err := objcache.NewService(nil, objcache.NewRedisByURLClient("redis://"+mr.Addr()), newSrvOpt(JSONCodec{}))
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+	ctx := context.TODO()
+
+	keys := []string{"pipe_1", "pipe_2", "pipe_3"}
+	err = p.SetMulti(ctx, keys, []interface{}{1, 2, 3}, []time.Duration{0, time.Second, 0})
+	assert.NoError(t, err, "%+v", err)
+
+	var v1, v2, v3 int
+	assert.NoError(t, p.GetMulti(ctx, keys, []interface{}{&v1, &v2, &v3}))
+	assert.Exactly(t, []int{1, 2, 3}, []int{v1, v2, v3})
+
+	mr.FastForward(time.Second * 2)
+	v1, v2, v3 = 0, 0, 0
+	assert.NoError(t, p.GetMulti(ctx, keys, []interface{}{&v1, &v2, &v3}))
+	assert.Exactly(t, []int{1, 0, 3}, []int{v1, v2, v3})
+
+	assert.NoError(t, p.Delete(ctx, keys...))
+	v1, v2, v3 = 0, 0, 0
+	assert.NoError(t, p.GetMulti(ctx, keys, []interface{}{&v1, &v2, &v3}))
+	assert.Exactly(t, []int{0, 0, 0}, []int{v1, v2, v3})
+}
+
+func TestWithRedisURL_TruncatePrefix(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.NewMiniRedis()
+	assert.NoError(t, mr.Start())
+	defer mr.Close()
+
+	p, err := objcache.NewService(nil, objcache.NewRedisByURLClient("redis://"+mr.Addr()), &objcache.ServiceOptions{
+		Codec:     JSONCodec{},
+		KeyPrefix: "app:v2:",
+	})
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+	ctx := context.TODO()
+
+	keys := []string{"catalog:1", "catalog:2", "cms:1", "cms*:1"}
+	assert.NoError(t, p.SetMulti(ctx, keys, []interface{}{1, 2, 3, 4}, nil))
+	assert.NoError(t, mr.Set("other:1", "5"))
+	assert.True(t, mr.Exists("app:v2:catalog:1"))
+
+	assert.NoError(t, p.TruncatePrefix(ctx, "cms*"))
+	assert.True(t, mr.Exists("app:v2:cms:1"), "glob characters must be escaped")
+	assert.False(t, mr.Exists("app:v2:cms*:1"))
+
+	assert.NoError(t, p.TruncatePrefix(ctx, "catalog:"))
+	assert.False(t, mr.Exists("app:v2:catalog:1"))
+	assert.False(t, mr.Exists("app:v2:catalog:2"))
+	assert.True(t, mr.Exists("app:v2:cms:1"))
+
+	assert.NoError(t, p.Truncate(ctx))
+	assert.Exactly(t, []string{"other:1"}, mr.Keys())
+}
+
+func TestWithRedisURL_Sentinel_MissingMaster(t *testing.T) {
+	t.Parallel()
+
+	p, err := objcache.NewService(nil, objcache.NewRedisByURLClient("redis-sentinel://localhost:26379/?server=localhost:26380"), newSrvOpt(JSONCodec{}))
+	assert.Nil(t, p)
+	assert.True(t, errors.Empty.Match(err), "%+v", err)
+}
+
+// fakeSentinel starts a server which answers the command SENTINEL
+// get-master-addr-by-name with masterAddr. All other commands fail.
+func fakeSentinel(t *testing.T, masterAddr string) (addr string, closeFn func()) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	host, port, err := net.SplitHostPort(masterAddr)
+	assert.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				br := bufio.NewReader(conn)
+				for {
+					args, err := readRESPCommand(br)
+					if err != nil {
+						return
+					}
+					if len(args) == 3 && strings.EqualFold(args[0], "SENTINEL") {
+						fmt.Fprintf(conn, "*2\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n", len(host), host, len(port), port)
+						continue
+					}
+					fmt.Fprint(conn, "-ERR unknown command\r\n")
+				}
+			}(conn)
+		}
+	}()
+	return ln.Addr().String(), func() { _ = ln.Close() }
+}
+
+// readRESPCommand reads a command sent as an array of bulk strings.
+func readRESPCommand(br *bufio.Reader) ([]string, error) {
+	readLine := func(prefix byte) (int, error) {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return 0, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if len(line) < 2 || line[0] != prefix {
+			return 0, fmt.Errorf("unexpected line %q", line)
+		}
+		return strconv.Atoi(line[1:])
+	}
+	n, err := readLine('*')
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		l, err := readLine('$')
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, l+2)
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:l])
+	}
+	return args, nil
+}
+
+func TestWithRedisURL_Sentinel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fake sentinel with miniredis", func(t *testing.T) {
+		mr := miniredis.NewMiniRedis()
+		assert.NoError(t, mr.Start())
+		defer mr.Close()
+		sntAddr, closeSnt := fakeSentinel(t, mr.Addr())
+		defer closeSnt()
+
+		p, err := objcache.NewService(nil, objcache.NewRedisByURLClient("redis-sentinel://"+sntAddr+"/?master=mymaster"), newSrvOpt(JSONCodec{}))
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, p.Close()) }()
+		ctx := context.TODO()
+
+		assert.NoError(t, p.Set(ctx, "product:1", "Gopher", 0))
+		assert.True(t, mr.Exists("product:1"), "key must be written into the master")
+		var name string
+		assert.NoError(t, p.Get(ctx, "product:1", &name))
+		assert.Exactly(t, "Gopher", name)
+
+		assert.NoError(t, p.Truncate(ctx))
+		assert.Empty(t, mr.Keys())
+	})
+
+	t.Run("real sentinel integration", func(t *testing.T) {
+		redConURL := lookupRedisSentinelEnv(t)
+		testExpiration(t, func() {
+			time.Sleep(time.Second * 2)
+		}, objcache.NewRedisByURLClient(redConURL), newSrvOpt(JSONCodec{}))
+	})
+}
+
+func TestWithRedisURL_Truncate(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.NewMiniRedis()
+	assert.NoError(t, mr.Start())
+	defer mr.Close()
+
+	p, err := objcache.NewService(nil, objcache.NewRedisByURLClient("redis://"+mr.Addr()), newSrvOpt(JSONCodec{}))
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+	ctx := context.TODO()
+
+	assert.NoError(t, p.SetMulti(ctx, []string{"catalog:1", "cms:1"}, []interface{}{1, 2}, nil))
+	assert.NoError(t, mr.Set("other:1", "5"))
+	assert.NoError(t, p.Truncate(ctx))
+	assert.Empty(t, mr.Keys())
+}
+
+func TestWithRedisURL_Cluster_Integration(t *testing.T) {
+	t.Parallel()
+
+	redConURL := lookupRedisClusterEnv(t)
+
+	t.Run("expiration", func(t *testing.T) {
+		testExpiration(t, func() {
+			time.Sleep(time.Second * 2)
+		}, objcache.NewRedisByURLClient(redConURL), newSrvOpt(JSONCodec{}))
+	})
+	t.Run("delete", func(t *testing.T) {
+		newTestServiceDelete(t, objcache.NewRedisByURLClient(redConURL))
+	})
+	t.Run("multi keys across slots", func(t *testing.T) {
+		p, err := objcache.NewService(nil, objcache.NewRedisByURLClient(redConURL), &objcache.ServiceOptions{
+			Codec:     JSONCodec{},
+			KeyPrefix: "objcache_cluster_",
+		})
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, p.Close()) }()
+		ctx := context.TODO()
+
+		keys := []string{"catalog:1", "catalog:2", "cms:1", "{tag}:1", "{tag}:2"}
+		assert.NoError(t, p.SetMulti(ctx, keys, []interface{}{1, 2, 3, 4, 5}, []time.Duration{0, time.Minute, 0, time.Minute, 0}))
+
+		vals := make([]int, len(keys))
+		dst := make([]interface{}, len(keys))
+		for i := range vals {
+			dst[i] = &vals[i]
+		}
+		assert.NoError(t, p.GetMulti(ctx, keys, dst))
+		assert.Exactly(t, []int{1, 2, 3, 4, 5}, vals)
+
+		assert.NoError(t, p.TruncatePrefix(ctx, "catalog:"))
+		vals = make([]int, len(keys))
+		for i := range vals {
+			dst[i] = &vals[i]
+		}
+		assert.NoError(t, p.GetMulti(ctx, keys, dst))
+		assert.Exactly(t, []int{0, 0, 3, 4, 5}, vals)
+
+		assert.NoError(t, p.Delete(ctx, keys...))
+	})
+	t.Run("truncate", func(t *testing.T) {
+		p, err := objcache.NewService(nil, objcache.NewRedisByURLClient(redConURL), newSrvOpt(JSONCodec{}))
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, p.Close()) }()
+		ctx := context.TODO()
+
+		keys := []string{"catalog:1", "cms:1", "{tag}:1"}
+		assert.NoError(t, p.SetMulti(ctx, keys, []interface{}{1, 2, 3}, nil))
+		assert.NoError(t, p.Truncate(ctx))
+
+		vals := make([]int, len(keys))
+		dst := []interface{}{&vals[0], &vals[1], &vals[2]}
+		assert.NoError(t, p.GetMulti(ctx, keys, dst))
+		assert.Exactly(t, []int{0, 0, 0}, vals)
+	})
+}
+
+func TestWithRedisURL_CompareAndSet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("miniredis", func(t *testing.T) {
+		mr := miniredis.NewMiniRedis()
+		assert.NoError(t, mr.Start())
+		defer mr.Close()
+		testCompareAndSet(t, objcache.NewRedisByURLClient("redis://"+mr.Addr()))
+	})
+	t.Run("real redis integration", func(t *testing.T) {
+		testCompareAndSet(t, objcache.NewRedisByURLClient(lookupRedisEnv(t)))
+	})
+}
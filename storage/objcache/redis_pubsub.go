@@ -0,0 +1,161 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build redis csall
+
+package objcache
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/strs"
+	"github.com/gomodule/redigo/redis"
+)
+
+// redisInvalidationMsg gets published to the Redis channel. The NodeID
+// prevents a node from evicting its own freshly written level1 entries.
+type redisInvalidationMsg struct {
+	NodeID string   `json:"n"`
+	Keys   []string `json:"k"`
+}
+
+// NewRedisInvalidator creates an Invalidator which uses Redis pub/sub to
+// broadcast the keys of Set and Delete operations to all nodes subscribed to
+// the same channel. Each node must use its own Invalidator. After a lost
+// connection the subscription gets re-established, keys published in between
+// get lost, so level1 should have an expiration via Level1Expires.
+func NewRedisInvalidator(pool *redis.Pool, channel string) Invalidator {
+	return &redisInvalidator{
+		pool:    pool,
+		channel: channel,
+		nodeID:  strs.RandAlnum(16),
+		done:    make(chan struct{}),
+	}
+}
+
+type redisInvalidator struct {
+	pool    *redis.Pool
+	channel string
+	nodeID  string
+
+	mu   sync.Mutex
+	psc  *redis.PubSubConn
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func (ri *redisInvalidator) Publish(_ context.Context, keys []string) (err error) {
+	data, err := json.Marshal(redisInvalidationMsg{NodeID: ri.nodeID, Keys: keys})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	conn := ri.pool.Get()
+	defer func() {
+		if err2 := conn.Close(); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+	if _, err = conn.Do("PUBLISH", ri.channel, data); err != nil {
+		err = errors.Wrapf(err, "[objcache] Redis PUBLISH to channel %q", ri.channel)
+	}
+	return
+}
+
+// Subscribe starts a goroutine which receives the messages of other nodes.
+func (ri *redisInvalidator) Subscribe(fn func(keys []string)) error {
+	psc, err := ri.subscribe()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	ri.wg.Add(1)
+	go ri.receive(psc, fn)
+	return nil
+}
+
+func (ri *redisInvalidator) subscribe() (*redis.PubSubConn, error) {
+	psc := &redis.PubSubConn{Conn: ri.pool.Get()}
+	if err := psc.Subscribe(ri.channel); err != nil {
+		_ = psc.Close()
+		return nil, errors.ConnectionFailed.New(err, "[objcache] Redis SUBSCRIBE to channel %q", ri.channel)
+	}
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	if ri.isClosed() {
+		_ = psc.Close()
+		return nil, errors.AlreadyClosed.Newf("[objcache] Redis invalidator already closed")
+	}
+	ri.psc = psc
+	return psc, nil
+}
+
+func (ri *redisInvalidator) isClosed() bool {
+	select {
+	case <-ri.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (ri *redisInvalidator) receive(psc *redis.PubSubConn, fn func(keys []string)) {
+	defer ri.wg.Done()
+	for {
+		switch v := psc.Receive().(type) {
+		case redis.Message:
+			var msg redisInvalidationMsg
+			if err := json.Unmarshal(v.Data, &msg); err == nil && msg.NodeID != ri.nodeID && len(msg.Keys) > 0 {
+				fn(msg.Keys)
+			}
+		case error:
+			_ = psc.Close()
+			// reconnect until the subscription works again or Close gets
+			// called.
+			for {
+				if ri.isClosed() {
+					return
+				}
+				var err error
+				if psc, err = ri.subscribe(); err == nil {
+					break
+				}
+				select {
+				case <-ri.done:
+					return
+				case <-time.After(time.Second):
+				}
+			}
+		}
+	}
+}
+
+// Close unsubscribes from the channel and waits until the receiving goroutine
+// has been stopped.
+func (ri *redisInvalidator) Close() error {
+	if ri.isClosed() {
+		return nil
+	}
+	close(ri.done)
+	ri.mu.Lock()
+	var err error
+	if ri.psc != nil {
+		err = ri.psc.Close()
+	}
+	ri.mu.Unlock()
+	ri.wg.Wait()
+	return errors.WithStack(err)
+}
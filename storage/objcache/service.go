@@ -17,12 +17,16 @@ package objcache
 import (
 	"bytes"
 	"context"
+	"crypto/sha1"
 	"encoding"
+	encbin "encoding/binary" // package level type binary exists
 	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sync/singleflight"
 	"github.com/corestoreio/pkg/util/bufferpool"
 )
 
@@ -46,6 +50,41 @@ type Storager interface {
 
 type NewStorageFn func() (Storager, error)
 
+// PrefixTruncater can be implemented by a Storager to delete all keys starting
+// with a prefix. It gets used by Service.TruncatePrefix.
+type PrefixTruncater interface {
+	TruncatePrefix(ctx context.Context, prefix string) error
+}
+
+// CompareAndSwapper can be implemented by a Storager to write a value only if
+// the current value of the key has not been changed. The version of a value
+// gets calculated with function ValueVersion. A version of zero requires that
+// the key does not exist. It gets used by Service.CompareAndSet.
+type CompareAndSwapper interface {
+	CompareAndSwap(ctx context.Context, key string, version uint64, value []byte, expiration time.Duration) (swapped bool, err error)
+}
+
+// ValueVersion returns the version of a raw cached value, which are the first
+// eight bytes of its SHA1 hash. A nil value has the version zero.
+func ValueVersion(value []byte) uint64 {
+	if value == nil {
+		return 0
+	}
+	sum := sha1.Sum(value)
+	return encbin.BigEndian.Uint64(sum[:8])
+}
+
+// Invalidator broadcasts changed keys between several nodes, each running its
+// own Service with a local level1 cache. Must be safe for concurrent usage.
+type Invalidator interface {
+	// Publish sends the keys to all other nodes.
+	Publish(ctx context.Context, keys []string) error
+	// Subscribe calls fn with the keys published by other nodes until Close
+	// gets called. Subscribe must not block.
+	Subscribe(fn func(keys []string)) error
+	Close() error
+}
+
 // Codecer defines the functions needed to create a new Encoder or Decoder
 type Codecer interface {
 	NewEncoder(io.Writer) Encoder
@@ -74,7 +113,25 @@ func writeMarshal(buf *bytes.Buffer, m func() ([]byte, error)) error {
 	return err
 }
 
+// exclusiveCodec marks a Codecer which must encode and decode all types, even
+// those implementing a Marshal or Unmarshal function. For example an
+// encrypting codec must not be bypassed.
+type exclusiveCodec interface {
+	exclusiveCodec()
+}
+
+func isExclusiveCodec(c Codecer) bool {
+	if pc, ok := c.(*pooledCodec); ok {
+		c = pc.codec
+	}
+	_, ok := c.(exclusiveCodec)
+	return ok
+}
+
 func encodeOne(c Codecer, buf *bytes.Buffer, key string, src interface{}) (err error) {
+	if isExclusiveCodec(c) {
+		return encodeCodec(c, buf, key, src)
+	}
 	switch ot := src.(type) {
 	case marshaler:
 		if err = writeMarshal(buf, ot.Marshal); err != nil {
@@ -89,27 +146,38 @@ func encodeOne(c Codecer, buf *bytes.Buffer, key string, src interface{}) (err e
 			return errors.Wrapf(err, "[objcache] With key %q and dst type %T", key, src)
 		}
 	default:
-		if c == nil {
-			return errors.NotImplemented.Newf("[objcache] Src type %T does not implement Marshal or Codec not set.", src)
-		}
+		err = encodeCodec(c, buf, key, src)
+	}
+	return err
+}
 
-		enc := c.NewEncoder(buf)
-		pc, ok := c.(*pooledCodec)
-		err = enc.Encode(src)
-		if ok {
-			pc.PutEncoder(enc)
-		}
-		if err == io.EOF {
-			err = nil
-		}
-		if err != nil {
-			err = errors.Wrapf(err, "[objcache] With key %q and dst type %T", key, src) // saves an allocation ;-)
-		}
+func encodeCodec(c Codecer, buf *bytes.Buffer, key string, src interface{}) (err error) {
+	if c == nil {
+		return errors.NotImplemented.Newf("[objcache] Src type %T does not implement Marshal or Codec not set.", src)
+	}
+
+	enc := c.NewEncoder(buf)
+	pc, ok := c.(*pooledCodec)
+	err = enc.Encode(src)
+	if ok {
+		pc.PutEncoder(enc)
+	}
+	if err == io.EOF {
+		err = nil
+	}
+	if err != nil {
+		err = errors.Wrapf(err, "[objcache] With key %q and dst type %T", key, src) // saves an allocation ;-)
 	}
 	return err
 }
 
 func decodeOne(c Codecer, data []byte, key string, dst interface{}) (err error) {
+	if isExclusiveCodec(c) {
+		if data == nil {
+			return nil // cache miss, nothing to decrypt
+		}
+		return decodeCodec(c, data, key, dst)
+	}
 	switch ot := dst.(type) {
 	case unmarshaler:
 		if err = ot.Unmarshal(data); err != nil {
@@ -124,35 +192,41 @@ func decodeOne(c Codecer, data []byte, key string, dst interface{}) (err error)
 			return errors.Wrapf(err, "[objcache] With key %q and dst type %T", key, dst)
 		}
 	default:
-		if c == nil {
-			return errors.NotImplemented.Newf("[objcache] Dst type %T does not implement Unmarshal or Codec not set.", dst)
-		}
+		err = decodeCodec(c, data, key, dst)
+	}
+	return err
+}
 
-		r := bufferpool.GetReader(data)
-		defer bufferpool.PutReader(r)
-		dec := c.NewDecoder(r)
-		pc, ok := c.(*pooledCodec)
-		err = dec.Decode(dst)
-		if ok {
-			pc.PutDecoder(dec)
-		}
-		if err == io.EOF {
-			err = nil
-		}
-		if err != nil {
-			err = errors.Wrapf(err, "[objcache] With key %q and dst type %T", key, dst) // saves an allocation ;-)
-		}
+func decodeCodec(c Codecer, data []byte, key string, dst interface{}) (err error) {
+	if c == nil {
+		return errors.NotImplemented.Newf("[objcache] Dst type %T does not implement Unmarshal or Codec not set.", dst)
+	}
+
+	r := bufferpool.GetReader(data)
+	defer bufferpool.PutReader(r)
+	dec := c.NewDecoder(r)
+	pc, ok := c.(*pooledCodec)
+	err = dec.Decode(dst)
+	if ok {
+		pc.PutDecoder(dec)
+	}
+	if err == io.EOF {
+		err = nil
+	}
+	if err != nil {
+		err = errors.Wrapf(err, "[objcache] With key %q and dst type %T", key, dst) // saves an allocation ;-)
 	}
 	return err
 }
 
 // Encode encodes all items to their byte slice representation. Returns two
 // slices whose indexes match to the other. The data might be appended to the
-// optional arguments `keys` and `values`.
-func encodeAll(codec Codecer, ri *rawItems, defaultExpire time.Duration, keys []string, src []interface{}, expires []time.Duration) (_ *rawItems, err error) {
+// optional arguments `keys` and `values`. The keyPrefix gets prepended to each
+// key.
+func encodeAll(codec Codecer, ri *rawItems, defaultExpire time.Duration, keyPrefix string, keys []string, src []interface{}, expires []time.Duration) (_ *rawItems, err error) {
 	lenExpires := len(expires)
 	for i, key := range keys {
-		ri.keys = append(ri.keys, key)
+		ri.keys = append(ri.keys, keyPrefix+key)
 		var buf bytes.Buffer // TODO a buffer pool can be used because of the append
 		if err := encodeOne(codec, &buf, key, src[i]); err != nil {
 			return nil, errors.WithStack(err)
@@ -185,11 +259,19 @@ type rawItems struct {
 
 // Service handles the encoding, decoding and caching.
 type Service struct {
+	// level1Gen gets incremented before each write, eviction or truncation of
+	// level1. getLevels compares it to detect writes which happened while a
+	// value got copied from level2 into level1. First field for the 64-bit
+	// alignment of atomic operations.
+	level1Gen         uint64
 	so                ServiceOptions
 	level1            Storager
 	level2            Storager
 	defaultExpiration time.Duration // in seconds
 	rawItemsPool      sync.Pool
+	// fetchSFG deduplicates concurrent cache misses of the same key in Fetch.
+	fetchSFG singleflight.Group
+	counters *[2]levelCounters
 }
 
 func (tr *Service) poolGetRawItems() *rawItems {
@@ -212,6 +294,7 @@ func (tr *Service) poolPutRawItems(ri *rawItems) {
 // while level1 can be nil.
 func NewService(level1, level2 NewStorageFn, so *ServiceOptions) (_ *Service, err error) {
 	s := &Service{
+		counters: new([2]levelCounters),
 		rawItemsPool: sync.Pool{
 			// values might lead to bugs, theoretically, but never experienced them.
 			New: func() interface{} {
@@ -234,6 +317,11 @@ func NewService(level1, level2 NewStorageFn, so *ServiceOptions) (_ *Service, er
 	if s.level2, err = level2(); err != nil {
 		return nil, errors.WithStack(err)
 	}
+	if s.level1 != nil && s.so.Invalidator != nil {
+		if err = s.so.Invalidator.Subscribe(s.evictLevel1); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
 
 	if so != nil && len(so.PrimeObjects) > 0 {
 		so.Codec = newPooledCodec(so.Codec, so.PrimeObjects...)
@@ -267,20 +355,25 @@ func (tr *Service) Set(ctx context.Context, key string, src interface{}, expires
 	if err := encodeOne(tr.so.Codec, &buf, key, src); err != nil {
 		return errors.WithStack(err)
 	}
-	ri.keys = append(ri.keys, key)
+	ri.keys = append(ri.keys, tr.so.KeyPrefix+key)
 	ri.values = append(ri.values, buf.Bytes())
 	ri.expires = append(ri.expires, expires)
 
+	return tr.setLevels(ctx, ri)
+}
+
+// setLevels writes the raw items into level1 and level2 and publishes the keys.
+func (tr *Service) setLevels(ctx context.Context, ri *rawItems) error {
 	if tr.level1 != nil {
-		if err := tr.level1.Set(ctx, ri.keys, ri.values, ri.expires); err != nil {
+		tr.bumpLevel1Gen()
+		if err := tr.storageSet(ctx, 1, ri.keys, ri.values, ri.expires); err != nil {
 			return errors.WithStack(err)
 		}
 	}
-
-	if err := tr.level2.Set(ctx, ri.keys, ri.values, ri.expires); err != nil {
+	if err := tr.storageSet(ctx, 2, ri.keys, ri.values, ri.expires); err != nil {
 		return errors.WithStack(err)
 	}
-	return nil
+	return tr.publish(ctx, ri.keys)
 }
 
 // SetMulti allows a cache to write several entities at once. For example using
@@ -293,20 +386,11 @@ func (tr *Service) SetMulti(ctx context.Context, keys []string, src []interface{
 	ri := tr.poolGetRawItems()
 	defer tr.poolPutRawItems(ri)
 
-	ri, err := encodeAll(tr.so.Codec, ri, tr.defaultExpiration, keys, src, expires)
+	ri, err := encodeAll(tr.so.Codec, ri, tr.defaultExpiration, tr.so.KeyPrefix, keys, src, expires)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-
-	if tr.level1 != nil {
-		if err := tr.level1.Set(ctx, ri.keys, ri.values, ri.expires); err != nil {
-			return errors.WithStack(err)
-		}
-	}
-	if err := tr.level2.Set(ctx, ri.keys, ri.values, ri.expires); err != nil {
-		return errors.WithStack(err)
-	}
-	return nil
+	return tr.setLevels(ctx, ri)
 }
 
 // unmarshaler is the interface representing objects that can
@@ -333,28 +417,253 @@ func (tr *Service) Get(ctx context.Context, key string, dst interface{}) (err er
 	ri := tr.poolGetRawItems()
 	defer tr.poolPutRawItems(ri)
 
-	ri.keys = append(ri.keys, key)
+	ri.keys = append(ri.keys, tr.so.KeyPrefix+key)
+
+	vals, err := tr.getLevels(ctx, ri.keys)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	idst := [1]interface{}{dst}
+	if err := decodeAll(tr.so.Codec, vals, ri.keys, idst[:]); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Fetch looks up the key and decodes the value into `dst`. On a cache miss the
+// loader gets called and its result gets stored with the expiration `expires`
+// before it gets decoded into `dst`. Concurrent cache misses of the same key
+// call the loader only once, all other callers wait for its result. The loader
+// receives a context detached from the callers, limited by
+// ServiceOptions.FetchTimeout, so a canceled caller does not abort the load for
+// all others. Each caller returns when its own context gets canceled. Same
+// encoding and decoding logic applies as when calling `Set` and `Get`.
+func (tr *Service) Fetch(ctx context.Context, key string, dst interface{}, loader func(context.Context) (interface{}, error), expires time.Duration) error {
+	pKey := tr.so.KeyPrefix + key
+	vals, err := tr.getLevels(ctx, []string{pKey})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	data := vals[0]
+	if data == nil {
+		ch := tr.fetchSFG.DoChan(pKey, func() (interface{}, error) {
+			lctx, cancel := context.WithTimeout(context.Background(), tr.fetchTimeout())
+			defer cancel()
+			return tr.fetchLoad(lctx, pKey, loader, expires)
+		})
+		select {
+		case <-ctx.Done():
+			return errors.WithStack(ctx.Err())
+		case res := <-ch:
+			if res.Err != nil {
+				return errors.WithStack(res.Err)
+			}
+			data = res.Val.([]byte)
+		}
+	}
+	if err := decodeOne(tr.so.Codec, data, pKey, dst); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// defaultFetchTimeout limits a loader of Fetch if ServiceOptions.FetchTimeout
+// has not been set.
+const defaultFetchTimeout = 30 * time.Second
+
+func (tr *Service) fetchTimeout() time.Duration {
+	if tr.so.FetchTimeout > 0 {
+		return tr.so.FetchTimeout
+	}
+	return defaultFetchTimeout
+}
+
+// fetchLoad calls the loader, encodes its result and writes it into the
+// cache. Returns the encoded data.
+func (tr *Service) fetchLoad(ctx context.Context, pKey string, loader func(context.Context) (interface{}, error), expires time.Duration) ([]byte, error) {
+	src, err := loader(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if expires == 0 {
+		expires = tr.defaultExpiration
+	}
+	var buf bytes.Buffer
+	if err := encodeOne(tr.so.Codec, &buf, pKey, src); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	ri := &rawItems{
+		keys:    []string{pKey},
+		values:  [][]byte{buf.Bytes()},
+		expires: []time.Duration{expires},
+	}
+	if err := tr.setLevels(ctx, ri); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// GetWithVersion works like Get but returns additionally the version of the
+// value. The value gets always read from level2 because level1 might contain a
+// stale copy. If the key does not exist, the version is zero and dst stays
+// untouched. Use the version with CompareAndSet.
+func (tr *Service) GetWithVersion(ctx context.Context, key string, dst interface{}) (version uint64, err error) {
+	pKey := tr.so.KeyPrefix + key
+	vals, err := tr.storageGet(ctx, 2, []string{pKey})
+	if err != nil {
+		return 0, errors.Wrapf(err, "[objcache] Level2 with key %q", pKey)
+	}
+	if len(vals) == 0 || vals[0] == nil {
+		return 0, nil
+	}
+	if err := decodeOne(tr.so.Codec, vals[0], pKey, dst); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return ValueVersion(vals[0]), nil
+}
 
-	var vals [][]byte
+// CompareAndSet writes `src` only if the version of the stored value still
+// matches the version returned by GetWithVersion. A version of zero writes
+// `src` only if the key does not exist. If the value has been changed in the
+// meantime, an error with kind Mismatch gets returned and the caller can load
+// the value again to retry. Level2 must implement interface CompareAndSwapper
+// otherwise an error with kind NotSupported gets returned.
+func (tr *Service) CompareAndSet(ctx context.Context, key string, version uint64, src interface{}, expires time.Duration) error {
+	pKey := tr.so.KeyPrefix + key
+	cas, ok := tr.level2.(CompareAndSwapper)
+	if !ok {
+		return errors.NotSupported.Newf("[objcache] Storage %T does not support CompareAndSet with key %q", tr.level2, pKey)
+	}
+	if expires == 0 {
+		expires = tr.defaultExpiration
+	}
+	var buf bytes.Buffer
+	if err := encodeOne(tr.so.Codec, &buf, pKey, src); err != nil {
+		return errors.WithStack(err)
+	}
+
+	start := time.Now()
+	swapped, err := cas.CompareAndSwap(ctx, pKey, version, buf.Bytes(), expires)
+	tr.record(&Event{Op: EventSet, Level: 2, Keys: []string{pKey}, Bytes: buf.Len(), Err: err}, start)
+	if err != nil {
+		return errors.Wrapf(err, "[objcache] Level2 with key %q", pKey)
+	}
+	if !swapped {
+		return errors.Mismatch.Newf("[objcache] Key %q has been changed, version %d does not match", pKey, version)
+	}
+
+	// level1 gets evicted instead of updated because concurrent callers might
+	// write their level1 copies in a different order than level2.
+	keys := []string{pKey}
 	if tr.level1 != nil {
-		vals, err = tr.level1.Get(ctx, ri.keys)
-		if err != nil {
-			return errors.Wrapf(err, "[objcache] Level1 with keys %v", ri.keys)
+		tr.bumpLevel1Gen()
+		if err := tr.storageDelete(ctx, 1, keys); err != nil {
+			return errors.WithStack(err)
 		}
 	}
-	if lv := len(vals); lv == 0 {
-		vals, err = tr.level2.Get(ctx, ri.keys)
+	return tr.publish(ctx, keys)
+}
+
+// getLevels looks up the keys in level1 and afterwards the missing keys in
+// level2. Keys found in level2 get copied into level1.
+func (tr *Service) getLevels(ctx context.Context, keys []string) ([][]byte, error) {
+	if tr.level1 == nil {
+		vals, err := tr.storageGet(ctx, 2, keys)
 		if err != nil {
-			return errors.Wrapf(err, "[objcache] Level2 with keys %v", ri.keys)
+			return nil, errors.Wrapf(err, "[objcache] Level2 with keys %v", keys)
 		}
+		return vals, nil
+	}
+
+	vals, err := tr.storageGet(ctx, 1, keys)
+	if err != nil && !errors.NotFound.Match(err) {
+		return nil, errors.Wrapf(err, "[objcache] Level1 with keys %v", keys)
+	}
+	if len(vals) != len(keys) {
+		vals = make([][]byte, len(keys))
 	}
-	if err == nil {
-		idst := [1]interface{}{dst}
-		if err2 := decodeAll(tr.so.Codec, vals, ri.keys, idst[:]); err2 != nil {
-			return errors.WithStack(err2)
+	var missIdx []int
+	var missKeys []string
+	for i, v := range vals {
+		if v == nil {
+			missIdx = append(missIdx, i)
+			missKeys = append(missKeys, keys[i])
 		}
 	}
-	return err
+	if len(missKeys) == 0 {
+		return vals, nil
+	}
+
+	gen := atomic.LoadUint64(&tr.level1Gen)
+	missVals, err := tr.storageGet(ctx, 2, missKeys)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[objcache] Level2 with keys %v", missKeys)
+	}
+	var fillKeys []string
+	var fillVals [][]byte
+	var fillExpires []time.Duration
+	for i, v := range missVals {
+		if v == nil || i >= len(missIdx) {
+			continue
+		}
+		vals[missIdx[i]] = v
+		fillKeys = append(fillKeys, missKeys[i])
+		fillVals = append(fillVals, v)
+		fillExpires = append(fillExpires, tr.so.Level1Expires)
+	}
+	// Level1 gets only filled if no write, eviction or truncation happened
+	// since level2 has been read, otherwise a stale value would be copied into
+	// level1. A write during storageSet gets detected afterwards and the filled
+	// keys get removed again.
+	if len(fillKeys) > 0 && atomic.LoadUint64(&tr.level1Gen) == gen {
+		if err := tr.storageSet(ctx, 1, fillKeys, fillVals, fillExpires); err != nil {
+			return nil, errors.Wrapf(err, "[objcache] Level1 with keys %v", fillKeys)
+		}
+		if atomic.LoadUint64(&tr.level1Gen) != gen {
+			if err := tr.storageDelete(ctx, 1, fillKeys); err != nil {
+				return nil, errors.Wrapf(err, "[objcache] Level1 with keys %v", fillKeys)
+			}
+		}
+	}
+	return vals, nil
+}
+
+// bumpLevel1Gen must be called before level1 gets modified, see getLevels.
+func (tr *Service) bumpLevel1Gen() {
+	atomic.AddUint64(&tr.level1Gen, 1)
+}
+
+// prefixKeys returns the keys with the KeyPrefix prepended. Without a KeyPrefix
+// the keys get returned unchanged.
+func (tr *Service) prefixKeys(keys []string) []string {
+	if tr.so.KeyPrefix == "" {
+		return keys
+	}
+	pk := make([]string, len(keys))
+	for i, key := range keys {
+		pk[i] = tr.so.KeyPrefix + key
+	}
+	return pk
+}
+
+// publish broadcasts the keys to the other nodes, if an Invalidator has been
+// set.
+func (tr *Service) publish(ctx context.Context, keys []string) error {
+	if tr.level1 == nil || tr.so.Invalidator == nil {
+		return nil
+	}
+	if err := tr.so.Invalidator.Publish(ctx, keys); err != nil {
+		return errors.Wrapf(err, "[objcache] Invalidator with keys %v", keys)
+	}
+	return nil
+}
+
+// evictLevel1 removes the keys received from other nodes from level1.
+func (tr *Service) evictLevel1(keys []string) {
+	// errors can be ignored because a stale level1 entry expires on its own
+	// with Level1Expires.
+	tr.bumpLevel1Gen()
+	_ = tr.storageDelete(context.Background(), 1, keys)
 }
 
 // GetMulti allows a cache backend to retrieve several values at once. Same
@@ -363,33 +672,29 @@ func (tr *Service) GetMulti(ctx context.Context, keys []string, dst []interface{
 	if lk, ld := len(keys), len(dst); lk != ld {
 		return errors.Mismatch.Newf("[objcache] Length of keys (%d) vs length of dst (%d) must be equal", lk, ld)
 	}
-	var vals [][]byte
-	if tr.level1 != nil {
-		vals, err = tr.level1.Get(ctx, keys)
-		if err != nil && !errors.NotFound.Match(err) {
-			return errors.Wrapf(err, "[objcache] Level1 with keys %v", keys)
-		}
-	}
-	if lv := len(vals); lv == 0 {
-		vals, err = tr.level2.Get(ctx, keys)
-		if err != nil && !errors.NotFound.Match(err) {
-			return errors.Wrapf(err, "[objcache] Level2 with keys %v", keys)
-		}
-	}
-	if err != nil && errors.NotFound.Match(err) {
+	pKeys := tr.prefixKeys(keys)
+	vals, err := tr.getLevels(ctx, pKeys)
+	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	if err := decodeAll(tr.so.Codec, vals, keys, dst); err != nil {
+	if err := decodeAll(tr.so.Codec, vals, pKeys, dst); err != nil {
 		return errors.WithStack(err)
 	}
 
 	return nil
 }
 
-// Truncate truncates all caches.
+// Truncate truncates all caches. If a KeyPrefix has been set, only the keys
+// starting with the KeyPrefix get removed, see TruncatePrefix. Storages which
+// don't implement PrefixTruncater, like bigcache and memcache, return then an
+// error with kind NotSupported instead of silently flushing all keys.
 func (tr *Service) Truncate(ctx context.Context) (err error) {
+	if tr.so.KeyPrefix != "" {
+		return tr.TruncatePrefix(ctx, "")
+	}
 	if tr.level1 != nil {
+		tr.bumpLevel1Gen()
 		if err := tr.level1.Truncate(ctx); err != nil {
 			return errors.WithStack(err)
 		}
@@ -401,21 +706,54 @@ func (tr *Service) Truncate(ctx context.Context) (err error) {
 	return nil
 }
 
-// Delete removes keys from the storage.
+// TruncatePrefix removes all keys starting with the KeyPrefix followed by
+// argument prefix from all caches. For example the prefix "catalog:" removes
+// all keys of the catalog namespace. A storage must implement interface
+// PrefixTruncater otherwise an error with kind NotSupported gets returned.
+func (tr *Service) TruncatePrefix(ctx context.Context, prefix string) error {
+	prefix = tr.so.KeyPrefix + prefix
+	if tr.level1 != nil {
+		tr.bumpLevel1Gen()
+		if err := truncatePrefix(ctx, tr.level1, prefix); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return truncatePrefix(ctx, tr.level2, prefix)
+}
+
+func truncatePrefix(ctx context.Context, s Storager, prefix string) error {
+	pt, ok := s.(PrefixTruncater)
+	if !ok {
+		return errors.NotSupported.Newf("[objcache] Storage %T does not support TruncatePrefix with prefix %q", s, prefix)
+	}
+	if err := pt.TruncatePrefix(ctx, prefix); err != nil {
+		return errors.Wrapf(err, "[objcache] TruncatePrefix with prefix %q", prefix)
+	}
+	return nil
+}
+
+// Delete removes one or several keys at once from all storages.
 func (tr *Service) Delete(ctx context.Context, key ...string) error {
+	key = tr.prefixKeys(key)
 	if tr.level1 != nil {
-		if err := tr.level1.Delete(ctx, key); err != nil {
+		tr.bumpLevel1Gen()
+		if err := tr.storageDelete(ctx, 1, key); err != nil {
 			return errors.WithStack(err)
 		}
 	}
-	if err := tr.level2.Delete(ctx, key); err != nil {
+	if err := tr.storageDelete(ctx, 2, key); err != nil {
 		return errors.WithStack(err)
 	}
-	return nil
+	return tr.publish(ctx, key)
 }
 
 // Close closes the underlying storage engines.
 func (tr *Service) Close() error {
+	if tr.so.Invalidator != nil {
+		if err := tr.so.Invalidator.Close(); err != nil {
+			return errors.WithStack(err)
+		}
+	}
 	if tr.level1 != nil {
 		if err := tr.level1.Close(); err != nil {
 			return errors.WithStack(err)
@@ -23,6 +23,8 @@ import (
 	"math"
 	"net"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -402,3 +404,351 @@ func testExpiration(t *testing.T, cb func(), level2 objcache.NewStorageFn, so *o
 	assert.NoError(t, err, "%+v", err)
 	assert.Empty(t, newVal)
 }
+
+// memoryHub connects several in-memory invalidators.
+type memoryHub struct {
+	mu   sync.Mutex
+	subs map[*memoryInvalidator]func(keys []string)
+}
+
+type memoryInvalidator struct {
+	hub *memoryHub
+}
+
+func (mi *memoryInvalidator) Publish(_ context.Context, keys []string) error {
+	mi.hub.mu.Lock()
+	defer mi.hub.mu.Unlock()
+	for sub, fn := range mi.hub.subs {
+		if sub != mi {
+			fn(keys)
+		}
+	}
+	return nil
+}
+
+func (mi *memoryInvalidator) Subscribe(fn func(keys []string)) error {
+	mi.hub.mu.Lock()
+	defer mi.hub.mu.Unlock()
+	if mi.hub.subs == nil {
+		mi.hub.subs = map[*memoryInvalidator]func(keys []string){}
+	}
+	mi.hub.subs[mi] = fn
+	return nil
+}
+
+func (mi *memoryInvalidator) Close() error {
+	mi.hub.mu.Lock()
+	defer mi.hub.mu.Unlock()
+	delete(mi.hub.subs, mi)
+	return nil
+}
+
+func TestService_TieredInvalidation(t *testing.T) {
+	t.Parallel()
+
+	level2, err := objcache.NewCacheSimpleInmemory()
+	assert.NoError(t, err)
+	sharedLevel2 := func() (objcache.Storager, error) { return level2, nil }
+	hub := &memoryHub{}
+	newNode := func() *objcache.Service {
+		p, err := objcache.NewService(objcache.NewLRU(nil), sharedLevel2, &objcache.ServiceOptions{
+			Codec:       JSONCodec{},
+			Invalidator: &memoryInvalidator{hub: hub},
+		})
+		assert.NoError(t, err)
+		return p
+	}
+	nodeA := newNode()
+	nodeB := newNode()
+	ctx := context.Background()
+
+	assert.NoError(t, nodeA.Set(ctx, "product:1", "Gopher", 0))
+	var name string
+	assert.NoError(t, nodeB.Get(ctx, "product:1", &name))
+	assert.Exactly(t, "Gopher", name)
+
+	// level1 of node B has now a copy, which gets evicted by the Set of node A.
+	assert.NoError(t, nodeA.Set(ctx, "product:1", "Gopherine", 0))
+	assert.NoError(t, nodeB.Get(ctx, "product:1", &name))
+	assert.Exactly(t, "Gopherine", name)
+
+	assert.NoError(t, nodeB.Get(ctx, "product:1", &name))
+	assert.NoError(t, nodeA.Delete(ctx, "product:1"))
+	name = ""
+	assert.NoError(t, nodeB.Get(ctx, "product:1", &name))
+	assert.Empty(t, name)
+
+	assert.NoError(t, nodeA.Close())
+	assert.NoError(t, nodeB.Close())
+	assert.Len(t, hub.subs, 0)
+}
+
+func TestService_KeyPrefix(t *testing.T) {
+	t.Parallel()
+
+	level2, err := objcache.NewCacheSimpleInmemory()
+	assert.NoError(t, err)
+	p, err := objcache.NewService(objcache.NewLRU(nil), func() (objcache.Storager, error) { return level2, nil }, &objcache.ServiceOptions{
+		Codec:     JSONCodec{},
+		KeyPrefix: "app:v2:",
+	})
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+	ctx := context.Background()
+
+	assert.NoError(t, p.Set(ctx, "catalog:1", "Gopher", 0))
+	assert.NoError(t, p.SetMulti(ctx, []string{"catalog:2", "cms:1"}, []interface{}{"Plush", "Page"}, nil))
+	assert.NoError(t, level2.Set(ctx, []string{"other:1"}, [][]byte{[]byte(`"Other"`)}, nil))
+
+	vals, err := level2.Get(ctx, []string{"app:v2:catalog:1", "catalog:1"})
+	assert.NoError(t, err)
+	assert.Exactly(t, [][]byte{[]byte("\"Gopher\"\n"), nil}, vals)
+
+	var c1, c2, cms string
+	assert.NoError(t, p.GetMulti(ctx, []string{"catalog:1", "catalog:2", "cms:1"}, []interface{}{&c1, &c2, &cms}))
+	assert.Exactly(t, []string{"Gopher", "Plush", "Page"}, []string{c1, c2, cms})
+
+	assert.NoError(t, p.TruncatePrefix(ctx, "catalog:"))
+	c1, c2, cms = "", "", ""
+	assert.NoError(t, p.GetMulti(ctx, []string{"catalog:1", "catalog:2", "cms:1"}, []interface{}{&c1, &c2, &cms}))
+	assert.Exactly(t, []string{"", "", "Page"}, []string{c1, c2, cms})
+
+	assert.NoError(t, p.Truncate(ctx))
+	cms = ""
+	assert.NoError(t, p.Get(ctx, "cms:1", &cms))
+	assert.Exactly(t, "", cms)
+
+	// keys without the KeyPrefix must survive the Truncate.
+	vals, err = level2.Get(ctx, []string{"other:1"})
+	assert.NoError(t, err)
+	assert.Exactly(t, [][]byte{[]byte(`"Other"`)}, vals)
+}
+
+func TestService_TruncatePrefix_NotSupported(t *testing.T) {
+	t.Parallel()
+
+	p, err := objcache.NewService(nil, func() (objcache.Storager, error) { return notPrefixTruncater{}, nil }, nil)
+	assert.NoError(t, err)
+	err = p.TruncatePrefix(context.Background(), "catalog:")
+	assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+
+	t.Run("Truncate with KeyPrefix", func(t *testing.T) {
+		p, err := objcache.NewService(nil, func() (objcache.Storager, error) { return notPrefixTruncater{}, nil }, &objcache.ServiceOptions{
+			KeyPrefix: "app:",
+		})
+		assert.NoError(t, err)
+		err = p.Truncate(context.Background())
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	})
+}
+
+type notPrefixTruncater struct {
+	objcache.Storager
+}
+
+func TestService_Fetch(t *testing.T) {
+	t.Parallel()
+
+	p, err := objcache.NewService(objcache.NewLRU(nil), objcache.NewCacheSimpleInmemory, newSrvOpt(JSONCodec{}))
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+	ctx := context.Background()
+
+	t.Run("deduplicates concurrent misses", func(t *testing.T) {
+		var calls int32
+		release := make(chan struct{})
+		loader := func(ctx context.Context) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return "Gopher", nil
+		}
+
+		const goroutines = 20
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		names := make([]string, goroutines)
+		for i := 0; i < goroutines; i++ {
+			go func(i int) {
+				defer wg.Done()
+				assert.NoError(t, p.Fetch(ctx, "product:1", &names[i], loader, 0))
+			}(i)
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Exactly(t, int32(1), atomic.LoadInt32(&calls))
+		for _, n := range names {
+			assert.Exactly(t, "Gopher", n)
+		}
+
+		var name string
+		assert.NoError(t, p.Get(ctx, "product:1", &name))
+		assert.Exactly(t, "Gopher", name)
+	})
+
+	t.Run("cache hit skips loader", func(t *testing.T) {
+		assert.NoError(t, p.Set(ctx, "product:2", "Plush", 0))
+		var name string
+		assert.NoError(t, p.Fetch(ctx, "product:2", &name, func(context.Context) (interface{}, error) {
+			t.Fatal("loader must not be called")
+			return nil, nil
+		}, 0))
+		assert.Exactly(t, "Plush", name)
+	})
+
+	t.Run("loader error", func(t *testing.T) {
+		var name string
+		err := p.Fetch(ctx, "product:3", &name, func(context.Context) (interface{}, error) {
+			return nil, errors.NotFound.Newf("product 3 not found")
+		}, 0)
+		assert.True(t, errors.NotFound.Match(err), "%+v", err)
+		assert.Empty(t, name)
+	})
+
+	t.Run("canceled first caller does not abort the loader", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		loader := func(lctx context.Context) (interface{}, error) {
+			close(started)
+			select {
+			case <-release:
+			case <-lctx.Done():
+				return nil, lctx.Err()
+			}
+			return "Gopher", nil
+		}
+
+		ctx1, cancel1 := context.WithCancel(ctx)
+		errC := make(chan error, 1)
+		go func() {
+			var name string
+			errC <- p.Fetch(ctx1, "product:4", &name, loader, 0)
+		}()
+		<-started
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var name2 string
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, p.Fetch(ctx, "product:4", &name2, loader, 0))
+		}()
+
+		cancel1()
+		err := <-errC
+		assert.True(t, errors.Cause(err) == context.Canceled, "%+v", err)
+		close(release)
+		wg.Wait()
+		assert.Exactly(t, "Gopher", name2)
+	})
+}
+
+// hookedGetStorage calls afterGet once after the first Get has read the values.
+type hookedGetStorage struct {
+	objcache.Storager
+	once     sync.Once
+	afterGet func()
+}
+
+func (hs *hookedGetStorage) Get(ctx context.Context, keys []string) ([][]byte, error) {
+	vals, err := hs.Storager.Get(ctx, keys)
+	hs.once.Do(hs.afterGet)
+	return vals, err
+}
+
+func TestService_GetLevels_SkipsStaleLevel1Fill(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	level2, err := objcache.NewCacheSimpleInmemory()
+	assert.NoError(t, err)
+	hs := &hookedGetStorage{Storager: level2, afterGet: func() {}}
+	newLevel2 := func() (objcache.Storager, error) { return hs, nil }
+
+	// seed writes only into level2.
+	seed, err := objcache.NewService(nil, newLevel2, newSrvOpt(JSONCodec{}))
+	assert.NoError(t, err)
+	assert.NoError(t, seed.Set(ctx, "product:1", "old", 0))
+
+	var p *objcache.Service
+	// The concurrent Set happens after level2 has been read but before level1
+	// gets filled.
+	hs.afterGet = func() { assert.NoError(t, p.Set(ctx, "product:1", "new", 0)) }
+
+	p, err = objcache.NewService(objcache.NewLRU(nil), newLevel2, newSrvOpt(JSONCodec{}))
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+
+	var name string
+	assert.NoError(t, p.Get(ctx, "product:1", &name))
+	assert.Exactly(t, "old", name)
+
+	assert.NoError(t, p.Get(ctx, "product:1", &name))
+	assert.Exactly(t, "new", name, "level1 must not contain the stale value")
+}
+
+func TestValueVersion(t *testing.T) {
+	assert.Exactly(t, uint64(0), objcache.ValueVersion(nil))
+	assert.Exactly(t, uint64(0xa9993e364706816a), objcache.ValueVersion([]byte("abc")))
+}
+
+// testCompareAndSet runs an optimistic update loop on the same key from several
+// goroutines and checks that no update gets lost.
+func testCompareAndSet(t *testing.T, level2 objcache.NewStorageFn) {
+	p, err := objcache.NewService(objcache.NewLRU(nil), level2, newSrvOpt(JSONCodec{}))
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+	ctx := context.Background()
+	key := "cas_" + strs.RandAlnum(10)
+
+	var counter int
+	version, err := p.GetWithVersion(ctx, key, &counter)
+	assert.NoError(t, err)
+	assert.Exactly(t, uint64(0), version)
+
+	assert.NoError(t, p.CompareAndSet(ctx, key, 0, 0, 0))
+	err = p.CompareAndSet(ctx, key, 0, 1, 0)
+	assert.True(t, errors.Mismatch.Match(err), "%+v", err)
+
+	const goroutines = 5
+	const increments = 10
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < increments; {
+				var c int
+				version, err := p.GetWithVersion(ctx, key, &c)
+				assert.NoError(t, err)
+				err = p.CompareAndSet(ctx, key, version, c+1, 0)
+				if errors.Mismatch.Match(err) {
+					continue
+				}
+				assert.NoError(t, err)
+				j++
+			}
+		}()
+	}
+	wg.Wait()
+
+	_, err = p.GetWithVersion(ctx, key, &counter)
+	assert.NoError(t, err)
+	assert.Exactly(t, goroutines*increments, counter)
+	assert.NoError(t, p.Delete(ctx, key))
+}
+
+func TestService_CompareAndSet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("inmemory", func(t *testing.T) {
+		testCompareAndSet(t, objcache.NewCacheSimpleInmemory)
+	})
+	t.Run("not supported", func(t *testing.T) {
+		p, err := objcache.NewService(nil, objcache.NewBlackHoleClient(nil), nil)
+		assert.NoError(t, err)
+		err = p.CompareAndSet(context.Background(), "cas", 0, objcache.MakeBinary(), 0)
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	})
+}
@@ -0,0 +1,197 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objcache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// EventOp defines the operation of an Event.
+type EventOp uint8
+
+// Operations of an Event.
+const (
+	EventGet EventOp = iota + 1
+	EventSet
+	EventDelete
+)
+
+func (o EventOp) String() string {
+	switch o {
+	case EventGet:
+		return "get"
+	case EventSet:
+		return "set"
+	case EventDelete:
+		return "delete"
+	}
+	return "unknown"
+}
+
+// Event describes one call of the Service to a storage backend. It gets passed
+// to the ServiceOptions.OnEvent hook, for example to export metrics.
+type Event struct {
+	Op EventOp
+	// Level is either 1 or 2.
+	Level int
+	// Keys must not be modified or retained.
+	Keys []string
+	// Hits and Misses are only set for EventGet.
+	Hits   int
+	Misses int
+	// Bytes contains the size of the read or written values.
+	Bytes    int
+	Duration time.Duration
+	Err      error
+}
+
+// Stats contains the counters of both cache levels since the creation of the
+// Service.
+type Stats struct {
+	Level1 LevelStats
+	Level2 LevelStats
+}
+
+// LevelStats contains the counters of one cache level. The durations are the
+// total time spent in the backend.
+type LevelStats struct {
+	Hits   uint64
+	Misses uint64
+	// Gets, Sets and Deletes count the calls to the backend. A call can
+	// contain several keys.
+	Gets           uint64
+	Sets           uint64
+	Deletes        uint64
+	Errors         uint64
+	BytesRead      uint64
+	BytesWritten   uint64
+	GetDuration    time.Duration
+	SetDuration    time.Duration
+	DeleteDuration time.Duration
+}
+
+// HitRatio returns the ratio of hits to all looked up keys.
+func (ls LevelStats) HitRatio() float64 {
+	if total := ls.Hits + ls.Misses; total > 0 {
+		return float64(ls.Hits) / float64(total)
+	}
+	return 0
+}
+
+// levelCounters must only contain 64-bit fields for the atomic access on
+// 32-bit platforms.
+type levelCounters struct {
+	hits, misses                             uint64
+	gets, sets, deletes, errors              uint64
+	bytesRead, bytesWritten                  uint64
+	getDuration, setDuration, deleteDuration int64
+}
+
+func (lc *levelCounters) load() LevelStats {
+	return LevelStats{
+		Hits:           atomic.LoadUint64(&lc.hits),
+		Misses:         atomic.LoadUint64(&lc.misses),
+		Gets:           atomic.LoadUint64(&lc.gets),
+		Sets:           atomic.LoadUint64(&lc.sets),
+		Deletes:        atomic.LoadUint64(&lc.deletes),
+		Errors:         atomic.LoadUint64(&lc.errors),
+		BytesRead:      atomic.LoadUint64(&lc.bytesRead),
+		BytesWritten:   atomic.LoadUint64(&lc.bytesWritten),
+		GetDuration:    time.Duration(atomic.LoadInt64(&lc.getDuration)),
+		SetDuration:    time.Duration(atomic.LoadInt64(&lc.setDuration)),
+		DeleteDuration: time.Duration(atomic.LoadInt64(&lc.deleteDuration)),
+	}
+}
+
+func (lc *levelCounters) add(e *Event) {
+	switch e.Op {
+	case EventGet:
+		atomic.AddUint64(&lc.gets, 1)
+		atomic.AddUint64(&lc.hits, uint64(e.Hits))
+		atomic.AddUint64(&lc.misses, uint64(e.Misses))
+		atomic.AddUint64(&lc.bytesRead, uint64(e.Bytes))
+		atomic.AddInt64(&lc.getDuration, int64(e.Duration))
+	case EventSet:
+		atomic.AddUint64(&lc.sets, 1)
+		atomic.AddUint64(&lc.bytesWritten, uint64(e.Bytes))
+		atomic.AddInt64(&lc.setDuration, int64(e.Duration))
+	case EventDelete:
+		atomic.AddUint64(&lc.deletes, 1)
+		atomic.AddInt64(&lc.deleteDuration, int64(e.Duration))
+	}
+	if e.Err != nil {
+		atomic.AddUint64(&lc.errors, 1)
+	}
+}
+
+// Stats returns a snapshot of the counters of both cache levels.
+func (tr *Service) Stats() Stats {
+	return Stats{
+		Level1: tr.counters[0].load(),
+		Level2: tr.counters[1].load(),
+	}
+}
+
+func (tr *Service) storage(level int) Storager {
+	if level == 1 {
+		return tr.level1
+	}
+	return tr.level2
+}
+
+// record updates the counters of the level and calls the OnEvent hook.
+func (tr *Service) record(e *Event, start time.Time) {
+	e.Duration = time.Since(start)
+	tr.counters[e.Level-1].add(e)
+	if tr.so.OnEvent != nil {
+		tr.so.OnEvent(*e)
+	}
+}
+
+func (tr *Service) storageGet(ctx context.Context, level int, keys []string) ([][]byte, error) {
+	start := time.Now()
+	vals, err := tr.storage(level).Get(ctx, keys)
+	e := Event{Op: EventGet, Level: level, Keys: keys, Err: err}
+	for i := 0; i < len(keys) && err == nil; i++ {
+		if i < len(vals) && vals[i] != nil {
+			e.Hits++
+			e.Bytes += len(vals[i])
+		} else {
+			e.Misses++
+		}
+	}
+	tr.record(&e, start)
+	return vals, err
+}
+
+func (tr *Service) storageSet(ctx context.Context, level int, keys []string, values [][]byte, expirations []time.Duration) error {
+	start := time.Now()
+	err := tr.storage(level).Set(ctx, keys, values, expirations)
+	e := Event{Op: EventSet, Level: level, Keys: keys, Err: err}
+	for _, v := range values {
+		e.Bytes += len(v)
+	}
+	tr.record(&e, start)
+	return err
+}
+
+func (tr *Service) storageDelete(ctx context.Context, level int, keys []string) error {
+	start := time.Now()
+	err := tr.storage(level).Delete(ctx, keys)
+	tr.record(&Event{Op: EventDelete, Level: level, Keys: keys, Err: err}, start)
+	return err
+}
@@ -0,0 +1,91 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objcache_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/storage/objcache"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestService_Stats(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var events []objcache.Event
+	p, err := objcache.NewService(objcache.NewLRU(nil), objcache.NewCacheSimpleInmemory, &objcache.ServiceOptions{
+		Codec: JSONCodec{},
+		OnEvent: func(e objcache.Event) {
+			mu.Lock()
+			events = append(events, e)
+			mu.Unlock()
+		},
+	})
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+	ctx := context.Background()
+
+	assert.NoError(t, p.Set(ctx, "product:1", "Gopher", 0))
+	var name string
+	assert.NoError(t, p.Get(ctx, "product:1", &name))
+	assert.NoError(t, p.GetMulti(ctx, []string{"product:1", "product:2"}, []interface{}{&name, &name}))
+	assert.NoError(t, p.Delete(ctx, "product:1"))
+
+	s := p.Stats()
+	assert.Exactly(t, objcache.LevelStats{
+		Hits: 2, Misses: 1, Gets: 2, Sets: 1, Deletes: 1, BytesRead: 18, BytesWritten: 9,
+		GetDuration: s.Level1.GetDuration, SetDuration: s.Level1.SetDuration, DeleteDuration: s.Level1.DeleteDuration,
+	}, s.Level1)
+	assert.Exactly(t, objcache.LevelStats{
+		Misses: 1, Gets: 1, Sets: 1, Deletes: 1, BytesWritten: 9,
+		GetDuration: s.Level2.GetDuration, SetDuration: s.Level2.SetDuration, DeleteDuration: s.Level2.DeleteDuration,
+	}, s.Level2)
+	assert.Exactly(t, 2.0/3.0, s.Level1.HitRatio())
+	assert.Exactly(t, 0.0, s.Level2.HitRatio())
+
+	mu.Lock()
+	defer mu.Unlock()
+	var ops []string
+	for _, e := range events {
+		ops = append(ops, fmt.Sprintf("%s:%d:%v", e.Op, e.Level, e.Keys))
+	}
+	assert.Exactly(t, []string{
+		"set:1:[product:1]", "set:2:[product:1]",
+		"get:1:[product:1]",
+		"get:1:[product:1 product:2]", "get:2:[product:2]",
+		"delete:1:[product:1]", "delete:2:[product:1]",
+	}, ops)
+}
+
+func TestService_Stats_Errors(t *testing.T) {
+	t.Parallel()
+
+	p, err := objcache.NewService(nil, objcache.NewBlackHoleClient(errors.ConnectionFailed.Newf("Ups")), newSrvOpt(JSONCodec{}))
+	assert.NoError(t, err)
+
+	var name string
+	assert.Error(t, p.Get(context.Background(), "product:1", &name))
+	assert.Error(t, p.Set(context.Background(), "product:1", "Gopher", 0))
+
+	s := p.Stats()
+	assert.Exactly(t, uint64(2), s.Level2.Errors)
+	assert.Exactly(t, uint64(0), s.Level2.Misses)
+	assert.Exactly(t, objcache.LevelStats{}, s.Level1)
+}
@@ -119,8 +119,6 @@ func (bits Perm) String() string {
 	return strDefault
 }
 
-// TODO for Go2 implement encoding.TextMarshaler and econding.BinaryMarshaler
-
 var (
 	nullByte  = []byte("null")
 	quoteByte = []byte(`"`)
@@ -138,9 +136,9 @@ func (bits Perm) MarshalJSON() ([]byte, error) {
 	return []byte(buf.String()), nil
 }
 
-// MarshalJSON implements json.Marshaler
+// UnmarshalJSON implements json.Unmarshaler
 func (bits *Perm) UnmarshalJSON(data []byte) error {
-	if data == nil {
+	if data == nil || bytes.Equal(data, nullByte) {
 		*bits = 0
 		return nil
 	}
@@ -154,3 +152,24 @@ func (bits *Perm) UnmarshalJSON(data []byte) error {
 	*bits = p
 	return errors.WithStack(err)
 }
+
+// MarshalText implements encoding.TextMarshaler. It gets used by encoders
+// which do not know about JSON, for example YAML.
+func (bits Perm) MarshalText() ([]byte, error) {
+	if bits == 0 {
+		return nil, nil
+	}
+	return []byte(bits.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. An empty text resets the
+// permission.
+func (bits *Perm) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*bits = 0
+		return nil
+	}
+	p, err := MakePerm(string(text))
+	*bits = p
+	return errors.WithStack(err)
+}
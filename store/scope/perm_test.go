@@ -73,6 +73,30 @@ func TestPerm_JSON(t *testing.T) {
 		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
 		assert.Exactly(t, scope.Perm(0), p)
 	})
+	t.Run("null unmarshal", func(t *testing.T) {
+		p := scope.PermStore
+		assert.NoError(t, p.UnmarshalJSON([]byte(`null`)))
+		assert.Exactly(t, scope.Perm(0), p)
+	})
+}
+
+func TestPerm_Text(t *testing.T) {
+	txt, err := scope.PermWebsite.MarshalText()
+	assert.NoError(t, err)
+	assert.Exactly(t, "websites", string(txt))
+
+	txt, err = scope.Perm(0).MarshalText()
+	assert.NoError(t, err)
+	assert.Empty(t, txt)
+
+	var p scope.Perm
+	assert.NoError(t, p.UnmarshalText([]byte("s")))
+	assert.Exactly(t, scope.PermStore, p)
+	assert.NoError(t, p.UnmarshalText(nil))
+	assert.Exactly(t, scope.Perm(0), p)
+
+	err = p.UnmarshalText([]byte("x"))
+	assert.True(t, errors.NotSupported.Match(err), "%+v", err)
 }
 
 func TestMakePerm(t *testing.T) {
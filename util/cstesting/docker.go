@@ -0,0 +1,188 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cstesting
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+)
+
+// DockerOptions configures a disposable container started with StartDocker.
+// The docker command line client must be installed.
+type DockerOptions struct {
+	// Image, required, for example "mariadb:10.4".
+	Image string
+	// Env sets the environment variables of the container, for example
+	// "MYSQL_ROOT_PASSWORD=secret".
+	Env []string
+	// Port of the container which gets published to a random port of the
+	// host, for example "3306/tcp".
+	Port string
+	// Args get appended to the command of the image.
+	Args []string
+	// DockerPath defaults to "docker".
+	DockerPath string
+	// Ready gets called repeatedly with the published host address until it
+	// returns nil or ReadyTimeout expires. Optional.
+	Ready func(ctx context.Context, addr string) error
+	// ReadyTimeout defaults to one minute.
+	ReadyTimeout time.Duration
+	// mocked out for testing.
+	execCommand func(ctx context.Context, stdin io.Reader, name string, arg ...string) ([]byte, error)
+}
+
+// DockerContainer represents a running container started with StartDocker.
+type DockerContainer struct {
+	ID string
+	// Addr is the host address of the published port, for example
+	// "127.0.0.1:32768".
+	Addr string
+	o    DockerOptions
+}
+
+func execCommand(ctx context.Context, stdin io.Reader, name string, arg ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, arg...)
+	cmd.Stdin = stdin
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "[cstesting] %s %s\n%s", name, strings.Join(arg, " "), stderr.String())
+	}
+	return out, nil
+}
+
+// StartDocker starts a new container in the background, publishes its port and
+// waits until the container is ready. The container gets removed with Close,
+// including its volumes.
+func StartDocker(ctx context.Context, o DockerOptions) (_ *DockerContainer, err error) {
+	if o.Image == "" {
+		return nil, errors.Empty.Newf("[cstesting] DockerOptions.Image cannot be empty")
+	}
+	if o.DockerPath == "" {
+		o.DockerPath = "docker"
+	}
+	if o.ReadyTimeout == 0 {
+		o.ReadyTimeout = time.Minute
+	}
+	if o.execCommand == nil {
+		o.execCommand = execCommand
+	}
+
+	args := []string{"run", "--detach"}
+	if o.Port != "" {
+		args = append(args, "--publish", "127.0.0.1::"+o.Port)
+	}
+	for _, e := range o.Env {
+		args = append(args, "--env", e)
+	}
+	args = append(args, o.Image)
+	args = append(args, o.Args...)
+
+	out, err := o.execCommand(ctx, nil, o.DockerPath, args...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	c := &DockerContainer{
+		ID: strings.TrimSpace(string(out)),
+		o:  o,
+	}
+	defer func() {
+		if err != nil {
+			_ = c.Close()
+		}
+	}()
+
+	if o.Port != "" {
+		out, err = o.execCommand(ctx, nil, o.DockerPath, "port", c.ID, o.Port)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		// docker port might return an IPv4 and an IPv6 address.
+		if sc := bufio.NewScanner(bytes.NewReader(out)); sc.Scan() {
+			c.Addr = strings.TrimSpace(sc.Text())
+		}
+		if c.Addr == "" {
+			return nil, errors.NotFound.Newf("[cstesting] Docker container %q has no published port %q", c.ID, o.Port)
+		}
+	}
+
+	if o.Ready != nil {
+		if err = c.waitReady(ctx); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return c, nil
+}
+
+func (c *DockerContainer) waitReady(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.o.ReadyTimeout)
+	defer cancel()
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		err := c.o.Ready(ctx, c.Addr)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Timeout.New(err, "[cstesting] Docker container %q of image %q not ready after %s", c.ID, c.o.Image, c.o.ReadyTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Exec runs a command in the container. The optional stdin gets passed to the
+// command.
+func (c *DockerContainer) Exec(ctx context.Context, stdin io.Reader, arg ...string) ([]byte, error) {
+	out, err := c.o.execCommand(ctx, stdin, c.o.DockerPath, append([]string{"exec", "--interactive", c.ID}, arg...)...)
+	return out, errors.WithStack(err)
+}
+
+// Close removes the container and its volumes.
+func (c *DockerContainer) Close() error {
+	_, err := c.o.execCommand(context.Background(), nil, c.o.DockerPath, "rm", "--force", "--volumes", c.ID)
+	return errors.WithStack(err)
+}
+
+// MustStartDocker same as StartDocker but skips the test if the docker command
+// cannot be found and fails the test on any other error. The container must
+// be closed in the defer part of the test.
+//		c := cstesting.MustStartDocker(t, cstesting.DockerOptions{Image: "redis"})
+//		defer c.Close()
+func MustStartDocker(t testing.TB, o DockerOptions) *DockerContainer {
+	t.Helper()
+	if o.execCommand == nil {
+		p := o.DockerPath
+		if p == "" {
+			p = "docker"
+		}
+		if _, err := exec.LookPath(p); err != nil {
+			t.Skipf("Skipping test because docker command not found: %s", err)
+		}
+	}
+	c, err := StartDocker(context.Background(), o)
+	fatalIfError(t, err)
+	return c
+}
@@ -0,0 +1,100 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cstesting
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+type dockerMock struct {
+	calls   []string
+	readyOK int
+}
+
+func (dm *dockerMock) exec(_ context.Context, _ io.Reader, name string, arg ...string) ([]byte, error) {
+	dm.calls = append(dm.calls, name+" "+strings.Join(arg, " "))
+	switch arg[0] {
+	case "run":
+		return []byte("4711abc\n"), nil
+	case "port":
+		return []byte("127.0.0.1:32768\n[::1]:32768\n"), nil
+	}
+	return nil, nil
+}
+
+func TestStartDocker(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		dm := &dockerMock{}
+		var readyCalls int
+		c, err := StartDocker(context.Background(), DockerOptions{
+			Image: "mariadb:10.4",
+			Env:   []string{"MYSQL_ROOT_PASSWORD=secret"},
+			Port:  "3306/tcp",
+			Ready: func(_ context.Context, addr string) error {
+				assert.Exactly(t, "127.0.0.1:32768", addr)
+				readyCalls++
+				if readyCalls < 2 {
+					return errors.New("not yet")
+				}
+				return nil
+			},
+			execCommand: dm.exec,
+		})
+		assert.NoError(t, err)
+		assert.Exactly(t, "4711abc", c.ID)
+		assert.Exactly(t, "127.0.0.1:32768", c.Addr)
+		assert.Exactly(t, 2, readyCalls)
+
+		_, err = c.Exec(context.Background(), nil, "mysql", "-uroot")
+		assert.NoError(t, err)
+		assert.NoError(t, c.Close())
+
+		assert.Exactly(t, []string{
+			"docker run --detach --publish 127.0.0.1::3306/tcp --env MYSQL_ROOT_PASSWORD=secret mariadb:10.4",
+			"docker port 4711abc 3306/tcp",
+			"docker exec --interactive 4711abc mysql -uroot",
+			"docker rm --force --volumes 4711abc",
+		}, dm.calls)
+	})
+
+	t.Run("timeout removes container", func(t *testing.T) {
+		dm := &dockerMock{}
+		c, err := StartDocker(context.Background(), DockerOptions{
+			Image:        "mariadb:10.4",
+			Port:         "3306/tcp",
+			ReadyTimeout: 10 * time.Millisecond,
+			Ready: func(_ context.Context, addr string) error {
+				return errors.New("never ready")
+			},
+			execCommand: dm.exec,
+		})
+		assert.Nil(t, c)
+		assert.True(t, errors.Timeout.Match(err), "%+v", err)
+		assert.Exactly(t, "docker rm --force --volumes 4711abc", dm.calls[len(dm.calls)-1])
+	})
+
+	t.Run("empty image", func(t *testing.T) {
+		c, err := StartDocker(context.Background(), DockerOptions{})
+		assert.Nil(t, c)
+		assert.True(t, errors.Empty.Match(err), "%+v", err)
+	})
+}
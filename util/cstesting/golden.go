@@ -0,0 +1,91 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cstesting
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// UpdateGolden gets set by the test flag -update and instructs AssertGolden to
+// write the golden files instead of comparing them.
+//		go test ./sql/dml -run TestOpRune -update
+var UpdateGolden = flag.Bool("update", false, "Updates the golden files in the testdata directory")
+
+// EnvUpdateGolden names the environment variable which has the same effect as
+// the flag -update. Any non-empty value enables it. Useful when running the
+// tests of several packages at once.
+//		CS_UPDATE_GOLDEN=1 go test ./...
+const EnvUpdateGolden = "CS_UPDATE_GOLDEN"
+
+// goldenFormatter describes the functions needed to report a mismatch of a
+// golden file.
+type goldenFormatter interface {
+	fataler
+	Errorf(format string, args ...interface{})
+	Helper()
+}
+
+// AssertGolden compares `got` with the content of the golden file
+// testdata/`name`. If the flag -update has been set, it writes `got` to the
+// golden file instead. Large expected outputs like generated SQL or code can be
+// kept out of the test source this way. On mismatch it reports the first line
+// which differs.
+//		cstesting.AssertGolden(t, "TestOpRune.sql.golden", []byte(sqlStr))
+func AssertGolden(t goldenFormatter, name string, got []byte) {
+	t.Helper()
+	file := filepath.Join("testdata", name)
+
+	if *UpdateGolden || os.Getenv(EnvUpdateGolden) != "" {
+		fatalIfError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		fatalIfError(t, ioutil.WriteFile(file, got, 0644))
+		return
+	}
+
+	want, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		t.Fatalf("Golden file %q not found. Run the test with the flag -update to create it.", file)
+		return
+	}
+	fatalIfError(t, err)
+
+	if bytes.Equal(want, got) {
+		return
+	}
+	line, wantLine, gotLine := firstDiffLine(want, got)
+	t.Errorf("Golden file %q does not match, run the test with the flag -update to accept the changes.\nFirst difference in line %d\nwant: %q\ngot:  %q",
+		file, line, wantLine, gotLine)
+}
+
+func firstDiffLine(want, got []byte) (line int, wantLine, gotLine []byte) {
+	wl := bytes.Split(want, []byte("\n"))
+	gl := bytes.Split(got, []byte("\n"))
+	for i := 0; i < len(wl) || i < len(gl); i++ {
+		wantLine, gotLine = nil, nil
+		if i < len(wl) {
+			wantLine = wl[i]
+		}
+		if i < len(gl) {
+			gotLine = gl[i]
+		}
+		if i >= len(wl) || i >= len(gl) || !bytes.Equal(wantLine, gotLine) {
+			return i + 1, wantLine, gotLine
+		}
+	}
+	return 0, nil, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cstesting_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/corestoreio/pkg/util/cstesting"
+)
+
+type goldenRecorder struct {
+	errors []string
+	fatals []string
+}
+
+func (gr *goldenRecorder) Errorf(format string, args ...interface{}) {
+	gr.errors = append(gr.errors, fmt.Sprintf(format, args...))
+}
+
+func (gr *goldenRecorder) Fatalf(format string, args ...interface{}) {
+	gr.fatals = append(gr.fatals, fmt.Sprintf(format, args...))
+}
+
+func (gr *goldenRecorder) Helper() {}
+
+func TestAssertGolden(t *testing.T) {
+	// cannot run parallel
+
+	dir, err := ioutil.TempDir("", "cs_golden")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	defer cstesting.ChangeDir(t, dir)()
+
+	t.Run("missing file", func(t *testing.T) {
+		gr := new(goldenRecorder)
+		cstesting.AssertGolden(gr, "query.sql.golden", []byte("SELECT 1"))
+		assert.Len(t, gr.fatals, 1)
+		assert.Contains(t, gr.fatals[0], "-update")
+	})
+
+	t.Run("update", func(t *testing.T) {
+		*cstesting.UpdateGolden = true
+		defer func() { *cstesting.UpdateGolden = false }()
+		gr := new(goldenRecorder)
+		cstesting.AssertGolden(gr, "query.sql.golden", []byte("SELECT 1\nFROM dual\n"))
+		assert.Len(t, gr.fatals, 0)
+		data, err := ioutil.ReadFile("testdata/query.sql.golden")
+		assert.NoError(t, err)
+		assert.Exactly(t, "SELECT 1\nFROM dual\n", string(data))
+	})
+
+	t.Run("update via environment", func(t *testing.T) {
+		assert.NoError(t, os.Setenv(cstesting.EnvUpdateGolden, "1"))
+		defer os.Unsetenv(cstesting.EnvUpdateGolden)
+		gr := new(goldenRecorder)
+		cstesting.AssertGolden(gr, "env.sql.golden", []byte("SELECT 2\n"))
+		assert.Len(t, gr.fatals, 0)
+		data, err := ioutil.ReadFile("testdata/env.sql.golden")
+		assert.NoError(t, err)
+		assert.Exactly(t, "SELECT 2\n", string(data))
+	})
+
+	t.Run("match", func(t *testing.T) {
+		gr := new(goldenRecorder)
+		cstesting.AssertGolden(gr, "query.sql.golden", []byte("SELECT 1\nFROM dual\n"))
+		assert.Len(t, gr.errors, 0)
+		assert.Len(t, gr.fatals, 0)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		gr := new(goldenRecorder)
+		cstesting.AssertGolden(gr, "query.sql.golden", []byte("SELECT 1\nFROM t1\n"))
+		assert.Len(t, gr.errors, 1)
+		assert.Contains(t, gr.errors[0], "First difference in line 2")
+		assert.Contains(t, gr.errors[0], `want: "FROM dual"`)
+		assert.Contains(t, gr.errors[0], `got:  "FROM t1"`)
+	})
+}